@@ -148,6 +148,9 @@ describe('search providers', () => {
         const stubFetchSymbolInfo = sinon.stub(api, 'fetchSymbolInfo')
         stubFetchSymbolInfo.callsFake(() => Promise.resolve(undefined))
 
+        const stubFetchSymbolReferences = sinon.stub(api, 'fetchSymbolReferences')
+        stubFetchSymbolReferences.callsFake(() => Promise.resolve(undefined))
+
         return api
     }
 
@@ -411,6 +414,42 @@ describe('search providers', () => {
             ])
         })
 
+        it('should narrow same-repository results down to syntactic references', async () => {
+            const api = newAPIWithStubResolveRepo()
+            const searchStub = sinon.stub(api, 'search')
+            searchStub.callsFake((searchQuery: string) =>
+                Promise.resolve(searchQuery.includes('-repo') ? [searchResult2] : [searchResult1])
+            )
+
+            const getFileContentStub = sinon.stub(api, 'getFileContent')
+            getFileContentStub.resolves('\n\n\nfoobar\n')
+
+            const fetchSymbolReferencesStub = api.fetchSymbolReferences as sinon.SinonStub
+            fetchSymbolReferencesStub.callsFake(() =>
+                Promise.resolve([
+                    { repo: 'repo1', commit: 'rev1', path: 'b.ts', range: { line: 2, character: 3, length: 6 } },
+                ])
+            )
+
+            const values = await gatherValues(
+                createProviders(spec, {}, api).references(textDocument1, position, {
+                    includeDeclaration: false,
+                })
+            )
+            assert.deepStrictEqual(
+                values.map(locations => locations?.map(location => [location.uri.href, location.range?.start.line])),
+                [
+                    [
+                        ['git://repo1?rev1#b.ts', 2],
+                        ['git://repo2?rev2#d.ts', 3],
+                    ],
+                ]
+            )
+
+            assert.strictEqual(fetchSymbolReferencesStub.callCount, 1)
+            assert.deepStrictEqual(fetchSymbolReferencesStub.firstCall.args[2], ['b.ts'])
+        })
+
         it('should correctly format repositories with spaces', async () => {
             const api = newAPIWithStubResolveRepo()
             const searchStub = sinon.stub(api, 'search')
@@ -18,7 +18,7 @@ import { Result, resultToLocation, searchResultToResults } from './conversion'
 import { findDocstring } from './docstrings'
 import { wrapIndentationInCodeBlocks } from './markdown'
 import { definitionQuery, referencesQuery } from './queries'
-import { mkSquirrel } from './squirrel'
+import { findSyntacticReferences, mkSquirrel } from './squirrel'
 import { findSearchToken } from './tokens'
 
 /** The number of files whose content can be cached at once. */
@@ -189,9 +189,12 @@ export function createProviders(
         const doSearch = (negateRepoFilter: boolean): Promise<sourcegraph.Location[]> =>
             searchWithFallback(args => searchReferences(api, args), queryArguments, negateRepoFilter)
 
-        // Perform a search in the current git tree, suppressing references within the current file when
-        // we have squirrel results
-        const sameRepoReferences = doSearch(false)
+        // Perform a search in the current git tree, and keep only the matches that resolve to the
+        // same symbol when squirrel can resolve references in the matched files
+        const sameRepoReferences = doSearch(false).then(
+            async references =>
+                (await findSyntacticReferences(api, textDocument, position, references)) ?? references
+        )
 
         // Perform an indexed search over all _other_ repositories.
         const remoteRepoReferences = !getConfig('basicCodeIntel.globalSearchesEnabled', true)
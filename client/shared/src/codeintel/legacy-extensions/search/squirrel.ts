@@ -1,6 +1,6 @@
 /* eslint-disable @typescript-eslint/consistent-type-assertions */
 
-import { sortBy } from 'lodash'
+import { sortBy, uniq } from 'lodash'
 
 import * as sourcegraph from '../api'
 import { PromiseProviders } from '../providers'
@@ -74,6 +74,43 @@ export const mkSquirrel = (api: API): PromiseProviders => ({
     },
 })
 
+/**
+ * The maximum number of candidate paths accepted by the symbolReferences GraphQL field.
+ */
+const MAX_CANDIDATE_PATHS = 100
+
+/**
+ * Narrows down the references found by a text search at the current commit to the references that
+ * resolve to the same symbol as the one at the given position. Returns undefined if syntactic
+ * references are unavailable for the symbol or there are too many candidates, in which case the text
+ * search results should be used.
+ */
+export const findSyntacticReferences = async (
+    api: API,
+    document: sourcegraph.TextDocument,
+    position: sourcegraph.Position,
+    candidates: sourcegraph.Location[]
+): Promise<sourcegraph.Location[] | undefined> => {
+    const candidatePaths = uniq(candidates.map(candidate => parseGitURI(candidate.uri).path))
+    if (candidatePaths.length > MAX_CANDIDATE_PATHS) {
+        return undefined
+    }
+
+    const references = await api.fetchSymbolReferences(document, position, candidatePaths)
+    if (!references || references.length === 0) {
+        return undefined
+    }
+
+    return references.map(({ repo, commit, path, range }) =>
+        mkSourcegraphLocation({
+            repo,
+            commit,
+            path,
+            range: { row: range.line, column: range.character, length: range.length },
+        })
+    )
+}
+
 type RepoCommitPathRange = RepoCommitPath & { range: Range }
 
 const mkSourcegraphLocation = ({ repo, commit, path, range }: RepoCommitPathRange): sourcegraph.Location => ({
@@ -293,6 +293,60 @@ export class API {
         return symbolInfoFlexibleToCanonical(symbolInfoFlexible)
     }
 
+    /**
+     * Determines via introspection if the GraphQL API has symbol references available
+     *
+     * TODO - Remove this when we no longer need to support versions without symbol references
+     */
+    public hasSymbolReferences = once(async () => {
+        const introspectionQuery = gql`
+            query LegacySymbolReferencesIntrospectionQuery {
+                __type(name: "GitBlob") {
+                    fields {
+                        name
+                    }
+                }
+            }
+        `
+
+        interface IntrospectionResponse {
+            __type: { fields: { name: string }[] }
+        }
+
+        return (await queryGraphQL<IntrospectionResponse>(introspectionQuery)).__type.fields.some(
+            field => field.name === 'symbolReferences'
+        )
+    })
+
+    /**
+     * Retrieves the syntactic references to the symbol at the given position within the current
+     * file and the given candidate paths of the same commit. Returns undefined if the GraphQL API
+     * does not support syntactic references.
+     */
+    public fetchSymbolReferences = async (
+        document: sourcegraph.TextDocument,
+        position: sourcegraph.Position,
+        candidatePaths: string[]
+    ): Promise<(RepoCommitPath & { range: LineCharLength })[] | undefined> => {
+        if (!(await this.hasSymbolReferences())) {
+            return
+        }
+
+        const { repo, commit, path } = parseGitURI(new URL(document.uri))
+
+        const vars = {
+            repository: repo,
+            commit,
+            path,
+            line: position.line,
+            character: position.character,
+            candidatePaths,
+        }
+        const response = await queryGraphQL<SymbolReferencesResponse>(symbolReferencesQuery, vars)
+
+        return response?.repository?.commit?.blob?.symbolReferences ?? undefined
+    }
+
     /**
      * Get the content of a file. Throws an error if the repository is not known to
      * the Sourcegraph instance. Returns undefined if the input rev or the file is
@@ -600,3 +654,35 @@ const symbolInfoDefinitionQueryWithRange = gql`
         }
     }
 `
+
+type SymbolReferencesResponse = GenericBlobResponse<{
+    symbolReferences: (RepoCommitPath & { range: LineCharLength })[]
+}>
+
+const symbolReferencesQuery = gql`
+    query LegacySymbolReferences(
+        $repository: String!
+        $commit: String!
+        $path: String!
+        $line: Int!
+        $character: Int!
+        $candidatePaths: [String!]
+    ) {
+        repository(name: $repository) {
+            commit(rev: $commit) {
+                blob(path: $path) {
+                    symbolReferences(line: $line, character: $character, candidatePaths: $candidatePaths) {
+                        repo
+                        commit
+                        path
+                        range {
+                            line
+                            character
+                            length
+                        }
+                    }
+                }
+            }
+        }
+    }
+`
@@ -113,6 +113,10 @@ export interface MatchedSymbol {
     containerName: string
     kind: SymbolKind
     line: number
+    /** Set only for symbols from precise code intelligence indexes. */
+    fullyQualifiedName?: string
+    packageName?: string
+    packageVersion?: string
 }
 
 type MarkdownText = string
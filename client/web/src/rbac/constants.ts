@@ -3,3 +3,17 @@
 export const BatchChangesReadPermission = 'BATCH_CHANGES#READ'
 
 export const BatchChangesWritePermission = 'BATCH_CHANGES#WRITE'
+
+export const CodeInsightsWritePermission = 'CODE_INSIGHTS#WRITE'
+
+export const CodeMonitorsWritePermission = 'CODE_MONITORS#WRITE'
+
+export const NotebooksWritePermission = 'NOTEBOOKS#WRITE'
+
+export const SearchContextsWritePermission = 'SEARCH_CONTEXTS#WRITE'
+
+export const CodeIntelUploadPermission = 'CODE_INTEL#UPLOAD'
+
+export const CodeIntelAutoIndexingWritePermission = 'CODE_INTEL#AUTO_INDEXING_WRITE'
+
+export const CodyAccessPermission = 'CODY#ACCESS'
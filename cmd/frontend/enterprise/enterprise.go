@@ -35,12 +35,16 @@ type Services struct {
 	ReposGitLabWebhook          webhooks.Registerer
 	ReposBitbucketServerWebhook webhooks.Registerer
 	ReposBitbucketCloudWebhook  webhooks.Registerer
+	ReposGiteaWebhook           webhooks.Registerer
 
 	SCIMHandler http.Handler
 
 	// Handler for exporting code insights data.
 	CodeInsightsDataExportHandler http.Handler
 
+	// Handler for downloading the files of search exports.
+	SearchExportDownloadHandler http.Handler
+
 	// Handler for completions stream.
 	NewCompletionsStreamHandler NewCompletionsStreamHandler
 
@@ -87,6 +91,7 @@ func DefaultServices() Services {
 		ReposGitLabWebhook:              &emptyWebhookHandler{name: "gitlab sync webhook"},
 		ReposBitbucketServerWebhook:     &emptyWebhookHandler{name: "bitbucket server sync webhook"},
 		ReposBitbucketCloudWebhook:      &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
+		ReposGiteaWebhook:               &emptyWebhookHandler{name: "gitea sync webhook"},
 		PermissionsGitHubWebhook:        &emptyWebhookHandler{name: "permissions github webhook"},
 		BatchesGitHubWebhook:            &emptyWebhookHandler{name: "batches github webhook"},
 		BatchesGitLabWebhook:            &emptyWebhookHandler{name: "batches gitlab webhook"},
@@ -103,6 +108,7 @@ func DefaultServices() Services {
 		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
 		NewComputeStreamHandler:         func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
 		CodeInsightsDataExportHandler:   makeNotFoundHandler("code insights data export handler"),
+		SearchExportDownloadHandler:     makeNotFoundHandler("search export download handler"),
 		NewCompletionsStreamHandler:     func() http.Handler { return makeNotFoundHandler("completions streaming endpoint") },
 		EnterpriseSearchJobs:            jobutil.NewUnimplementedEnterpriseJobs(),
 	}
@@ -0,0 +1,108 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// batchChangesWriteMutations are the mutations that access tokens with the
+// "batch-changes:write" scope may perform. Managing batch changes credentials is
+// deliberately excluded, as it requires the "user:all" scope.
+var batchChangesWriteMutations = map[string]struct{}{
+	"applyBatchChange":                   {},
+	"cancelBatchSpecExecution":           {},
+	"cancelBatchSpecWorkspaceExecution":  {},
+	"closeBatchChange":                   {},
+	"closeChangesets":                    {},
+	"createBatchChange":                  {},
+	"createBatchSpec":                    {},
+	"createBatchSpecFromRaw":             {},
+	"createChangesetComments":            {},
+	"createChangesetSpec":                {},
+	"createChangesetSpecs":               {},
+	"createEmptyBatchChange":             {},
+	"deleteBatchChange":                  {},
+	"deleteBatchSpec":                    {},
+	"detachChangesets":                   {},
+	"enqueueBatchSpecWorkspaceExecution": {},
+	"executeBatchSpec":                   {},
+	"mergeChangesets":                    {},
+	"moveBatchChange":                    {},
+	"publishChangesets":                  {},
+	"reenqueueChangeset":                 {},
+	"reenqueueChangesets":                {},
+	"replaceBatchSpecInput":              {},
+	"retryBatchSpecExecution":            {},
+	"retryBatchSpecWorkspaceExecution":   {},
+	"syncChangeset":                      {},
+	"toggleBatchSpecAutoApply":           {},
+	"upsertBatchSpecInput":               {},
+	"upsertEmptyBatchChange":             {},
+}
+
+// CheckAccessTokenScopes returns an error if the actor in the context was authenticated with a
+// restricted access token whose scopes do not permit the given GraphQL operation. Queries require
+// the "api:read" or "batch-changes:write" scope, and mutations are only permitted for the batch
+// changes mutations with the "batch-changes:write" scope.
+//
+// 🚨 SECURITY: This must be checked before executing any GraphQL request.
+func CheckAccessTokenScopes(ctx context.Context, query, operationName string) error {
+	a := actor.FromContext(ctx)
+	if !a.IsRestricted() {
+		return nil
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return errors.Wrap(err, "parsing query")
+	}
+
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		candidate, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (candidate.Name != nil && candidate.Name.Value == operationName) {
+			if op != nil {
+				return errors.New("the operation to execute must be specified by name")
+			}
+			op = candidate
+		}
+	}
+	if op == nil {
+		return errors.Errorf("unknown operation %q", operationName)
+	}
+
+	switch op.Operation {
+	case ast.OperationTypeQuery:
+		if authz.HasScope(a.AccessTokenScopes, authz.ScopeAPIRead) || authz.HasScope(a.AccessTokenScopes, authz.ScopeBatchChangesWrite) {
+			return nil
+		}
+		return errors.Errorf("access token scopes %q do not permit GraphQL queries", a.AccessTokenScopes)
+
+	case ast.OperationTypeMutation:
+		if !authz.HasScope(a.AccessTokenScopes, authz.ScopeBatchChangesWrite) {
+			return errors.Errorf("access token scopes %q do not permit GraphQL mutations", a.AccessTokenScopes)
+		}
+		for _, selection := range op.SelectionSet.Selections {
+			// Fragments are rejected, as they would hide the mutations that are performed.
+			field, ok := selection.(*ast.Field)
+			if !ok {
+				return errors.New("mutations performed with a restricted access token must not use fragments")
+			}
+			if _, ok := batchChangesWriteMutations[field.Name.Value]; !ok {
+				return errors.Errorf("access token scopes %q do not permit the mutation %q", a.AccessTokenScopes, field.Name.Value)
+			}
+		}
+		return nil
+	}
+
+	return errors.Errorf("access token scopes %q do not permit GraphQL %s operations", a.AccessTokenScopes, op.Operation)
+}
@@ -0,0 +1,83 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+func TestCheckAccessTokenScopes(t *testing.T) {
+	withScopes := func(scopes ...string) context.Context {
+		return actor.WithActor(context.Background(), &actor.Actor{UID: 1, AccessTokenScopes: scopes})
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		query         string
+		operationName string
+		wantErr       string
+	}{
+		{
+			name:  "unrestricted actor",
+			ctx:   actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			query: `mutation { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+		},
+		{
+			name:  "query with api:read",
+			ctx:   withScopes(authz.ScopeAPIRead),
+			query: `{ currentUser { username } }`,
+		},
+		{
+			name:    "query with codeintel:upload",
+			ctx:     withScopes(authz.ScopeCodeIntelUpload),
+			query:   `query CurrentUser { currentUser { username } }`,
+			wantErr: `access token scopes ["codeintel:upload"] do not permit GraphQL queries`,
+		},
+		{
+			name:    "mutation with api:read",
+			ctx:     withScopes(authz.ScopeAPIRead),
+			query:   `mutation { createBatchSpecFromRaw(batchSpec: "", namespace: "") { id } }`,
+			wantErr: `access token scopes ["api:read"] do not permit GraphQL mutations`,
+		},
+		{
+			name:  "batch changes mutation with batch-changes:write",
+			ctx:   withScopes(authz.ScopeBatchChangesWrite),
+			query: `mutation { createBatchSpecFromRaw(batchSpec: "", namespace: "") { id } }`,
+		},
+		{
+			name:    "other mutation with batch-changes:write",
+			ctx:     withScopes(authz.ScopeBatchChangesWrite),
+			query:   `mutation { createBatchChangesCredential(externalServiceKind: GITHUB, externalServiceURL: "", credential: "") { id } }`,
+			wantErr: `access token scopes ["batch-changes:write"] do not permit the mutation "createBatchChangesCredential"`,
+		},
+		{
+			name:    "mutation fragment with batch-changes:write",
+			ctx:     withScopes(authz.ScopeBatchChangesWrite),
+			query:   `mutation { ...F } fragment F on Mutation { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			wantErr: "mutations performed with a restricted access token must not use fragments",
+		},
+		{
+			name:          "named operation",
+			ctx:           withScopes(authz.ScopeAPIRead),
+			query:         `query Q { currentUser { username } } mutation M { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			operationName: "M",
+			wantErr:       `access token scopes ["api:read"] do not permit GraphQL mutations`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckAccessTokenScopes(test.ctx, test.query, test.operationName)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
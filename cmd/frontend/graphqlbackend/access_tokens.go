@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 
@@ -17,13 +18,15 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type createAccessTokenInput struct {
-	User   graphql.ID
-	Scopes []string
-	Note   string
+	User      graphql.ID
+	Scopes    []string
+	Note      string
+	ExpiresAt *gqlutil.DateTime
 }
 
 func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAccessTokenInput) (*createAccessTokenResult, error) {
@@ -60,13 +63,15 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 	}
 
 	// Validate scopes.
-	var hasUserAllScope bool
+	var hasUserAllScope, hasRestrictedScope bool
 	seenScope := map[string]struct{}{}
 	sort.Strings(args.Scopes)
 	for _, scope := range args.Scopes {
 		switch scope {
 		case authz.ScopeUserAll:
 			hasUserAllScope = true
+		case authz.ScopeAPIRead, authz.ScopeCodeIntelUpload, authz.ScopeBatchChangesWrite:
+			hasRestrictedScope = true
 		case authz.ScopeSiteAdminSudo:
 			// 🚨 SECURITY: Only site admins may create a token with the "site-admin:sudo" scope.
 			if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
@@ -83,12 +88,26 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 		}
 		seenScope[scope] = struct{}{}
 	}
-	if !hasUserAllScope {
-		return nil, errors.Errorf("all access tokens must have scope %q", authz.ScopeUserAll)
+	if !hasUserAllScope && !hasRestrictedScope {
+		return nil, errors.Errorf("access tokens must have scope %q or at least one of the scopes %q", authz.ScopeUserAll, authz.RestrictedScopes)
+	}
+	if hasUserAllScope && hasRestrictedScope {
+		return nil, errors.Errorf("access tokens with scope %q already grant all of the scopes %q", authz.ScopeUserAll, authz.RestrictedScopes)
+	}
+	if _, ok := seenScope[authz.ScopeSiteAdminSudo]; ok && !hasUserAllScope {
+		return nil, errors.Errorf("access tokens with scope %q must also have scope %q", authz.ScopeSiteAdminSudo, authz.ScopeUserAll)
+	}
+
+	var expiresAt time.Time
+	if args.ExpiresAt != nil {
+		expiresAt = args.ExpiresAt.Time
+		if !expiresAt.After(time.Now()) {
+			return nil, errors.New("access token expiration must be in the future")
+		}
 	}
 
 	uid := actor.FromContext(ctx).UID
-	id, token, err := r.db.AccessTokens().Create(ctx, userID, args.Scopes, args.Note, uid)
+	id, token, err := r.db.AccessTokens().Create(ctx, userID, args.Scopes, args.Note, uid, expiresAt)
 	logger := r.logger.Scoped("CreateAccessToken", "access token creation").
 		With(log.Int32("userID", uid))
 
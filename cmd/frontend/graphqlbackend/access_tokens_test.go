@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	gqlerrors "github.com/graph-gophers/graphql-go/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -26,7 +28,7 @@ import (
 func TestMutation_CreateAccessToken(t *testing.T) {
 	newMockAccessTokens := func(t *testing.T, wantCreatorUserID int32, wantScopes []string) database.AccessTokenStore {
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.CreateFunc.SetDefaultHook(func(_ context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (int64, string, error) {
+		accessTokens.CreateFunc.SetDefaultHook(func(_ context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, _ time.Time) (int64, string, error) {
 			if want := int32(1); subjectUserID != want {
 				t.Errorf("got %v, want %v", subjectUserID, want)
 			}
@@ -79,6 +81,68 @@ func TestMutation_CreateAccessToken(t *testing.T) {
 		})
 	})
 
+	t.Run("authenticated as user, using restricted scopes with an expiration", func(t *testing.T) {
+		expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+		accessTokens := newMockAccessTokens(t, 1, []string{authz.ScopeAPIRead, authz.ScopeCodeIntelUpload})
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: false}, nil)
+
+		db := database.NewMockDB()
+		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).CreateAccessToken(ctx, &createAccessTokenInput{
+			User:      uid1GQLID,
+			Scopes:    []string{authz.ScopeCodeIntelUpload, authz.ScopeAPIRead},
+			Note:      "n",
+			ExpiresAt: &gqlutil.DateTime{Time: expiresAt},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := accessTokens.(*database.MockAccessTokenStore).CreateFunc.History()[0].Arg5; !got.Equal(expiresAt) {
+			t.Errorf("got expiration %v, want %v", got, expiresAt)
+		}
+	})
+
+	for _, tc := range []struct {
+		name      string
+		scopes    []string
+		expiresAt time.Time
+		wantErr   string
+	}{
+		{
+			name:    "user:all combined with restricted scopes",
+			scopes:  []string{authz.ScopeUserAll, authz.ScopeAPIRead},
+			wantErr: `access tokens with scope "user:all" already grant all of the scopes ["api:read" "codeintel:upload" "batch-changes:write"]`,
+		},
+		{
+			name:    "unknown scope",
+			scopes:  []string{"api:write"},
+			wantErr: `unknown access token scope "api:write" (valid scopes: ["user:all" "site-admin:sudo" "api:read" "codeintel:upload" "batch-changes:write"])`,
+		},
+		{
+			name:      "expiration in the past",
+			scopes:    []string{authz.ScopeAPIRead},
+			expiresAt: time.Now().Add(-time.Hour),
+			wantErr:   "access token expiration must be in the future",
+		},
+	} {
+		t.Run("authenticated as user, "+tc.name, func(t *testing.T) {
+			db := database.NewMockDB()
+			args := &createAccessTokenInput{User: uid1GQLID, Scopes: tc.scopes, Note: "n"}
+			if !tc.expiresAt.IsZero() {
+				args.ExpiresAt = &gqlutil.DateTime{Time: tc.expiresAt}
+			}
+
+			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+			_, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).CreateAccessToken(ctx, args)
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+
 	t.Run("authenticated as user, using invalid scopes", func(t *testing.T) {
 		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 		db := database.NewMockDB()
@@ -0,0 +1,177 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type AuditLogArgs struct {
+	Actor  *graphql.ID
+	Action *string
+	Entity *string
+	Since  *gqlutil.DateTime
+	Until  *gqlutil.DateTime
+	graphqlutil.ConnectionResolverArgs
+}
+
+func (r *schemaResolver) AuditLog(ctx context.Context, args *AuditLogArgs) (*graphqlutil.ConnectionResolver[*auditLogEntryResolver], error) {
+	// 🚨 SECURITY: Only site admins can see the audit log.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var opts database.AuditLogListOpts
+	if args.Actor != nil {
+		userID, err := UnmarshalUserID(*args.Actor)
+		if err != nil {
+			return nil, err
+		}
+		opts.ActorUserID = userID
+	}
+	if args.Action != nil {
+		opts.Action = *args.Action
+	}
+	if args.Entity != nil {
+		opts.Entity = *args.Entity
+	}
+	if args.Since != nil {
+		opts.Since = &args.Since.Time
+	}
+	if args.Until != nil {
+		opts.Until = &args.Until.Time
+	}
+
+	connectionStore := &auditLogConnectionStore{
+		db:   r.db,
+		opts: opts,
+	}
+
+	reverse := false
+	connectionOptions := graphqlutil.ConnectionResolverOptions{
+		Reverse:   &reverse,
+		OrderBy:   database.OrderBy{{Field: "id"}},
+		Ascending: false,
+	}
+	return graphqlutil.NewConnectionResolver[*auditLogEntryResolver](connectionStore, &args.ConnectionResolverArgs, &connectionOptions)
+}
+
+type auditLogConnectionStore struct {
+	db   database.DB
+	opts database.AuditLogListOpts
+}
+
+func (s *auditLogConnectionStore) ComputeTotal(ctx context.Context) (*int32, error) {
+	count, err := s.db.AuditLogs().Count(ctx, s.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := int32(count)
+
+	return &totalCount, nil
+}
+
+func (s *auditLogConnectionStore) ComputeNodes(ctx context.Context, args *database.PaginationArgs) ([]*auditLogEntryResolver, error) {
+	entries, err := s.db.AuditLogs().List(ctx, s.opts, args)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*auditLogEntryResolver, len(entries))
+	for i, entry := range entries {
+		resolvers[i] = &auditLogEntryResolver{db: s.db, entry: entry}
+	}
+
+	return resolvers, nil
+}
+
+func (s *auditLogConnectionStore) MarshalCursor(node *auditLogEntryResolver, _ database.OrderBy) (*string, error) {
+	if node == nil {
+		return nil, errors.New(`node is nil`)
+	}
+
+	cursor := string(relay.MarshalID("AuditLogEntry", node.entry.ID))
+
+	return &cursor, nil
+}
+
+func (s *auditLogConnectionStore) UnmarshalCursor(cursor string, _ database.OrderBy) (*string, error) {
+	var nodeID int64
+	if err := relay.UnmarshalSpec(graphql.ID(cursor), &nodeID); err != nil {
+		return nil, err
+	}
+
+	id := strconv.FormatInt(nodeID, 10)
+
+	return &id, nil
+}
+
+// auditLogEntryResolver resolves an entry of the audit log.
+type auditLogEntryResolver struct {
+	db    database.DB
+	entry *database.AuditLogEntry
+}
+
+func (r *auditLogEntryResolver) AuditID() string { return r.entry.AuditID }
+
+func (r *auditLogEntryResolver) Entity() string { return r.entry.Entity }
+
+func (r *auditLogEntryResolver) Action() string { return r.entry.Action }
+
+func (r *auditLogEntryResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	if r.entry.ActorUID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, r.entry.ActorUID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *auditLogEntryResolver) ActorUserID() *int32 {
+	if r.entry.ActorUID == 0 {
+		return nil
+	}
+	return &r.entry.ActorUID
+}
+
+func (r *auditLogEntryResolver) AnonymousActorID() *string {
+	return nonEmptyStringPtr(r.entry.AnonymousUID)
+}
+
+func (r *auditLogEntryResolver) IP() *string { return nonEmptyStringPtr(r.entry.IP) }
+
+func (r *auditLogEntryResolver) ForwardedFor() *string {
+	return nonEmptyStringPtr(r.entry.ForwardedFor)
+}
+
+func (r *auditLogEntryResolver) Fields() JSONValue {
+	if r.entry.Fields == nil {
+		return JSONValue{Value: map[string]any{}}
+	}
+	return JSONValue{Value: r.entry.Fields}
+}
+
+func (r *auditLogEntryResolver) Source() string { return r.entry.Source }
+
+func (r *auditLogEntryResolver) Timestamp() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.entry.Timestamp}
+}
+
+func nonEmptyStringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,161 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAuditLogQuery(t *testing.T) {
+	const auditLogQuery = `
+	query AuditLog($actor: ID, $action: String, $since: DateTime, $first: Int) {
+		auditLog(actor: $actor, action: $action, since: $since, first: $first) {
+			nodes {
+				auditID
+				entity
+				action
+				actor {
+					username
+				}
+				actorUserID
+				anonymousActorID
+				ip
+				forwardedFor
+				fields
+				source
+				timestamp
+			}
+			totalCount
+			pageInfo {
+				hasNextPage
+			}
+		}
+	}`
+
+	db := database.NewMockDB()
+
+	userStore := database.NewMockUserStore()
+	db.UsersFunc.SetDefaultReturn(userStore)
+
+	auditLogStore := database.NewMockAuditLogStore()
+	db.AuditLogsFunc.SetDefaultReturn(auditLogStore)
+
+	t.Run("non-admin user", func(t *testing.T) {
+		userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: false}, nil)
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		RunTest(t, &Test{
+			Schema:         mustParseGraphQLSchema(t, db),
+			Context:        ctx,
+			Query:          auditLogQuery,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Path:          []any{"auditLog"},
+					Message:       auth.ErrMustBeSiteAdmin.Error(),
+					ResolverError: auth.ErrMustBeSiteAdmin,
+				},
+			},
+			Variables: map[string]any{
+				"first": 10,
+			},
+		})
+	})
+
+	t.Run("admin user", func(t *testing.T) {
+		timestamp, _ := time.Parse(time.RFC3339, "2023-04-01T12:00:00Z")
+		entries := []*database.AuditLogEntry{
+			{ID: 2, Entry: audit.Entry{
+				AuditID:      "b",
+				Entity:       "security events",
+				Action:       "SignInSucceeded",
+				ActorUID:     2,
+				IP:           "127.0.0.1",
+				ForwardedFor: "10.0.0.1",
+				Fields:       map[string]any{"event": "SignInSucceeded"},
+				Source:       "frontend",
+				Timestamp:    timestamp,
+			}},
+			{ID: 1, Entry: audit.Entry{
+				AuditID:      "a",
+				Entity:       "security events",
+				Action:       "SignInSucceeded",
+				ActorUID:     2,
+				AnonymousUID: "anon",
+				Source:       "frontend",
+				Timestamp:    timestamp,
+			}},
+		}
+
+		auditLogStore.ListFunc.SetDefaultReturn(entries, nil)
+		auditLogStore.CountFunc.SetDefaultReturn(len(entries), nil)
+		userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+		userStore.GetByIDFunc.SetDefaultReturn(&types.User{ID: 2, Username: "alice"}, nil)
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+		RunTest(t, &Test{
+			Schema:  mustParseGraphQLSchema(t, db),
+			Context: ctx,
+			Query:   auditLogQuery,
+			ExpectedResult: `{
+				"auditLog": {
+					"nodes": [
+						{
+							"auditID": "b",
+							"entity": "security events",
+							"action": "SignInSucceeded",
+							"actor": {"username": "alice"},
+							"actorUserID": 2,
+							"anonymousActorID": null,
+							"ip": "127.0.0.1",
+							"forwardedFor": "10.0.0.1",
+							"fields": {"event": "SignInSucceeded"},
+							"source": "frontend",
+							"timestamp": "2023-04-01T12:00:00Z"
+						},
+						{
+							"auditID": "a",
+							"entity": "security events",
+							"action": "SignInSucceeded",
+							"actor": {"username": "alice"},
+							"actorUserID": 2,
+							"anonymousActorID": "anon",
+							"ip": null,
+							"forwardedFor": null,
+							"fields": {},
+							"source": "frontend",
+							"timestamp": "2023-04-01T12:00:00Z"
+						}
+					],
+					"totalCount": 2,
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}`,
+			Variables: map[string]any{
+				"actor":  string(MarshalUserID(2)),
+				"action": "SignInSucceeded",
+				"since":  "2023-04-01T00:00:00Z",
+				"first":  10,
+			},
+		})
+
+		opts := auditLogStore.ListFunc.History()[0].Arg1
+		assert.Equal(t, int32(2), opts.ActorUserID)
+		assert.Equal(t, "SignInSucceeded", opts.Action)
+		assert.Equal(t, "", opts.Entity)
+		if assert.NotNil(t, opts.Since) {
+			assert.Equal(t, "2023-04-01T00:00:00Z", opts.Since.Format(time.RFC3339))
+		}
+		assert.Nil(t, opts.Until)
+	})
+}
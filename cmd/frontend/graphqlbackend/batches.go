@@ -77,6 +77,26 @@ type CreateBatchSpecArgs struct {
 	ChangesetSpecs []graphql.ID
 }
 
+type CreateBatchSpecFromDiffsArgs struct {
+	Namespace graphql.ID
+
+	Name          string
+	Description   string
+	Title         string
+	Body          string
+	Branch        string
+	CommitMessage string
+
+	Diffs []BatchSpecRepositoryDiffInput
+}
+
+type BatchSpecRepositoryDiffInput struct {
+	Repository graphql.ID
+	BaseRev    string
+	BaseRef    *string
+	Diff       string
+}
+
 type CreateEmptyBatchChangeArgs struct {
 	Namespace graphql.ID
 	Name      string
@@ -253,6 +273,7 @@ type BatchChangesResolver interface {
 	//
 	CreateBatchChange(ctx context.Context, args *CreateBatchChangeArgs) (BatchChangeResolver, error)
 	CreateBatchSpec(ctx context.Context, args *CreateBatchSpecArgs) (BatchSpecResolver, error)
+	CreateBatchSpecFromDiffs(ctx context.Context, args *CreateBatchSpecFromDiffsArgs) (BatchSpecResolver, error)
 	CreateEmptyBatchChange(ctx context.Context, args *CreateEmptyBatchChangeArgs) (BatchChangeResolver, error)
 	UpsertEmptyBatchChange(ctx context.Context, args *UpsertEmptyBatchChangeArgs) (BatchChangeResolver, error)
 	CreateBatchSpecFromRaw(ctx context.Context, args *CreateBatchSpecFromRawArgs) (BatchSpecResolver, error)
@@ -15,6 +15,7 @@ type ComputeResolver interface {
 type ComputeResultResolver interface {
 	ToComputeMatchContext() (ComputeMatchContextResolver, bool)
 	ToComputeText() (ComputeTextResolver, bool)
+	ToComputeAggregate() (ComputeAggregateResolver, bool)
 }
 
 type ComputeMatchContextResolver interface {
@@ -43,3 +44,14 @@ type ComputeTextResolver interface {
 	Kind() *string
 	Value() string
 }
+
+type ComputeAggregateResolver interface {
+	Groups() []ComputeAggregateGroupResolver
+	OtherGroupCount() int32
+	OtherResultCount() int32
+}
+
+type ComputeAggregateGroupResolver interface {
+	Value() string
+	Count() int32
+}
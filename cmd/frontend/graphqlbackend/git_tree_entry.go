@@ -369,6 +369,53 @@ func (r *GitTreeEntryResolver) SymbolInfo(ctx context.Context, args *symbolInfoA
 	return &symbolInfoResolver{symbolInfo: result}, nil
 }
 
+func (r *GitTreeEntryResolver) SymbolReferences(ctx context.Context, args *symbolReferencesArgs) ([]*symbolLocationResolver, error) {
+	if args == nil {
+		return nil, errors.New("expected arguments to symbolReferences")
+	}
+
+	repo, err := r.commit.repoResolver.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidatePaths []string
+	if args.CandidatePaths != nil {
+		candidatePaths = *args.CandidatePaths
+	}
+	if len(candidatePaths) > types.MaxReferencesCandidatePaths {
+		return nil, errors.Errorf("too many candidate paths: %d, the maximum is %d", len(candidatePaths), types.MaxReferencesCandidatePaths)
+	}
+
+	refs, err := symbols.DefaultClient.References(ctx, types.ReferencesArgs{
+		RepoCommitPathPoint: types.RepoCommitPathPoint{
+			RepoCommitPath: types.RepoCommitPath{
+				Repo:   string(repo.Name),
+				Commit: string(r.commit.oid),
+				Path:   r.Path(),
+			},
+			Point: types.Point{
+				Row:    int(args.Line),
+				Column: int(args.Character),
+			},
+		},
+		CandidatePaths: candidatePaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*symbolLocationResolver, 0, len(refs))
+	for _, ref := range refs {
+		ref := ref
+		resolvers = append(resolvers, &symbolLocationResolver{
+			location: types.RepoCommitPathMaybeRange{RepoCommitPath: ref.RepoCommitPath, Range: &ref.Range},
+		})
+	}
+
+	return resolvers, nil
+}
+
 func (r *GitTreeEntryResolver) LFS(ctx context.Context) (*lfsResolver, error) {
 	// We only care about the full content length here, so we just need content to be set.
 	content, err := r.Content(ctx, &GitTreeContentPageArgs{})
@@ -405,6 +452,12 @@ type symbolInfoArgs struct {
 	Character int32
 }
 
+type symbolReferencesArgs struct {
+	Line           int32
+	Character      int32
+	CandidatePaths *[]string
+}
+
 type symbolInfoResolver struct{ symbolInfo *types.SymbolInfo }
 
 func (r *symbolInfoResolver) Definition(ctx context.Context) (*symbolLocationResolver, error) {
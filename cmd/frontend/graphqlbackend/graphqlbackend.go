@@ -521,6 +521,16 @@ func NewSchema(
 		}
 	}
 
+	if searchExports := optional.SearchExportsResolver; searchExports != nil {
+		EnterpriseResolvers.searchExportsResolver = searchExports
+		resolver.SearchExportsResolver = searchExports
+		schemas = append(schemas, searchExportsSchema)
+		// Register NodeByID handlers.
+		for kind, res := range searchExports.NodeResolvers() {
+			resolver.nodeByIDFns[kind] = res
+		}
+	}
+
 	if compute := optional.ComputeResolver; compute != nil {
 		EnterpriseResolvers.computeResolver = compute
 		resolver.ComputeResolver = compute
@@ -617,6 +627,7 @@ type OptionalResolver struct {
 	DotcomRootResolver
 	SearchContextsResolver
 	NotebooksResolver
+	SearchExportsResolver
 	InsightsAggregationResolver
 	WebhooksResolver
 	EmbeddingsResolver
@@ -726,6 +737,7 @@ var EnterpriseResolvers = struct {
 	dotcomResolver              DotcomRootResolver
 	searchContextsResolver      SearchContextsResolver
 	notebooksResolver           NotebooksResolver
+	searchExportsResolver       SearchExportsResolver
 	InsightsAggregationResolver InsightsAggregationResolver
 	webhooksResolver            WebhooksResolver
 	embeddingsResolver          EmbeddingsResolver
@@ -216,6 +216,11 @@ func (r *NodeResolver) ToSearchContext() (SearchContextResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToSearchExport() (SearchExportResolver, bool) {
+	n, ok := r.Node.(SearchExportResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToNotebook() (NotebookResolver, bool) {
 	n, ok := r.Node.(NotebookResolver)
 	return n, ok
@@ -20,6 +20,7 @@ const (
 type NotebooksResolver interface {
 	NotebookByID(ctx context.Context, id graphql.ID) (NotebookResolver, error)
 	CreateNotebook(ctx context.Context, args CreateNotebookInputArgs) (NotebookResolver, error)
+	ImportNotebook(ctx context.Context, args ImportNotebookArgs) (NotebookResolver, error)
 	UpdateNotebook(ctx context.Context, args UpdateNotebookInputArgs) (NotebookResolver, error)
 	DeleteNotebook(ctx context.Context, args DeleteNotebookArgs) (*EmptyResponse, error)
 	Notebooks(ctx context.Context, args ListNotebooksArgs) (NotebookConnectionResolver, error)
@@ -27,6 +28,9 @@ type NotebooksResolver interface {
 	CreateNotebookStar(ctx context.Context, args CreateNotebookStarInputArgs) (NotebookStarResolver, error)
 	DeleteNotebookStar(ctx context.Context, args DeleteNotebookStarInputArgs) (*EmptyResponse, error)
 
+	SetNotebookReportSchedule(ctx context.Context, args SetNotebookReportScheduleArgs) (NotebookReportScheduleResolver, error)
+	DeleteNotebookReportSchedule(ctx context.Context, args DeleteNotebookReportScheduleArgs) (*EmptyResponse, error)
+
 	NodeResolvers() map[string]NodeByIDFunc
 }
 
@@ -51,6 +55,7 @@ type NotebookResolver interface {
 	ID() graphql.ID
 	Title(ctx context.Context) string
 	Blocks(ctx context.Context) []NotebookBlockResolver
+	Markdown(ctx context.Context) (string, error)
 	Creator(ctx context.Context) (*UserResolver, error)
 	Updater(ctx context.Context) (*UserResolver, error)
 	Namespace(ctx context.Context) (*NamespaceResolver, error)
@@ -60,6 +65,53 @@ type NotebookResolver interface {
 	ViewerCanManage(ctx context.Context) (bool, error)
 	ViewerHasStarred(ctx context.Context) (bool, error)
 	Stars(ctx context.Context, args ListNotebookStarsArgs) (NotebookStarConnectionResolver, error)
+	ReportSchedule(ctx context.Context) (NotebookReportScheduleResolver, error)
+	ReportSnapshots(ctx context.Context, args ListNotebookReportSnapshotsArgs) (NotebookReportSnapshotConnectionResolver, error)
+}
+
+type NotebookReportScheduleResolver interface {
+	Frequency() string
+	SendEmail() bool
+	User(ctx context.Context) (*UserResolver, error)
+	NextRunAt() gqlutil.DateTime
+	LastRunAt() *gqlutil.DateTime
+	CreatedAt() gqlutil.DateTime
+}
+
+type NotebookReportSnapshotConnectionResolver interface {
+	Nodes() []NotebookReportSnapshotResolver
+	TotalCount() int32
+	PageInfo() *graphqlutil.PageInfo
+}
+
+type NotebookReportSnapshotResolver interface {
+	ID() graphql.ID
+	CreatedAt() gqlutil.DateTime
+	Blocks() []NotebookBlockSnapshotResolver
+}
+
+type NotebookBlockSnapshotResolver interface {
+	BlockID() string
+	Query() string
+	ResultCount() int32
+	LimitHit() bool
+	Error() *string
+	TopMatches() []NotebookSnapshotMatchResolver
+	Diff() NotebookBlockSnapshotDiffResolver
+}
+
+type NotebookBlockSnapshotDiffResolver interface {
+	ResultCountDelta() int32
+	QueryChanged() bool
+	AddedMatches() []NotebookSnapshotMatchResolver
+	RemovedMatches() []NotebookSnapshotMatchResolver
+}
+
+type NotebookSnapshotMatchResolver interface {
+	Repository() string
+	Path() *string
+	Label() *string
+	URL() string
 }
 
 type NotebookBlockResolver interface {
@@ -124,6 +176,13 @@ type CreateNotebookInputArgs struct {
 	Notebook NotebookInputArgs `json:"notebook"`
 }
 
+type ImportNotebookArgs struct {
+	Markdown  string     `json:"markdown"`
+	Namespace graphql.ID `json:"namespace"`
+	Public    bool       `json:"public"`
+	Title     *string    `json:"title"`
+}
+
 type UpdateNotebookInputArgs struct {
 	ID       graphql.ID        `json:"id"`
 	Notebook NotebookInputArgs `json:"notebook"`
@@ -182,6 +241,28 @@ type ListNotebooksArgs struct {
 	Descending      bool             `json:"descending"`
 }
 
+type NotebookReportFrequency string
+
+const (
+	NotebookReportFrequencyDaily  NotebookReportFrequency = "DAILY"
+	NotebookReportFrequencyWeekly NotebookReportFrequency = "WEEKLY"
+)
+
+type SetNotebookReportScheduleArgs struct {
+	Notebook  graphql.ID
+	Frequency NotebookReportFrequency
+	SendEmail bool
+}
+
+type DeleteNotebookReportScheduleArgs struct {
+	Notebook graphql.ID
+}
+
+type ListNotebookReportSnapshotsArgs struct {
+	First int32   `json:"first"`
+	After *string `json:"after"`
+}
+
 type ListNotebookStarsArgs struct {
 	First int32   `json:"first"`
 	After *string `json:"after"`
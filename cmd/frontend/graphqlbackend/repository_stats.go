@@ -54,6 +54,14 @@ func (r *repositoryStatsResolver) computeGitDirBytes(ctx context.Context) (int64
 	return r.gitDirBytes, r.gitDirBytesErr
 }
 
+func (r *repositoryStatsResolver) LFSBytes(ctx context.Context) (BigInt, error) {
+	counts, err := r.computeRepoStatistics(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return BigInt(counts.LFSSizeBytes), nil
+}
+
 func (r *repositoryStatsResolver) Indexed(ctx context.Context) (int32, error) {
 	indexedRepos, _, err := r.computeIndexedStats(ctx)
 	if err != nil {
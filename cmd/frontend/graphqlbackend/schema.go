@@ -70,6 +70,11 @@ var insightsAggregationsSchema string
 //go:embed outbound_webhooks.graphql
 var outboundWebhooksSchema string
 
+// searchExportsSchema is the search exports raw graphql schema.
+//
+//go:embed search_exports.graphql
+var searchExportsSchema string
+
 // embeddingsSchema is the Embeddings raw graqhql schema.
 //
 //go:embed embeddings.graphql
@@ -33,6 +33,11 @@ type SearchContextsResolver interface {
 	DeleteSearchContextStar(ctx context.Context, args DeleteSearchContextStarArgs) (*EmptyResponse, error)
 	SetDefaultSearchContext(ctx context.Context, args SetDefaultSearchContextArgs) (*EmptyResponse, error)
 
+	CreateSearchContextSubscription(ctx context.Context, args CreateSearchContextSubscriptionArgs) (*EmptyResponse, error)
+	DeleteSearchContextSubscription(ctx context.Context, args DeleteSearchContextSubscriptionArgs) (*EmptyResponse, error)
+	PinSearchContextResolution(ctx context.Context, args PinSearchContextResolutionArgs) (SearchContextResolutionResolver, error)
+	UnpinSearchContextResolution(ctx context.Context, args UnpinSearchContextResolutionArgs) (*EmptyResponse, error)
+
 	NodeResolvers() map[string]NodeByIDFunc
 	SearchContextsToResolvers(searchContexts []*types.SearchContext) []SearchContextResolver
 }
@@ -51,6 +56,24 @@ type SearchContextResolver interface {
 	ViewerHasStarred(ctx context.Context) bool
 	Repositories(ctx context.Context) ([]SearchContextRepositoryRevisionsResolver, error)
 	Query() string
+	ViewerIsSubscribed(ctx context.Context) (bool, error)
+	Resolutions(ctx context.Context, args ListSearchContextResolutionsArgs) (SearchContextResolutionConnectionResolver, error)
+	PinnedResolution(ctx context.Context) (SearchContextResolutionResolver, error)
+}
+
+type SearchContextResolutionResolver interface {
+	ID() graphql.ID
+	CreatedAt() gqlutil.DateTime
+	Pinned() bool
+	Repositories(ctx context.Context) ([]SearchContextRepositoryRevisionsResolver, error)
+	Added(ctx context.Context, args SearchContextResolutionDiffArgs) ([]SearchContextRepositoryRevisionsResolver, error)
+	Removed(ctx context.Context, args SearchContextResolutionDiffArgs) ([]SearchContextRepositoryRevisionsResolver, error)
+}
+
+type SearchContextResolutionConnectionResolver interface {
+	Nodes() []SearchContextResolutionResolver
+	TotalCount() int32
+	PageInfo() *graphqlutil.PageInfo
 }
 
 type SearchContextConnectionResolver interface {
@@ -114,6 +137,34 @@ type SetDefaultSearchContextArgs struct {
 	UserID          graphql.ID
 }
 
+type CreateSearchContextSubscriptionArgs struct {
+	SearchContextID graphql.ID
+	UserID          graphql.ID
+}
+
+type DeleteSearchContextSubscriptionArgs struct {
+	SearchContextID graphql.ID
+	UserID          graphql.ID
+}
+
+type PinSearchContextResolutionArgs struct {
+	SearchContextID graphql.ID
+	ResolutionID    graphql.ID
+}
+
+type UnpinSearchContextResolutionArgs struct {
+	SearchContextID graphql.ID
+}
+
+type ListSearchContextResolutionsArgs struct {
+	First int32
+	After *string
+}
+
+type SearchContextResolutionDiffArgs struct {
+	Base *graphql.ID
+}
+
 type SearchContextBySpecArgs struct {
 	Spec string
 }
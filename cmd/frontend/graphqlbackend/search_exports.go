@@ -0,0 +1,47 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+type SearchExportsResolver interface {
+	CreateSearchExport(ctx context.Context, args CreateSearchExportArgs) (SearchExportResolver, error)
+	CancelSearchExport(ctx context.Context, args CancelSearchExportArgs) (SearchExportResolver, error)
+	SearchExports(ctx context.Context, args ListSearchExportsArgs) (*graphqlutil.ConnectionResolver[SearchExportResolver], error)
+
+	NodeResolvers() map[string]NodeByIDFunc
+}
+
+type CreateSearchExportArgs struct {
+	Query  string
+	Format string
+}
+
+type CancelSearchExportArgs struct {
+	ID graphql.ID
+}
+
+type ListSearchExportsArgs struct {
+	graphqlutil.ConnectionResolverArgs
+}
+
+type SearchExportResolver interface {
+	ID() graphql.ID
+	Query() string
+	Format() string
+	State() string
+	FailureMessage() *string
+	Creator(ctx context.Context) (*UserResolver, error)
+	QueuedAt() gqlutil.DateTime
+	StartedAt() *gqlutil.DateTime
+	FinishedAt() *gqlutil.DateTime
+	RepositoriesTotal() int32
+	RepositoriesDone() int32
+	MatchCount() int32
+	DownloadURL() *string
+}
@@ -49,13 +49,22 @@ func UpdatePermissions(ctx context.Context, logger log.Logger, db database.DB) {
 				return errors.Wrap(err, "creating new permissions")
 			}
 
+			excludedFromUserRole := make(map[string]struct{}, len(rbac.RBACSchema.ExcludeFromUserRole))
+			for _, name := range rbac.RBACSchema.ExcludeFromUserRole {
+				excludedFromUserRole[name] = struct{}{}
+			}
+
 			for _, permission := range permissions {
 				// Assign the permission to both SITE_ADMINISTRATOR and USER roles. We do this so that we don't break the
 				// current experience and always assume that everyone has access until a site administrator revokes that
-				// access.
+				// access. Permissions that the schema excludes from the USER role are only granted to site admins.
 				// Context: https://sourcegraph.slack.com/archives/C044BUJET7C/p1675292124253779?thread_ts=1675280399.192819&cid=C044BUJET7C
+				roles := []types.SystemRole{types.SiteAdministratorSystemRole}
+				if _, ok := excludedFromUserRole[permission.DisplayName()]; !ok {
+					roles = append(roles, types.UserSystemRole)
+				}
 				if err := rolePermissionStore.BulkAssignPermissionsToSystemRoles(ctx, database.BulkAssignPermissionsToSystemRolesOpts{
-					Roles:        []types.SystemRole{types.SiteAdministratorSystemRole, types.UserSystemRole},
+					Roles:        roles,
 					PermissionID: permission.ID,
 				}); err != nil {
 					return errors.Wrap(err, "assigning permission to system roles")
@@ -27,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/siteid"
 	oce "github.com/sourcegraph/sourcegraph/cmd/frontend/oneclickexport"
 	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	"github.com/sourcegraph/sourcegraph/internal/audit/dbsink"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
@@ -236,7 +237,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		return err
 	}
 
-	routines := []goroutine.BackgroundRoutine{server}
+	routines := []goroutine.BackgroundRoutine{server, dbsink.Register(logger, db)}
 	if internalAPI != nil {
 		routines = append(routines, internalAPI)
 	}
@@ -273,6 +274,7 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 			GitLabSyncWebhook:               enterprise.ReposGitLabWebhook,
 			BitbucketServerSyncWebhook:      enterprise.ReposBitbucketServerWebhook,
 			BitbucketCloudSyncWebhook:       enterprise.ReposBitbucketCloudWebhook,
+			GiteaSyncWebhook:                enterprise.ReposGiteaWebhook,
 			PermissionsGitHubWebhook:        enterprise.PermissionsGitHubWebhook,
 			BatchesGitHubWebhook:            enterprise.BatchesGitHubWebhook,
 			BatchesGitLabWebhook:            enterprise.BatchesGitLabWebhook,
@@ -286,6 +288,7 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
 			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
 			CodeInsightsDataExportHandler:   enterprise.CodeInsightsDataExportHandler,
+			SearchExportDownloadHandler:     enterprise.SearchExportDownloadHandler,
 			NewCompletionsStreamHandler:     enterprise.NewCompletionsStreamHandler,
 		},
 		enterprise.NewExecutorProxyHandler,
@@ -98,13 +98,13 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 			//
 			// 🚨 SECURITY: It's important we check for the correct scopes to know what this token
 			// is allowed to do.
-			var requiredScope string
+			var acceptedScopes []string
 			if sudoUser == "" {
-				requiredScope = authz.ScopeUserAll
+				acceptedScopes = acceptedAccessTokenScopes(r)
 			} else {
-				requiredScope = authz.ScopeSiteAdminSudo
+				acceptedScopes = []string{authz.ScopeSiteAdminSudo}
 			}
-			subjectUserID, err := db.AccessTokens().Lookup(r.Context(), token, requiredScope)
+			subjectUserID, scopes, err := db.AccessTokens().Lookup(r.Context(), token, acceptedScopes...)
 			if err != nil {
 				if err == database.ErrAccessTokenNotFound || errors.HasType(err, database.InvalidTokenError{}) {
 					anonymousId, anonCookieSet := cookie.AnonymousUID(r)
@@ -261,12 +261,20 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 				)
 			}
 
+			// 🚨 SECURITY: Tokens without the "user:all" scope only grant a subset of the user's
+			// privileges, which handlers enforce based on the actor's access token scopes.
+			var restrictedScopes []string
+			if !authz.HasScope(scopes, authz.ScopeUserAll) {
+				restrictedScopes = scopes
+			}
+
 			r = r.WithContext(
 				actor.WithActor(
 					r.Context(),
 					&actor.Actor{
 						UID:                 actorUserID,
 						SourcegraphOperator: sourcegraphOperator,
+						AccessTokenScopes:   restrictedScopes,
 					},
 				),
 			)
@@ -275,3 +283,22 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 		next.ServeHTTP(w, r)
 	})
 }
+
+// acceptedAccessTokenScopes returns the access token scopes that grant access to the given
+// request. The restricted scopes only grant access to the API endpoints they are intended for;
+// all other requests require the "user:all" scope.
+func acceptedAccessTokenScopes(r *http.Request) []string {
+	path := r.URL.Path
+	switch {
+	case path == "/.api/graphql":
+		// The GraphQL handler additionally checks the operation against the token's scopes.
+		return []string{authz.ScopeUserAll, authz.ScopeAPIRead, authz.ScopeBatchChangesWrite}
+	case path == "/.api/search/stream":
+		return []string{authz.ScopeUserAll, authz.ScopeAPIRead}
+	case path == "/.api/lsif/upload", path == "/.api/scip/upload":
+		return []string{authz.ScopeUserAll, authz.ScopeCodeIntelUpload}
+	case strings.HasPrefix(path, "/.api/files/batch-changes/"):
+		return []string{authz.ScopeUserAll, authz.ScopeBatchChangesWrite}
+	}
+	return []string{authz.ScopeUserAll}
+}
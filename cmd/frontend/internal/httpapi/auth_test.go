@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"testing"
 
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
@@ -81,7 +82,7 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", "token badbad")
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultReturn(0, database.InvalidTokenError{})
+		accessTokens.LookupFunc.SetDefaultReturn(0, nil, database.InvalidTokenError{})
 		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
 		securityEventLogs := database.NewMockSecurityEventLogsStore()
@@ -103,14 +104,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 			req.Header.Set("Authorization", headerValue)
 
 			accessTokens := database.NewMockAccessTokenStore()
-			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
 				if want := "abcdef"; tokenHexEncoded != want {
 					t.Errorf("got %q, want %q", tokenHexEncoded, want)
 				}
-				if want := authz.ScopeUserAll; requiredScope != want {
-					t.Errorf("got %q, want %q", requiredScope, want)
+				if want := []string{authz.ScopeUserAll}; !reflect.DeepEqual(acceptedScopes, want) {
+					t.Errorf("got %q, want %q", acceptedScopes, want)
 				}
-				return 123, nil
+				return 123, []string{authz.ScopeUserAll}, nil
 			})
 			db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
@@ -126,14 +127,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req = req.WithContext(sgactor.WithActor(context.Background(), &sgactor.Actor{UID: 456}))
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeUserAll; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeUserAll}; !reflect.DeepEqual(acceptedScopes, want) {
+				t.Errorf("got %q, want %q", acceptedScopes, want)
 			}
-			return 123, nil
+			return 123, []string{authz.ScopeUserAll}, nil
 		})
 		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
@@ -159,14 +160,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 			req = req.WithContext(sgactor.WithActor(context.Background(), &sgactor.Actor{UID: 456}))
 
 			accessTokens := database.NewMockAccessTokenStore()
-			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
 				if want := "abcdef"; tokenHexEncoded != want {
 					t.Errorf("got %q, want %q", tokenHexEncoded, want)
 				}
-				if want := authz.ScopeUserAll; requiredScope != want {
-					t.Errorf("got %q, want %q", requiredScope, want)
+				if want := []string{authz.ScopeUserAll}; !reflect.DeepEqual(acceptedScopes, want) {
+					t.Errorf("got %q, want %q", acceptedScopes, want)
 				}
-				return 123, nil
+				return 123, []string{authz.ScopeUserAll}, nil
 			})
 			db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
@@ -180,14 +181,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", `token-sudo token="abcdef",user="alice"`)
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeSiteAdminSudo; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeSiteAdminSudo}; !reflect.DeepEqual(acceptedScopes, want) {
+				t.Errorf("got %q, want %q", acceptedScopes, want)
 			}
-			return 123, nil
+			return 123, []string{authz.ScopeUserAll, authz.ScopeSiteAdminSudo}, nil
 		})
 
 		users := database.NewMockUserStore()
@@ -227,14 +228,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", `token-sudo token="abcdef",user="alice"`)
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeSiteAdminSudo; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeSiteAdminSudo}; !reflect.DeepEqual(acceptedScopes, want) {
+				t.Errorf("got %q, want %q", acceptedScopes, want)
 			}
-			return 123, nil
+			return 123, []string{authz.ScopeUserAll, authz.ScopeSiteAdminSudo}, nil
 		})
 
 		users := database.NewMockUserStore()
@@ -278,14 +279,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", `token-sudo token="abcdef",user="alice"`)
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeSiteAdminSudo; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeSiteAdminSudo}; !reflect.DeepEqual(acceptedScopes, want) {
+				t.Errorf("got %q, want %q", acceptedScopes, want)
 			}
-			return 123, nil
+			return 123, []string{authz.ScopeUserAll, authz.ScopeSiteAdminSudo}, nil
 		})
 
 		users := database.NewMockUserStore()
@@ -318,14 +319,14 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", `token-sudo token="abcdef",user="doesntexist"`)
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeSiteAdminSudo; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
+			if want := []string{authz.ScopeSiteAdminSudo}; !reflect.DeepEqual(acceptedScopes, want) {
+				t.Errorf("got %q, want %q", acceptedScopes, want)
 			}
-			return 123, nil
+			return 123, []string{authz.ScopeUserAll, authz.ScopeSiteAdminSudo}, nil
 		})
 
 		users := database.NewMockUserStore()
@@ -351,3 +352,66 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		mockrequire.Called(t, users.GetByUsernameFunc)
 	})
 }
+
+func TestAccessTokenAuthMiddlewareRestrictedScopes(t *testing.T) {
+	handler := func(db database.DB) http.Handler {
+		return AccessTokenAuthMiddleware(
+			db,
+			logtest.NoOp(t),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor := sgactor.FromContext(r.Context())
+				_, _ = fmt.Fprintf(w, "user %v scopes %q", actor.UID, actor.AccessTokenScopes)
+			}))
+	}
+
+	tokenScopes := []string{authz.ScopeCodeIntelUpload}
+
+	db := database.NewMockDB()
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+	accessTokens := database.NewMockAccessTokenStore()
+	accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, _ string, acceptedScopes ...string) (int32, []string, error) {
+		for _, scope := range acceptedScopes {
+			if authz.HasScope(tokenScopes, scope) {
+				return 123, tokenScopes, nil
+			}
+		}
+		return 0, nil, database.ErrAccessTokenNotFound
+	})
+	db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+	db.SecurityEventLogsFunc.SetDefaultReturn(database.NewMockSecurityEventLogsStore())
+
+	for _, tc := range []struct {
+		method         string
+		path           string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{"POST", "/.api/scip/upload", http.StatusOK, `user 123 scopes ["codeintel:upload"]`},
+		{"POST", "/.api/lsif/upload", http.StatusOK, `user 123 scopes ["codeintel:upload"]`},
+		{"POST", "/.api/graphql", http.StatusUnauthorized, "Invalid access token.\n"},
+		{"GET", "/.api/search/stream", http.StatusUnauthorized, "Invalid access token.\n"},
+		{"GET", "/search", http.StatusUnauthorized, "Invalid access token.\n"},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("Authorization", "token abcdef")
+
+			rr := httptest.NewRecorder()
+			handler(db).ServeHTTP(rr, req)
+			require.Equal(t, tc.wantStatusCode, rr.Code)
+			require.Equal(t, tc.wantBody, rr.Body.String())
+		})
+	}
+
+	t.Run("unrestricted token", func(t *testing.T) {
+		tokenScopes = []string{authz.ScopeUserAll}
+
+		req, _ := http.NewRequest("POST", "/.api/scip/upload", nil)
+		req.Header.Set("Authorization", "token abcdef")
+
+		rr := httptest.NewRecorder()
+		handler(db).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, `user 123 scopes []`, rr.Body.String())
+	})
+}
@@ -61,6 +61,12 @@ func serveGraphQL(logger sglog.Logger, schema *graphql.Schema, rlw graphqlbacken
 			return err
 		}
 
+		// 🚨 SECURITY: Restricted access tokens may only perform the operations their scopes permit.
+		if err := graphqlbackend.CheckAccessTokenScopes(r.Context(), params.Query, params.OperationName); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return nil
+		}
+
 		traceData := traceData{
 			queryParams:   params,
 			isInternal:    isInternal,
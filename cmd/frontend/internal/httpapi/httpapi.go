@@ -47,6 +47,7 @@ type Handlers struct {
 	GitLabSyncWebhook          webhooks.Registerer
 	BitbucketServerSyncWebhook webhooks.Registerer
 	BitbucketCloudSyncWebhook  webhooks.Registerer
+	GiteaSyncWebhook           webhooks.Registerer
 
 	// Permissions
 	PermissionsGitHubWebhook webhooks.Registerer
@@ -73,6 +74,9 @@ type Handlers struct {
 	// Code Insights
 	CodeInsightsDataExportHandler http.Handler
 
+	// Search exports
+	SearchExportDownloadHandler http.Handler
+
 	// Completions stream
 	NewCompletionsStreamHandler enterprise.NewCompletionsStreamHandler
 }
@@ -123,6 +127,7 @@ func NewHandler(
 	handlers.BatchesGitLabWebhook.Register(&wh)
 	handlers.BitbucketServerSyncWebhook.Register(&wh)
 	handlers.BitbucketCloudSyncWebhook.Register(&wh)
+	handlers.GiteaSyncWebhook.Register(&wh)
 	handlers.BatchesBitbucketServerWebhook.Register(&wh)
 	handlers.BatchesBitbucketCloudWebhook.Register(&wh)
 	handlers.GitHubSyncWebhook.Register(&wh)
@@ -153,6 +158,7 @@ func NewHandler(
 	m.Get(apirouter.CompletionsStream).Handler(trace.Route(handlers.NewCompletionsStreamHandler()))
 
 	m.Get(apirouter.CodeInsightsDataExport).Handler(trace.Route(handlers.CodeInsightsDataExportHandler))
+	m.Get(apirouter.SearchExportDownload).Handler(trace.Route(handlers.SearchExportDownloadHandler))
 
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET", "POST").Name("updatecheck").Handler(trace.Route(http.HandlerFunc(updatecheck.HandlerWithLog(logger))))
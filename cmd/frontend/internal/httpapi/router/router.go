@@ -42,6 +42,8 @@ const (
 
 	CodeInsightsDataExport = "insights.data.export"
 
+	SearchExportDownload = "search.export.download"
+
 	ExternalURL            = "internal.app-url"
 	SendEmail              = "internal.send-email"
 	GitInfoRefs            = "internal.git.info-refs"
@@ -85,6 +87,7 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/insights/export/{id}").Methods("GET").Name(CodeInsightsDataExport)
+	base.Path("/search/exports/{id}").Methods("GET").Name(SearchExportDownload)
 	base.Path("/completions/stream").Methods("POST").Name(CompletionsStream)
 
 	// repo contains routes that are NOT specific to a revision. In these routes, the URL may not contain a revspec after the repo (that is, no "github.com/foo/bar@myrevspec").
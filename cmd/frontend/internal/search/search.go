@@ -434,6 +434,10 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searc
 			ContainerName: sym.Symbol.Parent,
 			Kind:          kindString,
 			Line:          int32(sym.Symbol.Line),
+
+			FullyQualifiedName: sym.Symbol.FullyQualifiedName,
+			PackageName:        sym.Symbol.PackageName,
+			PackageVersion:     sym.Symbol.PackageVersion,
 		})
 	}
 
@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+)
+
+func (wr *Router) HandleGiteaWebhook(logger log.Logger, w http.ResponseWriter, r *http.Request, codeHostURN extsvc.CodeHostBaseURL, payload []byte) {
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	eventType := gitea.WebhookEventType(r)
+	e, err := gitea.ParseWebhookEvent(eventType, payload)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			// Gitea sends every event type to the same webhook unless it is
+			// configured otherwise, so we acknowledge the ones we don't handle
+			// rather than have them show up as failed deliveries.
+			logger.Debug("unsupported Gitea event type", log.String("eventType", eventType))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Route the request based on the event type.
+	err = wr.Dispatch(ctx, eventType, extsvc.KindGitea, codeHostURN, e)
+	if err != nil {
+		logger.Error("Error handling Gitea webhook event", log.Error(err))
+		if errcode.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (wr *Router) handleGiteaWebhook(logger log.Logger, w http.ResponseWriter, r *http.Request, urn extsvc.CodeHostBaseURL, secret string) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Error while reading request body.", http.StatusInternalServerError)
+		return
+	}
+	if err := r.Body.Close(); err != nil {
+		http.Error(w, "Closing body", http.StatusInternalServerError)
+		return
+	}
+
+	if secret != "" {
+		if err := gitea.ValidateSignature(gitea.WebhookSignature(r), payload, []byte(secret)); err != nil {
+			http.Error(w, "Could not validate payload with secret.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	wr.HandleGiteaWebhook(logger, w, r, urn, payload)
+}
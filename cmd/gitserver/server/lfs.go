@@ -0,0 +1,537 @@
+package server
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// LFSOptions configure fetching the Git LFS objects of a repository.
+type LFSOptions struct {
+	// MaxFileSize is the maximum size in bytes of an object that is fetched.
+	MaxFileSize int64
+	// MaxRepoSize is the maximum total size in bytes of the objects fetched
+	// for a repository.
+	MaxRepoSize int64
+}
+
+// lfsPointerMaxSize is the maximum size of a Git LFS pointer file. Larger
+// blobs are never pointers.
+const lfsPointerMaxSize = 1024
+
+// lfsBatchSize is the number of objects requested per LFS batch API request.
+const lfsBatchSize = 100
+
+const lfsMediaType = "application/vnd.git-lfs+json"
+
+var lfsOidRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// lfsPointer is a Git LFS pointer file, which is committed in place of the
+// content of a file tracked by Git LFS.
+type lfsPointer struct {
+	Oid  string
+	Size int64
+}
+
+// parseLFSPointer parses data as a Git LFS pointer file. See
+// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md.
+func parseLFSPointer(data []byte) (lfsPointer, bool) {
+	if len(data) > lfsPointerMaxSize || !bytes.HasPrefix(data, []byte("version https://git-lfs.github.com/spec/v1\n")) {
+		return lfsPointer{}, false
+	}
+
+	p := lfsPointer{Size: -1}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return lfsPointer{}, false
+		}
+		switch key {
+		case "oid":
+			oid := strings.TrimPrefix(value, "sha256:")
+			if oid == value || !lfsOidRegex.MatchString(oid) {
+				return lfsPointer{}, false
+			}
+			p.Oid = oid
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return lfsPointer{}, false
+			}
+			p.Size = size
+		}
+	}
+	if p.Oid == "" || p.Size < 0 {
+		return lfsPointer{}, false
+	}
+	return p, true
+}
+
+// lfsObjectPath returns the path of an LFS object in dir. It is the same
+// layout git-lfs uses.
+func lfsObjectPath(dir GitDir, oid string) string {
+	return dir.Path("lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// hasLFSObjects returns whether any LFS objects have been fetched for the
+// repository at dir.
+func hasLFSObjects(dir GitDir) bool {
+	fi, err := os.Stat(dir.Path("lfs", "objects"))
+	return err == nil && fi.IsDir()
+}
+
+// openLFSObject opens the fetched LFS object of p. It returns an error if the
+// object hasn't been fetched.
+func openLFSObject(dir GitDir, p lfsPointer) (*os.File, error) {
+	f, err := os.Open(lfsObjectPath(dir, p.Oid))
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil || fi.Size() != p.Size {
+		f.Close()
+		return nil, errors.Newf("LFS object %s is incomplete", p.Oid)
+	}
+	return f, nil
+}
+
+// lfsPointers returns the LFS pointers of the files at rev.
+func lfsPointers(ctx context.Context, dir GitDir, rev string) ([]lfsPointer, error) {
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-l", "-z", rev)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "git ls-tree")
+	}
+
+	// Only small blobs can be pointers, so we only read those.
+	var candidates bytes.Buffer
+	for _, entry := range bytes.Split(out, []byte{0}) {
+		meta, _, ok := bytes.Cut(entry, []byte{'\t'})
+		if !ok {
+			continue
+		}
+		// <mode> SP <type> SP <object> SP <size>
+		fields := strings.Fields(string(meta))
+		if len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		if size, err := strconv.Atoi(fields[3]); err == nil && size <= lfsPointerMaxSize {
+			candidates.WriteString(fields[2] + "\n")
+		}
+	}
+	if candidates.Len() == 0 {
+		return nil, nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	dir.Set(cmd)
+	cmd.Stdin = &candidates
+	out, err = cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "git cat-file")
+	}
+
+	seen := make(map[string]bool)
+	var pointers []lfsPointer
+	r := bufio.NewReader(bytes.NewReader(out))
+	for {
+		// <object> SP <type> SP <size> LF <contents> LF
+		header, err := r.ReadString('\n')
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, errors.Newf("unexpected git cat-file output %q", header)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, errors.Newf("unexpected git cat-file output %q", header)
+		}
+		data := make([]byte, size+1)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		if p, ok := parseLFSPointer(data[:size]); ok && !seen[p.Oid] {
+			seen[p.Oid] = true
+			pointers = append(pointers, p)
+		}
+	}
+	return pointers, nil
+}
+
+// fetchLFSObjects fetches the LFS objects referenced at HEAD of the repository
+// at dir from the LFS server of remoteURL, within the limits of opts. Objects
+// that have been fetched before are kept, and objects that are no longer
+// referenced at HEAD or exceed the limits are deleted.
+func fetchLFSObjects(ctx context.Context, cli httpcli.Doer, dir GitDir, remoteURL *vcs.URL, opts LFSOptions) error {
+	if remoteURL.Scheme != "http" && remoteURL.Scheme != "https" {
+		return errors.Newf("fetching LFS objects is not supported for %s URLs", remoteURL.Scheme)
+	}
+
+	pointers, err := lfsPointers(ctx, dir, "HEAD")
+	if err != nil {
+		return errors.Wrap(err, "listing LFS pointers")
+	}
+
+	var total int64
+	var missing []lfsPointer
+	wanted := make(map[string]bool, len(pointers))
+	for _, p := range pointers {
+		if opts.MaxFileSize > 0 && p.Size > opts.MaxFileSize {
+			continue
+		}
+		if opts.MaxRepoSize > 0 && total+p.Size > opts.MaxRepoSize {
+			continue
+		}
+		total += p.Size
+		wanted[p.Oid] = true
+		if f, err := openLFSObject(dir, p); err == nil {
+			f.Close()
+			continue
+		}
+		missing = append(missing, p)
+	}
+
+	var errs error
+	if err := pruneLFSObjects(dir, wanted); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "pruning LFS objects"))
+	}
+
+	endpoint := lfsEndpoint(remoteURL)
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > lfsBatchSize {
+			batch = batch[:lfsBatchSize]
+		}
+		missing = missing[len(batch):]
+
+		objects, err := lfsBatch(ctx, cli, endpoint, batch)
+		if err != nil {
+			return err
+		}
+		for _, o := range objects {
+			if err := downloadLFSObject(ctx, cli, dir, endpoint, o); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "downloading LFS object %s", o.Oid))
+			}
+		}
+	}
+	return errs
+}
+
+// pruneLFSObjects deletes the fetched LFS objects of the repository at dir
+// whose oid isn't in wanted.
+func pruneLFSObjects(dir GitDir, wanted map[string]bool) error {
+	err := filepath.WalkDir(dir.Path("lfs", "objects"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && !wanted[d.Name()] {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// lfsEndpoint returns the LFS server URL of a Git remote, as git-lfs derives
+// it by default.
+func lfsEndpoint(remoteURL *vcs.URL) *vcs.URL {
+	u := *remoteURL
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, ".git") {
+		u.Path += ".git"
+	}
+	u.RawPath = ""
+	return u.JoinPath("info", "lfs")
+}
+
+type lfsBatchObject struct {
+	Oid     string `json:"oid"`
+	Size    int64  `json:"size"`
+	Actions struct {
+		Download *struct {
+			Href   string            `json:"href"`
+			Header map[string]string `json:"header"`
+		} `json:"download"`
+	} `json:"actions"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// lfsBatch requests the download actions of objects from the LFS batch API.
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md.
+func lfsBatch(ctx context.Context, cli httpcli.Doer, endpoint *vcs.URL, objects []lfsPointer) ([]lfsBatchObject, error) {
+	type object struct {
+		Oid  string `json:"oid"`
+		Size int64  `json:"size"`
+	}
+	body := struct {
+		Operation string   `json:"operation"`
+		Transfers []string `json:"transfers"`
+		Objects   []object `json:"objects"`
+	}{
+		Operation: "download",
+		Transfers: []string{"basic"},
+	}
+	for _, p := range objects {
+		body.Objects = append(body.Objects, object{Oid: p.Oid, Size: p.Size})
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint.JoinPath("objects", "batch").String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "LFS batch request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("LFS batch request: unexpected status code %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Objects []lfsBatchObject `json:"objects"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "decoding LFS batch response")
+	}
+	return result.Objects, nil
+}
+
+// downloadLFSObject downloads and verifies an LFS object and stores it in dir.
+func downloadLFSObject(ctx context.Context, cli httpcli.Doer, dir GitDir, endpoint *vcs.URL, o lfsBatchObject) error {
+	if o.Error != nil {
+		return errors.Newf("%d: %s", o.Error.Code, o.Error.Message)
+	}
+	if o.Actions.Download == nil || !lfsOidRegex.MatchString(o.Oid) {
+		return errors.New("no download action")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", o.Actions.Download.Href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range o.Actions.Download.Header {
+		req.Header.Set(k, v)
+	}
+	// Objects stored on the Git host itself need the credentials of the remote
+	// URL, unless the batch API provided its own.
+	if req.Header.Get("Authorization") == "" && req.URL.Host == endpoint.Host && endpoint.User != nil {
+		password, _ := endpoint.User.Password()
+		req.SetBasicAuth(endpoint.User.Username(), password)
+	}
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Newf("unexpected status code %d", resp.StatusCode)
+	}
+
+	tmpDir := dir.Path("lfs", "tmp")
+	if err := os.MkdirAll(tmpDir, os.ModePerm); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(tmpDir, o.Oid)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(resp.Body, o.Size+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if n != o.Size || hex.EncodeToString(h.Sum(nil)) != o.Oid {
+		return errors.New("content does not match the LFS pointer")
+	}
+
+	dst := lfsObjectPath(dir, o.Oid)
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// isShowBlobArgs returns whether args show a single blob, like
+// `git show <commit>:<path>`.
+func isShowBlobArgs(args []string) bool {
+	return len(args) == 2 && args[0] == "show" && strings.Contains(args[1], ":")
+}
+
+// lfsBlobWriter buffers a blob written to it. On Close, it writes the content
+// of its LFS object to w if the blob is an LFS pointer whose object has been
+// fetched, and the blob itself otherwise.
+type lfsBlobWriter struct {
+	dir         GitDir
+	w           io.Writer
+	buf         bytes.Buffer
+	passthrough bool
+}
+
+func newLFSBlobWriter(dir GitDir, w io.Writer) *lfsBlobWriter {
+	return &lfsBlobWriter{dir: dir, w: w}
+}
+
+func (lw *lfsBlobWriter) Write(p []byte) (int, error) {
+	if lw.passthrough {
+		return lw.w.Write(p)
+	}
+	lw.buf.Write(p)
+	if lw.buf.Len() > lfsPointerMaxSize {
+		// Too large to be a pointer.
+		lw.passthrough = true
+		if _, err := lw.buf.WriteTo(lw.w); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (lw *lfsBlobWriter) Close() error {
+	if lw.passthrough {
+		return nil
+	}
+	if p, ok := parseLFSPointer(lw.buf.Bytes()); ok {
+		if f, err := openLFSObject(lw.dir, p); err == nil {
+			defer f.Close()
+			_, err = io.Copy(lw.w, f)
+			return err
+		}
+	}
+	_, err := lw.buf.WriteTo(lw.w)
+	return err
+}
+
+// lfsTarWriter rewrites a tar archive written to it, replacing LFS pointer
+// files whose objects have been fetched with the content of the objects.
+type lfsTarWriter struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+func newLFSTarWriter(dir GitDir, w io.Writer) *lfsTarWriter {
+	pr, pw := io.Pipe()
+	lw := &lfsTarWriter{pw: pw, done: make(chan error, 1)}
+	go func() {
+		err := resolveLFSTar(dir, pr, w)
+		if err == nil {
+			// Consume the padding after the end of the archive.
+			_, err = io.Copy(io.Discard, pr)
+		}
+		pr.CloseWithError(err)
+		lw.done <- err
+	}()
+	return lw
+}
+
+func (lw *lfsTarWriter) Write(p []byte) (int, error) {
+	return lw.pw.Write(p)
+}
+
+// Close waits for the rewritten archive to be written.
+func (lw *lfsTarWriter) Close() error {
+	lw.pw.Close()
+	return <-lw.done
+}
+
+// resolveLFSTar copies the tar archive read from r to w, replacing LFS pointer
+// files whose objects have been fetched with the content of the objects.
+func resolveLFSTar(dir GitDir, r io.Reader, w io.Writer) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+	empty := true
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			// Don't turn the empty output of a failed command into an archive.
+			if empty {
+				return nil
+			}
+			return tw.Close()
+		} else if err != nil {
+			return err
+		}
+		empty = false
+
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > lfsPointerMaxSize {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := writeLFSTarEntry(dir, tw, hdr, data); err != nil {
+			return errors.Wrapf(err, "writing %s", hdr.Name)
+		}
+	}
+}
+
+// writeLFSTarEntry writes the small file data to tw, or the content of its LFS
+// object if data is a pointer whose object has been fetched.
+func writeLFSTarEntry(dir GitDir, tw *tar.Writer, hdr *tar.Header, data []byte) error {
+	if p, ok := parseLFSPointer(data); ok {
+		if f, err := openLFSObject(dir, p); err == nil {
+			defer f.Close()
+			hdr.Size = p.Size
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			_, err = io.Copy(tw, f)
+			return err
+		}
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
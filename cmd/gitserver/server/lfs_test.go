@@ -0,0 +1,249 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	oid := strings.Repeat("a", 64)
+	for _, tc := range []struct {
+		name string
+		data string
+		want lfsPointer
+		ok   bool
+	}{
+		{
+			name: "pointer",
+			data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			want: lfsPointer{Oid: oid, Size: 12345},
+			ok:   true,
+		},
+		{
+			name: "pointer with extensions",
+			data: "version https://git-lfs.github.com/spec/v1\next-0-foo sha256:" + oid + "\noid sha256:" + oid + "\nsize 0\n",
+			want: lfsPointer{Oid: oid, Size: 0},
+			ok:   true,
+		},
+		{
+			name: "regular file",
+			data: "hello world\n",
+		},
+		{
+			name: "missing size",
+			data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n",
+		},
+		{
+			name: "invalid oid",
+			data: "version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 1\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, ok := parseLFSPointer([]byte(tc.data))
+			if ok != tc.ok {
+				t.Fatalf("want ok=%t, have %t", tc.ok, ok)
+			}
+			if have != tc.want {
+				t.Fatalf("want %+v, have %+v", tc.want, have)
+			}
+		})
+	}
+}
+
+func lfsPointerFile(content string) (oid, pointer string) {
+	sum := sha256.Sum256([]byte(content))
+	oid = hex.EncodeToString(sum[:])
+	return oid, fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, len(content))
+}
+
+func TestLFS(t *testing.T) {
+	const (
+		small = "hello from LFS"
+		large = "this object is larger than the limit"
+	)
+	smallOid, smallPointer := lfsPointerFile(small)
+	_, largePointer := lfsPointerFile(large)
+	objects := map[string]string{smallOid: small}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "user" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/repo.git/info/lfs/objects/batch":
+			var req struct {
+				Objects []struct {
+					Oid string `json:"oid"`
+				} `json:"objects"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+			}
+			var resp []map[string]any
+			for _, o := range req.Objects {
+				resp = append(resp, map[string]any{
+					"oid":  o.Oid,
+					"size": len(objects[o.Oid]),
+					"actions": map[string]any{
+						"download": map[string]any{"href": "http://" + r.Host + "/objects/" + o.Oid},
+					},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"objects": resp})
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/objects/"):
+			content, ok := objects[strings.TrimPrefix(r.URL.Path, "/objects/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = io.WriteString(w, content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	root := t.TempDir()
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, root, name, arg...)
+	}
+	cmd("git", "init", ".")
+	for name, content := range map[string]string{
+		"small.bin": smallPointer,
+		"large.bin": largePointer,
+		"README":    "not in LFS\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd("git", "add", ".")
+	cmd("git", "commit", "-m", "lfs")
+	dir := GitDir(filepath.Join(root, ".git"))
+
+	remoteURL, err := vcs.ParseURL(strings.Replace(srv.URL, "http://", "http://user:token@", 1) + "/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if hasLFSObjects(dir) {
+		t.Fatal("expected no LFS objects before fetching")
+	}
+	// An object fetched before that is no longer referenced.
+	staleOid, _ := lfsPointerFile("stale")
+	stalePath := lfsObjectPath(dir, staleOid)
+	if err := os.MkdirAll(filepath.Dir(stalePath), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stalePath, []byte("stale"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = fetchLFSObjects(ctx, http.DefaultClient, dir, remoteURL, LFSOptions{MaxFileSize: int64(len(small)), MaxRepoSize: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasLFSObjects(dir) {
+		t.Fatal("expected LFS objects after fetching")
+	}
+	if got := dirSize(dir.Path("lfs", "objects")); got != int64(len(small)) {
+		t.Fatalf("want LFS objects of size %d, have %d", len(small), got)
+	}
+
+	t.Run("show", func(t *testing.T) {
+		for name, want := range map[string]string{
+			"small.bin": small,
+			// Not fetched because of the size limit.
+			"large.bin": largePointer,
+			"README":    "not in LFS\n",
+		} {
+			var buf bytes.Buffer
+			lw := newLFSBlobWriter(dir, &buf)
+			c := exec.Command("git", "show", "HEAD:"+name)
+			dir.Set(c)
+			c.Stdout = lw
+			if err := c.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if err := lw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, buf.String()); diff != "" {
+				t.Errorf("unexpected content of %s (-want +got):\n%s", name, diff)
+			}
+		}
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		var buf bytes.Buffer
+		lw := newLFSTarWriter(dir, &buf)
+		c := exec.Command("git", "archive", "--format=tar", "HEAD")
+		dir.Set(c)
+		c.Stdout = lw
+		if err := c.Run(); err != nil {
+			t.Fatal(err)
+		}
+		if err := lw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		have := map[string]string{}
+		tr := tar.NewReader(&buf)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if hdr.Typeflag == tar.TypeXGlobalHeader {
+				continue
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have[hdr.Name] = string(data)
+		}
+		want := map[string]string{
+			"small.bin": small,
+			"large.bin": largePointer,
+			"README":    "not in LFS\n",
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("unexpected archive content (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		cmd("git", "rm", "small.bin")
+		cmd("git", "commit", "-m", "remove small.bin")
+
+		err := fetchLFSObjects(ctx, http.DefaultClient, dir, remoteURL, LFSOptions{MaxFileSize: int64(len(small)), MaxRepoSize: 1024})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := dirSize(dir.Path("lfs", "objects")); got != 0 {
+			t.Fatalf("want no LFS objects after removing their pointers, have %d bytes", got)
+		}
+	})
+}
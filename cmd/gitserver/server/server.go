@@ -51,6 +51,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/search"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -1227,6 +1228,8 @@ func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 	req.Args = append(req.Args, treeish, "--")
 	req.Args = append(req.Args, pathspecs...)
 
+	req.ResolveLFS = format == string(gitserver.ArchiveFormatTar) && q.Get("lfs") == "true"
+
 	s.execHTTP(w, r, req)
 }
 
@@ -1827,6 +1830,20 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 		}
 	}
 
+	// Resolve Git LFS pointer files when reading a file, or archiving if
+	// requested, for repos with fetched LFS objects.
+	var lfsW io.WriteCloser
+	if hasLFSObjects(dir) {
+		if isShowBlobArgs(req.Args) {
+			lfsW = newLFSBlobWriter(dir, w)
+		} else if req.ResolveLFS && len(req.Args) > 0 && req.Args[0] == "archive" {
+			lfsW = newLFSTarWriter(dir, w)
+		}
+	}
+	if lfsW != nil {
+		w = lfsW
+	}
+
 	var stderrBuf bytes.Buffer
 	stdoutW := &writeCounter{w: w}
 	stderrW := &writeCounter{w: &limitWriter{W: &stderrBuf, N: 1024}}
@@ -1839,6 +1856,11 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	cmd.Unwrap().Stdin = bytes.NewReader(req.Stdin)
 
 	exitStatus, execErr = runCommand(ctx, cmd)
+	if lfsW != nil {
+		if err := lfsW.Close(); err != nil && execErr == nil {
+			execErr = errors.Wrap(err, "resolving LFS pointers")
+		}
+	}
 
 	status = strconv.Itoa(exitStatus)
 	stdoutN = stdoutW.n
@@ -2114,6 +2136,35 @@ func (s *Server) setRepoSize(ctx context.Context, name api.RepoName) error {
 	return s.DB.GitserverRepos().SetRepoSize(ctx, name, dirSize(s.dir(name).Path(".")), s.Hostname)
 }
 
+// fetchLFS fetches the Git LFS objects of the repo if its syncer is configured
+// to, and stores their size in the database. Otherwise, it deletes the objects
+// fetched before, so that LFS pointers are no longer resolved. Failures are
+// only logged.
+func (s *Server) fetchLFS(ctx context.Context, logger log.Logger, name api.RepoName, dir GitDir, syncer VCSSyncer, remoteURL *vcs.URL) {
+	gs, ok := syncer.(*GitRepoSyncer)
+	if !ok || gs.LFS == nil {
+		if !hasLFSObjects(dir) {
+			return
+		}
+		if err := os.RemoveAll(dir.Path("lfs")); err != nil {
+			logger.Warn("failed to delete LFS objects", log.Error(err))
+			return
+		}
+		if err := s.DB.GitserverRepos().SetLFSSize(ctx, name, 0); err != nil {
+			logger.Warn("failed to set LFS size", log.Error(err))
+		}
+		return
+	}
+
+	if err := fetchLFSObjects(ctx, httpcli.ExternalDoer, dir, remoteURL, *gs.LFS); err != nil {
+		logger.Warn("failed to fetch LFS objects", log.String("error", newURLRedactor(remoteURL).redact(err.Error())))
+	}
+
+	if err := s.DB.GitserverRepos().SetLFSSize(ctx, name, dirSize(dir.Path("lfs", "objects"))); err != nil {
+		logger.Warn("failed to set LFS size", log.Error(err))
+	}
+}
+
 func (s *Server) logIfCorrupt(ctx context.Context, repo api.RepoName, dir GitDir, stderr string) {
 	if checkMaybeCorruptRepo(s.Logger, repo, dir, stderr) {
 		reason := stderr
@@ -2397,6 +2448,10 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		logger.Warn("failed setting last fetch in DB", log.Error(err))
 	}
 
+	// Best-effort fetching of LFS objects, since the repository is usable
+	// without them.
+	s.fetchLFS(ctx, logger, repo, dir, syncer, remoteURL)
+
 	// Successfully updated, best-effort calculation of the repo size.
 	if err := s.setRepoSize(ctx, repo); err != nil {
 		logger.Warn("failed setting repo size", log.Error(err))
@@ -2750,6 +2805,10 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		logger.Warn("failed to set last_fetched in DB", log.Error(err))
 	}
 
+	// Best-effort fetching of LFS objects, since the repository is usable
+	// without them.
+	s.fetchLFS(ctx, logger, repo, dir, syncer, remoteURL)
+
 	// Successfully updated, best-effort calculation of the repo size.
 	if err := s.setRepoSize(ctx, repo); err != nil {
 		logger.Warn("failed to set repo size", log.Error(err))
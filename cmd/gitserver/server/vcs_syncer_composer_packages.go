@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewComposerPackagesSyncer(
+	connection *schema.ComposerPackagesConnection,
+	svc *dependencies.Service,
+	client *packagist.Client,
+	reposDir string,
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:      log.Scoped("ComposerPackagesSyncer", "sync Composer packages"),
+		typ:         "composer_packages",
+		scheme:      dependencies.ComposerPackagesScheme,
+		placeholder: reposource.NewComposerVersionedPackage("sourcegraph/placeholder", "0.0.0"),
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &composerDependencySource{client: client, reposDir: reposDir},
+	}
+}
+
+type composerDependencySource struct {
+	client   *packagist.Client
+	reposDir string
+}
+
+func (composerDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.NewComposerVersionedPackage(name, version), nil
+}
+
+func (composerDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseComposerVersionedPackage(dep)
+}
+
+func (composerDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseComposerPackageFromName(name)
+}
+
+func (composerDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseComposerPackageFromRepoName(repoName)
+}
+
+func (s *composerDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	version, err := s.client.Version(ctx, dep.PackageSyntax(), dep.PackageVersion())
+	if err != nil {
+		return errors.Wrapf(err, "error resolving Composer package %q", dep.VersionedPackageSyntax())
+	}
+
+	// Packagist points at zipballs of the source repository for nearly every
+	// package. Other dist types (tar, git, ...) are rare and not supported yet.
+	if version.Dist == nil || version.Dist.Type != "zip" {
+		return errors.Newf("Composer package %q has no zip distribution", dep.VersionedPackageSyntax())
+	}
+
+	pkgContents, err := s.client.Download(ctx, version.Dist.URL)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading Composer package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	if err = unpackComposerPackage(pkgContents, s.reposDir, dir); err != nil {
+		return errors.Wrapf(err, "failed to unzip Composer package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// unpackComposerPackage unpacks the given zip distribution of a Composer
+// package into workDir, skipping any files that aren't valid or that are
+// potentially malicious. Distributions are usually zipballs of a GitHub
+// repository with a single top-level directory, which is stripped.
+func unpackComposerPackage(pkg io.Reader, reposDir, workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	tmpdir, err := tempDir(reposDir, "composer-packages")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	zip, zipLen, err := writeZipToTemp(tmpdir, pkg)
+	if err != nil {
+		return err
+	}
+	defer zip.Close()
+
+	if err := unpack.Zip(zip, zipLen, workDir, opts); err != nil {
+		return err
+	}
+
+	return stripSingleOutermostDirectory(workDir)
+}
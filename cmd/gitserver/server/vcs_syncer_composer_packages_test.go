@@ -0,0 +1,66 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnpackComposerPackage(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for _, f := range []fileInfo{
+		{path: "Seldaek-monolog-e239236/composer.json", contents: []byte(`{"name":"monolog/monolog"}`)},
+		{path: "Seldaek-monolog-e239236/src/Monolog/Logger.php", contents: []byte("<?php class Logger {}")},
+		{path: "Seldaek-monolog-e239236/README.md", contents: []byte("# Monolog")},
+		{path: "../escape.php", contents: []byte("filter me")},
+	} {
+		fw, err := zw.Create(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(f.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reposDir := t.TempDir()
+	workDir := t.TempDir()
+	if err := unpackComposerPackage(&zipBuf, reposDir, workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	got := listFiles(t, workDir)
+	want := []string{"/README.md", "/composer.json", "/src/Monolog/Logger.php"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	var files []string
+	if err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, strings.TrimPrefix(path, dir))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	return files
+}
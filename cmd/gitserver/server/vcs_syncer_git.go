@@ -30,7 +30,11 @@ func (e *GitCommandError) Unwrap() error {
 }
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// LFS configures fetching Git LFS objects after a clone or fetch. LFS
+	// objects aren't fetched if it is nil.
+	LFS *LFSOptions
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
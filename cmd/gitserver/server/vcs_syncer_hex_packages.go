@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hex"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewHexPackagesSyncer(
+	connection *schema.HexPackagesConnection,
+	svc *dependencies.Service,
+	client *hex.Client,
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:      log.Scoped("HexPackagesSyncer", "sync Hex packages"),
+		typ:         "hex_packages",
+		scheme:      dependencies.HexPackagesScheme,
+		placeholder: reposource.NewHexVersionedPackage("sourcegraph_placeholder", "0.0.0"),
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &hexDependencySource{client: client},
+	}
+}
+
+type hexDependencySource struct {
+	client *hex.Client
+}
+
+func (hexDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.NewHexVersionedPackage(name, version), nil
+}
+
+func (hexDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseHexVersionedPackage(dep)
+}
+
+func (hexDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseHexPackageFromName(name)
+}
+
+func (hexDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseHexPackageFromRepoName(repoName)
+}
+
+func (s *hexDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading Hex package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	if err = unpackHexPackage(pkgContents, dir); err != nil {
+		return errors.Wrapf(err, "failed to untar Hex package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// unpackHexPackage unpacks the given Hex tarball into workDir. The outer tar
+// holds the package sources in contents.tar.gz next to the package metadata,
+// which is kept as hex_metadata.config like mix does when fetching a package.
+func unpackHexPackage(pkg io.Reader, workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			return path == "contents.tar.gz" || path == "metadata.config"
+		},
+	}
+
+	tmpDir, err := os.MkdirTemp("", "hex")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := unpack.Tar(pkg, tmpDir, opts); err != nil {
+		return errors.Wrap(err, "failed to unpack downloaded tar")
+	}
+
+	if err := unpackHexContentsTarGz(filepath.Join(tmpDir, "contents.tar.gz"), workDir); err != nil {
+		return err
+	}
+
+	metadata, err := os.ReadFile(filepath.Join(tmpDir, "metadata.config"))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(workDir, "hex_metadata.config"), metadata, 0o644)
+}
+
+// unpackHexContentsTarGz unpacks the given `contents.tar.gz` from a downloaded
+// Hex package. Unlike most other archives, its entries are not nested in a
+// top-level directory.
+func unpackHexContentsTarGz(path string, workDir string) error {
+	r, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read contents archive file %q", path)
+	}
+	defer r.Close()
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	return unpack.Tgz(r, workDir, opts)
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackHexPackage(t *testing.T) {
+	contents := createTgz(t, []fileInfo{
+		{path: "mix.exs", contents: []byte("defmodule Jason.MixProject do end")},
+		{path: "lib/jason.ex", contents: []byte("defmodule Jason do end")},
+		{path: "../escape.ex", contents: []byte("filter me")},
+	})
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range []fileInfo{
+		{path: "VERSION", contents: []byte("3")},
+		{path: "CHECKSUM", contents: []byte("ABCDEF")},
+		{path: "metadata.config", contents: []byte(`{<<"name">>,<<"jason">>}.`)},
+		{path: "contents.tar.gz", contents: contents},
+	} {
+		require.NoError(t, addFileToTarball(t, tw, f))
+	}
+	require.NoError(t, tw.Close())
+
+	workDir := t.TempDir()
+	if err := unpackHexPackage(&buf, workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	got := listFiles(t, workDir)
+	want := []string{"/hex_metadata.config", "/lib/jason.ex", "/mix.exs"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+
+	metadata, err := os.ReadFile(filepath.Join(workDir, "hex_metadata.config"))
+	require.NoError(t, err)
+	require.Equal(t, `{<<"name">>,<<"jason">>}.`, string(metadata))
+}
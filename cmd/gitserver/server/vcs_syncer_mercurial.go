@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MercurialSyncer is a syncer for Mercurial repositories, which are mirrored
+// into Git with the git-remote-hg remote helper. Bookmarks are mirrored as
+// branches, named branches as branches prefixed with "branches/", and tags as
+// tags.
+type MercurialSyncer struct{}
+
+func (s *MercurialSyncer) Type() string {
+	return "mercurial"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *MercurialSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	args := []string{"ls-remote", mercurialRemote(remoteURL), "HEAD"}
+	ctx, cancel := context.WithTimeout(ctx, shortGitCommandTimeout(args))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	out, err := runWith(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), true, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = &GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(out))}
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Mercurial repository.
+func (s *MercurialSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (cmd *exec.Cmd, err error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(&GitCommandError{Err: err}, "clone setup failed")
+	}
+
+	cmd = s.fetchCommand(ctx, remoteURL)
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+// Fetch tries to fetch updates of a Mercurial repository. git-remote-hg keeps
+// its state in the Git directory, so only new changesets are converted.
+func (s *MercurialSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	cmd := s.fetchCommand(ctx, remoteURL)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), true, nil); err != nil {
+		return &GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing remote of a Mercurial repository.
+func (s *MercurialSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	return exec.CommandContext(ctx, "git", "remote", "show", mercurialRemote(remoteURL)), nil
+}
+
+func (s *MercurialSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
+	// Example: git fetch --progress --prune hg::https://hg.example.com/billing +refs/heads/*:refs/heads/* +refs/tags/*:refs/tags/*
+	return exec.CommandContext(ctx, "git", "fetch",
+		"--progress", "--prune", mercurialRemote(remoteURL),
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+}
+
+// mercurialRemote returns the Git remote that makes Git use git-remote-hg for
+// the given Mercurial URL.
+func mercurialRemote(remoteURL *vcs.URL) string {
+	return "hg::" + remoteURL.String()
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnpackNuGetPackage(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for _, f := range []fileInfo{
+		{path: "_rels/.rels", contents: []byte("filter me")},
+		{path: "[Content_Types].xml", contents: []byte("filter me")},
+		{path: ".signature.p7s", contents: []byte("filter me")},
+		{path: "package/services/metadata/core-properties/abc.psmdcp", contents: []byte("filter me")},
+		{path: "../escape.cs", contents: []byte("filter me")},
+		{path: "newtonsoft.json.nuspec", contents: []byte("<package/>")},
+		{path: "src/Newtonsoft.Json/JsonConvert.cs", contents: []byte("class JsonConvert {}")},
+		{path: "README.md", contents: []byte("# Json.NET")},
+	} {
+		fw, err := zw.Create(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(f.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reposDir := t.TempDir()
+	workDir := t.TempDir()
+	if err := unpackNuGetPackage(&zipBuf, reposDir, workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	if err := filepath.Walk(workDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, workDir))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	want := []string{"/README.md", "/newtonsoft.json.nuspec", "/src/Newtonsoft.Json/JsonConvert.cs"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
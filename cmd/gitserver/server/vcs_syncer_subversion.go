@@ -0,0 +1,297 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SubversionSyncer is a syncer for Subversion repositories, which are mirrored
+// into Git with git svn.
+type SubversionSyncer struct {
+	// StandardLayout indicates that repositories contain the standard trunk,
+	// branches and tags directories. Trunk is mirrored as the master branch,
+	// branches as Git branches and tags as Git tags. Otherwise the whole
+	// repository is mirrored as the master branch.
+	StandardLayout bool
+}
+
+func (s *SubversionSyncer) Type() string {
+	return "subversion"
+}
+
+// IsCloneable checks to see if the Subversion remote URL is cloneable.
+func (s *SubversionSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	username, password, u := decomposeSubversionRemoteURL(remoteURL)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	args := []string{"info", "--non-interactive", "--no-auth-cache"}
+	if username != "" {
+		args = append(args, "--username", username, "--password-from-stdin")
+	}
+	args = append(args, u)
+
+	cmd := exec.CommandContext(ctx, "svn", args...)
+	if username != "" {
+		cmd.Stdin = strings.NewReader(password + "\n")
+	}
+
+	out, err := runWith(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand initializes a Git repository tracking the Subversion repository
+// and fetches all of its revisions. The returned command is a no-op.
+func (s *SubversionSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(&GitCommandError{Err: err}, "clone setup failed")
+	}
+
+	_, _, u := decomposeSubversionRemoteURL(remoteURL)
+
+	// Example: git svn init --stdlayout --no-minimize-url --prefix=origin/ https://svn.example.com/svn/billing
+	args := []string{"svn", "init", "--no-minimize-url", "--prefix=" + subversionRefPrefix}
+	if s.StandardLayout {
+		args = append(args, "--stdlayout")
+	}
+	args = append(args, u)
+
+	cmd = exec.CommandContext(ctx, "git", args...)
+	GitDir(tmpPath).Set(cmd)
+	if output, err := runWith(ctx, wrexec.Wrap(ctx, nil, cmd), false, nil); err != nil {
+		return nil, errors.Wrapf(err, "git svn init failed with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+
+	if err := s.Fetch(ctx, remoteURL, GitDir(tmpPath), ""); err != nil {
+		return nil, err
+	}
+
+	// no-op command to satisfy VCSSyncer interface, the repository has already
+	// been fetched above.
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch fetches the revisions committed to the Subversion repository since the
+// last fetch, and updates the Git branches and tags to match.
+func (s *SubversionSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	username, password, u := decomposeSubversionRemoteURL(remoteURL)
+	redactor := newURLRedactor(remoteURL)
+
+	// The URL is stored in the repository config by git svn init. Keep it up to
+	// date in case the connection has been reconfigured.
+	cmd := exec.CommandContext(ctx, "git", "config", "svn-remote.svn.url", u)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, wrexec.Wrap(ctx, nil, cmd), false, nil); err != nil {
+		return errors.Wrapf(err, "failed to set Subversion URL with output %q", redactor.redact(string(output)))
+	}
+
+	args := []string{"svn", "fetch", "--no-auth-cache"}
+	env := os.Environ()
+	if username != "" {
+		args = append(args, "--username", username)
+
+		// git svn reads passwords from the program in GIT_ASKPASS. The password
+		// is passed through the environment so that it is never written to disk.
+		askpass, cleanup, err := writeSubversionAskpass()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		env = append(env, "GIT_ASKPASS="+askpass, subversionPasswordEnv+"="+password)
+	} else {
+		env = append(env, "GIT_ASKPASS=true") // disable password prompt
+	}
+
+	// Example: git svn fetch --no-auth-cache --username admin
+	cmd = exec.CommandContext(ctx, "git", args...)
+	cmd.Env = env
+	dir.Set(cmd)
+	if output, err := runWith(ctx, wrexec.Wrap(ctx, nil, cmd), false, nil); err != nil {
+		return errors.Wrapf(err, "failed to fetch with output %q", redactor.redact(string(output)))
+	}
+
+	return s.updateRefs(ctx, dir)
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote of a Subversion repository.
+func (s *SubversionSyncer) RemoteShowCommand(ctx context.Context, _ *vcs.URL) (cmd *exec.Cmd, err error) {
+	// There is no Git remote, HEAD defaults to the master branch.
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// updateRefs points the Git branches and tags at the refs maintained by git svn.
+func (s *SubversionSyncer) updateRefs(ctx context.Context, dir GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads/", "refs/tags/", "refs/remotes/"+subversionRefPrefix)
+	dir.Set(cmd)
+	output, err := runWith(ctx, wrexec.Wrap(ctx, nil, cmd), false, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list refs with output %q", string(output))
+	}
+
+	refs := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(output))
+	for sc.Scan() {
+		oid, ref, ok := strings.Cut(sc.Text(), " ")
+		if ok {
+			refs[ref] = oid
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	updates := subversionRefUpdates(refs, s.StandardLayout)
+	if updates == "" {
+		return nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+	cmd.Stdin = strings.NewReader(updates)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, wrexec.Wrap(ctx, nil, cmd), false, nil); err != nil {
+		return errors.Wrapf(err, "failed to update refs with output %q", string(output))
+	}
+	return nil
+}
+
+const (
+	// subversionRefPrefix is the prefix of the refs git svn fetches revisions
+	// into, below refs/remotes/.
+	subversionRefPrefix = "origin/"
+
+	// subversionPasswordEnv is the environment variable the askpass program
+	// reads the Subversion password from.
+	subversionPasswordEnv = "SRC_SUBVERSION_PASSWORD"
+)
+
+// subversionRefUpdates returns the input of git update-ref --stdin that maps the
+// refs maintained by git svn to Git branches and tags, given the object IDs of
+// the refs below refs/heads/, refs/tags/ and refs/remotes/origin/. Branches and
+// tags that do not correspond to a git svn ref are deleted.
+func subversionRefUpdates(refs map[string]string, standardLayout bool) string {
+	const remotePrefix = "refs/remotes/" + subversionRefPrefix
+
+	want := make(map[string]string)
+	for ref, oid := range refs {
+		if !strings.HasPrefix(ref, remotePrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(ref, remotePrefix)
+		// git svn creates refs such as "feature@1234" for the history of
+		// branches that were deleted and recreated. They are not branches.
+		if strings.Contains(name, "@") {
+			continue
+		}
+
+		if !standardLayout {
+			if name == "git-svn" {
+				want["refs/heads/master"] = oid
+			}
+			continue
+		}
+
+		switch {
+		case name == "trunk":
+			want["refs/heads/master"] = oid
+		case strings.HasPrefix(name, "tags/"):
+			want["refs/tags/"+strings.TrimPrefix(name, "tags/")] = oid
+		default:
+			// A branch named master would collide with trunk, which wins.
+			if name == "master" {
+				continue
+			}
+			want["refs/heads/"+name] = oid
+		}
+	}
+
+	var lines []string
+	for ref, oid := range want {
+		if refs[ref] != oid {
+			lines = append(lines, fmt.Sprintf("update %s %s", ref, oid))
+		}
+	}
+	for ref := range refs {
+		if strings.HasPrefix(ref, remotePrefix) {
+			continue
+		}
+		if _, ok := want[ref]; !ok {
+			lines = append(lines, "delete "+ref)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// writeSubversionAskpass writes a program that prints the password found in
+// the environment, to be used as GIT_ASKPASS. The returned function removes it.
+func writeSubversionAskpass() (path string, cleanup func(), err error) {
+	f, err := os.CreateTemp("", "svn-askpass-")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "creating askpass program")
+	}
+	cleanup = func() { _ = os.Remove(f.Name()) }
+
+	script := "#!/bin/sh\nprintf '%s\\n' \"$" + subversionPasswordEnv + "\"\n"
+	if _, err := f.WriteString(script); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, errors.Wrap(err, "writing askpass program")
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "writing askpass program")
+	}
+	if err := os.Chmod(f.Name(), 0o700); err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "writing askpass program")
+	}
+	return f.Name(), cleanup, nil
+}
+
+// decomposeSubversionRemoteURL splits the credentials off the clone URL of a
+// Subversion repository, which Subversion does not accept as part of URLs.
+func decomposeSubversionRemoteURL(remoteURL *vcs.URL) (username, password, u string) {
+	if remoteURL.User != nil {
+		username = remoteURL.User.Username()
+		password, _ = remoteURL.User.Password()
+	}
+	// svn+ssh URLs carry the SSH user, which is not a Subversion credential.
+	if remoteURL.Scheme == "svn+ssh" {
+		return "", "", remoteURL.String()
+	}
+	plain := remoteURL.URL
+	plain.User = nil
+	return username, password, plain.String()
+}
@@ -0,0 +1,131 @@
+package shared
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	defaultLFSMaxFileSize = 100 * 1024 * 1024
+	defaultLFSMaxRepoSize = 1024 * 1024 * 1024
+
+	// lfsOptionsTTL is how long the Git LFS options of an external service are
+	// cached, and thus how long it takes for config changes to take effect.
+	lfsOptionsTTL = time.Minute
+)
+
+// lfsOptionsCache caches the Git LFS options of each external service, so that
+// clones and fetches don't look up and decrypt the external service config
+// every time.
+type lfsOptionsCache struct {
+	logger log.Logger
+	store  database.ExternalServiceStore
+
+	mu      sync.Mutex
+	entries map[int64]lfsOptionsCacheEntry
+}
+
+type lfsOptionsCacheEntry struct {
+	opts    *server.LFSOptions
+	expires time.Time
+}
+
+func newLFSOptionsCache(logger log.Logger, store database.ExternalServiceStore) *lfsOptionsCache {
+	return &lfsOptionsCache{
+		logger:  logger.Scoped("lfsOptionsCache", "caches the Git LFS options of external services"),
+		store:   store,
+		entries: make(map[int64]lfsOptionsCacheEntry),
+	}
+}
+
+// get returns the options for fetching Git LFS objects of repos synced by the
+// given external service, or nil if fetching them isn't enabled. Git LFS
+// objects are an addition to a fetch, so if the options can't be determined,
+// the repo is fetched without them rather than failing the fetch.
+func (c *lfsOptionsCache) get(ctx context.Context, id int64) *server.LFSOptions {
+	now := time.Now()
+
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.opts
+	}
+
+	opts, err := c.load(ctx, id)
+	if err != nil {
+		c.logger.Warn("failed to get Git LFS options, fetching without Git LFS objects", log.Int64("externalServiceID", id), log.Error(err))
+		return nil
+	}
+
+	c.mu.Lock()
+	c.entries[id] = lfsOptionsCacheEntry{opts: opts, expires: now.Add(lfsOptionsTTL)}
+	c.mu.Unlock()
+	return opts
+}
+
+func (c *lfsOptionsCache) load(ctx context.Context, id int64) (*server.LFSOptions, error) {
+	extSvc, err := c.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get external service")
+	}
+	rawConfig, err := extSvc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := jsonc.Parse(rawConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "normalize JSON")
+	}
+	// The gitLFS setting has the same shape for all code hosts that support it.
+	var config struct {
+		GitLFS *schema.GitLFS `json:"gitLFS"`
+	}
+	if err := jsoniter.Unmarshal(normalized, &config); err != nil {
+		return nil, errors.Wrap(err, "unmarshal JSON")
+	}
+	return lfsOptions(config.GitLFS), nil
+}
+
+// lfsOptions returns the options for fetching Git LFS objects, or nil if
+// fetching them isn't enabled.
+func lfsOptions(c *schema.GitLFS) *server.LFSOptions {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	opts := &server.LFSOptions{
+		MaxFileSize: int64(c.MaxFileSize),
+		MaxRepoSize: int64(c.MaxRepoSize),
+	}
+	if opts.MaxFileSize == 0 {
+		opts.MaxFileSize = defaultLFSMaxFileSize
+	}
+	if opts.MaxRepoSize == 0 {
+		opts.MaxRepoSize = defaultLFSMaxRepoSize
+	}
+	return opts
+}
+
+// externalServiceIDs returns the IDs of the external services that the repo is
+// synced from in ascending order, so that repos with several sources always use
+// the config of the same external service.
+func externalServiceIDs(r *types.Repo) []int64 {
+	ids := make([]int64, 0, len(r.Sources))
+	for _, info := range r.Sources {
+		ids = append(ids, info.ExternalServiceID())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit/dbsink"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -35,7 +36,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/crates"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hex"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npm"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pypi"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
 	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
@@ -118,6 +122,10 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	}
 	db := database.NewDB(observationCtx.Logger, sqlDB)
 
+	// Persist the audit log of gitserver accesses alongside the rest of the audit log.
+	auditLogSink := dbsink.Register(logger, db)
+	go auditLogSink.Start()
+
 	repoStore := db.Repos()
 	dependenciesSvc := dependencies.NewService(observationCtx, db)
 	externalServiceStore := db.ExternalServices()
@@ -135,6 +143,8 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		return errors.Wrap(err, "creating sub-repo client")
 	}
 
+	lfsCache := newLFSOptionsCache(logger, externalServiceStore)
+
 	gitserver := server.Server{
 		Logger:             logger,
 		ObservationCtx:     observationCtx,
@@ -144,7 +154,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 			return getRemoteURLFunc(ctx, externalServiceStore, repoStore, nil, repo)
 		},
 		GetVCSSyncer: func(ctx context.Context, repo api.RepoName) (server.VCSSyncer, error) {
-			return getVCSSyncer(ctx, externalServiceStore, repoStore, dependenciesSvc, repo, config.ReposDir, config.CoursierCacheDir)
+			return getVCSSyncer(ctx, externalServiceStore, repoStore, lfsCache, dependenciesSvc, repo, config.ReposDir, config.CoursierCacheDir)
 		},
 		Hostname:                externalAddress(),
 		DB:                      db,
@@ -233,6 +243,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	// The most important thing this does is kill all our clones. If we just
 	// shutdown they will be orphaned and continue running.
 	gitserver.Stop()
+	auditLogSink.Stop()
 
 	return nil
 }
@@ -425,6 +436,7 @@ func getVCSSyncer(
 	ctx context.Context,
 	externalServiceStore database.ExternalServiceStore,
 	repoStore database.RepoStore,
+	lfsCache *lfsOptionsCache,
 	depsSvc *dependencies.Service,
 	repo api.RepoName,
 	reposDir string,
@@ -439,8 +451,8 @@ func getVCSSyncer(
 	}
 
 	extractOptions := func(connection any) (string, error) {
-		for _, info := range r.Sources {
-			extSvc, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
+		for _, id := range externalServiceIDs(r) {
+			extSvc, err := externalServiceStore.GetByID(ctx, id)
 			if err != nil {
 				return "", errors.Wrap(err, "get external service")
 			}
@@ -479,6 +491,16 @@ func getVCSSyncer(
 			FusionConfig: configureFusionClient(c),
 			P4Home:       p4Home,
 		}, nil
+	case extsvc.TypeSubversion:
+		var c schema.SubversionConnection
+		if _, err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		return &server.SubversionSyncer{
+			StandardLayout: c.Layout != "none",
+		}, nil
+	case extsvc.TypeMercurial:
+		return &server.MercurialSyncer{}, nil
 	case extsvc.TypeJVMPackages:
 		var c schema.JVMPackagesConnection
 		if _, err := extractOptions(&c); err != nil {
@@ -537,6 +559,45 @@ func getVCSSyncer(
 			return nil, err
 		}
 		return server.NewRubyPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeNuGetPackages:
+		var c schema.NuGetPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		cli, err := nuget.NewClient(urn, c.Repository, c.Credentials, httpcli.ExternalClientFactory)
+		if err != nil {
+			return nil, err
+		}
+		return server.NewNuGetPackagesSyncer(&c, depsSvc, cli, reposDir), nil
+	case extsvc.TypeComposerPackages:
+		var c schema.ComposerPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		cli, err := packagist.NewClient(urn, c.Repository, httpcli.ExternalClientFactory)
+		if err != nil {
+			return nil, err
+		}
+		return server.NewComposerPackagesSyncer(&c, depsSvc, cli, reposDir), nil
+	case extsvc.TypeHexPackages:
+		var c schema.HexPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		cli, err := hex.NewClient(urn, c.Repository, httpcli.ExternalClientFactory)
+		if err != nil {
+			return nil, err
+		}
+		return server.NewHexPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeGitHub, extsvc.TypeGitLab, extsvc.TypeOther:
+		var lfs *server.LFSOptions
+		if ids := externalServiceIDs(r); len(ids) > 0 {
+			lfs = lfsCache.get(ctx, ids[0])
+		}
+		return &server.GitRepoSyncer{LFS: lfs}, nil
 	}
 	return &server.GitRepoSyncer{}, nil
 }
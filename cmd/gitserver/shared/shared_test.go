@@ -183,7 +183,9 @@ func TestGetVCSSyncer(t *testing.T) {
 		}, nil
 	})
 
-	s, err := getVCSSyncer(context.Background(), extsvcStore, repoStore, depsSvc, repo, tempReposDir, tempCoursierCacheDir)
+	lfsCache := newLFSOptionsCache(logtest.Scoped(t), extsvcStore)
+
+	s, err := getVCSSyncer(context.Background(), extsvcStore, repoStore, lfsCache, depsSvc, repo, tempReposDir, tempCoursierCacheDir)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -193,3 +195,106 @@ func TestGetVCSSyncer(t *testing.T) {
 		t.Fatalf("Want *server.PerforceDepotSyncer, got %T", s)
 	}
 }
+
+func TestGetVCSSyncer_GitLFS(t *testing.T) {
+	repoStore := database.NewMockRepoStore()
+	repoStore.GetByNameFunc.SetDefaultHook(func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{
+			ExternalRepo: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeGitHub,
+			},
+			Sources: map[string]*types.SourceInfo{
+				"extsvc:github:2": {
+					ID:       "extsvc:github:2",
+					CloneURL: "https://github.com/foo/bar",
+				},
+				"extsvc:github:1": {
+					ID:       "extsvc:github:1",
+					CloneURL: "https://github.com/foo/bar",
+				},
+			},
+		}, nil
+	})
+
+	for _, tc := range []struct {
+		name   string
+		config string
+		err    error
+		// failing is true if the options can't be determined.
+		failing bool
+		want    *server.LFSOptions
+	}{
+		{
+			name:   "not configured",
+			config: `{}`,
+		},
+		{
+			name:   "disabled",
+			config: `{"gitLFS": {"enabled": false}}`,
+		},
+		{
+			name:   "default limits",
+			config: `{"gitLFS": {"enabled": true}}`,
+			want:   &server.LFSOptions{MaxFileSize: defaultLFSMaxFileSize, MaxRepoSize: defaultLFSMaxRepoSize},
+		},
+		{
+			name:   "custom limits",
+			config: `{"gitLFS": {"enabled": true, "maxFileSize": 10, "maxRepoSize": 20}}`,
+			want:   &server.LFSOptions{MaxFileSize: 10, MaxRepoSize: 20},
+		},
+		{
+			name:    "invalid config",
+			config:  `{"gitLFS": true}`,
+			failing: true,
+		},
+		{
+			name:    "lookup error",
+			err:     errors.New("boom"),
+			failing: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			extsvcStore := database.NewMockExternalServiceStore()
+			extsvcStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.ExternalService, error) {
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				// The repo is synced from two external services, and the one
+				// with the lowest ID is used.
+				config := tc.config
+				if id != 1 {
+					config = `{"gitLFS": {"enabled": true, "maxFileSize": 1, "maxRepoSize": 2}}`
+				}
+				return &types.ExternalService{
+					ID:          id,
+					Kind:        extsvc.KindGitHub,
+					DisplayName: "test",
+					Config:      extsvc.NewUnencryptedConfig(config),
+				}, nil
+			})
+			lfsCache := newLFSOptionsCache(logtest.Scoped(t), extsvcStore)
+
+			// Failing to determine the Git LFS options doesn't fail the fetch,
+			// and the options are looked up once per external service.
+			for i := 0; i < 2; i++ {
+				s, err := getVCSSyncer(context.Background(), extsvcStore, repoStore, lfsCache, nil, "github.com/foo/bar", t.TempDir(), "")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				gs, ok := s.(*server.GitRepoSyncer)
+				if !ok {
+					t.Fatalf("Want *server.GitRepoSyncer, got %T", s)
+				}
+				assert.Equal(t, tc.want, gs.LFS)
+			}
+
+			wantLookups := 1
+			if tc.failing {
+				// Errors aren't cached.
+				wantLookups = 2
+			}
+			assert.Len(t, extsvcStore.GetByIDFunc.History(), wantLookups)
+		})
+	}
+}
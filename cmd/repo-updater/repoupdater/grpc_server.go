@@ -24,8 +24,11 @@ type RepoUpdaterServiceServer struct {
 	proto.UnimplementedRepoUpdaterServiceServer
 }
 
-func (s *RepoUpdaterServiceServer) RepoUpdateSchedulerInfo(_ context.Context, req *proto.RepoUpdateSchedulerInfoRequest) (*proto.RepoUpdateSchedulerInfoResponse, error) {
-	res := s.Server.Scheduler.ScheduleInfo(api.RepoID(req.GetId()))
+func (s *RepoUpdaterServiceServer) RepoUpdateSchedulerInfo(ctx context.Context, req *proto.RepoUpdateSchedulerInfoRequest) (*proto.RepoUpdateSchedulerInfoResponse, error) {
+	res, err := s.Server.Scheduler.ScheduleInfo(ctx, api.RepoID(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
 	return res.ToProto(), nil
 }
 
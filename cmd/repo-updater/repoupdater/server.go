@@ -38,8 +38,8 @@ type Server struct {
 	ObservationCtx        *observation.Context
 	SourcegraphDotComMode bool
 	Scheduler             interface {
-		UpdateOnce(id api.RepoID, name api.RepoName)
-		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
+		UpdateOnce(ctx context.Context, id api.RepoID, name api.RepoName) error
+		ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)
 	}
 	ChangesetSyncRegistry batches.ChangesetSyncRegistry
 	RateLimitSyncer       interface {
@@ -72,7 +72,11 @@ func (s *Server) handleRepoUpdateSchedulerInfo(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	result := s.Scheduler.ScheduleInfo(args.ID)
+	result, err := s.Scheduler.ScheduleInfo(r.Context(), args.ID)
+	if err != nil {
+		s.respond(w, http.StatusInternalServerError, err)
+		return
+	}
 	s.respond(w, http.StatusOK, result)
 }
 
@@ -142,7 +146,9 @@ func (s *Server) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdate
 
 	repo := rs[0]
 
-	s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+	if err := s.Scheduler.UpdateOnce(ctx, repo.ID, repo.Name); err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "scheduler.update-once")
+	}
 
 	return &protocol.RepoUpdateResponse{
 		ID:   repo.ID,
@@ -336,7 +342,9 @@ func (s *Server) repoLookup(ctx context.Context, args protocol.RepoLookupArgs) (
 
 	if s.Scheduler != nil && args.Update {
 		// Enqueue a high priority update for this repo.
-		s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+		if err := s.Scheduler.UpdateOnce(ctx, repo.ID, repo.Name); err != nil {
+			s.Logger.Warn("failed to enqueue repo update", log.Error(err), log.String("repo", string(repo.Name)))
+		}
 	}
 
 	repoInfo := protocol.NewRepoInfo(repo)
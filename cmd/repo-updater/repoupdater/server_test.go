@@ -660,7 +660,7 @@ func TestServer_RepoLookup(t *testing.T) {
 				ObsvCtx: observation.TestContextTB(t),
 			}
 
-			scheduler := repos.NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+			scheduler := repos.NewUpdateScheduler(observation.TestContextTB(t), database.NewDB(logger, db))
 
 			s := &Server{
 				Logger:    logger,
@@ -691,7 +691,10 @@ func TestServer_RepoLookup(t *testing.T) {
 			}
 
 			if tc.args.Update {
-				scheduleInfo := scheduler.ScheduleInfo(res.Repo.ID)
+				scheduleInfo, err := scheduler.ScheduleInfo(ctx, res.Repo.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
 				if have, want := scheduleInfo.Queue.Priority, 1; have != want { // highPriority
 					t.Fatalf("scheduler update priority mismatch: have %d, want %d", have, want)
 				}
@@ -713,9 +716,11 @@ func TestServer_RepoLookup(t *testing.T) {
 
 type fakeScheduler struct{}
 
-func (s *fakeScheduler) UpdateOnce(_ api.RepoID, _ api.RepoName) {}
-func (s *fakeScheduler) ScheduleInfo(_ api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
-	return &protocol.RepoUpdateSchedulerInfoResult{}
+func (s *fakeScheduler) UpdateOnce(_ context.Context, _ api.RepoID, _ api.RepoName) error {
+	return nil
+}
+func (s *fakeScheduler) ScheduleInfo(_ context.Context, _ api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+	return &protocol.RepoUpdateSchedulerInfoResult{}, nil
 }
 
 func TestServer_handleExternalServiceValidate(t *testing.T) {
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit/dbsink"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/batches"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
@@ -99,6 +100,10 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	}
 	db := database.NewDB(logger, sqlDB)
 
+	// Persist the audit log entries recorded by repo-updater, such as the security
+	// events of permissions syncs. Entries are buffered until the sink is started below.
+	auditLogSink := dbsink.Register(logger, db)
+
 	// Generally we'll mark the service as ready sometime after the database has been
 	// connected; migrations may take a while and we don't want to start accepting
 	// traffic until we've fully constructed the server we'll be exposing. We have a
@@ -127,7 +132,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		src = repos.NewSourcer(sourcerLogger, db, cf, repos.WithDependenciesService(dependencies.NewService(observationCtx, db)), repos.ObservedSource(sourcerLogger, m))
 	}
 
-	updateScheduler := repos.NewUpdateScheduler(logger, db)
+	updateScheduler := repos.NewUpdateScheduler(observationCtx, db)
 	server := &repoupdater.Server{
 		Logger:                logger,
 		ObservationCtx:        observationCtx,
@@ -247,7 +252,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		Handler: instrumentation.HTTPMiddleware("",
 			trace.HTTPMiddleware(logger, authzBypass(handler), conf.DefaultClient())),
 	})
-	goroutine.MonitorBackgroundRoutines(ctx, httpSrv)
+	goroutine.MonitorBackgroundRoutines(ctx, httpSrv, auditLogSink)
 
 	return nil
 }
@@ -470,7 +475,7 @@ func watchSyncer(
 			return
 		case diff := <-syncer.Synced:
 			if !conf.Get().DisableAutoGitUpdates {
-				sched.UpdateFromDiff(diff)
+				sched.UpdateFromDiff(ctx, diff)
 			}
 
 			// Similarly, changesetSyncer is only available in enterprise mode.
@@ -511,12 +516,19 @@ func manageUnclonedRepos(ctx context.Context, logger log.Logger, sched *repos.Up
 				return
 			}
 			// Ensure that uncloned indexable repos are known to the scheduler
-			sched.EnsureScheduled(indexable)
+			if err := sched.EnsureScheduled(ctx, indexable); err != nil {
+				logger.Error("ensuring indexable repos are scheduled", log.Error(err))
+				return
+			}
 		}
 
 		// Next, move any repos managed by the scheduler that are uncloned to the front
 		// of the queue
-		managed := sched.ListRepoIDs()
+		managed, err := sched.ListRepoIDs(ctx)
+		if err != nil {
+			logger.Warn("failed to list repositories managed by the scheduler", log.Error(err))
+			return
+		}
 
 		uncloned, err := baseRepoStore.ListMinimalRepos(ctx, database.ReposListOptions{IDs: managed, NoCloned: true})
 		if err != nil {
@@ -524,7 +536,9 @@ func manageUnclonedRepos(ctx context.Context, logger log.Logger, sched *repos.Up
 			return
 		}
 
-		sched.PrioritiseUncloned(uncloned)
+		if err := sched.PrioritiseUncloned(ctx, uncloned); err != nil {
+			logger.Warn("failed to prioritise uncloned repositories", log.Error(err))
+		}
 	}
 
 	for ctx.Err() == nil {
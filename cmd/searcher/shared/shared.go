@@ -47,6 +47,8 @@ var (
 	backgroundTimeout = env.MustGetDuration("PROCESSING_TIMEOUT", 2*time.Hour, "maximum time to spend processing a repository")
 
 	maxTotalPathsLengthRaw = env.Get("MAX_TOTAL_PATHS_LENGTH", "100000", "maximum sum of lengths of all paths in a single call to git archive")
+
+	searchLFSFiles = env.MustGetBool("SEARCHER_SEARCH_LFS_FILES", false, "search the content of files tracked by Git LFS instead of their pointer files, if gitserver fetched their LFS objects")
 )
 
 const port = "3181"
@@ -135,8 +137,9 @@ func Start(ctx context.Context, observationCtx *observation.Context, ready servi
 				// searcher needs access to all data in the archive.
 				ctx = actor.WithInternalActor(ctx)
 				return git.ArchiveReader(ctx, nil, repo, gitserver.ArchiveOptions{
-					Treeish: string(commit),
-					Format:  gitserver.ArchiveFormatTar,
+					Treeish:    string(commit),
+					Format:     gitserver.ArchiveFormatTar,
+					ResolveLFS: searchLFSFiles,
 				})
 			},
 			FetchTarPaths: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
@@ -148,9 +151,10 @@ func Start(ctx context.Context, observationCtx *observation.Context, ready servi
 				// searcher needs access to all data in the archive.
 				ctx = actor.WithInternalActor(ctx)
 				return git.ArchiveReader(ctx, nil, repo, gitserver.ArchiveOptions{
-					Treeish:   string(commit),
-					Format:    gitserver.ArchiveFormatTar,
-					Pathspecs: pathspecs,
+					Treeish:    string(commit),
+					Format:     gitserver.ArchiveFormatTar,
+					Pathspecs:  pathspecs,
+					ResolveLFS: searchLFSFiles,
 				})
 			},
 			FilterTar:         search.NewFilter,
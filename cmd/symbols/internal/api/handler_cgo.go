@@ -23,6 +23,7 @@ func addHandlers(
 ) {
 	mux.HandleFunc("/localCodeIntel", squirrel.LocalCodeIntelHandler(readFileFunc))
 	mux.HandleFunc("/symbolInfo", squirrel.NewSymbolInfoHandler(searchFunc, readFileFunc))
+	mux.HandleFunc("/references", squirrel.NewReferencesHandler(searchFunc, readFileFunc))
 }
 
 // LocalCodeIntel returns local code intelligence for the given file and commit
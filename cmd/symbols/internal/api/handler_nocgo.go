@@ -27,6 +27,7 @@ func addHandlers(
 
 	mux.HandleFunc("/localCodeIntel", jsonResponseHandler(internaltypes.LocalCodeIntelPayload{Symbols: []internaltypes.Symbol{}}))
 	mux.HandleFunc("/symbolInfo", jsonResponseHandler(internaltypes.SymbolInfo{}))
+	mux.HandleFunc("/references", jsonResponseHandler([]internaltypes.RepoCommitPathRange{}))
 }
 
 func jsonResponseHandler(v any) http.HandlerFunc {
@@ -125,3 +125,64 @@ func NewSymbolInfoHandler(symbolSearch symbolsTypes.SearchFunc, readFile readFil
 		}
 	}
 }
+
+// NewReferencesHandler responds to /references
+func NewReferencesHandler(symbolSearch symbolsTypes.SearchFunc, readFile readFileFunc) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Read the args from the request body.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log15.Error("failed to read request body", "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var args types.ReferencesArgs
+		if err := json.NewDecoder(bytes.NewReader(body)).Decode(&args); err != nil {
+			log15.Error("failed to decode request body", "err", err, "body", string(body))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(args.CandidatePaths) > types.MaxReferencesCandidatePaths {
+			http.Error(w, fmt.Sprintf("too many candidate paths: %d, the maximum is %d", len(args.CandidatePaths), types.MaxReferencesCandidatePaths), http.StatusBadRequest)
+			return
+		}
+
+		// Find the references.
+		squirrel := New(readFile, symbolSearch)
+		defer squirrel.Close()
+		result, err := squirrel.References(r.Context(), args)
+		if os.Getenv("SQUIRREL_DEBUG") == "true" {
+			debugStringBuilder := &strings.Builder{}
+			fmt.Fprintln(debugStringBuilder, "👉 /references repo:", args.Repo, "commit:", args.Commit, "path:", args.Path, "row:", args.Row, "column:", args.Column, "candidates:", len(args.CandidatePaths))
+			squirrel.breadcrumbs.pretty(debugStringBuilder, readFile)
+			if len(result) == 0 {
+				fmt.Fprintln(debugStringBuilder, "❌ no references found")
+			} else {
+				fmt.Fprintln(debugStringBuilder, "✅ /references", len(result), "references")
+			}
+
+			fmt.Println(" ")
+			fmt.Println(bracket(debugStringBuilder.String()))
+			fmt.Println(" ")
+		}
+		if err != nil {
+			_ = json.NewEncoder(w).Encode(nil)
+
+			// Log the error if it's not an unrecognized file extension or unsupported language error.
+			if !errors.Is(err, unrecognizedFileExtensionError) && !errors.Is(err, UnsupportedLanguageError) {
+				log15.Error("failed to get references", "err", err)
+			}
+
+			return
+		}
+
+		// Write the response.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(result)
+		if err != nil {
+			log15.Error("failed to write response: %s", "error", err)
+			http.Error(w, fmt.Sprintf("failed to get references: %s", err), http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/smacker/go-tree-sitter/javascript"
 	"github.com/smacker/go-tree-sitter/python"
 	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
 	"github.com/smacker/go-tree-sitter/typescript/tsx"
 )
 
@@ -277,6 +278,37 @@ var langToLangSpec = map[string]LangSpec{
 (assignment           left: (identifier) @definition)    ; x = ...
 (left_assignment_list (identifier) @definition)          ; x, y = ...
 (for                  pattern: (identifier) @definition) ; for i in 1..5 ...
+`,
+	},
+	"rust": {
+		name:     "rust",
+		language: rust.GetLanguage(),
+		commentStyle: CommentStyle{
+			nodeTypes:     []string{"line_comment", "block_comment"},
+			stripRegex:    regexp.MustCompile(`^//[/!]?|^\s*\*/?|^/\*[*!]?|\*/$`),
+			ignoreRegex:   javaStyleIgnoreRegex,
+			codeFenceName: "rust",
+		},
+		localsQuery: `
+(function_item)        @scope ; fn f() { ... }
+(closure_expression)   @scope ; |x| ...
+(block)                @scope ; { ... }
+(for_expression)       @scope ; for x in xs { ... }
+(if_let_expression)    @scope ; if let Some(x) = y { ... }
+(while_let_expression) @scope ; while let Some(x) = y { ... }
+(match_arm)            @scope ; Some(x) => ...
+
+(let_declaration      pattern: (identifier) @definition)                                              ; let x = ...
+(let_declaration      pattern: (mut_pattern (identifier) @definition))                                ; let mut x = ...
+(let_declaration      pattern: (tuple_pattern (identifier) @definition))                              ; let (x, y) = ...
+(parameter            pattern: (identifier) @definition)                                              ; fn f(x: i32) { ... }
+(parameter            pattern: (mut_pattern (identifier) @definition))                                ; fn f(mut x: i32) { ... }
+(closure_parameters   (identifier) @definition)                                                       ; |x| ...
+(for_expression       pattern: (identifier) @definition)                                              ; for x in xs { ... }
+(for_expression       pattern: (tuple_pattern (identifier) @definition))                              ; for (i, x) in xs { ... }
+(if_let_expression    pattern: (tuple_struct_pattern type: (_) (identifier) @definition))             ; if let Some(x) = y { ... }
+(while_let_expression pattern: (tuple_struct_pattern type: (_) (identifier) @definition))             ; while let Some(x) = y { ... }
+(match_arm            pattern: (match_pattern (tuple_struct_pattern type: (_) (identifier) @definition))) ; Some(x) => ...
 `,
 	},
 	"starlark": {
@@ -333,6 +333,81 @@ def f(p1, p2 = 3, *p3, **p4)
 		puts e
 	end
 end
+`}, {
+		path: "test.rs",
+		contents: `
+//   vv f.p1 def
+//   vv f.p1 ref
+//                vv f.p2 def
+//                vv f.p2 ref
+fn f(p1: i32, mut p2: i32) {
+    //  v f.x def
+    //  v f.x ref
+    //      vv f.p1 ref
+    let x = p1;
+
+    //      v f.y def
+    //      v f.y ref
+    //          vv f.p2 ref
+    let mut y = p2;
+
+    //   v f.a def
+    //   v f.a ref
+    //      v f.b def
+    //      v f.b ref
+    //            v f.x ref
+    //               v f.y ref
+    let (a, b) = (x, y);
+
+    //  v f.g def
+    //  v f.g ref
+    //       v f.c def
+    //       v f.c ref
+    //          v f.c ref
+    //              v f.a ref
+    //                  v f.b ref
+    let g = |c| c + a + b;
+
+    //  v f.i def
+    //  v f.i ref
+    //          v f.g ref
+    for i in 0..g(1) {
+        //             v f.i ref
+        println!("{}", i);
+    }
+
+    //   v f.j def
+    //   v f.j ref
+    //      v f.k def
+    //      v f.k ref
+    for (j, k) in pairs {
+        //                v f.j ref
+        //                   v f.k ref
+        println!("{} {}", j, k);
+    }
+
+    //          v f.s def
+    //          v f.s ref
+    if let Some(s) = opt {
+        //             v f.s ref
+        println!("{}", s);
+    }
+
+    //             v f.w def
+    //             v f.w ref
+    while let Some(w) = opt {
+        //             v f.w ref
+        println!("{}", w);
+    }
+
+    match opt {
+        //   v f.m def
+        //   v f.m ref
+        //                        v f.m ref
+        Some(m) => println!("{}", m),
+        None => {}
+    }
+}
 `},
 	}
 
@@ -0,0 +1,150 @@
+package squirrel
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// References finds the references to the symbol at the given point, including its definition.
+//
+// Symbols that are local to the file (e.g. parameters and local variables) are resolved with the
+// scopes of the language's locals query, so their references never leave the file. For all other
+// symbols, the definition is found first, and then every identifier with the same name in the file
+// and in the candidate paths that resolves to that same definition is a reference.
+func (s *SquirrelService) References(ctx context.Context, args types.ReferencesArgs) ([]types.RepoCommitPathRange, error) {
+	// Parse the file and find the starting node.
+	root, err := s.parse(ctx, args.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	startNode := root.NamedDescendantForPointRange(
+		sitter.Point{Row: uint32(args.Row), Column: uint32(args.Column)},
+		sitter.Point{Row: uint32(args.Row), Column: uint32(args.Column)},
+	)
+	if startNode == nil {
+		return nil, errors.New("node is nil")
+	}
+
+	// Check if it's a local symbol first.
+	payload, err := s.LocalCodeIntel(ctx, args.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	if symbol := findLocalSymbol(payload, args.Point); symbol != nil {
+		return localReferences(args.RepoCommitPath, *symbol), nil
+	}
+
+	// Otherwise find the definition.
+	def, err := s.getDef(ctx, swapNode(*root, startNode))
+	if err != nil {
+		return nil, err
+	}
+	if def == nil {
+		return nil, nil
+	}
+
+	name := startNode.Content(root.Contents)
+	defRange := types.RepoCommitPathRange{RepoCommitPath: def.RepoCommitPath, Range: nodeToRange(def.Node)}
+
+	refs := []types.RepoCommitPathRange{defRange}
+	seen := map[types.RepoCommitPathRange]struct{}{defRange: {}}
+
+	seenPaths := map[string]struct{}{}
+	for _, path := range append([]string{args.Path}, args.CandidatePaths...) {
+		if _, ok := seenPaths[path]; ok {
+			continue
+		}
+		seenPaths[path] = struct{}{}
+
+		repoCommitPath := types.RepoCommitPath{Repo: args.Repo, Commit: args.Commit, Path: path}
+		fileRefs, err := s.referencesInFile(ctx, repoCommitPath, name, defRange)
+		if err != nil {
+			// Candidates are typically text search results, which may be in any language.
+			if errors.Is(err, unrecognizedFileExtensionError) || errors.Is(err, UnsupportedLanguageError) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, ref := range fileRefs {
+			if _, ok := seen[ref]; ok {
+				continue
+			}
+			seen[ref] = struct{}{}
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs, nil
+}
+
+// referencesInFile returns the identifiers with the given name in the given file that resolve to
+// the given definition.
+func (s *SquirrelService) referencesInFile(ctx context.Context, repoCommitPath types.RepoCommitPath, name string, def types.RepoCommitPathRange) ([]types.RepoCommitPathRange, error) {
+	root, err := s.parse(ctx, repoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect identifiers with the same name by walking the entire tree.
+	candidates := []*sitter.Node{}
+	walk(root.Node, func(node *sitter.Node) {
+		if strings.Contains(node.Type(), "identifier") && node.Content(root.Contents) == name {
+			candidates = append(candidates, node)
+		}
+	})
+
+	refs := []types.RepoCommitPathRange{}
+	for _, candidate := range candidates {
+		found, err := s.getDef(ctx, swapNode(*root, candidate))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil || found.RepoCommitPath != def.RepoCommitPath || nodeToRange(found.Node) != def.Range {
+			continue
+		}
+
+		refs = append(refs, types.RepoCommitPathRange{RepoCommitPath: repoCommitPath, Range: nodeToRange(candidate)})
+	}
+
+	return refs, nil
+}
+
+// findLocalSymbol returns the local symbol defined or referenced at the given point, if any.
+func findLocalSymbol(payload *types.LocalCodeIntelPayload, point types.Point) *types.Symbol {
+	contains := func(r types.Range) bool {
+		return r.Row == point.Row && r.Column <= point.Column && point.Column < r.Column+r.Length
+	}
+
+	for i, symbol := range payload.Symbols {
+		if contains(symbol.Def) {
+			return &payload.Symbols[i]
+		}
+		for _, ref := range symbol.Refs {
+			if contains(ref) {
+				return &payload.Symbols[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// localReferences returns the definition and references of a local symbol.
+func localReferences(repoCommitPath types.RepoCommitPath, symbol types.Symbol) []types.RepoCommitPathRange {
+	rest := []types.RepoCommitPathRange{}
+	for _, ref := range symbol.Refs {
+		if ref != symbol.Def {
+			rest = append(rest, types.RepoCommitPathRange{RepoCommitPath: repoCommitPath, Range: ref})
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool { return isLessRange(rest[i].Range, rest[j].Range) })
+
+	return append([]types.RepoCommitPathRange{{RepoCommitPath: repoCommitPath, Range: symbol.Def}}, rest...)
+}
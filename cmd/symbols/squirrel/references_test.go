@@ -0,0 +1,155 @@
+package squirrel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestReferences(t *testing.T) {
+	contents := `
+class Foo {
+    int f;
+
+    void m1(int p) {
+        f = p;
+    }
+
+    void m2() {
+        f = 2;
+    }
+}
+`
+
+	readFile := func(ctx context.Context, path types.RepoCommitPath) ([]byte, error) {
+		return []byte(contents), nil
+	}
+
+	squirrel := New(readFile, nil)
+	defer squirrel.Close()
+
+	path := types.RepoCommitPath{Repo: "foo", Commit: "bar", Path: "Foo.java"}
+	rng := func(row, column, length int) types.RepoCommitPathRange {
+		return types.RepoCommitPathRange{
+			RepoCommitPath: path,
+			Range:          types.Range{Row: row, Column: column, Length: length},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		point types.Point
+		want  []types.RepoCommitPathRange
+	}{
+		{
+			name:  "local",
+			point: types.Point{Row: 5, Column: 12},
+			want:  []types.RepoCommitPathRange{rng(4, 16, 1), rng(5, 12, 1)},
+		},
+		{
+			name:  "field",
+			point: types.Point{Row: 9, Column: 8},
+			want:  []types.RepoCommitPathRange{rng(2, 8, 1), rng(5, 8, 1), rng(9, 8, 1)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := squirrel.References(context.Background(), types.ReferencesArgs{
+				RepoCommitPathPoint: types.RepoCommitPathPoint{RepoCommitPath: path, Point: test.point},
+				// Candidates in unsupported languages are skipped.
+				CandidatePaths: []string{"Foo.java", "README.md"},
+			})
+			fatalIfError(t, err)
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("unexpected references (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReferencesRust(t *testing.T) {
+	contents := `
+fn f(p1: i32) -> i32 {
+    let x = p1;
+    let y = x + 1;
+    x + y
+}
+`
+
+	readFile := func(ctx context.Context, path types.RepoCommitPath) ([]byte, error) {
+		return []byte(contents), nil
+	}
+
+	squirrel := New(readFile, nil)
+	defer squirrel.Close()
+
+	path := types.RepoCommitPath{Repo: "foo", Commit: "bar", Path: "lib.rs"}
+	rng := func(row, column, length int) types.RepoCommitPathRange {
+		return types.RepoCommitPathRange{
+			RepoCommitPath: path,
+			Range:          types.Range{Row: row, Column: column, Length: length},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		point types.Point
+		want  []types.RepoCommitPathRange
+	}{
+		{
+			name:  "parameter",
+			point: types.Point{Row: 2, Column: 12},
+			want:  []types.RepoCommitPathRange{rng(1, 5, 2), rng(2, 12, 2)},
+		},
+		{
+			name:  "let binding",
+			point: types.Point{Row: 4, Column: 4},
+			want:  []types.RepoCommitPathRange{rng(2, 8, 1), rng(3, 12, 1), rng(4, 4, 1)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := squirrel.References(context.Background(), types.ReferencesArgs{
+				RepoCommitPathPoint: types.RepoCommitPathPoint{RepoCommitPath: path, Point: test.point},
+			})
+			fatalIfError(t, err)
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("unexpected references (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReferencesHandlerTooManyCandidatePaths(t *testing.T) {
+	readFile := func(ctx context.Context, path types.RepoCommitPath) ([]byte, error) {
+		t.Fatalf("unexpected read of %s", path.Path)
+		return nil, nil
+	}
+
+	args := types.ReferencesArgs{
+		RepoCommitPathPoint: types.RepoCommitPathPoint{
+			RepoCommitPath: types.RepoCommitPath{Repo: "foo", Commit: "bar", Path: "Foo.java"},
+		},
+		CandidatePaths: make([]string, types.MaxReferencesCandidatePaths+1),
+	}
+	body, err := json.Marshal(args)
+	fatalIfError(t, err)
+
+	w := httptest.NewRecorder()
+	NewReferencesHandler(nil, readFile)(w, httptest.NewRequest("POST", "/references", bytes.NewReader(body)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: want %d, have %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,168 @@
+package accesstokens
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// expiryNoticePeriod is how long before an access token expires its subject user is notified.
+const expiryNoticePeriod = 7 * 24 * time.Hour
+
+// expiry is a worker responsible for notifying users of their expiring access tokens and for
+// deleting expired access tokens.
+type expiry struct{}
+
+func NewExpiry() job.Job {
+	return &expiry{}
+}
+
+func (j *expiry) Description() string {
+	return "notifies users of expiring access tokens and deletes expired access tokens"
+}
+
+func (j *expiry) Config() []env.Config {
+	return nil
+}
+
+func (j *expiry) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "auth.access-token-expiry", "notifies users of expiring access tokens and deletes expired ones",
+			time.Hour, &handler{
+				db:        db,
+				logger:    observationCtx.Logger,
+				sendEmail: internalapi.Client.SendEmail,
+			},
+		),
+	}, nil
+}
+
+type handler struct {
+	db        database.DB
+	logger    log.Logger
+	sendEmail func(ctx context.Context, source string, message txtypes.Message) error
+}
+
+var (
+	_ goroutine.Handler      = &handler{}
+	_ goroutine.ErrorHandler = &handler{}
+)
+
+func (h *handler) Handle(ctx context.Context) error {
+	var errs error
+	if conf.CanSendEmail() {
+		if err := h.notifyExpiring(ctx); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	deleted, err := h.db.AccessTokens().DeleteExpired(ctx)
+	if err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "deleting expired access tokens"))
+	} else if deleted > 0 {
+		h.logger.Info("deleted expired access tokens", log.Int("count", deleted))
+	}
+
+	return errs
+}
+
+func (h *handler) HandleError(err error) {
+	h.logger.Error("error handling access token expiry", log.Error(err))
+}
+
+// notifyExpiring notifies the subject users of all access tokens that expire within the notice
+// period. Each user is notified at most once per access token.
+func (h *handler) notifyExpiring(ctx context.Context) error {
+	tokens, err := h.db.AccessTokens().ListExpiring(ctx, time.Now().Add(expiryNoticePeriod))
+	if err != nil {
+		return errors.Wrap(err, "listing expiring access tokens")
+	}
+
+	var errs error
+	for _, token := range tokens {
+		if err := h.notify(ctx, token); err != nil {
+			// An unreachable user should not be retried forever, so the token is still marked as
+			// notified below.
+			h.logger.Warn("failed to notify user of expiring access token",
+				log.Int64("accessTokenID", token.ID),
+				log.Int32("userID", token.SubjectUserID),
+				log.Error(err))
+		}
+
+		if err := h.db.AccessTokens().MarkExpiryNotified(ctx, token.ID); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "marking access token expiry as notified"))
+		}
+	}
+
+	return errs
+}
+
+func (h *handler) notify(ctx context.Context, token *database.AccessToken) error {
+	email, verified, err := h.db.UserEmails().GetPrimaryEmail(ctx, token.SubjectUserID)
+	if err != nil {
+		return errors.Wrap(err, "get user primary email")
+	}
+	if !verified {
+		return errors.Newf("unable to send email to user ID %d's unverified primary email address", token.SubjectUserID)
+	}
+	user, err := h.db.Users().GetByID(ctx, token.SubjectUserID)
+	if err != nil {
+		return errors.Wrap(err, "get user")
+	}
+
+	var host string
+	if externalURL, err := url.Parse(conf.ExternalURL()); err == nil {
+		host = externalURL.Host
+	}
+
+	return h.sendEmail(ctx, "access_token_expiry", txtypes.Message{
+		To:       []string{email},
+		Template: expiringAccessTokenEmailTemplate,
+		Data: struct {
+			Username  string
+			Note      string
+			ExpiresAt string
+			Host      string
+		}{
+			Username:  user.Username,
+			Note:      token.Note,
+			ExpiresAt: token.ExpiresAt.UTC().Format(time.RFC1123),
+			Host:      host,
+		},
+	})
+}
+
+var expiringAccessTokenEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `Your Sourcegraph access token expires soon ({{.Host}})`,
+	Text: `
+Hi {{.Username}}, your access token "{{.Note}}" on Sourcegraph ({{.Host}}) expires on {{.ExpiresAt}}.
+
+Once it has expired, requests authenticated with it will fail. If you still need it, create a new access token in your user settings and replace the expiring one.
+`,
+	HTML: `
+<p>
+Hi {{.Username}}, your access token <strong>{{.Note}}</strong> on Sourcegraph ({{.Host}}) expires on {{.ExpiresAt}}.
+</p>
+
+<p>Once it has expired, requests authenticated with it will fail. If you still need it, create a new access token in your user settings and replace the expiring one.</p>
+`,
+})
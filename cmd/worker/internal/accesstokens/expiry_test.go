@@ -0,0 +1,116 @@
+package accesstokens
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestHandler(t *testing.T) {
+	expiresAt := time.Now().Add(48 * time.Hour)
+
+	newDB := func() (database.DB, *database.MockAccessTokenStore, *database.MockUserEmailsStore) {
+		accessTokens := database.NewMockAccessTokenStore()
+		accessTokens.ListExpiringFunc.SetDefaultReturn([]*database.AccessToken{
+			{ID: 1, SubjectUserID: 1, Note: "ci", ExpiresAt: &expiresAt},
+		}, nil)
+		accessTokens.DeleteExpiredFunc.SetDefaultReturn(2, nil)
+
+		userEmails := database.NewMockUserEmailsStore()
+		userEmails.GetPrimaryEmailFunc.SetDefaultReturn("alice@example.com", true, nil)
+
+		users := database.NewMockUserStore()
+		users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice"}, nil)
+
+		db := database.NewMockDB()
+		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+		db.UserEmailsFunc.SetDefaultReturn(userEmails)
+		db.UsersFunc.SetDefaultReturn(users)
+		return db, accessTokens, userEmails
+	}
+
+	t.Run("email disabled", func(t *testing.T) {
+		conf.Mock(&conf.Unified{})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		db, accessTokens, _ := newDB()
+		h := &handler{
+			db:     db,
+			logger: logtest.Scoped(t),
+			sendEmail: func(context.Context, string, txtypes.Message) error {
+				t.Fatal("unexpected email")
+				return nil
+			},
+		}
+
+		assert.NoError(t, h.Handle(context.Background()))
+		mockassert.NotCalled(t, accessTokens.ListExpiringFunc)
+		mockassert.CalledOnce(t, accessTokens.DeleteExpiredFunc)
+	})
+
+	t.Run("notifies and deletes", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{EmailSmtp: &schema.SMTPServerConfig{}}})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		db, accessTokens, _ := newDB()
+		var sent []txtypes.Message
+		h := &handler{
+			db:     db,
+			logger: logtest.Scoped(t),
+			sendEmail: func(_ context.Context, _ string, message txtypes.Message) error {
+				sent = append(sent, message)
+				return nil
+			},
+		}
+
+		assert.NoError(t, h.Handle(context.Background()))
+		if assert.Len(t, sent, 1) {
+			assert.Equal(t, []string{"alice@example.com"}, sent[0].To)
+		}
+		mockassert.CalledOnceWith(t, accessTokens.MarkExpiryNotifiedFunc, mockassert.Values(mockassert.Skip, int64(1)))
+		mockassert.CalledOnce(t, accessTokens.DeleteExpiredFunc)
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{EmailSmtp: &schema.SMTPServerConfig{}}})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		db, accessTokens, userEmails := newDB()
+		userEmails.GetPrimaryEmailFunc.SetDefaultReturn("alice@example.com", false, nil)
+		h := &handler{
+			db:     db,
+			logger: logtest.Scoped(t),
+			sendEmail: func(context.Context, string, txtypes.Message) error {
+				t.Fatal("unexpected email")
+				return nil
+			},
+		}
+
+		// The token is still marked as notified, so that it is not retried forever.
+		assert.NoError(t, h.Handle(context.Background()))
+		mockassert.CalledOnce(t, accessTokens.MarkExpiryNotifiedFunc)
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		conf.Mock(&conf.Unified{})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		db, accessTokens, _ := newDB()
+		want := errors.New("error")
+		accessTokens.DeleteExpiredFunc.SetDefaultReturn(0, want)
+		h := &handler{db: db, logger: logtest.Scoped(t)}
+
+		assert.ErrorIs(t, h.Handle(context.Background()), want)
+	})
+}
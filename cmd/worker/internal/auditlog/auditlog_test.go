@@ -0,0 +1,123 @@
+package auditlog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRetentionHandler(t *testing.T) {
+	store := database.NewMockAuditLogStore()
+	store.DeleteOlderThanFunc.SetDefaultReturn(3, nil)
+
+	h := &retentionHandler{
+		store:         store,
+		logger:        logtest.Scoped(t),
+		retentionDays: func() int { return 30 },
+	}
+	require.NoError(t, h.Handle(context.Background()))
+
+	mockassert.CalledOnce(t, store.DeleteOlderThanFunc)
+	before := store.DeleteOlderThanFunc.History()[0].Arg1
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), before, time.Minute)
+}
+
+func TestExportHandler(t *testing.T) {
+	newEntries := func(from, to int64) []*database.AuditLogEntry {
+		var entries []*database.AuditLogEntry
+		for id := from; id <= to; id++ {
+			entries = append(entries, &database.AuditLogEntry{ID: id, Entry: audit.Entry{AuditID: "entry", Entity: "e", Action: "a"}})
+		}
+		return entries
+	}
+
+	newStore := func(batches ...[]*database.AuditLogEntry) *database.MockAuditLogStore {
+		store := database.NewMockAuditLogStore()
+		for _, batch := range batches {
+			store.ListUnexportedFunc.PushReturn(batch, nil)
+		}
+		return store
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		store := newStore()
+		h := &exportHandler{
+			store:  store,
+			logger: logtest.Scoped(t),
+			client: http.DefaultClient,
+			config: func() *schema.AuditLogExport { return nil },
+		}
+		require.NoError(t, h.Handle(context.Background()))
+		mockassert.NotCalled(t, store.ListUnexportedFunc)
+	})
+
+	t.Run("exports in batches and marks entries as exported", func(t *testing.T) {
+		var requests []*http.Request
+		var bodies []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r)
+			bodies = append(bodies, string(body))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		t.Cleanup(srv.Close)
+
+		store := newStore(newEntries(11, 10+exportBatchSize), newEntries(11+exportBatchSize, 12+exportBatchSize))
+		h := &exportHandler{
+			store:  store,
+			logger: logtest.Scoped(t),
+			client: srv.Client(),
+			config: func() *schema.AuditLogExport {
+				return &schema.AuditLogExport{Url: srv.URL, Format: audit.ExportFormatCEF, Authorization: "Bearer secret"}
+			},
+		}
+		require.NoError(t, h.Handle(context.Background()))
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, "Bearer secret", requests[0].Header.Get("Authorization"))
+		assert.Equal(t, audit.ContentType(audit.ExportFormatCEF), requests[0].Header.Get("Content-Type"))
+		assert.Equal(t, exportBatchSize, strings.Count(bodies[0], "\n"))
+		assert.Equal(t, 2, strings.Count(bodies[1], "\n"))
+		assert.True(t, strings.HasPrefix(bodies[1], "CEF:0|"))
+
+		mockassert.CalledN(t, store.ListUnexportedFunc, 2)
+
+		marked := store.MarkExportedFunc.History()
+		require.Len(t, marked, 2)
+		require.Len(t, marked[0].Arg1, exportBatchSize)
+		assert.Equal(t, int64(11), marked[0].Arg1[0])
+		assert.Equal(t, []int64{11 + exportBatchSize, 12 + exportBatchSize}, marked[1].Arg1)
+	})
+
+	t.Run("does not mark entries as exported on failure", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(srv.Close)
+
+		store := newStore(newEntries(11, 12))
+		h := &exportHandler{
+			store:  store,
+			logger: logtest.Scoped(t),
+			client: srv.Client(),
+			config: func() *schema.AuditLogExport {
+				return &schema.AuditLogExport{Url: srv.URL}
+			},
+		}
+		require.Error(t, h.Handle(context.Background()))
+		mockassert.NotCalled(t, store.MarkExportedFunc)
+	})
+}
@@ -0,0 +1,113 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// exportInterval is how often new audit log entries are exported.
+	exportInterval = 30 * time.Second
+	// exportBatchSize is the maximum number of audit log entries sent in a single request.
+	exportBatchSize = 500
+)
+
+// exportHandler streams audit log entries that have not been exported yet to the
+// configured HTTP sink. Entries are exported in the order they were recorded, and each
+// entry is marked as exported once it was accepted by the sink. Marking entries rather
+// than tracking the last exported ID ensures that entries committed out of order are
+// still exported.
+type exportHandler struct {
+	store  database.AuditLogStore
+	logger log.Logger
+	client *http.Client
+	config func() *schema.AuditLogExport
+}
+
+var (
+	_ goroutine.Handler      = &exportHandler{}
+	_ goroutine.ErrorHandler = &exportHandler{}
+)
+
+func (h *exportHandler) Handle(ctx context.Context) error {
+	cfg := h.config()
+	if cfg == nil || cfg.Url == "" {
+		return nil
+	}
+
+	for {
+		entries, err := h.store.ListUnexported(ctx, exportBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "listing audit log entries")
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		if err := h.send(ctx, cfg, entries); err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+		if err := h.store.MarkExported(ctx, ids...); err != nil {
+			return errors.Wrap(err, "marking audit log entries as exported")
+		}
+
+		if len(entries) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+func (h *exportHandler) HandleError(err error) {
+	h.logger.Error("error exporting audit log entries", log.Error(err))
+}
+
+// send posts the given entries to the sink in the configured format.
+func (h *exportHandler) send(ctx context.Context, cfg *schema.AuditLogExport, entries []*database.AuditLogEntry) error {
+	plain := make([]audit.Entry, 0, len(entries))
+	for _, entry := range entries {
+		plain = append(plain, entry.Entry)
+	}
+
+	var body bytes.Buffer
+	if err := audit.Encode(&body, cfg.Format, plain); err != nil {
+		return errors.Wrap(err, "encoding audit log entries")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.Url, &body)
+	if err != nil {
+		return errors.Wrap(err, "building request")
+	}
+	req.Header.Set("Content-Type", audit.ContentType(cfg.Format))
+	if cfg.Authorization != "" {
+		req.Header.Set("Authorization", cfg.Authorization)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending audit log entries")
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status code from audit log sink: %d", resp.StatusCode)
+	}
+	return nil
+}
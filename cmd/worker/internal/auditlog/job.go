@@ -0,0 +1,62 @@
+package auditlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// auditLogJob deletes persisted audit log entries older than the retention period, and
+// exports new entries to the configured SIEM sink.
+type auditLogJob struct{}
+
+func NewAuditLogJob() job.Job {
+	return &auditLogJob{}
+}
+
+func (j *auditLogJob) Description() string {
+	return "deletes audit log entries past their retention period and exports new entries to a SIEM"
+}
+
+func (j *auditLogJob) Config() []env.Config {
+	return nil
+}
+
+func (j *auditLogJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+	logger := observationCtx.Logger
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "audit-log.retention", "deletes audit log entries past their retention period",
+			time.Hour, &retentionHandler{
+				store:  db.AuditLogs(),
+				logger: logger.Scoped("retention", "deletes audit log entries past their retention period"),
+				retentionDays: func() int {
+					return audit.RetentionDays(conf.SiteConfig())
+				},
+			},
+		),
+		goroutine.NewPeriodicGoroutine(context.Background(), "audit-log.export", "exports new audit log entries to the configured SIEM",
+			exportInterval, &exportHandler{
+				store:  db.AuditLogs(),
+				logger: logger.Scoped("export", "exports new audit log entries to the configured SIEM"),
+				client: httpcli.ExternalClient,
+				config: func() *schema.AuditLogExport {
+					return audit.ExportConfig(conf.SiteConfig())
+				},
+			},
+		),
+	}, nil
+}
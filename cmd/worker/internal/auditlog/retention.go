@@ -0,0 +1,41 @@
+package auditlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// retentionHandler deletes the audit log entries that are older than the configured
+// retention period.
+type retentionHandler struct {
+	store         database.AuditLogStore
+	logger        log.Logger
+	retentionDays func() int
+}
+
+var (
+	_ goroutine.Handler      = &retentionHandler{}
+	_ goroutine.ErrorHandler = &retentionHandler{}
+)
+
+func (h *retentionHandler) Handle(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -h.retentionDays())
+	deleted, err := h.store.DeleteOlderThan(ctx, before)
+	if err != nil {
+		return errors.Wrap(err, "deleting audit log entries")
+	}
+	if deleted > 0 {
+		h.logger.Info("deleted audit log entries past their retention period", log.Int("count", deleted))
+	}
+	return nil
+}
+
+func (h *retentionHandler) HandleError(err error) {
+	h.logger.Error("error deleting audit log entries", log.Error(err))
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine/recorder"
 
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/accesstokens"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/gitserver"
 	workermigrations "github.com/sourcegraph/sourcegraph/cmd/worker/internal/migrations"
@@ -23,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/zoektrepos"
 	workerjob "github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/audit/dbsink"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -57,6 +60,8 @@ func LoadConfig(additionalJobs map[string]workerjob.Job, registerEnterpriseMigra
 		"repo-statistics-compactor": repostatistics.NewCompactor(),
 		"zoekt-repos-updater":       zoektrepos.NewUpdater(),
 		"outbound-webhook-sender":   outboundwebhooks.NewSender(),
+		"access-token-expiry":       accesstokens.NewExpiry(),
+		"audit-log":                 auditlog.NewAuditLogJob(),
 	}
 
 	var config Config
@@ -116,6 +121,14 @@ func Start(ctx context.Context, observationCtx *observation.Context, ready servi
 	serverRoutineWithJobName := namedBackgroundRoutine{Routine: server, JobName: "health-server"}
 	allRoutinesWithJobNames = append(allRoutinesWithJobNames, serverRoutineWithJobName)
 
+	// Persist the audit log entries recorded by the jobs of this worker.
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create database connection")
+	}
+	auditLogSinkRoutineWithJobName := namedBackgroundRoutine{Routine: dbsink.Register(observationCtx.Logger, db), JobName: "audit-log-sink"}
+	allRoutinesWithJobNames = append(allRoutinesWithJobNames, auditLogSinkRoutineWithJobName)
+
 	// Register recorder in all routines that support it
 	recorderCache := recorder.GetCache()
 	rec := recorder.New(observationCtx.Logger, env.MyName, recorderCache)
@@ -29,6 +29,7 @@ import (
 	extsvcauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -610,6 +611,90 @@ func (r *Resolver) CreateBatchSpec(ctx context.Context, args *graphqlbackend.Cre
 	return specResolver, nil
 }
 
+func (r *Resolver) CreateBatchSpecFromDiffs(ctx context.Context, args *graphqlbackend.CreateBatchSpecFromDiffsArgs) (graphqlbackend.BatchSpecResolver, error) {
+	var err error
+	tr, ctx := trace.New(ctx, "CreateBatchSpecFromDiffs", fmt.Sprintf("Resolver.CreateBatchSpecFromDiffs %s, Diffs %d", args.Namespace, len(args.Diffs)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesCreateAccess(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	if batchChangesFeature, err := checkLicense(); err == nil {
+		if !batchChangesFeature.Unrestricted && len(args.Diffs) > batchChangesFeature.MaxNumChangesets {
+			return nil, ErrBatchChangesOverLimit{errors.Newf("maximum number of changesets per batch change (%d) exceeded", batchChangesFeature.MaxNumChangesets)}
+		}
+	} else {
+		return nil, ErrBatchChangesUnlicensed{err}
+	}
+
+	opts := service.CreateBatchSpecFromDiffsOpts{
+		Name:        args.Name,
+		Description: args.Description,
+		ChangesetTemplate: &batcheslib.ChangesetTemplate{
+			Title:  args.Title,
+			Body:   args.Body,
+			Branch: args.Branch,
+			Commit: batcheslib.ExpandedGitCommitDescription{Message: args.CommitMessage},
+		},
+	}
+
+	err = graphqlbackend.UnmarshalNamespaceID(args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range args.Diffs {
+		repoID, err := graphqlbackend.UnmarshalRepositoryID(d.Repository)
+		if err != nil {
+			return nil, err
+		}
+
+		var baseRef string
+		if d.BaseRef != nil {
+			baseRef = gitdomain.EnsureRefPrefix(*d.BaseRef)
+		} else {
+			// 🚨 SECURITY: database.Repos.Get uses the authzFilter under the hood and
+			// returns a not found error if the user doesn't have access to the repository.
+			repo, err := r.store.Repos().Get(ctx, repoID)
+			if err != nil {
+				return nil, err
+			}
+			baseRef, _, err = r.gitserverClient.GetDefaultBranch(ctx, repo.Name, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		opts.Diffs = append(opts.Diffs, service.RepositoryDiff{
+			RepoID:  repoID,
+			BaseRef: baseRef,
+			BaseRev: d.BaseRev,
+			Diff:    []byte(d.Diff),
+		})
+	}
+
+	svc := service.New(r.store)
+	batchSpec, err := svc.CreateBatchSpecFromDiffs(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	eventArg := &batchSpecCreatedArg{ChangesetSpecsCount: len(opts.Diffs)}
+	if err := logBackendEvent(ctx, r.store.DatabaseDB(), "BatchSpecCreated", eventArg, eventArg); err != nil {
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
+}
+
 func (r *Resolver) CreateChangesetSpec(ctx context.Context, args *graphqlbackend.CreateChangesetSpecArgs) (graphqlbackend.ChangesetSpecResolver, error) {
 	var err error
 	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetSpec", "")
@@ -51,6 +51,7 @@ func Init(
 
 	repoStore := db.Repos()
 	siteAdminChecker := sharedresolvers.NewSiteAdminChecker(db)
+	permissionChecker := sharedresolvers.NewPermissionChecker(db)
 	locationResolverFactory := gitresolvers.NewCachedLocationResolverFactory(repoStore, codeIntelServices.GitserverClient)
 	prefetcherFactory := uploadgraphql.NewPrefetcherFactory(codeIntelServices.UploadsService)
 
@@ -61,6 +62,7 @@ func Init(
 		codeIntelServices.PoliciesService,
 		codeIntelServices.GitserverClient,
 		siteAdminChecker,
+		permissionChecker,
 		repoStore,
 		prefetcherFactory,
 		locationResolverFactory,
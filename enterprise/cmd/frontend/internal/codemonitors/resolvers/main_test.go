@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -30,9 +31,42 @@ func insertTestUser(t *testing.T, db database.DB, name string, isAdmin bool) *ty
 	err = db.Users().SetIsSiteAdmin(context.Background(), u.ID, isAdmin)
 	require.NoError(t, err)
 
+	grantCodeMonitorsWritePermission(t, db, u.ID)
+
 	return u
 }
 
+// grantCodeMonitorsWritePermission makes sure the given user has the permission required to
+// create and edit code monitors. The permission is assigned to the USER system role, so it is
+// only created once per database.
+func grantCodeMonitorsWritePermission(t *testing.T, db database.DB, userID int32) {
+	t.Helper()
+
+	ctx := context.Background()
+	namespace, action, err := rbac.ParsePermissionDisplayName(rbac.CodeMonitorsWritePermission)
+	require.NoError(t, err)
+
+	if perm, err := db.Permissions().GetPermissionForUser(ctx, database.GetPermissionForUserOpts{
+		UserID:    userID,
+		Namespace: namespace,
+		Action:    action,
+	}); err == nil && perm != nil {
+		return
+	}
+
+	perm, err := db.Permissions().Create(ctx, database.CreatePermissionOpts{
+		Namespace: namespace,
+		Action:    action,
+	})
+	require.NoError(t, err)
+
+	err = db.RolePermissions().BulkAssignPermissionsToSystemRoles(ctx, database.BulkAssignPermissionsToSystemRolesOpts{
+		Roles:        []types.SystemRole{types.UserSystemRole},
+		PermissionID: perm.ID,
+	})
+	require.NoError(t, err)
+}
+
 func addUserToOrg(t *testing.T, db database.DB, userID int32, orgID int32) {
 	t.Helper()
 
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -120,6 +121,10 @@ func (r *Resolver) MonitorByID(ctx context.Context, id graphql.ID) (graphqlbacke
 }
 
 func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.CreateCodeMonitorArgs) (_ graphqlbackend.MonitorResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsWritePermission); err != nil {
+		return nil, err
+	}
+
 	if err := r.isAllowedToCreate(ctx, args.Monitor.Namespace); err != nil {
 		return nil, err
 	}
@@ -183,6 +188,10 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 }
 
 func (r *Resolver) ToggleCodeMonitor(ctx context.Context, args *graphqlbackend.ToggleCodeMonitorArgs) (graphqlbackend.MonitorResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsWritePermission); err != nil {
+		return nil, err
+	}
+
 	err := r.isAllowedToEdit(ctx, args.Id)
 	if err != nil {
 		return nil, errors.Errorf("UpdateMonitorEnabled: %w", err)
@@ -200,6 +209,10 @@ func (r *Resolver) ToggleCodeMonitor(ctx context.Context, args *graphqlbackend.T
 }
 
 func (r *Resolver) DeleteCodeMonitor(ctx context.Context, args *graphqlbackend.DeleteCodeMonitorArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsWritePermission); err != nil {
+		return nil, err
+	}
+
 	err := r.isAllowedToEdit(ctx, args.Id)
 	if err != nil {
 		return nil, errors.Errorf("DeleteCodeMonitor: %w", err)
@@ -217,6 +230,10 @@ func (r *Resolver) DeleteCodeMonitor(ctx context.Context, args *graphqlbackend.D
 }
 
 func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs) (graphqlbackend.MonitorResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsWritePermission); err != nil {
+		return nil, err
+	}
+
 	err := r.isAllowedToEdit(ctx, args.Monitor.Id)
 	if err != nil {
 		return nil, errors.Errorf("UpdateCodeMonitor: %w", err)
@@ -379,6 +396,10 @@ func (r *Resolver) ResetTriggerQueryTimestamps(ctx context.Context, args *graphq
 }
 
 func (r *Resolver) TriggerTestEmailAction(ctx context.Context, args *graphqlbackend.TriggerTestEmailActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsWritePermission); err != nil {
+		return nil, err
+	}
+
 	err := r.isAllowedToCreate(ctx, args.Namespace)
 	if err != nil {
 		return nil, err
@@ -394,6 +415,10 @@ func (r *Resolver) TriggerTestEmailAction(ctx context.Context, args *graphqlback
 }
 
 func (r *Resolver) TriggerTestWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsWritePermission); err != nil {
+		return nil, err
+	}
+
 	err := r.isAllowedToCreate(ctx, args.Namespace)
 	if err != nil {
 		return nil, err
@@ -407,6 +432,10 @@ func (r *Resolver) TriggerTestWebhookAction(ctx context.Context, args *graphqlba
 }
 
 func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestSlackWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsWritePermission); err != nil {
+		return nil, err
+	}
+
 	err := r.isAllowedToCreate(ctx, args.Namespace)
 	if err != nil {
 		return nil, err
@@ -14,8 +14,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/completions/streaming/openai"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/cody"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -24,12 +27,13 @@ import (
 const maxRequestDuration = time.Minute
 
 // NewCompletionsStreamHandler is an http handler which streams back completions results.
-func NewCompletionsStreamHandler(logger log.Logger) http.Handler {
-	return &streamHandler{logger: logger}
+func NewCompletionsStreamHandler(logger log.Logger, db database.DB) http.Handler {
+	return &streamHandler{logger: logger, db: db}
 }
 
 type streamHandler struct {
 	logger log.Logger
+	db     database.DB
 }
 
 func getCompletionStreamClient(provider string, accessToken string, model string) (types.CompletionStreamClient, error) {
@@ -61,6 +65,18 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err := rbac.CheckCurrentUserHasPermission(ctx, h.db, rbac.CodyAccessPermission); err != nil {
+		statusCode := http.StatusInternalServerError
+		var notAuthorizedErr *rbac.ErrNotAuthorized
+		if errors.Is(err, auth.ErrNotAuthenticated) {
+			statusCode = http.StatusUnauthorized
+		} else if errors.As(err, &notAuthorizedErr) {
+			statusCode = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
 	if r.Method != "POST" {
 		http.Error(w, fmt.Sprintf("unsupported method %s", r.Method), http.StatusBadRequest)
 		return
@@ -11,6 +11,7 @@ import (
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
@@ -121,10 +122,32 @@ func (c *computeTextResolver) Kind() *string {
 }
 func (c *computeTextResolver) Value() string { return c.t.Value }
 
+type computeAggregateResolver struct {
+	a *compute.Aggregation
+}
+
+func (c *computeAggregateResolver) Groups() []gql.ComputeAggregateGroupResolver {
+	groups := make([]gql.ComputeAggregateGroupResolver, 0, len(c.a.Groups))
+	for _, g := range c.a.Groups {
+		groups = append(groups, &computeAggregateGroupResolver{g: g})
+	}
+	return groups
+}
+
+func (c *computeAggregateResolver) OtherGroupCount() int32  { return int32(c.a.OtherGroupCount) }
+func (c *computeAggregateResolver) OtherResultCount() int32 { return int32(c.a.OtherResultCount) }
+
+type computeAggregateGroupResolver struct {
+	g compute.AggregationGroup
+}
+
+func (c *computeAggregateGroupResolver) Value() string { return c.g.Value }
+func (c *computeAggregateGroupResolver) Count() int32  { return int32(c.g.Count) }
+
 // A dummy type to express the union of compute results. This how its done by the GQL library we use.
 // https://github.com/graph-gophers/graphql-go/blob/af5bb93e114f0cd4cc095dd8eae0b67070ae8f20/example/starwars/starwars.go#L485-L487
 //
-// union ComputeResult = ComputeMatchContext | ComputeText
+// union ComputeResult = ComputeMatchContext | ComputeText | ComputeAggregate
 
 type computeResultResolver struct {
 	result any
@@ -140,6 +163,11 @@ func (r *computeResultResolver) ToComputeText() (gql.ComputeTextResolver, bool)
 	return res, ok
 }
 
+func (r *computeResultResolver) ToComputeAggregate() (gql.ComputeAggregateResolver, bool) {
+	res, ok := r.result.(*computeAggregateResolver)
+	return res, ok
+}
+
 func toComputeMatchContextResolver(mc *compute.MatchContext, repository *gql.RepositoryResolver, path, commit string) *computeMatchContextResolver {
 	computeMatches := make([]gql.ComputeMatchResolver, 0, len(mc.Matches))
 	for _, m := range mc.Matches {
@@ -202,6 +230,41 @@ func toResultResolverList(ctx context.Context, cmd compute.Command, matches []re
 		return resolver
 	}
 
+	if _, ok := cmd.(*compute.Aggregate); ok {
+		// Aggregate commands combine all matches into a single result.
+		aggregator := compute.NewAggregator(compute.DefaultAggregateLimit)
+		for _, m := range matches {
+			computeResult, err := cmd.Run(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			aggregator.Add(computeResult)
+		}
+		return []gql.ComputeResultResolver{&computeResultResolver{result: &computeAggregateResolver{a: aggregator.Aggregation()}}}, nil
+	}
+
+	if replace, ok := cmd.(*compute.Replace); ok && replace.Diff {
+		// Diffs are combined into a single diff per repository.
+		var diffs []*compute.FileDiff
+		for _, m := range matches {
+			computeResult, err := cmd.Run(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			if diff, ok := computeResult.(*compute.FileDiff); ok {
+				diffs = append(diffs, diff)
+			}
+		}
+
+		results := make([]gql.ComputeResultResolver, 0, len(diffs))
+		for _, d := range compute.GroupDiffsByRepository(diffs) {
+			repoResolver := getRepoResolver(types.MinimalRepo{ID: api.RepoID(d.RepositoryID), Name: api.RepoName(d.Repository)}, "")
+			text := &compute.Text{Value: d.Diff, Kind: "replace-diff"}
+			results = append(results, &computeResultResolver{result: toComputeTextResolver(text, repoResolver, "", d.Commit)})
+		}
+		return results, nil
+	}
+
 	results := make([]gql.ComputeResultResolver, 0, len(matches))
 	for _, m := range matches {
 		computeResult, err := cmd.Run(ctx, m)
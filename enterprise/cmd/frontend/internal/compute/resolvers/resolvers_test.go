@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/hexops/autogold/v2"
@@ -28,6 +29,11 @@ func TestToResultResolverList(t *testing.T) {
 					results = append(results, m.Value())
 				}
 			}
+			if rr, ok := r.ToComputeAggregate(); ok {
+				for _, g := range rr.Groups() {
+					results = append(results, fmt.Sprintf("%s:%d", g.Value(), g.Count()))
+				}
+			}
 		}
 		v, _ := json.Marshal(results)
 		return string(v)
@@ -51,6 +57,7 @@ func TestToResultResolverList(t *testing.T) {
 		},
 	}
 	autogold.Expect(`["a","b"]`).Equal(t, test("a|b", nonNilMatches))
+	autogold.Expect(`["a:1","b:1"]`).Equal(t, test("content:aggregate((a|b) -> $1)", nonNilMatches))
 
 	producesNilResult := []result.Match{&result.CommitMatch{}}
 	autogold.Expect("[]").Equal(t, test("a|b", producesNilResult))
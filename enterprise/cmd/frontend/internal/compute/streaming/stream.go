@@ -121,12 +121,23 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	pingTicker := time.NewTicker(h.pingTickerInterval)
 	defer pingTicker.Stop()
 
+	// Aggregate commands combine the results of the whole stream, which are
+	// sent as a single result once the stream is done.
+	var aggregator *compute.Aggregator
+	if _, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(args.Display)
+	}
+
 	first := true
 	handleEvent := func(event Event) {
 		progress.Dirty = true
 		progress.Stats.Update(&event.Stats)
 
 		for _, result := range event.Results {
+			if aggregator != nil {
+				aggregator.Add(result)
+				continue
+			}
 			_ = matchesBuf.Append(result)
 		}
 
@@ -152,6 +163,9 @@ LOOP:
 		}
 	}
 
+	if aggregator != nil {
+		_ = matchesBuf.Append(aggregator.Aggregation())
+	}
 	matchesFlush()
 
 	alert, err := getResults()
@@ -206,7 +220,9 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.New("no query found")
 	}
 
-	display := get("display", "-1") // TODO(rvantonder): Currently unused; implement a limit for compute results.
+	// TODO(rvantonder): Only used as the number of groups returned by
+	// aggregate commands; implement a limit for other compute results.
+	display := get("display", "-1")
 	var err error
 	if a.Display, err = strconv.Atoi(display); err != nil {
 		return nil, errors.Errorf("display must be an integer, got %q: %w", display, err)
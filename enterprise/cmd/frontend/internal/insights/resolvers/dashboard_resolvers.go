@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -244,6 +245,10 @@ func (d *DashboardInsightViewConnectionResolver) computeConnectedViews(ctx conte
 }
 
 func (r *Resolver) CreateInsightsDashboard(ctx context.Context, args *graphqlbackend.CreateInsightsDashboardArgs) (graphqlbackend.InsightsDashboardPayloadResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	dashboardGrants, err := parseDashboardGrants(args.Input.Grants)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to parse dashboard grants")
@@ -276,6 +281,10 @@ func (r *Resolver) CreateInsightsDashboard(ctx context.Context, args *graphqlbac
 }
 
 func (r *Resolver) UpdateInsightsDashboard(ctx context.Context, args *graphqlbackend.UpdateInsightsDashboardArgs) (graphqlbackend.InsightsDashboardPayloadResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
 
 	var dashboardGrants []store.DashboardGrant
@@ -368,6 +377,10 @@ func hasPermissionForGrants(dashboardGrants []store.DashboardGrant, userIds []in
 }
 
 func (r *Resolver) DeleteInsightsDashboard(ctx context.Context, args *graphqlbackend.DeleteInsightsDashboardArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	emptyResponse := &graphqlbackend.EmptyResponse{}
 
 	dashboardID, err := unmarshalDashboardID(args.Id)
@@ -402,6 +415,10 @@ func (r *Resolver) DeleteInsightsDashboard(ctx context.Context, args *graphqlbac
 }
 
 func (r *Resolver) AddInsightViewToDashboard(ctx context.Context, args *graphqlbackend.AddInsightViewToDashboardArgs) (_ graphqlbackend.InsightsDashboardPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	var viewID string
 	err = relay.UnmarshalSpec(args.Input.InsightViewID, &viewID)
 	if err != nil {
@@ -463,6 +480,10 @@ func (r *Resolver) AddInsightViewToDashboard(ctx context.Context, args *graphqlb
 }
 
 func (r *Resolver) RemoveInsightViewFromDashboard(ctx context.Context, args *graphqlbackend.RemoveInsightViewFromDashboardArgs) (_ graphqlbackend.InsightsDashboardPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	var viewID string
 	err = relay.UnmarshalSpec(args.Input.InsightViewID, &viewID)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -436,6 +437,10 @@ func (l *lineChartDataSeriesPresentationResolver) Color(ctx context.Context) (st
 }
 
 func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graphqlbackend.CreateLineChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	// Validation
 	// Needs at least 1 series
 	if len(args.Input.DataSeries) == 0 {
@@ -527,6 +532,10 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 }
 
 func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graphqlbackend.UpdateLineChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	if len(args.Input.DataSeries) == 0 {
 		return nil, errors.New("At least one data series is required to update an insight view")
 	}
@@ -735,6 +744,10 @@ func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSerie
 }
 
 func (r *Resolver) SaveInsightAsNewView(ctx context.Context, args graphqlbackend.SaveInsightAsNewViewArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	uid := actor.FromContext(ctx).UID
 	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
 
@@ -826,6 +839,10 @@ func (r *Resolver) SaveInsightAsNewView(ctx context.Context, args graphqlbackend
 }
 
 func (r *Resolver) CreatePieChartSearchInsight(ctx context.Context, args *graphqlbackend.CreatePieChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	insightTx, err := r.insightStore.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -907,6 +924,10 @@ func (r *Resolver) CreatePieChartSearchInsight(ctx context.Context, args *graphq
 }
 
 func (r *Resolver) UpdatePieChartSearchInsight(ctx context.Context, args *graphqlbackend.UpdatePieChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	tx, err := r.insightStore.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -1379,6 +1400,10 @@ func seriesFound(existingSeries types.InsightViewSeries, inputSeries []graphqlba
 }
 
 func (r *Resolver) DeleteInsightView(ctx context.Context, args *graphqlbackend.DeleteInsightViewArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	var viewId string
 	err := relay.UnmarshalSpec(args.Id, &viewId)
 	if err != nil {
@@ -55,3 +55,35 @@ type NotebookStar struct {
 type NotebookStarUser struct {
 	Username string
 }
+
+type NotebookReportSchedule struct {
+	Frequency string
+	SendEmail bool
+	User      *NotebookUser
+	LastRunAt *string
+}
+
+type NotebookReportSnapshot struct {
+	Blocks []NotebookBlockSnapshot
+}
+
+type NotebookBlockSnapshot struct {
+	BlockID     string
+	Query       string
+	ResultCount int32
+	Error       *string
+	TopMatches  []NotebookSnapshotMatch
+	Diff        *NotebookBlockSnapshotDiff
+}
+
+type NotebookBlockSnapshotDiff struct {
+	ResultCountDelta int32
+	AddedMatches     []NotebookSnapshotMatch
+	RemovedMatches   []NotebookSnapshotMatch
+}
+
+type NotebookSnapshotMatch struct {
+	Repository string
+	Path       *string
+	URL        string
+}
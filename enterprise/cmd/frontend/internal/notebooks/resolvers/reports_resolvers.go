@@ -0,0 +1,369 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func marshalNotebookReportSnapshotID(snapshotID int64) graphql.ID {
+	return relay.MarshalID("NotebookReportSnapshot", snapshotID)
+}
+
+func marshalNotebookReportSnapshotCursor(cursor int64) string {
+	return string(relay.MarshalID("NotebookReportSnapshotCursor", cursor))
+}
+
+func unmarshalNotebookReportSnapshotCursor(cursor *string) (int64, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+	var after int64
+	err := relay.UnmarshalSpec(graphql.ID(*cursor), &after)
+	if err != nil {
+		return -1, err
+	}
+	return after, nil
+}
+
+func toNotebookReportFrequency(frequency graphqlbackend.NotebookReportFrequency) (notebooks.NotebookReportFrequency, error) {
+	switch frequency {
+	case graphqlbackend.NotebookReportFrequencyDaily:
+		return notebooks.NotebookReportFrequencyDaily, nil
+	case graphqlbackend.NotebookReportFrequencyWeekly:
+		return notebooks.NotebookReportFrequencyWeekly, nil
+	}
+	return "", errors.Newf("invalid notebook report frequency: %s", frequency)
+}
+
+// getManageableNotebook returns the notebook if the current user is allowed to manage it.
+func (r *Resolver) getManageableNotebook(ctx context.Context, id graphql.ID) (*notebooks.Notebook, int32, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.NotebooksWritePermission); err != nil {
+		return nil, 0, err
+	}
+
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notebookID, err := unmarshalNotebookID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notebook, err := notebooks.Notebooks(r.db).GetNotebook(ctx, notebookID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID); err != nil {
+		return nil, 0, err
+	}
+	return notebook, user.ID, nil
+}
+
+func (r *Resolver) SetNotebookReportSchedule(ctx context.Context, args graphqlbackend.SetNotebookReportScheduleArgs) (graphqlbackend.NotebookReportScheduleResolver, error) {
+	frequency, err := toNotebookReportFrequency(args.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	notebook, userID, err := r.getManageableNotebook(ctx, args.Notebook)
+	if err != nil {
+		return nil, err
+	}
+
+	store := notebooks.Notebooks(r.db)
+	// A new schedule runs right away. An updated schedule keeps its cadence, relative
+	// to the last run.
+	nextRunAt := time.Now()
+	existing, err := store.GetNotebookReportSchedule(ctx, notebook.ID)
+	if err != nil && !errors.Is(err, notebooks.ErrNotebookReportScheduleNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.LastRunAt != nil {
+		nextRunAt = existing.LastRunAt.Add(frequency.Interval())
+	}
+
+	schedule, err := store.UpsertNotebookReportSchedule(ctx, &notebooks.NotebookReportSchedule{
+		NotebookID: notebook.ID,
+		UserID:     userID,
+		Frequency:  frequency,
+		SendEmail:  args.SendEmail,
+		NextRunAt:  nextRunAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &notebookReportScheduleResolver{schedule, r.db}, nil
+}
+
+func (r *Resolver) DeleteNotebookReportSchedule(ctx context.Context, args graphqlbackend.DeleteNotebookReportScheduleArgs) (*graphqlbackend.EmptyResponse, error) {
+	notebook, _, err := r.getManageableNotebook(ctx, args.Notebook)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := notebooks.Notebooks(r.db).DeleteNotebookReportSchedule(ctx, notebook.ID); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *notebookResolver) ReportSchedule(ctx context.Context) (graphqlbackend.NotebookReportScheduleResolver, error) {
+	// 🚨 SECURITY: The schedule holds no search results, so it is visible to all users that can
+	// manage the notebook.
+	if canManage, err := r.ViewerCanManage(ctx); err != nil || !canManage {
+		return nil, err
+	}
+
+	schedule, err := notebooks.Notebooks(r.db).GetNotebookReportSchedule(ctx, r.notebook.ID)
+	if errors.Is(err, notebooks.ErrNotebookReportScheduleNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &notebookReportScheduleResolver{schedule, r.db}, nil
+}
+
+func (r *notebookResolver) ReportSnapshots(ctx context.Context, args graphqlbackend.ListNotebookReportSnapshotsArgs) (graphqlbackend.NotebookReportSnapshotConnectionResolver, error) {
+	afterCursor, err := unmarshalNotebookReportSnapshotCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Snapshots are run with the permissions of the user that scheduled them, so
+	// they are only visible to that user, and only while they can manage the notebook.
+	userID := actor.FromContext(ctx).UID
+	if canManage, err := r.ViewerCanManage(ctx); err != nil {
+		return nil, err
+	} else if !canManage || userID == 0 {
+		return &notebookReportSnapshotConnectionResolver{afterCursor: afterCursor}, nil
+	}
+
+	// Request one extra to determine if there are more pages. It also serves as the
+	// previous snapshot of the last one on this page.
+	pageOpts := notebooks.ListNotebookReportSnapshotsPageOptions{First: args.First + 1, After: afterCursor}
+	store := notebooks.Notebooks(r.db)
+	snapshots, err := store.ListNotebookReportSnapshots(ctx, pageOpts, r.notebook.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := store.CountNotebookReportSnapshots(ctx, r.notebook.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.NotebookReportSnapshotResolver, 0, len(snapshots))
+	for idx, snapshot := range snapshots {
+		if idx == int(args.First) {
+			break
+		}
+		var previous *notebooks.NotebookReportSnapshot
+		if idx+1 < len(snapshots) {
+			previous = snapshots[idx+1]
+		}
+		resolvers = append(resolvers, &notebookReportSnapshotResolver{snapshot: snapshot, previous: previous})
+	}
+
+	return &notebookReportSnapshotConnectionResolver{
+		afterCursor: afterCursor,
+		snapshots:   resolvers,
+		totalCount:  int32(count),
+		hasNextPage: len(snapshots) == int(args.First)+1,
+	}, nil
+}
+
+type notebookReportScheduleResolver struct {
+	schedule *notebooks.NotebookReportSchedule
+	db       database.DB
+}
+
+func (r *notebookReportScheduleResolver) Frequency() string {
+	return strings.ToUpper(string(r.schedule.Frequency))
+}
+
+func (r *notebookReportScheduleResolver) SendEmail() bool {
+	return r.schedule.SendEmail
+}
+
+func (r *notebookReportScheduleResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.schedule.UserID)
+	if err != nil {
+		// Handle soft-deleted users
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *notebookReportScheduleResolver) NextRunAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.NextRunAt}
+}
+
+func (r *notebookReportScheduleResolver) LastRunAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.schedule.LastRunAt)
+}
+
+func (r *notebookReportScheduleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.CreatedAt}
+}
+
+type notebookReportSnapshotConnectionResolver struct {
+	afterCursor int64
+	snapshots   []graphqlbackend.NotebookReportSnapshotResolver
+	totalCount  int32
+	hasNextPage bool
+}
+
+func (r *notebookReportSnapshotConnectionResolver) Nodes() []graphqlbackend.NotebookReportSnapshotResolver {
+	return r.snapshots
+}
+
+func (r *notebookReportSnapshotConnectionResolver) TotalCount() int32 {
+	return r.totalCount
+}
+
+func (r *notebookReportSnapshotConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	if len(r.snapshots) == 0 || !r.hasNextPage {
+		return graphqlutil.HasNextPage(false)
+	}
+	// The after value (offset) for the next page is computed from the current after value + the number of retrieved snapshots
+	return graphqlutil.NextPageCursor(marshalNotebookReportSnapshotCursor(r.afterCursor + int64(len(r.snapshots))))
+}
+
+type notebookReportSnapshotResolver struct {
+	snapshot *notebooks.NotebookReportSnapshot
+	// previous is the snapshot taken right before this one, or nil if this is the first one.
+	previous *notebooks.NotebookReportSnapshot
+}
+
+func (r *notebookReportSnapshotResolver) ID() graphql.ID {
+	return marshalNotebookReportSnapshotID(r.snapshot.ID)
+}
+
+func (r *notebookReportSnapshotResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.snapshot.CreatedAt}
+}
+
+func (r *notebookReportSnapshotResolver) Blocks() []graphqlbackend.NotebookBlockSnapshotResolver {
+	resolvers := make([]graphqlbackend.NotebookBlockSnapshotResolver, 0, len(r.snapshot.Blocks))
+	for _, block := range r.snapshot.Blocks {
+		var previous *notebooks.NotebookBlockSnapshot
+		if r.previous != nil {
+			previous = r.previous.Blocks.Block(block.BlockID)
+		}
+		resolvers = append(resolvers, &notebookBlockSnapshotResolver{block: block, previous: previous})
+	}
+	return resolvers
+}
+
+type notebookBlockSnapshotResolver struct {
+	block    notebooks.NotebookBlockSnapshot
+	previous *notebooks.NotebookBlockSnapshot
+}
+
+func (r *notebookBlockSnapshotResolver) BlockID() string {
+	return r.block.BlockID
+}
+
+func (r *notebookBlockSnapshotResolver) Query() string {
+	return r.block.Query
+}
+
+func (r *notebookBlockSnapshotResolver) ResultCount() int32 {
+	return int32(r.block.ResultCount)
+}
+
+func (r *notebookBlockSnapshotResolver) LimitHit() bool {
+	return r.block.LimitHit
+}
+
+func (r *notebookBlockSnapshotResolver) Error() *string {
+	if r.block.Error == "" {
+		return nil
+	}
+	return &r.block.Error
+}
+
+func (r *notebookBlockSnapshotResolver) TopMatches() []graphqlbackend.NotebookSnapshotMatchResolver {
+	return toNotebookSnapshotMatchResolvers(r.block.TopMatches)
+}
+
+func (r *notebookBlockSnapshotResolver) Diff() graphqlbackend.NotebookBlockSnapshotDiffResolver {
+	if r.previous == nil {
+		return nil
+	}
+	return &notebookBlockSnapshotDiffResolver{notebooks.DiffBlockSnapshots(*r.previous, r.block)}
+}
+
+type notebookBlockSnapshotDiffResolver struct {
+	diff notebooks.NotebookBlockSnapshotDiff
+}
+
+func (r *notebookBlockSnapshotDiffResolver) ResultCountDelta() int32 {
+	return int32(r.diff.ResultCountDelta)
+}
+
+func (r *notebookBlockSnapshotDiffResolver) QueryChanged() bool {
+	return r.diff.QueryChanged
+}
+
+func (r *notebookBlockSnapshotDiffResolver) AddedMatches() []graphqlbackend.NotebookSnapshotMatchResolver {
+	return toNotebookSnapshotMatchResolvers(r.diff.AddedMatches)
+}
+
+func (r *notebookBlockSnapshotDiffResolver) RemovedMatches() []graphqlbackend.NotebookSnapshotMatchResolver {
+	return toNotebookSnapshotMatchResolvers(r.diff.RemovedMatches)
+}
+
+func toNotebookSnapshotMatchResolvers(matches []notebooks.NotebookSnapshotMatch) []graphqlbackend.NotebookSnapshotMatchResolver {
+	resolvers := make([]graphqlbackend.NotebookSnapshotMatchResolver, 0, len(matches))
+	for _, match := range matches {
+		resolvers = append(resolvers, &notebookSnapshotMatchResolver{match})
+	}
+	return resolvers
+}
+
+type notebookSnapshotMatchResolver struct {
+	match notebooks.NotebookSnapshotMatch
+}
+
+func (r *notebookSnapshotMatchResolver) Repository() string {
+	return r.match.Repository
+}
+
+func (r *notebookSnapshotMatchResolver) Path() *string {
+	if r.match.Path == "" {
+		return nil
+	}
+	return &r.match.Path
+}
+
+func (r *notebookSnapshotMatchResolver) Label() *string {
+	if r.match.Label == "" {
+		return nil
+	}
+	return &r.match.Label
+}
+
+func (r *notebookSnapshotMatchResolver) URL() string {
+	return r.match.URL
+}
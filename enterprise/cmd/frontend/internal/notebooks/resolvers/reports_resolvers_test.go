@@ -0,0 +1,195 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers/apitest"
+	notebooksapitest "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/notebooks/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+const notebookReportScheduleFields = `
+	frequency
+	sendEmail
+	user {
+		username
+	}
+	lastRunAt
+`
+
+var setNotebookReportScheduleMutation = fmt.Sprintf(`
+mutation SetNotebookReportSchedule($notebook: ID!, $frequency: NotebookReportFrequency!, $sendEmail: Boolean) {
+	setNotebookReportSchedule(notebook: $notebook, frequency: $frequency, sendEmail: $sendEmail) {
+		%s
+	}
+}
+`, notebookReportScheduleFields)
+
+var deleteNotebookReportScheduleMutation = `
+mutation DeleteNotebookReportSchedule($notebook: ID!) {
+	deleteNotebookReportSchedule(notebook: $notebook) {
+		alwaysNil
+	}
+}
+`
+
+var notebookReportsQuery = fmt.Sprintf(`
+query NotebookReports($id: ID!, $first: Int!) {
+	node(id: $id) {
+		... on Notebook {
+			reportSchedule {
+				%s
+			}
+			reportSnapshots(first: $first) {
+				nodes {
+					blocks {
+						blockID
+						query
+						resultCount
+						error
+						topMatches { repository path url }
+						diff {
+							resultCountDelta
+							addedMatches { repository path url }
+							removedMatches { repository path url }
+						}
+					}
+				}
+				totalCount
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+		}
+	}
+}
+`, notebookReportScheduleFields)
+
+type notebookReportsResponse struct {
+	Node struct {
+		ReportSchedule  *notebooksapitest.NotebookReportSchedule
+		ReportSnapshots struct {
+			Nodes      []notebooksapitest.NotebookReportSnapshot
+			TotalCount int32
+			PageInfo   apitest.PageInfo
+		}
+	}
+}
+
+func TestNotebookReports(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+
+	user1, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	user2, err := u.Create(internalCtx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	grantNotebooksWritePermission(t, db)
+
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createdNotebooks := createNotebooks(t, db, []*notebooks.Notebook{userNotebookFixture(user1.ID, true)})
+	notebookID := createdNotebooks[0].ID
+	user1Ctx := actor.WithActor(context.Background(), actor.FromUser(user1.ID))
+	user2Ctx := actor.WithActor(context.Background(), actor.FromUser(user2.ID))
+
+	// user2 can view the public notebook, but cannot schedule it.
+	input := map[string]any{"notebook": marshalNotebookID(notebookID), "frequency": "DAILY"}
+	var setResponse struct {
+		SetNotebookReportSchedule notebooksapitest.NotebookReportSchedule
+	}
+	if errs := apitest.Exec(user2Ctx, t, schema, input, &setResponse, setNotebookReportScheduleMutation); errs == nil {
+		t.Fatal("expected error when scheduling a notebook of another user, got nil")
+	}
+
+	input = map[string]any{"notebook": marshalNotebookID(notebookID), "frequency": "WEEKLY", "sendEmail": true}
+	apitest.MustExec(user1Ctx, t, schema, input, &setResponse, setNotebookReportScheduleMutation)
+	wantSchedule := notebooksapitest.NotebookReportSchedule{
+		Frequency: "WEEKLY",
+		SendEmail: true,
+		User:      &notebooksapitest.NotebookUser{Username: "u1"},
+	}
+	if diff := cmp.Diff(wantSchedule, setResponse.SetNotebookReportSchedule); diff != "" {
+		t.Fatalf("unexpected schedule (-want +got):\n%s", diff)
+	}
+
+	store := notebooks.Notebooks(db)
+	path := "a.go"
+	a := notebooks.NotebookSnapshotMatch{Repository: "r", Path: path, URL: "/r/-/blob/a.go"}
+	b := notebooks.NotebookSnapshotMatch{Repository: "r", URL: "/r"}
+	for _, blocks := range []notebooks.NotebookBlockSnapshots{
+		{{BlockID: "1", Query: "q", ResultCount: 1, TopMatches: []notebooks.NotebookSnapshotMatch{a}}},
+		{{BlockID: "1", Query: "q", ResultCount: 3, TopMatches: []notebooks.NotebookSnapshotMatch{b}}},
+	} {
+		if _, err := store.CreateNotebookReportSnapshot(internalCtx, notebookID, user1.ID, blocks); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Snapshots run with the permissions of another user are never visible to user1.
+	otherBlocks := notebooks.NotebookBlockSnapshots{{BlockID: "1", Query: "q", ResultCount: 7}}
+	if _, err := store.CreateNotebookReportSnapshot(internalCtx, notebookID, user2.ID, otherBlocks); err != nil {
+		t.Fatal(err)
+	}
+
+	var response notebookReportsResponse
+	apitest.MustExec(user1Ctx, t, schema, map[string]any{"id": marshalNotebookID(notebookID), "first": 1}, &response, notebookReportsQuery)
+	if diff := cmp.Diff(&wantSchedule, response.Node.ReportSchedule); diff != "" {
+		t.Fatalf("unexpected schedule (-want +got):\n%s", diff)
+	}
+	if response.Node.ReportSnapshots.TotalCount != 2 || !response.Node.ReportSnapshots.PageInfo.HasNextPage {
+		t.Fatalf("expected 2 snapshots with a next page, got %+v", response.Node.ReportSnapshots)
+	}
+	wantSnapshots := []notebooksapitest.NotebookReportSnapshot{{
+		Blocks: []notebooksapitest.NotebookBlockSnapshot{{
+			BlockID:     "1",
+			Query:       "q",
+			ResultCount: 3,
+			TopMatches:  []notebooksapitest.NotebookSnapshotMatch{{Repository: "r", URL: "/r"}},
+			Diff: &notebooksapitest.NotebookBlockSnapshotDiff{
+				ResultCountDelta: 2,
+				AddedMatches:     []notebooksapitest.NotebookSnapshotMatch{{Repository: "r", URL: "/r"}},
+				RemovedMatches:   []notebooksapitest.NotebookSnapshotMatch{{Repository: "r", Path: &path, URL: "/r/-/blob/a.go"}},
+			},
+		}},
+	}}
+	if diff := cmp.Diff(wantSnapshots, response.Node.ReportSnapshots.Nodes); diff != "" {
+		t.Fatalf("unexpected snapshots (-want +got):\n%s", diff)
+	}
+
+	// Reports are not visible to users that cannot manage the notebook.
+	response = notebookReportsResponse{}
+	apitest.MustExec(user2Ctx, t, schema, map[string]any{"id": marshalNotebookID(notebookID), "first": 10}, &response, notebookReportsQuery)
+	if response.Node.ReportSchedule != nil || response.Node.ReportSnapshots.TotalCount != 0 || len(response.Node.ReportSnapshots.Nodes) != 0 {
+		t.Fatalf("expected no reports for user2, got %+v", response.Node)
+	}
+
+	var deleteResponse struct{}
+	apitest.MustExec(user1Ctx, t, schema, map[string]any{"notebook": marshalNotebookID(notebookID)}, &deleteResponse, deleteNotebookReportScheduleMutation)
+	response = notebookReportsResponse{}
+	apitest.MustExec(user1Ctx, t, schema, map[string]any{"id": marshalNotebookID(notebookID), "first": 10}, &response, notebookReportsQuery)
+	if response.Node.ReportSchedule != nil {
+		t.Fatalf("expected schedule to be deleted, got %+v", response.Node.ReportSchedule)
+	}
+	if response.Node.ReportSnapshots.TotalCount != 2 {
+		t.Fatalf("expected snapshots to be kept, got %d", response.Node.ReportSnapshots.TotalCount)
+	}
+}
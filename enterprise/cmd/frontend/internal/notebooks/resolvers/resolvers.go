@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -115,6 +116,10 @@ func convertNotebookBlockInput(inputBlock graphqlbackend.CreateNotebookBlockInpu
 }
 
 func (r *Resolver) CreateNotebook(ctx context.Context, args graphqlbackend.CreateNotebookInputArgs) (graphqlbackend.NotebookResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.NotebooksWritePermission); err != nil {
+		return nil, err
+	}
+
 	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
 	if err != nil {
 		return nil, err
@@ -137,11 +142,46 @@ func (r *Resolver) CreateNotebook(ctx context.Context, args graphqlbackend.Creat
 		UpdaterUserID: user.ID,
 		Blocks:        blocks,
 	}
-	err = graphqlbackend.UnmarshalNamespaceID(args.Notebook.Namespace, &notebook.NamespaceUserID, &notebook.NamespaceOrgID)
+	return r.createNotebook(ctx, notebook, args.Notebook.Namespace, user.ID)
+}
+
+func (r *Resolver) ImportNotebook(ctx context.Context, args graphqlbackend.ImportNotebookArgs) (graphqlbackend.NotebookResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.NotebooksWritePermission); err != nil {
+		return nil, err
+	}
+
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
 	if err != nil {
 		return nil, err
 	}
-	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
+
+	title, blocks, err := notebooks.ImportMarkdown(args.Markdown)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing notebook markdown")
+	}
+	if args.Title != nil {
+		title = *args.Title
+	}
+	if title == "" {
+		return nil, errors.New("notebook title is required, either as an argument or as the leading heading of the markdown")
+	}
+
+	notebook := &notebooks.Notebook{
+		Title:         title,
+		Public:        args.Public,
+		CreatorUserID: user.ID,
+		UpdaterUserID: user.ID,
+		Blocks:        blocks,
+	}
+	return r.createNotebook(ctx, notebook, args.Namespace, user.ID)
+}
+
+func (r *Resolver) createNotebook(ctx context.Context, notebook *notebooks.Notebook, namespace graphql.ID, userID int32) (graphqlbackend.NotebookResolver, error) {
+	err := graphqlbackend.UnmarshalNamespaceID(namespace, &notebook.NamespaceUserID, &notebook.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +194,10 @@ func (r *Resolver) CreateNotebook(ctx context.Context, args graphqlbackend.Creat
 }
 
 func (r *Resolver) UpdateNotebook(ctx context.Context, args graphqlbackend.UpdateNotebookInputArgs) (graphqlbackend.NotebookResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.NotebooksWritePermission); err != nil {
+		return nil, err
+	}
+
 	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
 	if err != nil {
 		return nil, err
@@ -210,6 +254,10 @@ func (r *Resolver) UpdateNotebook(ctx context.Context, args graphqlbackend.Updat
 }
 
 func (r *Resolver) DeleteNotebook(ctx context.Context, args graphqlbackend.DeleteNotebookArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.NotebooksWritePermission); err != nil {
+		return nil, err
+	}
+
 	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
 	if err != nil {
 		return nil, err
@@ -383,6 +431,10 @@ func (r *notebookResolver) Blocks(ctx context.Context) []graphqlbackend.Notebook
 	return blockResolvers
 }
 
+func (r *notebookResolver) Markdown(ctx context.Context) (string, error) {
+	return notebooks.ExportMarkdown(r.notebook.Title, r.notebook.Blocks)
+}
+
 func (r *notebookResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
 	if r.notebook.CreatorUserID == 0 {
 		return nil, nil
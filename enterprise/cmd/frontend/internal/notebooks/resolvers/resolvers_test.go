@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -199,6 +200,8 @@ func TestSingleNotebookCRUD(t *testing.T) {
 		t.Fatalf("Expected no error, got %s", err)
 	}
 
+	grantNotebooksWritePermission(t, db)
+
 	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
 	if err != nil {
 		t.Fatal(err)
@@ -228,6 +231,29 @@ func testGetNotebook(t *testing.T, db database.DB, schema *graphql.Schema, user
 	compareNotebookAPIResponses(t, wantNotebookResponse, response.Node, false)
 }
 
+// grantNotebooksWritePermission assigns the permission required to write notebooks to the USER
+// system role, which every user is a member of.
+func grantNotebooksWritePermission(t *testing.T, db database.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	namespace, action, err := rbac.ParsePermissionDisplayName(rbac.NotebooksWritePermission)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perm, err := db.Permissions().Create(ctx, database.CreatePermissionOpts{Namespace: namespace, Action: action})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.RolePermissions().BulkAssignPermissionsToSystemRoles(ctx, database.BulkAssignPermissionsToSystemRolesOpts{
+		Roles:        []types.SystemRole{types.UserSystemRole},
+		PermissionID: perm.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func testCreateNotebook(t *testing.T, schema *graphql.Schema, user1 *types.User, user2 *types.User, org *types.Org) {
 	tests := []struct {
 		name            string
@@ -738,3 +764,61 @@ func TestGetNotebookWithSoftDeletedUserColumns(t *testing.T) {
 	var response struct{ Node notebooksapitest.Notebook }
 	apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, input, &response, queryNotebook)
 }
+
+var importNotebookMutation = fmt.Sprintf(`
+mutation ImportNotebook($markdown: String!, $namespace: ID!, $public: Boolean, $title: String) {
+	importNotebook(markdown: $markdown, namespace: $namespace, public: $public, title: $title) {
+		%s
+	}
+}
+`, notebookFields)
+
+func TestNotebookMarkdownExportImport(t *testing.T) {
+	logger := logtest.Scoped(t)
+	internalCtx := actor.WithInternalActor(context.Background())
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	user, err := db.Users().Create(internalCtx, database.NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	grantNotebooksWritePermission(t, db)
+
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notebook, err := notebooks.Notebooks(db).CreateNotebook(internalCtx, userNotebookFixture(user.ID, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+	var exportResponse struct{ Node struct{ Markdown string } }
+	apitest.MustExec(userCtx, t, schema, map[string]any{"id": marshalNotebookID(notebook.ID)}, &exportResponse, `
+query Notebook($id: ID!) {
+	node(id: $id) {
+		... on Notebook {
+			markdown
+		}
+	}
+}
+`)
+
+	input := map[string]any{"markdown": exportResponse.Node.Markdown, "namespace": graphqlbackend.MarshalUserID(user.ID)}
+	var importResponse struct{ ImportNotebook notebooksapitest.Notebook }
+	apitest.MustExec(userCtx, t, schema, input, &importResponse, importNotebookMutation)
+
+	wantNotebookResponse := notebooksapitest.NotebookToAPIResponse(notebook, "", user.Username, user.Username, true)
+	compareNotebookAPIResponses(t, wantNotebookResponse, importResponse.ImportNotebook, true)
+
+	t.Run("title is required", func(t *testing.T) {
+		input := map[string]any{"markdown": "```sourcegraph\nrepo:a\n```", "namespace": graphqlbackend.MarshalUserID(user.ID)}
+		var response struct{ ImportNotebook notebooksapitest.Notebook }
+		gotErrors := apitest.Exec(userCtx, t, schema, input, &response, importNotebookMutation)
+		if len(gotErrors) == 0 || !strings.Contains(gotErrors[0].Message, "notebook title is required") {
+			t.Fatalf("expected title error, got %v", gotErrors)
+		}
+	})
+}
@@ -17,8 +17,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -37,6 +39,7 @@ func Init(
 	enterpriseServices.ReposGitLabWebhook = NewGitLabHandler()
 	enterpriseServices.ReposBitbucketServerWebhook = NewBitbucketServerHandler()
 	enterpriseServices.ReposBitbucketCloudWebhook = NewBitbucketCloudHandler()
+	enterpriseServices.ReposGiteaWebhook = NewGiteaHandler()
 
 	enterpriseServices.WebhooksResolver = resolvers.NewWebhooksResolver(db)
 	return nil
@@ -161,6 +164,36 @@ func bitbucketCloudCloneURLFromEvent(event *bitbucketcloud.PushEvent) (string, e
 	return href, nil
 }
 
+type GiteaHandler struct {
+	logger log.Logger
+}
+
+func NewGiteaHandler() *GiteaHandler {
+	return &GiteaHandler{
+		logger: log.Scoped("webhooks.GiteaHandler", "gitea webhook handler"),
+	}
+}
+
+func (g *GiteaHandler) Register(router *webhooks.Router) {
+	router.Register(func(ctx context.Context, db database.DB, _ extsvc.CodeHostBaseURL, payload any) error {
+		return g.handlePushEvent(ctx, db, payload)
+	}, extsvc.KindGitea, gitea.PushEventType)
+}
+
+func (g *GiteaHandler) handlePushEvent(ctx context.Context, db database.DB, payload any) error {
+	return handlePushEvent[*gitea.PushEvent](ctx, db, g.logger, payload, giteaCloneURLFromEvent)
+}
+
+func giteaCloneURLFromEvent(event *gitea.PushEvent) (string, error) {
+	if event == nil || event.Repository == nil {
+		return "", errors.New("nil PushEvent received")
+	}
+	if event.Repository.CloneURL == "" {
+		return "", errors.New("clone url is empty")
+	}
+	return event.Repository.CloneURL, nil
+}
+
 // handlePushEvent takes a push payload and a function to extract the repo
 // clone URL from the event. It then uses the clone URL to find a repo and queues
 // a repo update.
@@ -193,6 +226,17 @@ func handlePushEvent[T any](ctx context.Context, db database.DB, logger log.Logg
 		return errors.Wrap(err, "handlePushEvent: EnqueueRepoUpdate failed")
 	}
 
+	// Recording the delivery stops the scheduler from polling the repo while
+	// webhooks keep arriving for it. It's best-effort, since the update has
+	// already been queued and the repo is still polled if it fails.
+	if err := newSchedulerStore(db).RecordWebhook(ctx, resp.ID); err != nil {
+		logger.Warn("failed to record push webhook", log.String("name", resp.Name), log.Error(err))
+	}
+
 	logger.Info("successfully updated", log.String("name", resp.Name))
 	return nil
 }
+
+// newSchedulerStore is the constructor of the store in which push webhook
+// deliveries are recorded. It is replaced in tests.
+var newSchedulerStore = repos.NewSchedulerStore
@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -29,6 +30,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
@@ -203,6 +205,7 @@ func TestGitLabHandler(t *testing.T) {
 		return api.RepoName(repoName), nil
 	})
 	db.ReposFunc.SetDefaultReturn(repositories)
+	schedulerStore := mockSchedulerStore(t)
 
 	handler := NewGitLabHandler()
 	data, err := os.ReadFile("testdata/gitlab-push.json")
@@ -228,6 +231,7 @@ func TestGitLabHandler(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.Equal(t, repoName, updateQueued)
+	mockassert.CalledOnceWith(t, schedulerStore.RecordWebhookFunc, mockassert.Values(mockassert.Skip, api.RepoID(1)))
 }
 
 func TestBitbucketServerHandler(t *testing.T) {
@@ -239,6 +243,7 @@ func TestBitbucketServerHandler(t *testing.T) {
 		return "bitbucket.sgdev.org/private/test-2020-06-01", nil
 	})
 	db.ReposFunc.SetDefaultReturn(repositories)
+	schedulerStore := mockSchedulerStore(t)
 
 	handler := NewBitbucketServerHandler()
 	data, err := os.ReadFile("testdata/bitbucket-server-push.json")
@@ -264,6 +269,7 @@ func TestBitbucketServerHandler(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.Equal(t, repoName, updateQueued)
+	mockassert.CalledOnceWith(t, schedulerStore.RecordWebhookFunc, mockassert.Values(mockassert.Skip, api.RepoID(1)))
 }
 
 func TestBitbucketCloudHandler(t *testing.T) {
@@ -275,6 +281,7 @@ func TestBitbucketCloudHandler(t *testing.T) {
 		return "bitbucket.org/sourcegraph-testing/sourcegraph", nil
 	})
 	db.ReposFunc.SetDefaultReturn(repositories)
+	schedulerStore := mockSchedulerStore(t)
 
 	handler := NewBitbucketCloudHandler()
 	data, err := os.ReadFile("testdata/bitbucket-cloud-push.json")
@@ -300,4 +307,55 @@ func TestBitbucketCloudHandler(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.Equal(t, repoName, updateQueued)
+	mockassert.CalledOnceWith(t, schedulerStore.RecordWebhookFunc, mockassert.Values(mockassert.Skip, api.RepoID(1)))
+}
+
+func TestGiteaHandler(t *testing.T) {
+	repoName := "gitea.example.com/acme/infra"
+
+	db := database.NewMockDB()
+	repositories := database.NewMockRepoStore()
+	repositories.GetFirstRepoNameByCloneURLFunc.SetDefaultHook(func(ctx context.Context, s string) (api.RepoName, error) {
+		if s != "https://gitea.example.com/acme/infra.git" {
+			return "", nil
+		}
+		return api.RepoName(repoName), nil
+	})
+	db.ReposFunc.SetDefaultReturn(repositories)
+	schedulerStore := mockSchedulerStore(t)
+
+	handler := NewGiteaHandler()
+	data, err := os.ReadFile("testdata/gitea-push.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload gitea.PushEvent
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	var updateQueued string
+	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+		updateQueued = string(repo)
+		return &protocol.RepoUpdateResponse{
+			ID:   1,
+			Name: string(repo),
+		}, nil
+	}
+	t.Cleanup(func() { repoupdater.MockEnqueueRepoUpdate = nil })
+
+	if err := handler.handlePushEvent(context.Background(), db, &payload); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, repoName, updateQueued)
+	mockassert.CalledOnceWith(t, schedulerStore.RecordWebhookFunc, mockassert.Values(mockassert.Skip, api.RepoID(1)))
+}
+
+// mockSchedulerStore replaces the store in which push webhook deliveries are
+// recorded with a mock for the duration of the test.
+func mockSchedulerStore(t *testing.T) *repos.MockSchedulerStore {
+	store := repos.NewMockSchedulerStore()
+	newSchedulerStore = func(database.DB) repos.SchedulerStore { return store }
+	t.Cleanup(func() { newSchedulerStore = repos.NewSchedulerStore })
+	return store
 }
@@ -0,0 +1,330 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const searchContextResolutionIDKind = "SearchContextResolution"
+
+func marshalSearchContextResolutionID(id int64) graphql.ID {
+	return relay.MarshalID(searchContextResolutionIDKind, id)
+}
+
+func unmarshalSearchContextResolutionID(id graphql.ID) (resolutionID int64, err error) {
+	if kind := relay.UnmarshalKind(id); kind != searchContextResolutionIDKind {
+		return 0, errors.Errorf("expected graphql ID to have kind %q; got %q", searchContextResolutionIDKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &resolutionID)
+	return
+}
+
+func (r *Resolver) CreateSearchContextSubscription(ctx context.Context, args graphqlbackend.CreateSearchContextSubscriptionArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Make sure the current user has permission to subscribe to the search context.
+	userID, err := graphqlbackend.UnmarshalUserID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, userID); err != nil {
+		return nil, err
+	}
+
+	searchContext, err := r.searchContextByID(ctx, args.SearchContextID)
+	if err != nil {
+		return nil, err
+	}
+	if searchContext.Query == "" {
+		return nil, errors.New("only search contexts defined by a query can be subscribed to")
+	}
+
+	err = r.db.SearchContexts().CreateSearchContextSubscriptionForUser(ctx, userID, searchContext.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) DeleteSearchContextSubscription(ctx context.Context, args graphqlbackend.DeleteSearchContextSubscriptionArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Make sure the current user has permission to unsubscribe from the search context.
+	userID, err := graphqlbackend.UnmarshalUserID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, userID); err != nil {
+		return nil, err
+	}
+
+	searchContext, err := r.searchContextByID(ctx, args.SearchContextID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SearchContexts().DeleteSearchContextSubscriptionForUser(ctx, userID, searchContext.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) PinSearchContextResolution(ctx context.Context, args graphqlbackend.PinSearchContextResolutionArgs) (graphqlbackend.SearchContextResolutionResolver, error) {
+	searchContext, err := r.manageableSearchContextByID(ctx, args.SearchContextID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolutionID, err := unmarshalSearchContextResolutionID(args.ResolutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	store := r.db.SearchContexts()
+	if err := store.SetPinnedSearchContextResolution(ctx, searchContext.ID, resolutionID); err != nil {
+		return nil, err
+	}
+	resolution, err := store.GetSearchContextResolution(ctx, searchContext.ID, resolutionID)
+	if err != nil {
+		return nil, err
+	}
+	return &searchContextResolutionResolver{resolution: resolution, db: r.db}, nil
+}
+
+func (r *Resolver) UnpinSearchContextResolution(ctx context.Context, args graphqlbackend.UnpinSearchContextResolutionArgs) (*graphqlbackend.EmptyResponse, error) {
+	searchContext, err := r.manageableSearchContextByID(ctx, args.SearchContextID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.SearchContexts().SetPinnedSearchContextResolution(ctx, searchContext.ID, 0); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) searchContextByID(ctx context.Context, id graphql.ID) (*types.SearchContext, error) {
+	searchContextSpec, err := unmarshalSearchContextID(id)
+	if err != nil {
+		return nil, err
+	}
+	return searchcontexts.ResolveSearchContextSpec(ctx, r.db, searchContextSpec)
+}
+
+// manageableSearchContextByID returns the search context if the current user has write access to it.
+func (r *Resolver) manageableSearchContextByID(ctx context.Context, id graphql.ID) (*types.SearchContext, error) {
+	searchContext, err := r.searchContextByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users that can manage the search context can pin its resolutions.
+	if searchcontexts.IsAutoDefinedSearchContext(searchContext) {
+		return nil, errors.New("cannot pin resolutions of auto-defined search contexts")
+	}
+	if err := searchcontexts.ValidateSearchContextWriteAccessForCurrentUser(ctx, r.db, searchContext.NamespaceUserID, searchContext.NamespaceOrgID, searchContext.Public); err != nil {
+		return nil, err
+	}
+	return searchContext, nil
+}
+
+func (r *searchContextResolver) ViewerIsSubscribed(ctx context.Context) (bool, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || searchcontexts.IsAutoDefinedSearchContext(r.sc) {
+		return false, nil
+	}
+	return r.db.SearchContexts().IsSearchContextSubscriber(ctx, a.UID, r.sc.ID)
+}
+
+func (r *searchContextResolver) Resolutions(ctx context.Context, args graphqlbackend.ListSearchContextResolutionsArgs) (graphqlbackend.SearchContextResolutionConnectionResolver, error) {
+	if searchcontexts.IsAutoDefinedSearchContext(r.sc) || r.sc.Query == "" {
+		return &searchContextResolutionConnectionResolver{}, nil
+	}
+
+	afterCursor, err := unmarshalSearchContextCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	store := r.db.SearchContexts()
+	// Request one extra to determine if there are more pages
+	resolutions, err := store.ListSearchContextResolutions(ctx, r.sc.ID, database.ListSearchContextsPageOptions{First: args.First + 1, After: afterCursor})
+	if err != nil {
+		return nil, err
+	}
+	count, err := store.CountSearchContextResolutions(ctx, r.sc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := false
+	if len(resolutions) == int(args.First)+1 {
+		hasNextPage = true
+		resolutions = resolutions[:len(resolutions)-1]
+	}
+
+	nodes := make([]graphqlbackend.SearchContextResolutionResolver, 0, len(resolutions))
+	for _, resolution := range resolutions {
+		nodes = append(nodes, &searchContextResolutionResolver{resolution: resolution, db: r.db})
+	}
+	return &searchContextResolutionConnectionResolver{
+		afterCursor: afterCursor,
+		nodes:       nodes,
+		totalCount:  count,
+		hasNextPage: hasNextPage,
+	}, nil
+}
+
+func (r *searchContextResolver) PinnedResolution(ctx context.Context) (graphqlbackend.SearchContextResolutionResolver, error) {
+	if searchcontexts.IsAutoDefinedSearchContext(r.sc) || r.sc.Query == "" {
+		return nil, nil
+	}
+
+	resolution, err := r.db.SearchContexts().GetPinnedSearchContextResolution(ctx, r.sc.ID)
+	if errors.Is(err, database.ErrSearchContextResolutionNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &searchContextResolutionResolver{resolution: resolution, db: r.db}, nil
+}
+
+type searchContextResolutionResolver struct {
+	resolution *types.SearchContextResolution
+	db         database.DB
+}
+
+func (r *searchContextResolutionResolver) ID() graphql.ID {
+	return marshalSearchContextResolutionID(r.resolution.ID)
+}
+
+func (r *searchContextResolutionResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.resolution.CreatedAt}
+}
+
+func (r *searchContextResolutionResolver) Pinned() bool {
+	return r.resolution.Pinned
+}
+
+func (r *searchContextResolutionResolver) Repositories(ctx context.Context) ([]graphqlbackend.SearchContextRepositoryRevisionsResolver, error) {
+	return r.toResolvers(ctx, r.resolution.Repositories)
+}
+
+func (r *searchContextResolutionResolver) Added(ctx context.Context, args graphqlbackend.SearchContextResolutionDiffArgs) ([]graphqlbackend.SearchContextRepositoryRevisionsResolver, error) {
+	base, err := r.base(ctx, args.Base)
+	if err != nil {
+		return nil, err
+	}
+	added, _ := searchcontexts.DiffResolutionRepositories(base, r.resolution.Repositories)
+	return r.toResolvers(ctx, added)
+}
+
+func (r *searchContextResolutionResolver) Removed(ctx context.Context, args graphqlbackend.SearchContextResolutionDiffArgs) ([]graphqlbackend.SearchContextRepositoryRevisionsResolver, error) {
+	base, err := r.base(ctx, args.Base)
+	if err != nil {
+		return nil, err
+	}
+	_, removed := searchcontexts.DiffResolutionRepositories(base, r.resolution.Repositories)
+	return r.toResolvers(ctx, removed)
+}
+
+// base returns the repositories of the resolution to compare against: the given one, or the
+// previous resolution of the search context if none is given.
+func (r *searchContextResolutionResolver) base(ctx context.Context, id *graphql.ID) ([]types.SearchContextResolutionRepository, error) {
+	store := r.db.SearchContexts()
+	var (
+		base *types.SearchContextResolution
+		err  error
+	)
+	if id != nil {
+		baseID, err := unmarshalSearchContextResolutionID(*id)
+		if err != nil {
+			return nil, err
+		}
+		base, err = store.GetSearchContextResolution(ctx, r.resolution.SearchContextID, baseID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		base, err = store.GetPreviousSearchContextResolution(ctx, r.resolution.SearchContextID, r.resolution.ID)
+		if errors.Is(err, database.ErrSearchContextResolutionNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return base.Repositories, nil
+}
+
+// toResolvers returns resolvers for the repositories the current user has access to.
+func (r *searchContextResolutionResolver) toResolvers(ctx context.Context, repositories []types.SearchContextResolutionRepository) ([]graphqlbackend.SearchContextRepositoryRevisionsResolver, error) {
+	if len(repositories) == 0 {
+		return []graphqlbackend.SearchContextRepositoryRevisionsResolver{}, nil
+	}
+
+	ids := make([]api.RepoID, 0, len(repositories))
+	for _, repository := range repositories {
+		ids = append(ids, repository.ID)
+	}
+	// 🚨 SECURITY: Resolutions contain all repositories the query matched, so they are
+	// filtered by the repositories the current user has access to.
+	repos, err := r.db.Repos().GetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	reposByID := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByID[repo.ID] = repo
+	}
+
+	resolvers := make([]graphqlbackend.SearchContextRepositoryRevisionsResolver, 0, len(repos))
+	for _, repository := range repositories {
+		repo, ok := reposByID[repository.ID]
+		if !ok {
+			continue
+		}
+		revisions := repository.Revisions
+		if revisions == nil {
+			revisions = []string{}
+		}
+		resolvers = append(resolvers, &searchContextRepositoryRevisionsResolver{graphqlbackend.NewRepositoryResolver(r.db, gitserver.NewClient(), repo), revisions})
+	}
+	return resolvers, nil
+}
+
+type searchContextResolutionConnectionResolver struct {
+	afterCursor int32
+	nodes       []graphqlbackend.SearchContextResolutionResolver
+	totalCount  int32
+	hasNextPage bool
+}
+
+func (s *searchContextResolutionConnectionResolver) Nodes() []graphqlbackend.SearchContextResolutionResolver {
+	return s.nodes
+}
+
+func (s *searchContextResolutionConnectionResolver) TotalCount() int32 {
+	return s.totalCount
+}
+
+func (s *searchContextResolutionConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	if len(s.nodes) == 0 || !s.hasNextPage {
+		return graphqlutil.HasNextPage(false)
+	}
+	return graphqlutil.NextPageCursor(marshalSearchContextCursor(s.afterCursor + int32(len(s.nodes))))
+}
@@ -0,0 +1,94 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchContextResolutionDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	previous := &types.SearchContextResolution{
+		ID:              1,
+		SearchContextID: 1,
+		Repositories: []types.SearchContextResolutionRepository{
+			{ID: 1, Name: "github.com/example/a"},
+			{ID: 2, Name: "github.com/example/b"},
+		},
+	}
+	latest := &types.SearchContextResolution{
+		ID:              2,
+		SearchContextID: 1,
+		Repositories: []types.SearchContextResolutionRepository{
+			{ID: 2, Name: "github.com/example/b"},
+			{ID: 3, Name: "github.com/example/c", Revisions: []string{"main"}},
+			{ID: 4, Name: "github.com/example/private"},
+		},
+	}
+
+	sc := database.NewMockSearchContextsStore()
+	sc.GetPreviousSearchContextResolutionFunc.SetDefaultReturn(previous, nil)
+	sc.GetSearchContextResolutionFunc.SetDefaultReturn(previous, nil)
+
+	// The viewer does not have access to the private repository.
+	repos := database.NewMockRepoStore()
+	repos.GetByIDsFunc.SetDefaultHook(func(_ context.Context, ids ...api.RepoID) ([]*types.Repo, error) {
+		var rs []*types.Repo
+		for _, id := range ids {
+			if id == 4 {
+				continue
+			}
+			rs = append(rs, &types.Repo{ID: id})
+		}
+		return rs, nil
+	})
+
+	db := database.NewMockDB()
+	db.SearchContextsFunc.SetDefaultReturn(sc)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	repoIDs := func(t *testing.T, resolvers []graphqlbackend.SearchContextRepositoryRevisionsResolver) []api.RepoID {
+		t.Helper()
+		ids := make([]api.RepoID, 0, len(resolvers))
+		for _, r := range resolvers {
+			ids = append(ids, r.Repository().IDInt32())
+		}
+		return ids
+	}
+
+	r := &searchContextResolutionResolver{resolution: latest, db: db}
+
+	repositories, err := r.Repositories(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]api.RepoID{2, 3}, repoIDs(t, repositories)); diff != "" {
+		t.Fatalf("unexpected repositories (-want +got):\n%s", diff)
+	}
+
+	added, err := r.Added(ctx, graphqlbackend.SearchContextResolutionDiffArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]api.RepoID{3}, repoIDs(t, added)); diff != "" {
+		t.Fatalf("unexpected added repositories (-want +got):\n%s", diff)
+	}
+
+	base := marshalSearchContextResolutionID(previous.ID)
+	removed, err := r.Removed(ctx, graphqlbackend.SearchContextResolutionDiffArgs{Base: &base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]api.RepoID{1}, repoIDs(t, removed)); diff != "" {
+		t.Fatalf("unexpected removed repositories (-want +got):\n%s", diff)
+	}
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestSearchContexts(t *testing.T) {
@@ -155,3 +157,38 @@ func TestSearchContextsStarDefaultPermissions(t *testing.T) {
 		t.Fatalf("expected no error, got %s", err)
 	}
 }
+
+func TestSearchContextsWritePermission(t *testing.T) {
+	t.Parallel()
+
+	userID := int32(1)
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: userID})
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: userID, Username: "alice"}, nil)
+
+	permissions := database.NewMockPermissionStore()
+	permissions.GetPermissionForUserFunc.SetDefaultReturn(nil, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+
+	r := &Resolver{db: db}
+	graphqlSearchContextID := marshalSearchContextID("test")
+	wantErr := &rbac.ErrNotAuthorized{Permission: rbac.SearchContextsWritePermission}
+
+	_, err := r.CreateSearchContext(ctx, graphqlbackend.CreateSearchContextArgs{SearchContext: graphqlbackend.SearchContextInputArgs{Name: "test"}})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	_, err = r.UpdateSearchContext(ctx, graphqlbackend.UpdateSearchContextArgs{ID: graphqlSearchContextID, SearchContext: graphqlbackend.SearchContextEditInputArgs{Name: "test"}})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	_, err = r.DeleteSearchContext(ctx, graphqlbackend.DeleteSearchContextArgs{ID: graphqlSearchContextID})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	mockrequire.NotCalled(t, db.SearchContextsFunc)
+}
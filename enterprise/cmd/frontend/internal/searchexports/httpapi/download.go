@@ -0,0 +1,72 @@
+package httpapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexports/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewDownloadHandler returns a handler that streams the file of a completed search
+// export from the upload store.
+func NewDownloadHandler(logger log.Logger, db database.DB, store exports.Store, uploadStore uploadstore.Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !actor.FromContext(ctx).IsAuthenticated() {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := resolvers.UnmarshalSearchExportID(graphql.ID(mux.Vars(r)["id"]))
+		if err != nil {
+			http.Error(w, "invalid search export ID", http.StatusBadRequest)
+			return
+		}
+
+		export, err := store.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, exports.ErrNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+			} else {
+				http.Error(w, fmt.Sprintf("failed to get search export: %v", err), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		// 🚨 SECURITY: Only the creator of an export and site admins may download it.
+		// Respond with not found, so the existence of other users' exports is not leaked.
+		if err := auth.CheckSiteAdminOrSameUser(ctx, db, export.UserID); err != nil {
+			http.Error(w, exports.ErrNotFound.Error(), http.StatusNotFound)
+			return
+		}
+
+		if export.ObjectKey == nil {
+			http.Error(w, "search export has not completed", http.StatusNotFound)
+			return
+		}
+
+		rc, err := uploadStore.Get(ctx, *export.ObjectKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read search export: %v", err), http.StatusInternalServerError)
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", export.Format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename()))
+		if _, err := io.Copy(w, rc); err != nil {
+			logger.Warn("failed to write search export", log.Int("id", export.ID), log.Error(err))
+		}
+	})
+}
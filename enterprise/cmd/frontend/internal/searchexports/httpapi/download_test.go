@@ -0,0 +1,83 @@
+package httpapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexports/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+func TestDownloadHandler(t *testing.T) {
+	key := "search-exports/5.csv"
+	store := exports.NewMockStore()
+	store.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int) (*exports.SearchExport, error) {
+		switch id {
+		case 5:
+			return &exports.SearchExport{ID: 5, State: "completed", UserID: 1, Format: exports.FormatCSV, ObjectKey: &key}, nil
+		case 6:
+			return &exports.SearchExport{ID: 6, State: "processing", UserID: 1, Format: exports.FormatCSV}, nil
+		}
+		return nil, exports.ErrNotFound
+	})
+
+	uploadStore := uploadstoremocks.NewMockStore()
+	uploadStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("type,repository\n")), nil)
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: actor.FromContext(ctx).UID}, nil
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	router := mux.NewRouter()
+	router.Path("/search/exports/{id}").Handler(NewDownloadHandler(logtest.Scoped(t), db, store, uploadStore))
+
+	download := func(userID int32, id int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/search/exports/"+string(resolvers.MarshalSearchExportID(id)), nil)
+		if userID != 0 {
+			req = req.WithContext(actor.WithActor(req.Context(), actor.FromUser(userID)))
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("creator", func(t *testing.T) {
+		rec := download(1, 5)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="search-export-5.csv"`, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, "type,repository\n", rec.Body.String())
+		assert.Equal(t, key, uploadStore.GetFunc.History()[0].Arg1)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, download(0, 5).Code)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, download(2, 5).Code)
+	})
+
+	t.Run("not completed", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, download(1, 6).Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, download(1, 7).Code)
+	})
+}
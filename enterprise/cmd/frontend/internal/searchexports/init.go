@@ -0,0 +1,41 @@
+package searchexports
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexports/httpapi"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexports/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func LoadConfig() {
+	exports.ConfigInst.Load()
+}
+
+func Init(
+	ctx context.Context,
+	observationCtx *observation.Context,
+	db database.DB,
+	_ codeintel.Services,
+	_ conftypes.UnifiedWatchable,
+	enterpriseServices *enterprise.Services,
+) error {
+	if err := exports.ConfigInst.Validate(); err != nil {
+		return err
+	}
+
+	uploadStore, err := exports.NewUploadStore(context.Background(), observationCtx, exports.ConfigInst)
+	if err != nil {
+		return err
+	}
+
+	store := exports.NewStore(db)
+	enterpriseServices.SearchExportsResolver = resolvers.NewResolver(db, store, exports.ConfigInst.MaxActivePerUser)
+	enterpriseServices.SearchExportDownloadHandler = httpapi.NewDownloadHandler(observationCtx.Logger.Scoped("searchexports", "search export downloads"), db, store, uploadStore)
+	return nil
+}
@@ -0,0 +1,232 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func NewResolver(db database.DB, store exports.Store, maxActivePerUser int) graphqlbackend.SearchExportsResolver {
+	return &Resolver{db: db, store: store, maxActivePerUser: maxActivePerUser}
+}
+
+type Resolver struct {
+	db               database.DB
+	store            exports.Store
+	maxActivePerUser int
+}
+
+func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
+	return map[string]graphqlbackend.NodeByIDFunc{
+		searchExportIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			export, err := r.searchExportByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return &searchExportResolver{db: r.db, export: export}, nil
+		},
+	}
+}
+
+func (r *Resolver) CreateSearchExport(ctx context.Context, args graphqlbackend.CreateSearchExportArgs) (graphqlbackend.SearchExportResolver, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	format := exports.Format(strings.ToLower(args.Format))
+	if !format.Valid() {
+		return nil, errors.Errorf("invalid search export format: %s", args.Format)
+	}
+	if err := exports.ValidateQuery(args.Query); err != nil {
+		return nil, err
+	}
+
+	export, err := r.store.Create(ctx, user.ID, args.Query, format, r.maxActivePerUser)
+	if err != nil {
+		return nil, err
+	}
+	return &searchExportResolver{db: r.db, export: export}, nil
+}
+
+func (r *Resolver) CancelSearchExport(ctx context.Context, args graphqlbackend.CancelSearchExportArgs) (graphqlbackend.SearchExportResolver, error) {
+	export, err := r.searchExportByID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.Cancel(ctx, export.ID); err != nil {
+		return nil, err
+	}
+	export, err = r.store.GetByID(ctx, export.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &searchExportResolver{db: r.db, export: export}, nil
+}
+
+func (r *Resolver) SearchExports(ctx context.Context, args graphqlbackend.ListSearchExportsArgs) (*graphqlutil.ConnectionResolver[graphqlbackend.SearchExportResolver], error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &searchExportsConnectionStore{db: r.db, store: r.store, userID: user.ID}
+	return graphqlutil.NewConnectionResolver[graphqlbackend.SearchExportResolver](store, &args.ConnectionResolverArgs, nil)
+}
+
+// searchExportByID returns the export if the current user requested it or is a site
+// admin.
+func (r *Resolver) searchExportByID(ctx context.Context, id graphql.ID) (*exports.SearchExport, error) {
+	exportID, err := UnmarshalSearchExportID(id)
+	if err != nil {
+		return nil, err
+	}
+	export, err := r.store.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Exports contain the results of a search run as their creator, so
+	// only the creator and site admins may access them.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, export.UserID); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+type searchExportsConnectionStore struct {
+	db     database.DB
+	store  exports.Store
+	userID int32
+}
+
+func (s *searchExportsConnectionStore) ComputeTotal(ctx context.Context) (*int32, error) {
+	count, err := s.store.CountForUser(ctx, s.userID)
+	if err != nil {
+		return nil, err
+	}
+	total := int32(count)
+	return &total, nil
+}
+
+func (s *searchExportsConnectionStore) ComputeNodes(ctx context.Context, args *database.PaginationArgs) ([]graphqlbackend.SearchExportResolver, error) {
+	list, err := s.store.ListForUser(ctx, s.userID, args)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.SearchExportResolver, 0, len(list))
+	for _, export := range list {
+		resolvers = append(resolvers, &searchExportResolver{db: s.db, export: export})
+	}
+	return resolvers, nil
+}
+
+func (s *searchExportsConnectionStore) MarshalCursor(node graphqlbackend.SearchExportResolver, _ database.OrderBy) (*string, error) {
+	if node == nil {
+		return nil, errors.New("node is nil")
+	}
+	cursor := string(node.ID())
+	return &cursor, nil
+}
+
+func (s *searchExportsConnectionStore) UnmarshalCursor(cursor string, _ database.OrderBy) (*string, error) {
+	nodeID, err := UnmarshalSearchExportID(graphql.ID(cursor))
+	if err != nil {
+		return nil, err
+	}
+	id := strconv.Itoa(nodeID)
+	return &id, nil
+}
+
+type searchExportResolver struct {
+	db     database.DB
+	export *exports.SearchExport
+}
+
+func (r *searchExportResolver) ID() graphql.ID {
+	return MarshalSearchExportID(r.export.ID)
+}
+
+func (r *searchExportResolver) Query() string {
+	return r.export.Query
+}
+
+func (r *searchExportResolver) Format() string {
+	return strings.ToUpper(string(r.export.Format))
+}
+
+func (r *searchExportResolver) State() string {
+	return strings.ToUpper(r.export.State)
+}
+
+func (r *searchExportResolver) FailureMessage() *string {
+	return r.export.FailureMessage
+}
+
+func (r *searchExportResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.export.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *searchExportResolver) QueuedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.export.QueuedAt}
+}
+
+func (r *searchExportResolver) StartedAt() *gqlutil.DateTime {
+	if r.export.StartedAt == nil {
+		return nil
+	}
+	return gqlutil.FromTime(*r.export.StartedAt)
+}
+
+func (r *searchExportResolver) FinishedAt() *gqlutil.DateTime {
+	if r.export.FinishedAt == nil {
+		return nil
+	}
+	return gqlutil.FromTime(*r.export.FinishedAt)
+}
+
+func (r *searchExportResolver) RepositoriesTotal() int32 {
+	return int32(r.export.ReposTotal)
+}
+
+func (r *searchExportResolver) RepositoriesDone() int32 {
+	return int32(r.export.ReposDone)
+}
+
+func (r *searchExportResolver) MatchCount() int32 {
+	return int32(r.export.MatchCount)
+}
+
+func (r *searchExportResolver) DownloadURL() *string {
+	if r.export.State != "completed" || r.export.ObjectKey == nil {
+		return nil
+	}
+	url := "/.api/search/exports/" + string(r.ID())
+	return &url
+}
+
+const searchExportIDKind = "SearchExport"
+
+func MarshalSearchExportID(id int) graphql.ID {
+	return relay.MarshalID(searchExportIDKind, id)
+}
+
+func UnmarshalSearchExportID(id graphql.ID) (exportID int, err error) {
+	err = relay.UnmarshalSpec(id, &exportID)
+	return
+}
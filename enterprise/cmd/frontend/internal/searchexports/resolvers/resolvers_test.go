@@ -0,0 +1,88 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newTestDB(userID int32, siteAdmin bool) database.DB {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: userID, SiteAdmin: siteAdmin}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	return db
+}
+
+func TestCreateSearchExport(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	store := exports.NewMockStore()
+	store.CreateFunc.SetDefaultHook(func(_ context.Context, userID int32, query string, format exports.Format, maxActive int) (*exports.SearchExport, error) {
+		return &exports.SearchExport{ID: 5, State: "queued", UserID: userID, Query: query, Format: format}, nil
+	})
+	r := NewResolver(newTestDB(1, false), store, 2)
+
+	export, err := r.CreateSearchExport(ctx, graphqlbackend.CreateSearchExportArgs{Query: "repo:a foo", Format: "JSONL"})
+	require.NoError(t, err)
+	assert.Equal(t, "QUEUED", export.State())
+	assert.Equal(t, "JSONL", export.Format())
+	assert.Nil(t, export.DownloadURL())
+
+	require.Len(t, store.CreateFunc.History(), 1)
+	call := store.CreateFunc.History()[0]
+	assert.Equal(t, int32(1), call.Arg1)
+	assert.Equal(t, exports.FormatJSONLines, call.Arg3)
+	assert.Equal(t, 2, call.Arg4)
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := r.CreateSearchExport(ctx, graphqlbackend.CreateSearchExportArgs{Query: "foo", Format: "XML"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := r.CreateSearchExport(ctx, graphqlbackend.CreateSearchExportArgs{Query: "foo count:many", Format: "CSV"})
+		assert.Error(t, err)
+	})
+
+	t.Run("too many active exports", func(t *testing.T) {
+		store.CreateFunc.PushReturn(nil, exports.ErrTooManyActiveExports)
+		_, err := r.CreateSearchExport(ctx, graphqlbackend.CreateSearchExportArgs{Query: "foo", Format: "CSV"})
+		assert.ErrorIs(t, err, exports.ErrTooManyActiveExports)
+	})
+}
+
+func TestCancelSearchExport(t *testing.T) {
+	key := "search-exports/5.csv"
+	store := exports.NewMockStore()
+	store.GetByIDFunc.SetDefaultReturn(&exports.SearchExport{ID: 5, State: "completed", UserID: 1, Format: exports.FormatCSV, ObjectKey: &key}, nil)
+
+	args := graphqlbackend.CancelSearchExportArgs{ID: MarshalSearchExportID(5)}
+
+	t.Run("other user", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(2))
+		r := NewResolver(newTestDB(2, false), store, 2)
+		_, err := r.CancelSearchExport(ctx, args)
+		assert.ErrorIs(t, err, auth.ErrMustBeSiteAdminOrSameUser)
+		assert.Empty(t, store.CancelFunc.History())
+	})
+
+	t.Run("creator", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+		r := NewResolver(newTestDB(1, false), store, 2)
+		export, err := r.CancelSearchExport(ctx, args)
+		require.NoError(t, err)
+		require.Len(t, store.CancelFunc.History(), 1)
+		assert.Equal(t, 5, store.CancelFunc.History()[0].Arg1)
+		assert.Equal(t, "/.api/search/exports/"+string(MarshalSearchExportID(5)), *export.DownloadURL())
+	})
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/repos/webhooks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexports"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
 	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/scim"
@@ -58,6 +59,7 @@ var initFunctions = map[string]EnterpriseInitializer{
 	"notebooks":      notebooks.Init,
 	"scim":           scim.Init,
 	"searchcontexts": searchcontexts.Init,
+	"searchexports":  searchexports.Init,
 	"repos.webhooks": webhooks.Init,
 	"embeddings":     embeddings.Init,
 	"rbac":           rbac.Init,
@@ -0,0 +1,133 @@
+package notebooks
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// To avoid a dependency on the notebooks GraphQL resolvers we redeclare the ID kind.
+const notebookIDKind = "Notebook"
+
+type reportEmailMatch struct {
+	Label string
+	URL   string
+}
+
+type reportEmailBlock struct {
+	Query       string
+	ResultCount int
+	LimitHit    bool
+	Error       string
+	// Change is the change in result count since the previous run, e.g. "+3". It is
+	// empty for the first run of a block.
+	Change string
+	// AddedMatches are the top matches that were not part of the previous run, or all
+	// top matches for the first run of a block.
+	AddedMatches []reportEmailMatch
+}
+
+type reportEmailData struct {
+	Title       string
+	NotebookURL string
+	Blocks      []reportEmailBlock
+}
+
+// notify emails a summary of the snapshot to the user, including the changes since the
+// previous snapshot if there is one.
+func (h *reportsHandler) notify(ctx context.Context, userID int32, notebook *notebooks.Notebook, snapshot, previous *notebooks.NotebookReportSnapshot) error {
+	email, verified, err := h.db.UserEmails().GetPrimaryEmail(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "get user primary email")
+	}
+	if !verified {
+		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
+	}
+
+	externalURL, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return errors.Wrap(err, "parsing external URL")
+	}
+
+	return h.sendEmail(ctx, "notebook_report", txtypes.Message{
+		To:       []string{email},
+		Template: notebookReportEmailTemplate,
+		Data:     newReportEmailData(externalURL, notebook, snapshot, previous),
+	})
+}
+
+func newReportEmailData(externalURL *url.URL, notebook *notebooks.Notebook, snapshot, previous *notebooks.NotebookReportSnapshot) reportEmailData {
+	data := reportEmailData{
+		Title:       notebook.Title,
+		NotebookURL: externalURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/notebooks/%s", relay.MarshalID(notebookIDKind, notebook.ID))}).String(),
+	}
+
+	for _, block := range snapshot.Blocks {
+		emailBlock := reportEmailBlock{
+			Query:       block.Query,
+			ResultCount: block.ResultCount,
+			LimitHit:    block.LimitHit,
+			Error:       block.Error,
+		}
+
+		added := block.TopMatches
+		if previous != nil {
+			if previousBlock := previous.Blocks.Block(block.BlockID); previousBlock != nil && previousBlock.Error == "" && block.Error == "" {
+				diff := notebooks.DiffBlockSnapshots(*previousBlock, block)
+				emailBlock.Change = fmt.Sprintf("%+d", diff.ResultCountDelta)
+				added = diff.AddedMatches
+			}
+		}
+		for _, m := range added {
+			label := m.Label
+			if label == "" {
+				label = m.Repository
+				if m.Path != "" {
+					label += "/" + m.Path
+				}
+			}
+			emailBlock.AddedMatches = append(emailBlock.AddedMatches, reportEmailMatch{
+				Label: label,
+				URL:   externalURL.ResolveReference(&url.URL{Path: m.URL}).String(),
+			})
+		}
+
+		data.Blocks = append(data.Blocks, emailBlock)
+	}
+	return data
+}
+
+var notebookReportEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph notebook report: {{.Title}}`,
+	Text: `
+Scheduled report for the notebook "{{.Title}}": {{.NotebookURL}}
+{{range .Blocks}}
+Query: {{.Query}}
+{{if .Error}}Failed: {{.Error}}
+{{else}}Results: {{.ResultCount}}{{if .LimitHit}}+{{end}}{{if .Change}} ({{.Change}} since the previous run){{end}}
+{{if .AddedMatches}}New matches:
+{{range .AddedMatches}}  - {{.Label}}: {{.URL}}
+{{end}}{{end}}{{end}}{{end}}`,
+	HTML: `
+<p>Scheduled report for the notebook <a href="{{.NotebookURL}}"><strong>{{.Title}}</strong></a>.</p>
+{{range .Blocks}}
+<p>
+<code>{{.Query}}</code><br>
+{{if .Error}}Failed: {{.Error}}
+{{else}}Results: {{.ResultCount}}{{if .LimitHit}}+{{end}}{{if .Change}} ({{.Change}} since the previous run){{end}}
+{{end}}
+</p>
+{{if .AddedMatches}}<p>New matches:</p>
+<ul>
+{{range .AddedMatches}}<li><a href="{{.URL}}">{{.Label}}</a></li>
+{{end}}</ul>{{end}}
+{{end}}`,
+})
@@ -0,0 +1,53 @@
+package notebooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// reportsInterval is how often due notebook report schedules are picked up.
+const reportsInterval = time.Minute
+
+// notebookReportsJob runs the query blocks of scheduled notebooks and stores snapshots of
+// their results.
+type notebookReportsJob struct{}
+
+func NewNotebookReportsJob() job.Job {
+	return &notebookReportsJob{}
+}
+
+func (j *notebookReportsJob) Description() string {
+	return "runs the query blocks of scheduled notebooks and stores snapshots of their results"
+}
+
+func (j *notebookReportsJob) Config() []env.Config {
+	return nil
+}
+
+func (j *notebookReportsJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "notebooks.reports", "runs scheduled notebook reports",
+			reportsInterval, &reportsHandler{
+				db:        db,
+				store:     notebooks.Notebooks(db),
+				logger:    observationCtx.Logger.Scoped("reports", "runs scheduled notebook reports"),
+				search:    streamingSearch,
+				sendEmail: internalapi.Client.SendEmail,
+				now:       time.Now,
+			},
+		),
+	}, nil
+}
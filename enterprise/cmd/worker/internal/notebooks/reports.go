@@ -0,0 +1,123 @@
+package notebooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// reportsBatchSize is the maximum number of schedules run in a single iteration.
+	reportsBatchSize = 50
+	// maxSnapshotsPerNotebook is the number of snapshots kept per notebook. Older ones
+	// are deleted after each run.
+	maxSnapshotsPerNotebook = 100
+)
+
+// reportsHandler runs the query blocks of notebooks whose report schedule is due, and
+// stores a snapshot of the results.
+type reportsHandler struct {
+	db        database.DB
+	store     notebooks.NotebooksStore
+	logger    log.Logger
+	search    searchFunc
+	sendEmail func(ctx context.Context, source string, message txtypes.Message) error
+	now       func() time.Time
+}
+
+var (
+	_ goroutine.Handler      = &reportsHandler{}
+	_ goroutine.ErrorHandler = &reportsHandler{}
+)
+
+func (h *reportsHandler) Handle(ctx context.Context) error {
+	schedules, err := h.store.ListDueNotebookReportSchedules(ctx, h.now(), reportsBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "listing due notebook report schedules")
+	}
+
+	var errs error
+	for _, schedule := range schedules {
+		if err := h.run(ctx, schedule); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "running report for notebook %d", schedule.NotebookID))
+		}
+	}
+	return errs
+}
+
+func (h *reportsHandler) HandleError(err error) {
+	h.logger.Error("error running notebook reports", log.Error(err))
+}
+
+func (h *reportsHandler) run(ctx context.Context, schedule *notebooks.NotebookReportSchedule) error {
+	ranAt := h.now()
+	// The next run is scheduled even if this run fails, so a notebook that keeps failing
+	// is retried on its regular schedule rather than on every iteration.
+	defer func() {
+		if err := h.store.MarkNotebookReportScheduleRun(ctx, schedule.ID, ranAt, ranAt.Add(schedule.Frequency.Interval())); err != nil {
+			h.logger.Error("failed to mark notebook report as run", log.Int64("scheduleID", schedule.ID), log.Error(err))
+		}
+	}()
+
+	// 🚨 SECURITY: Query blocks are run as the user that created the schedule, so the
+	// snapshot only contains results that user is allowed to see.
+	userCtx := actor.WithActor(ctx, actor.FromUser(schedule.UserID))
+	notebook, err := h.store.GetNotebook(userCtx, schedule.NotebookID)
+	if errors.Is(err, notebooks.ErrNotebookNotFound) {
+		// The user no longer has access to the notebook.
+		h.logger.Warn("skipping notebook report of inaccessible notebook",
+			log.Int64("notebookID", schedule.NotebookID),
+			log.Int32("userID", schedule.UserID))
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "getting notebook")
+	}
+
+	blocks := notebooks.NotebookBlockSnapshots{}
+	for _, block := range notebook.Blocks {
+		if block.Type != notebooks.NotebookQueryBlockType || block.QueryInput == nil {
+			continue
+		}
+		snapshot, err := h.search(userCtx, block.QueryInput.Text)
+		if err != nil {
+			snapshot = &notebooks.NotebookBlockSnapshot{Query: block.QueryInput.Text, Error: err.Error()}
+		}
+		snapshot.BlockID = block.ID
+		blocks = append(blocks, *snapshot)
+	}
+
+	previous, err := h.store.GetPreviousNotebookReportSnapshot(ctx, notebook.ID, schedule.UserID, 0)
+	if errors.Is(err, notebooks.ErrNotebookReportSnapshotNotFound) {
+		previous = nil
+	} else if err != nil {
+		return errors.Wrap(err, "getting previous snapshot")
+	}
+
+	snapshot, err := h.store.CreateNotebookReportSnapshot(ctx, notebook.ID, schedule.UserID, blocks)
+	if err != nil {
+		return errors.Wrap(err, "creating snapshot")
+	}
+	if err := h.store.DeleteOldNotebookReportSnapshots(ctx, notebook.ID, maxSnapshotsPerNotebook); err != nil {
+		return errors.Wrap(err, "deleting old snapshots")
+	}
+
+	if schedule.SendEmail && conf.CanSendEmail() {
+		if err := h.notify(ctx, schedule.UserID, notebook, snapshot, previous); err != nil {
+			// Failing to reach the user should not fail the run, the snapshot is stored.
+			h.logger.Warn("failed to send notebook report email",
+				log.Int64("notebookID", notebook.ID),
+				log.Int32("userID", schedule.UserID),
+				log.Error(err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package notebooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// fakeStore implements the parts of the notebooks store used by the reports handler.
+type fakeStore struct {
+	notebooks.NotebooksStore
+
+	schedules []*notebooks.NotebookReportSchedule
+	notebook  *notebooks.Notebook
+	previous  *notebooks.NotebookReportSnapshot
+
+	getNotebookActors []*actor.Actor
+	created           []notebooks.NotebookBlockSnapshots
+	nextRunAt         map[int64]time.Time
+}
+
+func (s *fakeStore) ListDueNotebookReportSchedules(context.Context, time.Time, int) ([]*notebooks.NotebookReportSchedule, error) {
+	return s.schedules, nil
+}
+
+func (s *fakeStore) GetNotebook(ctx context.Context, _ int64) (*notebooks.Notebook, error) {
+	s.getNotebookActors = append(s.getNotebookActors, actor.FromContext(ctx))
+	if s.notebook == nil {
+		return nil, notebooks.ErrNotebookNotFound
+	}
+	return s.notebook, nil
+}
+
+func (s *fakeStore) GetPreviousNotebookReportSnapshot(context.Context, int64, int32, int64) (*notebooks.NotebookReportSnapshot, error) {
+	if s.previous == nil {
+		return nil, notebooks.ErrNotebookReportSnapshotNotFound
+	}
+	return s.previous, nil
+}
+
+func (s *fakeStore) CreateNotebookReportSnapshot(_ context.Context, notebookID int64, userID int32, blocks notebooks.NotebookBlockSnapshots) (*notebooks.NotebookReportSnapshot, error) {
+	s.created = append(s.created, blocks)
+	return &notebooks.NotebookReportSnapshot{ID: int64(len(s.created)), NotebookID: notebookID, UserID: userID, Blocks: blocks}, nil
+}
+
+func (s *fakeStore) DeleteOldNotebookReportSnapshots(context.Context, int64, int) error {
+	return nil
+}
+
+func (s *fakeStore) MarkNotebookReportScheduleRun(_ context.Context, scheduleID int64, _, nextRunAt time.Time) error {
+	if s.nextRunAt == nil {
+		s.nextRunAt = map[int64]time.Time{}
+	}
+	s.nextRunAt[scheduleID] = nextRunAt
+	return nil
+}
+
+func TestReportsHandler(t *testing.T) {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	match := func(path string) notebooks.NotebookSnapshotMatch {
+		return notebooks.NotebookSnapshotMatch{Repository: "github.com/a/b", Path: path, URL: "/github.com/a/b/-/blob/" + path}
+	}
+
+	newStore := func() *fakeStore {
+		return &fakeStore{
+			schedules: []*notebooks.NotebookReportSchedule{
+				{ID: 1, NotebookID: 10, UserID: 42, Frequency: notebooks.NotebookReportFrequencyWeekly, SendEmail: true},
+			},
+			notebook: &notebooks.Notebook{ID: 10, Title: "Deprecations", Blocks: notebooks.NotebookBlocks{
+				{ID: "md", Type: notebooks.NotebookMarkdownBlockType, MarkdownInput: &notebooks.NotebookMarkdownBlockInput{Text: "# Title"}},
+				{ID: "q1", Type: notebooks.NotebookQueryBlockType, QueryInput: &notebooks.NotebookQueryBlockInput{Text: "deprecated"}},
+				{ID: "q2", Type: notebooks.NotebookQueryBlockType, QueryInput: &notebooks.NotebookQueryBlockInput{Text: "broken("}},
+			}},
+			previous: &notebooks.NotebookReportSnapshot{ID: 1, NotebookID: 10, Blocks: notebooks.NotebookBlockSnapshots{
+				{BlockID: "q1", Query: "deprecated", ResultCount: 1, TopMatches: []notebooks.NotebookSnapshotMatch{match("a.go")}},
+			}},
+		}
+	}
+
+	search := func(_ context.Context, query string) (*notebooks.NotebookBlockSnapshot, error) {
+		if query == "broken(" {
+			return nil, errors.New("invalid query")
+		}
+		return &notebooks.NotebookBlockSnapshot{Query: query, ResultCount: 3, TopMatches: []notebooks.NotebookSnapshotMatch{match("a.go"), match("b.go")}}, nil
+	}
+
+	newDB := func() database.DB {
+		userEmails := database.NewMockUserEmailsStore()
+		userEmails.GetPrimaryEmailFunc.SetDefaultReturn("alice@example.com", true, nil)
+		db := database.NewMockDB()
+		db.UserEmailsFunc.SetDefaultReturn(userEmails)
+		return db
+	}
+
+	t.Run("runs query blocks and emails a summary", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			ExternalURL: "https://sourcegraph.example.com",
+			EmailSmtp:   &schema.SMTPServerConfig{},
+		}})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		store := newStore()
+		var sent []txtypes.Message
+		h := &reportsHandler{
+			db:     newDB(),
+			store:  store,
+			logger: logtest.Scoped(t),
+			search: search,
+			sendEmail: func(_ context.Context, _ string, message txtypes.Message) error {
+				sent = append(sent, message)
+				return nil
+			},
+			now: func() time.Time { return now },
+		}
+		require.NoError(t, h.Handle(context.Background()))
+
+		require.Len(t, store.getNotebookActors, 1)
+		assert.Equal(t, int32(42), store.getNotebookActors[0].UID)
+
+		require.Len(t, store.created, 1)
+		assert.Equal(t, notebooks.NotebookBlockSnapshots{
+			{BlockID: "q1", Query: "deprecated", ResultCount: 3, TopMatches: []notebooks.NotebookSnapshotMatch{match("a.go"), match("b.go")}},
+			{BlockID: "q2", Query: "broken(", Error: "invalid query"},
+		}, store.created[0])
+		assert.Equal(t, now.Add(7*24*time.Hour), store.nextRunAt[1])
+
+		require.Len(t, sent, 1)
+		assert.Equal(t, []string{"alice@example.com"}, sent[0].To)
+		data := sent[0].Data.(reportEmailData)
+		assert.Equal(t, "Deprecations", data.Title)
+		assert.Equal(t, "https://sourcegraph.example.com/notebooks/Tm90ZWJvb2s6MTA=", data.NotebookURL)
+		assert.Equal(t, []reportEmailBlock{
+			{
+				Query:       "deprecated",
+				ResultCount: 3,
+				Change:      "+2",
+				AddedMatches: []reportEmailMatch{
+					{Label: "github.com/a/b/b.go", URL: "https://sourcegraph.example.com/github.com/a/b/-/blob/b.go"},
+				},
+			},
+			{Query: "broken(", Error: "invalid query"},
+		}, data.Blocks)
+	})
+
+	t.Run("skips inaccessible notebooks", func(t *testing.T) {
+		conf.Mock(&conf.Unified{})
+		t.Cleanup(func() { conf.Mock(nil) })
+
+		store := newStore()
+		store.notebook = nil
+		h := &reportsHandler{
+			db:     newDB(),
+			store:  store,
+			logger: logtest.Scoped(t),
+			search: func(context.Context, string) (*notebooks.NotebookBlockSnapshot, error) {
+				t.Fatal("unexpected search")
+				return nil, nil
+			},
+			sendEmail: func(context.Context, string, txtypes.Message) error {
+				t.Fatal("unexpected email")
+				return nil
+			},
+			now: func() time.Time { return now },
+		}
+		require.NoError(t, h.Handle(context.Background()))
+		assert.Empty(t, store.created)
+		// The schedule still advances, so the notebook is not retried on every iteration.
+		assert.Equal(t, now.Add(7*24*time.Hour), store.nextRunAt[1])
+	})
+}
+
+func TestSnapshotCollector(t *testing.T) {
+	var c snapshotCollector
+	dec := c.decoder()
+
+	var matches []streamhttp.EventMatch
+	for i := 0; i < maxTopMatches; i++ {
+		matches = append(matches, &streamhttp.EventPathMatch{Repository: "r", Path: "p"})
+	}
+	dec.OnMatches([]streamhttp.EventMatch{
+		&streamhttp.EventContentMatch{Repository: "r", Path: "a.go", ChunkMatches: []streamhttp.ChunkMatch{{Content: "\n\tfunc a() {\nreturn\n"}}},
+		&streamhttp.EventCommitMatch{Repository: "r", URL: "/r/-/commit/abc", Message: "fix bug\n\nlong description"},
+		&streamhttp.EventPersonMatch{Handle: "alice"},
+	})
+	dec.OnMatches(matches)
+	dec.OnProgress(&api.Progress{MatchCount: 120, Skipped: []api.Skipped{{Reason: api.DocumentMatchLimit}}})
+
+	snapshot, err := c.snapshot("q")
+	require.NoError(t, err)
+	assert.Equal(t, "q", snapshot.Query)
+	assert.Equal(t, 120, snapshot.ResultCount)
+	assert.True(t, snapshot.LimitHit)
+	require.Len(t, snapshot.TopMatches, maxTopMatches)
+	assert.Equal(t, notebooks.NotebookSnapshotMatch{Repository: "r", Path: "a.go", Label: "func a() {", URL: "/r/-/blob/a.go"}, snapshot.TopMatches[0])
+	assert.Equal(t, notebooks.NotebookSnapshotMatch{Repository: "r", Label: "fix bug", URL: "/r/-/commit/abc"}, snapshot.TopMatches[1])
+
+	dec.OnError(&streamhttp.EventError{Message: "timeout"})
+	_, err = c.snapshot("q")
+	assert.ErrorContains(t, err, "timeout")
+}
@@ -0,0 +1,139 @@
+package notebooks
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// maxTopMatches is the number of matches of each query block stored in a snapshot.
+	maxTopMatches = 10
+	// searchTimeout bounds the time a single query block may run for.
+	searchTimeout = 2 * time.Minute
+)
+
+// searchFunc runs a search query and summarizes its results in a snapshot. The BlockID of
+// the returned snapshot is left empty.
+type searchFunc func(ctx context.Context, query string) (*notebooks.NotebookBlockSnapshot, error)
+
+// streamingSearch runs the query against the streaming search API of the frontend. The
+// actor of ctx is forwarded, so the results respect the permissions of the user the
+// report runs as.
+func streamingSearch(ctx context.Context, query string) (*notebooks.NotebookBlockSnapshot, error) {
+	ctx, cancel := context.WithTimeout(ctx, searchTimeout)
+	defer cancel()
+
+	req, err := streamhttp.NewRequest(internalapi.Client.URL+"/.internal", query)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", "notebook-reports")
+
+	resp, err := httpcli.InternalClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code from search: %d", resp.StatusCode)
+	}
+
+	var c snapshotCollector
+	if err := c.decoder().ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+	return c.snapshot(query)
+}
+
+// snapshotCollector aggregates the events of a search stream into a snapshot.
+type snapshotCollector struct {
+	resultCount int
+	limitHit    bool
+	matches     []notebooks.NotebookSnapshotMatch
+	errs        error
+}
+
+func (c *snapshotCollector) decoder() streamhttp.FrontendStreamDecoder {
+	return streamhttp.FrontendStreamDecoder{
+		OnProgress: func(progress *api.Progress) {
+			c.resultCount = progress.MatchCount
+			for _, skipped := range progress.Skipped {
+				switch skipped.Reason {
+				case api.DocumentMatchLimit, api.ShardMatchLimit, api.RepositoryLimit, api.ShardTimeout:
+					c.limitHit = true
+				}
+			}
+		},
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				if len(c.matches) >= maxTopMatches {
+					return
+				}
+				if m, ok := toSnapshotMatch(match); ok {
+					c.matches = append(c.matches, m)
+				}
+			}
+		},
+		OnError: func(e *streamhttp.EventError) {
+			c.errs = errors.Append(c.errs, errors.New(e.Message))
+		},
+	}
+}
+
+func (c *snapshotCollector) snapshot(query string) (*notebooks.NotebookBlockSnapshot, error) {
+	if c.errs != nil {
+		return nil, c.errs
+	}
+	return &notebooks.NotebookBlockSnapshot{
+		Query:       query,
+		ResultCount: c.resultCount,
+		LimitHit:    c.limitHit,
+		TopMatches:  c.matches,
+	}, nil
+}
+
+// toSnapshotMatch converts a search result to its snapshot representation. Matches that
+// cannot be linked to, such as person matches, are skipped.
+func toSnapshotMatch(match streamhttp.EventMatch) (notebooks.NotebookSnapshotMatch, bool) {
+	switch m := match.(type) {
+	case *streamhttp.EventContentMatch:
+		var label string
+		if len(m.ChunkMatches) > 0 {
+			label = firstLine(m.ChunkMatches[0].Content)
+		} else if len(m.LineMatches) > 0 {
+			label = firstLine(m.LineMatches[0].Line)
+		}
+		return notebooks.NotebookSnapshotMatch{Repository: m.Repository, Path: m.Path, Label: label, URL: blobURL(m.Repository, m.Path)}, true
+	case *streamhttp.EventPathMatch:
+		return notebooks.NotebookSnapshotMatch{Repository: m.Repository, Path: m.Path, URL: blobURL(m.Repository, m.Path)}, true
+	case *streamhttp.EventSymbolMatch:
+		if len(m.Symbols) == 0 {
+			return notebooks.NotebookSnapshotMatch{}, false
+		}
+		return notebooks.NotebookSnapshotMatch{Repository: m.Repository, Path: m.Path, Label: m.Symbols[0].Name, URL: m.Symbols[0].URL}, true
+	case *streamhttp.EventRepoMatch:
+		return notebooks.NotebookSnapshotMatch{Repository: m.Repository, URL: "/" + m.Repository}, true
+	case *streamhttp.EventCommitMatch:
+		return notebooks.NotebookSnapshotMatch{Repository: m.Repository, Label: firstLine(m.Message), URL: m.URL}, true
+	}
+	return notebooks.NotebookSnapshotMatch{}, false
+}
+
+func blobURL(repo, path string) string {
+	return "/" + repo + "/-/blob/" + path
+}
+
+func firstLine(s string) string {
+	s, _, _ = strings.Cut(strings.TrimLeft(s, "\n"), "\n")
+	return strings.TrimSpace(s)
+}
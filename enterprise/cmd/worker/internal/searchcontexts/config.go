@@ -0,0 +1,29 @@
+package searchcontexts
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type config struct {
+	env.BaseConfig
+
+	Interval time.Duration
+}
+
+var ConfigInst = &config{}
+
+func (c *config) Load() {
+	c.Interval = c.GetInterval("SEARCH_CONTEXT_RESOLUTION_INTERVAL", "1h", "How frequently the queries of query-based search contexts are resolved to record their repositories")
+}
+
+func (c *config) Validate() error {
+	var errs error
+	errs = errors.Append(errs, c.BaseConfig.Validate())
+	if c.Interval <= 0 {
+		errs = errors.Append(errs, errors.New("SEARCH_CONTEXT_RESOLUTION_INTERVAL must be greater than 0"))
+	}
+	return errs
+}
@@ -0,0 +1,63 @@
+package searchcontexts
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type membershipEmailData struct {
+	Spec                string
+	SearchContextURL    string
+	AddedRepositories   []api.RepoName
+	RemovedRepositories []api.RepoName
+}
+
+func newMembershipEmailData(searchContext *types.SearchContext, added, removed []types.SearchContextResolutionRepository) (membershipEmailData, error) {
+	externalURL, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return membershipEmailData{}, errors.Wrap(err, "parsing external URL")
+	}
+
+	spec := searchcontexts.GetSearchContextSpec(searchContext)
+	data := membershipEmailData{
+		Spec:             spec,
+		SearchContextURL: externalURL.ResolveReference(&url.URL{Path: "/contexts/" + spec}).String(),
+	}
+	for _, r := range added {
+		data.AddedRepositories = append(data.AddedRepositories, r.Name)
+	}
+	for _, r := range removed {
+		data.RemovedRepositories = append(data.RemovedRepositories, r.Name)
+	}
+	return data, nil
+}
+
+var membershipEmailTemplate = txemail.MustValidate(txtypes.Templates{
+	Subject: `Repositories of the search context {{.Spec}} changed`,
+	Text: `
+The repositories matched by the search context {{.Spec}} changed: {{.SearchContextURL}}
+{{if .AddedRepositories}}
+Added:
+{{range .AddedRepositories}}  - {{.}}
+{{end}}{{end}}{{if .RemovedRepositories}}
+Removed:
+{{range .RemovedRepositories}}  - {{.}}
+{{end}}{{end}}`,
+	HTML: `
+<p>The repositories matched by the search context <a href="{{.SearchContextURL}}"><strong>{{.Spec}}</strong></a> changed.</p>
+{{if .AddedRepositories}}<p>Added:</p>
+<ul>
+{{range .AddedRepositories}}<li>{{.}}</li>
+{{end}}</ul>{{end}}
+{{if .RemovedRepositories}}<p>Removed:</p>
+<ul>
+{{range .RemovedRepositories}}<li>{{.}}</li>
+{{end}}</ul>{{end}}`,
+})
@@ -0,0 +1,47 @@
+package searchcontexts
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+)
+
+// searchContextResolutionsJob periodically resolves the queries of query-based search
+// contexts and records the repositories they match.
+type searchContextResolutionsJob struct{}
+
+func NewSearchContextResolutionsJob() job.Job {
+	return &searchContextResolutionsJob{}
+}
+
+func (j *searchContextResolutionsJob) Description() string {
+	return "records the repositories query-based search contexts resolve to and notifies subscribers of changes"
+}
+
+func (j *searchContextResolutionsJob) Config() []env.Config {
+	return []env.Config{ConfigInst}
+}
+
+func (j *searchContextResolutionsJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "searchcontexts.resolutions", "records the repositories of query-based search contexts",
+			ConfigInst.Interval, &resolutionsHandler{
+				db:        db,
+				logger:    observationCtx.Logger.Scoped("resolutions", "records the repositories of query-based search contexts"),
+				resolve:   searchcontexts.ResolveQueryRepositories,
+				sendEmail: internalapi.Client.SendEmail,
+			},
+		),
+	}, nil
+}
@@ -0,0 +1,199 @@
+package searchcontexts
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// searchContextsPageSize is the number of search contexts loaded at a time.
+	searchContextsPageSize = 100
+	// maxResolutionsPerSearchContext is the number of unpinned resolutions kept per
+	// search context. Older ones are deleted after each change.
+	maxResolutionsPerSearchContext = 100
+)
+
+type resolveFunc func(ctx context.Context, db database.DB, searchContext *types.SearchContext) ([]types.SearchContextResolutionRepository, error)
+
+// resolutionsHandler resolves the query of every query-based search context, records a new
+// resolution when the matching repositories changed, and emails the subscribers of the
+// search context about added and removed repositories.
+type resolutionsHandler struct {
+	db        database.DB
+	logger    log.Logger
+	resolve   resolveFunc
+	sendEmail func(ctx context.Context, source string, message txtypes.Message) error
+}
+
+var (
+	_ goroutine.Handler      = &resolutionsHandler{}
+	_ goroutine.ErrorHandler = &resolutionsHandler{}
+)
+
+func (h *resolutionsHandler) Handle(ctx context.Context) error {
+	// 🚨 SECURITY: Resolutions must contain all matching repositories, so the queries are
+	// resolved as the internal actor. They are filtered by permissions when they are read.
+	ctx = actor.WithInternalActor(ctx)
+
+	var errs error
+	for offset := int32(0); ; offset += searchContextsPageSize {
+		searchContexts, err := h.db.SearchContexts().ListSearchContexts(ctx,
+			database.ListSearchContextsPageOptions{First: searchContextsPageSize, After: offset},
+			database.ListSearchContextsOptions{HasQuery: true},
+		)
+		if err != nil {
+			return errors.Append(errs, errors.Wrap(err, "listing search contexts"))
+		}
+
+		for _, searchContext := range searchContexts {
+			if err := h.record(ctx, searchContext); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "resolving search context %d", searchContext.ID))
+			}
+		}
+
+		if len(searchContexts) < searchContextsPageSize {
+			return errs
+		}
+	}
+}
+
+func (h *resolutionsHandler) HandleError(err error) {
+	h.logger.Error("error resolving search contexts", log.Error(err))
+}
+
+func (h *resolutionsHandler) record(ctx context.Context, searchContext *types.SearchContext) error {
+	repositories, err := h.resolve(ctx, h.db, searchContext)
+	if err != nil {
+		return err
+	}
+
+	store := h.db.SearchContexts()
+	latest, err := store.GetLatestSearchContextResolution(ctx, searchContext.ID)
+	if err != nil && !errors.Is(err, database.ErrSearchContextResolutionNotFound) {
+		return err
+	}
+	if latest != nil && searchcontexts.EqualResolutionRepositories(latest.Repositories, repositories) {
+		return nil
+	}
+
+	if _, err := store.CreateSearchContextResolution(ctx, &types.SearchContextResolution{
+		SearchContextID: searchContext.ID,
+		Repositories:    repositories,
+	}); err != nil {
+		return err
+	}
+	if err := store.DeleteOldSearchContextResolutions(ctx, searchContext.ID, maxResolutionsPerSearchContext); err != nil {
+		return err
+	}
+
+	// The first resolution of a search context is the baseline, there is nothing to notify about.
+	if latest == nil {
+		return nil
+	}
+	added, removed := searchcontexts.DiffResolutionRepositories(latest.Repositories, repositories)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	if !conf.CanSendEmail() {
+		return nil
+	}
+
+	subscriberIDs, err := store.GetSearchContextSubscriberIDs(ctx, searchContext.ID)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, userID := range subscriberIDs {
+		if err := h.notify(ctx, userID, searchContext, added, removed); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "notifying user %d", userID))
+		}
+	}
+	return errs
+}
+
+// notify emails the repositories that were added to and removed from the search context to the
+// user, limited to the search context and repositories the user can see.
+func (h *resolutionsHandler) notify(ctx context.Context, userID int32, searchContext *types.SearchContext, added, removed []types.SearchContextResolutionRepository) error {
+	// 🚨 SECURITY: The subscriber may have lost access to the search context or to some of
+	// the repositories since they subscribed.
+	userCtx := actor.WithActor(ctx, actor.FromUser(userID))
+	_, err := h.db.SearchContexts().GetSearchContext(userCtx, database.GetSearchContextOptions{
+		Name:            searchContext.Name,
+		NamespaceUserID: searchContext.NamespaceUserID,
+		NamespaceOrgID:  searchContext.NamespaceOrgID,
+	})
+	if errors.Is(err, database.ErrSearchContextNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	added, err = h.visibleRepositories(userCtx, added)
+	if err != nil {
+		return err
+	}
+	removed, err = h.visibleRepositories(userCtx, removed)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	email, verified, err := h.db.UserEmails().GetPrimaryEmail(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "get user primary email")
+	}
+	if !verified {
+		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
+	}
+
+	data, err := newMembershipEmailData(searchContext, added, removed)
+	if err != nil {
+		return err
+	}
+	return h.sendEmail(ctx, "search_context_membership", txtypes.Message{
+		To:       []string{email},
+		Template: membershipEmailTemplate,
+		Data:     data,
+	})
+}
+
+// visibleRepositories returns the repositories that the actor of ctx can access.
+func (h *resolutionsHandler) visibleRepositories(ctx context.Context, repositories []types.SearchContextResolutionRepository) ([]types.SearchContextResolutionRepository, error) {
+	if len(repositories) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]api.RepoID, 0, len(repositories))
+	for _, r := range repositories {
+		ids = append(ids, r.ID)
+	}
+	repos, err := h.db.Repos().GetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	visible := make(map[api.RepoID]struct{}, len(repos))
+	for _, repo := range repos {
+		visible[repo.ID] = struct{}{}
+	}
+
+	var filtered []types.SearchContextResolutionRepository
+	for _, r := range repositories {
+		if _, ok := visible[r.ID]; ok {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
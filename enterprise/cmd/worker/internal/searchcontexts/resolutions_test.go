@@ -0,0 +1,135 @@
+package searchcontexts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestResolutionsHandler(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExternalURL: "https://sourcegraph.example.com",
+		EmailSmtp:   &schema.SMTPServerConfig{},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	searchContext := &types.SearchContext{ID: 1, Name: "services", NamespaceUserID: 2, NamespaceUserName: "alice", Query: "repo:services"}
+	previous := &types.SearchContextResolution{
+		ID:              10,
+		SearchContextID: 1,
+		Repositories:    []types.SearchContextResolutionRepository{{ID: 1, Name: "github.com/a"}, {ID: 2, Name: "github.com/b"}},
+	}
+
+	newDB := func(latest *types.SearchContextResolution) (*database.MockDB, *database.MockSearchContextsStore) {
+		store := database.NewMockSearchContextsStore()
+		store.ListSearchContextsFunc.SetDefaultHook(func(ctx context.Context, _ database.ListSearchContextsPageOptions, opts database.ListSearchContextsOptions) ([]*types.SearchContext, error) {
+			assert.True(t, actor.FromContext(ctx).IsInternal())
+			assert.True(t, opts.HasQuery)
+			return []*types.SearchContext{searchContext}, nil
+		})
+		if latest == nil {
+			store.GetLatestSearchContextResolutionFunc.SetDefaultReturn(nil, database.ErrSearchContextResolutionNotFound)
+		} else {
+			store.GetLatestSearchContextResolutionFunc.SetDefaultReturn(latest, nil)
+		}
+		store.CreateSearchContextResolutionFunc.SetDefaultHook(func(_ context.Context, r *types.SearchContextResolution) (*types.SearchContextResolution, error) {
+			return r, nil
+		})
+		store.GetSearchContextSubscriberIDsFunc.SetDefaultReturn([]int32{3}, nil)
+		store.GetSearchContextFunc.SetDefaultReturn(searchContext, nil)
+
+		repos := database.NewMockRepoStore()
+		// The subscriber cannot see github.com/d.
+		repos.GetByIDsFunc.SetDefaultHook(func(ctx context.Context, ids ...api.RepoID) ([]*types.Repo, error) {
+			assert.Equal(t, int32(3), actor.FromContext(ctx).UID)
+			var visible []*types.Repo
+			for _, id := range ids {
+				if id != 4 {
+					visible = append(visible, &types.Repo{ID: id})
+				}
+			}
+			return visible, nil
+		})
+		userEmails := database.NewMockUserEmailsStore()
+		userEmails.GetPrimaryEmailFunc.SetDefaultReturn("user@example.com", true, nil)
+
+		db := database.NewMockDB()
+		db.SearchContextsFunc.SetDefaultReturn(store)
+		db.ReposFunc.SetDefaultReturn(repos)
+		db.UserEmailsFunc.SetDefaultReturn(userEmails)
+		return db, store
+	}
+
+	newHandler := func(db database.DB, resolved []types.SearchContextResolutionRepository, sent *[]txtypes.Message) *resolutionsHandler {
+		return &resolutionsHandler{
+			db:     db,
+			logger: logtest.Scoped(t),
+			resolve: func(context.Context, database.DB, *types.SearchContext) ([]types.SearchContextResolutionRepository, error) {
+				return resolved, nil
+			},
+			sendEmail: func(_ context.Context, _ string, message txtypes.Message) error {
+				*sent = append(*sent, message)
+				return nil
+			},
+		}
+	}
+
+	t.Run("records changes and notifies subscribers", func(t *testing.T) {
+		db, store := newDB(previous)
+		resolved := []types.SearchContextResolutionRepository{{ID: 2, Name: "github.com/b"}, {ID: 3, Name: "github.com/c"}, {ID: 4, Name: "github.com/d"}}
+		var sent []txtypes.Message
+		require.NoError(t, newHandler(db, resolved, &sent).Handle(context.Background()))
+
+		require.Len(t, store.CreateSearchContextResolutionFunc.History(), 1)
+		assert.Equal(t, resolved, store.CreateSearchContextResolutionFunc.History()[0].Arg1.Repositories)
+		require.Len(t, store.DeleteOldSearchContextResolutionsFunc.History(), 1)
+
+		require.Len(t, sent, 1)
+		assert.Equal(t, []string{"user@example.com"}, sent[0].To)
+		assert.Equal(t, membershipEmailData{
+			Spec:                "@alice/services",
+			SearchContextURL:    "https://sourcegraph.example.com/contexts/@alice/services",
+			AddedRepositories:   []api.RepoName{"github.com/c"},
+			RemovedRepositories: []api.RepoName{"github.com/a"},
+		}, sent[0].Data)
+	})
+
+	t.Run("unchanged repositories are not recorded", func(t *testing.T) {
+		db, store := newDB(previous)
+		var sent []txtypes.Message
+		require.NoError(t, newHandler(db, previous.Repositories, &sent).Handle(context.Background()))
+
+		assert.Empty(t, store.CreateSearchContextResolutionFunc.History())
+		assert.Empty(t, sent)
+	})
+
+	t.Run("first resolution does not notify", func(t *testing.T) {
+		db, store := newDB(nil)
+		var sent []txtypes.Message
+		require.NoError(t, newHandler(db, previous.Repositories, &sent).Handle(context.Background()))
+
+		assert.Len(t, store.CreateSearchContextResolutionFunc.History(), 1)
+		assert.Empty(t, sent)
+	})
+
+	t.Run("subscribers that lost access are not notified", func(t *testing.T) {
+		db, store := newDB(previous)
+		store.GetSearchContextFunc.SetDefaultReturn(nil, database.ErrSearchContextNotFound)
+		var sent []txtypes.Message
+		require.NoError(t, newHandler(db, []types.SearchContextResolutionRepository{{ID: 1, Name: "github.com/a"}}, &sent).Handle(context.Background()))
+
+		assert.Len(t, store.CreateSearchContextResolutionFunc.History(), 1)
+		assert.Empty(t, sent)
+	})
+}
@@ -0,0 +1,117 @@
+package searchexports
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// handler runs a search export. The query is first resolved to the repositories it
+// may match in, and then run against each repository on its own with all limits
+// lifted, so that neither result count nor timeout limits truncate the export.
+type handler struct {
+	store       exports.Store
+	uploadStore uploadstore.Store
+	search      searchFunc
+}
+
+var _ workerutil.Handler[*exports.SearchExport] = &handler{}
+
+func (h *handler) Handle(ctx context.Context, logger log.Logger, record *exports.SearchExport) error {
+	// 🚨 SECURITY: The search runs as the user that requested the export, so that it
+	// only contains results they have access to.
+	ctx = actor.WithActor(ctx, actor.FromUser(record.UserID))
+
+	repoNames, err := h.resolveRepositories(ctx, record.Query)
+	if err != nil {
+		return errors.Wrap(err, "resolving repositories")
+	}
+	if err := h.store.UpdateProgress(ctx, record.ID, len(repoNames), 0, 0); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp("", "search-export-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	buf := bufio.NewWriter(f)
+	w := exports.NewMatchWriter(buf, record.Format)
+
+	var matchCount int
+	for i, repoName := range repoNames {
+		q, err := exports.RepositoryQuery(record.Query, repoName)
+		if err != nil {
+			return err
+		}
+		count, err := h.search(ctx, q, w.Write)
+		if err != nil {
+			return errors.Wrapf(err, "searching %s", repoName)
+		}
+		matchCount += count
+
+		if err := h.store.UpdateProgress(ctx, record.ID, len(repoNames), i+1, matchCount); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	key := exports.NewObjectKey(record.ID, record.Format)
+	if _, err := h.uploadStore.Upload(ctx, key, f); err != nil {
+		return errors.Wrap(err, "uploading export")
+	}
+	logger.Info("search export uploaded", log.Int("repositories", len(repoNames)), log.Int("matches", matchCount))
+	return h.store.SetObjectKey(ctx, record.ID, key)
+}
+
+// resolveRepositories returns the sorted names of all repositories query may have
+// matches in.
+func (h *handler) resolveRepositories(ctx context.Context, query string) ([]string, error) {
+	repoQueries, err := exports.RepositoryQueries(query)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	var repoNames []string
+	for _, repoQuery := range repoQueries {
+		_, err := h.search(ctx, repoQuery, func(match streamhttp.EventMatch) error {
+			if m, ok := match.(*streamhttp.EventRepoMatch); ok {
+				if _, ok := seen[m.Repository]; !ok {
+					seen[m.Repository] = struct{}{}
+					repoNames = append(repoNames, m.Repository)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(repoNames)
+	return repoNames, nil
+}
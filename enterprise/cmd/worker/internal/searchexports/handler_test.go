@@ -0,0 +1,96 @@
+package searchexports
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestHandler(t *testing.T) {
+	store := exports.NewMockStore()
+	uploadStore := uploadstoremocks.NewMockStore()
+	var uploaded string
+	uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+		b, err := io.ReadAll(r)
+		uploaded = string(b)
+		return int64(len(b)), err
+	})
+
+	var queries []string
+	search := func(ctx context.Context, query string, onMatch func(streamhttp.EventMatch) error) (int, error) {
+		require.Equal(t, int32(42), actor.FromContext(ctx).UID)
+		queries = append(queries, query)
+
+		if strings.HasSuffix(query, "type:repo count:all") {
+			for _, name := range []string{"github.com/b/b", "github.com/a/a"} {
+				if err := onMatch(&streamhttp.EventRepoMatch{Type: streamhttp.RepoMatchType, Repository: name}); err != nil {
+					return 0, err
+				}
+			}
+			return 2, nil
+		}
+		repoName := "github.com/a/a"
+		if strings.HasPrefix(query, `repo:^github\.com/b/b$`) {
+			repoName = "github.com/b/b"
+		}
+		return 1, onMatch(&streamhttp.EventPathMatch{Type: streamhttp.PathMatchType, Repository: repoName, Path: "README.md"})
+	}
+
+	h := &handler{store: store, uploadStore: uploadStore, search: search}
+	record := &exports.SearchExport{ID: 7, UserID: 42, Query: "repo:github.com file:README count:10", Format: exports.FormatCSV}
+	require.NoError(t, h.Handle(context.Background(), logtest.Scoped(t), record))
+
+	assert.Equal(t, []string{
+		"repo:github.com type:repo count:all",
+		`repo:^github\.com/a/a$ count:all (repo:github.com file:README)`,
+		`repo:^github\.com/b/b$ count:all (repo:github.com file:README)`,
+	}, queries)
+
+	assert.Equal(t, `type,repository,commit,path,line,preview
+path,github.com/a/a,,README.md,,
+path,github.com/b/b,,README.md,,
+`, uploaded)
+	require.Len(t, uploadStore.UploadFunc.History(), 1)
+	assert.Equal(t, "search-exports/7.csv", uploadStore.UploadFunc.History()[0].Arg1)
+
+	var progress [][3]int
+	for _, call := range store.UpdateProgressFunc.History() {
+		progress = append(progress, [3]int{call.Arg2, call.Arg3, call.Arg4})
+	}
+	assert.Equal(t, [][3]int{{2, 0, 0}, {2, 1, 1}, {2, 2, 2}}, progress)
+
+	require.Len(t, store.SetObjectKeyFunc.History(), 1)
+	assert.Equal(t, "search-exports/7.csv", store.SetObjectKeyFunc.History()[0].Arg2)
+}
+
+func TestHandlerSearchError(t *testing.T) {
+	store := exports.NewMockStore()
+	uploadStore := uploadstoremocks.NewMockStore()
+
+	search := func(ctx context.Context, query string, onMatch func(streamhttp.EventMatch) error) (int, error) {
+		if strings.HasSuffix(query, "type:repo count:all") {
+			return 1, onMatch(&streamhttp.EventRepoMatch{Type: streamhttp.RepoMatchType, Repository: "github.com/a/a"})
+		}
+		return 0, errors.New("timed out")
+	}
+
+	h := &handler{store: store, uploadStore: uploadStore, search: search}
+	record := &exports.SearchExport{ID: 7, UserID: 42, Query: "foo", Format: exports.FormatJSONLines}
+	err := h.Handle(context.Background(), logtest.Scoped(t), record)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "searching github.com/a/a")
+
+	assert.Empty(t, uploadStore.UploadFunc.History())
+	assert.Empty(t, store.SetObjectKeyFunc.History())
+}
@@ -0,0 +1,55 @@
+package searchexports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// janitorBatchSize is the maximum number of expired exports deleted in a single iteration.
+const janitorBatchSize = 100
+
+// janitor deletes exports that finished longer than the retention period ago, together
+// with their files in the upload store.
+type janitor struct {
+	store       exports.Store
+	uploadStore uploadstore.Store
+	retention   time.Duration
+	now         func() time.Time
+}
+
+var _ goroutine.Handler = &janitor{}
+
+func (j *janitor) Handle(ctx context.Context) error {
+	expired, err := j.store.ListExpired(ctx, j.now().Add(-j.retention), janitorBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "listing expired search exports")
+	}
+
+	var (
+		ids  []int
+		errs error
+	)
+	for _, export := range expired {
+		// An export is only deleted once its file is gone, so that a file is never left
+		// behind without a record pointing to it.
+		if export.ObjectKey != nil {
+			if err := j.uploadStore.Delete(ctx, *export.ObjectKey); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "deleting file of search export %d", export.ID))
+				continue
+			}
+		}
+		ids = append(ids, export.ID)
+	}
+
+	if len(ids) > 0 {
+		if err := j.store.DeleteByIDs(ctx, ids); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "deleting expired search exports"))
+		}
+	}
+	return errs
+}
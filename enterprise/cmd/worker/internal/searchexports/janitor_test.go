@@ -0,0 +1,48 @@
+package searchexports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestJanitor(t *testing.T) {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	keyOf := func(id int) *string {
+		key := exports.NewObjectKey(id, exports.FormatCSV)
+		return &key
+	}
+
+	store := exports.NewMockStore()
+	store.ListExpiredFunc.SetDefaultReturn([]*exports.SearchExport{
+		{ID: 1, ObjectKey: keyOf(1)},
+		{ID: 2},
+		{ID: 3, ObjectKey: keyOf(3)},
+	}, nil)
+
+	uploadStore := uploadstoremocks.NewMockStore()
+	uploadStore.DeleteFunc.SetDefaultHook(func(_ context.Context, key string) error {
+		if key == *keyOf(3) {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	j := &janitor{store: store, uploadStore: uploadStore, retention: 24 * time.Hour, now: func() time.Time { return now }}
+	err := j.Handle(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, store.ListExpiredFunc.History(), 1)
+	assert.Equal(t, now.Add(-24*time.Hour), store.ListExpiredFunc.History()[0].Arg1)
+
+	// The export whose file could not be deleted is kept, so it is retried.
+	require.Len(t, store.DeleteByIDsFunc.History(), 1)
+	assert.Equal(t, []int{1, 2}, store.DeleteByIDsFunc.History()[0].Arg1)
+}
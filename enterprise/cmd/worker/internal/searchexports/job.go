@@ -0,0 +1,75 @@
+package searchexports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+)
+
+// searchExportJob writes all results of queued search exports to the upload store.
+type searchExportJob struct{}
+
+func NewSearchExportJob() job.Job {
+	return &searchExportJob{}
+}
+
+func (j *searchExportJob) Description() string {
+	return "writes all results of queued search exports to the upload store, and deletes them once expired"
+}
+
+func (j *searchExportJob) Config() []env.Config {
+	return []env.Config{exports.ConfigInst}
+}
+
+func (j *searchExportJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := exports.NewUploadStore(context.Background(), observationCtx, exports.ConfigInst)
+	if err != nil {
+		return nil, err
+	}
+
+	store := exports.NewStore(db)
+	handler := &handler{
+		store:       store,
+		uploadStore: uploadStore,
+		search:      streamingSearch,
+	}
+	janitor := &janitor{
+		store:       store,
+		uploadStore: uploadStore,
+		retention:   exports.ConfigInst.Retention,
+		now:         time.Now,
+	}
+	workerStore := exports.NewWorkerStore(observationCtx, db.Handle())
+
+	return []goroutine.BackgroundRoutine{
+		dbworker.NewWorker[*exports.SearchExport](context.Background(), workerStore, handler, workerutil.WorkerOptions{
+			Name:              "search_export_worker",
+			Interval:          10 * time.Second,
+			NumHandlers:       2,
+			HeartbeatInterval: 10 * time.Second,
+			Metrics:           workerutil.NewMetrics(observationCtx, "search_export_worker"),
+		}),
+		// Exports whose worker died are requeued, so they do not count against the limit
+		// of active exports of their user forever.
+		dbworker.NewResetter(observationCtx.Logger, workerStore, dbworker.ResetterOptions{
+			Name:     "search_export_worker_resetter",
+			Interval: time.Minute,
+			Metrics:  dbworker.NewResetterMetrics(observationCtx, "search_export_worker"),
+		}),
+		goroutine.NewPeriodicGoroutine(context.Background(), "search_exports.janitor", "deletes expired search exports and their files",
+			time.Hour, janitor),
+	}, nil
+}
@@ -0,0 +1,76 @@
+package searchexports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// searchFunc runs a search query, calls onMatch for every match, and returns the
+// number of matches reported by the search. It returns an error if the results are
+// incomplete.
+type searchFunc func(ctx context.Context, query string, onMatch func(streamhttp.EventMatch) error) (int, error)
+
+// streamingSearch runs the query against the streaming search API of the frontend. The
+// actor of ctx is forwarded, so the results respect the permissions of the user that
+// requested the export.
+func streamingSearch(ctx context.Context, query string, onMatch func(streamhttp.EventMatch) error) (int, error) {
+	req, err := streamhttp.NewRequest(internalapi.Client.URL+"/.internal", query)
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", "search-exports")
+
+	resp, err := httpcli.InternalClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("unexpected status code from search: %d", resp.StatusCode)
+	}
+
+	var (
+		matchCount int
+		incomplete bool
+		errs       error
+	)
+	decoder := streamhttp.FrontendStreamDecoder{
+		OnProgress: func(progress *api.Progress) {
+			matchCount = progress.MatchCount
+			for _, skipped := range progress.Skipped {
+				switch skipped.Reason {
+				case api.DocumentMatchLimit, api.ShardMatchLimit, api.RepositoryLimit, api.ShardTimeout:
+					incomplete = true
+				}
+			}
+		},
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				if errs != nil {
+					return
+				}
+				errs = onMatch(match)
+			}
+		},
+		OnError: func(e *streamhttp.EventError) {
+			errs = errors.Append(errs, errors.New(e.Message))
+		},
+	}
+	if err := decoder.ReadAll(resp.Body); err != nil {
+		return 0, err
+	}
+	if errs != nil {
+		return 0, errs
+	}
+	if incomplete {
+		return 0, errors.Errorf("search results are incomplete: %q hit a limit or timed out", query)
+	}
+	return matchCount, nil
+}
@@ -17,7 +17,10 @@ import (
 	repoembeddings "github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/embeddings/repo"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/executors"
 	workerinsights "github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/insights"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/notebooks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/permissions"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/searchexports"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/telemetry"
 	eiauthz "github.com/sourcegraph/sourcegraph/enterprise/internal/authz"
 	srp "github.com/sourcegraph/sourcegraph/enterprise/internal/authz/subrepoperms"
@@ -42,6 +45,9 @@ var additionalJobs = map[string]job.Job{
 	"executors-janitor":             executors.NewJanitorJob(),
 	"executors-metricsserver":       executors.NewMetricsServerJob(),
 	"codemonitors-job":              codemonitors.NewCodeMonitorJob(),
+	"notebook-reports":              notebooks.NewNotebookReportsJob(),
+	"search-context-resolutions":    searchcontexts.NewSearchContextResolutionsJob(),
+	"search-exports":                searchexports.NewSearchExportJob(),
 	"bitbucket-project-permissions": permissions.NewBitbucketProjectPermissionsJob(),
 	"export-usage-telemetry":        telemetry.NewTelemetryJob(),
 
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gerrit"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitea"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitlab"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/perforce"
@@ -63,6 +64,7 @@ func ProvidersFromConfig(
 			extsvc.KindBitbucketCloud,
 			extsvc.KindBitbucketServer,
 			extsvc.KindGerrit,
+			extsvc.KindGitea,
 			extsvc.KindGitHub,
 			extsvc.KindGitLab,
 			extsvc.KindPerforce,
@@ -80,6 +82,7 @@ func ProvidersFromConfig(
 		bitbucketCloudConns  []*types.BitbucketCloudConnection
 		gerritConns          []*types.GerritConnection
 		azuredevopsConns     []*types.AzureDevOpsConnection
+		giteaConns           []*types.GiteaConnection
 	)
 	for {
 		svcs, err := store.List(ctx, opt)
@@ -124,6 +127,11 @@ func ProvidersFromConfig(
 					URN:              svc.URN(),
 					GerritConnection: c,
 				})
+			case *schema.GiteaConnection:
+				giteaConns = append(giteaConns, &types.GiteaConnection{
+					URN:             svc.URN(),
+					GiteaConnection: c,
+				})
 			case *schema.GitHubConnection:
 				gitHubConns = append(gitHubConns,
 					&github.ExternalConnection{
@@ -168,6 +176,7 @@ func ProvidersFromConfig(
 	initResult.Append(bitbucketcloud.NewAuthzProviders(db, bitbucketCloudConns, cfg.SiteConfig().AuthProviders))
 	initResult.Append(gerrit.NewAuthzProviders(gerritConns, cfg.SiteConfig().AuthProviders))
 	initResult.Append(azuredevops.NewAuthzProviders(db, azuredevopsConns))
+	initResult.Append(gitea.NewAuthzProviders(giteaConns))
 
 	return allowAccessByDefault, initResult.Providers, initResult.Problems, initResult.Warnings, initResult.InvalidConnections
 }
@@ -0,0 +1,181 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider implements repository-centric permissions for Gitea. Sourcegraph
+// users are matched to Gitea users by verified email address, and the users
+// with access to a repository are its owner, its collaborators and the members
+// of the organization teams that have been granted access to it.
+type Provider struct {
+	urn      string
+	client   *gitea.Client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a Provider for the given connection. If a nil httpClient
+// is provided, httpcli.ExternalDoer will be used.
+func NewProvider(conn *types.GiteaConnection, httpClient httpcli.Doer) (*Provider, error) {
+	client, err := gitea.NewClient(conn.URN, conn.GiteaConnection, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		urn:      conn.URN,
+		client:   client,
+		codeHost: extsvc.NewCodeHost(client.URL, extsvc.TypeGitea),
+	}, nil
+}
+
+// FetchAccount looks up the Gitea user whose email address matches one of the
+// verified email addresses of the given Sourcegraph user. It returns nil if
+// there is no such Gitea user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, verifiedEmails []string) (*extsvc.Account, error) {
+	if user == nil || len(verifiedEmails) == 0 {
+		return nil, nil
+	}
+
+	emails := make(map[string]struct{}, len(verifiedEmails))
+	for _, email := range verifiedEmails {
+		emails[strings.ToLower(email)] = struct{}{}
+	}
+
+	it := p.client.ListUsers(ctx)
+	for it.Next() {
+		u := it.Current()
+		if _, ok := emails[strings.ToLower(u.Email)]; !ok || u.Email == "" {
+			continue
+		}
+
+		data, err := json.Marshal(u)
+		if err != nil {
+			return nil, err
+		}
+
+		return &extsvc.Account{
+			UserID: user.ID,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: p.codeHost.ServiceType,
+				ServiceID:   p.codeHost.ServiceID,
+				AccountID:   strconv.FormatInt(u.ID, 10),
+			},
+			AccountData: extsvc.AccountData{
+				Data: extsvc.NewUnencryptedData(data),
+			},
+		}, nil
+	}
+	if err := it.Err(); err != nil {
+		return nil, errors.Wrap(err, "list users")
+	}
+
+	return nil, nil
+}
+
+// FetchUserPerms is not implemented for Gitea, whose API cannot list the
+// repositories of another user. Permissions are synced repository by
+// repository with FetchRepoPerms instead.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "gitea.FetchUserPerms"}
+}
+
+// FetchRepoPerms returns the IDs of the Gitea users that have read access to
+// the given repository.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	if repo == nil {
+		return nil, errors.New("no repository provided")
+	} else if !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec) {
+		return nil, errors.Errorf("not a code host of the repository: want %q but have %q",
+			repo.ServiceID, p.codeHost.ServiceID)
+	}
+
+	id, err := strconv.ParseInt(repo.ID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing repository ID %q", repo.ID)
+	}
+
+	r, err := p.client.GetRepoByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get repository")
+	}
+	if r.Owner == nil {
+		return nil, errors.Errorf("repository %d has no owner", id)
+	}
+	owner, name := r.Owner.Login, r.Name
+
+	seen := make(map[int64]struct{})
+	var accountIDs []extsvc.AccountID
+	add := func(u *gitea.User) {
+		if _, ok := seen[u.ID]; ok {
+			return
+		}
+		seen[u.ID] = struct{}{}
+		accountIDs = append(accountIDs, extsvc.AccountID(strconv.FormatInt(u.ID, 10)))
+	}
+
+	// The owner of an organization repository is the organization itself,
+	// which never matches an external account, so adding it is harmless.
+	add(r.Owner)
+
+	collaborators := p.client.ListCollaborators(ctx, owner, name)
+	for collaborators.Next() {
+		add(collaborators.Current())
+	}
+	if err := collaborators.Err(); err != nil {
+		return nil, errors.Wrap(err, "list collaborators")
+	}
+
+	teams, err := p.client.ListRepoTeams(ctx, owner, name)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "list teams")
+	}
+	for _, team := range teams {
+		members := p.client.ListTeamMembers(ctx, team.ID)
+		for members.Next() {
+			add(members.Current())
+		}
+		if err := members.Err(); err != nil {
+			return nil, errors.Wrapf(err, "list members of team %d", team.ID)
+		}
+	}
+
+	return accountIDs, nil
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ValidateConnection checks that the configured token belongs to a site admin,
+// which is required to match users by email address.
+func (p *Provider) ValidateConnection(ctx context.Context) error {
+	user, err := p.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching the user of the Gitea token")
+	}
+	if !user.IsAdmin {
+		return errors.Newf("the Gitea token of user %q must belong to a site admin to enforce permissions", user.Login)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestProvider(t *testing.T, routes map[string]any) *Provider {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if page := r.URL.Query().Get("page"); page != "" && page != "1" {
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	p, err := NewProvider(&types.GiteaConnection{
+		URN: "extsvc:gitea:1",
+		GiteaConnection: &schema.GiteaConnection{
+			Url:           srv.URL,
+			Token:         "secret",
+			Authorization: &schema.GiteaAuthorization{},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t, map[string]any{
+		"/api/v1/admin/users": []*gitea.User{
+			{ID: 1, Login: "admin", Email: "admin@example.com"},
+			{ID: 2, Login: "alice", Email: "Alice@Example.com"},
+		},
+	})
+
+	ctx := context.Background()
+	user := &types.User{ID: 42}
+
+	acct, err := p.FetchAccount(ctx, user, nil, []string{"alice@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct == nil {
+		t.Fatal("expected an account")
+	}
+	if acct.UserID != 42 || acct.AccountID != "2" || acct.ServiceType != extsvc.TypeGitea {
+		t.Errorf("unexpected account: %+v", acct.AccountSpec)
+	}
+
+	acct, err = p.FetchAccount(ctx, user, nil, []string{"mallory@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct != nil {
+		t.Errorf("expected no account, got %+v", acct.AccountSpec)
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t, map[string]any{
+		"/api/v1/repositories/7": &gitea.Repository{
+			ID:    7,
+			Name:  "api",
+			Owner: &gitea.User{ID: 10, Login: "acme"},
+		},
+		"/api/v1/repos/acme/api/collaborators": []*gitea.User{
+			{ID: 2, Login: "alice"},
+		},
+		"/api/v1/repos/acme/api/teams": []*gitea.Team{
+			{ID: 5, Name: "Owners"},
+		},
+		"/api/v1/teams/5/members": []*gitea.User{
+			{ID: 2, Login: "alice"},
+			{ID: 3, Login: "bob"},
+		},
+	})
+
+	have, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "7",
+			ServiceType: p.ServiceType(),
+			ServiceID:   p.ServiceID(),
+		},
+	}, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []extsvc.AccountID{"10", "2", "3"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected account IDs (-want +got):\n%s", diff)
+	}
+
+	if _, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "8",
+			ServiceType: p.ServiceType(),
+			ServiceID:   p.ServiceID(),
+		},
+	}, authz.FetchPermsOptions{}); err == nil {
+		t.Error("expected an error for an unknown repository")
+	}
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		user    *gitea.User
+		wantErr bool
+	}{
+		"site admin":     {user: &gitea.User{Login: "admin", IsAdmin: true}},
+		"not site admin": {user: &gitea.User{Login: "alice"}, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := newTestProvider(t, map[string]any{"/api/v1/user": tc.user})
+			if err := p.ValidateConnection(context.Background()); (err != nil) != tc.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
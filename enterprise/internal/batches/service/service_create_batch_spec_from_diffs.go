@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store/author"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RepositoryDiff is a diff of changes to a repository, from which a changeset
+// spec is created.
+type RepositoryDiff struct {
+	RepoID api.RepoID
+
+	// BaseRef is the full name of the ref the changeset will be merged into,
+	// and BaseRev the revision the diff applies to.
+	BaseRef string
+	BaseRev string
+
+	Diff []byte
+}
+
+type CreateBatchSpecFromDiffsOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	Name        string
+	Description string
+
+	// ChangesetTemplate is used to create the changeset specs for the diffs.
+	// Its commit author defaults to the current user.
+	ChangesetTemplate *batcheslib.ChangesetTemplate
+
+	Diffs []RepositoryDiff
+}
+
+// CreateBatchSpecFromDiffs creates a BatchSpec with one ChangesetSpec for
+// each of the given diffs, such as the diffs computed by a replace command of
+// the compute API. Like a BatchSpec created with CreateBatchSpec, it must be
+// applied to create or update a batch change.
+func (s *Service) CreateBatchSpecFromDiffs(ctx context.Context, opts CreateBatchSpecFromDiffsOpts) (spec *btypes.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecFromDiffs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("diffs", len(opts.Diffs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(opts.Diffs) == 0 {
+		return nil, errors.New("at least one diff is required")
+	}
+
+	rawSpec, err := json.Marshal(&batcheslib.BatchSpec{
+		Name:              opts.Name,
+		Description:       opts.Description,
+		ChangesetTemplate: opts.ChangesetTemplate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	spec, err = btypes.NewBatchSpecFromRaw(string(rawSpec))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check whether the current user has access to either one of the namespaces.
+	err = s.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+	spec.NamespaceOrgID = opts.NamespaceOrgID
+	spec.NamespaceUserID = opts.NamespaceUserID
+	a := sgactor.FromContext(ctx)
+	spec.UserID = a.UID
+
+	changesetAuthor, err := author.GetChangesetAuthorForUser(ctx, database.UsersWith(s.logger, s.store), spec.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating changeset author")
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.CreateBatchSpec(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	var changesetSpecs []*btypes.ChangesetSpec
+	for _, d := range opts.Diffs {
+		if len(d.Diff) == 0 {
+			return nil, errors.Newf("diff for repository %d is empty", d.RepoID)
+		}
+
+		// 🚨 SECURITY: We use database.Repos.Get to check whether the user has access to
+		// the repository or not.
+		repo, err := tx.Repos().Get(ctx, d.RepoID)
+		if err != nil {
+			return nil, err
+		}
+
+		rawSpecs, err := batcheslib.BuildChangesetSpecs(&batcheslib.ChangesetSpecInput{
+			Repository: batcheslib.Repository{
+				ID:      string(relay.MarshalID("Repository", repo.ID)),
+				Name:    string(repo.Name),
+				BaseRef: d.BaseRef,
+				BaseRev: d.BaseRev,
+			},
+			BatchChangeAttributes: &template.BatchChangeAttributes{
+				Name:        spec.Spec.Name,
+				Description: spec.Spec.Description,
+			},
+			Template: spec.Spec.ChangesetTemplate,
+			Result:   execution.AfterStepResult{Diff: d.Diff},
+		}, true, changesetAuthor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "building changeset spec for repository %q", repo.Name)
+		}
+
+		for _, rawSpec := range rawSpecs {
+			changesetSpec, err := btypes.NewChangesetSpecFromSpec(rawSpec)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid changeset spec for repository %q", repo.Name)
+			}
+			changesetSpec.BatchSpecID = spec.ID
+			changesetSpec.BaseRepoID = repo.ID
+			changesetSpec.UserID = spec.UserID
+
+			changesetSpecs = append(changesetSpecs, changesetSpec)
+		}
+	}
+
+	if err := tx.CreateChangesetSpec(ctx, changesetSpecs...); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
@@ -405,6 +405,92 @@ func TestService(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateBatchSpecFromDiffs", func(t *testing.T) {
+		diff := []byte(`diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-Hello
++Hello world
+`)
+		template := &batcheslib.ChangesetTemplate{
+			Title:  "Update README",
+			Branch: "update-readme",
+			Commit: batcheslib.ExpandedGitCommitDescription{Message: "Update README"},
+		}
+
+		t.Run("success", func(t *testing.T) {
+			opts := CreateBatchSpecFromDiffsOpts{
+				NamespaceUserID:   admin.ID,
+				Name:              "update-readme",
+				ChangesetTemplate: template,
+				Diffs: []RepositoryDiff{
+					{RepoID: rs[0].ID, BaseRef: "refs/heads/main", BaseRev: "deadbeef", Diff: diff},
+					{RepoID: rs[1].ID, BaseRef: "refs/heads/main", BaseRev: "deadbeef", Diff: diff},
+				},
+			}
+
+			spec, err := svc.CreateBatchSpecFromDiffs(adminCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := spec.UserID, admin.ID; have != want {
+				t.Fatalf("UserID is %d, want %d", have, want)
+			}
+			if have, want := spec.Spec.Name, "update-readme"; have != want {
+				t.Fatalf("Name is %q, want %q", have, want)
+			}
+
+			changesetSpecs, _, err := s.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: spec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := len(changesetSpecs), 2; have != want {
+				t.Fatalf("wrong number of changeset specs. want=%d, have=%d", want, have)
+			}
+			for _, cs := range changesetSpecs {
+				if have, want := cs.HeadRef, "refs/heads/update-readme"; have != want {
+					t.Fatalf("wrong head ref. want=%q, have=%q", want, have)
+				}
+				if have, want := string(cs.Diff), string(diff); have != want {
+					t.Fatalf("wrong diff. want=%q, have=%q", want, have)
+				}
+				if have, want := cs.DiffStat().Added, int32(1); have != want {
+					t.Fatalf("wrong added lines. want=%d, have=%d", want, have)
+				}
+			}
+		})
+
+		t.Run("empty diff", func(t *testing.T) {
+			opts := CreateBatchSpecFromDiffsOpts{
+				NamespaceUserID:   admin.ID,
+				Name:              "update-readme",
+				ChangesetTemplate: template,
+				Diffs:             []RepositoryDiff{{RepoID: rs[0].ID, BaseRef: "refs/heads/main", BaseRev: "deadbeef"}},
+			}
+
+			if _, err := svc.CreateBatchSpecFromDiffs(adminCtx, opts); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+
+		t.Run("missing repository permissions", func(t *testing.T) {
+			bt.MockRepoPermissions(t, db, user.ID)
+
+			opts := CreateBatchSpecFromDiffsOpts{
+				NamespaceUserID:   user.ID,
+				Name:              "update-readme",
+				ChangesetTemplate: template,
+				Diffs:             []RepositoryDiff{{RepoID: rs[0].ID, BaseRef: "refs/heads/main", BaseRev: "deadbeef", Diff: diff}},
+			}
+
+			if _, err := svc.CreateBatchSpecFromDiffs(userCtx, opts); !errcode.IsNotFound(err) {
+				t.Fatalf("expected not-found error but got %s", err)
+			}
+		})
+	})
+
 	t.Run("CreateBatchSpec", func(t *testing.T) {
 		changesetSpecs := make([]*btypes.ChangesetSpec, 0, len(rs))
 		changesetSpecRandIDs := make([]string, 0, len(rs))
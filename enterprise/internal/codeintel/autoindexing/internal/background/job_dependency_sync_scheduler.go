@@ -65,11 +65,14 @@ type dependencySyncSchedulerHandler struct {
 var autoIndexingEnabled = conf.CodeIntelAutoIndexingEnabled
 
 var schemeToExternalService = map[string]string{
-	dependencies.JVMPackagesScheme:    extsvc.KindJVMPackages,
-	dependencies.NpmPackagesScheme:    extsvc.KindNpmPackages,
-	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
-	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
-	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.JVMPackagesScheme:      extsvc.KindJVMPackages,
+	dependencies.NpmPackagesScheme:      extsvc.KindNpmPackages,
+	dependencies.PythonPackagesScheme:   extsvc.KindPythonPackages,
+	dependencies.RustPackagesScheme:     extsvc.KindRustPackages,
+	dependencies.RubyPackagesScheme:     extsvc.KindRubyPackages,
+	dependencies.NuGetPackagesScheme:    extsvc.KindNuGetPackages,
+	dependencies.ComposerPackagesScheme: extsvc.KindComposerPackages,
+	dependencies.HexPackagesScheme:      extsvc.KindHexPackages,
 }
 
 func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.Logger, job dependencySyncingJob) error {
@@ -242,6 +245,25 @@ func newPackage(pkg uploadsshared.Package) (*precise.Package, error) {
 		// Override scip-python scheme so that we are able to autoindex
 		// index.scip created by scip-python
 		p.Scheme = dependencies.PythonPackagesScheme
+	case "scip-dotnet":
+		// scip-dotnet emits monikers of NuGet packages with the nuget
+		// manager, which are synced from NuGet package hosts. Package IDs
+		// and versions are case-insensitive and stored in lower case.
+		if p.Manager == "nuget" {
+			nugetPkg := reposource.NewNuGetVersionedPackage(reposource.PackageName(p.Name), p.Version)
+			p.Scheme = dependencies.NuGetPackagesScheme
+			p.Name = string(nugetPkg.Name)
+			p.Version = nugetPkg.Version
+		}
+	case "scip-php":
+		// scip-php emits monikers of Composer packages with the composer
+		// manager. Package names are case-insensitive and stored in lower
+		// case.
+		if p.Manager == "composer" {
+			composerPkg := reposource.NewComposerVersionedPackage(reposource.PackageName(p.Name), p.Version)
+			p.Scheme = dependencies.ComposerPackagesScheme
+			p.Name = string(composerPkg.Name)
+		}
 	}
 
 	return &p, nil
@@ -187,6 +187,51 @@ func TestNewPackage(t *testing.T) {
 				Version: "12.7.0",
 			},
 		},
+		{
+			name: "scip-dotnet nuget normalization",
+			in: shared.Package{
+				Scheme:  "scip-dotnet",
+				Manager: "nuget",
+				Name:    "Newtonsoft.Json",
+				Version: "13.0.3-Beta1",
+			},
+			out: &precise.Package{
+				Scheme:  dependencies.NuGetPackagesScheme,
+				Manager: "nuget",
+				Name:    "newtonsoft.json",
+				Version: "13.0.3-beta1",
+			},
+		},
+		{
+			name: "scip-php composer normalization",
+			in: shared.Package{
+				Scheme:  "scip-php",
+				Manager: "composer",
+				Name:    "Monolog/Monolog",
+				Version: "3.3.1",
+			},
+			out: &precise.Package{
+				Scheme:  dependencies.ComposerPackagesScheme,
+				Manager: "composer",
+				Name:    "monolog/monolog",
+				Version: "3.3.1",
+			},
+		},
+		{
+			name: "scip-dotnet project no-op",
+			in: shared.Package{
+				Scheme:  "scip-dotnet",
+				Manager: ".",
+				Name:    "MyProject",
+				Version: "1.0.0",
+			},
+			out: &precise.Package{
+				Scheme:  "scip-dotnet",
+				Manager: ".",
+				Name:    "MyProject",
+				Version: "1.0.0",
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			have, err := newPackage(tc.in)
@@ -19,6 +19,9 @@ func InferRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (
 		inferRustRepositoryAndRevision,
 		inferPythonRepositoryAndRevision,
 		inferRubyRepositoryAndRevision,
+		inferNuGetRepositoryAndRevision,
+		inferComposerRepositoryAndRevision,
+		inferHexRepositoryAndRevision,
 	} {
 		if repoName, gitTagOrCommit, ok := fn(pkg); ok {
 			return repoName, gitTagOrCommit, true
@@ -99,3 +102,30 @@ func inferRubyRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRep
 
 	return rubyPkg.RepoName(), pkg.Version, true
 }
+
+func inferNuGetRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.NuGetPackagesScheme {
+		return "", "", false
+	}
+
+	nugetPkg := reposource.NewNuGetVersionedPackage(pkg.Name, pkg.Version)
+	return nugetPkg.RepoName(), nugetPkg.GitTagFromVersion(), true
+}
+
+func inferComposerRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.ComposerPackagesScheme {
+		return "", "", false
+	}
+
+	composerPkg := reposource.NewComposerVersionedPackage(pkg.Name, pkg.Version)
+	return composerPkg.RepoName(), composerPkg.GitTagFromVersion(), true
+}
+
+func inferHexRepositoryAndRevision(pkg dependencies.MinimialVersionedPackageRepo) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.HexPackagesScheme {
+		return "", "", false
+	}
+
+	hexPkg := reposource.NewHexVersionedPackage(pkg.Name, pkg.Version)
+	return hexPkg.RepoName(), hexPkg.GitTagFromVersion(), true
+}
@@ -58,6 +58,33 @@ func TestInferRepositoryAndRevision(t *testing.T) {
 				repoName: "npm/myscope/mypackage",
 				revision: "v1.0.0",
 			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "nuget",
+					Name:    "newtonsoft.json",
+					Version: "13.0.3",
+				},
+				repoName: "nuget/newtonsoft.json",
+				revision: "v13.0.3",
+			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "composer",
+					Name:    "monolog/monolog",
+					Version: "v3.3.1",
+				},
+				repoName: "packagist/monolog/monolog",
+				revision: "v3.3.1",
+			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "hex",
+					Name:    "jason",
+					Version: "1.4.0",
+				},
+				repoName: "hex/jason",
+				revision: "v1.4.0",
+			},
 		}
 
 		for _, testCase := range testCases {
@@ -12,6 +12,7 @@ type operations struct {
 	createSandbox              *observation.Operation
 	inferIndexJobHints         *observation.Operation
 	inferIndexJobs             *observation.Operation
+	inferIndexJobsAndHints     *observation.Operation
 	invokeLinearizedRecognizer *observation.Operation
 	invokeRecognizers          *observation.Operation
 	resolveFileContents        *observation.Operation
@@ -49,6 +50,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		createSandbox:              op("createSandbox"),
 		inferIndexJobHints:         op("InferIndexJobHints"),
 		inferIndexJobs:             op("InferIndexJobs"),
+		inferIndexJobsAndHints:     op("InferIndexJobsAndHints"),
 		invokeLinearizedRecognizer: op("invokeLinearizedRecognizer"),
 		invokeRecognizers:          op("invokeRecognizers"),
 		resolveFileContents:        op("resolveFileContents"),
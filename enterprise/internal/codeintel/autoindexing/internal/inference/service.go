@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/lua"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/luatypes"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -44,9 +46,56 @@ type invocationContext struct {
 	gitService GitService
 	repo       api.RepoName
 	commit     string
+	recognizerNames
 	invocationFunctionTable
 }
 
+// recognizerNames tracks the name of the top-level recognizer responsible for each recognizer
+// instance invoked during inference (including those registered dynamically by another recognizer),
+// as well as the set of paths each named recognizer has been invoked with.
+type recognizerNames struct {
+	names        map[*luatypes.Recognizer]string
+	matchedPaths map[string]map[string]struct{}
+}
+
+func newRecognizerNames() recognizerNames {
+	return recognizerNames{
+		names:        map[*luatypes.Recognizer]string{},
+		matchedPaths: map[string]map[string]struct{}{},
+	}
+}
+
+func (n recognizerNames) addMatches(name string, paths []string, contentsByPath map[string]string) {
+	matchedPaths, ok := n.matchedPaths[name]
+	if !ok {
+		matchedPaths = map[string]struct{}{}
+		n.matchedPaths[name] = matchedPaths
+	}
+
+	for _, path := range paths {
+		matchedPaths[path] = struct{}{}
+	}
+	for path := range contentsByPath {
+		matchedPaths[path] = struct{}{}
+	}
+}
+
+func (n recognizerNames) matches() []shared.RecognizerMatch {
+	matches := make([]shared.RecognizerMatch, 0, len(n.matchedPaths))
+	for name, pathSet := range n.matchedPaths {
+		paths := make([]string, 0, len(pathSet))
+		for path := range pathSet {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		matches = append(matches, shared.RecognizerMatch{Name: name, Paths: paths})
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+
+	return matches
+}
+
 type invocationFunctionTable struct {
 	linearize    func(recognizer *luatypes.Recognizer) []*luatypes.Recognizer
 	callback     func(recognizer *luatypes.Recognizer) *baselua.LFunction
@@ -90,6 +139,10 @@ func (s *Service) InferIndexJobs(ctx context.Context, repo api.RepoName, commit,
 	}})
 	defer endObservation(1, observation.Args{})
 
+	return s.inferIndexJobs(ctx, repo, commit, overrideScript, newRecognizerNames())
+}
+
+func (s *Service) inferIndexJobs(ctx context.Context, repo api.RepoName, commit, overrideScript string, names recognizerNames) ([]config.IndexJob, error) {
 	functionTable := invocationFunctionTable{
 		linearize: luatypes.LinearizeGenerator,
 		callback:  func(recognizer *luatypes.Recognizer) *baselua.LFunction { return recognizer.Generator() },
@@ -109,7 +162,7 @@ func (s *Service) InferIndexJobs(ctx context.Context, repo api.RepoName, commit,
 		},
 	}
 
-	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable)
+	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable, names)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +190,10 @@ func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, com
 	}})
 	defer endObservation(1, observation.Args{})
 
+	return s.inferIndexJobHints(ctx, repo, commit, overrideScript, newRecognizerNames())
+}
+
+func (s *Service) inferIndexJobHints(ctx context.Context, repo api.RepoName, commit, overrideScript string, names recognizerNames) ([]config.IndexJobHint, error) {
 	functionTable := invocationFunctionTable{
 		linearize: luatypes.LinearizeHinter,
 		callback:  func(recognizer *luatypes.Recognizer) *baselua.LFunction { return recognizer.Hinter() },
@@ -156,7 +213,7 @@ func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, com
 		},
 	}
 
-	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable)
+	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable, names)
 	if err != nil {
 		return nil, err
 	}
@@ -173,6 +230,36 @@ func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, com
 	return jobHints, nil
 }
 
+// InferIndexJobsAndHints invokes the given script in a fresh Lua sandbox once for each of the
+// recognizer's generate and hints functions. The resulting index jobs and hints are returned along
+// with the set of paths each named recognizer was invoked with, which is useful to understand the
+// effect of a candidate override script before it is persisted.
+func (s *Service) InferIndexJobsAndHints(ctx context.Context, repo api.RepoName, commit, overrideScript string) (_ shared.InferenceResult, err error) {
+	ctx, _, endObservation := s.operations.inferIndexJobsAndHints.With(ctx, &err, observation.Args{LogFields: []otelog.Field{
+		otelog.String("repo", string(repo)),
+		otelog.String("commit", commit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	names := newRecognizerNames()
+
+	indexJobs, err := s.inferIndexJobs(ctx, repo, commit, overrideScript, names)
+	if err != nil {
+		return shared.InferenceResult{}, err
+	}
+
+	indexJobHints, err := s.inferIndexJobHints(ctx, repo, commit, overrideScript, names)
+	if err != nil {
+		return shared.InferenceResult{}, err
+	}
+
+	return shared.InferenceResult{
+		IndexJobs:         indexJobs,
+		IndexJobHints:     indexJobHints,
+		RecognizerMatches: names.matches(),
+	}, nil
+}
+
 // inferIndexJobOrHints invokes the given script in a fresh Lua sandbox. The return value of this script
 // is assumed to be a table of recognizer instances. Keys conflicting with the default recognizers will
 // overwrite them (to disable or change default behavior). Each recognizer's callback function is invoked
@@ -184,6 +271,7 @@ func (s *Service) inferIndexJobOrHints(
 	commit string,
 	overrideScript string,
 	invocationContextMethods invocationFunctionTable,
+	names recognizerNames,
 ) ([]indexJobOrHint, error) {
 	sandbox, err := s.createSandbox(ctx)
 	if err != nil {
@@ -191,16 +279,23 @@ func (s *Service) inferIndexJobOrHints(
 	}
 	defer sandbox.Close()
 
-	recognizers, err := s.setupRecognizers(ctx, sandbox, overrideScript)
-	if err != nil || len(recognizers) == 0 {
+	recognizerMap, err := s.setupRecognizers(ctx, sandbox, overrideScript)
+	if err != nil || len(recognizerMap) == 0 {
 		return nil, err
 	}
 
+	recognizers := make([]*luatypes.Recognizer, 0, len(recognizerMap))
+	for name, recognizer := range recognizerMap {
+		recognizers = append(recognizers, recognizer)
+		names.names[recognizer] = name
+	}
+
 	invocationContext := invocationContext{
 		sandbox:                 sandbox,
 		gitService:              s.gitService,
 		repo:                    repo,
 		commit:                  commit,
+		recognizerNames:         names,
 		invocationFunctionTable: invocationContextMethods,
 	}
 	return s.invokeRecognizers(ctx, invocationContext, recognizers)
@@ -232,8 +327,8 @@ func (s *Service) createSandbox(ctx context.Context) (_ *luasandbox.Sandbox, err
 }
 
 // setupRecognizers runs the given default and override scripts in the given sandbox and converts the
-// script return values to a list of recognizer instances.
-func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sandbox, overrideScript string) (_ []*luatypes.Recognizer, err error) {
+// script return values to a map of recognizer instances keyed by name.
+func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sandbox, overrideScript string) (_ map[string]*luatypes.Recognizer, err error) {
 	ctx, _, endObservation := s.operations.setupRecognizers.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
@@ -272,12 +367,7 @@ func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sand
 		}
 	}
 
-	recognizers := make([]*luatypes.Recognizer, 0, len(recognizerMap))
-	for _, recognizer := range recognizerMap {
-		recognizers = append(recognizers, recognizer)
-	}
-
-	return recognizers, nil
+	return recognizerMap, nil
 }
 
 // invokeRecognizers invokes each of the given recognizer's callback function and returns the resulting
@@ -456,6 +546,8 @@ func (s *Service) invokeRecognizerChains(
 
 	// Invoke the recognizers and gather the resulting jobs or hints
 	for _, recognizer := range recognizers {
+		numRegistered := len(registrationAPI.recognizers)
+
 		additionalJobOrHints, err := s.invokeRecognizerChainUntilResults(
 			ctx,
 			invocationContext,
@@ -468,6 +560,11 @@ func (s *Service) invokeRecognizerChains(
 			return nil, err
 		}
 
+		// Attribute recognizers registered by this invocation to the same name
+		for _, registered := range registrationAPI.recognizers[numRegistered:] {
+			invocationContext.names[registered] = invocationContext.names[recognizer]
+		}
+
 		jobOrHints = append(jobOrHints, additionalJobOrHints...)
 	}
 
@@ -498,10 +595,13 @@ func (s *Service) invokeRecognizerChainUntilResults(
 	paths []string,
 	contentsByPath map[string]string,
 ) ([]indexJobOrHint, error) {
+	name := invocationContext.names[recognizer]
+
 	for _, recognizer := range invocationContext.linearize(recognizer) {
 		if jobOrHints, err := s.invokeLinearizedRecognizer(
 			ctx,
 			invocationContext,
+			name,
 			recognizer,
 			registrationAPI,
 			paths,
@@ -518,6 +618,7 @@ func (s *Service) invokeRecognizerChainUntilResults(
 func (s *Service) invokeLinearizedRecognizer(
 	ctx context.Context,
 	invocationContext invocationContext,
+	name string,
 	recognizer *luatypes.Recognizer,
 	registrationAPI *registrationAPI,
 	paths []string,
@@ -533,6 +634,7 @@ func (s *Service) invokeLinearizedRecognizer(
 	if len(callPaths) == 0 && len(callContentsByPath) == 0 {
 		return nil, nil
 	}
+	invocationContext.addMatches(name, callPaths, callContentsByPath)
 
 	opts := luasandbox.RunOptions{}
 	args := []any{registrationAPI, callPaths, callContentsByPath}
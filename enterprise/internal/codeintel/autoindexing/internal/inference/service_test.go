@@ -7,8 +7,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"golang.org/x/time/rate"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/paths"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -17,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/unpack/unpacktest"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
 func testService(t *testing.T, repositoryContents map[string]string) *Service {
@@ -57,3 +60,61 @@ func testService(t *testing.T, repositoryContents map[string]string) *Service {
 
 	return newService(&observation.TestContext, sandboxService, gitService, ratelimit.NewInstrumentedLimiter("TestInference", rate.NewLimiter(rate.Limit(100), 1)), 100, 1024*1024)
 }
+
+func TestInferIndexJobsAndHints(t *testing.T) {
+	overrideScript := `
+		local path = require("path")
+		local pattern = require("sg.autoindex.patterns")
+		local recognizer = require("sg.autoindex.recognizer")
+
+		local custom_recognizer = recognizer.new_path_recognizer {
+			patterns = { pattern.new_path_basename("sg-test") },
+
+			generate = function(_, paths)
+				local jobs = {}
+				for i = 1, #paths do
+					table.insert(jobs, {
+						steps = {},
+						root = path.dirname(paths[i]),
+						indexer = "test-override",
+						indexer_args = {},
+						outfile = "",
+					})
+				end
+
+				return jobs
+			end,
+		}
+
+		return require("sg.autoindex.config").new({
+			["sg.test"] = false,
+			["custom.test"] = custom_recognizer,
+		})
+	`
+
+	service := testService(t, map[string]string{
+		"sg-test":     "",
+		"foo/sg-test": "",
+		"README.md":   "",
+	})
+
+	result, err := service.InferIndexJobsAndHints(context.Background(), "github.com/test/test", "HEAD", overrideScript)
+	if err != nil {
+		t.Fatalf("unexpected error inferring jobs and hints: %s", err)
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{Indexer: "test-override", Root: ""},
+		{Indexer: "test-override", Root: "foo"},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, sortIndexJobs(result.IndexJobs)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+
+	expectedMatches := []shared.RecognizerMatch{
+		{Name: "custom.test", Paths: []string{"foo/sg-test", "sg-test"}},
+	}
+	if diff := cmp.Diff(expectedMatches, result.RecognizerMatches); diff != "" {
+		t.Errorf("unexpected recognizer matches (-want +got):\n%s", diff)
+	}
+}
@@ -3,6 +3,7 @@ package jobselector
 import (
 	"context"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
@@ -10,4 +11,5 @@ import (
 type InferenceService interface {
 	InferIndexJobs(ctx context.Context, repo api.RepoName, commit, overrideScript string) ([]config.IndexJob, error)
 	InferIndexJobHints(ctx context.Context, repo api.RepoName, commit, overrideScript string) ([]config.IndexJobHint, error)
+	InferIndexJobsAndHints(ctx context.Context, repo api.RepoName, commit, overrideScript string) (shared.InferenceResult, error)
 }
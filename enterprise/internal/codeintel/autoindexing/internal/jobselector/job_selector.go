@@ -53,12 +53,9 @@ func (s *JobSelector) InferIndexJobsFromRepositoryStructure(ctx context.Context,
 		return nil, err
 	}
 
-	script, err := s.store.GetInferenceScript(ctx)
+	script, err := s.InferenceScript(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch inference script from database")
-	}
-	if script == "" {
-		script = overrideScript
+		return nil, err
 	}
 	if localOverrideScript != "" {
 		script = localOverrideScript
@@ -77,6 +74,20 @@ func (s *JobSelector) InferIndexJobsFromRepositoryStructure(ctx context.Context,
 	return indexes, nil
 }
 
+// InferenceScript returns the inference script currently used to infer index jobs. This is the
+// script set via the UI, falling back to the script set in the environment.
+func (s *JobSelector) InferenceScript(ctx context.Context) (string, error) {
+	script, err := s.store.GetInferenceScript(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to fetch inference script from database")
+	}
+	if script == "" {
+		script = overrideScript
+	}
+
+	return script, nil
+}
+
 // inferIndexJobsFromRepositoryStructure collects the result of  InferIndexJobHints over all registered recognizers.
 func (s *JobSelector) InferIndexJobHintsFromRepositoryStructure(ctx context.Context, repoName api.RepoName, commit string) ([]config.IndexJobHint, error) {
 	indexes, err := s.inferenceSvc.InferIndexJobHints(ctx, repoName, commit, overrideScript)
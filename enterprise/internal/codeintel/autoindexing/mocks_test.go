@@ -2474,6 +2474,9 @@ type MockInferenceService struct {
 	// InferIndexJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method InferIndexJobs.
 	InferIndexJobsFunc *InferenceServiceInferIndexJobsFunc
+	// InferIndexJobsAndHintsFunc is an instance of a mock function object
+	// controlling the behavior of the method InferIndexJobsAndHints.
+	InferIndexJobsAndHintsFunc *InferenceServiceInferIndexJobsAndHintsFunc
 }
 
 // NewMockInferenceService creates a new mock of the InferenceService
@@ -2491,6 +2494,11 @@ func NewMockInferenceService() *MockInferenceService {
 				return
 			},
 		},
+		InferIndexJobsAndHintsFunc: &InferenceServiceInferIndexJobsAndHintsFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (r0 shared.InferenceResult, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -2508,6 +2516,11 @@ func NewStrictMockInferenceService() *MockInferenceService {
 				panic("unexpected invocation of MockInferenceService.InferIndexJobs")
 			},
 		},
+		InferIndexJobsAndHintsFunc: &InferenceServiceInferIndexJobsAndHintsFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error) {
+				panic("unexpected invocation of MockInferenceService.InferIndexJobsAndHints")
+			},
+		},
 	}
 }
 
@@ -2522,6 +2535,9 @@ func NewMockInferenceServiceFrom(i InferenceService) *MockInferenceService {
 		InferIndexJobsFunc: &InferenceServiceInferIndexJobsFunc{
 			defaultHook: i.InferIndexJobs,
 		},
+		InferIndexJobsAndHintsFunc: &InferenceServiceInferIndexJobsAndHintsFunc{
+			defaultHook: i.InferIndexJobsAndHints,
+		},
 	}
 }
 
@@ -2758,6 +2774,124 @@ func (c InferenceServiceInferIndexJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// InferenceServiceInferIndexJobsAndHintsFunc describes the behavior when
+// the InferIndexJobsAndHints method of the parent MockInferenceService
+// instance is invoked.
+type InferenceServiceInferIndexJobsAndHintsFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error)
+	hooks       []func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error)
+	history     []InferenceServiceInferIndexJobsAndHintsFuncCall
+	mutex       sync.Mutex
+}
+
+// InferIndexJobsAndHints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockInferenceService) InferIndexJobsAndHints(v0 context.Context, v1 api.RepoName, v2 string, v3 string) (shared.InferenceResult, error) {
+	r0, r1 := m.InferIndexJobsAndHintsFunc.nextHook()(v0, v1, v2, v3)
+	m.InferIndexJobsAndHintsFunc.appendCall(InferenceServiceInferIndexJobsAndHintsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// InferIndexJobsAndHints method of the parent MockInferenceService instance
+// is invoked and the hook queue is empty.
+func (f *InferenceServiceInferIndexJobsAndHintsFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InferIndexJobsAndHints method of the parent MockInferenceService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *InferenceServiceInferIndexJobsAndHintsFunc) PushHook(hook func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *InferenceServiceInferIndexJobsAndHintsFunc) SetDefaultReturn(r0 shared.InferenceResult, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *InferenceServiceInferIndexJobsAndHintsFunc) PushReturn(r0 shared.InferenceResult, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error) {
+		return r0, r1
+	})
+}
+
+func (f *InferenceServiceInferIndexJobsAndHintsFunc) nextHook() func(context.Context, api.RepoName, string, string) (shared.InferenceResult, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InferenceServiceInferIndexJobsAndHintsFunc) appendCall(r0 InferenceServiceInferIndexJobsAndHintsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// InferenceServiceInferIndexJobsAndHintsFuncCall objects describing the
+// invocations of this function.
+func (f *InferenceServiceInferIndexJobsAndHintsFunc) History() []InferenceServiceInferIndexJobsAndHintsFuncCall {
+	f.mutex.Lock()
+	history := make([]InferenceServiceInferIndexJobsAndHintsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InferenceServiceInferIndexJobsAndHintsFuncCall is an object that
+// describes an invocation of method InferIndexJobsAndHints on an instance
+// of MockInferenceService.
+type InferenceServiceInferIndexJobsAndHintsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.InferenceResult
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InferenceServiceInferIndexJobsAndHintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InferenceServiceInferIndexJobsAndHintsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockRepoUpdaterClient is a mock implementation of the RepoUpdaterClient
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing)
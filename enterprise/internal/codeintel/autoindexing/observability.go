@@ -10,7 +10,8 @@ import (
 )
 
 type operations struct {
-	inferIndexConfiguration *observation.Operation
+	inferIndexConfiguration   *observation.Operation
+	previewIndexConfiguration *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -40,6 +41,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		inferIndexConfiguration: op("InferIndexConfiguration"),
+		inferIndexConfiguration:   op("InferIndexConfiguration"),
+		previewIndexConfiguration: op("PreviewIndexConfiguration"),
 	}
 }
@@ -0,0 +1,135 @@
+package autoindexing
+
+import (
+	"sort"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// unmarshalCandidateConfiguration parses the given index configuration, which may be supplied either
+// in the JSON format stored in the database or the YAML format of a committed `sourcegraph.yaml` file.
+func unmarshalCandidateConfiguration(configuration string) (config.IndexConfiguration, error) {
+	indexConfiguration, jsonErr := config.UnmarshalJSON([]byte(configuration))
+	if jsonErr == nil {
+		return indexConfiguration, nil
+	}
+
+	indexConfiguration, yamlErr := config.UnmarshalYAML([]byte(configuration))
+	if yamlErr == nil {
+		return indexConfiguration, nil
+	}
+
+	return config.IndexConfiguration{}, errors.Wrap(errors.Append(jsonErr, yamlErr), "candidate configuration")
+}
+
+type diffKey struct {
+	root    string
+	indexer string
+}
+
+// diffIndexJobs pairs the given index jobs by root and indexer and classifies each pair. The
+// resulting diffs are ordered by root, then indexer.
+func diffIndexJobs(current, candidate []config.IndexJob) []shared.IndexJobDiff {
+	currentByKey := make(map[diffKey]*config.IndexJob, len(current))
+	for i := range current {
+		currentByKey[diffKey{current[i].Root, current[i].Indexer}] = &current[i]
+	}
+	candidateByKey := make(map[diffKey]*config.IndexJob, len(candidate))
+	for i := range candidate {
+		candidateByKey[diffKey{candidate[i].Root, candidate[i].Indexer}] = &candidate[i]
+	}
+
+	diffs := make([]shared.IndexJobDiff, 0, len(currentByKey)+len(candidateByKey))
+	for _, key := range sortedDiffKeys(currentByKey, candidateByKey) {
+		currentJob, candidateJob := currentByKey[key], candidateByKey[key]
+
+		diffs = append(diffs, shared.IndexJobDiff{
+			Kind:      diffKind(currentJob != nil, candidateJob != nil, func() bool { return indexJobsEqual(*currentJob, *candidateJob) }),
+			Current:   currentJob,
+			Candidate: candidateJob,
+		})
+	}
+
+	return diffs
+}
+
+// diffIndexJobHints pairs the given index job hints by root and indexer and classifies each pair.
+// The resulting diffs are ordered by root, then indexer.
+func diffIndexJobHints(current, candidate []config.IndexJobHint) []shared.IndexJobHintDiff {
+	currentByKey := make(map[diffKey]*config.IndexJobHint, len(current))
+	for i := range current {
+		currentByKey[diffKey{current[i].Root, current[i].Indexer}] = &current[i]
+	}
+	candidateByKey := make(map[diffKey]*config.IndexJobHint, len(candidate))
+	for i := range candidate {
+		candidateByKey[diffKey{candidate[i].Root, candidate[i].Indexer}] = &candidate[i]
+	}
+
+	diffs := make([]shared.IndexJobHintDiff, 0, len(currentByKey)+len(candidateByKey))
+	for _, key := range sortedDiffKeys(currentByKey, candidateByKey) {
+		currentHint, candidateHint := currentByKey[key], candidateByKey[key]
+
+		diffs = append(diffs, shared.IndexJobHintDiff{
+			Kind:      diffKind(currentHint != nil, candidateHint != nil, func() bool { return *currentHint == *candidateHint }),
+			Current:   currentHint,
+			Candidate: candidateHint,
+		})
+	}
+
+	return diffs
+}
+
+func diffKind(hasCurrent, hasCandidate bool, equal func() bool) shared.DiffKind {
+	switch {
+	case !hasCurrent:
+		return shared.DiffKindAdded
+	case !hasCandidate:
+		return shared.DiffKindRemoved
+	case !equal():
+		return shared.DiffKindChanged
+	default:
+		return shared.DiffKindUnchanged
+	}
+}
+
+func sortedDiffKeys[T any](current, candidate map[diffKey]T) []diffKey {
+	keySet := make(map[diffKey]struct{}, len(current)+len(candidate))
+	for key := range current {
+		keySet[key] = struct{}{}
+	}
+	for key := range candidate {
+		keySet[key] = struct{}{}
+	}
+
+	keys := make([]diffKey, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].root < keys[j].root || (keys[i].root == keys[j].root && keys[i].indexer < keys[j].indexer)
+	})
+
+	return keys
+}
+
+func indexJobsEqual(a, b config.IndexJob) bool {
+	if len(a.Steps) != len(b.Steps) {
+		return false
+	}
+	for i := range a.Steps {
+		if a.Steps[i].Root != b.Steps[i].Root || a.Steps[i].Image != b.Steps[i].Image || !slices.Equal(a.Steps[i].Commands, b.Steps[i].Commands) {
+			return false
+		}
+	}
+
+	return a.Root == b.Root &&
+		a.Indexer == b.Indexer &&
+		a.Outfile == b.Outfile &&
+		slices.Equal(a.LocalSteps, b.LocalSteps) &&
+		slices.Equal(a.IndexerArgs, b.IndexerArgs) &&
+		slices.Equal(a.RequestedEnvVars, b.RequestedEnvVars)
+}
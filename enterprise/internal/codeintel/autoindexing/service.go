@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -99,21 +100,9 @@ func (s *Service) InferIndexConfiguration(ctx context.Context, repositoryID int,
 		return nil, nil, err
 	}
 
-	if commit == "" {
-		var ok bool
-		commit, ok, err = s.gitserverClient.Head(ctx, authz.DefaultSubRepoPermsChecker, repo.Name)
-		if err != nil || !ok {
-			return nil, nil, errors.Wrapf(err, "gitserver.Head: error resolving HEAD for %d", repositoryID)
-		}
-	} else {
-		exists, err := s.gitserverClient.CommitExists(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, api.CommitID(commit))
-		if err != nil {
-			return nil, nil, errors.Wrapf(err, "gitserver.CommitExists: error checking %s for %d", commit, repositoryID)
-		}
-
-		if !exists {
-			return nil, nil, errors.Newf("revision %s not found for %d", commit, repositoryID)
-		}
+	commit, err = s.resolveCommit(ctx, repo, commit)
+	if err != nil {
+		return nil, nil, err
 	}
 	trace.AddEvent("found", attribute.String("commit", commit))
 
@@ -136,6 +125,89 @@ func (s *Service) InferIndexConfiguration(ctx context.Context, repositoryID int,
 	}, indexJobHints, nil
 }
 
+// PreviewIndexConfiguration runs inference at the given commit of the given repository with both the
+// currently active inference script and the given candidate script, and returns both results along with
+// a diff of the resulting index jobs and hints. If a candidate configuration is supplied (as JSON or in
+// the YAML format of a committed `sourcegraph.yaml` file), its index jobs are used in place of the jobs
+// inferred by the candidate script. Nothing is persisted or enqueued.
+func (s *Service) PreviewIndexConfiguration(ctx context.Context, repositoryID int, commit, candidateScript, candidateConfiguration string) (_ *shared.InferencePreview, err error) {
+	ctx, trace, endObservation := s.operations.previewIndexConfiguration.With(ctx, &err, observation.Args{
+		LogFields: []otlog.Field{
+			otlog.Int("repositoryID", repositoryID),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	repo, err := s.repoStore.Get(ctx, api.RepoID(repositoryID))
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err = s.resolveCommit(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("found", attribute.String("commit", commit))
+
+	currentScript, err := s.jobSelector.InferenceScript(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if candidateScript == "" {
+		candidateScript = currentScript
+	}
+
+	current, err := s.inferenceSvc.InferIndexJobsAndHints(ctx, repo.Name, commit, currentScript)
+	if err != nil {
+		return nil, errors.Wrap(err, "current inference script")
+	}
+
+	candidate, err := s.inferenceSvc.InferIndexJobsAndHints(ctx, repo.Name, commit, candidateScript)
+	if err != nil {
+		return nil, errors.Wrap(err, "candidate inference script")
+	}
+
+	if candidateConfiguration != "" {
+		indexConfiguration, err := unmarshalCandidateConfiguration(candidateConfiguration)
+		if err != nil {
+			return nil, err
+		}
+
+		candidate.IndexJobs = indexConfiguration.IndexJobs
+	}
+
+	return &shared.InferencePreview{
+		Commit:        commit,
+		Current:       current,
+		Candidate:     candidate,
+		IndexJobs:     diffIndexJobs(current.IndexJobs, candidate.IndexJobs),
+		IndexJobHints: diffIndexJobHints(current.IndexJobHints, candidate.IndexJobHints),
+	}, nil
+}
+
+// resolveCommit returns the given commit if it exists in the given repository. If no commit is
+// supplied, the commit at the tip of the default branch is returned.
+func (s *Service) resolveCommit(ctx context.Context, repo *types.Repo, commit string) (string, error) {
+	if commit == "" {
+		commit, ok, err := s.gitserverClient.Head(ctx, authz.DefaultSubRepoPermsChecker, repo.Name)
+		if err != nil || !ok {
+			return "", errors.Wrapf(err, "gitserver.Head: error resolving HEAD for %d", repo.ID)
+		}
+
+		return commit, nil
+	}
+
+	exists, err := s.gitserverClient.CommitExists(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, api.CommitID(commit))
+	if err != nil {
+		return "", errors.Wrapf(err, "gitserver.CommitExists: error checking %s for %d", commit, repo.ID)
+	}
+	if !exists {
+		return "", errors.Newf("revision %s not found for %d", commit, repo.ID)
+	}
+
+	return commit, nil
+}
+
 func (s *Service) UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, data []byte) error {
 	return s.store.UpdateIndexConfigurationByRepositoryID(ctx, repositoryID, data)
 }
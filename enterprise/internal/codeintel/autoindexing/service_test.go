@@ -491,6 +491,92 @@ func TestQueueIndexesForPackage(t *testing.T) {
 	}
 }
 
+func TestPreviewIndexConfiguration(t *testing.T) {
+	mockDBStore := NewMockStore()
+	mockDBStore.GetInferenceScriptFunc.SetDefaultReturn("current", nil)
+	mockGitserverClient := gitserver.NewMockClient()
+	mockGitserverClient.CommitExistsFunc.SetDefaultReturn(true, nil)
+
+	inferenceService := NewMockInferenceService()
+	inferenceService.InferIndexJobsAndHintsFunc.SetDefaultHook(func(ctx context.Context, repo api.RepoName, commit, overrideScript string) (shared.InferenceResult, error) {
+		if overrideScript == "current" {
+			return shared.InferenceResult{
+				IndexJobs: []config.IndexJob{
+					{Root: "a", Indexer: "scip-go"},
+					{Root: "b", Indexer: "scip-go", IndexerArgs: []string{"--old"}},
+					{Root: "c", Indexer: "scip-go"},
+				},
+				IndexJobHints: []config.IndexJobHint{
+					{Root: "d", Indexer: "scip-java", HintConfidence: config.HintConfidenceLanguageSupport},
+				},
+				RecognizerMatches: []shared.RecognizerMatch{{Name: "sg.go", Paths: []string{"a/go.mod", "b/go.mod", "c/go.mod"}}},
+			}, nil
+		}
+
+		return shared.InferenceResult{
+			IndexJobs: []config.IndexJob{
+				{Root: "b", Indexer: "scip-go", IndexerArgs: []string{"--new"}},
+				{Root: "c", Indexer: "scip-go"},
+				{Root: "e", Indexer: "scip-go"},
+			},
+			IndexJobHints: []config.IndexJobHint{
+				{Root: "d", Indexer: "scip-java", HintConfidence: config.HintConfidenceLanguageSupport},
+			},
+		}, nil
+	})
+
+	service := newService(
+		&observation.TestContext,
+		mockDBStore,
+		inferenceService,
+		nil,                    // repoUpdater
+		defaultMockRepoStore(), // repoStore
+		mockGitserverClient,
+	)
+
+	preview, err := service.PreviewIndexConfiguration(context.Background(), 42, "deadbeef", "candidate", "")
+	if err != nil {
+		t.Fatalf("unexpected error previewing index configuration: %s", err)
+	}
+
+	if history := inferenceService.InferIndexJobsAndHintsFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected number of calls to InferIndexJobsAndHints. want=%d have=%d", 2, len(history))
+	} else if history[0].Arg3 != "current" || history[1].Arg3 != "candidate" {
+		t.Errorf("unexpected scripts. want=%v have=%v", []string{"current", "candidate"}, []string{history[0].Arg3, history[1].Arg3})
+	}
+
+	var kinds []string
+	for _, diff := range preview.IndexJobs {
+		job := diff.Current
+		if job == nil {
+			job = diff.Candidate
+		}
+
+		kinds = append(kinds, fmt.Sprintf("%s:%s", job.Root, diff.Kind))
+	}
+	if diff := cmp.Diff([]string{"a:REMOVED", "b:CHANGED", "c:UNCHANGED", "e:ADDED"}, kinds); diff != "" {
+		t.Errorf("unexpected index job diffs (-want +got):\n%s", diff)
+	}
+
+	if len(preview.IndexJobHints) != 1 || preview.IndexJobHints[0].Kind != shared.DiffKindUnchanged {
+		t.Errorf("unexpected index job hint diffs: %+v", preview.IndexJobHints)
+	}
+
+	// Explicit configuration replaces the candidate's inferred jobs
+	preview, err = service.PreviewIndexConfiguration(context.Background(), 42, "deadbeef", "", "index_jobs:\n  - root: a\n    indexer: scip-go\n")
+	if err != nil {
+		t.Fatalf("unexpected error previewing index configuration: %s", err)
+	}
+
+	kinds = kinds[:0]
+	for _, diff := range preview.IndexJobs {
+		kinds = append(kinds, string(diff.Kind))
+	}
+	if diff := cmp.Diff([]string{"UNCHANGED", "REMOVED", "REMOVED"}, kinds); diff != "" {
+		t.Errorf("unexpected index job diffs (-want +got):\n%s", diff)
+	}
+}
+
 func defaultMockRepoStore() *database.MockRepoStore {
 	repoStore := database.NewMockRepoStore()
 	repoStore.GetFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*internaltypes.Repo, error) {
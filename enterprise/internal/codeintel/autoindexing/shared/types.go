@@ -1,8 +1,62 @@
 package shared
 
+import "github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+
 // IndexConfiguration stores the index configuration for a repository.
 type IndexConfiguration struct {
 	ID           int
 	RepositoryID int
 	Data         []byte
 }
+
+// InferenceResult is the complete output of running the inference script against a single
+// commit of a repository.
+type InferenceResult struct {
+	IndexJobs         []config.IndexJob
+	IndexJobHints     []config.IndexJobHint
+	RecognizerMatches []RecognizerMatch
+}
+
+// RecognizerMatch describes the set of paths that were passed to the callbacks of a named
+// recognizer (or a recognizer registered by it) during inference.
+type RecognizerMatch struct {
+	Name  string
+	Paths []string
+}
+
+// InferencePreview compares the result of inference with the currently active inference script
+// and configuration against a candidate script and/or configuration at the same commit.
+type InferencePreview struct {
+	Commit        string
+	Current       InferenceResult
+	Candidate     InferenceResult
+	IndexJobs     []IndexJobDiff
+	IndexJobHints []IndexJobHintDiff
+}
+
+// DiffKind describes how a value changes between the current and candidate inference results.
+type DiffKind string
+
+const (
+	DiffKindAdded     DiffKind = "ADDED"
+	DiffKindRemoved   DiffKind = "REMOVED"
+	DiffKindChanged   DiffKind = "CHANGED"
+	DiffKindUnchanged DiffKind = "UNCHANGED"
+)
+
+// IndexJobDiff pairs index jobs from the current and candidate inference results that share
+// the same root and indexer. Current is nil for added jobs and Candidate is nil for removed jobs.
+type IndexJobDiff struct {
+	Kind      DiffKind
+	Current   *config.IndexJob
+	Candidate *config.IndexJob
+}
+
+// IndexJobHintDiff pairs index job hints from the current and candidate inference results that
+// share the same root and indexer. Current is nil for added hints and Candidate is nil for removed
+// hints.
+type IndexJobHintDiff struct {
+	Kind      DiffKind
+	Current   *config.IndexJobHint
+	Candidate *config.IndexJobHint
+}
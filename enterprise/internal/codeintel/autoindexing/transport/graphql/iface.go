@@ -22,6 +22,7 @@ type AutoIndexingService interface {
 	QueueIndexes(ctx context.Context, repositoryID int, rev, configuration string, force bool, bypassLimit bool) ([]uploadsshared.Index, error)
 	InferIndexConfiguration(ctx context.Context, repositoryID int, commit string, localOverrideScript string, bypassLimit bool) (*config.IndexConfiguration, []config.IndexJobHint, error)
 	InferIndexJobsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string, localOverrideScript string, bypassLimit bool) ([]config.IndexJob, error)
+	PreviewIndexConfiguration(ctx context.Context, repositoryID int, commit, candidateScript, candidateConfiguration string) (*shared.InferencePreview, error)
 }
 
 type (
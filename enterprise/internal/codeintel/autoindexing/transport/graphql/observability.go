@@ -11,6 +11,7 @@ type operations struct {
 	codeIntelligenceInferenceScript       *observation.Operation
 	indexConfiguration                    *observation.Operation
 	inferAutoIndexJobsForRepo             *observation.Operation
+	previewAutoIndexJobInference          *observation.Operation
 	queueAutoIndexJobsForRepo             *observation.Operation
 	updateCodeIntelligenceInferenceScript *observation.Operation
 	updateRepositoryIndexConfiguration    *observation.Operation
@@ -36,6 +37,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		codeIntelligenceInferenceScript:       op("CodeIntelligenceInferenceScript"),
 		indexConfiguration:                    op("IndexConfiguration"),
 		inferAutoIndexJobsForRepo:             op("InferAutoIndexJobsForRepo"),
+		previewAutoIndexJobInference:          op("PreviewAutoIndexJobInference"),
 		queueAutoIndexJobsForRepo:             op("QueueAutoIndexJobsForRepo"),
 		updateCodeIntelligenceInferenceScript: op("UpdateCodeIntelligenceInferenceScript"),
 		updateRepositoryIndexConfiguration:    op("UpdateRepositoryIndexConfiguration"),
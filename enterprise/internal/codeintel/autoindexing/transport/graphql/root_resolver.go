@@ -19,6 +19,7 @@ type rootResolver struct {
 	gitserverClient         gitserver.Client
 	operations              *operations
 	siteAdminChecker        sharedresolvers.SiteAdminChecker
+	permissionChecker       sharedresolvers.PermissionChecker
 	repoStore               database.RepoStore
 	prefetcherFactory       *graphql.PrefetcherFactory
 	locationResolverFactory *gitresolvers.CachedLocationResolverFactory
@@ -31,6 +32,7 @@ func NewRootResolver(
 	policySvc PolicyService,
 	gitserverClient gitserver.Client,
 	siteAdminChecker sharedresolvers.SiteAdminChecker,
+	permissionChecker sharedresolvers.PermissionChecker,
 	repoStore database.RepoStore,
 	prefetcherFactory *graphql.PrefetcherFactory,
 	locationResolverFactory *gitresolvers.CachedLocationResolverFactory,
@@ -42,6 +44,7 @@ func NewRootResolver(
 		gitserverClient:         gitserverClient,
 		operations:              newOperations(observationCtx),
 		siteAdminChecker:        siteAdminChecker,
+		permissionChecker:       permissionChecker,
 		repoStore:               repoStore,
 		prefetcherFactory:       prefetcherFactory,
 		locationResolverFactory: locationResolverFactory,
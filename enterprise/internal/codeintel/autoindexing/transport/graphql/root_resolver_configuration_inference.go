@@ -7,6 +7,7 @@ import (
 
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 )
 
 func (r *rootResolver) CodeIntelligenceInferenceScript(ctx context.Context) (script string, err error) {
@@ -16,12 +17,17 @@ func (r *rootResolver) CodeIntelligenceInferenceScript(ctx context.Context) (scr
 	return r.autoindexSvc.GetInferenceScript(ctx)
 }
 
+// 🚨 SECURITY: Only users with the CODE_INTEL#AUTO_INDEXING_WRITE permission may update the inference script
 func (r *rootResolver) UpdateCodeIntelligenceInferenceScript(ctx context.Context, args *resolverstubs.UpdateCodeIntelligenceInferenceScriptArgs) (_ *resolverstubs.EmptyResponse, err error) {
 	ctx, _, endObservation := r.operations.updateCodeIntelligenceInferenceScript.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("script", args.Script),
 	}})
 	defer endObservation(1, observation.Args{})
 
+	if err := r.permissionChecker.CheckCurrentUserHasPermission(ctx, rbac.CodeIntelAutoIndexingWritePermission); err != nil {
+		return nil, err
+	}
+
 	if err := r.autoindexSvc.SetInferenceScript(ctx, args.Script); err != nil {
 		return nil, err
 	}
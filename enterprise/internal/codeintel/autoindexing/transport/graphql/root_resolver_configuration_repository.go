@@ -12,6 +12,7 @@ import (
 	sharedresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -35,7 +36,7 @@ func (r *rootResolver) IndexConfiguration(ctx context.Context, repoID graphql.ID
 	return newIndexConfigurationResolver(r.autoindexSvc, r.siteAdminChecker, repositoryID, traceErrs), nil
 }
 
-// 🚨 SECURITY: Only site admins may modify code intelligence indexing configuration
+// 🚨 SECURITY: Only users with the CODE_INTEL#AUTO_INDEXING_WRITE permission may modify code intelligence indexing configuration
 func (r *rootResolver) UpdateRepositoryIndexConfiguration(ctx context.Context, args *resolverstubs.UpdateRepositoryIndexConfigurationArgs) (_ *resolverstubs.EmptyResponse, err error) {
 	ctx, _, endObservation := r.operations.updateRepositoryIndexConfiguration.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("repository", string(args.Repository)),
@@ -43,7 +44,7 @@ func (r *rootResolver) UpdateRepositoryIndexConfiguration(ctx context.Context, a
 	}})
 	defer endObservation(1, observation.Args{})
 
-	if err := r.siteAdminChecker.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+	if err := r.permissionChecker.CheckCurrentUserHasPermission(ctx, rbac.CodeIntelAutoIndexingWritePermission); err != nil {
 		return nil, err
 	}
 	if !autoIndexingEnabled() {
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
@@ -61,7 +62,7 @@ func (r *rootResolver) InferAutoIndexJobsForRepo(ctx context.Context, args *reso
 	return newDescriptionResolvers(r.siteAdminChecker, config)
 }
 
-// 🚨 SECURITY: Only site admins may queue auto-index jobs
+// 🚨 SECURITY: Only users with the CODE_INTEL#AUTO_INDEXING_WRITE permission may queue auto-index jobs
 func (r *rootResolver) QueueAutoIndexJobsForRepo(ctx context.Context, args *resolverstubs.QueueAutoIndexJobsForRepoArgs) (_ []resolverstubs.PreciseIndexResolver, err error) {
 	ctx, traceErrs, endObservation := r.operations.queueAutoIndexJobsForRepo.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("repository", string(args.Repository)),
@@ -70,7 +71,7 @@ func (r *rootResolver) QueueAutoIndexJobsForRepo(ctx context.Context, args *reso
 	}})
 	endObservation.OnCancel(ctx, 1, observation.Args{})
 
-	if err := r.siteAdminChecker.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+	if err := r.permissionChecker.CheckCurrentUserHasPermission(ctx, rbac.CodeIntelAutoIndexingWritePermission); err != nil {
 		return nil, err
 	}
 	if !autoIndexingEnabled() {
@@ -129,23 +130,27 @@ type autoIndexJobDescriptionResolver struct {
 func newDescriptionResolvers(siteAdminChecker sharedresolvers.SiteAdminChecker, indexConfiguration *config.IndexConfiguration) ([]resolverstubs.AutoIndexJobDescriptionResolver, error) {
 	var resolvers []resolverstubs.AutoIndexJobDescriptionResolver
 	for _, indexJob := range indexConfiguration.IndexJobs {
-		var steps []uploadsshared.DockerStep
-		for _, step := range indexJob.Steps {
-			steps = append(steps, uploadsshared.DockerStep{
-				Root:     step.Root,
-				Image:    step.Image,
-				Commands: step.Commands,
-			})
-		}
+		resolvers = append(resolvers, newDescriptionResolver(siteAdminChecker, indexJob))
+	}
+
+	return resolvers, nil
+}
 
-		resolvers = append(resolvers, &autoIndexJobDescriptionResolver{
-			siteAdminChecker: siteAdminChecker,
-			indexJob:         indexJob,
-			steps:            steps,
+func newDescriptionResolver(siteAdminChecker sharedresolvers.SiteAdminChecker, indexJob config.IndexJob) resolverstubs.AutoIndexJobDescriptionResolver {
+	var steps []uploadsshared.DockerStep
+	for _, step := range indexJob.Steps {
+		steps = append(steps, uploadsshared.DockerStep{
+			Root:     step.Root,
+			Image:    step.Image,
+			Commands: step.Commands,
 		})
 	}
 
-	return resolvers, nil
+	return &autoIndexJobDescriptionResolver{
+		siteAdminChecker: siteAdminChecker,
+		indexJob:         indexJob,
+		steps:            steps,
+	}
 }
 
 func (r *autoIndexJobDescriptionResolver) Root() string {
@@ -0,0 +1,211 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
+	sharedresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers"
+	uploadsgraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/transport/graphql"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+// 🚨 SECURITY: Only site admins may preview auto-index job inference
+func (r *rootResolver) PreviewAutoIndexJobInference(ctx context.Context, args *resolverstubs.PreviewAutoIndexJobInferenceArgs) (_ resolverstubs.AutoIndexJobInferencePreviewResolver, err error) {
+	ctx, _, endObservation := r.operations.previewAutoIndexJobInference.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repository", string(args.Repository)),
+		log.String("rev", resolverstubs.Deref(args.Rev, "")),
+		log.String("script", resolverstubs.Deref(args.Script, "")),
+		log.String("configuration", resolverstubs.Deref(args.Configuration, "")),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := r.siteAdminChecker.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if !autoIndexingEnabled() {
+		return nil, errAutoIndexingNotEnabled
+	}
+
+	repositoryID, err := resolverstubs.UnmarshalID[int](args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := r.autoindexSvc.PreviewIndexConfiguration(
+		ctx,
+		repositoryID,
+		resolverstubs.Deref(args.Rev, ""),
+		resolverstubs.Deref(args.Script, ""),
+		resolverstubs.Deref(args.Configuration, ""),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autoIndexJobInferencePreviewResolver{
+		siteAdminChecker: r.siteAdminChecker,
+		preview:          preview,
+	}, nil
+}
+
+//
+//
+
+type autoIndexJobInferencePreviewResolver struct {
+	siteAdminChecker sharedresolvers.SiteAdminChecker
+	preview          *shared.InferencePreview
+}
+
+func (r *autoIndexJobInferencePreviewResolver) Commit() string {
+	return r.preview.Commit
+}
+
+func (r *autoIndexJobInferencePreviewResolver) IndexJobs() []resolverstubs.AutoIndexJobDiffResolver {
+	resolvers := make([]resolverstubs.AutoIndexJobDiffResolver, 0, len(r.preview.IndexJobs))
+	for _, diff := range r.preview.IndexJobs {
+		resolvers = append(resolvers, &autoIndexJobDiffResolver{
+			kind:      diff.Kind,
+			current:   newDescriptionDiffResolver(r.siteAdminChecker, diff.Current),
+			candidate: newDescriptionDiffResolver(r.siteAdminChecker, diff.Candidate),
+		})
+	}
+
+	return resolvers
+}
+
+func (r *autoIndexJobInferencePreviewResolver) IndexJobHints() []resolverstubs.AutoIndexJobHintDiffResolver {
+	resolvers := make([]resolverstubs.AutoIndexJobHintDiffResolver, 0, len(r.preview.IndexJobHints))
+	for _, diff := range r.preview.IndexJobHints {
+		resolvers = append(resolvers, &autoIndexJobHintDiffResolver{
+			kind:      diff.Kind,
+			current:   newHintResolver(diff.Current),
+			candidate: newHintResolver(diff.Candidate),
+		})
+	}
+
+	return resolvers
+}
+
+func (r *autoIndexJobInferencePreviewResolver) CurrentRecognizerMatches() []resolverstubs.AutoIndexRecognizerMatchResolver {
+	return newRecognizerMatchResolvers(r.preview.Current.RecognizerMatches)
+}
+
+func (r *autoIndexJobInferencePreviewResolver) CandidateRecognizerMatches() []resolverstubs.AutoIndexRecognizerMatchResolver {
+	return newRecognizerMatchResolvers(r.preview.Candidate.RecognizerMatches)
+}
+
+//
+//
+
+type autoIndexJobDiffResolver struct {
+	kind      shared.DiffKind
+	current   resolverstubs.AutoIndexJobDescriptionResolver
+	candidate resolverstubs.AutoIndexJobDescriptionResolver
+}
+
+func newDescriptionDiffResolver(siteAdminChecker sharedresolvers.SiteAdminChecker, indexJob *config.IndexJob) resolverstubs.AutoIndexJobDescriptionResolver {
+	if indexJob == nil {
+		return nil
+	}
+
+	return newDescriptionResolver(siteAdminChecker, *indexJob)
+}
+
+func (r *autoIndexJobDiffResolver) Kind() string {
+	return string(r.kind)
+}
+
+func (r *autoIndexJobDiffResolver) Current() resolverstubs.AutoIndexJobDescriptionResolver {
+	return r.current
+}
+
+func (r *autoIndexJobDiffResolver) Candidate() resolverstubs.AutoIndexJobDescriptionResolver {
+	return r.candidate
+}
+
+//
+//
+
+type autoIndexJobHintDiffResolver struct {
+	kind      shared.DiffKind
+	current   resolverstubs.AutoIndexJobHintResolver
+	candidate resolverstubs.AutoIndexJobHintResolver
+}
+
+func (r *autoIndexJobHintDiffResolver) Kind() string {
+	return string(r.kind)
+}
+
+func (r *autoIndexJobHintDiffResolver) Current() resolverstubs.AutoIndexJobHintResolver {
+	return r.current
+}
+
+func (r *autoIndexJobHintDiffResolver) Candidate() resolverstubs.AutoIndexJobHintResolver {
+	return r.candidate
+}
+
+//
+//
+
+type autoIndexJobHintResolver struct {
+	hint config.IndexJobHint
+}
+
+func newHintResolver(hint *config.IndexJobHint) resolverstubs.AutoIndexJobHintResolver {
+	if hint == nil {
+		return nil
+	}
+
+	return &autoIndexJobHintResolver{hint: *hint}
+}
+
+func (r *autoIndexJobHintResolver) Root() string {
+	return r.hint.Root
+}
+
+func (r *autoIndexJobHintResolver) Indexer() resolverstubs.CodeIntelIndexerResolver {
+	return uploadsgraphql.NewCodeIntelIndexerResolver(r.hint.Indexer, r.hint.Indexer)
+}
+
+func (r *autoIndexJobHintResolver) ComparisonKey() string {
+	return comparisonKey(r.hint.Root, r.Indexer().Name())
+}
+
+func (r *autoIndexJobHintResolver) Confidence() string {
+	switch r.hint.HintConfidence {
+	case config.HintConfidenceLanguageSupport:
+		return "LANGUAGE_SUPPORT"
+	case config.HintConfidenceProjectStructureSupported:
+		return "PROJECT_STRUCTURE_SUPPORTED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+//
+//
+
+type autoIndexRecognizerMatchResolver struct {
+	match shared.RecognizerMatch
+}
+
+func newRecognizerMatchResolvers(matches []shared.RecognizerMatch) []resolverstubs.AutoIndexRecognizerMatchResolver {
+	resolvers := make([]resolverstubs.AutoIndexRecognizerMatchResolver, 0, len(matches))
+	for _, match := range matches {
+		resolvers = append(resolvers, &autoIndexRecognizerMatchResolver{match: match})
+	}
+
+	return resolvers
+}
+
+func (r *autoIndexRecognizerMatchResolver) Name() string {
+	return r.match.Name
+}
+
+func (r *autoIndexRecognizerMatchResolver) Paths() []string {
+	return r.match.Paths
+}
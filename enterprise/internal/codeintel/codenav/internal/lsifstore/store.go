@@ -29,6 +29,9 @@ type LsifStore interface {
 	// Metadata by position
 	GetHover(ctx context.Context, bundleID int, path string, line, character int) (string, shared.Range, bool, error)
 	GetDiagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]shared.Diagnostic, int, error)
+
+	// Symbol search
+	SearchSymbolDefinitions(ctx context.Context, uploadIDs []int, pattern string, limit, offset int) ([]shared.SymbolDefinition, error)
 }
 
 type store struct {
@@ -0,0 +1,272 @@
+package lsifstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/grafana/regexp/syntax"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/ranges"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// SearchSymbolDefinitions returns the definitions of non-local symbols within the given uploads
+// whose full symbol name may match the given RE2 regular expression. The pattern is loosened into
+// a case-insensitive, unanchored Postgres regular expression that matches at least every symbol
+// with a descriptor matching the pattern, so callers must filter the results precisely. Results
+// are ordered by upload, symbol name, and path, and there is one result per symbol and document.
+func (s *store) SearchSymbolDefinitions(ctx context.Context, uploadIDs []int, pattern string, limit, offset int) (_ []shared.SymbolDefinition, err error) {
+	ctx, _, endObservation := s.operations.searchSymbolDefinitions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numUploadIDs", len(uploadIDs)),
+		log.String("uploadIDs", intsToString(uploadIDs)),
+		log.String("pattern", pattern),
+		log.Int("limit", limit),
+		log.Int("offset", offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 {
+		return nil, nil
+	}
+
+	postgresPattern, err := postgresSymbolNamePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSymbolDefinitions(s.db.Query(ctx, sqlf.Sprintf(
+		searchSymbolDefinitionsQuery,
+		pq.Array(uploadIDs),
+		pq.Array(uploadIDs),
+		postgresPattern,
+		limit,
+		offset,
+	)))
+}
+
+const searchSymbolDefinitionsQuery = `
+WITH RECURSIVE
+defined_symbols AS (
+	SELECT
+		ss.upload_id,
+		ss.symbol_id,
+		ss.definition_ranges,
+		sid.document_path
+	FROM codeintel_scip_symbols ss
+	JOIN codeintel_scip_document_lookup sid ON sid.id = ss.document_lookup_id
+	WHERE
+		ss.upload_id = ANY(%s) AND
+		ss.definition_ranges IS NOT NULL
+),
+-- Reconstruct the full names of the defined symbols only, by walking each prefix tree from
+-- the defined symbol up to its root. The row with a null prefix identifier holds the full name.
+symbol_names(upload_id, symbol_id, prefix_id, symbol_name) AS (
+	(
+		SELECT
+			ssn.upload_id,
+			ssn.id,
+			ssn.prefix_id,
+			ssn.name_segment
+		FROM codeintel_scip_symbol_names ssn
+		WHERE
+			ssn.upload_id = ANY(%s) AND
+			ssn.id IN (SELECT ds.symbol_id FROM defined_symbols ds WHERE ds.upload_id = ssn.upload_id)
+	) UNION ALL (
+		SELECT
+			sn.upload_id,
+			sn.symbol_id,
+			ssn.prefix_id,
+			ssn.name_segment || sn.symbol_name
+		FROM symbol_names sn
+		JOIN codeintel_scip_symbol_names ssn ON
+			ssn.upload_id = sn.upload_id AND
+			ssn.id = sn.prefix_id
+	)
+)
+SELECT
+	ds.upload_id,
+	sn.symbol_name,
+	ds.definition_ranges,
+	ds.document_path
+FROM symbol_names sn
+JOIN defined_symbols ds ON ds.upload_id = sn.upload_id AND ds.symbol_id = sn.symbol_id
+WHERE
+	sn.prefix_id IS NULL AND
+	sn.symbol_name ~* %s
+ORDER BY ds.upload_id, sn.symbol_name, ds.document_path
+LIMIT %s OFFSET %s
+`
+
+func scanSymbolDefinitions(rows *sql.Rows, queryErr error) (_ []shared.SymbolDefinition, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var definitions []shared.SymbolDefinition
+	for rows.Next() {
+		var (
+			uploadID    int
+			symbol      string
+			scipPayload []byte
+			path        string
+		)
+		if err := rows.Scan(&uploadID, &symbol, &scipPayload, &path); err != nil {
+			return nil, err
+		}
+
+		definitionRanges, err := ranges.DecodeRanges(scipPayload)
+		if err != nil {
+			return nil, err
+		}
+
+		definition := shared.SymbolDefinition{
+			DumpID: uploadID,
+			Symbol: symbol,
+			Path:   path,
+			Ranges: make([]shared.Range, 0, len(definitionRanges)),
+		}
+		for _, r := range definitionRanges {
+			definition.Ranges = append(definition.Ranges, newRange(int(r.Start.Line), int(r.Start.Character), int(r.End.Line), int(r.End.Character)))
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
+
+// postgresSymbolNamePattern translates the given RE2 pattern into a Postgres regular expression
+// that matches a superset of the full symbol names whose descriptors match the pattern.
+//
+// The descriptor is only a part of the full symbol name, so anchors and word boundaries are
+// dropped. Constructs with differing or no Postgres equivalent are widened into a match of any
+// string, so the translated pattern never loses a match but may produce extra candidates.
+func postgresSymbolNamePattern(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	writePostgresPattern(&b, re)
+	return b.String(), nil
+}
+
+// maxPostgresRepeat is the largest repetition bound Postgres regular expressions accept.
+const maxPostgresRepeat = 255
+
+func writePostgresPattern(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		// Matches the empty string, or a position which need not hold within the full name
+
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			writePostgresRune(b, r)
+		}
+
+	case syntax.OpCharClass:
+		var class strings.Builder
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			lo, hi := re.Rune[i], re.Rune[i+1]
+			if lo == 0 {
+				// Postgres does not accept NUL characters, which cannot occur in symbol names
+				lo = 1
+			}
+			if hi < lo {
+				continue
+			}
+			writePostgresRune(&class, lo)
+			if hi > lo {
+				class.WriteString("-")
+				writePostgresRune(&class, hi)
+			}
+		}
+		if class.Len() == 0 {
+			b.WriteString(".*")
+			return
+		}
+		b.WriteString("[" + class.String() + "]")
+
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteString(".")
+
+	case syntax.OpCapture:
+		writePostgresGroup(b, re.Sub[0])
+
+	case syntax.OpStar:
+		writePostgresGroup(b, re.Sub[0])
+		b.WriteString("*")
+
+	case syntax.OpPlus:
+		writePostgresGroup(b, re.Sub[0])
+		b.WriteString("+")
+
+	case syntax.OpQuest:
+		writePostgresGroup(b, re.Sub[0])
+		b.WriteString("?")
+
+	case syntax.OpRepeat:
+		writePostgresGroup(b, re.Sub[0])
+		switch {
+		case re.Min > maxPostgresRepeat:
+			b.WriteString("*")
+		case re.Max == -1 || re.Max > maxPostgresRepeat:
+			fmt.Fprintf(b, "{%d,}", re.Min)
+		default:
+			fmt.Fprintf(b, "{%d,%d}", re.Min, re.Max)
+		}
+
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writePostgresPattern(b, sub)
+		}
+
+	case syntax.OpAlternate:
+		b.WriteString("(?:")
+		for i, sub := range re.Sub {
+			if i > 0 {
+				b.WriteString("|")
+			}
+			writePostgresPattern(b, sub)
+		}
+		b.WriteString(")")
+
+	default:
+		b.WriteString(".*")
+	}
+}
+
+func writePostgresGroup(b *strings.Builder, re *syntax.Regexp) {
+	b.WriteString("(?:")
+	writePostgresPattern(b, re)
+	b.WriteString(")")
+}
+
+// writePostgresRune writes a rune that matches itself literally. ASCII punctuation is escaped
+// and non-printable runes are written as Unicode escapes.
+func writePostgresRune(b *strings.Builder, r rune) {
+	switch {
+	case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+		b.WriteRune(r)
+	case r < unicode.MaxASCII && unicode.IsPrint(r):
+		b.WriteRune('\\')
+		b.WriteRune(r)
+	case r > unicode.MaxASCII && unicode.IsPrint(r):
+		b.WriteRune(r)
+	case r <= 0xFFFF:
+		fmt.Fprintf(b, `\u%04x`, r)
+	default:
+		fmt.Fprintf(b, `\U%08x`, r)
+	}
+}
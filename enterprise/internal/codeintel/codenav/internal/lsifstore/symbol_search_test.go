@@ -0,0 +1,68 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+)
+
+func TestSearchSymbolDefinitions(t *testing.T) {
+	store := populateTestStore(t)
+
+	// `export interface HoverPayload {`
+	//                   ^^^^^^^^^^^^
+
+	definitions, err := store.SearchSymbolDefinitions(context.Background(), []int{testSCIPUploadID}, "hoverpayload#$", 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	expected := []shared.SymbolDefinition{
+		{
+			DumpID: testSCIPUploadID,
+			Symbol: "scip-typescript npm template 0.0.0-DEVELOPMENT src/lsif/`definition-hover.ts`/HoverPayload#",
+			Path:   "template/src/lsif/definition-hover.ts",
+			Ranges: []shared.Range{newRange(21, 17, 21, 29)},
+		},
+	}
+	if diff := cmp.Diff(expected, definitions); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	if definitions, err := store.SearchSymbolDefinitions(context.Background(), nil, "hoverpayload#$", 10, 0); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else if len(definitions) != 0 {
+		t.Errorf("unexpected definitions for empty upload set: %v", definitions)
+	}
+}
+
+func TestPostgresSymbolNamePattern(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "server", want: "server"},
+		{pattern: "^Server$", want: "Server"},
+		{pattern: `\bnew\w+`, want: `new(?:[0-9A-Z\_a-z])+`},
+		{pattern: "(?i:foo|bar).go", want: "(?:FOO|BAR).go"},
+		{pattern: "a{2,300}", want: "(?:a){2,}"},
+		{pattern: `(?P<name>x)\z`, want: "(?:x)"},
+		{pattern: "[^a]", want: `[\u0001-\` + "`" + `b-\U0010ffff]`},
+		{pattern: `a\.b#`, want: `a\.b\#`},
+	} {
+		have, err := postgresSymbolNamePattern(tc.pattern)
+		if err != nil {
+			t.Fatalf("unexpected error translating %q: %s", tc.pattern, err)
+		}
+		if have != tc.want {
+			t.Errorf("unexpected translation of %q. want=%q have=%q", tc.pattern, tc.want, have)
+		}
+	}
+
+	if _, err := postgresSymbolNamePattern("("); err == nil {
+		t.Errorf("expected error for invalid pattern")
+	}
+}
@@ -55,6 +55,9 @@ type MockLsifStore struct {
 	// GetStencilFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStencil.
 	GetStencilFunc *LsifStoreGetStencilFunc
+	// SearchSymbolDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbolDefinitions.
+	SearchSymbolDefinitionsFunc *LsifStoreSearchSymbolDefinitionsFunc
 }
 
 // NewMockLsifStore creates a new mock of the LsifStore interface. All
@@ -116,6 +119,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		SearchSymbolDefinitionsFunc: &LsifStoreSearchSymbolDefinitionsFunc{
+			defaultHook: func(context.Context, []int, string, int, int) (r0 []shared.SymbolDefinition, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -178,6 +186,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetStencil")
 			},
 		},
+		SearchSymbolDefinitionsFunc: &LsifStoreSearchSymbolDefinitionsFunc{
+			defaultHook: func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error) {
+				panic("unexpected invocation of MockLsifStore.SearchSymbolDefinitions")
+			},
+		},
 	}
 }
 
@@ -218,6 +231,9 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetStencilFunc: &LsifStoreGetStencilFunc{
 			defaultHook: i.GetStencil,
 		},
+		SearchSymbolDefinitionsFunc: &LsifStoreSearchSymbolDefinitionsFunc{
+			defaultHook: i.SearchSymbolDefinitions,
+		},
 	}
 }
 
@@ -1550,6 +1566,126 @@ func (c LsifStoreGetStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LsifStoreSearchSymbolDefinitionsFunc describes the behavior when the
+// SearchSymbolDefinitions method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreSearchSymbolDefinitionsFunc struct {
+	defaultHook func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error)
+	hooks       []func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error)
+	history     []LsifStoreSearchSymbolDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbolDefinitions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) SearchSymbolDefinitions(v0 context.Context, v1 []int, v2 string, v3 int, v4 int) ([]shared.SymbolDefinition, error) {
+	r0, r1 := m.SearchSymbolDefinitionsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.SearchSymbolDefinitionsFunc.appendCall(LsifStoreSearchSymbolDefinitionsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// SearchSymbolDefinitions method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreSearchSymbolDefinitionsFunc) SetDefaultHook(hook func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbolDefinitions method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreSearchSymbolDefinitionsFunc) PushHook(hook func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreSearchSymbolDefinitionsFunc) SetDefaultReturn(r0 []shared.SymbolDefinition, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreSearchSymbolDefinitionsFunc) PushReturn(r0 []shared.SymbolDefinition, r1 error) {
+	f.PushHook(func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreSearchSymbolDefinitionsFunc) nextHook() func(context.Context, []int, string, int, int) ([]shared.SymbolDefinition, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreSearchSymbolDefinitionsFunc) appendCall(r0 LsifStoreSearchSymbolDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreSearchSymbolDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreSearchSymbolDefinitionsFunc) History() []LsifStoreSearchSymbolDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreSearchSymbolDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreSearchSymbolDefinitionsFuncCall is an object that describes an
+// invocation of method SearchSymbolDefinitions on an instance of
+// MockLsifStore.
+type LsifStoreSearchSymbolDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.SymbolDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreSearchSymbolDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreSearchSymbolDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockGitTreeTranslator is a mock implementation of the GitTreeTranslator
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
@@ -20,6 +20,7 @@ type operations struct {
 	getRanges              *observation.Operation
 	getStencil             *observation.Operation
 	getClosestDumpsForBlob *observation.Operation
+	searchSymbols          *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -51,6 +52,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getRanges:              op("getRanges"),
 		getStencil:             op("getStencil"),
 		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
+		searchSymbols:          op("SearchSymbols"),
 	}
 }
 
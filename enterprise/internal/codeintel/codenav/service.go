@@ -4,8 +4,10 @@ import (
 	"context"
 	"strings"
 
+	"github.com/grafana/regexp"
 	traceLog "github.com/opentracing/opentracing-go/log"
 	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/internal/lsifstore"
@@ -1189,6 +1191,151 @@ func (s *Service) GetClosestDumpsForBlob(ctx context.Context, repositoryID int,
 	return filtered, nil
 }
 
+// symbolSearchCandidateFactor is the number of candidate definitions fetched from the database
+// per requested result on the first page. Candidates are matched loosely by the database against
+// the full symbol name and then filtered precisely against the symbol's display name here, so we
+// keep fetching pages of twice the previous size until enough candidates match.
+const symbolSearchCandidateFactor = 10
+
+// maxSymbolSearchCandidates is the maximum number of candidate definitions fetched from the
+// database for a single search, so that a pattern that only matches loosely doesn't make us scan
+// all symbols of the searched indexes.
+const maxSymbolSearchCandidates = 10000
+
+// SearchSymbols returns the definitions of symbols whose names match the given pattern within the
+// given precise indexes, as returned by GetClosestDumpsForBlob for the searched commit.
+func (s *Service) SearchSymbols(ctx context.Context, dumps []uploadsshared.Dump, args SymbolSearchArgs) (_ []shared.PreciseSymbol, err error) {
+	ctx, trace, endObservation := s.operations.searchSymbols.With(ctx, &err, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("numDumps", len(dumps)),
+			traceLog.String("dumps", uploadIDsToString(dumps)),
+			traceLog.String("pattern", args.Pattern),
+			traceLog.Bool("isCaseSensitive", args.IsCaseSensitive),
+			traceLog.Int("limit", args.Limit),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	if len(dumps) == 0 || args.Limit <= 0 {
+		return nil, nil
+	}
+
+	namePattern := args.Pattern
+	if !args.IsCaseSensitive {
+		namePattern = "(?i:" + namePattern + ")"
+	}
+	nameMatcher, err := regexp.Compile(namePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpsByID := make(map[int]uploadsshared.Dump, len(dumps))
+	uploadIDs := make([]int, 0, len(dumps))
+	for _, dump := range dumps {
+		dumpsByID[dump.ID] = dump
+		uploadIDs = append(uploadIDs, dump.ID)
+	}
+
+	var (
+		symbols       []shared.PreciseSymbol
+		numCandidates int
+	)
+
+pages:
+	for offset, pageSize := 0, args.Limit*symbolSearchCandidateFactor; offset < maxSymbolSearchCandidates; offset, pageSize = offset+pageSize, pageSize*2 {
+		if pageSize > maxSymbolSearchCandidates-offset {
+			pageSize = maxSymbolSearchCandidates - offset
+		}
+
+		candidates, err := s.lsifstore.SearchSymbolDefinitions(ctx, uploadIDs, args.Pattern, pageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifstore.SearchSymbolDefinitions")
+		}
+		numCandidates += len(candidates)
+
+		for _, candidate := range candidates {
+			symbol, err := scip.ParseSymbol(candidate.Symbol)
+			if err != nil || len(symbol.Descriptors) == 0 {
+				continue
+			}
+			descriptor := symbol.Descriptors[len(symbol.Descriptors)-1]
+			if !nameMatcher.MatchString(descriptor.Name) {
+				continue
+			}
+
+			var parent *scip.Descriptor
+			if len(symbol.Descriptors) > 1 {
+				parent = symbol.Descriptors[len(symbol.Descriptors)-2]
+			}
+
+			for _, r := range candidate.Ranges {
+				if len(symbols) >= args.Limit {
+					break pages
+				}
+
+				preciseSymbol := shared.PreciseSymbol{
+					DumpID: candidate.DumpID,
+					Commit: dumpsByID[candidate.DumpID].Commit,
+					Path:   dumpsByID[candidate.DumpID].Root + candidate.Path,
+					Range:  r,
+					Symbol: candidate.Symbol,
+					Scheme: symbol.Scheme,
+					Name:   descriptor.Name,
+					Kind:   symbolKind(descriptor, parent),
+				}
+				if symbol.Package != nil {
+					preciseSymbol.PackageManager = symbol.Package.Manager
+					preciseSymbol.PackageName = symbol.Package.Name
+					preciseSymbol.PackageVersion = symbol.Package.Version
+				}
+				if parent != nil {
+					preciseSymbol.Parent = parent.Name
+				}
+
+				symbols = append(symbols, preciseSymbol)
+			}
+		}
+
+		if len(candidates) < pageSize {
+			break
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCandidates", numCandidates), attribute.Int("numSymbols", len(symbols)))
+
+	return symbols, nil
+}
+
+// symbolKind returns the symbol kind, in the vocabulary used by search results, of a symbol
+// whose final descriptor is given. The parent is the preceding descriptor, if any.
+func symbolKind(descriptor, parent *scip.Descriptor) string {
+	parentIsType := parent != nil && parent.Suffix == scip.Descriptor_Type
+
+	switch descriptor.Suffix {
+	case scip.Descriptor_Namespace:
+		return "package"
+	case scip.Descriptor_Type:
+		return "type"
+	case scip.Descriptor_Method:
+		if parentIsType {
+			return "method"
+		}
+		return "function"
+	case scip.Descriptor_Term:
+		if parentIsType {
+			return "field"
+		}
+		return "variable"
+	case scip.Descriptor_TypeParameter:
+		return "type parameter"
+	case scip.Descriptor_Parameter:
+		return "variable"
+	case scip.Descriptor_Macro:
+		return "macro"
+	}
+
+	return ""
+}
+
 // filterUploadsWithCommits removes the uploads for commits which are unknown to gitserver from the given
 // slice. The slice is filtered in-place and returned (to update the slice length).
 func filterUploadsWithCommits(ctx context.Context, commitCache CommitCache, uploads []uploadsshared.Dump) ([]uploadsshared.Dump, error) {
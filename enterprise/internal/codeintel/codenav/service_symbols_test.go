@@ -0,0 +1,221 @@
+package codenav
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestSearchSymbols(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	dumps := []uploadsshared.Dump{
+		{ID: 50, RepositoryID: 42, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, RepositoryID: 42, Commit: mockCommit, Root: "sub2/"},
+	}
+
+	testRange := shared.Range{Start: shared.Position{Line: 10, Character: 20}, End: shared.Position{Line: 10, Character: 30}}
+	mockLsifStore.SearchSymbolDefinitionsFunc.SetDefaultReturn([]shared.SymbolDefinition{
+		{DumpID: 50, Symbol: "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#Serve().", Path: "server.go", Ranges: []shared.Range{testRange}},
+		{DumpID: 50, Symbol: "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#", Path: "server.go", Ranges: []shared.Range{testRange}},
+		{DumpID: 51, Symbol: "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#server.", Path: "server.go", Ranges: []shared.Range{testRange}},
+		{DumpID: 51, Symbol: "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/NewServer().", Path: "new.go", Ranges: []shared.Range{testRange}},
+		{DumpID: 51, Symbol: "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/ServerOptions#", Path: "options.go", Ranges: []shared.Range{testRange}},
+		{DumpID: 51, Symbol: "not a valid symbol", Path: "invalid.go", Ranges: []shared.Range{testRange}},
+	}, nil)
+
+	symbols, err := svc.SearchSymbols(context.Background(), dumps, SymbolSearchArgs{
+		Pattern: "^server$",
+		Limit:   50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+
+	newSymbol := func(dumpID int, root, path, symbol, name, parent, kind string) shared.PreciseSymbol {
+		return shared.PreciseSymbol{
+			DumpID:         dumpID,
+			Commit:         mockCommit,
+			Path:           root + path,
+			Range:          testRange,
+			Symbol:         symbol,
+			Scheme:         "scip-go",
+			PackageManager: "gomod",
+			PackageName:    "github.com/foo/bar",
+			PackageVersion: "v1.2.3",
+			Name:           name,
+			Parent:         parent,
+			Kind:           kind,
+		}
+	}
+	expectedSymbols := []shared.PreciseSymbol{
+		newSymbol(50, "sub1/", "server.go", "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#", "Server", "github.com/foo/bar/pkg", "type"),
+		newSymbol(51, "sub2/", "server.go", "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#server.", "server", "Server", "field"),
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.SearchSymbolDefinitionsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to SearchSymbolDefinitions. want=%d have=%d", 1, len(history))
+	} else {
+		if diff := cmp.Diff([]int{50, 51}, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected upload identifiers (-want +got):\n%s", diff)
+		}
+		if history[0].Arg2 != "^server$" {
+			t.Errorf("unexpected pattern. want=%q have=%q", "^server$", history[0].Arg2)
+		}
+	}
+}
+
+func TestSearchSymbolsPaging(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	dumps := []uploadsshared.Dump{
+		{ID: 50, RepositoryID: 42, Commit: mockCommit},
+	}
+
+	testRange := shared.Range{Start: shared.Position{Line: 10, Character: 20}, End: shared.Position{Line: 10, Character: 30}}
+
+	// The first page is filled with candidates that only match loosely, such as symbols
+	// defined within the matching type.
+	var loose []shared.SymbolDefinition
+	for i := 0; i < symbolSearchCandidateFactor; i++ {
+		loose = append(loose, shared.SymbolDefinition{
+			DumpID: 50,
+			Symbol: fmt.Sprintf("scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#field%d.", i),
+			Path:   "server.go",
+			Ranges: []shared.Range{testRange},
+		})
+	}
+	mockLsifStore.SearchSymbolDefinitionsFunc.PushReturn(loose, nil)
+	mockLsifStore.SearchSymbolDefinitionsFunc.PushReturn([]shared.SymbolDefinition{
+		{DumpID: 50, Symbol: "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#", Path: "server.go", Ranges: []shared.Range{testRange}},
+	}, nil)
+
+	symbols, err := svc.SearchSymbols(context.Background(), dumps, SymbolSearchArgs{
+		Pattern: "^server$",
+		Limit:   1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if len(symbols) != 1 || symbols[0].Name != "Server" {
+		t.Errorf("unexpected symbols: %v", symbols)
+	}
+
+	history := mockLsifStore.SearchSymbolDefinitionsFunc.History()
+	if len(history) != 2 {
+		t.Fatalf("unexpected number of calls to SearchSymbolDefinitions. want=%d have=%d", 2, len(history))
+	}
+	for i, expected := range [][2]int{{symbolSearchCandidateFactor, 0}, {2 * symbolSearchCandidateFactor, symbolSearchCandidateFactor}} {
+		if limit, offset := history[i].Arg3, history[i].Arg4; limit != expected[0] || offset != expected[1] {
+			t.Errorf("unexpected page %d. want limit=%d offset=%d, have limit=%d offset=%d", i, expected[0], expected[1], limit, offset)
+		}
+	}
+}
+
+func TestSearchSymbolsCandidateLimit(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	dumps := []uploadsshared.Dump{
+		{ID: 50, RepositoryID: 42, Commit: mockCommit},
+	}
+
+	// Every page is full of candidates that don't match.
+	mockLsifStore.SearchSymbolDefinitionsFunc.SetDefaultHook(func(_ context.Context, _ []int, _ string, limit, offset int) ([]shared.SymbolDefinition, error) {
+		candidates := make([]shared.SymbolDefinition, 0, limit)
+		for i := 0; i < limit; i++ {
+			candidates = append(candidates, shared.SymbolDefinition{
+				DumpID: 50,
+				Symbol: fmt.Sprintf("scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#field%d.", offset+i),
+				Path:   "server.go",
+			})
+		}
+		return candidates, nil
+	})
+
+	for _, limit := range []int{0, -1} {
+		if _, err := svc.SearchSymbols(context.Background(), dumps, SymbolSearchArgs{Pattern: "^server$", Limit: limit}); err != nil {
+			t.Fatalf("unexpected error searching symbols: %s", err)
+		}
+	}
+	if len(mockLsifStore.SearchSymbolDefinitionsFunc.History()) != 0 {
+		t.Fatalf("unexpected call to SearchSymbolDefinitions without a limit")
+	}
+
+	symbols, err := svc.SearchSymbols(context.Background(), dumps, SymbolSearchArgs{
+		Pattern: "^server$",
+		Limit:   50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if len(symbols) != 0 {
+		t.Errorf("unexpected symbols: %v", symbols)
+	}
+
+	numCandidates := 0
+	for _, call := range mockLsifStore.SearchSymbolDefinitionsFunc.History() {
+		if call.Arg4 != numCandidates {
+			t.Errorf("unexpected offset. want=%d have=%d", numCandidates, call.Arg4)
+		}
+		numCandidates += call.Arg3
+	}
+	if numCandidates != maxSymbolSearchCandidates {
+		t.Errorf("unexpected number of candidates. want=%d have=%d", maxSymbolSearchCandidates, numCandidates)
+	}
+}
+
+func TestSearchSymbolsNoPreciseData(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	symbols, err := svc.SearchSymbols(context.Background(), nil, SymbolSearchArgs{
+		Pattern: "server",
+		Limit:   50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if len(symbols) != 0 {
+		t.Errorf("unexpected symbols: %v", symbols)
+	}
+	if len(mockLsifStore.SearchSymbolDefinitionsFunc.History()) != 0 {
+		t.Errorf("unexpected call to SearchSymbolDefinitions")
+	}
+}
@@ -20,6 +20,31 @@ type Diagnostic struct {
 	precise.DiagnosticData
 }
 
+// SymbolDefinition is the set of definitions of a symbol within a particular document of a dump.
+type SymbolDefinition struct {
+	DumpID int
+	Symbol string
+	Path   string
+	Ranges []Range
+}
+
+// PreciseSymbol is a symbol defined within a precise index, qualified by the package that
+// defines it. The commit and path denote where the symbol was indexed.
+type PreciseSymbol struct {
+	DumpID         int
+	Commit         string
+	Path           string
+	Range          Range
+	Symbol         string
+	Scheme         string
+	PackageManager string
+	PackageName    string
+	PackageVersion string
+	Name           string
+	Parent         string
+	Kind           string
+}
+
 // CodeIntelligenceRange pairs a range with its definitions, references, implementations, and hover text.
 type CodeIntelligenceRange struct {
 	Range           Range
@@ -57,6 +57,13 @@ type RequestArgs struct {
 	RawCursor    string
 }
 
+// SymbolSearchArgs describes a search over the symbols defined in a set of precise indexes.
+type SymbolSearchArgs struct {
+	Pattern         string // a regular expression matched against symbol names
+	IsCaseSensitive bool
+	Limit           int
+}
+
 // DiagnosticAtUpload is a diagnostic from within a particular upload. The adjusted commit denotes
 // the target commit for which the location was adjusted (the originally requested commit).
 type DiagnosticAtUpload struct {
@@ -14,6 +14,11 @@ type rankingConfig struct {
 	SymbolExporterWriteBatchSize int
 	MapperBatchSize              int
 	ReducerBatchSize             int
+	PageRankEnabled              bool
+	PageRankDampingFactor        float64
+	PageRankIterations           int
+	PageRankReadBatchSize        int
+	PageRankMaxEdges             int
 }
 
 var ConfigInst = &rankingConfig{}
@@ -24,4 +29,9 @@ func (c *rankingConfig) Load() {
 	c.SymbolExporterWriteBatchSize = c.GetInt("CODEINTEL_RANKING_SYMBOL_EXPORTER_WRITE_BATCH_SIZE", "10000", "The number of definitions and references to populate the ranking graph per batch.")
 	c.MapperBatchSize = c.GetInt("CODEINTEL_RANKING_MAPPER_BATCH_SIZE", "100", "How many definitions and references to map at once.")
 	c.ReducerBatchSize = c.GetInt("CODEINTEL_RANKING_REDUCER_BATCH_SIZE", "100", "How many path counts to reduce at once.")
+	c.PageRankEnabled = c.GetBool("CODEINTEL_RANKING_PAGERANK_ENABLED", "false", "Whether to rank documents by PageRank over the file-level reference graph instead of by reference counts.")
+	c.PageRankDampingFactor = float64(c.GetPercent("CODEINTEL_RANKING_PAGERANK_DAMPING_FACTOR", "85", "The percent chance of following a reference rather than jumping to a random document when computing PageRank.")) / 100
+	c.PageRankIterations = c.GetInt("CODEINTEL_RANKING_PAGERANK_ITERATIONS", "20", "The maximum number of iterations to run when computing PageRank.")
+	c.PageRankReadBatchSize = c.GetInt("CODEINTEL_RANKING_PAGERANK_READ_BATCH_SIZE", "1000", "How many reference records to read at once when loading the file-level reference graph.")
+	c.PageRankMaxEdges = c.GetInt("CODEINTEL_RANKING_PAGERANK_MAX_EDGES", "20000000", "The maximum number of file-level reference graph edges to load into memory when computing PageRank. Each edge takes roughly 24 bytes, plus roughly 100 bytes per distinct document.")
 }
@@ -81,6 +81,12 @@ func NewSymbolJanitor(observationCtx *observation.Context, rankingService *Servi
 }
 
 func NewMapper(observationCtx *observation.Context, rankingService *Service) []goroutine.BackgroundRoutine {
+	if ConfigInst.PageRankEnabled {
+		// The PageRank reducer reads the exported graph directly, including the
+		// initial paths the seed mapper would otherwise count
+		return nil
+	}
+
 	return []goroutine.BackgroundRoutine{
 		background.NewMapper(
 			observationCtx,
@@ -98,6 +104,18 @@ func NewMapper(observationCtx *observation.Context, rankingService *Service) []g
 }
 
 func NewReducer(observationCtx *observation.Context, rankingService *Service) goroutine.BackgroundRoutine {
+	if ConfigInst.PageRankEnabled {
+		return background.NewPageRankReducer(
+			observationCtx,
+			rankingService.store,
+			ConfigInst.SymbolExporterInterval,
+			ConfigInst.PageRankReadBatchSize,
+			ConfigInst.PageRankMaxEdges,
+			ConfigInst.PageRankDampingFactor,
+			ConfigInst.PageRankIterations,
+		)
+	}
+
 	return background.NewReducer(
 		observationCtx,
 		rankingService.store,
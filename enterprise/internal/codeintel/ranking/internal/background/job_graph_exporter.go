@@ -264,3 +264,26 @@ func NewReducer(
 		},
 	})
 }
+
+func NewPageRankReducer(
+	observationCtx *observation.Context,
+	store store.Store,
+	interval time.Duration,
+	batchSize int,
+	maxEdges int,
+	dampingFactor float64,
+	iterations int,
+) goroutine.BackgroundRoutine {
+	name := "codeintel.ranking.file-pagerank-reducer"
+
+	return background.NewPipelineJob(context.Background(), background.PipelineOptions{
+		Name:        name,
+		Description: "Computes PageRank over the file-level reference graph into `codeintel_path_ranks`.",
+		Interval:    interval,
+		Metrics:     background.NewPipelineMetrics(observationCtx, name, recordTypeName),
+		ProcessFunc: func(ctx context.Context) (numRecordsProcessed int, numRecordsAltered background.TaggedCounts, err error) {
+			numEdgesScanned, numPathRanksInserted, err := rankGraphWithPageRank(ctx, store, batchSize, maxEdges, dampingFactor, iterations)
+			return numEdgesScanned, background.NewSingleCount(numPathRanksInserted), err
+		},
+	})
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/sourcegraph/scip/bindings/go/scip"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/pagerank"
 	rankingshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/shared"
 	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func exportRankingGraph(
@@ -124,7 +126,7 @@ func setDefinitionsAndReferencesForUpload(
 		}
 	}()
 
-	if err := store.InsertReferencesForRanking(ctx, rankingGraphKey, batchSize, upload.ID, references); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, rankingGraphKey, batchSize, upload.ID, filepath.Join(upload.Root, path), references); err != nil {
 		for range references {
 			// Drain channel to ensure it closes
 		}
@@ -298,3 +300,108 @@ func reduceRankingGraph(
 
 	return numPathRanksInserted, numPathCountInputsProcessed, nil
 }
+
+type rankedDocument struct {
+	repositoryID int
+	path         string
+}
+
+// rankGraphWithPageRank loads the file-level reference graph and stores the PageRank of
+// each document as its path rank.
+//
+// The whole graph is held in memory while ranking: each edge costs roughly 24 bytes and
+// each distinct document roughly 100 bytes plus the length of its path. To keep this
+// bounded, ranking is skipped for a graph key with more than maxEdges edges.
+func rankGraphWithPageRank(
+	ctx context.Context,
+	store store.Store,
+	batchSize int,
+	maxEdges int,
+	dampingFactor float64,
+	iterations int,
+) (numEdgesProcessed int, numPathRanksInserted int, err error) {
+	if enabled := conf.CodeIntelRankingDocumentReferenceCountsEnabled(); !enabled {
+		return 0, 0, nil
+	}
+
+	// Ranks are computed over the entire graph at once, so we only do so once per derivative
+	// graph key. Documents exported after this point will be ranked in the next bucket.
+	derivativeGraphKey := rankingshared.DerivativeGraphKeyFromTime(time.Now())
+	if exists, err := store.HasPathRanks(ctx, derivativeGraphKey); err != nil || exists {
+		return 0, 0, err
+	}
+
+	graph := pagerank.NewGraph[rankedDocument]()
+
+	for lastReferenceID := 0; ; {
+		edges, nextReferenceID, err := store.GetRankingGraphEdges(ctx, rankingshared.GraphKey(), lastReferenceID, batchSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		if nextReferenceID == 0 {
+			break
+		}
+
+		for _, edge := range edges {
+			graph.AddEdge(
+				rankedDocument{repositoryID: edge.SourceRepositoryID, path: edge.SourceDocumentPath},
+				rankedDocument{repositoryID: edge.TargetRepositoryID, path: edge.TargetDocumentPath},
+				float64(edge.Count),
+			)
+		}
+
+		numEdgesProcessed += len(edges)
+		lastReferenceID = nextReferenceID
+
+		if maxEdges > 0 && numEdgesProcessed > maxEdges {
+			return numEdgesProcessed, 0, errors.Newf("reference graph has more than %d edges, which is the maximum number of edges ranked in memory", maxEdges)
+		}
+	}
+
+	// Documents that neither reference nor define symbols used elsewhere are in no edge. We
+	// add every exported document to the graph so that those are ranked as well.
+	for lastInitialPathID := 0; ; {
+		documents, nextInitialPathID, err := store.GetRankingInitialPaths(ctx, rankingshared.GraphKey(), lastInitialPathID, batchSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		if nextInitialPathID == 0 {
+			break
+		}
+
+		for _, document := range documents {
+			graph.AddNode(rankedDocument{repositoryID: document.RepositoryID, path: document.DocumentPath})
+		}
+
+		lastInitialPathID = nextInitialPathID
+	}
+
+	if graph.NumNodes() == 0 {
+		return numEdgesProcessed, 0, nil
+	}
+
+	// Ranks sum to one over the entire graph, so most of them are tiny. Consumers take the
+	// logarithm of path ranks, so we keep full precision and scale ranks such that the
+	// smallest possible rank, (1 - dampingFactor) / n for a document nobody references,
+	// becomes one. This keeps the relative order PageRank produced between documents.
+	scale := float64(graph.NumNodes())
+	if dampingFactor < 1 {
+		scale /= 1 - dampingFactor
+	}
+
+	pathRanksByRepositoryID := map[int]map[string]float64{}
+	for document, rank := range graph.Rank(dampingFactor, iterations) {
+		if _, ok := pathRanksByRepositoryID[document.repositoryID]; !ok {
+			pathRanksByRepositoryID[document.repositoryID] = map[string]float64{}
+		}
+
+		pathRanksByRepositoryID[document.repositoryID][document.path] = rank * scale
+		numPathRanksInserted++
+	}
+
+	if err := store.SetPathRanks(ctx, derivativeGraphKey, pathRanksByRepositoryID); err != nil {
+		return 0, 0, err
+	}
+
+	return numEdgesProcessed, numPathRanksInserted, nil
+}
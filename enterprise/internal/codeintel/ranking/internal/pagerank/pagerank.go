@@ -0,0 +1,115 @@
+package pagerank
+
+import "math"
+
+// convergenceThreshold is the L1 distance between two successive rank vectors under
+// which we consider the computation converged and stop iterating early.
+const convergenceThreshold = 1e-12
+
+// Graph is a weighted directed graph over comparable node keys.
+type Graph[K comparable] struct {
+	indexes    map[K]int
+	nodes      []K
+	edges      []edge
+	outWeights []float64
+}
+
+type edge struct {
+	source int
+	target int
+	weight float64
+}
+
+// NewGraph creates an empty graph.
+func NewGraph[K comparable]() *Graph[K] {
+	return &Graph[K]{indexes: map[K]int{}}
+}
+
+// AddNode ensures the given node exists in the graph.
+func (g *Graph[K]) AddNode(node K) {
+	g.index(node)
+}
+
+// AddEdge adds a directed edge from source to target with the given weight. Adding the
+// same edge multiple times accumulates its weight. Edges with non-positive weight are
+// ignored, but their endpoints are still added to the graph.
+func (g *Graph[K]) AddEdge(source, target K, weight float64) {
+	s, t := g.index(source), g.index(target)
+	if weight <= 0 {
+		return
+	}
+
+	g.edges = append(g.edges, edge{source: s, target: t, weight: weight})
+	g.outWeights[s] += weight
+}
+
+// NumNodes returns the number of nodes in the graph.
+func (g *Graph[K]) NumNodes() int {
+	return len(g.nodes)
+}
+
+// Rank computes the PageRank of each node in the graph. The damping factor is the
+// probability of following an outgoing edge rather than jumping to a random node, and
+// must be within [0, 1]. The computation stops after the given number of iterations or
+// once the ranks converge, whichever comes first. The resulting ranks sum to one.
+//
+// The mass of nodes without outgoing edges is redistributed uniformly over all nodes
+// on each iteration so that no rank is lost from the graph.
+func (g *Graph[K]) Rank(dampingFactor float64, iterations int) map[K]float64 {
+	n := len(g.nodes)
+	if n == 0 {
+		return map[K]float64{}
+	}
+
+	ranks := make([]float64, n)
+	for i := range ranks {
+		ranks[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+
+	for iteration := 0; iteration < iterations; iteration++ {
+		danglingMass := 0.0
+		for i, outWeight := range g.outWeights {
+			if outWeight == 0 {
+				danglingMass += ranks[i]
+			}
+		}
+
+		base := (1-dampingFactor)/float64(n) + dampingFactor*danglingMass/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for _, e := range g.edges {
+			next[e.target] += dampingFactor * ranks[e.source] * e.weight / g.outWeights[e.source]
+		}
+
+		delta := 0.0
+		for i := range ranks {
+			delta += math.Abs(next[i] - ranks[i])
+		}
+
+		ranks, next = next, ranks
+		if delta < convergenceThreshold {
+			break
+		}
+	}
+
+	ranksByNode := make(map[K]float64, n)
+	for i, node := range g.nodes {
+		ranksByNode[node] = ranks[i]
+	}
+
+	return ranksByNode
+}
+
+func (g *Graph[K]) index(node K) int {
+	if i, ok := g.indexes[node]; ok {
+		return i
+	}
+
+	i := len(g.nodes)
+	g.indexes[node] = i
+	g.nodes = append(g.nodes, node)
+	g.outWeights = append(g.outWeights, 0)
+	return i
+}
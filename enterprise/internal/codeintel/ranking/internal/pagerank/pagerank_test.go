@@ -0,0 +1,70 @@
+package pagerank
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	g := NewGraph[string]()
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("c", "a", 1)
+	g.AddEdge("d", "c", 2)
+	g.AddNode("e")
+
+	ranks := g.Rank(0.85, 100)
+	if len(ranks) != 5 {
+		t.Fatalf("unexpected number of ranks. want=%d have=%d", 5, len(ranks))
+	}
+
+	sum := 0.0
+	for _, rank := range ranks {
+		sum += rank
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("expected ranks to sum to one. have=%f", sum)
+	}
+
+	for _, node := range []string{"a", "b", "d", "e"} {
+		if ranks["c"] <= ranks[node] {
+			t.Errorf("expected c to outrank %s. c=%f %s=%f", node, ranks["c"], node, ranks[node])
+		}
+	}
+	if ranks["a"] <= ranks["b"] {
+		t.Errorf("expected a to outrank b. a=%f b=%f", ranks["a"], ranks["b"])
+	}
+	if math.Abs(ranks["b"]-ranks["e"]) > 1e-9 {
+		t.Errorf("expected unreferenced nodes to share a rank. b=%f e=%f", ranks["b"], ranks["e"])
+	}
+}
+
+func TestRankCycle(t *testing.T) {
+	g := NewGraph[int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+
+	for node, rank := range g.Rank(0.85, 20) {
+		if math.Abs(rank-1.0/3) > 1e-9 {
+			t.Errorf("unexpected rank for node %d. want=%f have=%f", node, 1.0/3, rank)
+		}
+	}
+}
+
+func TestRankNoIterations(t *testing.T) {
+	g := NewGraph[int]()
+	g.AddEdge(1, 2, 1)
+
+	for node, rank := range g.Rank(0.85, 0) {
+		if rank != 0.5 {
+			t.Errorf("unexpected rank for node %d. want=%f have=%f", node, 0.5, rank)
+		}
+	}
+}
+
+func TestRankEmpty(t *testing.T) {
+	if ranks := NewGraph[string]().Rank(0.85, 20); len(ranks) != 0 {
+		t.Errorf("unexpected ranks: %v", ranks)
+	}
+}
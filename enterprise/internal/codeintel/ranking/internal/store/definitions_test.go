@@ -147,7 +147,7 @@ func TestVacuumStaleDefinitionsAndReferences(t *testing.T) {
 	mockReferences <- "foo"
 	mockReferences <- "bar"
 	close(mockReferences)
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 1, mockReferences); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 1, "main.go", mockReferences); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
 
@@ -156,7 +156,7 @@ func TestVacuumStaleDefinitionsAndReferences(t *testing.T) {
 	mockReferences <- "bar"
 	mockReferences <- "baz"
 	close(mockReferences)
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 2, mockReferences); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 2, "main.go", mockReferences); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
 
@@ -164,7 +164,7 @@ func TestVacuumStaleDefinitionsAndReferences(t *testing.T) {
 	mockReferences <- "bar"
 	mockReferences <- "baz"
 	close(mockReferences)
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 3, mockReferences); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 3, "main.go", mockReferences); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
 
@@ -28,6 +28,10 @@ type operations struct {
 	vacuumStaleGraphs                *observation.Operation
 	insertPathRanks                  *observation.Operation
 	vacuumStaleRanks                 *observation.Operation
+	getRankingGraphEdges             *observation.Operation
+	getRankingInitialPaths           *observation.Operation
+	hasPathRanks                     *observation.Operation
+	setPathRanks                     *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -71,5 +75,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 		vacuumStaleGraphs:                op("VacuumStaleGraphs"),
 		insertPathRanks:                  op("InsertPathRanks"),
 		vacuumStaleRanks:                 op("VacuumStaleRanks"),
+		getRankingGraphEdges:             op("GetRankingGraphEdges"),
+		getRankingInitialPaths:           op("GetRankingInitialPaths"),
+		hasPathRanks:                     op("HasPathRanks"),
+		setPathRanks:                     op("SetPathRanks"),
 	}
 }
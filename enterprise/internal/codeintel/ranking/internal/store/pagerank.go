@@ -0,0 +1,261 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/keegancsmith/sqlf"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func (s *store) GetRankingGraphEdges(
+	ctx context.Context,
+	graphKey string,
+	lastReferenceID int,
+	batchSize int,
+) (_ []shared.RankingGraphEdge, nextReferenceID int, err error) {
+	ctx, _, endObservation := s.operations.getRankingGraphEdges.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("graphKey", graphKey),
+		otlog.Int("lastReferenceID", lastReferenceID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	nextReferenceID, _, err = basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		getRankingGraphEdgesBoundQuery,
+		graphKey,
+		lastReferenceID,
+		batchSize,
+	)))
+	if err != nil || nextReferenceID == 0 {
+		return nil, 0, err
+	}
+
+	edges, err := scanRankingGraphEdges(s.db.Query(ctx, sqlf.Sprintf(
+		getRankingGraphEdgesQuery,
+		graphKey,
+		lastReferenceID,
+		nextReferenceID,
+		graphKey,
+	)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return edges, nextReferenceID, nil
+}
+
+const getRankingGraphEdgesBoundQuery = `
+SELECT COALESCE(MAX(rr.id), 0)
+FROM (
+	SELECT rr.id
+	FROM codeintel_ranking_references rr
+	WHERE rr.graph_key = %s AND rr.id > %s
+	ORDER BY rr.id
+	LIMIT %s
+) rr
+`
+
+const getRankingGraphEdgesQuery = `
+WITH
+refs AS (
+	SELECT
+		u.repository_id,
+		rr.document_path,
+		rr.symbol_names
+	FROM codeintel_ranking_references rr
+	JOIN lsif_uploads u ON u.id = rr.upload_id
+	WHERE
+		rr.graph_key = %s AND
+		rr.id > %s AND
+		rr.id <= %s AND
+		-- References exported before document paths were tracked cannot be placed in the graph
+		rr.document_path != '' AND
+		EXISTS (
+			SELECT 1
+			FROM lsif_uploads_visible_at_tip uvt
+			WHERE
+				uvt.repository_id = u.repository_id AND
+				uvt.upload_id = u.id AND
+				uvt.is_default_branch
+		)
+),
+referenced_symbols AS (
+	SELECT
+		r.repository_id,
+		r.document_path,
+		unnest(r.symbol_names) AS symbol_name
+	FROM refs r
+)
+SELECT
+	rs.repository_id,
+	rs.document_path,
+	u.repository_id,
+	rd.document_path,
+	COUNT(*)
+FROM referenced_symbols rs
+JOIN codeintel_ranking_definitions rd ON rd.symbol_name = rs.symbol_name
+JOIN lsif_uploads u ON u.id = rd.upload_id
+WHERE
+	rd.graph_key = %s AND
+	EXISTS (
+		SELECT 1
+		FROM lsif_uploads_visible_at_tip uvt
+		WHERE
+			uvt.repository_id = u.repository_id AND
+			uvt.upload_id = u.id AND
+			uvt.is_default_branch
+	)
+GROUP BY rs.repository_id, rs.document_path, u.repository_id, rd.document_path
+`
+
+var scanRankingGraphEdges = basestore.NewSliceScanner(func(s dbutil.Scanner) (e shared.RankingGraphEdge, _ error) {
+	err := s.Scan(&e.SourceRepositoryID, &e.SourceDocumentPath, &e.TargetRepositoryID, &e.TargetDocumentPath, &e.Count)
+	return e, err
+})
+
+func (s *store) GetRankingInitialPaths(
+	ctx context.Context,
+	graphKey string,
+	lastInitialPathID int,
+	batchSize int,
+) (_ []shared.RankingDocument, nextInitialPathID int, err error) {
+	ctx, _, endObservation := s.operations.getRankingInitialPaths.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("graphKey", graphKey),
+		otlog.Int("lastInitialPathID", lastInitialPathID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	nextInitialPathID, _, err = basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		getRankingInitialPathsBoundQuery,
+		graphKey,
+		lastInitialPathID,
+		batchSize,
+	)))
+	if err != nil || nextInitialPathID == 0 {
+		return nil, 0, err
+	}
+
+	documents, err := scanRankingDocuments(s.db.Query(ctx, sqlf.Sprintf(
+		getRankingInitialPathsQuery,
+		graphKey,
+		lastInitialPathID,
+		nextInitialPathID,
+	)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return documents, nextInitialPathID, nil
+}
+
+const getRankingInitialPathsBoundQuery = `
+SELECT COALESCE(MAX(ipr.id), 0)
+FROM (
+	SELECT ipr.id
+	FROM codeintel_initial_path_ranks ipr
+	WHERE ipr.graph_key = %s AND ipr.id > %s
+	ORDER BY ipr.id
+	LIMIT %s
+) ipr
+`
+
+const getRankingInitialPathsQuery = `
+SELECT DISTINCT
+	u.repository_id,
+	u.root,
+	unnest(
+		CASE
+			WHEN ipr.document_path != '' THEN array_append('{}'::text[], ipr.document_path)
+			ELSE ipr.document_paths
+		END
+	) AS document_path
+FROM codeintel_initial_path_ranks ipr
+JOIN lsif_uploads u ON u.id = ipr.upload_id
+WHERE
+	ipr.graph_key = %s AND
+	ipr.id > %s AND
+	ipr.id <= %s AND
+	EXISTS (
+		SELECT 1
+		FROM lsif_uploads_visible_at_tip uvt
+		WHERE
+			uvt.repository_id = u.repository_id AND
+			uvt.upload_id = u.id AND
+			uvt.is_default_branch
+	)
+`
+
+// scanRankingDocuments scans documents whose path is relative to the root of their
+// upload, and makes the path relative to the repository like the paths of graph edges.
+var scanRankingDocuments = basestore.NewSliceScanner(func(s dbutil.Scanner) (d shared.RankingDocument, _ error) {
+	var root string
+	if err := s.Scan(&d.RepositoryID, &root, &d.DocumentPath); err != nil {
+		return d, err
+	}
+
+	d.DocumentPath = filepath.Join(root, d.DocumentPath)
+	return d, nil
+})
+
+func (s *store) HasPathRanks(ctx context.Context, derivativeGraphKey string) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.hasPathRanks.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("derivativeGraphKey", derivativeGraphKey),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	exists, _, err := basestore.ScanFirstBool(s.db.Query(ctx, sqlf.Sprintf(hasPathRanksQuery, derivativeGraphKey)))
+	return exists, err
+}
+
+const hasPathRanksQuery = `
+SELECT EXISTS (
+	SELECT 1
+	FROM codeintel_path_ranks pr
+	WHERE pr.graph_key = %s
+)
+`
+
+func (s *store) SetPathRanks(
+	ctx context.Context,
+	derivativeGraphKey string,
+	pathRanksByRepositoryID map[int]map[string]float64,
+) (err error) {
+	ctx, _, endObservation := s.operations.setPathRanks.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("derivativeGraphKey", derivativeGraphKey),
+		otlog.Int("numRepositories", len(pathRanksByRepositoryID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	for repositoryID, pathRanks := range pathRanksByRepositoryID {
+		payload, err := json.Marshal(pathRanks)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec(ctx, sqlf.Sprintf(setPathRanksQuery, repositoryID, derivativeGraphKey, string(payload))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const setPathRanksQuery = `
+INSERT INTO codeintel_path_ranks AS pr (repository_id, graph_key, payload)
+VALUES (%s, %s, %s)
+ON CONFLICT (repository_id) DO UPDATE SET
+	graph_key = EXCLUDED.graph_key,
+	payload = EXCLUDED.payload
+`
@@ -0,0 +1,221 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	rankingshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetRankingGraphEdges(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertUploads(t, db,
+		uploadsshared.Upload{ID: 1, RepositoryID: 50},
+		uploadsshared.Upload{ID: 2, RepositoryID: 51},
+		uploadsshared.Upload{ID: 3, RepositoryID: 52},
+	)
+	insertVisibleAtTip(t, db, 50, 1)
+	insertVisibleAtTip(t, db, 51, 2)
+	// upload 3 is not visible at tip
+
+	// Insert definitions
+	mockDefinitions := make(chan shared.RankingDefinitions, 4)
+	mockDefinitions <- shared.RankingDefinitions{UploadID: 1, SymbolName: "bar", DocumentPath: "bar.go"}
+	mockDefinitions <- shared.RankingDefinitions{UploadID: 2, SymbolName: "foo", DocumentPath: "lib/foo.go"}
+	mockDefinitions <- shared.RankingDefinitions{UploadID: 2, SymbolName: "baz", DocumentPath: "lib/foo.go"}
+	mockDefinitions <- shared.RankingDefinitions{UploadID: 3, SymbolName: "foo", DocumentPath: "stale.go"}
+	close(mockDefinitions)
+	if err := store.InsertDefinitionsForRanking(ctx, mockRankingGraphKey, mockDefinitions); err != nil {
+		t.Fatalf("unexpected error inserting definitions: %s", err)
+	}
+
+	// Insert references
+	for _, r := range []struct {
+		uploadID     int
+		documentPath string
+		symbols      []string
+	}{
+		{1, "main.go", []string{"foo", "bar", "baz", "unknown"}},
+		{1, "", []string{"foo"}}, // no document path
+		{2, "lib/util.go", []string{"bar"}},
+		{3, "main.go", []string{"bar"}}, // not visible at tip
+	} {
+		mockReferences := make(chan string, len(r.symbols))
+		for _, symbol := range r.symbols {
+			mockReferences <- symbol
+		}
+		close(mockReferences)
+
+		if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, r.uploadID, r.documentPath, mockReferences); err != nil {
+			t.Fatalf("unexpected error inserting references: %s", err)
+		}
+	}
+
+	var edges []shared.RankingGraphEdge
+	for lastReferenceID, numBatches := 0, 0; ; numBatches++ {
+		batch, nextReferenceID, err := store.GetRankingGraphEdges(ctx, mockRankingGraphKey, lastReferenceID, 1)
+		if err != nil {
+			t.Fatalf("unexpected error getting ranking graph edges: %s", err)
+		}
+		if nextReferenceID == 0 {
+			if numBatches != 4 {
+				t.Errorf("unexpected number of batches. want=%d have=%d", 4, numBatches)
+			}
+
+			break
+		}
+
+		edges = append(edges, batch...)
+		lastReferenceID = nextReferenceID
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].SourceDocumentPath != edges[j].SourceDocumentPath {
+			return edges[i].SourceDocumentPath < edges[j].SourceDocumentPath
+		}
+		return edges[i].TargetDocumentPath < edges[j].TargetDocumentPath
+	})
+
+	expectedEdges := []shared.RankingGraphEdge{
+		{SourceRepositoryID: 51, SourceDocumentPath: "lib/util.go", TargetRepositoryID: 50, TargetDocumentPath: "bar.go", Count: 1},
+		{SourceRepositoryID: 50, SourceDocumentPath: "main.go", TargetRepositoryID: 50, TargetDocumentPath: "bar.go", Count: 1},
+		{SourceRepositoryID: 50, SourceDocumentPath: "main.go", TargetRepositoryID: 51, TargetDocumentPath: "lib/foo.go", Count: 2},
+	}
+	if diff := cmp.Diff(expectedEdges, edges); diff != "" {
+		t.Errorf("unexpected edges (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetRankingInitialPaths(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertUploads(t, db,
+		uploadsshared.Upload{ID: 1, RepositoryID: 50},
+		uploadsshared.Upload{ID: 2, RepositoryID: 51, Root: "lib/"},
+		uploadsshared.Upload{ID: 3, RepositoryID: 52},
+	)
+	insertVisibleAtTip(t, db, 50, 1)
+	insertVisibleAtTip(t, db, 51, 2)
+	// upload 3 is not visible at tip
+
+	for uploadID, paths := range map[int][]string{
+		1: {"main.go", "unreferenced.go"},
+		2: {"foo.go"},
+		3: {"stale.go"},
+	} {
+		mockPathNames := make(chan string, len(paths))
+		for _, path := range paths {
+			mockPathNames <- path
+		}
+		close(mockPathNames)
+
+		if err := store.InsertInitialPathRanks(ctx, uploadID, mockPathNames, 2, mockRankingGraphKey); err != nil {
+			t.Fatalf("unexpected error inserting initial path counts: %s", err)
+		}
+	}
+
+	var documents []shared.RankingDocument
+	for lastInitialPathID := 0; ; {
+		batch, nextInitialPathID, err := store.GetRankingInitialPaths(ctx, mockRankingGraphKey, lastInitialPathID, 1)
+		if err != nil {
+			t.Fatalf("unexpected error getting ranking initial paths: %s", err)
+		}
+		if nextInitialPathID == 0 {
+			break
+		}
+
+		documents = append(documents, batch...)
+		lastInitialPathID = nextInitialPathID
+	}
+
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].DocumentPath < documents[j].DocumentPath
+	})
+
+	expectedDocuments := []shared.RankingDocument{
+		{RepositoryID: 51, DocumentPath: "lib/foo.go"},
+		{RepositoryID: 50, DocumentPath: "main.go"},
+		{RepositoryID: 50, DocumentPath: "unreferenced.go"},
+	}
+	if diff := cmp.Diff(expectedDocuments, documents); diff != "" {
+		t.Errorf("unexpected documents (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetPathRanks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertRepo(t, db, 50, "foo")
+	insertRepo(t, db, 51, "bar")
+
+	derivativeGraphKey := rankingshared.NewDerivativeGraphKeyKey(mockRankingGraphKey, "", 123)
+
+	if exists, err := store.HasPathRanks(ctx, derivativeGraphKey); err != nil {
+		t.Fatalf("unexpected error checking path ranks: %s", err)
+	} else if exists {
+		t.Fatalf("expected no path ranks")
+	}
+
+	if err := store.SetPathRanks(ctx, derivativeGraphKey, map[int]map[string]float64{
+		50: {"main.go": 1, "bar.go": 3.5},
+		51: {"lib/foo.go": 4},
+	}); err != nil {
+		t.Fatalf("unexpected error setting path ranks: %s", err)
+	}
+	if err := store.SetPathRanks(ctx, derivativeGraphKey, map[int]map[string]float64{
+		50: {"main.go": 2},
+	}); err != nil {
+		t.Fatalf("unexpected error setting path ranks: %s", err)
+	}
+
+	if exists, err := store.HasPathRanks(ctx, derivativeGraphKey); err != nil {
+		t.Fatalf("unexpected error checking path ranks: %s", err)
+	} else if !exists {
+		t.Fatalf("expected path ranks")
+	}
+
+	for repoName, expectedRanks := range map[api.RepoName]map[string]float64{
+		"foo": {"main.go": 2},
+		"bar": {"lib/foo.go": 4},
+	} {
+		ranks, _, err := store.GetDocumentRanks(ctx, repoName)
+		if err != nil {
+			t.Fatalf("unexpected error getting document ranks: %s", err)
+		}
+		if diff := cmp.Diff(expectedRanks, ranks); diff != "" {
+			t.Errorf("unexpected ranks for %s (-want +got):\n%s", repoName, diff)
+		}
+	}
+}
@@ -60,7 +60,7 @@ func TestInsertPathRanks(t *testing.T) {
 	mockReferences <- "bar"
 	mockReferences <- "baz"
 	close(mockReferences)
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 1, mockReferences); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 1, "main.go", mockReferences); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
 
@@ -18,6 +18,7 @@ func (s *store) InsertReferencesForRanking(
 	rankingGraphKey string,
 	batchSize int,
 	uploadID int,
+	documentPath string,
 	references chan string,
 ) (err error) {
 	ctx, _, endObservation := s.operations.insertReferencesForRanking.With(
@@ -35,7 +36,7 @@ func (s *store) InsertReferencesForRanking(
 
 	inserter := func(inserter *batch.Inserter) error {
 		for symbols := range batchChannel(references, batchSize) {
-			if err := inserter.Insert(ctx, uploadID, pq.Array(symbols), rankingGraphKey); err != nil {
+			if err := inserter.Insert(ctx, uploadID, documentPath, pq.Array(symbols), rankingGraphKey); err != nil {
 				return err
 			}
 		}
@@ -48,7 +49,7 @@ func (s *store) InsertReferencesForRanking(
 		tx.Handle(),
 		"codeintel_ranking_references",
 		batch.MaxNumPostgresParameters,
-		[]string{"upload_id", "symbol_names", "graph_key"},
+		[]string{"upload_id", "document_path", "symbol_names", "graph_key"},
 		inserter,
 	); err != nil {
 		return err
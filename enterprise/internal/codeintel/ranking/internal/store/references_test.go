@@ -29,7 +29,7 @@ func TestInsertReferences(t *testing.T) {
 	mockReferences <- "bar"
 	mockReferences <- "baz"
 	close(mockReferences)
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 1, mockReferences); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 1, "main.go", mockReferences); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
 
@@ -68,13 +68,13 @@ func TestVacuumAbandonedReferences(t *testing.T) {
 	close(mockReferences2)
 	close(mockReferences3)
 
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey+"-abandoned", 1, 1, mockReferences1); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey+"-abandoned", 1, 1, "main.go", mockReferences1); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, 1, 1, mockReferences2); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, 1, 1, "main.go", mockReferences2); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
-	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey+"-abandoned", 1, 1, mockReferences3); err != nil {
+	if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey+"-abandoned", 1, 1, "main.go", mockReferences3); err != nil {
 		t.Fatalf("unexpected error inserting references: %s", err)
 	}
 
@@ -34,7 +34,7 @@ type Store interface {
 	VacuumStaleDefinitions(ctx context.Context, graphKey string) (numDefinitionRecordsScanned int, numStaleDefinitionRecordsDeleted int, _ error)
 
 	// Export references + cleanup
-	InsertReferencesForRanking(ctx context.Context, rankingGraphKey string, batchSize int, uploadID int, references chan string) error
+	InsertReferencesForRanking(ctx context.Context, rankingGraphKey string, batchSize int, uploadID int, documentPath string, references chan string) error
 	VacuumAbandonedReferences(ctx context.Context, graphKey string, batchSize int) (int, error)
 	VacuumStaleReferences(ctx context.Context, graphKey string) (numReferenceRecordsScanned int, numStaleReferenceRecordsDeleted int, _ error)
 
@@ -51,6 +51,12 @@ type Store interface {
 	// Reducer behavior + cleanup
 	InsertPathRanks(ctx context.Context, graphKey string, batchSize int) (numPathRanksInserted int, numInputsProcessed int, _ error)
 	VacuumStaleRanks(ctx context.Context, derivativeGraphKey string) (rankRecordsScanned int, rankRecordsSDeleted int, _ error)
+
+	// PageRank behavior
+	GetRankingGraphEdges(ctx context.Context, graphKey string, lastReferenceID int, batchSize int) (_ []shared.RankingGraphEdge, nextReferenceID int, _ error)
+	GetRankingInitialPaths(ctx context.Context, graphKey string, lastInitialPathID int, batchSize int) (_ []shared.RankingDocument, nextInitialPathID int, _ error)
+	HasPathRanks(ctx context.Context, derivativeGraphKey string) (bool, error)
+	SetPathRanks(ctx context.Context, derivativeGraphKey string, pathRanksByRepositoryID map[int]map[string]float64) error
 }
 
 type store struct {
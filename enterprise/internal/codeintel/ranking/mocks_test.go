@@ -12,8 +12,8 @@ import (
 	"time"
 
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/store"
-	shared1 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/shared"
-	shared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	shared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/shared"
+	shared1 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	conftypes "github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	schema "github.com/sourcegraph/sourcegraph/schema"
@@ -30,6 +30,12 @@ type MockStore struct {
 	// GetDocumentRanksFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDocumentRanks.
 	GetDocumentRanksFunc *StoreGetDocumentRanksFunc
+	// GetRankingGraphEdgesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRankingGraphEdges.
+	GetRankingGraphEdgesFunc *StoreGetRankingGraphEdgesFunc
+	// GetRankingInitialPathsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRankingInitialPaths.
+	GetRankingInitialPathsFunc *StoreGetRankingInitialPathsFunc
 	// GetReferenceCountStatisticsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetReferenceCountStatistics.
@@ -40,6 +46,9 @@ type MockStore struct {
 	// GetUploadsForRankingFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsForRanking.
 	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
+	// HasPathRanksFunc is an instance of a mock function object controlling
+	// the behavior of the method HasPathRanks.
+	HasPathRanksFunc *StoreHasPathRanksFunc
 	// InsertDefinitionsForRankingFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// InsertDefinitionsForRanking.
@@ -67,6 +76,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// ProcessStaleExportedUploads.
 	ProcessStaleExportedUploadsFunc *StoreProcessStaleExportedUploadsFunc
+	// SetPathRanksFunc is an instance of a mock function object controlling
+	// the behavior of the method SetPathRanks.
+	SetPathRanksFunc *StoreSetPathRanksFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *StoreTransactFunc
@@ -113,6 +125,16 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetRankingGraphEdgesFunc: &StoreGetRankingGraphEdgesFunc{
+			defaultHook: func(context.Context, string, int, int) (r0 []shared.RankingGraphEdge, r1 int, r2 error) {
+				return
+			},
+		},
+		GetRankingInitialPathsFunc: &StoreGetRankingInitialPathsFunc{
+			defaultHook: func(context.Context, string, int, int) (r0 []shared.RankingDocument, r1 int, r2 error) {
+				return
+			},
+		},
 		GetReferenceCountStatisticsFunc: &StoreGetReferenceCountStatisticsFunc{
 			defaultHook: func(context.Context) (r0 float64, r1 error) {
 				return
@@ -124,12 +146,17 @@ func NewMockStore() *MockStore {
 			},
 		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
-			defaultHook: func(context.Context, string, string, int) (r0 []shared.ExportedUpload, r1 error) {
+			defaultHook: func(context.Context, string, string, int) (r0 []shared1.ExportedUpload, r1 error) {
+				return
+			},
+		},
+		HasPathRanksFunc: &StoreHasPathRanksFunc{
+			defaultHook: func(context.Context, string) (r0 bool, r1 error) {
 				return
 			},
 		},
 		InsertDefinitionsForRankingFunc: &StoreInsertDefinitionsForRankingFunc{
-			defaultHook: func(context.Context, string, chan shared1.RankingDefinitions) (r0 error) {
+			defaultHook: func(context.Context, string, chan shared.RankingDefinitions) (r0 error) {
 				return
 			},
 		},
@@ -154,7 +181,7 @@ func NewMockStore() *MockStore {
 			},
 		},
 		InsertReferencesForRankingFunc: &StoreInsertReferencesForRankingFunc{
-			defaultHook: func(context.Context, string, int, int, chan string) (r0 error) {
+			defaultHook: func(context.Context, string, int, int, string, chan string) (r0 error) {
 				return
 			},
 		},
@@ -168,6 +195,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		SetPathRanksFunc: &StoreSetPathRanksFunc{
+			defaultHook: func(context.Context, string, map[int]map[string]float64) (r0 error) {
+				return
+			},
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: func(context.Context) (r0 store.Store, r1 error) {
 				return
@@ -230,6 +262,16 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetDocumentRanks")
 			},
 		},
+		GetRankingGraphEdgesFunc: &StoreGetRankingGraphEdgesFunc{
+			defaultHook: func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error) {
+				panic("unexpected invocation of MockStore.GetRankingGraphEdges")
+			},
+		},
+		GetRankingInitialPathsFunc: &StoreGetRankingInitialPathsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]shared.RankingDocument, int, error) {
+				panic("unexpected invocation of MockStore.GetRankingInitialPaths")
+			},
+		},
 		GetReferenceCountStatisticsFunc: &StoreGetReferenceCountStatisticsFunc{
 			defaultHook: func(context.Context) (float64, error) {
 				panic("unexpected invocation of MockStore.GetReferenceCountStatistics")
@@ -241,12 +283,17 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
-			defaultHook: func(context.Context, string, string, int) ([]shared.ExportedUpload, error) {
+			defaultHook: func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
 				panic("unexpected invocation of MockStore.GetUploadsForRanking")
 			},
 		},
+		HasPathRanksFunc: &StoreHasPathRanksFunc{
+			defaultHook: func(context.Context, string) (bool, error) {
+				panic("unexpected invocation of MockStore.HasPathRanks")
+			},
+		},
 		InsertDefinitionsForRankingFunc: &StoreInsertDefinitionsForRankingFunc{
-			defaultHook: func(context.Context, string, chan shared1.RankingDefinitions) error {
+			defaultHook: func(context.Context, string, chan shared.RankingDefinitions) error {
 				panic("unexpected invocation of MockStore.InsertDefinitionsForRanking")
 			},
 		},
@@ -271,7 +318,7 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		InsertReferencesForRankingFunc: &StoreInsertReferencesForRankingFunc{
-			defaultHook: func(context.Context, string, int, int, chan string) error {
+			defaultHook: func(context.Context, string, int, int, string, chan string) error {
 				panic("unexpected invocation of MockStore.InsertReferencesForRanking")
 			},
 		},
@@ -285,6 +332,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.ProcessStaleExportedUploads")
 			},
 		},
+		SetPathRanksFunc: &StoreSetPathRanksFunc{
+			defaultHook: func(context.Context, string, map[int]map[string]float64) error {
+				panic("unexpected invocation of MockStore.SetPathRanks")
+			},
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: func(context.Context) (store.Store, error) {
 				panic("unexpected invocation of MockStore.Transact")
@@ -343,6 +395,12 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetDocumentRanksFunc: &StoreGetDocumentRanksFunc{
 			defaultHook: i.GetDocumentRanks,
 		},
+		GetRankingGraphEdgesFunc: &StoreGetRankingGraphEdgesFunc{
+			defaultHook: i.GetRankingGraphEdges,
+		},
+		GetRankingInitialPathsFunc: &StoreGetRankingInitialPathsFunc{
+			defaultHook: i.GetRankingInitialPaths,
+		},
 		GetReferenceCountStatisticsFunc: &StoreGetReferenceCountStatisticsFunc{
 			defaultHook: i.GetReferenceCountStatistics,
 		},
@@ -352,6 +410,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: i.GetUploadsForRanking,
 		},
+		HasPathRanksFunc: &StoreHasPathRanksFunc{
+			defaultHook: i.HasPathRanks,
+		},
 		InsertDefinitionsForRankingFunc: &StoreInsertDefinitionsForRankingFunc{
 			defaultHook: i.InsertDefinitionsForRanking,
 		},
@@ -376,6 +437,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		ProcessStaleExportedUploadsFunc: &StoreProcessStaleExportedUploadsFunc{
 			defaultHook: i.ProcessStaleExportedUploads,
 		},
+		SetPathRanksFunc: &StoreSetPathRanksFunc{
+			defaultHook: i.SetPathRanks,
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -618,6 +682,241 @@ func (c StoreGetDocumentRanksFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetRankingGraphEdgesFunc describes the behavior when the
+// GetRankingGraphEdges method of the parent MockStore instance is invoked.
+type StoreGetRankingGraphEdgesFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error)
+	hooks       []func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error)
+	history     []StoreGetRankingGraphEdgesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRankingGraphEdges delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetRankingGraphEdges(v0 context.Context, v1 string, v2 int, v3 int) ([]shared.RankingGraphEdge, int, error) {
+	r0, r1, r2 := m.GetRankingGraphEdgesFunc.nextHook()(v0, v1, v2, v3)
+	m.GetRankingGraphEdgesFunc.appendCall(StoreGetRankingGraphEdgesFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetRankingGraphEdges
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetRankingGraphEdgesFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRankingGraphEdges method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetRankingGraphEdgesFunc) PushHook(hook func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetRankingGraphEdgesFunc) SetDefaultReturn(r0 []shared.RankingGraphEdge, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetRankingGraphEdgesFunc) PushReturn(r0 []shared.RankingGraphEdge, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetRankingGraphEdgesFunc) nextHook() func(context.Context, string, int, int) ([]shared.RankingGraphEdge, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetRankingGraphEdgesFunc) appendCall(r0 StoreGetRankingGraphEdgesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetRankingGraphEdgesFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetRankingGraphEdgesFunc) History() []StoreGetRankingGraphEdgesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetRankingGraphEdgesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetRankingGraphEdgesFuncCall is an object that describes an
+// invocation of method GetRankingGraphEdges on an instance of MockStore.
+type StoreGetRankingGraphEdgesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.RankingGraphEdge
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetRankingGraphEdgesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetRankingGraphEdgesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetRankingInitialPathsFunc describes the behavior when the
+// GetRankingInitialPaths method of the parent MockStore instance is
+// invoked.
+type StoreGetRankingInitialPathsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]shared.RankingDocument, int, error)
+	hooks       []func(context.Context, string, int, int) ([]shared.RankingDocument, int, error)
+	history     []StoreGetRankingInitialPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRankingInitialPaths delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetRankingInitialPaths(v0 context.Context, v1 string, v2 int, v3 int) ([]shared.RankingDocument, int, error) {
+	r0, r1, r2 := m.GetRankingInitialPathsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetRankingInitialPathsFunc.appendCall(StoreGetRankingInitialPathsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetRankingInitialPaths method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreGetRankingInitialPathsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]shared.RankingDocument, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRankingInitialPaths method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetRankingInitialPathsFunc) PushHook(hook func(context.Context, string, int, int) ([]shared.RankingDocument, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetRankingInitialPathsFunc) SetDefaultReturn(r0 []shared.RankingDocument, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]shared.RankingDocument, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetRankingInitialPathsFunc) PushReturn(r0 []shared.RankingDocument, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]shared.RankingDocument, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetRankingInitialPathsFunc) nextHook() func(context.Context, string, int, int) ([]shared.RankingDocument, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetRankingInitialPathsFunc) appendCall(r0 StoreGetRankingInitialPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetRankingInitialPathsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetRankingInitialPathsFunc) History() []StoreGetRankingInitialPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetRankingInitialPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetRankingInitialPathsFuncCall is an object that describes an
+// invocation of method GetRankingInitialPaths on an instance of MockStore.
+type StoreGetRankingInitialPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.RankingDocument
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetRankingInitialPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetRankingInitialPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetReferenceCountStatisticsFunc describes the behavior when the
 // GetReferenceCountStatistics method of the parent MockStore instance is
 // invoked.
@@ -836,15 +1135,15 @@ func (c StoreGetStarRankFuncCall) Results() []interface{} {
 // StoreGetUploadsForRankingFunc describes the behavior when the
 // GetUploadsForRanking method of the parent MockStore instance is invoked.
 type StoreGetUploadsForRankingFunc struct {
-	defaultHook func(context.Context, string, string, int) ([]shared.ExportedUpload, error)
-	hooks       []func(context.Context, string, string, int) ([]shared.ExportedUpload, error)
+	defaultHook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
+	hooks       []func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
 	history     []StoreGetUploadsForRankingFuncCall
 	mutex       sync.Mutex
 }
 
 // GetUploadsForRanking delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockStore) GetUploadsForRanking(v0 context.Context, v1 string, v2 string, v3 int) ([]shared.ExportedUpload, error) {
+func (m *MockStore) GetUploadsForRanking(v0 context.Context, v1 string, v2 string, v3 int) ([]shared1.ExportedUpload, error) {
 	r0, r1 := m.GetUploadsForRankingFunc.nextHook()(v0, v1, v2, v3)
 	m.GetUploadsForRankingFunc.appendCall(StoreGetUploadsForRankingFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
@@ -853,7 +1152,7 @@ func (m *MockStore) GetUploadsForRanking(v0 context.Context, v1 string, v2 strin
 // SetDefaultHook sets function that is called when the GetUploadsForRanking
 // method of the parent MockStore instance is invoked and the hook queue is
 // empty.
-func (f *StoreGetUploadsForRankingFunc) SetDefaultHook(hook func(context.Context, string, string, int) ([]shared.ExportedUpload, error)) {
+func (f *StoreGetUploadsForRankingFunc) SetDefaultHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
 	f.defaultHook = hook
 }
 
@@ -861,7 +1160,7 @@ func (f *StoreGetUploadsForRankingFunc) SetDefaultHook(hook func(context.Context
 // GetUploadsForRanking method of the parent MockStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, string, string, int) ([]shared.ExportedUpload, error)) {
+func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -869,20 +1168,20 @@ func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, stri
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetUploadsForRankingFunc) SetDefaultReturn(r0 []shared.ExportedUpload, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, string, int) ([]shared.ExportedUpload, error) {
+func (f *StoreGetUploadsForRankingFunc) SetDefaultReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetUploadsForRankingFunc) PushReturn(r0 []shared.ExportedUpload, r1 error) {
-	f.PushHook(func(context.Context, string, string, int) ([]shared.ExportedUpload, error) {
+func (f *StoreGetUploadsForRankingFunc) PushReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.PushHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreGetUploadsForRankingFunc) nextHook() func(context.Context, string, string, int) ([]shared.ExportedUpload, error) {
+func (f *StoreGetUploadsForRankingFunc) nextHook() func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -929,7 +1228,7 @@ type StoreGetUploadsForRankingFuncCall struct {
 	Arg3 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared.ExportedUpload
+	Result0 []shared1.ExportedUpload
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -947,19 +1246,126 @@ func (c StoreGetUploadsForRankingFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreHasPathRanksFunc describes the behavior when the HasPathRanks method
+// of the parent MockStore instance is invoked.
+type StoreHasPathRanksFunc struct {
+	defaultHook func(context.Context, string) (bool, error)
+	hooks       []func(context.Context, string) (bool, error)
+	history     []StoreHasPathRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// HasPathRanks delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) HasPathRanks(v0 context.Context, v1 string) (bool, error) {
+	r0, r1 := m.HasPathRanksFunc.nextHook()(v0, v1)
+	m.HasPathRanksFunc.appendCall(StoreHasPathRanksFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the HasPathRanks method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreHasPathRanksFunc) SetDefaultHook(hook func(context.Context, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HasPathRanks method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreHasPathRanksFunc) PushHook(hook func(context.Context, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreHasPathRanksFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreHasPathRanksFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreHasPathRanksFunc) nextHook() func(context.Context, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreHasPathRanksFunc) appendCall(r0 StoreHasPathRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreHasPathRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreHasPathRanksFunc) History() []StoreHasPathRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreHasPathRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreHasPathRanksFuncCall is an object that describes an invocation of
+// method HasPathRanks on an instance of MockStore.
+type StoreHasPathRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreHasPathRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreHasPathRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreInsertDefinitionsForRankingFunc describes the behavior when the
 // InsertDefinitionsForRanking method of the parent MockStore instance is
 // invoked.
 type StoreInsertDefinitionsForRankingFunc struct {
-	defaultHook func(context.Context, string, chan shared1.RankingDefinitions) error
-	hooks       []func(context.Context, string, chan shared1.RankingDefinitions) error
+	defaultHook func(context.Context, string, chan shared.RankingDefinitions) error
+	hooks       []func(context.Context, string, chan shared.RankingDefinitions) error
 	history     []StoreInsertDefinitionsForRankingFuncCall
 	mutex       sync.Mutex
 }
 
 // InsertDefinitionsForRanking delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) InsertDefinitionsForRanking(v0 context.Context, v1 string, v2 chan shared1.RankingDefinitions) error {
+func (m *MockStore) InsertDefinitionsForRanking(v0 context.Context, v1 string, v2 chan shared.RankingDefinitions) error {
 	r0 := m.InsertDefinitionsForRankingFunc.nextHook()(v0, v1, v2)
 	m.InsertDefinitionsForRankingFunc.appendCall(StoreInsertDefinitionsForRankingFuncCall{v0, v1, v2, r0})
 	return r0
@@ -968,7 +1374,7 @@ func (m *MockStore) InsertDefinitionsForRanking(v0 context.Context, v1 string, v
 // SetDefaultHook sets function that is called when the
 // InsertDefinitionsForRanking method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultHook(hook func(context.Context, string, chan shared1.RankingDefinitions) error) {
+func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
 	f.defaultHook = hook
 }
 
@@ -977,7 +1383,7 @@ func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultHook(hook func(context.
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreInsertDefinitionsForRankingFunc) PushHook(hook func(context.Context, string, chan shared1.RankingDefinitions) error) {
+func (f *StoreInsertDefinitionsForRankingFunc) PushHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -986,19 +1392,19 @@ func (f *StoreInsertDefinitionsForRankingFunc) PushHook(hook func(context.Contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, string, chan shared1.RankingDefinitions) error {
+	f.SetDefaultHook(func(context.Context, string, chan shared.RankingDefinitions) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *StoreInsertDefinitionsForRankingFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, string, chan shared1.RankingDefinitions) error {
+	f.PushHook(func(context.Context, string, chan shared.RankingDefinitions) error {
 		return r0
 	})
 }
 
-func (f *StoreInsertDefinitionsForRankingFunc) nextHook() func(context.Context, string, chan shared1.RankingDefinitions) error {
+func (f *StoreInsertDefinitionsForRankingFunc) nextHook() func(context.Context, string, chan shared.RankingDefinitions) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1040,7 +1446,7 @@ type StoreInsertDefinitionsForRankingFuncCall struct {
 	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 chan shared1.RankingDefinitions
+	Arg2 chan shared.RankingDefinitions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -1520,24 +1926,24 @@ func (c StoreInsertPathRanksFuncCall) Results() []interface{} {
 // InsertReferencesForRanking method of the parent MockStore instance is
 // invoked.
 type StoreInsertReferencesForRankingFunc struct {
-	defaultHook func(context.Context, string, int, int, chan string) error
-	hooks       []func(context.Context, string, int, int, chan string) error
+	defaultHook func(context.Context, string, int, int, string, chan string) error
+	hooks       []func(context.Context, string, int, int, string, chan string) error
 	history     []StoreInsertReferencesForRankingFuncCall
 	mutex       sync.Mutex
 }
 
 // InsertReferencesForRanking delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) InsertReferencesForRanking(v0 context.Context, v1 string, v2 int, v3 int, v4 chan string) error {
-	r0 := m.InsertReferencesForRankingFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.InsertReferencesForRankingFunc.appendCall(StoreInsertReferencesForRankingFuncCall{v0, v1, v2, v3, v4, r0})
+func (m *MockStore) InsertReferencesForRanking(v0 context.Context, v1 string, v2 int, v3 int, v4 string, v5 chan string) error {
+	r0 := m.InsertReferencesForRankingFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.InsertReferencesForRankingFunc.appendCall(StoreInsertReferencesForRankingFuncCall{v0, v1, v2, v3, v4, v5, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
 // InsertReferencesForRanking method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreInsertReferencesForRankingFunc) SetDefaultHook(hook func(context.Context, string, int, int, chan string) error) {
+func (f *StoreInsertReferencesForRankingFunc) SetDefaultHook(hook func(context.Context, string, int, int, string, chan string) error) {
 	f.defaultHook = hook
 }
 
@@ -1546,7 +1952,7 @@ func (f *StoreInsertReferencesForRankingFunc) SetDefaultHook(hook func(context.C
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreInsertReferencesForRankingFunc) PushHook(hook func(context.Context, string, int, int, chan string) error) {
+func (f *StoreInsertReferencesForRankingFunc) PushHook(hook func(context.Context, string, int, int, string, chan string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1555,19 +1961,19 @@ func (f *StoreInsertReferencesForRankingFunc) PushHook(hook func(context.Context
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *StoreInsertReferencesForRankingFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, string, int, int, chan string) error {
+	f.SetDefaultHook(func(context.Context, string, int, int, string, chan string) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *StoreInsertReferencesForRankingFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, string, int, int, chan string) error {
+	f.PushHook(func(context.Context, string, int, int, string, chan string) error {
 		return r0
 	})
 }
 
-func (f *StoreInsertReferencesForRankingFunc) nextHook() func(context.Context, string, int, int, chan string) error {
+func (f *StoreInsertReferencesForRankingFunc) nextHook() func(context.Context, string, int, int, string, chan string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1615,7 +2021,10 @@ type StoreInsertReferencesForRankingFuncCall struct {
 	Arg3 int
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
-	Arg4 chan string
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 chan string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -1624,7 +2033,7 @@ type StoreInsertReferencesForRankingFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c StoreInsertReferencesForRankingFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -1857,6 +2266,113 @@ func (c StoreProcessStaleExportedUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreSetPathRanksFunc describes the behavior when the SetPathRanks method
+// of the parent MockStore instance is invoked.
+type StoreSetPathRanksFunc struct {
+	defaultHook func(context.Context, string, map[int]map[string]float64) error
+	hooks       []func(context.Context, string, map[int]map[string]float64) error
+	history     []StoreSetPathRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// SetPathRanks delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) SetPathRanks(v0 context.Context, v1 string, v2 map[int]map[string]float64) error {
+	r0 := m.SetPathRanksFunc.nextHook()(v0, v1, v2)
+	m.SetPathRanksFunc.appendCall(StoreSetPathRanksFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetPathRanks method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreSetPathRanksFunc) SetDefaultHook(hook func(context.Context, string, map[int]map[string]float64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetPathRanks method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreSetPathRanksFunc) PushHook(hook func(context.Context, string, map[int]map[string]float64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreSetPathRanksFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, map[int]map[string]float64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreSetPathRanksFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, map[int]map[string]float64) error {
+		return r0
+	})
+}
+
+func (f *StoreSetPathRanksFunc) nextHook() func(context.Context, string, map[int]map[string]float64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreSetPathRanksFunc) appendCall(r0 StoreSetPathRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreSetPathRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreSetPathRanksFunc) History() []StoreSetPathRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreSetPathRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreSetPathRanksFuncCall is an object that describes an invocation of
+// method SetPathRanks on an instance of MockStore.
+type StoreSetPathRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 map[int]map[string]float64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreSetPathRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreSetPathRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreTransactFunc describes the behavior when the Transact method of the
 // parent MockStore instance is invoked.
 type StoreTransactFunc struct {
@@ -10,3 +10,20 @@ type RankingReferences struct {
 	UploadID    int
 	SymbolNames []string
 }
+
+// RankingGraphEdge is a weighted edge of the file-level reference graph. The source document
+// references symbols defined in the target document, and the count is the number of such
+// references.
+type RankingGraphEdge struct {
+	SourceRepositoryID int
+	SourceDocumentPath string
+	TargetRepositoryID int
+	TargetDocumentPath string
+	Count              int
+}
+
+// RankingDocument is a document of the file-level reference graph.
+type RankingDocument struct {
+	RepositoryID int
+	DocumentPath string
+}
@@ -0,0 +1,26 @@
+package sharedresolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+)
+
+type PermissionChecker interface {
+	CheckCurrentUserHasPermission(ctx context.Context, permission string) error
+}
+
+type permissionChecker struct {
+	db database.DB
+}
+
+func NewPermissionChecker(db database.DB) PermissionChecker {
+	return &permissionChecker{
+		db: db,
+	}
+}
+
+func (c *permissionChecker) CheckCurrentUserHasPermission(ctx context.Context, permission string) error {
+	return rbac.CheckCurrentUserHasPermission(ctx, c.db, permission)
+}
@@ -167,3 +167,16 @@ func IndexerFromName(name string) CodeIntelIndexer {
 
 	return CodeIntelIndexer{Name: name}
 }
+
+// IndexerCoversPath returns true if an index produced by the indexer with the given name is
+// expected to describe the file with the given path, judging by the file's extension. Paths
+// are never covered by indexers of unknown languages.
+func IndexerCoversPath(name, path string) bool {
+	for _, extension := range extensions[IndexerFromName(name).LanguageKey] {
+		if strings.HasSuffix(path, extension) {
+			return true
+		}
+	}
+
+	return false
+}
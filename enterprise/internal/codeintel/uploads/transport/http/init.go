@@ -41,14 +41,15 @@ func GetHandler(svc *uploads.Service, db database.DB, gitserverClient gitserver.
 		// in the auth middleware defined on the next few lines
 		handler = newHandler(repoStore, uploadStore, svc.UploadHandlerStore(), uploadHandlerOperations)
 
-		// 🚨 SECURITY: Non-internal installations of this handler will require a user/repo
-		// visibility check with the remote code host (if enabled via site configuration).
-		handlerWithAuth = auth.AuthMiddleware(
+		// 🚨 SECURITY: Non-internal installations of this handler will require the current
+		// user to have the code intel upload permission, as well as a user/repo visibility
+		// check with the remote code host (if enabled via site configuration).
+		handlerWithAuth = permissionMiddleware(db, auth.AuthMiddleware(
 			handler,
 			userStore,
 			auth.DefaultValidatorByCodeHost,
 			operations.authMiddleware,
-		)
+		))
 	})
 
 	if withCodeHostAuthAuth {
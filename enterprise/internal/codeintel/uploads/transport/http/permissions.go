@@ -0,0 +1,40 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// permissionMiddleware wraps the given upload handler with a check that the current user has
+// been granted the permission to upload precise code intelligence data. Internal actors are
+// always allowed to upload.
+//
+// Requests without an authenticated Sourcegraph user are passed through unchanged: they are
+// either permitted because lsifEnforceAuth is disabled, or are authorized by a code host token
+// in the auth middleware wrapped by this handler.
+func permissionMiddleware(db database.DB, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a := actor.FromContext(r.Context()); !a.IsAuthenticated() || a.IsInternal() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := rbac.CheckCurrentUserHasPermission(r.Context(), db, rbac.CodeIntelUploadPermission); err != nil {
+			statusCode := http.StatusInternalServerError
+			var notAuthorizedErr *rbac.ErrNotAuthorized
+			if errors.As(err, &notAuthorizedErr) {
+				statusCode = http.StatusForbidden
+			}
+
+			http.Error(w, fmt.Sprintf("failed to authorize request: %s", err.Error()), statusCode)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/transport/http/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPermissionMiddleware(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		a := actor.FromContext(ctx)
+		if !a.IsAuthenticated() {
+			return nil, database.ErrNoCurrentUser
+		}
+		return &types.User{ID: a.UID}, nil
+	})
+
+	permissions := database.NewMockPermissionStore()
+	permissions.GetPermissionForUserFunc.SetDefaultHook(func(_ context.Context, opts database.GetPermissionForUserOpts) (*types.Permission, error) {
+		if opts.UserID == 1 {
+			return &types.Permission{ID: 1, Namespace: opts.Namespace, Action: opts.Action}, nil
+		}
+		return nil, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+
+	authValidators := auth.AuthValidatorMap{
+		"github.com": func(_ context.Context, query url.Values, _ string) (int, error) {
+			if query.Get("github_token") != "valid" {
+				return http.StatusUnauthorized, errors.New("invalid token")
+			}
+			return 0, nil
+		},
+	}
+
+	handler := permissionMiddleware(db, auth.AuthMiddleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		users,
+		authValidators,
+		newOperations(&observation.TestContext).authMiddleware,
+	))
+
+	testCases := []struct {
+		name        string
+		actor       *actor.Actor
+		enforceAuth bool
+		token       string
+		wantStatus  int
+	}{
+		{name: "internal actor", actor: &actor.Actor{Internal: true}, wantStatus: http.StatusOK},
+		{name: "anonymous without enforced auth", actor: &actor.Actor{}, wantStatus: http.StatusOK},
+		{name: "anonymous with code host token", actor: &actor.Actor{}, enforceAuth: true, token: "valid", wantStatus: http.StatusOK},
+		{name: "anonymous without code host token", actor: &actor.Actor{}, enforceAuth: true, wantStatus: http.StatusUnauthorized},
+		{name: "user with permission", actor: actor.FromUser(1), wantStatus: http.StatusOK},
+		{name: "user without permission", actor: actor.FromUser(2), wantStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf.Mock(&conf.Unified{
+				SiteConfiguration: schema.SiteConfiguration{
+					LsifEnforceAuth: tc.enforceAuth,
+				},
+			})
+			t.Cleanup(func() { conf.Mock(nil) })
+
+			query := url.Values{"repository": []string{"github.com/test/test"}}
+			if tc.token != "" {
+				query.Set("github_token", tc.token)
+			}
+
+			r, err := http.NewRequest("POST", "/upload?"+query.Encode(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error constructing request: %s", err)
+			}
+			r = r.WithContext(actor.WithActor(context.Background(), tc.actor))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tc.wantStatus {
+				t.Errorf("unexpected status code. want=%d have=%d", tc.wantStatus, w.Code)
+			}
+		})
+	}
+}
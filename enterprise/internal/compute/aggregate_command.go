@@ -0,0 +1,141 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// DefaultAggregateLimit is the number of groups an aggregation returns when
+// no explicit limit is requested.
+const DefaultAggregateLimit = 50
+
+// Aggregate evaluates a template for every match of a search pattern and
+// groups the evaluated values by key, counting how often each value occurs
+// across the whole result stream.
+type Aggregate struct {
+	SearchPattern MatchPattern
+	KeyPattern    string
+	Selector      string
+	TypeValue     string
+	Kind          string
+}
+
+func (c *Aggregate) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *Aggregate) String() string {
+	return fmt.Sprintf("Aggregate: (%s) -> (%s)", c.SearchPattern.String(), c.KeyPattern)
+}
+
+// Run returns the counts of the keys evaluated for a single result. Counts of
+// all results are combined into an Aggregation with an Aggregator.
+func (c *Aggregate) Run(ctx context.Context, r result.Match) (Result, error) {
+	kind := "output"
+	if c.Kind == "aggregate.structural" {
+		kind = "output.structural"
+	}
+	onlyPath := c.TypeValue == "path" // don't read file contents for file matches when we only want type:path
+	chunks := resultChunks(r, kind, onlyPath)
+
+	counts := &Counts{Values: map[string]int{}}
+	for _, content := range chunks {
+		env := NewMetaEnvironment(r, content)
+		keyPattern, err := substituteMetaVariables(c.KeyPattern, env)
+		if err != nil {
+			return nil, err
+		}
+
+		keys, err := toTextResult(ctx, content, c.SearchPattern, keyPattern, "\n", c.Selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range strings.Split(keys, "\n") {
+			if key == "" {
+				continue
+			}
+			counts.Values[key]++
+		}
+	}
+	return counts, nil
+}
+
+// Counts holds the number of times each key was evaluated for a single result.
+type Counts struct {
+	Values map[string]int `json:"values"`
+}
+
+// Aggregation is the result of an aggregate command over a result stream.
+type Aggregation struct {
+	Kind string `json:"kind"`
+
+	// Groups are the keys with the highest counts, in descending order of count.
+	Groups []AggregationGroup `json:"groups"`
+
+	// OtherGroupCount is the number of groups not included in Groups.
+	OtherGroupCount int `json:"otherGroupCount"`
+
+	// OtherResultCount is the sum of the counts of the groups not included in Groups.
+	OtherResultCount int `json:"otherResultCount"`
+}
+
+type AggregationGroup struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Aggregator combines the Counts of individual results into an Aggregation.
+// It is not safe for concurrent use.
+type Aggregator struct {
+	limit  int
+	counts map[string]int
+}
+
+// NewAggregator returns an Aggregator that keeps at most limit groups in its
+// Aggregation. A limit of zero or less uses DefaultAggregateLimit.
+func NewAggregator(limit int) *Aggregator {
+	if limit <= 0 {
+		limit = DefaultAggregateLimit
+	}
+	return &Aggregator{limit: limit, counts: map[string]int{}}
+}
+
+// Add adds the counts of a result. Results other than Counts are ignored.
+func (a *Aggregator) Add(r Result) {
+	counts, ok := r.(*Counts)
+	if !ok {
+		return
+	}
+	for value, count := range counts.Values {
+		a.counts[value] += count
+	}
+}
+
+// Aggregation returns the groups seen so far with the highest counts. Ties are
+// broken by value so that the result is stable.
+func (a *Aggregator) Aggregation() *Aggregation {
+	groups := make([]AggregationGroup, 0, len(a.counts))
+	for value, count := range a.counts {
+		groups = append(groups, AggregationGroup{Value: value, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+
+	aggregation := &Aggregation{Kind: "aggregate", Groups: groups}
+	if len(groups) > a.limit {
+		aggregation.Groups = groups[:a.limit]
+		for _, g := range groups[a.limit:] {
+			aggregation.OtherGroupCount++
+			aggregation.OtherResultCount += g.Count
+		}
+	}
+	return aggregation
+}
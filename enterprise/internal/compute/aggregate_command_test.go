@@ -0,0 +1,50 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestAggregate(t *testing.T) {
+	test := func(q string, limit int, matches ...result.Match) string {
+		computeQuery, err := Parse(q)
+		if err != nil {
+			return err.Error()
+		}
+		aggregator := NewAggregator(limit)
+		for _, m := range matches {
+			r, err := computeQuery.Command.Run(context.Background(), m)
+			if err != nil {
+				return err.Error()
+			}
+			aggregator.Add(r)
+		}
+		v, _ := json.Marshal(aggregator.Aggregation())
+		return string(v)
+	}
+
+	autogold.Expect(`{"kind":"aggregate","groups":[{"value":"1.2","count":3},{"value":"1.3","count":1}],"otherGroupCount":0,"otherResultCount":0}`).
+		Equal(t, test(`content:aggregate(lodash@(\d+\.\d+) -> $1)`, 0,
+			fileMatch("lodash@1.2", "lodash@1.3"),
+			fileMatch("lodash@1.2 lodash@1.2"),
+		))
+
+	autogold.Expect(`{"kind":"aggregate","groups":[{"value":"b","count":2}],"otherGroupCount":3,"otherResultCount":3}`).
+		Equal(t, test(`content:aggregate((\w) -> $1)`, 1,
+			fileMatch("a b c"),
+			fileMatch("b d"),
+		))
+
+	autogold.Expect(`{"kind":"aggregate","groups":[{"value":"my/awesome/repo","count":2}],"otherGroupCount":0,"otherResultCount":0}`).
+		Equal(t, test(`content:aggregate(\d -> $repo) select:repo`, 0,
+			fileMatch("a 1", "b 2"),
+		))
+
+	autogold.Expect("invalid arrow statement, no left and right hand sides of `->`").
+		Equal(t, test(`content:aggregate(lodash)`, 0))
+}
@@ -0,0 +1,77 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+)
+
+// FileDiff is a unified diff of the changes a replace command makes to a file.
+type FileDiff struct {
+	Diff         string `json:"diff"`
+	Kind         string `json:"kind"`
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Commit       string `json:"commit"`
+	Path         string `json:"path"`
+}
+
+// RepositoryDiff is the unified diff of all changes to files in a repository
+// at a commit.
+type RepositoryDiff struct {
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Commit       string `json:"commit"`
+	Diff         string `json:"diff"`
+}
+
+// unifiedDiff returns a diff of the changes to the file at path in the format
+// of git diff, or an empty string if the contents are equal.
+func unifiedDiff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+	edits := myers.ComputeEdits("", before, after)
+	return fmt.Sprintf("diff --git a/%s b/%s\n%s", path, path, gotextdiff.ToUnified("a/"+path, "b/"+path, before, edits))
+}
+
+// GroupDiffsByRepository concatenates the file diffs of each repository and
+// commit into a single diff. Repositories are sorted by name and the files
+// within a diff by path.
+func GroupDiffsByRepository(diffs []*FileDiff) []*RepositoryDiff {
+	type key struct {
+		repositoryID int32
+		commit       string
+	}
+	byRepository := make(map[key][]*FileDiff)
+	for _, d := range diffs {
+		k := key{d.RepositoryID, d.Commit}
+		byRepository[k] = append(byRepository[k], d)
+	}
+
+	result := make([]*RepositoryDiff, 0, len(byRepository))
+	for k, fileDiffs := range byRepository {
+		sort.Slice(fileDiffs, func(i, j int) bool { return fileDiffs[i].Path < fileDiffs[j].Path })
+
+		var b strings.Builder
+		for _, d := range fileDiffs {
+			b.WriteString(d.Diff)
+		}
+		result = append(result, &RepositoryDiff{
+			RepositoryID: k.repositoryID,
+			Repository:   fileDiffs[0].Repository,
+			Commit:       k.commit,
+			Diff:         b.String(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Repository != result[j].Repository {
+			return result[i].Repository < result[j].Repository
+		}
+		return result[i].Commit < result[j].Commit
+	})
+	return result
+}
@@ -0,0 +1,33 @@
+package compute
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+)
+
+func Test_unifiedDiff(t *testing.T) {
+	autogold.Expect(`diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+ 
+-import "github.com/pkg/errors"
++import "github.com/cockroachdb/errors"
+`).Equal(t, unifiedDiff("main.go", "package main\n\nimport \"github.com/pkg/errors\"\n", "package main\n\nimport \"github.com/cockroachdb/errors\"\n"))
+
+	autogold.Expect("").Equal(t, unifiedDiff("main.go", "unchanged\n", "unchanged\n"))
+}
+
+func TestGroupDiffsByRepository(t *testing.T) {
+	diffs := []*FileDiff{
+		{RepositoryID: 2, Repository: "github.com/b/b", Commit: "c2", Path: "z.go", Diff: "z\n"},
+		{RepositoryID: 1, Repository: "github.com/a/a", Commit: "c1", Path: "b.go", Diff: "b\n"},
+		{RepositoryID: 2, Repository: "github.com/b/b", Commit: "c2", Path: "y.go", Diff: "y\n"},
+		{RepositoryID: 1, Repository: "github.com/a/a", Commit: "c1", Path: "a.go", Diff: "a\n"},
+	}
+	v, _ := json.Marshal(GroupDiffsByRepository(diffs))
+	autogold.Expect(`[{"repositoryID":1,"repository":"github.com/a/a","commit":"c1","diff":"a\nb\n"},{"repositoryID":2,"repository":"github.com/b/b","commit":"c2","diff":"y\nz\n"}]`).Equal(t, string(v))
+}
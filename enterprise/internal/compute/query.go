@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -97,13 +98,19 @@ func toRegexpPattern(value string) (MatchPattern, error) {
 
 var ComputePredicateRegistry = query.PredicateRegistry{
 	query.FieldContent: {
-		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
-		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace":                 func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp":          func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural":      func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff":            func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp.diff":     func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural.diff": func() query.Predicate { return query.EmptyPredicate{} },
+		"output":                  func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":           func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural":       func() query.Predicate { return query.EmptyPredicate{} },
+		"output.extra":            func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate":               func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.regexp":        func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.structural":    func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -146,7 +153,7 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 	}
 
 	var matchPattern MatchPattern
-	switch name {
+	switch strings.TrimSuffix(name, ".diff") {
 	case "replace", "replace.regexp":
 		var err error
 		matchPattern, err = toRegexpPattern(left)
@@ -161,7 +168,7 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 		return nil, false, nil
 	}
 
-	return &Replace{SearchPattern: matchPattern, ReplacePattern: right}, true, nil
+	return &Replace{SearchPattern: matchPattern, ReplacePattern: right, Diff: strings.HasSuffix(name, ".diff")}, true, nil
 }
 
 func parseOutput(q *query.Basic) (Command, bool, error) {
@@ -217,6 +224,56 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+func parseAggregate(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+	left, right, err := parseArrowSyntax(args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var matchPattern MatchPattern
+	switch name {
+	case "aggregate", "aggregate.regexp":
+		var err error
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "aggregate command")
+		}
+	case "aggregate.structural":
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: left}
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	var typeValue string
+	query.VisitField(q.ToParseTree(), query.FieldType, func(value string, _ bool, _ query.Annotation) {
+		typeValue = value
+	})
+
+	var selector string
+	query.VisitField(q.ToParseTree(), query.FieldSelect, func(value string, _ bool, _ query.Annotation) {
+		selector = value
+	})
+
+	return &Aggregate{
+		SearchPattern: matchPattern,
+		KeyPattern:    right,
+		TypeValue:     typeValue,
+		Selector:      selector,
+		Kind:          name,
+	}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -260,6 +317,7 @@ func first(parsers ...commandParser) commandParser {
 var parseCommand = first(
 	parseReplace,
 	parseOutput,
+	parseAggregate,
 	parseMatchOnly,
 )
 
@@ -41,6 +41,12 @@ func TestParse(t *testing.T) {
 
 	autogold.Expect("Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Expect("Command: `Replace as diff: (a) -> (b)`").
+		Equal(t, test("content:replace.diff(a -> b)"))
+
+	autogold.Expect("Command: `Aggregate: (lodash@(\\d+)) -> ($1)`").
+		Equal(t, test(`content:aggregate(lodash@(\d+) -> $1)`))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -14,6 +14,10 @@ import (
 type Replace struct {
 	SearchPattern  MatchPattern
 	ReplacePattern string
+
+	// Diff makes Run return a unified diff of the replacement instead of
+	// the replaced file content.
+	Diff bool
 }
 
 func (c *Replace) ToSearchPattern() string {
@@ -21,6 +25,9 @@ func (c *Replace) ToSearchPattern() string {
 }
 
 func (c *Replace) String() string {
+	if c.Diff {
+		return fmt.Sprintf("Replace as diff: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
+	}
 	return fmt.Sprintf("Replace in place: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
 }
 
@@ -56,7 +63,26 @@ func (c *Replace) Run(ctx context.Context, r result.Match) (Result, error) {
 		if err != nil {
 			return nil, err
 		}
-		return replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+		replaced, err := replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+		if err != nil {
+			return nil, err
+		}
+		if !c.Diff {
+			return replaced, nil
+		}
+		diff := unifiedDiff(m.Path, string(content), replaced.Value)
+		if diff == "" {
+			// We processed a match for which the replacement doesn't change anything.
+			return nil, nil
+		}
+		return &FileDiff{
+			Diff:         diff,
+			Kind:         "replace-diff",
+			RepositoryID: int32(m.Repo.ID),
+			Repository:   string(m.Repo.Name),
+			Commit:       string(m.CommitID),
+			Path:         m.Path,
+		}, nil
 	}
 	return nil, nil
 }
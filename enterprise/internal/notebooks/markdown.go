@@ -0,0 +1,387 @@
+package notebooks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Notebooks are exported to Markdown files that render as regular Markdown, e.g. on a
+// code host, and that can be imported again without losing any information:
+//
+//	# Notebook title
+//
+//	<!-- sourcegraph:markdown id=1 -->
+//	Some *Markdown* text.
+//	<!-- /sourcegraph:markdown -->
+//
+//	```sourcegraph id=2
+//	repo:sourcegraph/sourcegraph lang:go
+//	```
+//
+//	```sourcegraph:file id=3
+//	repositoryName: github.com/sourcegraph/sourcegraph
+//	filePath: README.md
+//	startLine: 1
+//	endLine: 10
+//	```
+//
+// Query blocks use the same fence as the file-based .snb.md notebooks. The fences of
+// file and symbol blocks hold one "key: value" line per input field.
+//
+// Text outside of blocks is imported as Markdown blocks, so Markdown files that were
+// written by hand or exported by the web app can be imported as well.
+
+const (
+	markdownBlockDirective = "sourcegraph:markdown"
+	queryBlockFenceInfo    = "sourcegraph"
+	fileBlockFenceInfo     = "sourcegraph:file"
+	symbolBlockFenceInfo   = "sourcegraph:symbol"
+)
+
+var (
+	markdownBlockStartRegex = lazyregexp.New(`^<!-- ` + markdownBlockDirective + `((?:\s+\w+=(?:"(?:[^"\\]|\\.)*"|[^\s"]+))*)\s*-->$`)
+	markdownBlockEnd        = "<!-- /" + markdownBlockDirective + " -->"
+	fenceStartRegex         = lazyregexp.New("^(`{3,})(" + queryBlockFenceInfo + "(?::file|:symbol)?)((?:\\s+\\w+=(?:\"(?:[^\"\\\\]|\\\\.)*\"|[^\\s\"]+))*)\\s*$")
+	attributeRegex          = lazyregexp.New(`(\w+)=("(?:[^"\\]|\\.)*"|[^\s"]+)`)
+	plainValueRegex         = lazyregexp.New(`^[^\s"` + "`" + `<>]+$`)
+	backtickLineRegex       = lazyregexp.New("^`+$")
+)
+
+// ExportMarkdown serializes a notebook to Markdown. The result can be parsed back into
+// the same title and blocks with ImportMarkdown.
+func ExportMarkdown(title string, blocks NotebookBlocks) (string, error) {
+	if strings.ContainsAny(title, "\r\n") {
+		return "", errors.New("notebook title cannot contain line breaks")
+	}
+	if err := validateNotebookBlocks(blocks); err != nil {
+		return "", err
+	}
+
+	parts := []string{"# " + title}
+	for _, block := range blocks {
+		part, err := exportBlock(block)
+		if err != nil {
+			return "", errors.Wrapf(err, "block %s", block.ID)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "\n\n") + "\n", nil
+}
+
+func exportBlock(block NotebookBlock) (string, error) {
+	id := exportValue(block.ID)
+	switch block.Type {
+	case NotebookMarkdownBlockType:
+		for _, line := range strings.Split(block.MarkdownInput.Text, "\n") {
+			if line == markdownBlockEnd {
+				return "", errors.New("markdown text cannot contain the end of block directive")
+			}
+		}
+		return fmt.Sprintf("<!-- %s id=%s -->\n%s\n%s", markdownBlockDirective, id, block.MarkdownInput.Text, markdownBlockEnd), nil
+
+	case NotebookQueryBlockType:
+		fence := fenceFor(block.QueryInput.Text)
+		return fmt.Sprintf("%s%s id=%s\n%s\n%s", fence, queryBlockFenceInfo, id, block.QueryInput.Text, fence), nil
+
+	case NotebookFileBlockType:
+		input := block.FileInput
+		fields := []string{
+			exportField("repositoryName", input.RepositoryName),
+			exportField("filePath", input.FilePath),
+		}
+		if input.Revision != nil {
+			fields = append(fields, exportField("revision", *input.Revision))
+		}
+		if input.LineRange != nil {
+			fields = append(fields,
+				exportField("startLine", strconv.Itoa(int(input.LineRange.StartLine))),
+				exportField("endLine", strconv.Itoa(int(input.LineRange.EndLine))),
+			)
+		}
+		return fmt.Sprintf("```%s id=%s\n%s\n```", fileBlockFenceInfo, id, strings.Join(fields, "\n")), nil
+
+	case NotebookSymbolBlockType:
+		input := block.SymbolInput
+		fields := []string{
+			exportField("repositoryName", input.RepositoryName),
+			exportField("filePath", input.FilePath),
+		}
+		if input.Revision != nil {
+			fields = append(fields, exportField("revision", *input.Revision))
+		}
+		fields = append(fields,
+			exportField("lineContext", strconv.Itoa(int(input.LineContext))),
+			exportField("symbolName", input.SymbolName),
+			exportField("symbolContainerName", input.SymbolContainerName),
+			exportField("symbolKind", input.SymbolKind),
+		)
+		return fmt.Sprintf("```%s id=%s\n%s\n```", symbolBlockFenceInfo, id, strings.Join(fields, "\n")), nil
+	}
+	return "", errors.Errorf("invalid block type: %s", block.Type)
+}
+
+// fenceFor returns a backtick fence that is longer than any line of the text consisting
+// only of backticks, so that the text cannot close the fence early.
+func fenceFor(text string) string {
+	n := 3
+	for _, line := range strings.Split(text, "\n") {
+		if backtickLineRegex.MatchString(line) && len(line) >= n {
+			n = len(line) + 1
+		}
+	}
+	return strings.Repeat("`", n)
+}
+
+func exportField(key, value string) string {
+	return key + ": " + exportValue(value)
+}
+
+// exportValue quotes values that would otherwise not survive a round trip. Characters
+// that could end an HTML comment or a fence are escaped as well.
+func exportValue(value string) string {
+	if plainValueRegex.MatchString(value) && !strings.Contains(value, "-->") {
+		return value
+	}
+	quoted := strconv.Quote(value)
+	quoted = strings.ReplaceAll(quoted, ">", `\u003e`)
+	quoted = strings.ReplaceAll(quoted, "`", `\u0060`)
+	return quoted
+}
+
+func importValue(value string) (string, error) {
+	if strings.HasPrefix(value, `"`) {
+		return strconv.Unquote(value)
+	}
+	return value, nil
+}
+
+// ImportMarkdown parses a notebook exported with ExportMarkdown. The title is taken from
+// the leading "# " heading and is empty if there is none. Blocks without an ID are
+// assigned a new one, and the blocks are validated before they are returned.
+func ImportMarkdown(markdown string) (title string, blocks NotebookBlocks, err error) {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var pending []string
+	flushPending := func() {
+		text := strings.Trim(strings.Join(pending, "\n"), "\n")
+		pending = nil
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		blocks = append(blocks, NotebookBlock{
+			ID:            uuid.NewString(),
+			Type:          NotebookMarkdownBlockType,
+			MarkdownInput: &NotebookMarkdownBlockInput{Text: text},
+		})
+	}
+	hasTitle := false
+	hasContent := func() bool {
+		return len(blocks) > 0 || strings.TrimSpace(strings.Join(pending, "")) != ""
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if !hasTitle && !hasContent() && (line == "#" || strings.HasPrefix(line, "# ")) {
+			title, hasTitle = strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "), true
+			continue
+		}
+
+		if match := markdownBlockStartRegex.FindStringSubmatch(line); match != nil {
+			flushPending()
+			id, err := parseBlockID(match[1])
+			if err != nil {
+				return "", nil, errors.Wrapf(err, "line %d", i+1)
+			}
+			end := indexOfLine(lines, i+1, func(l string) bool { return l == markdownBlockEnd })
+			if end < 0 {
+				return "", nil, errors.Errorf("line %d: markdown block is not closed", i+1)
+			}
+			blocks = append(blocks, NotebookBlock{
+				ID:            id,
+				Type:          NotebookMarkdownBlockType,
+				MarkdownInput: &NotebookMarkdownBlockInput{Text: strings.Join(lines[i+1:end], "\n")},
+			})
+			i = end
+			continue
+		}
+
+		if match := fenceStartRegex.FindStringSubmatch(line); match != nil {
+			flushPending()
+			fence, info := match[1], match[2]
+			id, err := parseBlockID(match[3])
+			if err != nil {
+				return "", nil, errors.Wrapf(err, "line %d", i+1)
+			}
+			end := indexOfLine(lines, i+1, func(l string) bool { return backtickLineRegex.MatchString(l) && len(l) >= len(fence) })
+			if end < 0 {
+				return "", nil, errors.Errorf("line %d: %s block is not closed", i+1, info)
+			}
+			block, err := importFencedBlock(id, info, lines[i+1:end])
+			if err != nil {
+				return "", nil, errors.Wrapf(err, "line %d", i+1)
+			}
+			blocks = append(blocks, block)
+			i = end
+			continue
+		}
+
+		pending = append(pending, line)
+	}
+	flushPending()
+
+	if blocks == nil {
+		blocks = NotebookBlocks{}
+	}
+	if err := validateNotebookBlocks(blocks); err != nil {
+		return "", nil, err
+	}
+	return title, blocks, nil
+}
+
+func indexOfLine(lines []string, start int, match func(string) bool) int {
+	for i := start; i < len(lines); i++ {
+		if match(lines[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseBlockID returns the id attribute of a block directive, or a new ID if there is none.
+func parseBlockID(attributes string) (string, error) {
+	for _, match := range attributeRegex.FindAllStringSubmatch(attributes, -1) {
+		if match[1] != "id" {
+			continue
+		}
+		id, err := importValue(match[2])
+		if err != nil {
+			return "", errors.Wrap(err, "invalid block id")
+		}
+		return id, nil
+	}
+	return uuid.NewString(), nil
+}
+
+func importFencedBlock(id, info string, lines []string) (NotebookBlock, error) {
+	if info == queryBlockFenceInfo {
+		return NotebookBlock{
+			ID:         id,
+			Type:       NotebookQueryBlockType,
+			QueryInput: &NotebookQueryBlockInput{Text: strings.Join(lines, "\n")},
+		}, nil
+	}
+
+	fields := map[string]string{}
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return NotebookBlock{}, errors.Errorf("invalid %s block field: %q", info, line)
+		}
+		value, err := importValue(strings.TrimSpace(value))
+		if err != nil {
+			return NotebookBlock{}, errors.Wrapf(err, "invalid value of %s", key)
+		}
+		fields[strings.TrimSpace(key)] = value
+	}
+
+	fieldReader := blockFieldReader{fields: fields}
+	var block NotebookBlock
+	switch info {
+	case fileBlockFenceInfo:
+		input := &NotebookFileBlockInput{
+			RepositoryName: fieldReader.required("repositoryName"),
+			FilePath:       fieldReader.required("filePath"),
+			Revision:       fieldReader.optional("revision"),
+		}
+		startLine, endLine := fieldReader.optional("startLine"), fieldReader.optional("endLine")
+		if (startLine == nil) != (endLine == nil) {
+			return NotebookBlock{}, errors.New("file block line range needs both startLine and endLine")
+		}
+		if startLine != nil {
+			input.LineRange = &LineRange{StartLine: fieldReader.int32("startLine"), EndLine: fieldReader.int32("endLine")}
+		}
+		block = NotebookBlock{ID: id, Type: NotebookFileBlockType, FileInput: input}
+
+	case symbolBlockFenceInfo:
+		block = NotebookBlock{ID: id, Type: NotebookSymbolBlockType, SymbolInput: &NotebookSymbolBlockInput{
+			RepositoryName:      fieldReader.required("repositoryName"),
+			FilePath:            fieldReader.required("filePath"),
+			Revision:            fieldReader.optional("revision"),
+			LineContext:         fieldReader.int32("lineContext"),
+			SymbolName:          fieldReader.required("symbolName"),
+			SymbolContainerName: fieldReader.string("symbolContainerName"),
+			SymbolKind:          fieldReader.string("symbolKind"),
+		}}
+	}
+
+	if fieldReader.err != nil {
+		return NotebookBlock{}, fieldReader.err
+	}
+	for key := range fields {
+		if !fieldReader.read[key] {
+			return NotebookBlock{}, errors.Errorf("unknown %s block field: %s", info, key)
+		}
+	}
+	return block, nil
+}
+
+// blockFieldReader reads the input fields of a file or symbol block, and records the
+// first error.
+type blockFieldReader struct {
+	fields map[string]string
+	read   map[string]bool
+	err    error
+}
+
+func (r *blockFieldReader) optional(key string) *string {
+	if r.read == nil {
+		r.read = map[string]bool{}
+	}
+	r.read[key] = true
+	value, ok := r.fields[key]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+func (r *blockFieldReader) string(key string) string {
+	if value := r.optional(key); value != nil {
+		return *value
+	}
+	return ""
+}
+
+func (r *blockFieldReader) required(key string) string {
+	value := r.optional(key)
+	if (value == nil || *value == "") && r.err == nil {
+		r.err = errors.Errorf("missing required field %s", key)
+		return ""
+	}
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func (r *blockFieldReader) int32(key string) int32 {
+	value := r.string(key)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil && r.err == nil {
+		r.err = errors.Errorf("invalid %s: %q", key, value)
+	}
+	return int32(n)
+}
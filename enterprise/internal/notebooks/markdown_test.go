@@ -0,0 +1,103 @@
+package notebooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	revision, emptyRevision := "main", ""
+	blocks := NotebookBlocks{
+		{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Heading\n\n<!-- comment -->\n\n```go\nfunc main() {}\n```\n"}},
+		{ID: "2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "repo:a b"}},
+		{ID: "3", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "content:\"\n```\n\"\n"}},
+		{ID: "4", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{RepositoryName: "github.com/a/b", FilePath: "dir/file name.go", Revision: &revision, LineRange: &LineRange{StartLine: 1, EndLine: 10}}},
+		{ID: "5", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{RepositoryName: "github.com/a/b", FilePath: "README.md", Revision: &emptyRevision}},
+		{ID: "id with spaces --> and `", Type: NotebookSymbolBlockType, SymbolInput: &NotebookSymbolBlockInput{RepositoryName: "github.com/a/b", FilePath: "main.go", LineContext: 3, SymbolName: "main", SymbolKind: "FUNCTION"}},
+		{ID: "7", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: ""}},
+	}
+
+	markdown, err := ExportMarkdown("My notebook", blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title, gotBlocks, err := ImportMarkdown(markdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "My notebook" {
+		t.Fatalf("wanted title 'My notebook', got '%s'", title)
+	}
+	if diff := cmp.Diff(blocks, gotBlocks); diff != "" {
+		t.Fatalf("blocks mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestImportMarkdownWithoutDirectives(t *testing.T) {
+	title, blocks, err := ImportMarkdown("Intro text\n\n```sourcegraph\nrepo:a\n```\n\nOutro\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "" {
+		t.Fatalf("wanted empty title, got '%s'", title)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("wanted 3 blocks, got %d", len(blocks))
+	}
+	for i, want := range []string{"Intro text", "repo:a", "Outro"} {
+		var got string
+		if blocks[i].MarkdownInput != nil {
+			got = blocks[i].MarkdownInput.Text
+		} else {
+			got = blocks[i].QueryInput.Text
+		}
+		if got != want {
+			t.Fatalf("block %d: wanted '%s', got '%s'", i, want, got)
+		}
+		if blocks[i].ID == "" {
+			t.Fatalf("block %d: wanted generated id", i)
+		}
+	}
+}
+
+func TestImportMarkdownErrors(t *testing.T) {
+	tests := []struct {
+		markdown string
+		wantErr  string
+	}{
+		{markdown: "<!-- sourcegraph:markdown id=1 -->\ntext", wantErr: "line 1: markdown block is not closed"},
+		{markdown: "# T\n```sourcegraph id=1\nrepo:a", wantErr: "line 2: sourcegraph block is not closed"},
+		{markdown: "```sourcegraph:file id=1\nfilePath: a\n```", wantErr: "line 1: missing required field repositoryName"},
+		{markdown: "```sourcegraph:file id=1\nrepositoryName: a\nfilePath: b\nstartLine: 1\n```", wantErr: "line 1: file block line range needs both startLine and endLine"},
+		{markdown: "```sourcegraph:symbol id=1\nrepositoryName: a\nfilePath: b\nsymbolName: c\nlineContext: x\n```", wantErr: `line 1: invalid lineContext: "x"`},
+		{markdown: "```sourcegraph:symbol id=1\nrepositoryName: a\nfilePath: b\nsymbolName: c\ncolor: red\n```", wantErr: "line 1: unknown sourcegraph:symbol block field: color"},
+		{markdown: "```sourcegraph id=1\na\n```\n```sourcegraph id=1\nb\n```", wantErr: "duplicate block id found: 1"},
+		{markdown: "```sourcegraph:symbol id=1\nrepositoryName: a\nfilePath: b\nsymbolName: c\nlineContext: -1\n```", wantErr: "symbol block line context cannot be negative, block id: 1"},
+	}
+
+	for _, tt := range tests {
+		_, _, err := ImportMarkdown(tt.markdown)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		} else if err.Error() != tt.wantErr {
+			t.Fatalf("wanted '%s' error, got '%s'", tt.wantErr, err.Error())
+		}
+	}
+}
+
+func TestExportMarkdownErrors(t *testing.T) {
+	_, err := ExportMarkdown("a\nb", NotebookBlocks{})
+	if err == nil || !strings.Contains(err.Error(), "line breaks") {
+		t.Fatalf("expected line breaks error, got %v", err)
+	}
+
+	_, err = ExportMarkdown("title", NotebookBlocks{
+		{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "a\n<!-- /sourcegraph:markdown -->"}},
+	})
+	if err == nil || err.Error() != "block 1: markdown text cannot contain the end of block directive" {
+		t.Fatalf("expected end of block directive error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package notebooks
+
+// NotebookBlockSnapshotDiff describes how the results of a query block changed between
+// two runs of a notebook report.
+type NotebookBlockSnapshotDiff struct {
+	ResultCountDelta int
+	// QueryChanged is true if the query of the block was edited between the two runs,
+	// in which case the diff compares the results of different queries.
+	QueryChanged   bool
+	AddedMatches   []NotebookSnapshotMatch
+	RemovedMatches []NotebookSnapshotMatch
+}
+
+// Block returns the snapshot of the block with the given ID, or nil if the block was not
+// part of the run.
+func (s NotebookBlockSnapshots) Block(blockID string) *NotebookBlockSnapshot {
+	for i := range s {
+		if s[i].BlockID == blockID {
+			return &s[i]
+		}
+	}
+	return nil
+}
+
+// DiffBlockSnapshots compares the results of the same block from two runs. Matches are
+// identified by their URL. Only the top matches are stored in a snapshot, so matches
+// that moved in or out of the top matches are reported as added or removed.
+func DiffBlockSnapshots(previous, current NotebookBlockSnapshot) NotebookBlockSnapshotDiff {
+	diff := NotebookBlockSnapshotDiff{
+		ResultCountDelta: current.ResultCount - previous.ResultCount,
+		QueryChanged:     current.Query != previous.Query,
+	}
+
+	previousURLs := make(map[string]struct{}, len(previous.TopMatches))
+	for _, m := range previous.TopMatches {
+		previousURLs[m.URL] = struct{}{}
+	}
+	currentURLs := make(map[string]struct{}, len(current.TopMatches))
+	for _, m := range current.TopMatches {
+		currentURLs[m.URL] = struct{}{}
+		if _, ok := previousURLs[m.URL]; !ok {
+			diff.AddedMatches = append(diff.AddedMatches, m)
+		}
+	}
+	for _, m := range previous.TopMatches {
+		if _, ok := currentURLs[m.URL]; !ok {
+			diff.RemovedMatches = append(diff.RemovedMatches, m)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,66 @@
+package notebooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffBlockSnapshots(t *testing.T) {
+	a := NotebookSnapshotMatch{Repository: "r", Path: "a.go", URL: "/r/-/blob/a.go"}
+	b := NotebookSnapshotMatch{Repository: "r", Path: "b.go", URL: "/r/-/blob/b.go"}
+	c := NotebookSnapshotMatch{Repository: "r", Path: "c.go", URL: "/r/-/blob/c.go"}
+
+	tests := []struct {
+		name     string
+		previous NotebookBlockSnapshot
+		current  NotebookBlockSnapshot
+		want     NotebookBlockSnapshotDiff
+	}{
+		{
+			name:     "unchanged",
+			previous: NotebookBlockSnapshot{Query: "q", ResultCount: 2, TopMatches: []NotebookSnapshotMatch{a, b}},
+			current:  NotebookBlockSnapshot{Query: "q", ResultCount: 2, TopMatches: []NotebookSnapshotMatch{b, a}},
+			want:     NotebookBlockSnapshotDiff{},
+		},
+		{
+			name:     "added and removed matches",
+			previous: NotebookBlockSnapshot{Query: "q", ResultCount: 2, TopMatches: []NotebookSnapshotMatch{a, b}},
+			current:  NotebookBlockSnapshot{Query: "q", ResultCount: 5, TopMatches: []NotebookSnapshotMatch{b, c}},
+			want: NotebookBlockSnapshotDiff{
+				ResultCountDelta: 3,
+				AddedMatches:     []NotebookSnapshotMatch{c},
+				RemovedMatches:   []NotebookSnapshotMatch{a},
+			},
+		},
+		{
+			name:     "query changed",
+			previous: NotebookBlockSnapshot{Query: "q", ResultCount: 1, TopMatches: []NotebookSnapshotMatch{a}},
+			current:  NotebookBlockSnapshot{Query: "q2", ResultCount: 0},
+			want: NotebookBlockSnapshotDiff{
+				ResultCountDelta: -1,
+				QueryChanged:     true,
+				RemovedMatches:   []NotebookSnapshotMatch{a},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffBlockSnapshots(tt.previous, tt.current)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotebookBlockSnapshotsBlock(t *testing.T) {
+	snapshots := NotebookBlockSnapshots{{BlockID: "1", Query: "a"}, {BlockID: "2", Query: "b"}}
+	if got := snapshots.Block("2"); got == nil || got.Query != "b" {
+		t.Fatalf("expected block 2, got %+v", got)
+	}
+	if got := snapshots.Block("3"); got != nil {
+		t.Fatalf("expected no block, got %+v", got)
+	}
+}
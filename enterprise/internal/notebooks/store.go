@@ -6,7 +6,9 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 
@@ -20,6 +22,8 @@ import (
 
 var ErrNotebookNotFound = errors.New("notebook not found")
 var ErrNotebookStarNotFound = errors.New("notebook star not found")
+var ErrNotebookReportScheduleNotFound = errors.New("notebook report schedule not found")
+var ErrNotebookReportSnapshotNotFound = errors.New("notebook report snapshot not found")
 
 type NotebooksOrderByOption uint8
 
@@ -40,6 +44,11 @@ type ListNotebookStarsPageOptions struct {
 	After int64
 }
 
+type ListNotebookReportSnapshotsPageOptions struct {
+	First int32
+	After int64
+}
+
 type ListNotebooksOptions struct {
 	Query             string
 	CreatorUserID     int32
@@ -62,6 +71,18 @@ func (blocks *NotebookBlocks) Scan(value any) error {
 	return json.Unmarshal(b, &blocks)
 }
 
+func (snapshots NotebookBlockSnapshots) Value() (driver.Value, error) {
+	return json.Marshal(snapshots)
+}
+
+func (snapshots *NotebookBlockSnapshots) Scan(value any) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &snapshots)
+}
+
 func Notebooks(db database.DB) NotebooksStore {
 	store := basestore.NewWithHandle(db.Handle())
 	return &notebooksStore{store}
@@ -81,6 +102,18 @@ type NotebooksStore interface {
 	DeleteNotebookStar(ctx context.Context, notebookID int64, userID int32) error
 	ListNotebookStars(ctx context.Context, pageOpts ListNotebookStarsPageOptions, notebookID int64) ([]*NotebookStar, error)
 	CountNotebookStars(ctx context.Context, notebookID int64) (int64, error)
+
+	GetNotebookReportSchedule(ctx context.Context, notebookID int64) (*NotebookReportSchedule, error)
+	UpsertNotebookReportSchedule(ctx context.Context, schedule *NotebookReportSchedule) (*NotebookReportSchedule, error)
+	DeleteNotebookReportSchedule(ctx context.Context, notebookID int64) error
+	ListDueNotebookReportSchedules(ctx context.Context, now time.Time, limit int) ([]*NotebookReportSchedule, error)
+	MarkNotebookReportScheduleRun(ctx context.Context, scheduleID int64, ranAt, nextRunAt time.Time) error
+
+	CreateNotebookReportSnapshot(ctx context.Context, notebookID int64, userID int32, blocks NotebookBlockSnapshots) (*NotebookReportSnapshot, error)
+	GetPreviousNotebookReportSnapshot(ctx context.Context, notebookID int64, userID int32, snapshotID int64) (*NotebookReportSnapshot, error)
+	ListNotebookReportSnapshots(ctx context.Context, pageOpts ListNotebookReportSnapshotsPageOptions, notebookID int64, userID int32) ([]*NotebookReportSnapshot, error)
+	CountNotebookReportSnapshots(ctx context.Context, notebookID int64, userID int32) (int64, error)
+	DeleteOldNotebookReportSnapshots(ctx context.Context, notebookID int64, keep int) error
 }
 
 type notebooksStore struct {
@@ -479,3 +512,217 @@ func (s *notebooksStore) CountNotebookStars(ctx context.Context, notebookID int6
 	}
 	return count, nil
 }
+
+var notebookReportScheduleColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("notebook_id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("frequency"),
+	sqlf.Sprintf("send_email"),
+	sqlf.Sprintf("next_run_at"),
+	sqlf.Sprintf("last_run_at"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+func scanNotebookReportSchedule(scanner dbutil.Scanner) (*NotebookReportSchedule, error) {
+	s := &NotebookReportSchedule{}
+	err := scanner.Scan(
+		&s.ID,
+		&s.NotebookID,
+		&s.UserID,
+		&s.Frequency,
+		&s.SendEmail,
+		&s.NextRunAt,
+		&s.LastRunAt,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+const getNotebookReportScheduleFmtStr = `SELECT %s FROM notebook_report_schedules WHERE notebook_id = %d`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) GetNotebookReportSchedule(ctx context.Context, notebookID int64) (*NotebookReportSchedule, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getNotebookReportScheduleFmtStr, sqlf.Join(notebookReportScheduleColumns, ","), notebookID))
+	schedule, err := scanNotebookReportSchedule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotebookReportScheduleNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+const upsertNotebookReportScheduleFmtStr = `
+INSERT INTO notebook_report_schedules (notebook_id, user_id, frequency, send_email, next_run_at)
+VALUES (%d, %d, %s, %s, %s)
+ON CONFLICT (notebook_id) DO UPDATE SET
+	user_id = EXCLUDED.user_id,
+	frequency = EXCLUDED.frequency,
+	send_email = EXCLUDED.send_email,
+	next_run_at = EXCLUDED.next_run_at,
+	updated_at = now()
+RETURNING %s
+`
+
+// UpsertNotebookReportSchedule creates the report schedule of a notebook, or replaces
+// the existing one.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func (s *notebooksStore) UpsertNotebookReportSchedule(ctx context.Context, schedule *NotebookReportSchedule) (*NotebookReportSchedule, error) {
+	row := s.QueryRow(
+		ctx,
+		sqlf.Sprintf(
+			upsertNotebookReportScheduleFmtStr,
+			schedule.NotebookID,
+			schedule.UserID,
+			schedule.Frequency,
+			schedule.SendEmail,
+			schedule.NextRunAt,
+			sqlf.Join(notebookReportScheduleColumns, ","),
+		),
+	)
+	return scanNotebookReportSchedule(row)
+}
+
+const deleteNotebookReportScheduleFmtStr = `DELETE FROM notebook_report_schedules WHERE notebook_id = %d`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func (s *notebooksStore) DeleteNotebookReportSchedule(ctx context.Context, notebookID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteNotebookReportScheduleFmtStr, notebookID))
+}
+
+const listDueNotebookReportSchedulesFmtStr = `
+SELECT %s
+FROM notebook_report_schedules
+WHERE next_run_at <= %s
+ORDER BY next_run_at ASC
+LIMIT %d
+`
+
+// ListDueNotebookReportSchedules returns the schedules that are due to run at the given
+// time, starting with the ones that are overdue the longest.
+//
+// 🚨 SECURITY: This bypasses notebook permission checks and must only be used by background jobs.
+func (s *notebooksStore) ListDueNotebookReportSchedules(ctx context.Context, now time.Time, limit int) ([]*NotebookReportSchedule, error) {
+	return basestore.NewSliceScanner(scanNotebookReportSchedule)(s.Query(ctx, sqlf.Sprintf(
+		listDueNotebookReportSchedulesFmtStr,
+		sqlf.Join(notebookReportScheduleColumns, ","),
+		now,
+		limit,
+	)))
+}
+
+const markNotebookReportScheduleRunFmtStr = `UPDATE notebook_report_schedules SET last_run_at = %s, next_run_at = %s WHERE id = %d`
+
+// 🚨 SECURITY: This bypasses notebook permission checks and must only be used by background jobs.
+func (s *notebooksStore) MarkNotebookReportScheduleRun(ctx context.Context, scheduleID int64, ranAt, nextRunAt time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(markNotebookReportScheduleRunFmtStr, ranAt, nextRunAt, scheduleID))
+}
+
+const notebookReportSnapshotColumnsFmtStr = `id, notebook_id, user_id, blocks, created_at`
+
+func scanNotebookReportSnapshot(scanner dbutil.Scanner) (*NotebookReportSnapshot, error) {
+	snapshot := &NotebookReportSnapshot{}
+	err := scanner.Scan(&snapshot.ID, &snapshot.NotebookID, &snapshot.UserID, &snapshot.Blocks, &snapshot.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+const insertNotebookReportSnapshotFmtStr = `
+INSERT INTO notebook_report_snapshots (notebook_id, user_id, blocks) VALUES (%d, %d, %s)
+RETURNING ` + notebookReportSnapshotColumnsFmtStr
+
+// 🚨 SECURITY: This bypasses notebook permission checks and must only be used by background jobs.
+func (s *notebooksStore) CreateNotebookReportSnapshot(ctx context.Context, notebookID int64, userID int32, blocks NotebookBlockSnapshots) (*NotebookReportSnapshot, error) {
+	if blocks == nil {
+		blocks = NotebookBlockSnapshots{}
+	}
+	row := s.QueryRow(ctx, sqlf.Sprintf(insertNotebookReportSnapshotFmtStr, notebookID, userID, blocks))
+	return scanNotebookReportSnapshot(row)
+}
+
+const getPreviousNotebookReportSnapshotFmtStr = `
+SELECT ` + notebookReportSnapshotColumnsFmtStr + `
+FROM notebook_report_snapshots
+WHERE notebook_id = %d AND user_id = %d AND id < %d
+ORDER BY id DESC
+LIMIT 1
+`
+
+// GetPreviousNotebookReportSnapshot returns the snapshot of the given user that was taken
+// right before the snapshot with the given ID. Pass 0 as snapshotID to get the latest snapshot.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook, and
+// is the given user.
+func (s *notebooksStore) GetPreviousNotebookReportSnapshot(ctx context.Context, notebookID int64, userID int32, snapshotID int64) (*NotebookReportSnapshot, error) {
+	if snapshotID == 0 {
+		snapshotID = math.MaxInt64
+	}
+	row := s.QueryRow(ctx, sqlf.Sprintf(getPreviousNotebookReportSnapshotFmtStr, notebookID, userID, snapshotID))
+	snapshot, err := scanNotebookReportSnapshot(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotebookReportSnapshotNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+const listNotebookReportSnapshotsFmtStr = `
+SELECT ` + notebookReportSnapshotColumnsFmtStr + `
+FROM notebook_report_snapshots
+WHERE notebook_id = %d AND user_id = %d
+ORDER BY id DESC
+LIMIT %d
+OFFSET %d
+`
+
+// ListNotebookReportSnapshots returns the snapshots of a notebook that were run with the
+// permissions of the given user, newest first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook, and
+// is the given user.
+func (s *notebooksStore) ListNotebookReportSnapshots(ctx context.Context, pageOpts ListNotebookReportSnapshotsPageOptions, notebookID int64, userID int32) ([]*NotebookReportSnapshot, error) {
+	return basestore.NewSliceScanner(scanNotebookReportSnapshot)(s.Query(ctx, sqlf.Sprintf(
+		listNotebookReportSnapshotsFmtStr,
+		notebookID,
+		userID,
+		pageOpts.First,
+		pageOpts.After,
+	)))
+}
+
+const countNotebookReportSnapshotsFmtStr = `SELECT COUNT(*) FROM notebook_report_snapshots WHERE notebook_id = %d AND user_id = %d`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook, and
+// is the given user.
+func (s *notebooksStore) CountNotebookReportSnapshots(ctx context.Context, notebookID int64, userID int32) (int64, error) {
+	var count int64
+	err := s.QueryRow(ctx, sqlf.Sprintf(countNotebookReportSnapshotsFmtStr, notebookID, userID)).Scan(&count)
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+const deleteOldNotebookReportSnapshotsFmtStr = `
+DELETE FROM notebook_report_snapshots
+WHERE notebook_id = %d AND id NOT IN (
+	SELECT id FROM notebook_report_snapshots WHERE notebook_id = %d ORDER BY id DESC LIMIT %d
+)
+`
+
+// DeleteOldNotebookReportSnapshots deletes all but the newest keep snapshots of a notebook.
+//
+// 🚨 SECURITY: This bypasses notebook permission checks and must only be used by background jobs.
+func (s *notebooksStore) DeleteOldNotebookReportSnapshots(ctx context.Context, notebookID int64, keep int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteOldNotebookReportSnapshotsFmtStr, notebookID, notebookID, keep))
+}
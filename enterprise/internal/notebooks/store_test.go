@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -679,3 +680,139 @@ func TestCreatingAndDeletingNotebookStars(t *testing.T) {
 		t.Errorf("expected non-nil error, got nil")
 	}
 }
+
+func TestNotebookReports(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	n := Notebooks(db)
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	createdNotebooks, err := createNotebooks(ctx, n, []*Notebook{notebookByUser(&Notebook{Title: "Notebook", Blocks: NotebookBlocks{}}, user.ID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notebookID := createdNotebooks[0].ID
+
+	if _, err := n.GetNotebookReportSchedule(ctx, notebookID); !errors.Is(err, ErrNotebookReportScheduleNotFound) {
+		t.Fatalf("expected schedule not found error, got %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	schedule, err := n.UpsertNotebookReportSchedule(ctx, &NotebookReportSchedule{
+		NotebookID: notebookID,
+		UserID:     user.ID,
+		Frequency:  NotebookReportFrequencyDaily,
+		NextRunAt:  now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedule.Frequency = NotebookReportFrequencyWeekly
+	schedule.SendEmail = true
+	updated, err := n.UpsertNotebookReportSchedule(ctx, schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ID != schedule.ID || updated.Frequency != NotebookReportFrequencyWeekly || !updated.SendEmail {
+		t.Fatalf("expected schedule to be updated in place, got %+v", updated)
+	}
+
+	due, err := n.ListDueNotebookReportSchedules(ctx, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].ID != schedule.ID {
+		t.Fatalf("expected the schedule to be due, got %+v", due)
+	}
+
+	if err := n.MarkNotebookReportScheduleRun(ctx, schedule.ID, now, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	due, err = n.ListDueNotebookReportSchedules(ctx, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("expected no due schedules, got %+v", due)
+	}
+
+	var snapshotIDs []int64
+	for _, count := range []int{1, 2, 3} {
+		snapshot, err := n.CreateNotebookReportSnapshot(ctx, notebookID, user.ID, NotebookBlockSnapshots{{BlockID: "1", Query: "q", ResultCount: count}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		snapshotIDs = append(snapshotIDs, snapshot.ID)
+	}
+
+	previous, err := n.GetPreviousNotebookReportSnapshot(ctx, notebookID, user.ID, snapshotIDs[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous.ID != snapshotIDs[1] || previous.Blocks[0].ResultCount != 2 {
+		t.Fatalf("unexpected previous snapshot %+v", previous)
+	}
+	if _, err := n.GetPreviousNotebookReportSnapshot(ctx, notebookID, user.ID, snapshotIDs[0]); !errors.Is(err, ErrNotebookReportSnapshotNotFound) {
+		t.Fatalf("expected snapshot not found error, got %v", err)
+	}
+
+	if err := n.DeleteOldNotebookReportSnapshots(ctx, notebookID, 2); err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := n.ListNotebookReportSnapshots(ctx, ListNotebookReportSnapshotsPageOptions{First: 10}, notebookID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || snapshots[0].ID != snapshotIDs[2] || snapshots[1].ID != snapshotIDs[1] {
+		t.Fatalf("expected the two newest snapshots, got %+v", snapshots)
+	}
+	count, err := n.CountNotebookReportSnapshots(ctx, notebookID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", count)
+	}
+
+	// Snapshots run with the permissions of another user are kept apart.
+	otherUser, err := db.Users().Create(ctx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSnapshot, err := n.CreateNotebookReportSnapshot(ctx, notebookID, otherUser.ID, NotebookBlockSnapshots{{BlockID: "1", Query: "q", ResultCount: 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherSnapshot.UserID != otherUser.ID {
+		t.Fatalf("expected snapshot of user %d, got %+v", otherUser.ID, otherSnapshot)
+	}
+	if previous, err := n.GetPreviousNotebookReportSnapshot(ctx, notebookID, user.ID, 0); err != nil || previous.ID != snapshotIDs[2] {
+		t.Fatalf("expected the newest snapshot of the user, got %+v, %v", previous, err)
+	}
+	if _, err := n.GetPreviousNotebookReportSnapshot(ctx, notebookID, otherUser.ID, otherSnapshot.ID); !errors.Is(err, ErrNotebookReportSnapshotNotFound) {
+		t.Fatalf("expected snapshot not found error, got %v", err)
+	}
+	if count, err := n.CountNotebookReportSnapshots(ctx, notebookID, user.ID); err != nil || count != 2 {
+		t.Fatalf("expected 2 snapshots of the user, got %d, %v", count, err)
+	}
+	snapshots, err = n.ListNotebookReportSnapshots(ctx, ListNotebookReportSnapshotsPageOptions{First: 10}, notebookID, otherUser.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].ID != otherSnapshot.ID {
+		t.Fatalf("expected only the snapshot of the other user, got %+v", snapshots)
+	}
+
+	if err := n.DeleteNotebookReportSchedule(ctx, notebookID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.GetNotebookReportSchedule(ctx, notebookID); !errors.Is(err, ErrNotebookReportScheduleNotFound) {
+		t.Fatalf("expected schedule not found error, got %v", err)
+	}
+}
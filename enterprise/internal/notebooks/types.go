@@ -75,3 +75,68 @@ type NotebookStar struct {
 	UserID     int32
 	CreatedAt  time.Time
 }
+
+type NotebookReportFrequency string
+
+const (
+	NotebookReportFrequencyDaily  NotebookReportFrequency = "daily"
+	NotebookReportFrequencyWeekly NotebookReportFrequency = "weekly"
+)
+
+// Interval returns the time between two consecutive runs of a report.
+func (f NotebookReportFrequency) Interval() time.Duration {
+	if f == NotebookReportFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// NotebookReportSchedule configures a notebook to have its query blocks run server-side
+// on a schedule.
+type NotebookReportSchedule struct {
+	ID         int64
+	NotebookID int64
+	// UserID is the user that created the schedule. Query blocks are run with the
+	// permissions of this user, and report emails are sent to them.
+	UserID    int32
+	Frequency NotebookReportFrequency
+	SendEmail bool
+	NextRunAt time.Time
+	LastRunAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NotebookSnapshotMatch is a single search result stored in a snapshot.
+type NotebookSnapshotMatch struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path,omitempty"`
+	// Label describes the match, e.g. the first matched line for content matches or the
+	// subject for commit matches.
+	Label string `json:"label,omitempty"`
+	URL   string `json:"url"`
+}
+
+// NotebookBlockSnapshot holds the results of running a single query block.
+type NotebookBlockSnapshot struct {
+	BlockID     string                  `json:"blockId"`
+	Query       string                  `json:"query"`
+	ResultCount int                     `json:"resultCount"`
+	LimitHit    bool                    `json:"limitHit"`
+	TopMatches  []NotebookSnapshotMatch `json:"topMatches"`
+	Error       string                  `json:"error,omitempty"`
+}
+
+type NotebookBlockSnapshots []NotebookBlockSnapshot
+
+// NotebookReportSnapshot holds the results of all query blocks of a notebook from a
+// single scheduled run.
+type NotebookReportSnapshot struct {
+	ID         int64
+	NotebookID int64
+	// UserID is the user whose permissions the query blocks were run with. Only this user
+	// can view the snapshot.
+	UserID    int32
+	Blocks    NotebookBlockSnapshots
+	CreatedAt time.Time
+}
@@ -167,6 +167,44 @@ func mkIsMatch(args search.SymbolsParameters) (func(string) bool, error) {
 	}
 }
 
+// mkIsPathMatch returns a function that reports whether a path satisfies the include and
+// exclude patterns of the given search.
+func mkIsPathMatch(args search.SymbolsParameters) (func(string) bool, error) {
+	compile := func(expr string) (*regexp.Regexp, error) {
+		if !args.IsCaseSensitive {
+			expr = "(?i)" + expr
+		}
+		return regexp.Compile(expr)
+	}
+
+	includes := make([]*regexp.Regexp, 0, len(args.IncludePatterns))
+	for _, includePattern := range args.IncludePatterns {
+		regex, err := compile(includePattern)
+		if err != nil {
+			return nil, err
+		}
+		includes = append(includes, regex)
+	}
+
+	var exclude *regexp.Regexp
+	if args.ExcludePattern != "" {
+		regex, err := compile(args.ExcludePattern)
+		if err != nil {
+			return nil, err
+		}
+		exclude = regex
+	}
+
+	return func(path string) bool {
+		for _, include := range includes {
+			if !include.MatchString(path) {
+				return false
+			}
+		}
+		return exclude == nil || !exclude.MatchString(path)
+	}, nil
+}
+
 func (s *Service) emitIndexRequest(rc repoCommit) (chan struct{}, error) {
 	key := fmt.Sprintf("%s@%s", rc.repo, rc.commit)
 
@@ -254,6 +292,11 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 		return nil, err
 	}
 
+	isPathMatch, err := mkIsPathMatch(args)
+	if err != nil {
+		return nil, err
+	}
+
 	paths := goset.NewSet[string]()
 	for rows.Next() {
 		var path string
@@ -261,7 +304,10 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 		if err != nil {
 			return nil, errors.Wrap(err, "Search: Scan")
 		}
-		paths.Add(path)
+		// The database only pre-filters paths when a pattern can't be evaluated by Postgres.
+		if isPathMatch(path) {
+			paths.Add(path)
+		}
 	}
 
 	stopErr := errors.New("stop iterating")
@@ -422,20 +468,25 @@ func sqlEscapeQuotes(s string) string {
 }
 
 func convertSearchArgsToSqlQuery(args search.SymbolsParameters) *sqlf.Query {
-	// TODO support non regexp queries once the frontend supports it.
-
 	conjunctOrNils := []*sqlf.Query{}
 
 	// Query
-	conjunctOrNils = append(conjunctOrNils, regexMatch(nameConditions, args.Query, args.IsCaseSensitive))
+	if args.IsRegExp {
+		conjunctOrNils = append(conjunctOrNils, regexMatch(nameConditions, args.Query, args.IsCaseSensitive))
+	} else {
+		conjunctOrNils = append(conjunctOrNils, substringMatch(nameConditions, args.Query, args.IsCaseSensitive))
+	}
 
 	// IncludePatterns
 	for _, includePattern := range args.IncludePatterns {
 		conjunctOrNils = append(conjunctOrNils, regexMatch(pathConditions, includePattern, args.IsCaseSensitive))
 	}
 
-	// ExcludePattern
-	conjunctOrNils = append(conjunctOrNils, negate(regexMatch(pathConditions, args.ExcludePattern, args.IsCaseSensitive)))
+	// ExcludePattern. Only exact conditions can be negated, so an exclusion that Postgres can't
+	// evaluate is left to the filtering in Go.
+	if isPortableRegex(args.ExcludePattern) {
+		conjunctOrNils = append(conjunctOrNils, negate(regexMatch(pathConditions, args.ExcludePattern, args.IsCaseSensitive)))
+	}
 
 	// Drop nils
 	conjuncts := []*sqlf.Query{}
@@ -454,14 +505,16 @@ func convertSearchArgsToSqlQuery(args search.SymbolsParameters) *sqlf.Query {
 
 // Conditions specifies how to construct query clauses depending on the regex kind.
 type Conditions struct {
-	regex    QueryFunc
-	regexI   QueryFunc
-	exact    QueryFunc
-	exactI   QueryFunc
-	prefix   QueryFunc
-	prefixI  QueryFunc
-	fileExt  QueryNFunc
-	fileExtI QueryNFunc
+	regex      QueryFunc
+	regexI     QueryFunc
+	exact      QueryFunc
+	exactI     QueryFunc
+	prefix     QueryFunc
+	prefixI    QueryFunc
+	substring  QueryFunc
+	substringI QueryFunc
+	fileExt    QueryNFunc
+	fileExtI   QueryNFunc
 }
 
 // Returns a SQL query for the given value.
@@ -477,10 +530,12 @@ var nameConditions = Conditions{
 	exactI: func(v string) *sqlf.Query {
 		return sqlf.Sprintf("ARRAY[%s] && singleton(lower(name))", strings.ToLower(v))
 	},
-	prefix:   nil,
-	prefixI:  nil,
-	fileExt:  nil,
-	fileExtI: nil,
+	prefix:     func(v string) *sqlf.Query { return sqlf.Sprintf("name LIKE %s", escapeLike(v)+"%") },
+	prefixI:    func(v string) *sqlf.Query { return sqlf.Sprintf("name ILIKE %s", escapeLike(v)+"%") },
+	substring:  func(v string) *sqlf.Query { return sqlf.Sprintf("name LIKE %s", "%"+escapeLike(v)+"%") },
+	substringI: func(v string) *sqlf.Query { return sqlf.Sprintf("name ILIKE %s", "%"+escapeLike(v)+"%") },
+	fileExt:    nil,
+	fileExtI:   nil,
 }
 
 var pathConditions = Conditions{
@@ -494,6 +549,8 @@ var pathConditions = Conditions{
 	prefixI: func(v string) *sqlf.Query {
 		return sqlf.Sprintf("ARRAY[%s] && path_prefixes(lower(path))", strings.ToLower(v))
 	},
+	substring:  func(v string) *sqlf.Query { return sqlf.Sprintf("path LIKE %s", "%"+escapeLike(v)+"%") },
+	substringI: func(v string) *sqlf.Query { return sqlf.Sprintf("path ILIKE %s", "%"+escapeLike(v)+"%") },
 	fileExt: func(vs []string) *sqlf.Query {
 		return sqlf.Sprintf("%s && singleton(get_file_extension(path))", pg.Array(vs))
 	},
@@ -502,6 +559,11 @@ var pathConditions = Conditions{
 	},
 }
 
+// escapeLike escapes the wildcards of a LIKE pattern using the default escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 func lowerAll(strs []string) []string {
 	lowers := []string{}
 	for _, s := range strs {
@@ -545,6 +607,12 @@ func regexMatch(conditions Conditions, regex string, isCaseSensitive bool) *sqlf
 		}
 	}
 
+	// Postgres doesn't understand every RE2 construct, so only require the literal fragments of
+	// such a regex in the database. Candidates are matched exactly in Go afterwards.
+	if !isPortableRegex(regex) {
+		return literalFragmentsMatch(conditions, regex, isCaseSensitive)
+	}
+
 	// Regex match
 	if isCaseSensitive && conditions.regex != nil {
 		return conditions.regex(regex)
@@ -557,6 +625,103 @@ func regexMatch(conditions Conditions, regex string, isCaseSensitive bool) *sqlf
 	return nil
 }
 
+// substringMatch returns a SQL query matching values that contain the given literal.
+func substringMatch(conditions Conditions, literal string, isCaseSensitive bool) *sqlf.Query {
+	if literal == "" {
+		return nil
+	}
+
+	if isCaseSensitive && conditions.substring != nil {
+		return conditions.substring(literal)
+	}
+	if !isCaseSensitive && conditions.substringI != nil {
+		return conditions.substringI(literal)
+	}
+
+	return nil
+}
+
+// literalFragmentsMatch returns a SQL query matching values that contain every literal
+// fragment required by the given regex. The query is a necessary but not a sufficient
+// condition for the regex to match, and returns nil if no fragment is required.
+func literalFragmentsMatch(conditions Conditions, regex string, isCaseSensitive bool) *sqlf.Query {
+	parsed, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+
+	conjuncts := []*sqlf.Query{}
+	for _, fragment := range requiredLiterals(parsed.Simplify()) {
+		if condition := substringMatch(conditions, fragment.value, isCaseSensitive && !fragment.foldCase); condition != nil {
+			conjuncts = append(conjuncts, condition)
+		}
+	}
+
+	if len(conjuncts) == 0 {
+		return nil
+	}
+
+	return sqlf.Sprintf("(%s)", sqlf.Join(conjuncts, "AND"))
+}
+
+type literalFragment struct {
+	value    string
+	foldCase bool
+}
+
+// requiredLiterals returns the literals that every match of the given regex must contain.
+func requiredLiterals(regex *syntax.Regexp) []literalFragment {
+	switch regex.Op {
+	case syntax.OpLiteral:
+		if regex.Flags&syntax.FoldCase != 0 {
+			return []literalFragment{{value: strings.ToLower(string(regex.Rune)), foldCase: true}}
+		}
+		return []literalFragment{{value: string(regex.Rune)}}
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(regex.Sub[0])
+
+	case syntax.OpRepeat:
+		if regex.Min > 0 {
+			return requiredLiterals(regex.Sub[0])
+		}
+
+	case syntax.OpConcat:
+		fragments := []literalFragment{}
+		for _, sub := range regex.Sub {
+			fragments = append(fragments, requiredLiterals(sub)...)
+		}
+		return fragments
+	}
+
+	return nil
+}
+
+// isPortableRegex returns true if the given RE2 regex has the same meaning when evaluated
+// by Postgres. Inline flags, named groups, Unicode classes, quoting and word boundaries are
+// either spelled differently or unsupported by Postgres.
+func isPortableRegex(expr string) bool {
+	if _, err := syntax.Parse(expr, syntax.Perl); err != nil {
+		return false
+	}
+
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			if i+1 < len(expr) && strings.ContainsRune("bBpPQEzC", rune(expr[i+1])) {
+				return false
+			}
+			i++
+		case '(':
+			if i+2 < len(expr) && expr[i+1] == '?' && expr[i+2] != ':' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 // isLiteralEquality returns true if the given regex matches literal strings exactly.
 // If so, this function returns true along with the literal search query. If not, this
 // function returns false.
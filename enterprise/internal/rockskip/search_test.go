@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
 )
 
 func TestIsFileExtensionMatch(t *testing.T) {
@@ -68,3 +71,118 @@ func TestIsLiteralPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestIsPortableRegex(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`foo`, true},
+		{`^foo.*bar$`, true},
+		{`(?:foo|bar)baz`, true},
+		{`foo\\bar`, true},
+		{`\bfoo\b`, false},
+		{`(?i)foo`, false},
+		{`(?P<name>foo)`, false},
+		{`\pLfoo`, false},
+		{`\Qfoo.bar\E`, false},
+		{`foo(`, false},
+	}
+
+	for _, test := range tests {
+		if got := isPortableRegex(test.expr); got != test.want {
+			t.Errorf("isPortableRegex(%q) = %v, want %v", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestConvertSearchArgsToSqlQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    search.SymbolsParameters
+		query   string
+		sqlArgs []any
+	}{
+		{
+			name:    "literal",
+			args:    search.SymbolsParameters{Query: "foo_bar"},
+			query:   "name ILIKE $1",
+			sqlArgs: []any{`%foo\_bar%`},
+		},
+		{
+			name:    "prefix",
+			args:    search.SymbolsParameters{Query: "^Foo", IsRegExp: true, IsCaseSensitive: true},
+			query:   "name LIKE $1",
+			sqlArgs: []any{"Foo%"},
+		},
+		{
+			name:    "portable regex",
+			args:    search.SymbolsParameters{Query: "foo.*bar", IsRegExp: true},
+			query:   "name ~* $1",
+			sqlArgs: []any{"foo.*bar"},
+		},
+		{
+			name:    "non-portable regex",
+			args:    search.SymbolsParameters{Query: `\bfoo\w+bar\b`, IsRegExp: true, IsCaseSensitive: true},
+			query:   "(name LIKE $1 AND name LIKE $2)",
+			sqlArgs: []any{"%foo%", "%bar%"},
+		},
+		{
+			name:    "non-portable regex without literals",
+			args:    search.SymbolsParameters{Query: `\b\w+\b`, IsRegExp: true},
+			query:   "TRUE",
+			sqlArgs: []any{},
+		},
+		{
+			name: "paths",
+			args: search.SymbolsParameters{
+				Query:           "^foo$",
+				IsRegExp:        true,
+				IncludePatterns: []string{`^cmd/`, `(?i)server`},
+				ExcludePattern:  `_test\.go$`,
+			},
+			query:   "ARRAY[$1] && singleton(lower(name)) AND ARRAY[$2] && path_prefixes(lower(path)) AND (path ILIKE $3) AND NOT path ~* $4",
+			sqlArgs: []any{"foo", "cmd/", "%server%", `_test\.go$`},
+		},
+		{
+			name:    "non-portable exclude",
+			args:    search.SymbolsParameters{Query: "^foo$", IsRegExp: true, ExcludePattern: `\btest\b`},
+			query:   "ARRAY[$1] && singleton(lower(name))",
+			sqlArgs: []any{"foo"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := convertSearchArgsToSqlQuery(test.args)
+			if diff := cmp.Diff(test.query, q.Query(sqlf.PostgresBindVar)); diff != "" {
+				t.Errorf("unexpected query (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.sqlArgs, q.Args()); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMkIsPathMatch(t *testing.T) {
+	isPathMatch, err := mkIsPathMatch(search.SymbolsParameters{
+		IncludePatterns: []string{`^cmd/`, `\bserver\b`},
+		ExcludePattern:  `_test\.go$`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]bool{
+		"cmd/server/main.go":      true,
+		"CMD/Server/main.go":      true,
+		"cmd/servers/main.go":     false,
+		"internal/server/main.go": false,
+		"cmd/server/main_test.go": false,
+	} {
+		if got := isPathMatch(path); got != want {
+			t.Errorf("isPathMatch(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+	"github.com/elimity-com/scim/optional"
+	"github.com/elimity-com/scim/schema"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	AttrMembers     = "members"
+	AttrMemberValue = "value"
+)
+
+// GroupResourceHandler implements the scim.ResourceHandler interface for groups.
+// SCIM groups are mapped to Sourcegraph teams, and their members to team members.
+type GroupResourceHandler struct {
+	ctx            context.Context
+	observationCtx *observation.Context
+	db             database.DB
+	coreSchema     schema.Schema
+}
+
+// NewGroupResourceHandler returns a new GroupResourceHandler.
+func NewGroupResourceHandler(ctx context.Context, observationCtx *observation.Context, db database.DB) *GroupResourceHandler {
+	return &GroupResourceHandler{
+		ctx:            ctx,
+		observationCtx: observationCtx,
+		db:             db,
+		coreSchema:     schema.CoreGroupSchema(),
+	}
+}
+
+// createGroupResourceType creates a SCIM resource type for groups.
+func createGroupResourceType(groupResourceHandler *GroupResourceHandler) scim.ResourceType {
+	return scim.ResourceType{
+		ID:          optional.NewString("Group"),
+		Name:        "Group",
+		Endpoint:    "/Groups",
+		Description: optional.NewString("Group"),
+		Schema:      groupResourceHandler.coreSchema,
+		Handler:     groupResourceHandler,
+	}
+}
+
+// getTeamFromDB returns the team with the given ID.
+// When it fails, it returns an error that's safe to return to the client as a SCIM error.
+func getTeamFromDB(ctx context.Context, store database.TeamStore, idStr string) (*types.Team, error) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+	}
+
+	team, err := store.GetTeamByID(ctx, int32(id))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+		}
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	return team, nil
+}
+
+// getSCIMTeamFromDB returns the team with the given ID if it was provisioned with SCIM.
+// Teams managed in Sourcegraph are reported as not found, so that they can't be changed
+// or deleted by the IdP.
+// When it fails, it returns an error that's safe to return to the client as a SCIM error.
+func getSCIMTeamFromDB(ctx context.Context, store database.TeamStore, idStr string) (*types.Team, error) {
+	team, err := getTeamFromDB(ctx, store, idStr)
+	if err != nil {
+		return nil, err
+	}
+	if !team.ReadOnly {
+		return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+	}
+	return team, nil
+}
+
+// getTeamMemberIDs returns the IDs of all members of the given team.
+func getTeamMemberIDs(ctx context.Context, store database.TeamStore, teamID int32) ([]int32, error) {
+	members, _, err := store.ListTeamMembers(ctx, database.ListTeamMembersOpts{TeamID: teamID})
+	if err != nil {
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "list team members").Error()}
+	}
+	ids := make([]int32, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.UserID)
+	}
+	return ids, nil
+}
+
+// getTeamNameFromDisplayName returns a team name derived from the given SCIM group display name.
+// Team names share their namespace with users and organizations, so they are normalized the same
+// way as usernames.
+func getTeamNameFromDisplayName(displayName string) (string, error) {
+	name, err := auth.NormalizeUsername(displayName)
+	if err != nil {
+		return "", scimerrors.ScimErrorBadParams([]string{"invalid displayName"})
+	}
+	return name, nil
+}
+
+// setTeamMembers makes the given user IDs the only members of the team. This is meant to be used
+// in a transaction.
+func setTeamMembers(ctx context.Context, tx database.DB, teamID int32, currentMemberIDs, wantMemberIDs []int32) error {
+	current := make(map[int32]struct{}, len(currentMemberIDs))
+	for _, id := range currentMemberIDs {
+		current[id] = struct{}{}
+	}
+	want := make(map[int32]struct{}, len(wantMemberIDs))
+	for _, id := range wantMemberIDs {
+		want[id] = struct{}{}
+	}
+
+	var toAdd, toRemove []int32
+	for id := range want {
+		if _, ok := current[id]; !ok {
+			toAdd = append(toAdd, id)
+		}
+	}
+	for id := range current {
+		if _, ok := want[id]; !ok {
+			toRemove = append(toRemove, id)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		users, err := tx.Users().List(ctx, &database.UsersListOptions{UserIDs: toAdd})
+		if err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "list users").Error()}
+		}
+		if len(users) != len(toAdd) {
+			return scimerrors.ScimErrorBadParams([]string{"members contain unknown users"})
+		}
+
+		members := make([]*types.TeamMember, 0, len(toAdd))
+		for _, id := range toAdd {
+			members = append(members, &types.TeamMember{TeamID: teamID, UserID: id})
+		}
+		if err := tx.Teams().CreateTeamMember(ctx, members...); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "add team members").Error()}
+		}
+	}
+
+	if len(toRemove) > 0 {
+		members := make([]*types.TeamMember, 0, len(toRemove))
+		for _, id := range toRemove {
+			members = append(members, &types.TeamMember{TeamID: teamID, UserID: id})
+		}
+		if err := tx.Teams().DeleteTeamMember(ctx, members...); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "remove team members").Error()}
+		}
+	}
+
+	return nil
+}
+
+// extractMemberIDs extracts the user IDs from the given SCIM "members" attribute value, which
+// is either a list of member objects or a single member object.
+func extractMemberIDs(value interface{}) ([]int32, error) {
+	var items []interface{}
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		items = v
+	default:
+		items = []interface{}{v}
+	}
+
+	ids := make([]int32, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, scimerrors.ScimErrorBadParams([]string{"invalid members"})
+		}
+		idStr, _ := m[AttrMemberValue].(string)
+		id, err := strconv.ParseInt(idStr, 10, 32)
+		if err != nil {
+			return nil, scimerrors.ScimErrorBadParams([]string{"invalid member value " + strconv.Quote(idStr)})
+		}
+		ids = append(ids, int32(id))
+	}
+	return ids, nil
+}
+
+// convertTeamToSCIMResource converts a Sourcegraph team and the IDs of its members to a SCIM resource.
+func convertTeamToSCIMResource(team *types.Team, memberIDs []int32) scim.Resource {
+	displayName := team.DisplayName
+	if displayName == "" {
+		displayName = team.Name
+	}
+
+	members := make([]interface{}, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		members = append(members, map[string]interface{}{
+			AttrMemberValue: strconv.FormatInt(int64(id), 10),
+		})
+	}
+
+	return scim.Resource{
+		ID: strconv.FormatInt(int64(team.ID), 10),
+		Attributes: scim.ResourceAttributes{
+			AttrDisplayName: displayName,
+			AttrMembers:     members,
+		},
+		Meta: scim.Meta{
+			Created:      &team.CreatedAt,
+			LastModified: &team.UpdatedAt,
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Create stores given attributes. Returns a resource with the attributes that are stored and a (new) unique identifier.
+func (h *GroupResourceHandler) Create(r *http.Request, attributes scim.ResourceAttributes) (scim.Resource, error) {
+	if err := checkBodyNotEmpty(r); err != nil {
+		return scim.Resource{}, err
+	}
+
+	displayName := extractStringAttribute(attributes, AttrDisplayName)
+	name, err := getTeamNameFromDisplayName(displayName)
+	if err != nil {
+		return scim.Resource{}, err
+	}
+	memberIDs, err := extractMemberIDs(attributes[AttrMembers])
+	if err != nil {
+		return scim.Resource{}, err
+	}
+
+	// Teams provisioned by the IdP are read-only, so that they don't drift from their source of truth.
+	team := &types.Team{
+		Name:        name,
+		DisplayName: displayName,
+		ReadOnly:    true,
+	}
+	err = h.db.WithTransact(r.Context(), func(tx database.DB) error {
+		if err := tx.Teams().CreateTeam(r.Context(), team); err != nil {
+			if errors.Is(err, database.ErrTeamNameAlreadyExists) {
+				return scimerrors.ScimError{Status: http.StatusConflict, ScimType: scimerrors.ScimTypeUniqueness, Detail: err.Error()}
+			}
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "create team").Error()}
+		}
+		return setTeamMembers(r.Context(), tx, team.ID, nil, memberIDs)
+	})
+	if err != nil {
+		return scim.Resource{}, unwrapTransactionError(err)
+	}
+
+	memberIDs, err = getTeamMemberIDs(r.Context(), h.db.Teams(), team.ID)
+	if err != nil {
+		return scim.Resource{}, err
+	}
+	return convertTeamToSCIMResource(team, memberIDs), nil
+}
@@ -0,0 +1,53 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGroupResourceHandler_Create(t *testing.T) {
+	db := createMockDBWithTeams()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	t.Run("with members", func(t *testing.T) {
+		group, err := groupResourceHandler.Create(createDummyRequest(), scim.ResourceAttributes{
+			AttrDisplayName: "Code Owners",
+			AttrMembers:     []interface{}{map[string]interface{}{AttrMemberValue: "2"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "4", group.ID)
+		assert.Equal(t, "Code Owners", group.Attributes[AttrDisplayName])
+		assert.Equal(t, []interface{}{map[string]interface{}{AttrMemberValue: "2"}}, group.Attributes[AttrMembers])
+
+		team, err := db.Teams().GetTeamByID(context.Background(), 4)
+		assert.NoError(t, err)
+		assert.Equal(t, "Code-Owners", team.Name)
+		assert.True(t, team.ReadOnly)
+	})
+
+	t.Run("existing name", func(t *testing.T) {
+		_, err := groupResourceHandler.Create(createDummyRequest(), scim.ResourceAttributes{AttrDisplayName: "sales"})
+		var scimErr scimerrors.ScimError
+		if assert.ErrorAs(t, err, &scimErr) {
+			assert.Equal(t, http.StatusConflict, scimErr.Status)
+		}
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		_, err := groupResourceHandler.Create(createDummyRequest(), scim.ResourceAttributes{
+			AttrDisplayName: "Support",
+			AttrMembers:     []interface{}{map[string]interface{}{AttrMemberValue: "42"}},
+		})
+		var scimErr scimerrors.ScimError
+		if assert.ErrorAs(t, err, &scimErr) {
+			assert.Equal(t, http.StatusBadRequest, scimErr.Status)
+		}
+	})
+}
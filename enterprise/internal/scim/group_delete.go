@@ -0,0 +1,28 @@
+package scim
+
+import (
+	"net/http"
+
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Delete removes the resource with corresponding ID.
+func (h *GroupResourceHandler) Delete(r *http.Request, id string) error {
+	team, err := getSCIMTeamFromDB(r.Context(), h.db.Teams(), id)
+	if err != nil {
+		return err
+	}
+
+	// Deleting a team also deletes its memberships.
+	if err := h.db.Teams().DeleteTeam(r.Context(), team.ID); err != nil {
+		if errcode.IsNotFound(err) {
+			return scimerrors.ScimErrorResourceNotFound(id)
+		}
+		return errors.Wrap(err, "delete team")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/scim/filter"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// Get returns the resource corresponding with the given identifier.
+func (h *GroupResourceHandler) Get(r *http.Request, idStr string) (scim.Resource, error) {
+	team, err := getTeamFromDB(r.Context(), h.db.Teams(), idStr)
+	if err != nil {
+		return scim.Resource{}, err
+	}
+	memberIDs, err := getTeamMemberIDs(r.Context(), h.db.Teams(), team.ID)
+	if err != nil {
+		return scim.Resource{}, err
+	}
+	return convertTeamToSCIMResource(team, memberIDs), nil
+}
+
+// GetAll returns a paginated list of resources.
+func (h *GroupResourceHandler) GetAll(r *http.Request, params scim.ListRequestParams) (scim.Page, error) {
+	var totalCount int
+	var resources []scim.Resource
+	var err error
+
+	if params.Filter == nil {
+		totalCount, resources, err = h.getAllFromDB(r, params.StartIndex, &params.Count)
+	} else {
+		validator := filter.NewFilterValidator(params.Filter, h.coreSchema)
+
+		// Like for users, fetch all resources from the DB and then filter them here.
+		var allResources []scim.Resource
+		_, allResources, err = h.getAllFromDB(r, 0, nil)
+
+		for _, resource := range allResources {
+			if err := validator.PassesFilter(resource.Attributes); err != nil {
+				continue
+			}
+
+			totalCount++
+			if totalCount >= params.StartIndex && len(resources) < params.Count {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	if err != nil {
+		return scim.Page{}, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+
+	return scim.Page{
+		TotalResults: totalCount,
+		Resources:    resources,
+	}, nil
+}
+
+// getAllFromDB returns all teams from the database, starting at the given index, and up to the given count.
+func (h *GroupResourceHandler) getAllFromDB(r *http.Request, startIndex int, count *int) (totalCount int, resources []scim.Resource, err error) {
+	var offset int
+	if startIndex > 0 {
+		offset = startIndex - 1
+	}
+
+	var opts database.ListTeamsOpts
+	if count != nil {
+		opts.LimitOffset = &database.LimitOffset{Limit: *count, Offset: offset}
+	}
+	teams, _, err := h.db.Teams().ListTeams(r.Context(), opts)
+	if err != nil {
+		return
+	}
+	resources = make([]scim.Resource, 0, len(teams))
+	for _, team := range teams {
+		var memberIDs []int32
+		memberIDs, err = getTeamMemberIDs(r.Context(), h.db.Teams(), team.ID)
+		if err != nil {
+			return
+		}
+		resources = append(resources, convertTeamToSCIMResource(team, memberIDs))
+	}
+
+	if count == nil {
+		totalCount = len(teams)
+	} else {
+		var count int32
+		count, err = h.db.Teams().CountTeams(r.Context(), database.ListTeamsOpts{})
+		totalCount = int(count)
+	}
+
+	return
+}
@@ -0,0 +1,103 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/elimity-com/scim"
+	"github.com/scim2/filter-parser/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// createMockDBWithTeams returns a mock database with two users and three teams.
+func createMockDBWithTeams() *database.MockDB {
+	db := getMockDB([]*types.UserForSCIM{
+		{User: types.User{ID: 1, Username: "user1"}},
+		{User: types.User{ID: 2, Username: "user2"}},
+	}, map[int32][]*database.UserEmail{})
+	addMockTeams(db, []*types.Team{
+		{ID: 1, Name: "engineering", DisplayName: "Engineering", ReadOnly: true},
+		{ID: 2, Name: "sales", DisplayName: "Sales", ReadOnly: true},
+		// Not provisioned with SCIM
+		{ID: 3, Name: "no-display-name"},
+	}, map[int32][]int32{
+		1: {1, 2},
+		2: {2},
+	})
+	return db
+}
+
+func TestGroupResourceHandler_Get(t *testing.T) {
+	db := createMockDBWithTeams()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	group1, err := groupResourceHandler.Get(&http.Request{}, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "1", group1.ID)
+	assert.Equal(t, "Engineering", group1.Attributes[AttrDisplayName])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{AttrMemberValue: "1"},
+		map[string]interface{}{AttrMemberValue: "2"},
+	}, group1.Attributes[AttrMembers])
+
+	// Teams without a display name are represented by their name
+	group3, err := groupResourceHandler.Get(&http.Request{}, "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "no-display-name", group3.Attributes[AttrDisplayName])
+	assert.Equal(t, []interface{}{}, group3.Attributes[AttrMembers])
+
+	_, err = groupResourceHandler.Get(&http.Request{}, "4")
+	assert.Error(t, err)
+}
+
+func TestGroupResourceHandler_GetAll(t *testing.T) {
+	t.Parallel()
+
+	db := createMockDBWithTeams()
+
+	cases := []struct {
+		name             string
+		count            int
+		startIndex       int
+		filter           string
+		wantTotalResults int
+		wantResults      int
+		wantFirstID      int
+	}{
+		{name: "no filter, count=2", count: 2, startIndex: 1, filter: "", wantTotalResults: 3, wantResults: 2, wantFirstID: 1},
+		{name: "no filter, count=2, offset=1", count: 2, startIndex: 2, filter: "", wantTotalResults: 3, wantResults: 2, wantFirstID: 2},
+		{name: "filter: displayName", count: 999, startIndex: 1, filter: "displayName eq \"Sales\"", wantTotalResults: 1, wantResults: 1, wantFirstID: 2},
+		{name: "filter: no match", count: 999, startIndex: 1, filter: "displayName eq \"Marketing\"", wantTotalResults: 0, wantResults: 0},
+	}
+
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			params := scim.ListRequestParams{Count: c.count, StartIndex: c.startIndex}
+			if c.filter != "" {
+				filterExpr, err := filter.ParseFilter([]byte(c.filter))
+				if err != nil {
+					t.Fatal(err)
+				}
+				params.Filter = filterExpr
+			}
+			page, err := groupResourceHandler.GetAll(&http.Request{}, params)
+			assert.NoError(t, err)
+			assert.Equal(t, c.wantTotalResults, page.TotalResults)
+			assert.Equal(t, c.wantResults, len(page.Resources))
+			if c.wantResults > 0 {
+				assert.Equal(t, strconv.Itoa(c.wantFirstID), page.Resources[0].ID)
+			}
+		})
+	}
+}
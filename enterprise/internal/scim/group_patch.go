@@ -0,0 +1,225 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	sgfilter "github.com/sourcegraph/sourcegraph/enterprise/internal/scim/filter"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// groupPatchState is the state of a group that PATCH operations are applied to.
+type groupPatchState struct {
+	displayName string
+	memberIDs   map[int32]struct{}
+}
+
+// Patch updates one or more attributes of a SCIM resource using a sequence of
+// operations to "add", "remove", or "replace" values.
+// If this returns no Resource.Attributes, a 204 No Content status code will be returned.
+//
+// Members are removed either with a filter ("members[value eq \"1\"]", the standard way used by
+// Okta) or by listing them in the value of an operation on the "members" path (the way used by
+// Azure AD). A "remove" operation on the "members" path without a value removes all members.
+func (h *GroupResourceHandler) Patch(r *http.Request, id string, operations []scim.PatchOperation) (scim.Resource, error) {
+	if err := checkBodyNotEmpty(r); err != nil {
+		return scim.Resource{}, err
+	}
+	rawValues, err := getRawPatchOperationValues(r)
+	if err != nil {
+		return scim.Resource{}, scimerrors.ScimErrorInvalidSyntax
+	}
+
+	var groupRes scim.Resource
+	err = h.db.WithTransact(r.Context(), func(tx database.DB) error {
+		team, err := getSCIMTeamFromDB(r.Context(), tx.Teams(), id)
+		if err != nil {
+			return err
+		}
+		currentMemberIDs, err := getTeamMemberIDs(r.Context(), tx.Teams(), team.ID)
+		if err != nil {
+			return err
+		}
+
+		state := groupPatchState{displayName: team.DisplayName, memberIDs: make(map[int32]struct{}, len(currentMemberIDs))}
+		for _, id := range currentMemberIDs {
+			state.memberIDs[id] = struct{}{}
+		}
+		for i, op := range operations {
+			var rawValue json.RawMessage
+			if i < len(rawValues) {
+				rawValue = rawValues[i]
+			}
+			if err := h.applyOperation(op, rawValue, &state); err != nil {
+				return err
+			}
+		}
+
+		displayNameChanged := state.displayName != team.DisplayName
+		membersChanged := len(state.memberIDs) != len(currentMemberIDs)
+		for _, id := range currentMemberIDs {
+			if _, ok := state.memberIDs[id]; !ok {
+				membersChanged = true
+			}
+		}
+		if !displayNameChanged && !membersChanged {
+			// StatusNoContent
+			return nil
+		}
+
+		if displayNameChanged {
+			team.DisplayName = state.displayName
+			if err := tx.Teams().UpdateTeam(r.Context(), team); err != nil {
+				return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "update team").Error()}
+			}
+		}
+		if membersChanged {
+			memberIDs := make([]int32, 0, len(state.memberIDs))
+			for id := range state.memberIDs {
+				memberIDs = append(memberIDs, id)
+			}
+			if err := setTeamMembers(r.Context(), tx, team.ID, currentMemberIDs, memberIDs); err != nil {
+				return err
+			}
+		}
+
+		memberIDs, err := getTeamMemberIDs(r.Context(), tx.Teams(), team.ID)
+		if err != nil {
+			return err
+		}
+		groupRes = convertTeamToSCIMResource(team, memberIDs)
+		return nil
+	})
+	if err != nil {
+		return scim.Resource{}, unwrapTransactionError(err)
+	}
+
+	return groupRes, nil
+}
+
+// applyOperation applies a single operation to the given group state.
+func (h *GroupResourceHandler) applyOperation(op scim.PatchOperation, rawValue json.RawMessage, state *groupPatchState) error {
+	// Handle multiple attributes in one value
+	if op.Path == nil {
+		values, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return scimerrors.ScimErrorInvalidValue
+		}
+		for attrName, value := range values {
+			if err := applyGroupAttributeChange(state, op.Op, attrName, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	attrName := op.Path.AttributePath.AttributeName
+	if !strings.EqualFold(attrName, AttrMembers) || op.Op != scim.PatchOperationRemove {
+		if op.Path.ValueExpression != nil {
+			// Filters are only supported to remove members.
+			return scimerrors.ScimErrorNoTarget
+		}
+		return applyGroupAttributeChange(state, op.Op, attrName, op.Value)
+	}
+
+	// Remove members matching a filter.
+	if op.Path.ValueExpression != nil {
+		validator, err := sgfilter.NewValidator(buildFilterString(op.Path.ValueExpression, AttrMembers), h.coreSchema)
+		if err != nil {
+			return scimerrors.ScimErrorInvalidFilter
+		}
+		for id := range state.memberIDs {
+			item := map[string]interface{}{AttrMemberValue: strconv.FormatInt(int64(id), 10)}
+			if arrayItemMatchesFilter(AttrMembers, item, validator) {
+				delete(state.memberIDs, id)
+			}
+		}
+		return nil
+	}
+
+	// Remove the members listed in the value.
+	if len(rawValue) > 0 && string(rawValue) != "null" {
+		var value interface{}
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			return scimerrors.ScimErrorInvalidValue
+		}
+		ids, err := extractMemberIDs(value)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			delete(state.memberIDs, id)
+		}
+		return nil
+	}
+
+	// Remove all members.
+	state.memberIDs = map[int32]struct{}{}
+	return nil
+}
+
+// applyGroupAttributeChange applies an "add", "replace" or "remove" operation on a whole
+// attribute to the given group state.
+func applyGroupAttributeChange(state *groupPatchState, op string, attrName string, value interface{}) error {
+	switch {
+	case strings.EqualFold(attrName, AttrDisplayName):
+		if op == scim.PatchOperationRemove {
+			state.displayName = ""
+			return nil
+		}
+		displayName, ok := value.(string)
+		if !ok {
+			return scimerrors.ScimErrorInvalidValue
+		}
+		state.displayName = displayName
+
+	case strings.EqualFold(attrName, AttrMembers):
+		ids, err := extractMemberIDs(value)
+		if err != nil {
+			return err
+		}
+		if op != scim.PatchOperationAdd {
+			state.memberIDs = make(map[int32]struct{}, len(ids))
+		}
+		for _, id := range ids {
+			state.memberIDs[id] = struct{}{}
+		}
+	}
+
+	// Other attributes, like externalId, are not stored.
+	return nil
+}
+
+// getRawPatchOperationValues returns the raw values of the operations in the PATCH request body.
+// The SCIM library drops the values of "remove" operations, but Azure AD uses them to identify
+// the members to remove.
+func getRawPatchOperationValues(r *http.Request) ([]json.RawMessage, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Restore the original body so that it can be read by a next handler.
+	r.Body = io.NopCloser(bytes.NewBuffer(data))
+
+	var req struct {
+		Operations []struct {
+			Value json.RawMessage
+		}
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	values := make([]json.RawMessage, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		values = append(values, op.Value)
+	}
+	return values, nil
+}
@@ -0,0 +1,127 @@
+package scim
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/elimity-com/scim"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// createPatchRequest creates a request with the given PATCH request body.
+func createPatchRequest(body string) *http.Request {
+	return &http.Request{Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// getMemberIDs returns the sorted IDs of the members of the given team in the given database.
+func getMemberIDs(t *testing.T, db database.DB, teamID int32) []int32 {
+	ids, err := getTeamMemberIDs(context.Background(), db.Teams(), teamID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func TestGroupResourceHandler_Patch(t *testing.T) {
+	member := func(id string) map[string]interface{} {
+		return map[string]interface{}{AttrMemberValue: id}
+	}
+
+	testCases := []struct {
+		name            string
+		body            string
+		operations      []scim.PatchOperation
+		wantNoContent   bool
+		wantDisplayName string
+		wantMemberIDs   []int32
+	}{
+		{
+			name:            "add member",
+			body:            `{"Operations":[{"op":"add","path":"members","value":[{"value":"1"}]}]}`,
+			operations:      []scim.PatchOperation{{Op: "add", Path: createPath(AttrMembers, nil), Value: []interface{}{member("1")}}},
+			wantDisplayName: "Sales",
+			wantMemberIDs:   []int32{1, 2},
+		},
+		{
+			name:          "add existing member",
+			body:          `{"Operations":[{"op":"add","path":"members","value":[{"value":"2"}]}]}`,
+			operations:    []scim.PatchOperation{{Op: "add", Path: createPath(AttrMembers, nil), Value: []interface{}{member("2")}}},
+			wantNoContent: true,
+			wantMemberIDs: []int32{2},
+		},
+		{
+			name:            "remove member with filter",
+			body:            `{"Operations":[{"op":"remove","path":"members[value eq \"2\"]"}]}`,
+			operations:      []scim.PatchOperation{{Op: "remove", Path: parseStringPath(`members[value eq "2"]`)}},
+			wantDisplayName: "Sales",
+			wantMemberIDs:   []int32{},
+		},
+		{
+			name: "remove member with value",
+			body: `{"Operations":[{"op":"add","path":"members","value":[{"value":"1"}]},{"op":"Remove","path":"members","value":[{"value":"2"}]}]}`,
+			operations: []scim.PatchOperation{
+				{Op: "add", Path: createPath(AttrMembers, nil), Value: []interface{}{member("1")}},
+				{Op: "remove", Path: createPath(AttrMembers, nil)},
+			},
+			wantDisplayName: "Sales",
+			wantMemberIDs:   []int32{1},
+		},
+		{
+			name:            "remove all members",
+			body:            `{"Operations":[{"op":"remove","path":"members"}]}`,
+			operations:      []scim.PatchOperation{{Op: "remove", Path: createPath(AttrMembers, nil)}},
+			wantDisplayName: "Sales",
+			wantMemberIDs:   []int32{},
+		},
+		{
+			name:            "replace members",
+			body:            `{"Operations":[{"op":"replace","path":"members","value":[{"value":"1"}]}]}`,
+			operations:      []scim.PatchOperation{{Op: "replace", Path: createPath(AttrMembers, nil), Value: []interface{}{member("1")}}},
+			wantDisplayName: "Sales",
+			wantMemberIDs:   []int32{1},
+		},
+		{
+			name:            "replace display name without path",
+			body:            `{"Operations":[{"op":"replace","value":{"displayName":"Sales EMEA"}}]}`,
+			operations:      []scim.PatchOperation{{Op: "replace", Value: map[string]interface{}{AttrDisplayName: "Sales EMEA"}}},
+			wantDisplayName: "Sales EMEA",
+			wantMemberIDs:   []int32{2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := createMockDBWithTeams()
+			groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+			group, err := groupResourceHandler.Patch(createPatchRequest(tc.body), "2", tc.operations)
+			assert.NoError(t, err)
+			if tc.wantNoContent {
+				assert.Nil(t, group.Attributes)
+			} else {
+				assert.Equal(t, tc.wantDisplayName, group.Attributes[AttrDisplayName])
+			}
+			assert.Equal(t, tc.wantMemberIDs, getMemberIDs(t, db, 2))
+		})
+	}
+
+	t.Run("unknown member", func(t *testing.T) {
+		db := createMockDBWithTeams()
+		groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+		_, err := groupResourceHandler.Patch(
+			createPatchRequest(`{"Operations":[{"op":"add","path":"members","value":[{"value":"42"}]}]}`),
+			"2",
+			[]scim.PatchOperation{{Op: "add", Path: createPath(AttrMembers, nil), Value: []interface{}{member("42")}}},
+		)
+		assert.Error(t, err)
+	})
+}
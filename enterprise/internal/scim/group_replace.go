@@ -0,0 +1,58 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Replace replaces ALL existing attributes of the resource with given identifier. Given attributes that are empty
+// are to be deleted. Returns a resource with the attributes that are stored.
+func (h *GroupResourceHandler) Replace(r *http.Request, id string, attributes scim.ResourceAttributes) (scim.Resource, error) {
+	if err := checkBodyNotEmpty(r); err != nil {
+		return scim.Resource{}, err
+	}
+
+	memberIDs, err := extractMemberIDs(attributes[AttrMembers])
+	if err != nil {
+		return scim.Resource{}, err
+	}
+
+	var groupRes scim.Resource
+	err = h.db.WithTransact(r.Context(), func(tx database.DB) error {
+		team, err := getSCIMTeamFromDB(r.Context(), tx.Teams(), id)
+		if err != nil {
+			return err
+		}
+		currentMemberIDs, err := getTeamMemberIDs(r.Context(), tx.Teams(), team.ID)
+		if err != nil {
+			return err
+		}
+
+		// The team name is kept stable, so that references to the team (for example in
+		// CODEOWNERS files) keep working when the group is renamed in the IdP.
+		team.DisplayName = extractStringAttribute(attributes, AttrDisplayName)
+		if err := tx.Teams().UpdateTeam(r.Context(), team); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "update team").Error()}
+		}
+		if err := setTeamMembers(r.Context(), tx, team.ID, currentMemberIDs, memberIDs); err != nil {
+			return err
+		}
+
+		memberIDs, err = getTeamMemberIDs(r.Context(), tx.Teams(), team.ID)
+		if err != nil {
+			return err
+		}
+		groupRes = convertTeamToSCIMResource(team, memberIDs)
+		return nil
+	})
+	if err != nil {
+		return scim.Resource{}, unwrapTransactionError(err)
+	}
+
+	return groupRes, nil
+}
@@ -0,0 +1,70 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGroupResourceHandler_Replace(t *testing.T) {
+	db := createMockDBWithTeams()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	group, err := groupResourceHandler.Replace(createDummyRequest(), "1", scim.ResourceAttributes{
+		AttrDisplayName: "Platform Engineering",
+		AttrMembers:     []interface{}{map[string]interface{}{AttrMemberValue: "1"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Platform Engineering", group.Attributes[AttrDisplayName])
+	assert.Equal(t, []int32{1}, getMemberIDs(t, db, 1))
+
+	// The team name stays the same
+	team, err := db.Teams().GetTeamByID(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "engineering", team.Name)
+	assert.Equal(t, "Platform Engineering", team.DisplayName)
+}
+
+func TestGroupResourceHandler_Delete(t *testing.T) {
+	db := createMockDBWithTeams()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	assert.NoError(t, groupResourceHandler.Delete(&http.Request{}, "1"))
+	_, err := groupResourceHandler.Get(&http.Request{}, "1")
+	assert.Error(t, err)
+
+	assert.Error(t, groupResourceHandler.Delete(&http.Request{}, "1"))
+}
+
+func TestGroupResourceHandler_NotProvisionedWithSCIM(t *testing.T) {
+	db := createMockDBWithTeams()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	var scimErr scimerrors.ScimError
+
+	_, err := groupResourceHandler.Replace(createDummyRequest(), "3", scim.ResourceAttributes{AttrDisplayName: "Renamed"})
+	assert.ErrorAs(t, err, &scimErr)
+	assert.Equal(t, http.StatusNotFound, scimErr.Status)
+
+	_, err = groupResourceHandler.Patch(createPatchRequest(`{"Operations":[{"op":"replace","path":"displayName","value":"Renamed"}]}`), "3", []scim.PatchOperation{{Op: "replace", Path: createPath(AttrDisplayName, nil), Value: "Renamed"}})
+	assert.ErrorAs(t, err, &scimErr)
+	assert.Equal(t, http.StatusNotFound, scimErr.Status)
+
+	err = groupResourceHandler.Delete(&http.Request{}, "3")
+	assert.ErrorAs(t, err, &scimErr)
+	assert.Equal(t, http.StatusNotFound, scimErr.Status)
+
+	team, err := db.Teams().GetTeamByID(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "", team.DisplayName)
+	mockassert.NotCalled(t, db.Teams().(*database.MockTeamStore).UpdateTeamFunc)
+	mockassert.NotCalled(t, db.Teams().(*database.MockTeamStore).DeleteTeamFunc)
+}
@@ -62,8 +62,12 @@ func newHandler(ctx context.Context, db database.DB, observationCtx *observation
 	}
 
 	var userResourceHandler = NewUserResourceHandler(ctx, observationCtx, db)
+	var groupResourceHandler = NewGroupResourceHandler(ctx, observationCtx, db)
 
-	resourceTypes := []scim.ResourceType{createUserResourceType(userResourceHandler)}
+	resourceTypes := []scim.ResourceType{
+		createUserResourceType(userResourceHandler),
+		createGroupResourceType(groupResourceHandler),
+	}
 
 	server := scim.Server{
 		Config:        config,
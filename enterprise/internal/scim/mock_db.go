@@ -213,3 +213,107 @@ func applyLimitOffset(users []*types.UserForSCIM, limitOffset *database.LimitOff
 	}
 	return users[start:end], nil
 }
+
+// addMockTeams adds a mock team store that contains the given teams and team members to the given mock database.
+// The users of the mock database are the only users that can become team members.
+// Note: IDs of teams must be ascending.
+func addMockTeams(db *database.MockDB, teams []*types.Team, teamMembers map[int32][]int32) {
+	userStore := db.Users().(*database.MockUserStore)
+	userStore.ListFunc.SetDefaultHook(func(ctx context.Context, opt *database.UsersListOptions) ([]*types.User, error) {
+		users, err := userStore.ListForSCIM(ctx, &database.UsersListOptions{UserIDs: opt.UserIDs})
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*types.User, 0, len(users))
+		for _, user := range users {
+			result = append(result, &user.User)
+		}
+		return result, nil
+	})
+
+	teamStore := database.NewMockTeamStore()
+	teamStore.GetTeamByIDFunc.SetDefaultHook(func(ctx context.Context, id int32) (*types.Team, error) {
+		for _, team := range teams {
+			if team.ID == id {
+				return team, nil
+			}
+		}
+		return nil, database.TeamNotFoundError{}
+	})
+	teamStore.ListTeamsFunc.SetDefaultHook(func(ctx context.Context, opts database.ListTeamsOpts) ([]*types.Team, int32, error) {
+		if opts.LimitOffset == nil {
+			return teams, 0, nil
+		}
+		start := opts.Offset
+		if start > len(teams) {
+			start = len(teams)
+		}
+		end := start + opts.Limit
+		if end > len(teams) {
+			end = len(teams)
+		}
+		return teams[start:end], 0, nil
+	})
+	teamStore.CountTeamsFunc.SetDefaultHook(func(ctx context.Context, opts database.ListTeamsOpts) (int32, error) {
+		return int32(len(teams)), nil
+	})
+	teamStore.CreateTeamFunc.SetDefaultHook(func(ctx context.Context, team *types.Team) error {
+		for _, t := range teams {
+			if t.Name == team.Name {
+				return database.ErrTeamNameAlreadyExists
+			}
+		}
+		team.ID = 1
+		if len(teams) > 0 {
+			team.ID = teams[len(teams)-1].ID + 1
+		}
+		teams = append(teams, team)
+		return nil
+	})
+	teamStore.UpdateTeamFunc.SetDefaultHook(func(ctx context.Context, team *types.Team) error {
+		for i, t := range teams {
+			if t.ID == team.ID {
+				teams[i] = team
+				return nil
+			}
+		}
+		return database.TeamNotFoundError{}
+	})
+	teamStore.DeleteTeamFunc.SetDefaultHook(func(ctx context.Context, id int32) error {
+		for i, t := range teams {
+			if t.ID == id {
+				teams = append(teams[:i], teams[i+1:]...)
+				delete(teamMembers, id)
+				return nil
+			}
+		}
+		return database.TeamNotFoundError{}
+	})
+	teamStore.ListTeamMembersFunc.SetDefaultHook(func(ctx context.Context, opts database.ListTeamMembersOpts) ([]*types.TeamMember, *database.TeamMemberListCursor, error) {
+		var members []*types.TeamMember
+		for _, userID := range teamMembers[opts.TeamID] {
+			members = append(members, &types.TeamMember{TeamID: opts.TeamID, UserID: userID})
+		}
+		return members, nil, nil
+	})
+	teamStore.CreateTeamMemberFunc.SetDefaultHook(func(ctx context.Context, members ...*types.TeamMember) error {
+		for _, m := range members {
+			teamMembers[m.TeamID] = append(teamMembers[m.TeamID], m.UserID)
+		}
+		return nil
+	})
+	teamStore.DeleteTeamMemberFunc.SetDefaultHook(func(ctx context.Context, members ...*types.TeamMember) error {
+		for _, m := range members {
+			remaining := []int32{}
+			for _, userID := range teamMembers[m.TeamID] {
+				if userID != m.UserID {
+					remaining = append(remaining, userID)
+				}
+			}
+			teamMembers[m.TeamID] = remaining
+		}
+		return nil
+	})
+
+	db.TeamsFunc.SetDefaultReturn(teamStore)
+}
@@ -337,6 +337,16 @@ func checkBodyNotEmpty(r *http.Request) (err error) {
 	return
 }
 
+// unwrapTransactionError returns the last error of a multi-error returned by a transaction, so
+// that SCIM errors returned from within the transaction reach the client as-is.
+func unwrapTransactionError(err error) error {
+	multiErr, ok := err.(errors.MultiError)
+	if !ok || len(multiErr.Errors()) == 0 {
+		return err
+	}
+	return multiErr.Errors()[len(multiErr.Errors())-1]
+}
+
 // convertUserToSCIMResource converts a Sourcegraph user to a SCIM resource.
 func convertUserToSCIMResource(user *types.UserForSCIM) scim.Resource {
 	// Convert account data – if it doesn't exist, never mind
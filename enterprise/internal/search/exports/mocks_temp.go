@@ -0,0 +1,1518 @@
+// Code generated by go-mockgen 1.3.7; DO NOT EDIT.
+//
+// This file was generated by running `sg generate` (or `go-mockgen`) at the root of
+// this repository. To add additional mocks to this or another package, add a new entry
+// to the mockgen.yaml file in the root of this repository.
+
+package exports
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	database "github.com/sourcegraph/sourcegraph/internal/database"
+	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// MockStore is a mock implementation of the Store interface (from the
+// package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/search/exports)
+// used for unit testing.
+type MockStore struct {
+	// CancelFunc is an instance of a mock function object controlling the
+	// behavior of the method Cancel.
+	CancelFunc *StoreCancelFunc
+	// CountForUserFunc is an instance of a mock function object controlling
+	// the behavior of the method CountForUser.
+	CountForUserFunc *StoreCountForUserFunc
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *StoreCreateFunc
+	// DeleteByIDsFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteByIDs.
+	DeleteByIDsFunc *StoreDeleteByIDsFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *StoreDoneFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *StoreGetByIDFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *StoreHandleFunc
+	// ListExpiredFunc is an instance of a mock function object controlling
+	// the behavior of the method ListExpired.
+	ListExpiredFunc *StoreListExpiredFunc
+	// ListForUserFunc is an instance of a mock function object controlling
+	// the behavior of the method ListForUser.
+	ListForUserFunc *StoreListForUserFunc
+	// SetObjectKeyFunc is an instance of a mock function object controlling
+	// the behavior of the method SetObjectKey.
+	SetObjectKeyFunc *StoreSetObjectKeyFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *StoreTransactFunc
+	// UpdateProgressFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateProgress.
+	UpdateProgressFunc *StoreUpdateProgressFunc
+}
+
+// NewMockStore creates a new mock of the Store interface. All methods
+// return zero values for all results, unless overwritten.
+func NewMockStore() *MockStore {
+	return &MockStore{
+		CancelFunc: &StoreCancelFunc{
+			defaultHook: func(context.Context, int) (r0 error) {
+				return
+			},
+		},
+		CountForUserFunc: &StoreCountForUserFunc{
+			defaultHook: func(context.Context, int32) (r0 int, r1 error) {
+				return
+			},
+		},
+		CreateFunc: &StoreCreateFunc{
+			defaultHook: func(context.Context, int32, string, Format, int) (r0 *SearchExport, r1 error) {
+				return
+			},
+		},
+		DeleteByIDsFunc: &StoreDeleteByIDsFunc{
+			defaultHook: func(context.Context, []int) (r0 error) {
+				return
+			},
+		},
+		DoneFunc: &StoreDoneFunc{
+			defaultHook: func(error) (r0 error) {
+				return
+			},
+		},
+		GetByIDFunc: &StoreGetByIDFunc{
+			defaultHook: func(context.Context, int) (r0 *SearchExport, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &StoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		ListExpiredFunc: &StoreListExpiredFunc{
+			defaultHook: func(context.Context, time.Time, int) (r0 []*SearchExport, r1 error) {
+				return
+			},
+		},
+		ListForUserFunc: &StoreListForUserFunc{
+			defaultHook: func(context.Context, int32, *database.PaginationArgs) (r0 []*SearchExport, r1 error) {
+				return
+			},
+		},
+		SetObjectKeyFunc: &StoreSetObjectKeyFunc{
+			defaultHook: func(context.Context, int, string) (r0 error) {
+				return
+			},
+		},
+		TransactFunc: &StoreTransactFunc{
+			defaultHook: func(context.Context) (r0 Store, r1 error) {
+				return
+			},
+		},
+		UpdateProgressFunc: &StoreUpdateProgressFunc{
+			defaultHook: func(context.Context, int, int, int, int) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockStore creates a new mock of the Store interface. All methods
+// panic on invocation, unless overwritten.
+func NewStrictMockStore() *MockStore {
+	return &MockStore{
+		CancelFunc: &StoreCancelFunc{
+			defaultHook: func(context.Context, int) error {
+				panic("unexpected invocation of MockStore.Cancel")
+			},
+		},
+		CountForUserFunc: &StoreCountForUserFunc{
+			defaultHook: func(context.Context, int32) (int, error) {
+				panic("unexpected invocation of MockStore.CountForUser")
+			},
+		},
+		CreateFunc: &StoreCreateFunc{
+			defaultHook: func(context.Context, int32, string, Format, int) (*SearchExport, error) {
+				panic("unexpected invocation of MockStore.Create")
+			},
+		},
+		DeleteByIDsFunc: &StoreDeleteByIDsFunc{
+			defaultHook: func(context.Context, []int) error {
+				panic("unexpected invocation of MockStore.DeleteByIDs")
+			},
+		},
+		DoneFunc: &StoreDoneFunc{
+			defaultHook: func(error) error {
+				panic("unexpected invocation of MockStore.Done")
+			},
+		},
+		GetByIDFunc: &StoreGetByIDFunc{
+			defaultHook: func(context.Context, int) (*SearchExport, error) {
+				panic("unexpected invocation of MockStore.GetByID")
+			},
+		},
+		HandleFunc: &StoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockStore.Handle")
+			},
+		},
+		ListExpiredFunc: &StoreListExpiredFunc{
+			defaultHook: func(context.Context, time.Time, int) ([]*SearchExport, error) {
+				panic("unexpected invocation of MockStore.ListExpired")
+			},
+		},
+		ListForUserFunc: &StoreListForUserFunc{
+			defaultHook: func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error) {
+				panic("unexpected invocation of MockStore.ListForUser")
+			},
+		},
+		SetObjectKeyFunc: &StoreSetObjectKeyFunc{
+			defaultHook: func(context.Context, int, string) error {
+				panic("unexpected invocation of MockStore.SetObjectKey")
+			},
+		},
+		TransactFunc: &StoreTransactFunc{
+			defaultHook: func(context.Context) (Store, error) {
+				panic("unexpected invocation of MockStore.Transact")
+			},
+		},
+		UpdateProgressFunc: &StoreUpdateProgressFunc{
+			defaultHook: func(context.Context, int, int, int, int) error {
+				panic("unexpected invocation of MockStore.UpdateProgress")
+			},
+		},
+	}
+}
+
+// NewMockStoreFrom creates a new mock of the MockStore interface. All
+// methods delegate to the given implementation, unless overwritten.
+func NewMockStoreFrom(i Store) *MockStore {
+	return &MockStore{
+		CancelFunc: &StoreCancelFunc{
+			defaultHook: i.Cancel,
+		},
+		CountForUserFunc: &StoreCountForUserFunc{
+			defaultHook: i.CountForUser,
+		},
+		CreateFunc: &StoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeleteByIDsFunc: &StoreDeleteByIDsFunc{
+			defaultHook: i.DeleteByIDs,
+		},
+		DoneFunc: &StoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		GetByIDFunc: &StoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
+		HandleFunc: &StoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListExpiredFunc: &StoreListExpiredFunc{
+			defaultHook: i.ListExpired,
+		},
+		ListForUserFunc: &StoreListForUserFunc{
+			defaultHook: i.ListForUser,
+		},
+		SetObjectKeyFunc: &StoreSetObjectKeyFunc{
+			defaultHook: i.SetObjectKey,
+		},
+		TransactFunc: &StoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpdateProgressFunc: &StoreUpdateProgressFunc{
+			defaultHook: i.UpdateProgress,
+		},
+	}
+}
+
+// StoreCancelFunc describes the behavior when the Cancel method of the
+// parent MockStore instance is invoked.
+type StoreCancelFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []StoreCancelFuncCall
+	mutex       sync.Mutex
+}
+
+// Cancel delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Cancel(v0 context.Context, v1 int) error {
+	r0 := m.CancelFunc.nextHook()(v0, v1)
+	m.CancelFunc.appendCall(StoreCancelFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Cancel method of the
+// parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreCancelFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Cancel method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreCancelFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreCancelFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreCancelFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *StoreCancelFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreCancelFunc) appendCall(r0 StoreCancelFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreCancelFuncCall objects describing the
+// invocations of this function.
+func (f *StoreCancelFunc) History() []StoreCancelFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreCancelFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreCancelFuncCall is an object that describes an invocation of method
+// Cancel on an instance of MockStore.
+type StoreCancelFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreCancelFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreCancelFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreCountForUserFunc describes the behavior when the CountForUser method
+// of the parent MockStore instance is invoked.
+type StoreCountForUserFunc struct {
+	defaultHook func(context.Context, int32) (int, error)
+	hooks       []func(context.Context, int32) (int, error)
+	history     []StoreCountForUserFuncCall
+	mutex       sync.Mutex
+}
+
+// CountForUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) CountForUser(v0 context.Context, v1 int32) (int, error) {
+	r0, r1 := m.CountForUserFunc.nextHook()(v0, v1)
+	m.CountForUserFunc.appendCall(StoreCountForUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountForUser method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreCountForUserFunc) SetDefaultHook(hook func(context.Context, int32) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountForUser method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreCountForUserFunc) PushHook(hook func(context.Context, int32) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreCountForUserFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreCountForUserFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int32) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreCountForUserFunc) nextHook() func(context.Context, int32) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreCountForUserFunc) appendCall(r0 StoreCountForUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreCountForUserFuncCall objects
+// describing the invocations of this function.
+func (f *StoreCountForUserFunc) History() []StoreCountForUserFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreCountForUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreCountForUserFuncCall is an object that describes an invocation of
+// method CountForUser on an instance of MockStore.
+type StoreCountForUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreCountForUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreCountForUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreCreateFunc describes the behavior when the Create method of the
+// parent MockStore instance is invoked.
+type StoreCreateFunc struct {
+	defaultHook func(context.Context, int32, string, Format, int) (*SearchExport, error)
+	hooks       []func(context.Context, int32, string, Format, int) (*SearchExport, error)
+	history     []StoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Create(v0 context.Context, v1 int32, v2 string, v3 Format, v4 int) (*SearchExport, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateFunc.appendCall(StoreCreateFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreCreateFunc) SetDefaultHook(hook func(context.Context, int32, string, Format, int) (*SearchExport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreCreateFunc) PushHook(hook func(context.Context, int32, string, Format, int) (*SearchExport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreCreateFunc) SetDefaultReturn(r0 *SearchExport, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, Format, int) (*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreCreateFunc) PushReturn(r0 *SearchExport, r1 error) {
+	f.PushHook(func(context.Context, int32, string, Format, int) (*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreCreateFunc) nextHook() func(context.Context, int32, string, Format, int) (*SearchExport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreCreateFunc) appendCall(r0 StoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreCreateFuncCall objects describing the
+// invocations of this function.
+func (f *StoreCreateFunc) History() []StoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreCreateFuncCall is an object that describes an invocation of method
+// Create on an instance of MockStore.
+type StoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 Format
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *SearchExport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreDeleteByIDsFunc describes the behavior when the DeleteByIDs method
+// of the parent MockStore instance is invoked.
+type StoreDeleteByIDsFunc struct {
+	defaultHook func(context.Context, []int) error
+	hooks       []func(context.Context, []int) error
+	history     []StoreDeleteByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteByIDs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) DeleteByIDs(v0 context.Context, v1 []int) error {
+	r0 := m.DeleteByIDsFunc.nextHook()(v0, v1)
+	m.DeleteByIDsFunc.appendCall(StoreDeleteByIDsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteByIDs method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreDeleteByIDsFunc) SetDefaultHook(hook func(context.Context, []int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteByIDs method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreDeleteByIDsFunc) PushHook(hook func(context.Context, []int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDeleteByIDsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDeleteByIDsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+func (f *StoreDeleteByIDsFunc) nextHook() func(context.Context, []int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeleteByIDsFunc) appendCall(r0 StoreDeleteByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeleteByIDsFuncCall objects describing
+// the invocations of this function.
+func (f *StoreDeleteByIDsFunc) History() []StoreDeleteByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDeleteByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeleteByIDsFuncCall is an object that describes an invocation of
+// method DeleteByIDs on an instance of MockStore.
+type StoreDeleteByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeleteByIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeleteByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreDoneFunc describes the behavior when the Done method of the parent
+// MockStore instance is invoked.
+type StoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []StoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(StoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *StoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDoneFunc) appendCall(r0 StoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDoneFuncCall objects describing the
+// invocations of this function.
+func (f *StoreDoneFunc) History() []StoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDoneFuncCall is an object that describes an invocation of method
+// Done on an instance of MockStore.
+type StoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDoneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreGetByIDFunc describes the behavior when the GetByID method of the
+// parent MockStore instance is invoked.
+type StoreGetByIDFunc struct {
+	defaultHook func(context.Context, int) (*SearchExport, error)
+	hooks       []func(context.Context, int) (*SearchExport, error)
+	history     []StoreGetByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) GetByID(v0 context.Context, v1 int) (*SearchExport, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(StoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int) (*SearchExport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByID method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreGetByIDFunc) PushHook(hook func(context.Context, int) (*SearchExport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetByIDFunc) SetDefaultReturn(r0 *SearchExport, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetByIDFunc) PushReturn(r0 *SearchExport, r1 error) {
+	f.PushHook(func(context.Context, int) (*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetByIDFunc) nextHook() func(context.Context, int) (*SearchExport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetByIDFunc) appendCall(r0 StoreGetByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetByIDFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetByIDFunc) History() []StoreGetByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetByIDFuncCall is an object that describes an invocation of method
+// GetByID on an instance of MockStore.
+type StoreGetByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *SearchExport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreHandleFunc describes the behavior when the Handle method of the
+// parent MockStore instance is invoked.
+type StoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []StoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(StoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *StoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreHandleFunc) appendCall(r0 StoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreHandleFuncCall objects describing the
+// invocations of this function.
+func (f *StoreHandleFunc) History() []StoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreHandleFuncCall is an object that describes an invocation of method
+// Handle on an instance of MockStore.
+type StoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreListExpiredFunc describes the behavior when the ListExpired method
+// of the parent MockStore instance is invoked.
+type StoreListExpiredFunc struct {
+	defaultHook func(context.Context, time.Time, int) ([]*SearchExport, error)
+	hooks       []func(context.Context, time.Time, int) ([]*SearchExport, error)
+	history     []StoreListExpiredFuncCall
+	mutex       sync.Mutex
+}
+
+// ListExpired delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) ListExpired(v0 context.Context, v1 time.Time, v2 int) ([]*SearchExport, error) {
+	r0, r1 := m.ListExpiredFunc.nextHook()(v0, v1, v2)
+	m.ListExpiredFunc.appendCall(StoreListExpiredFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListExpired method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreListExpiredFunc) SetDefaultHook(hook func(context.Context, time.Time, int) ([]*SearchExport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListExpired method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreListExpiredFunc) PushHook(hook func(context.Context, time.Time, int) ([]*SearchExport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreListExpiredFunc) SetDefaultReturn(r0 []*SearchExport, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time, int) ([]*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreListExpiredFunc) PushReturn(r0 []*SearchExport, r1 error) {
+	f.PushHook(func(context.Context, time.Time, int) ([]*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreListExpiredFunc) nextHook() func(context.Context, time.Time, int) ([]*SearchExport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreListExpiredFunc) appendCall(r0 StoreListExpiredFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreListExpiredFuncCall objects describing
+// the invocations of this function.
+func (f *StoreListExpiredFunc) History() []StoreListExpiredFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreListExpiredFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreListExpiredFuncCall is an object that describes an invocation of
+// method ListExpired on an instance of MockStore.
+type StoreListExpiredFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*SearchExport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreListExpiredFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreListExpiredFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreListForUserFunc describes the behavior when the ListForUser method
+// of the parent MockStore instance is invoked.
+type StoreListForUserFunc struct {
+	defaultHook func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error)
+	hooks       []func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error)
+	history     []StoreListForUserFuncCall
+	mutex       sync.Mutex
+}
+
+// ListForUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) ListForUser(v0 context.Context, v1 int32, v2 *database.PaginationArgs) ([]*SearchExport, error) {
+	r0, r1 := m.ListForUserFunc.nextHook()(v0, v1, v2)
+	m.ListForUserFunc.appendCall(StoreListForUserFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListForUser method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreListForUserFunc) SetDefaultHook(hook func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListForUser method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreListForUserFunc) PushHook(hook func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreListForUserFunc) SetDefaultReturn(r0 []*SearchExport, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreListForUserFunc) PushReturn(r0 []*SearchExport, r1 error) {
+	f.PushHook(func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreListForUserFunc) nextHook() func(context.Context, int32, *database.PaginationArgs) ([]*SearchExport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreListForUserFunc) appendCall(r0 StoreListForUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreListForUserFuncCall objects describing
+// the invocations of this function.
+func (f *StoreListForUserFunc) History() []StoreListForUserFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreListForUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreListForUserFuncCall is an object that describes an invocation of
+// method ListForUser on an instance of MockStore.
+type StoreListForUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *database.PaginationArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*SearchExport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreListForUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreListForUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreSetObjectKeyFunc describes the behavior when the SetObjectKey method
+// of the parent MockStore instance is invoked.
+type StoreSetObjectKeyFunc struct {
+	defaultHook func(context.Context, int, string) error
+	hooks       []func(context.Context, int, string) error
+	history     []StoreSetObjectKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// SetObjectKey delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) SetObjectKey(v0 context.Context, v1 int, v2 string) error {
+	r0 := m.SetObjectKeyFunc.nextHook()(v0, v1, v2)
+	m.SetObjectKeyFunc.appendCall(StoreSetObjectKeyFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetObjectKey method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreSetObjectKeyFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetObjectKey method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreSetObjectKeyFunc) PushHook(hook func(context.Context, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreSetObjectKeyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreSetObjectKeyFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+func (f *StoreSetObjectKeyFunc) nextHook() func(context.Context, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreSetObjectKeyFunc) appendCall(r0 StoreSetObjectKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreSetObjectKeyFuncCall objects
+// describing the invocations of this function.
+func (f *StoreSetObjectKeyFunc) History() []StoreSetObjectKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreSetObjectKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreSetObjectKeyFuncCall is an object that describes an invocation of
+// method SetObjectKey on an instance of MockStore.
+type StoreSetObjectKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreSetObjectKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreSetObjectKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreTransactFunc describes the behavior when the Transact method of the
+// parent MockStore instance is invoked.
+type StoreTransactFunc struct {
+	defaultHook func(context.Context) (Store, error)
+	hooks       []func(context.Context) (Store, error)
+	history     []StoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Transact(v0 context.Context) (Store, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(StoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreTransactFunc) SetDefaultHook(hook func(context.Context) (Store, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreTransactFunc) PushHook(hook func(context.Context) (Store, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreTransactFunc) SetDefaultReturn(r0 Store, r1 error) {
+	f.SetDefaultHook(func(context.Context) (Store, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreTransactFunc) PushReturn(r0 Store, r1 error) {
+	f.PushHook(func(context.Context) (Store, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreTransactFunc) nextHook() func(context.Context) (Store, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreTransactFunc) appendCall(r0 StoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreTransactFuncCall objects describing
+// the invocations of this function.
+func (f *StoreTransactFunc) History() []StoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreTransactFuncCall is an object that describes an invocation of method
+// Transact on an instance of MockStore.
+type StoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 Store
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreUpdateProgressFunc describes the behavior when the UpdateProgress
+// method of the parent MockStore instance is invoked.
+type StoreUpdateProgressFunc struct {
+	defaultHook func(context.Context, int, int, int, int) error
+	hooks       []func(context.Context, int, int, int, int) error
+	history     []StoreUpdateProgressFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateProgress delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateProgress(v0 context.Context, v1 int, v2 int, v3 int, v4 int) error {
+	r0 := m.UpdateProgressFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateProgressFunc.appendCall(StoreUpdateProgressFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateProgress
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreUpdateProgressFunc) SetDefaultHook(hook func(context.Context, int, int, int, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateProgress method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreUpdateProgressFunc) PushHook(hook func(context.Context, int, int, int, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreUpdateProgressFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreUpdateProgressFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, int, int) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateProgressFunc) nextHook() func(context.Context, int, int, int, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateProgressFunc) appendCall(r0 StoreUpdateProgressFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateProgressFuncCall objects
+// describing the invocations of this function.
+func (f *StoreUpdateProgressFunc) History() []StoreUpdateProgressFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateProgressFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateProgressFuncCall is an object that describes an invocation of
+// method UpdateProgress on an instance of MockStore.
+type StoreUpdateProgressFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateProgressFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateProgressFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -0,0 +1,73 @@
+package exports
+
+import (
+	"fmt"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// repoScopeFields are the query fields that determine which repositories a query
+// searches.
+var repoScopeFields = map[string]struct{}{
+	query.FieldRepo:       {},
+	query.FieldContext:    {},
+	query.FieldFork:       {},
+	query.FieldArchived:   {},
+	query.FieldVisibility: {},
+}
+
+// ValidateQuery returns an error if q cannot be exported.
+func ValidateQuery(q string) error {
+	_, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+	return err
+}
+
+// RepositoryQueries returns repository searches that together list every repository
+// q may have matches in. The pattern and all parameters that do not scope the
+// repositories are dropped, so the searches stay cheap regardless of how expensive
+// q is.
+func RepositoryQueries(q string) ([]string, error) {
+	plan, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	var queries []string
+	for _, basic := range plan {
+		var parameters []query.Parameter
+		for _, p := range basic.Parameters {
+			if _, ok := repoScopeFields[p.Field]; ok {
+				parameters = append(parameters, p)
+			}
+		}
+		repoQuery := query.Basic{Parameters: parameters}.StringHuman()
+		if repoQuery != "" {
+			repoQuery += " "
+		}
+		repoQuery += "type:repo count:all"
+
+		if _, ok := seen[repoQuery]; ok {
+			continue
+		}
+		seen[repoQuery] = struct{}{}
+		queries = append(queries, repoQuery)
+	}
+	return queries, nil
+}
+
+// RepositoryQuery returns q restricted to the repository repoName, with all result
+// count and timeout limits lifted.
+func RepositoryQuery(q, repoName string) (string, error) {
+	nodes, err := query.ParseStandard(q)
+	if err != nil {
+		return "", err
+	}
+	if nodes.Exists(query.FieldCount) || nodes.Exists(query.FieldTimeout) {
+		omit := func(string, bool, query.Annotation) query.Node { return nil }
+		q = query.StringHuman(query.MapField(query.MapField(nodes, query.FieldCount, omit), query.FieldTimeout, omit))
+	}
+	return fmt.Sprintf("repo:^%s$ count:all (%s)", regexp.QuoteMeta(repoName), q), nil
+}
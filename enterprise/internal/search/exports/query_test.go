@@ -0,0 +1,48 @@
+package exports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryQueries(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{query: "foo", want: []string{"type:repo count:all"}},
+		{query: "repo:a fork:yes lang:go foo count:10", want: []string{"repo:a fork:yes type:repo count:all"}},
+		{query: "context:@me foo or bar", want: []string{"context:@me type:repo count:all"}},
+		{query: "(repo:a foo) or (repo:b bar)", want: []string{"repo:a type:repo count:all", "repo:b type:repo count:all"}},
+		{query: "(repo:a foo) or (repo:a bar)", want: []string{"repo:a type:repo count:all"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			got, err := RepositoryQueries(tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRepositoryQuery(t *testing.T) {
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{query: "foo", want: `repo:^github\.com/a/b\.c$ count:all (foo)`},
+		{query: "repo:a lang:go foo bar", want: `repo:^github\.com/a/b\.c$ count:all (repo:a lang:go foo bar)`},
+		{query: "repo:a /fo+/ count:10 timeout:3s", want: `repo:^github\.com/a/b\.c$ count:all (repo:a /fo+/)`},
+		{query: "(repo:a foo) or (repo:b bar)", want: `repo:^github\.com/a/b\.c$ count:all ((repo:a foo) or (repo:b bar))`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			got, err := RepositoryQuery(tc.query, "github.com/a/b.c")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.NoError(t, ValidateQuery(got))
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	// ErrNotFound is returned when a search export does not exist.
+	ErrNotFound = errors.New("search export not found")
+	// ErrTooManyActiveExports is returned when a user already has the maximum number of
+	// exports queued or processing.
+	ErrTooManyActiveExports = errors.New("too many search exports in progress, wait for one to finish or cancel it")
+)
+
+var searchExportColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_exports.id"),
+	sqlf.Sprintf("search_exports.state"),
+	sqlf.Sprintf("search_exports.failure_message"),
+	sqlf.Sprintf("search_exports.queued_at"),
+	sqlf.Sprintf("search_exports.started_at"),
+	sqlf.Sprintf("search_exports.finished_at"),
+	sqlf.Sprintf("search_exports.process_after"),
+	sqlf.Sprintf("search_exports.num_resets"),
+	sqlf.Sprintf("search_exports.num_failures"),
+	sqlf.Sprintf("search_exports.last_heartbeat_at"),
+	sqlf.Sprintf("search_exports.execution_logs"),
+	sqlf.Sprintf("search_exports.worker_hostname"),
+	sqlf.Sprintf("search_exports.cancel"),
+	sqlf.Sprintf("search_exports.user_id"),
+	sqlf.Sprintf("search_exports.query"),
+	sqlf.Sprintf("search_exports.format"),
+	sqlf.Sprintf("search_exports.repos_total"),
+	sqlf.Sprintf("search_exports.repos_done"),
+	sqlf.Sprintf("search_exports.match_count"),
+	sqlf.Sprintf("search_exports.object_key"),
+}
+
+func scanSearchExport(s dbutil.Scanner) (*SearchExport, error) {
+	var export SearchExport
+	var executionLogs []executor.ExecutionLogEntry
+
+	if err := s.Scan(
+		&export.ID,
+		&export.State,
+		&export.FailureMessage,
+		&export.QueuedAt,
+		&export.StartedAt,
+		&export.FinishedAt,
+		&export.ProcessAfter,
+		&export.NumResets,
+		&export.NumFailures,
+		&export.LastHeartbeatAt,
+		pq.Array(&executionLogs),
+		&export.WorkerHostname,
+		&export.Cancel,
+		&export.UserID,
+		&export.Query,
+		&export.Format,
+		&export.ReposTotal,
+		&export.ReposDone,
+		&export.MatchCount,
+		&export.ObjectKey,
+	); err != nil {
+		return nil, err
+	}
+	export.ExecutionLogs = append(export.ExecutionLogs, executionLogs...)
+	return &export, nil
+}
+
+func NewWorkerStore(observationCtx *observation.Context, dbHandle basestore.TransactableHandle) dbworkerstore.Store[*SearchExport] {
+	return dbworkerstore.New(observationCtx, dbHandle, dbworkerstore.Options[*SearchExport]{
+		Name:              "search_export_worker",
+		TableName:         "search_exports",
+		ColumnExpressions: searchExportColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(scanSearchExport),
+		OrderByExpression: sqlf.Sprintf("search_exports.queued_at, search_exports.id"),
+		StalledMaxAge:     time.Second * 60,
+		MaxNumResets:      5,
+		MaxNumRetries:     1,
+	})
+}
+
+type Store interface {
+	basestore.ShareableStore
+
+	Transact(ctx context.Context) (Store, error)
+	Done(err error) error
+
+	// Create queues an export of query for the user, unless the user already has
+	// maxActive exports queued or processing.
+	Create(ctx context.Context, userID int32, query string, format Format, maxActive int) (*SearchExport, error)
+	GetByID(ctx context.Context, id int) (*SearchExport, error)
+	ListForUser(ctx context.Context, userID int32, args *database.PaginationArgs) ([]*SearchExport, error)
+	CountForUser(ctx context.Context, userID int32) (int, error)
+	// Cancel cancels a queued export directly and flags a processing export so the
+	// worker aborts it.
+	Cancel(ctx context.Context, id int) error
+
+	UpdateProgress(ctx context.Context, id, reposTotal, reposDone, matchCount int) error
+	SetObjectKey(ctx context.Context, id int, key string) error
+
+	// ListExpired returns up to limit exports that finished before the given time.
+	ListExpired(ctx context.Context, finishedBefore time.Time, limit int) ([]*SearchExport, error)
+	// DeleteByIDs deletes the exports with the given IDs.
+	DeleteByIDs(ctx context.Context, ids []int) error
+}
+
+var _ basestore.ShareableStore = &store{}
+
+type store struct {
+	*basestore.Store
+}
+
+func NewStore(other basestore.ShareableStore) Store {
+	return &store{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *store) Transact(ctx context.Context) (Store, error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &store{Store: tx}, nil
+}
+
+// createLockNamespace is the advisory lock namespace of the per-user lock taken when
+// creating an export.
+const createLockNamespace = "search_exports_create"
+
+const createSearchExportQueryFmtstr = `
+INSERT INTO search_exports (user_id, query, format)
+SELECT %s, %s, %s
+WHERE (
+	SELECT COUNT(*)
+	FROM search_exports
+	WHERE user_id = %s AND state IN ('queued', 'processing', 'errored')
+) < %s
+RETURNING %s
+`
+
+func (s *store) Create(ctx context.Context, userID int32, query string, format Format, maxActive int) (_ *SearchExport, err error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Concurrent creates of the same user are serialized, so that they cannot all pass the
+	// limit check before any of them has inserted its export.
+	if _, err := locker.NewWith(tx, createLockNamespace).LockInTransaction(ctx, userID, true); err != nil {
+		return nil, err
+	}
+
+	q := sqlf.Sprintf(
+		createSearchExportQueryFmtstr,
+		userID,
+		query,
+		format,
+		userID,
+		maxActive,
+		sqlf.Join(searchExportColumns, ", "),
+	)
+	export, err := scanSearchExport(tx.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, ErrTooManyActiveExports
+	}
+	return export, err
+}
+
+const getSearchExportQueryFmtstr = `
+SELECT %s
+FROM search_exports
+WHERE id = %s
+`
+
+func (s *store) GetByID(ctx context.Context, id int) (*SearchExport, error) {
+	q := sqlf.Sprintf(getSearchExportQueryFmtstr, sqlf.Join(searchExportColumns, ", "), id)
+	export, err := scanSearchExport(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return export, err
+}
+
+const listSearchExportsQueryFmtstr = `
+SELECT %s
+FROM search_exports
+WHERE %s
+`
+
+func (s *store) ListForUser(ctx context.Context, userID int32, paginationArgs *database.PaginationArgs) (_ []*SearchExport, err error) {
+	pagination := paginationArgs.SQL()
+
+	conds := []*sqlf.Query{sqlf.Sprintf("user_id = %s", userID)}
+	if pagination.Where != nil {
+		conds = append(conds, pagination.Where)
+	}
+
+	q := sqlf.Sprintf(listSearchExportsQueryFmtstr, sqlf.Join(searchExportColumns, ", "), sqlf.Join(conds, "\n AND "))
+	q = pagination.AppendOrderToQuery(q)
+	q = pagination.AppendLimitToQuery(q)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var exports []*SearchExport
+	for rows.Next() {
+		export, err := scanSearchExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, export)
+	}
+	return exports, nil
+}
+
+const countSearchExportsQueryFmtstr = `
+SELECT COUNT(*)
+FROM search_exports
+WHERE user_id = %s
+`
+
+func (s *store) CountForUser(ctx context.Context, userID int32) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(countSearchExportsQueryFmtstr, userID)))
+	return count, err
+}
+
+const cancelSearchExportQueryFmtstr = `
+UPDATE search_exports
+SET
+	cancel = TRUE,
+	-- Queued exports are canceled directly, processing exports are aborted by the
+	-- worker, which marks them as failed.
+	state = CASE WHEN state = 'processing' THEN state ELSE 'canceled' END,
+	finished_at = CASE WHEN state = 'processing' THEN finished_at ELSE NOW() END
+WHERE id = %s AND state IN ('queued', 'processing', 'errored')
+`
+
+func (s *store) Cancel(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(cancelSearchExportQueryFmtstr, id))
+}
+
+const updateSearchExportProgressQueryFmtstr = `
+UPDATE search_exports
+SET repos_total = %s, repos_done = %s, match_count = %s
+WHERE id = %s
+`
+
+func (s *store) UpdateProgress(ctx context.Context, id, reposTotal, reposDone, matchCount int) error {
+	return s.Exec(ctx, sqlf.Sprintf(updateSearchExportProgressQueryFmtstr, reposTotal, reposDone, matchCount, id))
+}
+
+const setSearchExportObjectKeyQueryFmtstr = `
+UPDATE search_exports
+SET object_key = %s
+WHERE id = %s
+`
+
+func (s *store) SetObjectKey(ctx context.Context, id int, key string) error {
+	return s.Exec(ctx, sqlf.Sprintf(setSearchExportObjectKeyQueryFmtstr, key, id))
+}
+
+const listExpiredSearchExportsQueryFmtstr = `
+SELECT %s
+FROM search_exports
+WHERE state IN ('completed', 'failed', 'canceled') AND finished_at < %s
+ORDER BY finished_at, id
+LIMIT %s
+`
+
+func (s *store) ListExpired(ctx context.Context, finishedBefore time.Time, limit int) ([]*SearchExport, error) {
+	q := sqlf.Sprintf(listExpiredSearchExportsQueryFmtstr, sqlf.Join(searchExportColumns, ", "), finishedBefore, limit)
+	return basestore.NewSliceScanner(scanSearchExport)(s.Query(ctx, q))
+}
+
+const deleteSearchExportsQueryFmtstr = `
+DELETE FROM search_exports
+WHERE id = ANY(%s)
+`
+
+func (s *store) DeleteByIDs(ctx context.Context, ids []int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteSearchExportsQueryFmtstr, pq.Array(ids)))
+}
@@ -0,0 +1,129 @@
+package exports
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "alice"})
+	require.NoError(t, err)
+	other, err := db.Users().Create(ctx, database.NewUser{Username: "bob"})
+	require.NoError(t, err)
+
+	store := NewStore(db)
+
+	first, err := store.Create(ctx, user.ID, "repo:a foo", FormatCSV, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "queued", first.State)
+	assert.Equal(t, FormatCSV, first.Format)
+
+	second, err := store.Create(ctx, user.ID, "repo:b foo", FormatJSONLines, 2)
+	require.NoError(t, err)
+
+	// The user has reached the limit of active exports, other users have not.
+	_, err = store.Create(ctx, user.ID, "repo:c foo", FormatCSV, 2)
+	require.ErrorIs(t, err, ErrTooManyActiveExports)
+	_, err = store.Create(ctx, other.ID, "repo:c foo", FormatCSV, 2)
+	require.NoError(t, err)
+
+	// Canceling an export frees up a slot.
+	require.NoError(t, store.Cancel(ctx, first.ID))
+	canceled, err := store.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "canceled", canceled.State)
+	assert.True(t, canceled.Cancel)
+	assert.NotNil(t, canceled.FinishedAt)
+
+	third, err := store.Create(ctx, user.ID, "repo:c foo", FormatCSV, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, store.UpdateProgress(ctx, second.ID, 10, 4, 123))
+	require.NoError(t, store.SetObjectKey(ctx, second.ID, NewObjectKey(second.ID, second.Format)))
+	updated, err := store.GetByID(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, updated.ReposTotal)
+	assert.Equal(t, 4, updated.ReposDone)
+	assert.Equal(t, 123, updated.MatchCount)
+	require.NotNil(t, updated.ObjectKey)
+	assert.Equal(t, NewObjectKey(second.ID, FormatJSONLines), *updated.ObjectKey)
+
+	count, err := store.CountForUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	list, err := store.ListForUser(ctx, user.ID, &database.PaginationArgs{OrderBy: database.OrderBy{{Field: "id"}}})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, []int{third.ID, second.ID, first.ID}, []int{list[0].ID, list[1].ID, list[2].ID})
+
+	_, err = store.GetByID(ctx, third.ID+100)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Only finished exports expire.
+	expired, err := store.ListExpired(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, first.ID, expired[0].ID)
+	expired, err = store.ListExpired(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+
+	require.NoError(t, store.DeleteByIDs(ctx, []int{first.ID}))
+	_, err = store.GetByID(ctx, first.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStoreCreateConcurrent(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "alice"})
+	require.NoError(t, err)
+
+	store := NewStore(db)
+
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int32
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.Create(ctx, user.ID, "foo", FormatCSV, 2); err == nil {
+				created.Add(1)
+			} else {
+				assert.ErrorIs(t, err, ErrTooManyActiveExports)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), created.Load())
+}
@@ -0,0 +1,71 @@
+package exports
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+)
+
+// Format is the file format matches of a search export are written in.
+type Format string
+
+const (
+	FormatCSV       Format = "csv"
+	FormatJSONLines Format = "jsonl"
+)
+
+// objectKeyPrefix is the prefix of the keys of all export files in the upload store.
+const objectKeyPrefix = "search-exports/"
+
+// Valid returns whether f is a supported export format.
+func (f Format) Valid() bool {
+	return f == FormatCSV || f == FormatJSONLines
+}
+
+// ContentType returns the MIME type of files in format f.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// SearchExport is a request to write all results of a search query to the upload store.
+type SearchExport struct {
+	ID              int
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt *time.Time
+	ExecutionLogs   []executor.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+
+	UserID     int32
+	Query      string
+	Format     Format
+	ReposTotal int
+	ReposDone  int
+	MatchCount int
+	ObjectKey  *string
+}
+
+func (e *SearchExport) RecordID() int {
+	return e.ID
+}
+
+// Filename is the name of the file the export is downloaded as.
+func (e *SearchExport) Filename() string {
+	return fmt.Sprintf("search-export-%d.%s", e.ID, e.Format)
+}
+
+// NewObjectKey returns the key the file of export id is stored under in the upload store.
+func NewObjectKey(id int, format Format) string {
+	return fmt.Sprintf("%s%d.%s", objectKeyPrefix, id, format)
+}
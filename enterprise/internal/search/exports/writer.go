@@ -0,0 +1,108 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+// MatchWriter writes the matches of a search to an export file.
+type MatchWriter interface {
+	Write(match streamhttp.EventMatch) error
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+}
+
+// NewMatchWriter returns a MatchWriter that writes to w in the given format.
+func NewMatchWriter(w io.Writer, format Format) MatchWriter {
+	if format == FormatCSV {
+		return &csvWriter{w: csv.NewWriter(w)}
+	}
+	return &jsonLinesWriter{enc: json.NewEncoder(w)}
+}
+
+// jsonLinesWriter writes each match as a JSON object on its own line, in the same
+// representation the streaming search API uses.
+type jsonLinesWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonLinesWriter) Write(match streamhttp.EventMatch) error {
+	return w.enc.Encode(match)
+}
+
+func (w *jsonLinesWriter) Flush() error {
+	return nil
+}
+
+var csvHeader = []string{"type", "repository", "commit", "path", "line", "preview"}
+
+// csvWriter writes a row per matched line, symbol, path, repository or commit.
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) Write(match streamhttp.EventMatch) error {
+	if !w.headerWritten {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	for _, row := range csvRows(match) {
+		if err := w.w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *csvWriter) Flush() error {
+	if !w.headerWritten {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func csvRows(match streamhttp.EventMatch) [][]string {
+	switch m := match.(type) {
+	case *streamhttp.EventContentMatch:
+		var rows [][]string
+		for _, chunk := range m.ChunkMatches {
+			for i, line := range strings.Split(strings.TrimSuffix(chunk.Content, "\n"), "\n") {
+				rows = append(rows, []string{"content", m.Repository, m.Commit, m.Path, strconv.Itoa(chunk.ContentStart.Line + i + 1), line})
+			}
+		}
+		for _, line := range m.LineMatches {
+			rows = append(rows, []string{"content", m.Repository, m.Commit, m.Path, strconv.Itoa(int(line.LineNumber) + 1), line.Line})
+		}
+		return rows
+	case *streamhttp.EventPathMatch:
+		return [][]string{{"path", m.Repository, m.Commit, m.Path, "", ""}}
+	case *streamhttp.EventSymbolMatch:
+		rows := make([][]string, 0, len(m.Symbols))
+		for _, symbol := range m.Symbols {
+			rows = append(rows, []string{"symbol", m.Repository, m.Commit, m.Path, strconv.Itoa(int(symbol.Line)), symbol.Name})
+		}
+		return rows
+	case *streamhttp.EventRepoMatch:
+		return [][]string{{"repo", m.Repository, "", "", "", m.Description}}
+	case *streamhttp.EventCommitMatch:
+		message, _, _ := strings.Cut(m.Message, "\n")
+		return [][]string{{"commit", m.Repository, m.OID, "", "", message}}
+	case *streamhttp.EventPersonMatch:
+		return [][]string{{"person", "", "", "", "", m.Handle}}
+	case *streamhttp.EventTeamMatch:
+		return [][]string{{"team", "", "", "", "", m.Handle}}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package exports
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+var testMatches = []streamhttp.EventMatch{
+	&streamhttp.EventContentMatch{
+		Type:       streamhttp.ContentMatchType,
+		Repository: "github.com/a/b",
+		Commit:     "deadbeef",
+		Path:       "main.go",
+		ChunkMatches: []streamhttp.ChunkMatch{{
+			Content:      "func main() {\n\tfmt.Println(\"hi, there\")\n",
+			ContentStart: streamhttp.Location{Line: 4},
+		}},
+	},
+	&streamhttp.EventPathMatch{
+		Type:       streamhttp.PathMatchType,
+		Repository: "github.com/a/b",
+		Commit:     "deadbeef",
+		Path:       "README.md",
+	},
+	&streamhttp.EventCommitMatch{
+		Type:       streamhttp.CommitMatchType,
+		Repository: "github.com/a/b",
+		OID:        "cafe",
+		Message:    "Fix bug\n\nLonger description",
+	},
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewMatchWriter(&buf, FormatCSV)
+	for _, match := range testMatches {
+		require.NoError(t, w.Write(match))
+	}
+	require.NoError(t, w.Flush())
+
+	want := `type,repository,commit,path,line,preview
+content,github.com/a/b,deadbeef,main.go,5,func main() {
+content,github.com/a/b,deadbeef,main.go,6,"	fmt.Println(""hi, there"")"
+path,github.com/a/b,deadbeef,README.md,,
+commit,github.com/a/b,cafe,,,Fix bug
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestCSVWriterNoMatches(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewMatchWriter(&buf, FormatCSV).Flush())
+	assert.Equal(t, "type,repository,commit,path,line,preview\n", buf.String())
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewMatchWriter(&buf, FormatJSONLines)
+	for _, match := range testMatches[1:] {
+		require.NoError(t, w.Write(match))
+	}
+	require.NoError(t, w.Flush())
+
+	want := `{"type":"path","path":"README.md","repositoryID":0,"repository":"github.com/a/b","commit":"deadbeef"}
+{"type":"commit","label":"","url":"","detail":"","repositoryID":0,"repository":"github.com/a/b","oid":"cafe","message":"Fix bug\n\nLonger description","authorName":"","authorDate":"0001-01-01T00:00:00Z","committerName":"","committerDate":"0001-01-01T00:00:00Z","content":"","ranges":null}
+`
+	assert.Equal(t, want, buf.String())
+}
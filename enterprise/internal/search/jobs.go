@@ -2,16 +2,21 @@ package search
 
 import (
 	ownsearch "github.com/sourcegraph/sourcegraph/enterprise/internal/own/search"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/symbol"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 )
 
-func NewEnterpriseSearchJobs() jobutil.EnterpriseJobs {
-	return &enterpriseJobs{}
+// NewEnterpriseSearchJobs creates the enterprise search jobs. The precise symbol searcher
+// may be nil, in which case symbol results always come from ctags.
+func NewEnterpriseSearchJobs(preciseSymbols symbol.PreciseSymbolSearcher) jobutil.EnterpriseJobs {
+	return &enterpriseJobs{preciseSymbols: preciseSymbols}
 }
 
-type enterpriseJobs struct{}
+type enterpriseJobs struct {
+	preciseSymbols symbol.PreciseSymbolSearcher
+}
 
 func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job {
 	return ownsearch.NewFileHasOwnersJob(child, features, includeOwners, excludeOwners)
@@ -20,3 +25,7 @@ func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Feature
 func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Features) job.Job {
 	return ownsearch.NewSelectOwnersJob(child, features)
 }
+
+func (e *enterpriseJobs) PreciseSymbolSearchJob(child job.Job, repoOpts search.RepoOptions, patternInfo *search.TextPatternInfo, limit int) job.Job {
+	return symbol.NewPreciseSymbolSearchJob(child, e.preciseSymbols, repoOpts, patternInfo, limit)
+}
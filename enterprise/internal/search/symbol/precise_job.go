@@ -0,0 +1,362 @@
+package symbol
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/grafana/regexp"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/conc/pool"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	codenavshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// PreciseSymbolSearcher searches the symbols defined in the precise indexes visible from a
+// particular commit of a repository.
+type PreciseSymbolSearcher interface {
+	GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]uploadsshared.Dump, error)
+	SearchSymbols(ctx context.Context, dumps []uploadsshared.Dump, args codenav.SymbolSearchArgs) ([]codenavshared.PreciseSymbol, error)
+}
+
+// NewPreciseSymbolSearchJob returns a job that searches precise indexes for symbols matching
+// the given pattern, concurrently with the given child job. Symbol results from the child job
+// are dropped for the files covered by a precise index, so that ctags results are only returned
+// where no precise index describes the file.
+func NewPreciseSymbolSearchJob(
+	child job.Job,
+	searcher PreciseSymbolSearcher,
+	repoOpts search.RepoOptions,
+	patternInfo *search.TextPatternInfo,
+	limit int,
+) job.Job {
+	if searcher == nil {
+		return child
+	}
+
+	return &preciseSymbolSearchJob{
+		child:       child,
+		searcher:    searcher,
+		repoOpts:    repoOpts,
+		patternInfo: patternInfo,
+		limit:       limit,
+	}
+}
+
+type preciseSymbolSearchJob struct {
+	child       job.Job
+	searcher    PreciseSymbolSearcher
+	repoOpts    search.RepoOptions
+	patternInfo *search.TextPatternInfo
+	limit       int
+}
+
+func (s *preciseSymbolSearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	pathFilter, err := newPathFilter(s.patternInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	dumps := newDumpCache(s.searcher)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		event.Results = dropCoveredSymbols(event.Results, func(fm *result.FileMatch) bool {
+			repoDumps, err := dumps.get(ctx, fm.Repo.ID, fm.CommitID)
+			if err != nil {
+				// Keep the ctags results if we cannot tell whether they are covered
+				clients.Logger.Warn("failed to get precise indexes",
+					sglog.String("repo", string(fm.Repo.Name)),
+					sglog.Error(err))
+				return false
+			}
+			return dumpsCoverPath(repoDumps, fm.Path)
+		})
+		stream.Send(event)
+	})
+
+	p := pool.New().WithContext(ctx)
+	p.Go(func(ctx context.Context) error {
+		childAlert, err := s.child.Run(ctx, clients, filteredStream)
+		alert = childAlert
+		return err
+	})
+	p.Go(func(ctx context.Context) error {
+		return s.searchRepos(ctx, clients, stream, dumps, pathFilter)
+	})
+	err = p.Wait()
+	return alert, err
+}
+
+// searchRepos streams the precise symbols of all searched repositories.
+func (s *preciseSymbolSearchJob) searchRepos(
+	ctx context.Context,
+	clients job.RuntimeClients,
+	stream streaming.Sender,
+	dumps *dumpCache,
+	pathFilter func(path string) bool,
+) error {
+	p := pool.New().WithContext(ctx).WithMaxGoroutines(conf.SearchSymbolsParallelism())
+
+	it := repos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt).Iterator(ctx, s.repoOpts)
+	for it.Next() {
+		for _, repoRevs := range it.Current().RepoRevs {
+			repoRevs := repoRevs
+			if len(repoRevs.Revs) == 0 {
+				continue
+			}
+
+			p.Go(func(ctx context.Context) error {
+				matches, err := s.searchRepo(ctx, clients.Gitserver, dumps, repoRevs, pathFilter)
+				if err != nil {
+					// A failure to read precise data must not fail the search; the
+					// repository is served from ctags instead.
+					clients.Logger.Warn("failed to search precise symbols",
+						sglog.String("repo", string(repoRevs.Repo.Name)),
+						sglog.Error(err))
+					return nil
+				}
+				if len(matches) > 0 {
+					stream.Send(streaming.SearchEvent{Results: matches})
+				}
+				return nil
+			})
+		}
+	}
+	if err := p.Wait(); err != nil {
+		return err
+	}
+
+	return it.Err()
+}
+
+// searchRepo returns the precise symbols of the given repository as file matches.
+func (s *preciseSymbolSearchJob) searchRepo(
+	ctx context.Context,
+	gitserverClient gitserver.Client,
+	dumps *dumpCache,
+	repoRevs *search.RepositoryRevisions,
+	pathFilter func(path string) bool,
+) (result.Matches, error) {
+	inputRev := repoRevs.Revs[0]
+	commitID, err := gitserverClient.ResolveRevision(ctx, repoRevs.GitserverRepo(), inputRev, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	repoDumps, err := dumps.get(ctx, repoRevs.Repo.ID, commitID)
+	if err != nil || len(repoDumps) == 0 {
+		return nil, err
+	}
+
+	symbols, err := s.searcher.SearchSymbols(ctx, repoDumps, codenav.SymbolSearchArgs{
+		Pattern:         s.patternInfo.Pattern,
+		IsCaseSensitive: s.patternInfo.IsCaseSensitive,
+		Limit:           s.limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type fileKey struct {
+		commit string
+		path   string
+	}
+	symbolsByFile := map[fileKey][]codenavshared.PreciseSymbol{}
+	for _, symbol := range symbols {
+		if !pathFilter(symbol.Path) {
+			continue
+		}
+
+		key := fileKey{commit: symbol.Commit, path: symbol.Path}
+		symbolsByFile[key] = append(symbolsByFile[key], symbol)
+	}
+
+	matches := make(result.Matches, 0, len(symbolsByFile))
+	for key, symbols := range symbolsByFile {
+		file := result.File{
+			Path:     key.path,
+			Repo:     repoRevs.Repo,
+			CommitID: api.CommitID(key.commit),
+			InputRev: &inputRev,
+		}
+
+		symbolMatches := make([]*result.SymbolMatch, 0, len(symbols))
+		for _, symbol := range symbols {
+			symbolMatches = append(symbolMatches, &result.SymbolMatch{
+				File: &file,
+				Symbol: result.Symbol{
+					Name:               symbol.Name,
+					Path:               symbol.Path,
+					Line:               symbol.Range.Start.Line + 1,
+					Character:          symbol.Range.Start.Character,
+					Kind:               symbol.Kind,
+					Parent:             symbol.Parent,
+					FullyQualifiedName: symbol.Symbol,
+					PackageName:        symbol.PackageName,
+					PackageVersion:     symbol.PackageVersion,
+				},
+			})
+		}
+
+		matches = append(matches, &result.FileMatch{
+			File:    file,
+			Symbols: symbolMatches,
+		})
+	}
+
+	// Make the results deterministic
+	sort.Sort(matches)
+	return matches, nil
+}
+
+// dumpCache memoizes the precise indexes visible from each searched commit, which are looked
+// up both to search precise symbols and to drop the ctags results of covered files.
+type dumpCache struct {
+	searcher PreciseSymbolSearcher
+
+	mu      sync.Mutex
+	entries map[dumpCacheKey]*dumpCacheEntry
+}
+
+type dumpCacheKey struct {
+	repoID   api.RepoID
+	commitID api.CommitID
+}
+
+type dumpCacheEntry struct {
+	once  sync.Once
+	dumps []uploadsshared.Dump
+	err   error
+}
+
+func newDumpCache(searcher PreciseSymbolSearcher) *dumpCache {
+	return &dumpCache{
+		searcher: searcher,
+		entries:  map[dumpCacheKey]*dumpCacheEntry{},
+	}
+}
+
+func (c *dumpCache) get(ctx context.Context, repoID api.RepoID, commitID api.CommitID) ([]uploadsshared.Dump, error) {
+	key := dumpCacheKey{repoID: repoID, commitID: commitID}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &dumpCacheEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.dumps, entry.err = c.searcher.GetClosestDumpsForBlob(ctx, int(repoID), string(commitID), "", false, "")
+	})
+	return entry.dumps, entry.err
+}
+
+// dumpsCoverPath returns true if one of the given precise indexes describes the file with the
+// given path: the file must be within the root of the index and in the language of its indexer.
+func dumpsCoverPath(dumps []uploadsshared.Dump, path string) bool {
+	for _, dump := range dumps {
+		if strings.HasPrefix(path, dump.Root) && uploadsshared.IndexerCoversPath(dump.Indexer, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newPathFilter returns a function that reports whether a path satisfies the include and
+// exclude patterns of the given pattern info.
+func newPathFilter(patternInfo *search.TextPatternInfo) (func(path string) bool, error) {
+	compile := func(pattern string) (*regexp.Regexp, error) {
+		if !patternInfo.PathPatternsAreCaseSensitive {
+			pattern = "(?i:" + pattern + ")"
+		}
+		return regexp.Compile(pattern)
+	}
+
+	includes := make([]*regexp.Regexp, 0, len(patternInfo.IncludePatterns))
+	for _, pattern := range patternInfo.IncludePatterns {
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		includes = append(includes, re)
+	}
+
+	var exclude *regexp.Regexp
+	if patternInfo.ExcludePattern != "" {
+		re, err := compile(patternInfo.ExcludePattern)
+		if err != nil {
+			return nil, err
+		}
+		exclude = re
+	}
+
+	return func(path string) bool {
+		for _, re := range includes {
+			if !re.MatchString(path) {
+				return false
+			}
+		}
+		return exclude == nil || !exclude.MatchString(path)
+	}, nil
+}
+
+// dropCoveredSymbols removes the symbol-only file matches of files that are covered by precise
+// symbols. Matches carrying content are kept, as they are not symbol results.
+func dropCoveredSymbols(matches result.Matches, isCovered func(fm *result.FileMatch) bool) result.Matches {
+	filtered := matches[:0]
+	for _, match := range matches {
+		if fm, ok := match.(*result.FileMatch); ok && len(fm.Symbols) > 0 && len(fm.ChunkMatches) == 0 && isCovered(fm) {
+			continue
+		}
+		filtered = append(filtered, match)
+	}
+
+	return filtered
+}
+
+func (s *preciseSymbolSearchJob) Name() string {
+	return "PreciseSymbolSearchJob"
+}
+
+func (s *preciseSymbolSearchJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Scoped("patternInfo", s.patternInfo.Fields()...),
+			trace.Scoped("repoOpts", s.repoOpts.Tags()...),
+			otlog.Int("limit", s.limit),
+		)
+	}
+	return res
+}
+
+func (s *preciseSymbolSearchJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *preciseSymbolSearchJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
@@ -0,0 +1,167 @@
+package symbol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	codenavshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakePreciseSymbolSearcher struct {
+	dumps   []uploadsshared.Dump
+	symbols []codenavshared.PreciseSymbol
+	commits []string
+	args    []codenav.SymbolSearchArgs
+}
+
+func (f *fakePreciseSymbolSearcher) GetClosestDumpsForBlob(_ context.Context, _ int, commit, _ string, _ bool, _ string) ([]uploadsshared.Dump, error) {
+	f.commits = append(f.commits, commit)
+	return f.dumps, nil
+}
+
+func (f *fakePreciseSymbolSearcher) SearchSymbols(_ context.Context, dumps []uploadsshared.Dump, args codenav.SymbolSearchArgs) ([]codenavshared.PreciseSymbol, error) {
+	f.args = append(f.args, args)
+	return f.symbols, nil
+}
+
+func TestPreciseSymbolSearchJobSearchRepo(t *testing.T) {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+
+	searcher := &fakePreciseSymbolSearcher{
+		dumps: []uploadsshared.Dump{{ID: 1, Commit: "cafebabe", Indexer: "scip-go"}},
+		symbols: []codenavshared.PreciseSymbol{
+			{
+				Commit:         "cafebabe",
+				Path:           "pkg/server.go",
+				Range:          codenavshared.Range{Start: codenavshared.Position{Line: 9, Character: 5}},
+				Symbol:         "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#",
+				PackageName:    "github.com/foo/bar",
+				PackageVersion: "v1.2.3",
+				Name:           "Server",
+				Parent:         "github.com/foo/bar/pkg",
+				Kind:           "type",
+			},
+			{
+				Commit: "cafebabe",
+				Path:   "pkg/server_test.go",
+				Name:   "ServerTest",
+				Kind:   "function",
+			},
+		},
+	}
+
+	j := NewPreciseSymbolSearchJob(nil, searcher, search.RepoOptions{}, &search.TextPatternInfo{
+		Pattern:        "server",
+		ExcludePattern: `_test\.go$`,
+	}, 50).(*preciseSymbolSearchJob)
+	pathFilter, err := newPathFilter(j.patternInfo)
+	require.NoError(t, err)
+
+	repoRevs := &search.RepositoryRevisions{
+		Repo: types.MinimalRepo{ID: 42, Name: "github.com/foo/bar"},
+		Revs: []string{"main"},
+	}
+	dumps := newDumpCache(searcher)
+	matches, err := j.searchRepo(context.Background(), gitserverClient, dumps, repoRevs, pathFilter)
+	require.NoError(t, err)
+
+	require.Len(t, searcher.args, 1)
+	assert.Equal(t, codenav.SymbolSearchArgs{Pattern: "server", Limit: 50}, searcher.args[0])
+
+	// The indexes of the resolved commit are cached for filtering ctags results
+	_, err = dumps.get(context.Background(), 42, "deadbeef")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deadbeef"}, searcher.commits)
+
+	require.Len(t, matches, 1)
+	fm := matches[0].(*result.FileMatch)
+	assert.Equal(t, "pkg/server.go", fm.Path)
+	assert.Equal(t, api.CommitID("cafebabe"), fm.CommitID)
+	require.Len(t, fm.Symbols, 1)
+	assert.Equal(t, result.Symbol{
+		Name:               "Server",
+		Path:               "pkg/server.go",
+		Line:               10,
+		Character:          5,
+		Kind:               "type",
+		Parent:             "github.com/foo/bar/pkg",
+		FullyQualifiedName: "scip-go gomod github.com/foo/bar v1.2.3 `github.com/foo/bar/pkg`/Server#",
+		PackageName:        "github.com/foo/bar",
+		PackageVersion:     "v1.2.3",
+	}, fm.Symbols[0].Symbol)
+}
+
+func TestPreciseSymbolSearchJobNoSearcher(t *testing.T) {
+	child := NewPreciseSymbolSearchJob(nil, nil, search.RepoOptions{}, &search.TextPatternInfo{}, 50)
+	assert.Nil(t, child)
+}
+
+func TestPreciseSymbolSearchJobSearchRepoNoPreciseData(t *testing.T) {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+
+	searcher := &fakePreciseSymbolSearcher{}
+	j := NewPreciseSymbolSearchJob(nil, searcher, search.RepoOptions{}, &search.TextPatternInfo{Pattern: "server"}, 50).(*preciseSymbolSearchJob)
+
+	repoRevs := &search.RepositoryRevisions{
+		Repo: types.MinimalRepo{ID: 42, Name: "github.com/foo/bar"},
+		Revs: []string{"main"},
+	}
+	matches, err := j.searchRepo(context.Background(), gitserverClient, newDumpCache(searcher), repoRevs, func(string) bool { return true })
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+	assert.Empty(t, searcher.args)
+}
+
+func TestDumpsCoverPath(t *testing.T) {
+	dumps := []uploadsshared.Dump{
+		{Root: "backend/", Indexer: "sourcegraph/scip-go"},
+		{Root: "web/", Indexer: "scip-typescript"},
+	}
+
+	for path, expected := range map[string]bool{
+		"backend/main.go":   true,
+		"backend/script.py": false,
+		"web/app.tsx":       true,
+		"web/tools/gen.go":  false,
+		"main.go":           false,
+	} {
+		assert.Equal(t, expected, dumpsCoverPath(dumps, path), path)
+	}
+
+	assert.False(t, dumpsCoverPath([]uploadsshared.Dump{{Indexer: "unknown-indexer"}}, "main.go"))
+}
+
+func TestDropCoveredSymbols(t *testing.T) {
+	symbolMatch := func(repoID api.RepoID, path string) result.Match {
+		return &result.FileMatch{
+			File:    result.File{Repo: types.MinimalRepo{ID: repoID}, Path: path},
+			Symbols: []*result.SymbolMatch{{}},
+		}
+	}
+	contentMatch := &result.FileMatch{
+		File:         result.File{Repo: types.MinimalRepo{ID: 1}, Path: "main.go"},
+		ChunkMatches: result.ChunkMatches{{}},
+	}
+
+	matches := result.Matches{symbolMatch(1, "main.go"), symbolMatch(1, "script.py"), symbolMatch(2, "main.go"), contentMatch}
+	filtered := dropCoveredSymbols(matches, func(fm *result.FileMatch) bool {
+		return fm.Repo.ID == 1 && fm.Path == "main.go"
+	})
+
+	require.Len(t, filtered, 3)
+	assert.Equal(t, "script.py", filtered[0].(*result.FileMatch).Path)
+	assert.Equal(t, api.RepoID(2), filtered[1].RepoName().ID)
+	assert.Equal(t, contentMatch, filtered[2])
+}
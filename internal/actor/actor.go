@@ -44,6 +44,11 @@ type Actor struct {
 	// cookie, logout would be ineffective.)
 	FromSessionCookie bool `json:"-"`
 
+	// AccessTokenScopes are the scopes of the access token used to authenticate the actor, if that
+	// access token is restricted to a subset of the user's privileges (i.e. it does not have the
+	// "user:all" scope). It is nil for unrestricted actors.
+	AccessTokenScopes []string `json:"-"`
+
 	// user is populated lazily by (*Actor).User()
 	user     *types.User
 	userErr  error
@@ -86,6 +91,12 @@ func (a *Actor) IsInternal() bool {
 	return a != nil && a.Internal
 }
 
+// IsRestricted returns true if the Actor was authenticated with an access token that only grants
+// a subset of the user's privileges.
+func (a *Actor) IsRestricted() bool {
+	return a != nil && a.AccessTokenScopes != nil
+}
+
 // IsMockUser returns true if the Actor is a test user.
 func (a *Actor) IsMockUser() bool {
 	return a != nil && a.mockUser
@@ -3,12 +3,14 @@ package audit
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/requestclient"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -41,6 +43,19 @@ func Log(ctx context.Context, logger log.Logger, record Record) {
 	loggerFunc := getLoggerFuncWithSeverity(logger, siteConfig)
 	// message string looks like: #{record.Action} (sampling immunity token: #{auditId})
 	loggerFunc(fmt.Sprintf("%s (sampling immunity token: %s)", record.Action, auditId), fields...)
+
+	writeToSinks(Entry{
+		AuditID:      auditId,
+		Entity:       record.Entity,
+		Action:       record.Action,
+		ActorUID:     act.UID,
+		AnonymousUID: act.AnonymousUID,
+		IP:           clientIP(client),
+		ForwardedFor: clientForwardedFor(client),
+		Fields:       fieldsToMap(record.Fields),
+		Source:       env.MyName,
+		Timestamp:    time.Now().UTC(),
+	})
 }
 
 func actorId(act *actor.Actor) string {
@@ -67,6 +82,20 @@ func forwardedFor(client *requestclient.Client) string {
 	return client.ForwardedFor
 }
 
+func clientIP(client *requestclient.Client) string {
+	if client == nil {
+		return ""
+	}
+	return client.IP
+}
+
+func clientForwardedFor(client *requestclient.Client) string {
+	if client == nil {
+		return ""
+	}
+	return client.ForwardedFor
+}
+
 type Record struct {
 	// Entity is the name of the audited entity
 	Entity string
@@ -101,6 +130,27 @@ func IsEnabled(cfg schema.SiteConfiguration, setting AuditLogSetting) bool {
 	return false
 }
 
+// DefaultRetentionDays is the number of days audit log entries are kept in the database if not
+// configured otherwise.
+const DefaultRetentionDays = 90
+
+// RetentionDays returns the number of days audit log entries are kept in the database.
+func RetentionDays(cfg schema.SiteConfiguration) int {
+	if auditCfg := getAuditCfg(cfg); auditCfg != nil && auditCfg.RetentionDays > 0 {
+		return auditCfg.RetentionDays
+	}
+	return DefaultRetentionDays
+}
+
+// ExportConfig returns the configuration of the audit log export, or nil if the audit log is not
+// exported.
+func ExportConfig(cfg schema.SiteConfiguration) *schema.AuditLogExport {
+	if auditCfg := getAuditCfg(cfg); auditCfg != nil {
+		return auditCfg.Export
+	}
+	return nil
+}
+
 // getLoggerFuncWithSeverity returns a specific logger function (logger.Info, logger.Warn, etc.), a the severity is configurable.
 func getLoggerFuncWithSeverity(logger log.Logger, cfg schema.SiteConfiguration) func(string, ...log.Field) {
 	if auditCfg := getAuditCfg(cfg); auditCfg != nil {
@@ -238,3 +238,47 @@ func auditLogMessage(t *testing.T) []logtest.CapturedLog {
 
 	return exportLogs()
 }
+
+type recordingSink struct {
+	entries []Entry
+}
+
+func (s *recordingSink) Write(entry Entry) {
+	s.entries = append(s.entries, entry)
+}
+
+func TestLogWritesToSinks(t *testing.T) {
+	sink := &recordingSink{}
+	RegisterSink(sink)
+	t.Cleanup(func() { sinks = nil })
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	ctx = requestclient.WithClient(ctx, &requestclient.Client{IP: "192.168.0.1", ForwardedFor: "10.0.0.1"})
+
+	logger, exportLogs := logtest.Captured(t)
+	Log(ctx, logger, Record{
+		Entity: "test entity",
+		Action: "test audit action",
+		Fields: []log.Field{log.Object("request", log.String("name", "test"))},
+	})
+
+	logs := exportLogs()
+	if len(logs) != 1 || len(sink.entries) != 1 {
+		t.Fatalf("expected exactly one log and one sink entry, got %d and %d", len(logs), len(sink.entries))
+	}
+
+	entry := sink.entries[0]
+	assert.Equal(t, logs[0].Fields["audit"].(map[string]interface{})["auditId"], entry.AuditID)
+	assert.Equal(t, "test entity", entry.Entity)
+	assert.Equal(t, "test audit action", entry.Action)
+	assert.Equal(t, int32(1), entry.ActorUID)
+	assert.Equal(t, "192.168.0.1", entry.IP)
+	assert.Equal(t, "10.0.0.1", entry.ForwardedFor)
+	assert.Equal(t, map[string]any{"request": map[string]any{"name": "test"}}, entry.Fields)
+	assert.False(t, entry.Timestamp.IsZero())
+}
+
+func TestRetentionDays(t *testing.T) {
+	assert.Equal(t, DefaultRetentionDays, RetentionDays(schema.SiteConfiguration{}))
+	assert.Equal(t, 7, RetentionDays(schema.SiteConfiguration{Log: &schema.Log{AuditLog: &schema.AuditLog{RetentionDays: 7}}}))
+}
@@ -0,0 +1,130 @@
+// Package dbsink persists the entries of the audit log to the database, so they can be
+// queried by site admins and exported to a SIEM.
+package dbsink
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+const (
+	// bufferSize is the number of entries that can be queued before new entries are
+	// dropped. Recording an audit log entry must never block the audited action.
+	bufferSize = 1000
+	// batchSize is the maximum number of entries inserted in a single query.
+	batchSize = 100
+	// flushInterval is the maximum time an entry is queued before it is persisted.
+	flushInterval = 5 * time.Second
+)
+
+// Sink is an audit.Sink that asynchronously writes audit log entries to the database
+// in batches. It must be started as a background routine.
+type Sink struct {
+	logger  log.Logger
+	store   database.AuditLogStore
+	entries chan audit.Entry
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+var (
+	_ audit.Sink                  = &Sink{}
+	_ goroutine.BackgroundRoutine = &Sink{}
+)
+
+// New returns a new Sink writing to the audit log store of the given database.
+func New(logger log.Logger, db database.DB) *Sink {
+	return &Sink{
+		logger:  logger.Scoped("auditLogSink", "persists audit log entries to the database"),
+		store:   db.AuditLogs(),
+		entries: make(chan audit.Entry, bufferSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Register creates a new Sink, registers it to receive all audit log entries and
+// returns it, so that it can be started with the other background routines of the
+// service.
+func Register(logger log.Logger, db database.DB) *Sink {
+	sink := New(logger, db)
+	audit.RegisterSink(sink)
+	return sink
+}
+
+// Write queues the given entry to be persisted. If the queue is full the entry is
+// dropped, but it is still present in the service logs.
+func (s *Sink) Write(entry audit.Entry) {
+	select {
+	case s.entries <- entry:
+	default:
+		s.logger.Warn("audit log queue is full, dropping entry", log.String("auditId", entry.AuditID))
+	}
+}
+
+// Start persists queued entries until Stop is called.
+func (s *Sink) Start() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]audit.Entry, 0, batchSize)
+	for {
+		select {
+		case entry := <-s.entries:
+			batch = append(batch, entry)
+			if len(batch) >= batchSize {
+				batch = s.flush(batch)
+			}
+
+		case <-ticker.C:
+			batch = s.flush(batch)
+
+		case <-s.stop:
+			// Persist whatever is still queued before shutting down.
+			for {
+				select {
+				case entry := <-s.entries:
+					batch = append(batch, entry)
+					if len(batch) >= batchSize {
+						batch = s.flush(batch)
+					}
+				default:
+					s.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stop signals Start to persist the remaining entries and return, and blocks until it has.
+func (s *Sink) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// flush inserts the given entries and returns the emptied batch.
+func (s *Sink) flush(batch []audit.Entry) []audit.Entry {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.store.Insert(ctx, batch...); err != nil {
+		s.logger.Error("failed to persist audit log entries", log.Int("count", len(batch)), log.Error(err))
+	}
+	return batch[:0]
+}
@@ -0,0 +1,54 @@
+package dbsink
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestSink(t *testing.T) {
+	var inserted []audit.Entry
+	store := database.NewMockAuditLogStore()
+	store.InsertFunc.SetDefaultHook(func(_ context.Context, entries ...audit.Entry) error {
+		inserted = append(inserted, entries...)
+		return nil
+	})
+	db := database.NewMockDB()
+	db.AuditLogsFunc.SetDefaultReturn(store)
+
+	sink := New(logtest.Scoped(t), db)
+	go sink.Start()
+
+	for i := 0; i < batchSize+1; i++ {
+		sink.Write(audit.Entry{AuditID: fmt.Sprint(i)})
+	}
+	sink.Stop()
+
+	// Stop persists the queued entries before returning.
+	require.Len(t, inserted, batchSize+1)
+	require.Len(t, store.InsertFunc.History(), 2)
+	for i, entry := range inserted {
+		require.Equal(t, fmt.Sprint(i), entry.AuditID)
+	}
+
+	// Stopping twice is a no-op.
+	sink.Stop()
+}
+
+func TestSinkDropsEntriesWhenFull(t *testing.T) {
+	db := database.NewMockDB()
+	db.AuditLogsFunc.SetDefaultReturn(database.NewMockAuditLogStore())
+
+	// The sink is not started, so nothing drains the queue.
+	sink := New(logtest.Scoped(t), db)
+	for i := 0; i < bufferSize+10; i++ {
+		sink.Write(audit.Entry{AuditID: fmt.Sprint(i)})
+	}
+	require.Len(t, sink.entries, bufferSize)
+}
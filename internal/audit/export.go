@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/version"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// ExportFormatJSON encodes each audit log entry as a JSON object on its own line (JSON Lines).
+	ExportFormatJSON = "json"
+	// ExportFormatCEF encodes each audit log entry as an ArcSight Common Event Format record on
+	// its own line.
+	ExportFormatCEF = "cef"
+)
+
+// ContentType returns the MIME type of audit log entries encoded in the given format.
+func ContentType(format string) string {
+	if format == ExportFormatCEF {
+		return "text/plain; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Encode writes the given audit log entries to w in the given export format, one entry per line.
+// An empty format defaults to ExportFormatJSON.
+func Encode(w io.Writer, format string, entries []Entry) error {
+	switch format {
+	case "", ExportFormatJSON:
+		enc := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := enc.Encode(newJSONEntry(entry)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case ExportFormatCEF:
+		for _, entry := range entries {
+			line, err := formatCEF(entry)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, line+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return errors.Newf("unsupported audit log export format %q", format)
+	}
+}
+
+type jsonEntry struct {
+	AuditID   string         `json:"auditId"`
+	Timestamp string         `json:"timestamp"`
+	Entity    string         `json:"entity"`
+	Action    string         `json:"action"`
+	Actor     jsonActor      `json:"actor"`
+	Source    string         `json:"source"`
+	Fields    map[string]any `json:"fields,omitempty"`
+}
+
+type jsonActor struct {
+	UserID       int32  `json:"userId,omitempty"`
+	AnonymousUID string `json:"anonymousUid,omitempty"`
+	IP           string `json:"ip,omitempty"`
+	ForwardedFor string `json:"forwardedFor,omitempty"`
+}
+
+func newJSONEntry(entry Entry) jsonEntry {
+	return jsonEntry{
+		AuditID:   entry.AuditID,
+		Timestamp: entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		Entity:    entry.Entity,
+		Action:    entry.Action,
+		Actor: jsonActor{
+			UserID:       entry.ActorUID,
+			AnonymousUID: entry.AnonymousUID,
+			IP:           entry.IP,
+			ForwardedFor: entry.ForwardedFor,
+		},
+		Source: entry.Source,
+		Fields: entry.Fields,
+	}
+}
+
+// cefSeverity is the severity of all exported records. Audit log entries record that an action
+// was taken, not whether it was harmful, so they are all reported with a low severity.
+const cefSeverity = "3"
+
+// formatCEF formats the given entry as a CEF record:
+//
+//	CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+func formatCEF(entry Entry) (string, error) {
+	header := []string{
+		"CEF:0",
+		"Sourcegraph",
+		"Sourcegraph",
+		cefEscapeHeader(version.Version()),
+		cefEscapeHeader(entry.Entity + ":" + entry.Action),
+		cefEscapeHeader(entry.Action),
+		cefSeverity,
+	}
+
+	ext := []string{
+		"rt=" + strconv.FormatInt(entry.Timestamp.UnixMilli(), 10),
+		"act=" + cefEscapeExtension(entry.Action),
+		"externalId=" + cefEscapeExtension(entry.AuditID),
+		"cs1Label=entity",
+		"cs1=" + cefEscapeExtension(entry.Entity),
+		"cs2Label=source",
+		"cs2=" + cefEscapeExtension(entry.Source),
+	}
+	if entry.ActorUID != 0 {
+		ext = append(ext, "suid="+strconv.FormatInt(int64(entry.ActorUID), 10))
+	} else if entry.AnonymousUID != "" {
+		ext = append(ext, "suser="+cefEscapeExtension(entry.AnonymousUID))
+	}
+	if entry.IP != "" {
+		ext = append(ext, "src="+cefEscapeExtension(entry.IP))
+	}
+	if entry.ForwardedFor != "" {
+		ext = append(ext, "cs3Label=forwardedFor", "cs3="+cefEscapeExtension(entry.ForwardedFor))
+	}
+	if len(entry.Fields) > 0 {
+		fields, err := json.Marshal(entry.Fields)
+		if err != nil {
+			return "", errors.Wrap(err, "marshalling fields")
+		}
+		ext = append(ext, "msg="+cefEscapeExtension(string(fields)))
+	}
+
+	return fmt.Sprintf("%s|%s", strings.Join(header, "|"), strings.Join(ext, " ")), nil
+}
+
+var (
+	cefHeaderReplacer    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefExtensionReplacer = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
+
+func cefEscapeHeader(s string) string {
+	return cefHeaderReplacer.Replace(s)
+}
+
+func cefEscapeExtension(s string) string {
+	return cefExtensionReplacer.Replace(s)
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	entries := []Entry{
+		{
+			AuditID:      "a1",
+			Entity:       "security events",
+			Action:       "RoleChangeGranted",
+			ActorUID:     1,
+			IP:           "192.168.0.1",
+			ForwardedFor: "10.0.0.1",
+			Fields:       map[string]any{"event": map[string]any{"argument": `{"a=b":"c|d"}`}},
+			Source:       "frontend",
+			Timestamp:    time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			AuditID:      "a2",
+			Entity:       "GraphQL",
+			Action:       "request",
+			AnonymousUID: "anon",
+			Source:       "frontend",
+			Timestamp:    time.Date(2023, 4, 1, 12, 0, 1, 0, time.UTC),
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Encode(&buf, ExportFormatJSON, entries))
+
+		want := `{"auditId":"a1","timestamp":"2023-04-01T12:00:00Z","entity":"security events","action":"RoleChangeGranted","actor":{"userId":1,"ip":"192.168.0.1","forwardedFor":"10.0.0.1"},"source":"frontend","fields":{"event":{"argument":"{\"a=b\":\"c|d\"}"}}}
+{"auditId":"a2","timestamp":"2023-04-01T12:00:01Z","entity":"GraphQL","action":"request","actor":{"anonymousUid":"anon"},"source":"frontend"}
+`
+		assert.Equal(t, want, buf.String())
+	})
+
+	t.Run("cef", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Encode(&buf, ExportFormatCEF, entries))
+
+		lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+		require.Len(t, lines, 2)
+		assert.Regexp(t, `^CEF:0\|Sourcegraph\|Sourcegraph\|[^|]+\|security events:RoleChangeGranted\|RoleChangeGranted\|3\|`, string(lines[0]))
+		assert.Contains(t, string(lines[0]), `rt=1680350400000 act=RoleChangeGranted externalId=a1 cs1Label=entity cs1=security events cs2Label=source cs2=frontend suid=1 src=192.168.0.1 cs3Label=forwardedFor cs3=10.0.0.1 msg={"event":{"argument":"{\\"a\=b\\":\\"c|d\\"}"}}`)
+		assert.Contains(t, string(lines[1]), `suser=anon`)
+		assert.NotContains(t, string(lines[1]), `msg=`)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		assert.Error(t, Encode(&bytes.Buffer{}, "xml", entries))
+	})
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// Entry is a single record of the audit log, in a form that can be persisted and exported.
+type Entry struct {
+	// AuditID is the unique ID of the entry, also part of the message written to the service logger.
+	AuditID string
+	// Entity is the name of the audited entity.
+	Entity string
+	// Action describes the state change relevant to the audit log.
+	Action string
+	// ActorUID is the ID of the user that took the action, or 0 if the actor is not a user.
+	ActorUID int32
+	// AnonymousUID is the anonymous ID of the actor, if any.
+	AnonymousUID string
+	// IP is the IP address the action originated from, if known.
+	IP string
+	// ForwardedFor is the value of the X-Forwarded-For header of the request, if known.
+	ForwardedFor string
+	// Fields hold any additional context relevant to the action.
+	Fields map[string]any
+	// Source is the name of the service that recorded the entry.
+	Source string
+	// Timestamp is the time at which the entry was recorded.
+	Timestamp time.Time
+}
+
+// A Sink receives every entry of the audit log recorded by the current process, in addition
+// to the service logger.
+type Sink interface {
+	// Write is called with every entry of the audit log. Implementations must not block.
+	Write(entry Entry)
+}
+
+var (
+	sinksMu sync.RWMutex
+	sinks   []Sink
+)
+
+// RegisterSink registers a sink that receives all subsequently recorded audit log entries.
+func RegisterSink(sink Sink) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	sinks = append(sinks, sink)
+}
+
+func writeToSinks(entry Entry) {
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+	for _, sink := range sinks {
+		sink.Write(entry)
+	}
+}
+
+// fieldsToMap converts the given structured log fields to a map that can be serialized as JSON.
+func fieldsToMap(fields []log.Field) map[string]any {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return enc.Fields
+}
@@ -4,10 +4,37 @@ const (
 	// Access token scopes.
 	ScopeUserAll       = "user:all"        // Full control of all resources accessible to the user account.
 	ScopeSiteAdminSudo = "site-admin:sudo" // Ability to perform any action as any other user.
+
+	// Restricted access token scopes, each granting a subset of ScopeUserAll.
+	ScopeAPIRead           = "api:read"            // Read-only access to the GraphQL and search APIs.
+	ScopeCodeIntelUpload   = "codeintel:upload"    // Ability to upload precise code intelligence indexes.
+	ScopeBatchChangesWrite = "batch-changes:write" // Ability to create, apply and manage batch changes.
 )
 
 // AllScopes is a list of all known access token scopes.
 var AllScopes = []string{
 	ScopeUserAll,
 	ScopeSiteAdminSudo,
+	ScopeAPIRead,
+	ScopeCodeIntelUpload,
+	ScopeBatchChangesWrite,
+}
+
+// RestrictedScopes is a list of the access token scopes that grant only a subset of the
+// privileges of ScopeUserAll. An access token without ScopeUserAll must have at least one of
+// them.
+var RestrictedScopes = []string{
+	ScopeAPIRead,
+	ScopeCodeIntelUpload,
+	ScopeBatchChangesWrite,
+}
+
+// HasScope returns true if the given scopes contain the scope.
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
 }
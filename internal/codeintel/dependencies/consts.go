@@ -3,10 +3,13 @@ package dependencies
 import "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 
 const (
-	JVMPackagesScheme    = shared.JVMPackagesScheme
-	NpmPackagesScheme    = shared.NpmPackagesScheme
-	GoPackagesScheme     = shared.GoPackagesScheme
-	PythonPackagesScheme = shared.PythonPackagesScheme
-	RustPackagesScheme   = shared.RustPackagesScheme
-	RubyPackagesScheme   = shared.RubyPackagesScheme
+	JVMPackagesScheme      = shared.JVMPackagesScheme
+	NpmPackagesScheme      = shared.NpmPackagesScheme
+	GoPackagesScheme       = shared.GoPackagesScheme
+	PythonPackagesScheme   = shared.PythonPackagesScheme
+	RustPackagesScheme     = shared.RustPackagesScheme
+	RubyPackagesScheme     = shared.RubyPackagesScheme
+	NuGetPackagesScheme    = shared.NuGetPackagesScheme
+	ComposerPackagesScheme = shared.ComposerPackagesScheme
+	HexPackagesScheme      = shared.HexPackagesScheme
 )
@@ -17,6 +17,7 @@ type AutoindexingServiceResolver interface {
 
 	// Inference
 	InferAutoIndexJobsForRepo(ctx context.Context, args *InferAutoIndexJobsForRepoArgs) ([]AutoIndexJobDescriptionResolver, error)
+	PreviewAutoIndexJobInference(ctx context.Context, args *PreviewAutoIndexJobInferenceArgs) (AutoIndexJobInferencePreviewResolver, error)
 	QueueAutoIndexJobsForRepo(ctx context.Context, args *QueueAutoIndexJobsForRepoArgs) ([]PreciseIndexResolver, error)
 }
 
@@ -35,12 +36,51 @@ type InferAutoIndexJobsForRepoArgs struct {
 	Script     *string
 }
 
+type PreviewAutoIndexJobInferenceArgs struct {
+	Repository    graphql.ID
+	Rev           *string
+	Script        *string
+	Configuration *string
+}
+
 type QueueAutoIndexJobsForRepoArgs struct {
 	Repository    graphql.ID
 	Rev           *string
 	Configuration *string
 }
 
+type AutoIndexJobInferencePreviewResolver interface {
+	Commit() string
+	IndexJobs() []AutoIndexJobDiffResolver
+	IndexJobHints() []AutoIndexJobHintDiffResolver
+	CurrentRecognizerMatches() []AutoIndexRecognizerMatchResolver
+	CandidateRecognizerMatches() []AutoIndexRecognizerMatchResolver
+}
+
+type AutoIndexJobDiffResolver interface {
+	Kind() string
+	Current() AutoIndexJobDescriptionResolver
+	Candidate() AutoIndexJobDescriptionResolver
+}
+
+type AutoIndexJobHintDiffResolver interface {
+	Kind() string
+	Current() AutoIndexJobHintResolver
+	Candidate() AutoIndexJobHintResolver
+}
+
+type AutoIndexJobHintResolver interface {
+	Root() string
+	Indexer() CodeIntelIndexerResolver
+	ComparisonKey() string
+	Confidence() string
+}
+
+type AutoIndexRecognizerMatchResolver interface {
+	Name() string
+	Paths() []string
+}
+
 type IndexConfigurationResolver interface {
 	Configuration(ctx context.Context) (*string, error)
 	ParsedConfiguration(ctx context.Context) (*[]AutoIndexJobDescriptionResolver, error)
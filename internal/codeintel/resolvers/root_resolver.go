@@ -149,6 +149,10 @@ func (r *Resolver) InferAutoIndexJobsForRepo(ctx context.Context, args *InferAut
 	return r.autoIndexingRootResolver.InferAutoIndexJobsForRepo(ctx, args)
 }
 
+func (r *Resolver) PreviewAutoIndexJobInference(ctx context.Context, args *PreviewAutoIndexJobInferenceArgs) (_ AutoIndexJobInferencePreviewResolver, err error) {
+	return r.autoIndexingRootResolver.PreviewAutoIndexJobInference(ctx, args)
+}
+
 func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (_ GitBlobLSIFDataResolver, err error) {
 	return r.codenavResolver.GitBlobLSIFData(ctx, args)
 }
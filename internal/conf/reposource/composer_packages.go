@@ -0,0 +1,94 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const composerPackagesPrefix = "packagist/"
+
+// composerPackageNameRegex matches valid Composer package names, as defined by
+// the Composer JSON schema.
+var composerPackageNameRegex = lazyregexp.New(`^[a-z0-9]([_.-]?[a-z0-9]+)*/[a-z0-9](([_.]|-{1,2})?[a-z0-9]+)*$`)
+
+// ComposerVersionedPackage is a PHP package of the form vendor/package, as
+// distributed by Packagist and other Composer repositories.
+type ComposerVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewComposerVersionedPackage(name PackageName, version string) *ComposerVersionedPackage {
+	return &ComposerVersionedPackage{
+		Name:    PackageName(strings.ToLower(string(name))),
+		Version: version,
+	}
+}
+
+// ParseComposerVersionedPackage parses a string in a 'vendor/package(:version)?'
+// format into a ComposerVersionedPackage.
+func ParseComposerVersionedPackage(dependency string) (*ComposerVersionedPackage, error) {
+	name, version, _ := strings.Cut(dependency, ":")
+	dep := NewComposerVersionedPackage(PackageName(strings.TrimSpace(name)), strings.TrimSpace(version))
+	if !composerPackageNameRegex.MatchString(string(dep.Name)) {
+		return nil, errors.Errorf("invalid Composer package name %q, expected vendor/package", name)
+	}
+	return dep, nil
+}
+
+func ParseComposerPackageFromName(name PackageName) (*ComposerVersionedPackage, error) {
+	return ParseComposerVersionedPackage(string(name))
+}
+
+// ParseComposerPackageFromRepoName is a convenience function to parse a repo name in a
+// 'packagist/vendor/package' format into a ComposerVersionedPackage.
+func ParseComposerPackageFromRepoName(name api.RepoName) (*ComposerVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), composerPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid Composer dependency repo name, missing %s prefix '%s'", composerPackagesPrefix, name)
+	}
+	return ParseComposerVersionedPackage(dependency)
+}
+
+func (p *ComposerVersionedPackage) Scheme() string {
+	return "composer"
+}
+
+func (p *ComposerVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *ComposerVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + ":" + p.Version
+}
+
+func (p *ComposerVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *ComposerVersionedPackage) Description() string { return "" }
+
+func (p *ComposerVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(composerPackagesPrefix + p.Name)
+}
+
+func (p *ComposerVersionedPackage) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *ComposerVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*ComposerVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(strings.TrimPrefix(p.Version, "v"), strings.TrimPrefix(o.Version, "v"))
+	}
+
+	return p.Name > o.Name
+}
@@ -0,0 +1,55 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseComposerVersionedPackage(t *testing.T) {
+	tests := []struct {
+		dependency   string
+		wantName     PackageName
+		wantVersion  string
+		wantRepoName api.RepoName
+		wantErr      bool
+	}{
+		{dependency: "monolog/monolog:3.4.0", wantName: "monolog/monolog", wantVersion: "3.4.0", wantRepoName: "packagist/monolog/monolog"},
+		{dependency: "Symfony/Console:v6.3.4", wantName: "symfony/console", wantVersion: "v6.3.4", wantRepoName: "packagist/symfony/console"},
+		{dependency: "phpunit/php-code-coverage", wantName: "phpunit/php-code-coverage", wantRepoName: "packagist/phpunit/php-code-coverage"},
+		{dependency: "monolog", wantErr: true},
+		{dependency: "monolog/monolog/extra:1.0.0", wantErr: true},
+		{dependency: "-monolog/monolog:1.0.0", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.dependency, func(t *testing.T) {
+			pkg, err := ParseComposerVersionedPackage(test.dependency)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.wantName, pkg.PackageSyntax())
+			require.Equal(t, test.wantVersion, pkg.PackageVersion())
+			require.Equal(t, test.wantRepoName, pkg.RepoName())
+		})
+	}
+}
+
+func TestParseComposerPackageFromRepoName(t *testing.T) {
+	pkg, err := ParseComposerPackageFromRepoName("packagist/monolog/monolog")
+	require.NoError(t, err)
+	require.Equal(t, PackageName("monolog/monolog"), pkg.PackageSyntax())
+
+	_, err = ParseComposerPackageFromRepoName("github.com/Seldaek/monolog")
+	require.Error(t, err)
+}
+
+func TestComposerVersionedPackage_Less(t *testing.T) {
+	a, _ := ParseComposerVersionedPackage("symfony/console:v6.3.4")
+	b, _ := ParseComposerVersionedPackage("symfony/console:6.2.0")
+	require.True(t, a.Less(b))
+	require.False(t, b.Less(a))
+}
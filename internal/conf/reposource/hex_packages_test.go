@@ -0,0 +1,47 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseHexVersionedPackage(t *testing.T) {
+	tests := []struct {
+		dependency   string
+		wantName     PackageName
+		wantVersion  string
+		wantRepoName api.RepoName
+		wantErr      bool
+	}{
+		{dependency: "jason@1.4.1", wantName: "jason", wantVersion: "1.4.1", wantRepoName: "hex/jason"},
+		{dependency: "phoenix_live_view@0.20.0-rc.1", wantName: "phoenix_live_view", wantVersion: "0.20.0-rc.1", wantRepoName: "hex/phoenix_live_view"},
+		{dependency: "plug", wantName: "plug", wantRepoName: "hex/plug"},
+		{dependency: "Plug@1.0.0", wantErr: true},
+		{dependency: "1plug@1.0.0", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.dependency, func(t *testing.T) {
+			pkg, err := ParseHexVersionedPackage(test.dependency)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.wantName, pkg.PackageSyntax())
+			require.Equal(t, test.wantVersion, pkg.PackageVersion())
+			require.Equal(t, test.wantRepoName, pkg.RepoName())
+		})
+	}
+}
+
+func TestParseHexPackageFromRepoName(t *testing.T) {
+	pkg, err := ParseHexPackageFromRepoName("hex/jason")
+	require.NoError(t, err)
+	require.Equal(t, PackageName("jason"), pkg.PackageSyntax())
+
+	_, err = ParseHexPackageFromRepoName("github.com/michalmuskala/jason")
+	require.Error(t, err)
+}
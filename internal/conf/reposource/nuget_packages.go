@@ -0,0 +1,88 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const nugetPackagesPrefix = "nuget/"
+
+// NuGetVersionedPackage is a NuGet package. NuGet package IDs and versions
+// are case-insensitive, so both are normalized to lower case, the same way as
+// in the URLs of NuGet v3 feeds.
+type NuGetVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewNuGetVersionedPackage(name PackageName, version string) *NuGetVersionedPackage {
+	return &NuGetVersionedPackage{
+		Name:    PackageName(strings.ToLower(string(name))),
+		Version: strings.ToLower(version),
+	}
+}
+
+// ParseNuGetVersionedPackage parses a string in a '<id>(@version>)?' format into a
+// NuGetVersionedPackage.
+func ParseNuGetVersionedPackage(dependency string) *NuGetVersionedPackage {
+	if i := strings.LastIndex(dependency, "@"); i != -1 {
+		return NewNuGetVersionedPackage(PackageName(strings.TrimSpace(dependency[:i])), strings.TrimSpace(dependency[i+1:]))
+	}
+	return NewNuGetVersionedPackage(PackageName(strings.TrimSpace(dependency)), "")
+}
+
+func ParseNuGetPackageFromName(name PackageName) *NuGetVersionedPackage {
+	return ParseNuGetVersionedPackage(string(name))
+}
+
+// ParseNuGetPackageFromRepoName is a convenience function to parse a repo name in a
+// 'nuget/<id>(@<version>)?' format into a NuGetVersionedPackage.
+func ParseNuGetPackageFromRepoName(name api.RepoName) (*NuGetVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), nugetPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid NuGet dependency repo name, missing %s prefix '%s'", nugetPackagesPrefix, name)
+	}
+	return ParseNuGetVersionedPackage(dependency), nil
+}
+
+func (p *NuGetVersionedPackage) Scheme() string {
+	return "nuget"
+}
+
+func (p *NuGetVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *NuGetVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *NuGetVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *NuGetVersionedPackage) Description() string { return "" }
+
+func (p *NuGetVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(nugetPackagesPrefix + p.Name)
+}
+
+func (p *NuGetVersionedPackage) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *NuGetVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*NuGetVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
@@ -0,0 +1,44 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseNuGetVersionedPackage(t *testing.T) {
+	tests := []struct {
+		dependency   string
+		wantName     PackageName
+		wantVersion  string
+		wantRepoName api.RepoName
+	}{
+		{dependency: "Newtonsoft.Json@13.0.3", wantName: "newtonsoft.json", wantVersion: "13.0.3", wantRepoName: "nuget/newtonsoft.json"},
+		{dependency: "Serilog@3.1.0-DEV-02077", wantName: "serilog", wantVersion: "3.1.0-dev-02077", wantRepoName: "nuget/serilog"},
+		{dependency: "xunit", wantName: "xunit", wantVersion: "", wantRepoName: "nuget/xunit"},
+	}
+	for _, test := range tests {
+		pkg := ParseNuGetVersionedPackage(test.dependency)
+		require.Equal(t, test.wantName, pkg.PackageSyntax())
+		require.Equal(t, test.wantVersion, pkg.PackageVersion())
+		require.Equal(t, test.wantRepoName, pkg.RepoName())
+	}
+}
+
+func TestParseNuGetPackageFromRepoName(t *testing.T) {
+	pkg, err := ParseNuGetPackageFromRepoName("nuget/newtonsoft.json")
+	require.NoError(t, err)
+	require.Equal(t, PackageName("newtonsoft.json"), pkg.PackageSyntax())
+
+	_, err = ParseNuGetPackageFromRepoName("github.com/JamesNK/Newtonsoft.Json")
+	require.Error(t, err)
+}
+
+func TestNuGetVersionedPackage_Less(t *testing.T) {
+	a := ParseNuGetVersionedPackage("serilog@3.0.1")
+	b := ParseNuGetVersionedPackage("serilog@2.12.0")
+	require.True(t, a.Less(b))
+	require.False(t, b.Less(a))
+}
@@ -25,4 +25,7 @@ var (
 	_ VersionedPackage = (*GoVersionedPackage)(nil)
 	_ VersionedPackage = (*PythonVersionedPackage)(nil)
 	_ VersionedPackage = (*RustVersionedPackage)(nil)
+	_ VersionedPackage = (*NuGetVersionedPackage)(nil)
+	_ VersionedPackage = (*ComposerVersionedPackage)(nil)
+	_ VersionedPackage = (*HexVersionedPackage)(nil)
 )
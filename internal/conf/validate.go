@@ -221,6 +221,7 @@ var siteConfigSecrets = []struct {
 	{readPath: `dotcom.srcCliVersionCache.github.webhookSecret`, editPaths: []string{"dotcom", "srcCliVersionCache", "github", "webhookSecret"}},
 	{readPath: `embeddings.accessToken`, editPaths: []string{"embeddings", "accessToken"}},
 	{readPath: `completions.accessToken`, editPaths: []string{"completions", "accessToken"}},
+	{readPath: `log.auditLog.export.authorization`, editPaths: []string{"log", "auditLog", "export", "authorization"}},
 }
 
 // UnredactSecrets unredacts unchanged secrets back to their original value for
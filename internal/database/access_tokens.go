@@ -33,6 +33,9 @@ type AccessToken struct {
 	Internal   bool
 	CreatedAt  time.Time
 	LastUsedAt *time.Time
+	// ExpiresAt is the time after which the access token can no longer be used to
+	// authenticate. It is nil if the access token does not expire.
+	ExpiresAt *time.Time
 }
 
 // ErrAccessTokenNotFound occurs when a database operation expects a specific access token to exist
@@ -69,9 +72,12 @@ type AccessTokenStore interface {
 	// implausible for an attacker to brute-force the input space; also bcrypt is slow and would add
 	// noticeable latency to each request that supplied a token.
 	//
+	// If expiresAt is not the zero time, the token can no longer be used to authenticate after
+	// that time.
+	//
 	// 🚨 SECURITY: The caller must ensure that the actor is permitted to create tokens for the
 	// specified user (i.e., that the actor is either the user or a site admin).
-	Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error)
+	Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt time.Time) (id int64, token string, err error)
 
 	// CreateInternal creates an *internal* access token for the specified user. An
 	// internal access token will be used by Sourcegraph to talk to its API from
@@ -92,6 +98,10 @@ type AccessTokenStore interface {
 	// that an API client would use to authenticate). The token prefix "sgp_", if present, is stripped.
 	DeleteByToken(ctx context.Context, token string) error
 
+	// DeleteExpired deletes all access tokens that have expired. It returns the number of deleted
+	// access tokens.
+	DeleteExpired(ctx context.Context) (int, error)
+
 	// GetByID retrieves the access token (if any) given its ID.
 	//
 	// 🚨 SECURITY: The caller must ensure that the actor is permitted to view this access token.
@@ -113,16 +123,25 @@ type AccessTokenStore interface {
 	// options.
 	List(context.Context, AccessTokensListOptions) ([]*AccessToken, error)
 
-	// Lookup looks up the access token. If it's valid and contains the required scope, it returns the
-	// subject's user ID. Otherwise ErrAccessTokenNotFound is returned.
+	// ListExpiring lists all access tokens, except internal tokens, that expire before the given
+	// time and whose subject user has not yet been notified of the upcoming expiry.
+	ListExpiring(ctx context.Context, before time.Time) ([]*AccessToken, error)
+
+	// Lookup looks up the access token. If it's valid and contains at least one of the accepted
+	// scopes, it returns the subject's user ID and all scopes of the token. Otherwise
+	// ErrAccessTokenNotFound is returned.
 	//
 	// The token prefix "sgp_", if present, is stripped.
 	//
 	// Calling Lookup also updates the access token's last-used-at date.
 	//
 	// 🚨 SECURITY: This returns a user ID if and only if the token corresponds to a valid,
-	// non-deleted access token.
-	Lookup(ctx context.Context, token, requiredScope string) (subjectUserID int32, err error)
+	// non-deleted, non-expired access token.
+	Lookup(ctx context.Context, token string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error)
+
+	// MarkExpiryNotified records that the subject user of the access token has been notified of
+	// its upcoming expiry.
+	MarkExpiryNotified(ctx context.Context, id int64) error
 
 	WithTransact(context.Context, func(AccessTokenStore) error) error
 	With(basestore.ShareableStore) AccessTokenStore
@@ -151,12 +170,12 @@ func (s *accessTokenStore) WithTransact(ctx context.Context, f func(AccessTokenS
 	})
 }
 
-func (s *accessTokenStore) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error) {
-	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, false)
+func (s *accessTokenStore) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt time.Time) (id int64, token string, err error) {
+	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, expiresAt, false)
 }
 
 func (s *accessTokenStore) CreateInternal(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error) {
-	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, true)
+	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, time.Time{}, true)
 }
 
 // personalAccessTokenPrefix is the token prefix for Sourcegraph personal access tokens. Its purpose
@@ -164,7 +183,7 @@ func (s *accessTokenStore) CreateInternal(ctx context.Context, subjectUserID int
 // Sourcegraph personal access token (vs. some arbitrary high-entropy hex-encoded value).
 const personalAccessTokenPrefix = "sgp_"
 
-func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, internal bool) (id int64, token string, err error) {
+func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt time.Time, internal bool) (id int64, token string, err error) {
 	var b [20]byte
 	if _, err := rand.Read(b[:]); err != nil {
 		return 0, "", err
@@ -177,6 +196,11 @@ func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32,
 		return 0, "", errors.New("access tokens without scopes are not supported")
 	}
 
+	var expiresAtValue *time.Time
+	if !expiresAt.IsZero() {
+		expiresAtValue = &expiresAt
+	}
+
 	if err := s.Handle().QueryRowContext(ctx,
 		// Include users table query (with "FOR UPDATE") to ensure that subject/creator users have
 		// not been deleted. If they were deleted, the query will return an error.
@@ -188,12 +212,12 @@ creator_user AS (
   SELECT id FROM users WHERE id=$5 AND deleted_at IS NULL FOR UPDATE
 ),
 insert_values AS (
-  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id, $6::boolean AS internal
+  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id, $6::boolean AS internal, $7::timestamptz AS expires_at
   FROM subject_user, creator_user
 )
-INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id, internal) SELECT * FROM insert_values RETURNING id
+INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id, internal, expires_at) SELECT * FROM insert_values RETURNING id
 `,
-		subjectUserID, pq.Array(scopes), hashutil.ToSHA256Bytes(b[:]), note, creatorUserID, internal,
+		subjectUserID, pq.Array(scopes), hashutil.ToSHA256Bytes(b[:]), note, creatorUserID, internal, expiresAtValue,
 	).Scan(&id); err != nil {
 		return 0, "", err
 	}
@@ -201,15 +225,17 @@ INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_u
 	// only log access tokens created by users
 	if !internal {
 		arg, err := json.Marshal(struct {
-			SubjectUserId int32    `json:"subject_user_id"`
-			CreatorUserId int32    `json:"creator_user_id"`
-			Scopes        []string `json:"scopes"`
-			Note          string   `json:"note"`
+			SubjectUserId int32      `json:"subject_user_id"`
+			CreatorUserId int32      `json:"creator_user_id"`
+			Scopes        []string   `json:"scopes"`
+			Note          string     `json:"note"`
+			ExpiresAt     *time.Time `json:"expires_at,omitempty"`
 		}{
 			SubjectUserId: subjectUserID,
 			CreatorUserId: creatorUserID,
 			Scopes:        scopes,
 			Note:          note,
+			ExpiresAt:     expiresAtValue,
 		})
 		if err != nil {
 			s.logger.Error("failed to marshall the access token log argument")
@@ -228,14 +254,19 @@ INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_u
 	return id, token, nil
 }
 
-func (s *accessTokenStore) Lookup(ctx context.Context, token, requiredScope string) (subjectUserID int32, err error) {
-	if requiredScope == "" {
-		return 0, errors.New("no scope provided in access token lookup")
+func (s *accessTokenStore) Lookup(ctx context.Context, token string, acceptedScopes ...string) (subjectUserID int32, scopes []string, err error) {
+	if len(acceptedScopes) == 0 {
+		return 0, nil, errors.New("no scope provided in access token lookup")
+	}
+	for _, scope := range acceptedScopes {
+		if scope == "" {
+			return 0, nil, errors.New("empty scope provided in access token lookup")
+		}
 	}
 
 	tokenHash, err := tokenSHA256Hash(token)
 	if err != nil {
-		return 0, errors.Wrap(err, "AccessTokens.Lookup")
+		return 0, nil, errors.Wrap(err, "AccessTokens.Lookup")
 	}
 
 	if err := s.Handle().QueryRowContext(ctx,
@@ -247,18 +278,19 @@ WHERE t.id IN (
 	JOIN users subject_user ON t2.subject_user_id=subject_user.id AND subject_user.deleted_at IS NULL
 	JOIN users creator_user ON t2.creator_user_id=creator_user.id AND creator_user.deleted_at IS NULL
 	WHERE t2.value_sha256=$1 AND t2.deleted_at IS NULL AND
-	$2 = ANY (t2.scopes)
+	(t2.expires_at IS NULL OR t2.expires_at > now()) AND
+	t2.scopes && $2::text[]
 )
-RETURNING t.subject_user_id
+RETURNING t.subject_user_id, t.scopes
 `,
-		tokenHash, requiredScope,
-	).Scan(&subjectUserID); err != nil {
+		tokenHash, pq.Array(acceptedScopes),
+	).Scan(&subjectUserID, pq.Array(&scopes)); err != nil {
 		if err == sql.ErrNoRows {
-			return 0, ErrAccessTokenNotFound
+			return 0, nil, ErrAccessTokenNotFound
 		}
-		return 0, err
+		return 0, nil, err
 	}
-	return subjectUserID, nil
+	return subjectUserID, scopes, nil
 }
 
 func (s *accessTokenStore) GetByID(ctx context.Context, id int64) (*AccessToken, error) {
@@ -315,9 +347,24 @@ func (s *accessTokenStore) List(ctx context.Context, opt AccessTokensListOptions
 	return s.list(ctx, opt.sqlConditions(), opt.LimitOffset)
 }
 
+func (s *accessTokenStore) ListExpiring(ctx context.Context, before time.Time) ([]*AccessToken, error) {
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("deleted_at IS NULL"),
+		sqlf.Sprintf("internal IS FALSE"),
+		sqlf.Sprintf("expires_at > now()"),
+		sqlf.Sprintf("expires_at < %s", before),
+		sqlf.Sprintf("expiry_notified_at IS NULL"),
+	}
+	return s.list(ctx, conds, nil)
+}
+
+func (s *accessTokenStore) MarkExpiryNotified(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf("UPDATE access_tokens SET expiry_notified_at=now() WHERE id=%s", id))
+}
+
 func (s *accessTokenStore) list(ctx context.Context, conds []*sqlf.Query, limitOffset *LimitOffset) ([]*AccessToken, error) {
 	q := sqlf.Sprintf(`
-SELECT id, subject_user_id, scopes, note, creator_user_id, internal, created_at, last_used_at FROM access_tokens
+SELECT id, subject_user_id, scopes, note, creator_user_id, internal, created_at, last_used_at, expires_at FROM access_tokens
 WHERE (%s)
 ORDER BY now() - created_at < interval '5 minutes' DESC, -- show recently created tokens first
 last_used_at DESC NULLS FIRST, -- ensure newly created tokens show first
@@ -336,7 +383,7 @@ created_at DESC
 	var results []*AccessToken
 	for rows.Next() {
 		var t AccessToken
-		if err := rows.Scan(&t.ID, &t.SubjectUserID, pq.Array(&t.Scopes), &t.Note, &t.CreatorUserID, &t.Internal, &t.CreatedAt, &t.LastUsedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.SubjectUserID, pq.Array(&t.Scopes), &t.Note, &t.CreatorUserID, &t.Internal, &t.CreatedAt, &t.LastUsedAt, &t.ExpiresAt); err != nil {
 			return nil, err
 		}
 		results = append(results, &t)
@@ -425,6 +472,18 @@ func (s *accessTokenStore) DeleteByToken(ctx context.Context, token string) erro
 	return nil
 }
 
+func (s *accessTokenStore) DeleteExpired(ctx context.Context) (int, error) {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE deleted_at IS NULL AND expires_at <= now()"))
+	if err != nil {
+		return 0, err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(nrows), nil
+}
+
 func (s *accessTokenStore) delete(ctx context.Context, cond *sqlf.Query) error {
 	conds := []*sqlf.Query{cond, sqlf.Sprintf("deleted_at IS NULL")}
 	q := sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE (%s)", sqlf.Join(conds, ") AND ("))
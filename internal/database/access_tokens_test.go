@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
@@ -42,7 +43,7 @@ func TestAccessTokens_Create(t *testing.T) {
 	}
 
 	assertSecurityEventCount(t, db, SecurityEventAccessTokenCreated, 0)
-	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, time.Time{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -66,7 +67,7 @@ func TestAccessTokens_Create(t *testing.T) {
 		t.Errorf("got %q, want %q", got.Note, want)
 	}
 
-	gotSubjectUserID, err := db.AccessTokens().Lookup(ctx, tv0, "a")
+	gotSubjectUserID, _, err := db.AccessTokens().Lookup(ctx, tv0, "a")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -126,15 +127,15 @@ func TestAccessTokens_Delete(t *testing.T) {
 	subjectActor := actor.FromUser(subject.ID)
 	ctxWithActor := actor.WithActor(context.Background(), subjectActor)
 
-	tid0, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID, time.Time{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, tv1, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	_, tv1, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID, time.Time{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	tid2, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid2, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID, time.Time{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -233,11 +234,11 @@ func TestAccessTokens_List(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID)
+	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID, time.Time{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID)
+	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID, time.Time{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -314,13 +315,13 @@ func TestAccessTokens_Lookup(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, time.Time{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	for _, scope := range []string{"a", "b"} {
-		gotSubjectUserID, err := db.AccessTokens().Lookup(ctx, tv0, scope)
+		gotSubjectUserID, _, err := db.AccessTokens().Lookup(ctx, tv0, scope)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -330,12 +331,12 @@ func TestAccessTokens_Lookup(t *testing.T) {
 	}
 
 	// Lookup with a nonexistent scope and ensure it fails.
-	if _, err := db.AccessTokens().Lookup(ctx, tv0, "x"); err == nil {
+	if _, _, err := db.AccessTokens().Lookup(ctx, tv0, "x"); err == nil {
 		t.Fatal(err)
 	}
 
 	// Lookup with an empty scope and ensure it fails.
-	if _, err := db.AccessTokens().Lookup(ctx, tv0, ""); err == nil {
+	if _, _, err := db.AccessTokens().Lookup(ctx, tv0, ""); err == nil {
 		t.Fatal(err)
 	}
 
@@ -343,12 +344,12 @@ func TestAccessTokens_Lookup(t *testing.T) {
 	if err := db.AccessTokens().DeleteByID(ctx, tid0); err != nil {
 		t.Fatal(err)
 	}
-	if _, err := db.AccessTokens().Lookup(ctx, tv0, "a"); err == nil {
+	if _, _, err := db.AccessTokens().Lookup(ctx, tv0, "a"); err == nil {
 		t.Fatal(err)
 	}
 
 	// Try to Lookup a token that was never created.
-	if _, err := db.AccessTokens().Lookup(ctx, "abcdefg" /* this token value was never created */, "a"); err == nil {
+	if _, _, err := db.AccessTokens().Lookup(ctx, "abcdefg" /* this token value was never created */, "a"); err == nil {
 		t.Fatal(err)
 	}
 }
@@ -384,18 +385,18 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, time.Time{})
 		if err != nil {
 			t.Fatal(err)
 		}
 		if err := db.Users().Delete(ctx, subject.ID); err != nil {
 			t.Fatal(err)
 		}
-		if _, err := db.AccessTokens().Lookup(ctx, tv0, "a"); err == nil {
+		if _, _, err := db.AccessTokens().Lookup(ctx, tv0, "a"); err == nil {
 			t.Fatal("Lookup: want error looking up token for deleted subject user")
 		}
 
-		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID, time.Time{}); err == nil {
 			t.Fatal("Create: want error creating token for deleted subject user")
 		}
 	})
@@ -420,23 +421,97 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, time.Time{})
 		if err != nil {
 			t.Fatal(err)
 		}
 		if err := db.Users().Delete(ctx, creator.ID); err != nil {
 			t.Fatal(err)
 		}
-		if _, err := db.AccessTokens().Lookup(ctx, tv0, "a"); err == nil {
+		if _, _, err := db.AccessTokens().Lookup(ctx, tv0, "a"); err == nil {
 			t.Fatal("Lookup: want error looking up token for deleted creator user")
 		}
 
-		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID, time.Time{}); err == nil {
 			t.Fatal("Create: want error creating token for deleted creator user")
 		}
 	})
 }
 
+// 🚨 SECURITY: This tests that expired access tokens are rejected and cleaned up.
+func TestAccessTokens_Expiry(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	subject, err := db.Users().Create(ctx, NewUser{
+		Email:                 "a@example.com",
+		Username:              "u1",
+		Password:              "p1",
+		EmailVerificationCode: "c1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, expiredToken, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "expired", subject.ID, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiringID, expiringToken, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "expiring", subject.ID, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "no expiry", subject.ID, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := db.AccessTokens().Lookup(ctx, expiredToken, "a"); err == nil {
+		t.Fatal("Lookup: want error looking up expired token")
+	}
+	if _, _, err := db.AccessTokens().Lookup(ctx, expiringToken, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	expiring, err := db.AccessTokens().ListExpiring(ctx, time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expiring) != 1 || expiring[0].ID != expiringID || expiring[0].ExpiresAt == nil {
+		t.Fatalf("got expiring tokens %+v, want only token %d", expiring, expiringID)
+	}
+
+	if err := db.AccessTokens().MarkExpiryNotified(ctx, expiringID); err != nil {
+		t.Fatal(err)
+	}
+	expiring, err = db.AccessTokens().ListExpiring(ctx, time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expiring) != 0 {
+		t.Fatalf("got %d expiring tokens after notifying, want 0", len(expiring))
+	}
+
+	deleted, err := db.AccessTokens().DeleteExpired(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 {
+		t.Errorf("got %d deleted tokens, want 1", deleted)
+	}
+	tokens, err := db.AccessTokens().List(ctx, AccessTokensListOptions{SubjectUserID: subject.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 {
+		t.Errorf("got %d remaining tokens, want 2", len(tokens))
+	}
+}
+
 func TestAccessTokens_tokenSHA256Hash(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -0,0 +1,288 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AuditLogEntry represents a row in the `audit_log_entries` table.
+type AuditLogEntry struct {
+	ID int64
+	audit.Entry
+}
+
+// AuditLogStore provides access to the `audit_log_entries` table.
+type AuditLogStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) AuditLogStore
+	WithTransact(context.Context, func(AuditLogStore) error) error
+
+	// Insert persists the given audit log entries.
+	Insert(ctx context.Context, entries ...audit.Entry) error
+	// List returns the audit log entries matching the given options, paginated by
+	// the given pagination arguments.
+	List(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error)
+	// Count counts the audit log entries matching the given options.
+	Count(context.Context, AuditLogListOpts) (int, error)
+	// ListUnexported returns at most limit audit log entries that have not been
+	// marked as exported yet, in the order they were inserted.
+	ListUnexported(ctx context.Context, limit int) ([]*AuditLogEntry, error)
+	// MarkExported marks the audit log entries with the given IDs as exported.
+	MarkExported(ctx context.Context, ids ...int64) error
+	// DeleteOlderThan deletes all audit log entries recorded before the given time,
+	// and returns the number of deleted entries.
+	DeleteOlderThan(ctx context.Context, before time.Time) (int, error)
+}
+
+// AuditLogListOpts provide the options when listing audit log entries.
+type AuditLogListOpts struct {
+	// ActorUserID filters the entries by the ID of the user that took the action.
+	ActorUserID int32
+	// Action filters the entries by action.
+	Action string
+	// Entity filters the entries by audited entity.
+	Entity string
+	// Since filters out entries recorded before the given time.
+	Since *time.Time
+	// Until filters out entries recorded at or after the given time.
+	Until *time.Time
+}
+
+func (opts AuditLogListOpts) sqlConds() []*sqlf.Query {
+	preds := []*sqlf.Query{}
+
+	if opts.ActorUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("actor_user_id = %s", opts.ActorUserID))
+	}
+	if opts.Action != "" {
+		preds = append(preds, sqlf.Sprintf("action = %s", opts.Action))
+	}
+	if opts.Entity != "" {
+		preds = append(preds, sqlf.Sprintf("entity = %s", opts.Entity))
+	}
+	if opts.Since != nil {
+		preds = append(preds, sqlf.Sprintf("created_at >= %s", *opts.Since))
+	}
+	if opts.Until != nil {
+		preds = append(preds, sqlf.Sprintf("created_at < %s", *opts.Until))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return preds
+}
+
+type auditLogStore struct {
+	*basestore.Store
+}
+
+// AuditLogsWith instantiates and returns a new AuditLogStore using the other store handle.
+func AuditLogsWith(other basestore.ShareableStore) AuditLogStore {
+	return &auditLogStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+	}
+}
+
+func (s *auditLogStore) With(other basestore.ShareableStore) AuditLogStore {
+	return &auditLogStore{
+		Store: s.Store.With(other),
+	}
+}
+
+func (s *auditLogStore) WithTransact(ctx context.Context, f func(AuditLogStore) error) error {
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		return f(&auditLogStore{
+			Store: tx,
+		})
+	})
+}
+
+func (s *auditLogStore) Insert(ctx context.Context, entries ...audit.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	values := make([]*sqlf.Query, 0, len(entries))
+	for _, e := range entries {
+		fields := []byte("{}")
+		if len(e.Fields) > 0 {
+			var err error
+			if fields, err = json.Marshal(e.Fields); err != nil {
+				return errors.Wrap(err, "marshalling fields")
+			}
+		}
+		createdAt := e.Timestamp
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		values = append(values, sqlf.Sprintf(
+			"(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)",
+			e.AuditID,
+			e.Entity,
+			e.Action,
+			dbutil.NullInt32Column(e.ActorUID),
+			dbutil.NullStringColumn(e.AnonymousUID),
+			dbutil.NullStringColumn(e.IP),
+			dbutil.NullStringColumn(e.ForwardedFor),
+			string(fields),
+			e.Source,
+			createdAt.UTC(),
+		))
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(auditLogInsertQueryFmtstr, sqlf.Join(values, ",\n")))
+}
+
+const auditLogInsertQueryFmtstr = `
+INSERT INTO audit_log_entries (
+	audit_id,
+	entity,
+	action,
+	actor_user_id,
+	actor_anonymous_uid,
+	actor_ip,
+	actor_forwarded_for,
+	fields,
+	source,
+	created_at
+)
+VALUES %s
+`
+
+func (s *auditLogStore) List(ctx context.Context, opts AuditLogListOpts, pArgs *PaginationArgs) ([]*AuditLogEntry, error) {
+	where := opts.sqlConds()
+	if pArgs == nil {
+		pArgs = &PaginationArgs{}
+	}
+	p := pArgs.SQL()
+	if p.Where != nil {
+		where = append(where, p.Where)
+	}
+
+	q := sqlf.Sprintf(auditLogListQueryFmtstr, sqlf.Join(auditLogColumns, ", "), sqlf.Join(where, ") AND ("))
+	q = p.AppendOrderToQuery(q)
+	q = p.AppendLimitToQuery(q)
+
+	return scanAuditLogEntries(s.Query(ctx, q))
+}
+
+const auditLogListQueryFmtstr = `
+SELECT %s
+FROM audit_log_entries
+WHERE (%s)
+`
+
+func (s *auditLogStore) Count(ctx context.Context, opts AuditLogListOpts) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(auditLogCountQueryFmtstr, sqlf.Join(opts.sqlConds(), ") AND ("))))
+	return count, err
+}
+
+const auditLogCountQueryFmtstr = `
+SELECT COUNT(*)
+FROM audit_log_entries
+WHERE (%s)
+`
+
+func (s *auditLogStore) ListUnexported(ctx context.Context, limit int) ([]*AuditLogEntry, error) {
+	q := sqlf.Sprintf(
+		auditLogListUnexportedQueryFmtstr,
+		sqlf.Join(auditLogColumns, ", "),
+		limit,
+	)
+
+	return scanAuditLogEntries(s.Query(ctx, q))
+}
+
+const auditLogListUnexportedQueryFmtstr = `
+SELECT %s
+FROM audit_log_entries
+WHERE exported_at IS NULL
+ORDER BY id ASC
+LIMIT %s
+`
+
+func (s *auditLogStore) MarkExported(ctx context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(auditLogMarkExportedQueryFmtstr, pq.Array(ids)))
+}
+
+const auditLogMarkExportedQueryFmtstr = `
+UPDATE audit_log_entries
+SET exported_at = NOW()
+WHERE id = ANY(%s) AND exported_at IS NULL
+`
+
+func (s *auditLogStore) DeleteOlderThan(ctx context.Context, before time.Time) (int, error) {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(auditLogDeleteOlderThanQueryFmtstr, before))
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := res.RowsAffected()
+	return int(deleted), err
+}
+
+const auditLogDeleteOlderThanQueryFmtstr = `
+DELETE FROM audit_log_entries
+WHERE created_at < %s
+`
+
+// auditLogColumns are the columns that must be selected by audit_log_entries
+// queries in order to use scanAuditLogEntry().
+var auditLogColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("audit_id"),
+	sqlf.Sprintf("entity"),
+	sqlf.Sprintf("action"),
+	sqlf.Sprintf("actor_user_id"),
+	sqlf.Sprintf("actor_anonymous_uid"),
+	sqlf.Sprintf("actor_ip"),
+	sqlf.Sprintf("actor_forwarded_for"),
+	sqlf.Sprintf("fields"),
+	sqlf.Sprintf("source"),
+	sqlf.Sprintf("created_at"),
+}
+
+var scanAuditLogEntries = basestore.NewSliceScanner(scanAuditLogEntry)
+
+// scanAuditLogEntry scans an AuditLogEntry from the given scanner.
+func scanAuditLogEntry(s dbutil.Scanner) (*AuditLogEntry, error) {
+	var (
+		entry  AuditLogEntry
+		fields []byte
+	)
+	if err := s.Scan(
+		&entry.ID,
+		&entry.AuditID,
+		&entry.Entity,
+		&entry.Action,
+		&dbutil.NullInt32{N: &entry.ActorUID},
+		&dbutil.NullString{S: &entry.AnonymousUID},
+		&dbutil.NullString{S: &entry.IP},
+		&dbutil.NullString{S: &entry.ForwardedFor},
+		&fields,
+		&entry.Source,
+		&entry.Timestamp,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(fields, &entry.Fields); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling fields")
+	}
+
+	return &entry, nil
+}
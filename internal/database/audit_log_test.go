@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestAuditLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	store := db.AuditLogs()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	entries := []audit.Entry{
+		{
+			AuditID:   "1",
+			Entity:    "security events",
+			Action:    "SignInSucceeded",
+			ActorUID:  1,
+			IP:        "127.0.0.1",
+			Fields:    map[string]any{"event": "SignInSucceeded"},
+			Source:    "frontend",
+			Timestamp: now.Add(-48 * time.Hour),
+		},
+		{
+			AuditID:      "2",
+			Entity:       "site configuration",
+			Action:       "update",
+			AnonymousUID: "anon",
+			Source:       "frontend",
+			Timestamp:    now.Add(-time.Hour),
+		},
+		{
+			AuditID:   "3",
+			Entity:    "security events",
+			Action:    "SignOutSucceeded",
+			ActorUID:  1,
+			Source:    "frontend",
+			Timestamp: now,
+		},
+	}
+	require.NoError(t, store.Insert(ctx, entries...))
+
+	t.Run("List", func(t *testing.T) {
+		all, err := store.List(ctx, AuditLogListOpts{}, nil)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, "3", all[0].AuditID)
+		assert.Equal(t, "1", all[2].AuditID)
+		assert.Equal(t, entries[0], all[2].Entry)
+		assert.Equal(t, map[string]any{}, all[1].Fields)
+
+		byActor, err := store.List(ctx, AuditLogListOpts{ActorUserID: 1}, nil)
+		require.NoError(t, err)
+		assert.Len(t, byActor, 2)
+
+		byAction, err := store.List(ctx, AuditLogListOpts{Action: "update"}, nil)
+		require.NoError(t, err)
+		require.Len(t, byAction, 1)
+		assert.Equal(t, "anon", byAction[0].AnonymousUID)
+
+		since := now.Add(-2 * time.Hour)
+		until := now
+		byTime, err := store.List(ctx, AuditLogListOpts{Since: &since, Until: &until}, nil)
+		require.NoError(t, err)
+		require.Len(t, byTime, 1)
+		assert.Equal(t, "2", byTime[0].AuditID)
+
+		first, after := 1, strconv.FormatInt(all[0].ID, 10)
+		paged, err := store.List(ctx, AuditLogListOpts{}, &PaginationArgs{First: &first, After: &after})
+		require.NoError(t, err)
+		require.Len(t, paged, 1)
+		assert.Equal(t, "2", paged[0].AuditID)
+
+		count, err := store.Count(ctx, AuditLogListOpts{Entity: "security events"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("ListUnexported and MarkExported", func(t *testing.T) {
+		batch, err := store.ListUnexported(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, batch, 2)
+		assert.Equal(t, "1", batch[0].AuditID)
+		assert.Equal(t, "2", batch[1].AuditID)
+
+		require.NoError(t, store.MarkExported(ctx, batch[0].ID, batch[1].ID))
+
+		batch, err = store.ListUnexported(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, batch, 1)
+		assert.Equal(t, "3", batch[0].AuditID)
+
+		require.NoError(t, store.MarkExported(ctx, batch[0].ID))
+
+		batch, err = store.ListUnexported(ctx, 2)
+		require.NoError(t, err)
+		assert.Empty(t, batch)
+	})
+
+	t.Run("DeleteOlderThan", func(t *testing.T) {
+		deleted, err := store.DeleteOlderThan(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		count, err := store.Count(ctx, AuditLogListOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
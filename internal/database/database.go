@@ -22,6 +22,7 @@ type DB interface {
 
 	AccessRequests() AccessRequestStore
 	AccessTokens() AccessTokenStore
+	AuditLogs() AuditLogStore
 	Authz() AuthzStore
 	BitbucketProjectPermissions() BitbucketProjectPermissionsStore
 	Conf() ConfStore
@@ -124,6 +125,10 @@ func (d *db) AccessRequests() AccessRequestStore {
 	return AccessRequestsWith(d.Store, d.logger.Scoped("AccessRequestStore", ""))
 }
 
+func (d *db) AuditLogs() AuditLogStore {
+	return AuditLogsWith(d.Store)
+}
+
 func (d *db) BitbucketProjectPermissions() BitbucketProjectPermissionsStore {
 	return BitbucketProjectPermissionsStoreWith(d.Store)
 }
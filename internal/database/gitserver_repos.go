@@ -51,6 +51,9 @@ type GitserverRepoStore interface {
 	// a matching row does not yet exist a new one will be created.
 	// If the size value hasn't changed, the row will not be updated.
 	SetRepoSize(ctx context.Context, name api.RepoName, size int64, shardID string) error
+	// SetLFSSize will attempt to update ONLY the size of the Git LFS objects of a
+	// GitServerRepo. If the size value hasn't changed, the row will not be updated.
+	SetLFSSize(ctx context.Context, name api.RepoName, size int64) error
 	// ListReposWithLastError iterates over repos w/ non-empty last_error field and calls the repoFn for these repos.
 	// note that this currently filters out any repos which do not have an associated external service where cloud_default = true.
 	ListReposWithLastError(ctx context.Context) ([]api.RepoName, error)
@@ -516,6 +519,24 @@ WHERE
 	return nil
 }
 
+func (s *gitserverRepoStore) SetLFSSize(ctx context.Context, name api.RepoName, size int64) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	lfs_size_bytes = %s,
+	updated_at = NOW()
+WHERE
+	repo_id = (SELECT id FROM repo WHERE name = %s)
+	AND
+	lfs_size_bytes IS DISTINCT FROM %s
+	`, size, name, size))
+	if err != nil {
+		return errors.Wrap(err, "setting LFS size")
+	}
+
+	return nil
+}
+
 func (s *gitserverRepoStore) LogCorruption(ctx context.Context, name api.RepoName, reason string, shardID string) error {
 	// trim reason to 1 MB so that we don't store huge reasons and run into trouble when it gets too large
 	if len(reason) > MaxReasonSizeInMB {
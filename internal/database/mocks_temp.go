@@ -15,6 +15,7 @@ import (
 	uuid "github.com/google/uuid"
 	sqlf "github.com/keegancsmith/sqlf"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
+	audit "github.com/sourcegraph/sourcegraph/internal/audit"
 	conf "github.com/sourcegraph/sourcegraph/internal/conf"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	encryption "github.com/sourcegraph/sourcegraph/internal/encryption"
@@ -1182,6 +1183,9 @@ type MockAccessTokenStore struct {
 	// DeleteByTokenFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteByToken.
 	DeleteByTokenFunc *AccessTokenStoreDeleteByTokenFunc
+	// DeleteExpiredFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteExpired.
+	DeleteExpiredFunc *AccessTokenStoreDeleteExpiredFunc
 	// GetByIDFunc is an instance of a mock function object controlling the
 	// behavior of the method GetByID.
 	GetByIDFunc *AccessTokenStoreGetByIDFunc
@@ -1197,9 +1201,15 @@ type MockAccessTokenStore struct {
 	// ListFunc is an instance of a mock function object controlling the
 	// behavior of the method List.
 	ListFunc *AccessTokenStoreListFunc
+	// ListExpiringFunc is an instance of a mock function object controlling
+	// the behavior of the method ListExpiring.
+	ListExpiringFunc *AccessTokenStoreListExpiringFunc
 	// LookupFunc is an instance of a mock function object controlling the
 	// behavior of the method Lookup.
 	LookupFunc *AccessTokenStoreLookupFunc
+	// MarkExpiryNotifiedFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkExpiryNotified.
+	MarkExpiryNotifiedFunc *AccessTokenStoreMarkExpiryNotifiedFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
 	WithFunc *AccessTokenStoreWithFunc
@@ -1219,7 +1229,7 @@ func NewMockAccessTokenStore() *MockAccessTokenStore {
 			},
 		},
 		CreateFunc: &AccessTokenStoreCreateFunc{
-			defaultHook: func(context.Context, int32, []string, string, int32) (r0 int64, r1 string, r2 error) {
+			defaultHook: func(context.Context, int32, []string, string, int32, time.Time) (r0 int64, r1 string, r2 error) {
 				return
 			},
 		},
@@ -1238,6 +1248,11 @@ func NewMockAccessTokenStore() *MockAccessTokenStore {
 				return
 			},
 		},
+		DeleteExpiredFunc: &AccessTokenStoreDeleteExpiredFunc{
+			defaultHook: func(context.Context) (r0 int, r1 error) {
+				return
+			},
+		},
 		GetByIDFunc: &AccessTokenStoreGetByIDFunc{
 			defaultHook: func(context.Context, int64) (r0 *AccessToken, r1 error) {
 				return
@@ -1263,8 +1278,18 @@ func NewMockAccessTokenStore() *MockAccessTokenStore {
 				return
 			},
 		},
+		ListExpiringFunc: &AccessTokenStoreListExpiringFunc{
+			defaultHook: func(context.Context, time.Time) (r0 []*AccessToken, r1 error) {
+				return
+			},
+		},
 		LookupFunc: &AccessTokenStoreLookupFunc{
-			defaultHook: func(context.Context, string, string) (r0 int32, r1 error) {
+			defaultHook: func(context.Context, string, ...string) (r0 int32, r1 []string, r2 error) {
+				return
+			},
+		},
+		MarkExpiryNotifiedFunc: &AccessTokenStoreMarkExpiryNotifiedFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
 				return
 			},
 		},
@@ -1291,7 +1316,7 @@ func NewStrictMockAccessTokenStore() *MockAccessTokenStore {
 			},
 		},
 		CreateFunc: &AccessTokenStoreCreateFunc{
-			defaultHook: func(context.Context, int32, []string, string, int32) (int64, string, error) {
+			defaultHook: func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error) {
 				panic("unexpected invocation of MockAccessTokenStore.Create")
 			},
 		},
@@ -1310,6 +1335,11 @@ func NewStrictMockAccessTokenStore() *MockAccessTokenStore {
 				panic("unexpected invocation of MockAccessTokenStore.DeleteByToken")
 			},
 		},
+		DeleteExpiredFunc: &AccessTokenStoreDeleteExpiredFunc{
+			defaultHook: func(context.Context) (int, error) {
+				panic("unexpected invocation of MockAccessTokenStore.DeleteExpired")
+			},
+		},
 		GetByIDFunc: &AccessTokenStoreGetByIDFunc{
 			defaultHook: func(context.Context, int64) (*AccessToken, error) {
 				panic("unexpected invocation of MockAccessTokenStore.GetByID")
@@ -1335,11 +1365,21 @@ func NewStrictMockAccessTokenStore() *MockAccessTokenStore {
 				panic("unexpected invocation of MockAccessTokenStore.List")
 			},
 		},
+		ListExpiringFunc: &AccessTokenStoreListExpiringFunc{
+			defaultHook: func(context.Context, time.Time) ([]*AccessToken, error) {
+				panic("unexpected invocation of MockAccessTokenStore.ListExpiring")
+			},
+		},
 		LookupFunc: &AccessTokenStoreLookupFunc{
-			defaultHook: func(context.Context, string, string) (int32, error) {
+			defaultHook: func(context.Context, string, ...string) (int32, []string, error) {
 				panic("unexpected invocation of MockAccessTokenStore.Lookup")
 			},
 		},
+		MarkExpiryNotifiedFunc: &AccessTokenStoreMarkExpiryNotifiedFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockAccessTokenStore.MarkExpiryNotified")
+			},
+		},
 		WithFunc: &AccessTokenStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) AccessTokenStore {
 				panic("unexpected invocation of MockAccessTokenStore.With")
@@ -1373,6 +1413,9 @@ func NewMockAccessTokenStoreFrom(i AccessTokenStore) *MockAccessTokenStore {
 		DeleteByTokenFunc: &AccessTokenStoreDeleteByTokenFunc{
 			defaultHook: i.DeleteByToken,
 		},
+		DeleteExpiredFunc: &AccessTokenStoreDeleteExpiredFunc{
+			defaultHook: i.DeleteExpired,
+		},
 		GetByIDFunc: &AccessTokenStoreGetByIDFunc{
 			defaultHook: i.GetByID,
 		},
@@ -1388,9 +1431,15 @@ func NewMockAccessTokenStoreFrom(i AccessTokenStore) *MockAccessTokenStore {
 		ListFunc: &AccessTokenStoreListFunc{
 			defaultHook: i.List,
 		},
+		ListExpiringFunc: &AccessTokenStoreListExpiringFunc{
+			defaultHook: i.ListExpiring,
+		},
 		LookupFunc: &AccessTokenStoreLookupFunc{
 			defaultHook: i.Lookup,
 		},
+		MarkExpiryNotifiedFunc: &AccessTokenStoreMarkExpiryNotifiedFunc{
+			defaultHook: i.MarkExpiryNotified,
+		},
 		WithFunc: &AccessTokenStoreWithFunc{
 			defaultHook: i.With,
 		},
@@ -1511,24 +1560,24 @@ func (c AccessTokenStoreCountFuncCall) Results() []interface{} {
 // AccessTokenStoreCreateFunc describes the behavior when the Create method
 // of the parent MockAccessTokenStore instance is invoked.
 type AccessTokenStoreCreateFunc struct {
-	defaultHook func(context.Context, int32, []string, string, int32) (int64, string, error)
-	hooks       []func(context.Context, int32, []string, string, int32) (int64, string, error)
+	defaultHook func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error)
+	hooks       []func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error)
 	history     []AccessTokenStoreCreateFuncCall
 	mutex       sync.Mutex
 }
 
 // Create delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockAccessTokenStore) Create(v0 context.Context, v1 int32, v2 []string, v3 string, v4 int32) (int64, string, error) {
-	r0, r1, r2 := m.CreateFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateFunc.appendCall(AccessTokenStoreCreateFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+func (m *MockAccessTokenStore) Create(v0 context.Context, v1 int32, v2 []string, v3 string, v4 int32, v5 time.Time) (int64, string, error) {
+	r0, r1, r2 := m.CreateFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateFunc.appendCall(AccessTokenStoreCreateFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
 	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the Create method of the
 // parent MockAccessTokenStore instance is invoked and the hook queue is
 // empty.
-func (f *AccessTokenStoreCreateFunc) SetDefaultHook(hook func(context.Context, int32, []string, string, int32) (int64, string, error)) {
+func (f *AccessTokenStoreCreateFunc) SetDefaultHook(hook func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error)) {
 	f.defaultHook = hook
 }
 
@@ -1536,7 +1585,7 @@ func (f *AccessTokenStoreCreateFunc) SetDefaultHook(hook func(context.Context, i
 // Create method of the parent MockAccessTokenStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *AccessTokenStoreCreateFunc) PushHook(hook func(context.Context, int32, []string, string, int32) (int64, string, error)) {
+func (f *AccessTokenStoreCreateFunc) PushHook(hook func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1545,19 +1594,19 @@ func (f *AccessTokenStoreCreateFunc) PushHook(hook func(context.Context, int32,
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *AccessTokenStoreCreateFunc) SetDefaultReturn(r0 int64, r1 string, r2 error) {
-	f.SetDefaultHook(func(context.Context, int32, []string, string, int32) (int64, string, error) {
+	f.SetDefaultHook(func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error) {
 		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *AccessTokenStoreCreateFunc) PushReturn(r0 int64, r1 string, r2 error) {
-	f.PushHook(func(context.Context, int32, []string, string, int32) (int64, string, error) {
+	f.PushHook(func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *AccessTokenStoreCreateFunc) nextHook() func(context.Context, int32, []string, string, int32) (int64, string, error) {
+func (f *AccessTokenStoreCreateFunc) nextHook() func(context.Context, int32, []string, string, int32, time.Time) (int64, string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1605,6 +1654,9 @@ type AccessTokenStoreCreateFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 int32
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 int64
@@ -1619,7 +1671,7 @@ type AccessTokenStoreCreateFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c AccessTokenStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -1962,6 +2014,113 @@ func (c AccessTokenStoreDeleteByTokenFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// AccessTokenStoreDeleteExpiredFunc describes the behavior when the
+// DeleteExpired method of the parent MockAccessTokenStore instance is
+// invoked.
+type AccessTokenStoreDeleteExpiredFunc struct {
+	defaultHook func(context.Context) (int, error)
+	hooks       []func(context.Context) (int, error)
+	history     []AccessTokenStoreDeleteExpiredFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteExpired delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAccessTokenStore) DeleteExpired(v0 context.Context) (int, error) {
+	r0, r1 := m.DeleteExpiredFunc.nextHook()(v0)
+	m.DeleteExpiredFunc.appendCall(AccessTokenStoreDeleteExpiredFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeleteExpired method
+// of the parent MockAccessTokenStore instance is invoked and the hook queue
+// is empty.
+func (f *AccessTokenStoreDeleteExpiredFunc) SetDefaultHook(hook func(context.Context) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteExpired method of the parent MockAccessTokenStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AccessTokenStoreDeleteExpiredFunc) PushHook(hook func(context.Context) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AccessTokenStoreDeleteExpiredFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AccessTokenStoreDeleteExpiredFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *AccessTokenStoreDeleteExpiredFunc) nextHook() func(context.Context) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AccessTokenStoreDeleteExpiredFunc) appendCall(r0 AccessTokenStoreDeleteExpiredFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AccessTokenStoreDeleteExpiredFuncCall
+// objects describing the invocations of this function.
+func (f *AccessTokenStoreDeleteExpiredFunc) History() []AccessTokenStoreDeleteExpiredFuncCall {
+	f.mutex.Lock()
+	history := make([]AccessTokenStoreDeleteExpiredFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AccessTokenStoreDeleteExpiredFuncCall is an object that describes an
+// invocation of method DeleteExpired on an instance of
+// MockAccessTokenStore.
+type AccessTokenStoreDeleteExpiredFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AccessTokenStoreDeleteExpiredFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AccessTokenStoreDeleteExpiredFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AccessTokenStoreGetByIDFunc describes the behavior when the GetByID
 // method of the parent MockAccessTokenStore instance is invoked.
 type AccessTokenStoreGetByIDFunc struct {
@@ -2492,27 +2651,136 @@ func (c AccessTokenStoreListFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AccessTokenStoreListExpiringFunc describes the behavior when the
+// ListExpiring method of the parent MockAccessTokenStore instance is
+// invoked.
+type AccessTokenStoreListExpiringFunc struct {
+	defaultHook func(context.Context, time.Time) ([]*AccessToken, error)
+	hooks       []func(context.Context, time.Time) ([]*AccessToken, error)
+	history     []AccessTokenStoreListExpiringFuncCall
+	mutex       sync.Mutex
+}
+
+// ListExpiring delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAccessTokenStore) ListExpiring(v0 context.Context, v1 time.Time) ([]*AccessToken, error) {
+	r0, r1 := m.ListExpiringFunc.nextHook()(v0, v1)
+	m.ListExpiringFunc.appendCall(AccessTokenStoreListExpiringFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListExpiring method
+// of the parent MockAccessTokenStore instance is invoked and the hook queue
+// is empty.
+func (f *AccessTokenStoreListExpiringFunc) SetDefaultHook(hook func(context.Context, time.Time) ([]*AccessToken, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListExpiring method of the parent MockAccessTokenStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AccessTokenStoreListExpiringFunc) PushHook(hook func(context.Context, time.Time) ([]*AccessToken, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AccessTokenStoreListExpiringFunc) SetDefaultReturn(r0 []*AccessToken, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) ([]*AccessToken, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AccessTokenStoreListExpiringFunc) PushReturn(r0 []*AccessToken, r1 error) {
+	f.PushHook(func(context.Context, time.Time) ([]*AccessToken, error) {
+		return r0, r1
+	})
+}
+
+func (f *AccessTokenStoreListExpiringFunc) nextHook() func(context.Context, time.Time) ([]*AccessToken, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AccessTokenStoreListExpiringFunc) appendCall(r0 AccessTokenStoreListExpiringFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AccessTokenStoreListExpiringFuncCall
+// objects describing the invocations of this function.
+func (f *AccessTokenStoreListExpiringFunc) History() []AccessTokenStoreListExpiringFuncCall {
+	f.mutex.Lock()
+	history := make([]AccessTokenStoreListExpiringFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AccessTokenStoreListExpiringFuncCall is an object that describes an
+// invocation of method ListExpiring on an instance of MockAccessTokenStore.
+type AccessTokenStoreListExpiringFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*AccessToken
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AccessTokenStoreListExpiringFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AccessTokenStoreListExpiringFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AccessTokenStoreLookupFunc describes the behavior when the Lookup method
 // of the parent MockAccessTokenStore instance is invoked.
 type AccessTokenStoreLookupFunc struct {
-	defaultHook func(context.Context, string, string) (int32, error)
-	hooks       []func(context.Context, string, string) (int32, error)
+	defaultHook func(context.Context, string, ...string) (int32, []string, error)
+	hooks       []func(context.Context, string, ...string) (int32, []string, error)
 	history     []AccessTokenStoreLookupFuncCall
 	mutex       sync.Mutex
 }
 
 // Lookup delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockAccessTokenStore) Lookup(v0 context.Context, v1 string, v2 string) (int32, error) {
-	r0, r1 := m.LookupFunc.nextHook()(v0, v1, v2)
-	m.LookupFunc.appendCall(AccessTokenStoreLookupFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+func (m *MockAccessTokenStore) Lookup(v0 context.Context, v1 string, v2 ...string) (int32, []string, error) {
+	r0, r1, r2 := m.LookupFunc.nextHook()(v0, v1, v2...)
+	m.LookupFunc.appendCall(AccessTokenStoreLookupFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the Lookup method of the
 // parent MockAccessTokenStore instance is invoked and the hook queue is
 // empty.
-func (f *AccessTokenStoreLookupFunc) SetDefaultHook(hook func(context.Context, string, string) (int32, error)) {
+func (f *AccessTokenStoreLookupFunc) SetDefaultHook(hook func(context.Context, string, ...string) (int32, []string, error)) {
 	f.defaultHook = hook
 }
 
@@ -2520,7 +2788,7 @@ func (f *AccessTokenStoreLookupFunc) SetDefaultHook(hook func(context.Context, s
 // Lookup method of the parent MockAccessTokenStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *AccessTokenStoreLookupFunc) PushHook(hook func(context.Context, string, string) (int32, error)) {
+func (f *AccessTokenStoreLookupFunc) PushHook(hook func(context.Context, string, ...string) (int32, []string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2528,20 +2796,20 @@ func (f *AccessTokenStoreLookupFunc) PushHook(hook func(context.Context, string,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *AccessTokenStoreLookupFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, string) (int32, error) {
-		return r0, r1
+func (f *AccessTokenStoreLookupFunc) SetDefaultReturn(r0 int32, r1 []string, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, ...string) (int32, []string, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *AccessTokenStoreLookupFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, string, string) (int32, error) {
-		return r0, r1
+func (f *AccessTokenStoreLookupFunc) PushReturn(r0 int32, r1 []string, r2 error) {
+	f.PushHook(func(context.Context, string, ...string) (int32, []string, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *AccessTokenStoreLookupFunc) nextHook() func(context.Context, string, string) (int32, error) {
+func (f *AccessTokenStoreLookupFunc) nextHook() func(context.Context, string, ...string) (int32, []string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2580,27 +2848,145 @@ type AccessTokenStoreLookupFuncCall struct {
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 string
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 int32
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 []string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation.
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
 func (c AccessTokenStoreLookupFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
 func (c AccessTokenStoreLookupFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// AccessTokenStoreMarkExpiryNotifiedFunc describes the behavior when the
+// MarkExpiryNotified method of the parent MockAccessTokenStore instance is
+// invoked.
+type AccessTokenStoreMarkExpiryNotifiedFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []AccessTokenStoreMarkExpiryNotifiedFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkExpiryNotified delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAccessTokenStore) MarkExpiryNotified(v0 context.Context, v1 int64) error {
+	r0 := m.MarkExpiryNotifiedFunc.nextHook()(v0, v1)
+	m.MarkExpiryNotifiedFunc.appendCall(AccessTokenStoreMarkExpiryNotifiedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MarkExpiryNotified
+// method of the parent MockAccessTokenStore instance is invoked and the
+// hook queue is empty.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkExpiryNotified method of the parent MockAccessTokenStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) appendCall(r0 AccessTokenStoreMarkExpiryNotifiedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AccessTokenStoreMarkExpiryNotifiedFuncCall
+// objects describing the invocations of this function.
+func (f *AccessTokenStoreMarkExpiryNotifiedFunc) History() []AccessTokenStoreMarkExpiryNotifiedFuncCall {
+	f.mutex.Lock()
+	history := make([]AccessTokenStoreMarkExpiryNotifiedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AccessTokenStoreMarkExpiryNotifiedFuncCall is an object that describes an
+// invocation of method MarkExpiryNotified on an instance of
+// MockAccessTokenStore.
+type AccessTokenStoreMarkExpiryNotifiedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AccessTokenStoreMarkExpiryNotifiedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AccessTokenStoreMarkExpiryNotifiedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
 // AccessTokenStoreWithFunc describes the behavior when the With method of
@@ -2811,6 +3197,1140 @@ func (c AccessTokenStoreWithTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockAuditLogStore is a mock implementation of the AuditLogStore interface
+// (from the package github.com/sourcegraph/sourcegraph/internal/database)
+// used for unit testing.
+type MockAuditLogStore struct {
+	// CountFunc is an instance of a mock function object controlling the
+	// behavior of the method Count.
+	CountFunc *AuditLogStoreCountFunc
+	// DeleteOlderThanFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteOlderThan.
+	DeleteOlderThanFunc *AuditLogStoreDeleteOlderThanFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *AuditLogStoreHandleFunc
+	// InsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Insert.
+	InsertFunc *AuditLogStoreInsertFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *AuditLogStoreListFunc
+	// ListUnexportedFunc is an instance of a mock function object
+	// controlling the behavior of the method ListUnexported.
+	ListUnexportedFunc *AuditLogStoreListUnexportedFunc
+	// MarkExportedFunc is an instance of a mock function object controlling
+	// the behavior of the method MarkExported.
+	MarkExportedFunc *AuditLogStoreMarkExportedFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *AuditLogStoreWithFunc
+	// WithTransactFunc is an instance of a mock function object controlling
+	// the behavior of the method WithTransact.
+	WithTransactFunc *AuditLogStoreWithTransactFunc
+}
+
+// NewMockAuditLogStore creates a new mock of the AuditLogStore interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockAuditLogStore() *MockAuditLogStore {
+	return &MockAuditLogStore{
+		CountFunc: &AuditLogStoreCountFunc{
+			defaultHook: func(context.Context, AuditLogListOpts) (r0 int, r1 error) {
+				return
+			},
+		},
+		DeleteOlderThanFunc: &AuditLogStoreDeleteOlderThanFunc{
+			defaultHook: func(context.Context, time.Time) (r0 int, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &AuditLogStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		InsertFunc: &AuditLogStoreInsertFunc{
+			defaultHook: func(context.Context, ...audit.Entry) (r0 error) {
+				return
+			},
+		},
+		ListFunc: &AuditLogStoreListFunc{
+			defaultHook: func(context.Context, AuditLogListOpts, *PaginationArgs) (r0 []*AuditLogEntry, r1 error) {
+				return
+			},
+		},
+		ListUnexportedFunc: &AuditLogStoreListUnexportedFunc{
+			defaultHook: func(context.Context, int) (r0 []*AuditLogEntry, r1 error) {
+				return
+			},
+		},
+		MarkExportedFunc: &AuditLogStoreMarkExportedFunc{
+			defaultHook: func(context.Context, ...int64) (r0 error) {
+				return
+			},
+		},
+		WithFunc: &AuditLogStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 AuditLogStore) {
+				return
+			},
+		},
+		WithTransactFunc: &AuditLogStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(AuditLogStore) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockAuditLogStore creates a new mock of the AuditLogStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockAuditLogStore() *MockAuditLogStore {
+	return &MockAuditLogStore{
+		CountFunc: &AuditLogStoreCountFunc{
+			defaultHook: func(context.Context, AuditLogListOpts) (int, error) {
+				panic("unexpected invocation of MockAuditLogStore.Count")
+			},
+		},
+		DeleteOlderThanFunc: &AuditLogStoreDeleteOlderThanFunc{
+			defaultHook: func(context.Context, time.Time) (int, error) {
+				panic("unexpected invocation of MockAuditLogStore.DeleteOlderThan")
+			},
+		},
+		HandleFunc: &AuditLogStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockAuditLogStore.Handle")
+			},
+		},
+		InsertFunc: &AuditLogStoreInsertFunc{
+			defaultHook: func(context.Context, ...audit.Entry) error {
+				panic("unexpected invocation of MockAuditLogStore.Insert")
+			},
+		},
+		ListFunc: &AuditLogStoreListFunc{
+			defaultHook: func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error) {
+				panic("unexpected invocation of MockAuditLogStore.List")
+			},
+		},
+		ListUnexportedFunc: &AuditLogStoreListUnexportedFunc{
+			defaultHook: func(context.Context, int) ([]*AuditLogEntry, error) {
+				panic("unexpected invocation of MockAuditLogStore.ListUnexported")
+			},
+		},
+		MarkExportedFunc: &AuditLogStoreMarkExportedFunc{
+			defaultHook: func(context.Context, ...int64) error {
+				panic("unexpected invocation of MockAuditLogStore.MarkExported")
+			},
+		},
+		WithFunc: &AuditLogStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) AuditLogStore {
+				panic("unexpected invocation of MockAuditLogStore.With")
+			},
+		},
+		WithTransactFunc: &AuditLogStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(AuditLogStore) error) error {
+				panic("unexpected invocation of MockAuditLogStore.WithTransact")
+			},
+		},
+	}
+}
+
+// NewMockAuditLogStoreFrom creates a new mock of the MockAuditLogStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockAuditLogStoreFrom(i AuditLogStore) *MockAuditLogStore {
+	return &MockAuditLogStore{
+		CountFunc: &AuditLogStoreCountFunc{
+			defaultHook: i.Count,
+		},
+		DeleteOlderThanFunc: &AuditLogStoreDeleteOlderThanFunc{
+			defaultHook: i.DeleteOlderThan,
+		},
+		HandleFunc: &AuditLogStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		InsertFunc: &AuditLogStoreInsertFunc{
+			defaultHook: i.Insert,
+		},
+		ListFunc: &AuditLogStoreListFunc{
+			defaultHook: i.List,
+		},
+		ListUnexportedFunc: &AuditLogStoreListUnexportedFunc{
+			defaultHook: i.ListUnexported,
+		},
+		MarkExportedFunc: &AuditLogStoreMarkExportedFunc{
+			defaultHook: i.MarkExported,
+		},
+		WithFunc: &AuditLogStoreWithFunc{
+			defaultHook: i.With,
+		},
+		WithTransactFunc: &AuditLogStoreWithTransactFunc{
+			defaultHook: i.WithTransact,
+		},
+	}
+}
+
+// AuditLogStoreCountFunc describes the behavior when the Count method of
+// the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreCountFunc struct {
+	defaultHook func(context.Context, AuditLogListOpts) (int, error)
+	hooks       []func(context.Context, AuditLogListOpts) (int, error)
+	history     []AuditLogStoreCountFuncCall
+	mutex       sync.Mutex
+}
+
+// Count delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) Count(v0 context.Context, v1 AuditLogListOpts) (int, error) {
+	r0, r1 := m.CountFunc.nextHook()(v0, v1)
+	m.CountFunc.appendCall(AuditLogStoreCountFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Count method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreCountFunc) SetDefaultHook(hook func(context.Context, AuditLogListOpts) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Count method of the parent MockAuditLogStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreCountFunc) PushHook(hook func(context.Context, AuditLogListOpts) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreCountFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, AuditLogListOpts) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreCountFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, AuditLogListOpts) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *AuditLogStoreCountFunc) nextHook() func(context.Context, AuditLogListOpts) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreCountFunc) appendCall(r0 AuditLogStoreCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreCountFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreCountFunc) History() []AuditLogStoreCountFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreCountFuncCall is an object that describes an invocation of
+// method Count on an instance of MockAuditLogStore.
+type AuditLogStoreCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 AuditLogListOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AuditLogStoreDeleteOlderThanFunc describes the behavior when the
+// DeleteOlderThan method of the parent MockAuditLogStore instance is
+// invoked.
+type AuditLogStoreDeleteOlderThanFunc struct {
+	defaultHook func(context.Context, time.Time) (int, error)
+	hooks       []func(context.Context, time.Time) (int, error)
+	history     []AuditLogStoreDeleteOlderThanFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteOlderThan delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAuditLogStore) DeleteOlderThan(v0 context.Context, v1 time.Time) (int, error) {
+	r0, r1 := m.DeleteOlderThanFunc.nextHook()(v0, v1)
+	m.DeleteOlderThanFunc.appendCall(AuditLogStoreDeleteOlderThanFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeleteOlderThan
+// method of the parent MockAuditLogStore instance is invoked and the hook
+// queue is empty.
+func (f *AuditLogStoreDeleteOlderThanFunc) SetDefaultHook(hook func(context.Context, time.Time) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteOlderThan method of the parent MockAuditLogStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AuditLogStoreDeleteOlderThanFunc) PushHook(hook func(context.Context, time.Time) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreDeleteOlderThanFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreDeleteOlderThanFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *AuditLogStoreDeleteOlderThanFunc) nextHook() func(context.Context, time.Time) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreDeleteOlderThanFunc) appendCall(r0 AuditLogStoreDeleteOlderThanFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreDeleteOlderThanFuncCall
+// objects describing the invocations of this function.
+func (f *AuditLogStoreDeleteOlderThanFunc) History() []AuditLogStoreDeleteOlderThanFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreDeleteOlderThanFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreDeleteOlderThanFuncCall is an object that describes an
+// invocation of method DeleteOlderThan on an instance of MockAuditLogStore.
+type AuditLogStoreDeleteOlderThanFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreDeleteOlderThanFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreDeleteOlderThanFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AuditLogStoreHandleFunc describes the behavior when the Handle method of
+// the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []AuditLogStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(AuditLogStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockAuditLogStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreHandleFunc) appendCall(r0 AuditLogStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreHandleFunc) History() []AuditLogStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreHandleFuncCall is an object that describes an invocation of
+// method Handle on an instance of MockAuditLogStore.
+type AuditLogStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AuditLogStoreInsertFunc describes the behavior when the Insert method of
+// the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreInsertFunc struct {
+	defaultHook func(context.Context, ...audit.Entry) error
+	hooks       []func(context.Context, ...audit.Entry) error
+	history     []AuditLogStoreInsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Insert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) Insert(v0 context.Context, v1 ...audit.Entry) error {
+	r0 := m.InsertFunc.nextHook()(v0, v1...)
+	m.InsertFunc.appendCall(AuditLogStoreInsertFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Insert method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreInsertFunc) SetDefaultHook(hook func(context.Context, ...audit.Entry) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Insert method of the parent MockAuditLogStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreInsertFunc) PushHook(hook func(context.Context, ...audit.Entry) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreInsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, ...audit.Entry) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreInsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, ...audit.Entry) error {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreInsertFunc) nextHook() func(context.Context, ...audit.Entry) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreInsertFunc) appendCall(r0 AuditLogStoreInsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreInsertFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreInsertFunc) History() []AuditLogStoreInsertFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreInsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreInsertFuncCall is an object that describes an invocation of
+// method Insert on an instance of MockAuditLogStore.
+type AuditLogStoreInsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []audit.Entry
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c AuditLogStoreInsertFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreInsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AuditLogStoreListFunc describes the behavior when the List method of the
+// parent MockAuditLogStore instance is invoked.
+type AuditLogStoreListFunc struct {
+	defaultHook func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error)
+	hooks       []func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error)
+	history     []AuditLogStoreListFuncCall
+	mutex       sync.Mutex
+}
+
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) List(v0 context.Context, v1 AuditLogListOpts, v2 *PaginationArgs) ([]*AuditLogEntry, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1, v2)
+	m.ListFunc.appendCall(AuditLogStoreListFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreListFunc) SetDefaultHook(hook func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockAuditLogStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreListFunc) PushHook(hook func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreListFunc) SetDefaultReturn(r0 []*AuditLogEntry, r1 error) {
+	f.SetDefaultHook(func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreListFunc) PushReturn(r0 []*AuditLogEntry, r1 error) {
+	f.PushHook(func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error) {
+		return r0, r1
+	})
+}
+
+func (f *AuditLogStoreListFunc) nextHook() func(context.Context, AuditLogListOpts, *PaginationArgs) ([]*AuditLogEntry, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreListFunc) appendCall(r0 AuditLogStoreListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreListFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreListFunc) History() []AuditLogStoreListFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreListFuncCall is an object that describes an invocation of
+// method List on an instance of MockAuditLogStore.
+type AuditLogStoreListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 AuditLogListOpts
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *PaginationArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*AuditLogEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AuditLogStoreListUnexportedFunc describes the behavior when the
+// ListUnexported method of the parent MockAuditLogStore instance is
+// invoked.
+type AuditLogStoreListUnexportedFunc struct {
+	defaultHook func(context.Context, int) ([]*AuditLogEntry, error)
+	hooks       []func(context.Context, int) ([]*AuditLogEntry, error)
+	history     []AuditLogStoreListUnexportedFuncCall
+	mutex       sync.Mutex
+}
+
+// ListUnexported delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAuditLogStore) ListUnexported(v0 context.Context, v1 int) ([]*AuditLogEntry, error) {
+	r0, r1 := m.ListUnexportedFunc.nextHook()(v0, v1)
+	m.ListUnexportedFunc.appendCall(AuditLogStoreListUnexportedFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListUnexported
+// method of the parent MockAuditLogStore instance is invoked and the hook
+// queue is empty.
+func (f *AuditLogStoreListUnexportedFunc) SetDefaultHook(hook func(context.Context, int) ([]*AuditLogEntry, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListUnexported method of the parent MockAuditLogStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AuditLogStoreListUnexportedFunc) PushHook(hook func(context.Context, int) ([]*AuditLogEntry, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreListUnexportedFunc) SetDefaultReturn(r0 []*AuditLogEntry, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]*AuditLogEntry, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreListUnexportedFunc) PushReturn(r0 []*AuditLogEntry, r1 error) {
+	f.PushHook(func(context.Context, int) ([]*AuditLogEntry, error) {
+		return r0, r1
+	})
+}
+
+func (f *AuditLogStoreListUnexportedFunc) nextHook() func(context.Context, int) ([]*AuditLogEntry, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreListUnexportedFunc) appendCall(r0 AuditLogStoreListUnexportedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreListUnexportedFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreListUnexportedFunc) History() []AuditLogStoreListUnexportedFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreListUnexportedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreListUnexportedFuncCall is an object that describes an
+// invocation of method ListUnexported on an instance of MockAuditLogStore.
+type AuditLogStoreListUnexportedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*AuditLogEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreListUnexportedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreListUnexportedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AuditLogStoreMarkExportedFunc describes the behavior when the
+// MarkExported method of the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreMarkExportedFunc struct {
+	defaultHook func(context.Context, ...int64) error
+	hooks       []func(context.Context, ...int64) error
+	history     []AuditLogStoreMarkExportedFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkExported delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAuditLogStore) MarkExported(v0 context.Context, v1 ...int64) error {
+	r0 := m.MarkExportedFunc.nextHook()(v0, v1...)
+	m.MarkExportedFunc.appendCall(AuditLogStoreMarkExportedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MarkExported method
+// of the parent MockAuditLogStore instance is invoked and the hook queue is
+// empty.
+func (f *AuditLogStoreMarkExportedFunc) SetDefaultHook(hook func(context.Context, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkExported method of the parent MockAuditLogStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *AuditLogStoreMarkExportedFunc) PushHook(hook func(context.Context, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreMarkExportedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreMarkExportedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, ...int64) error {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreMarkExportedFunc) nextHook() func(context.Context, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreMarkExportedFunc) appendCall(r0 AuditLogStoreMarkExportedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreMarkExportedFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreMarkExportedFunc) History() []AuditLogStoreMarkExportedFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreMarkExportedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreMarkExportedFuncCall is an object that describes an
+// invocation of method MarkExported on an instance of MockAuditLogStore.
+type AuditLogStoreMarkExportedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c AuditLogStoreMarkExportedFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreMarkExportedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AuditLogStoreWithFunc describes the behavior when the With method of the
+// parent MockAuditLogStore instance is invoked.
+type AuditLogStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) AuditLogStore
+	hooks       []func(basestore.ShareableStore) AuditLogStore
+	history     []AuditLogStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAuditLogStore) With(v0 basestore.ShareableStore) AuditLogStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(AuditLogStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockAuditLogStore instance is invoked and the hook queue is empty.
+func (f *AuditLogStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) AuditLogStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockAuditLogStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AuditLogStoreWithFunc) PushHook(hook func(basestore.ShareableStore) AuditLogStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreWithFunc) SetDefaultReturn(r0 AuditLogStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) AuditLogStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreWithFunc) PushReturn(r0 AuditLogStore) {
+	f.PushHook(func(basestore.ShareableStore) AuditLogStore {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreWithFunc) nextHook() func(basestore.ShareableStore) AuditLogStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreWithFunc) appendCall(r0 AuditLogStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreWithFunc) History() []AuditLogStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreWithFuncCall is an object that describes an invocation of
+// method With on an instance of MockAuditLogStore.
+type AuditLogStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 AuditLogStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AuditLogStoreWithTransactFunc describes the behavior when the
+// WithTransact method of the parent MockAuditLogStore instance is invoked.
+type AuditLogStoreWithTransactFunc struct {
+	defaultHook func(context.Context, func(AuditLogStore) error) error
+	hooks       []func(context.Context, func(AuditLogStore) error) error
+	history     []AuditLogStoreWithTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// WithTransact delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAuditLogStore) WithTransact(v0 context.Context, v1 func(AuditLogStore) error) error {
+	r0 := m.WithTransactFunc.nextHook()(v0, v1)
+	m.WithTransactFunc.appendCall(AuditLogStoreWithTransactFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WithTransact method
+// of the parent MockAuditLogStore instance is invoked and the hook queue is
+// empty.
+func (f *AuditLogStoreWithTransactFunc) SetDefaultHook(hook func(context.Context, func(AuditLogStore) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithTransact method of the parent MockAuditLogStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *AuditLogStoreWithTransactFunc) PushHook(hook func(context.Context, func(AuditLogStore) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AuditLogStoreWithTransactFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, func(AuditLogStore) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AuditLogStoreWithTransactFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, func(AuditLogStore) error) error {
+		return r0
+	})
+}
+
+func (f *AuditLogStoreWithTransactFunc) nextHook() func(context.Context, func(AuditLogStore) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AuditLogStoreWithTransactFunc) appendCall(r0 AuditLogStoreWithTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AuditLogStoreWithTransactFuncCall objects
+// describing the invocations of this function.
+func (f *AuditLogStoreWithTransactFunc) History() []AuditLogStoreWithTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]AuditLogStoreWithTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AuditLogStoreWithTransactFuncCall is an object that describes an
+// invocation of method WithTransact on an instance of MockAuditLogStore.
+type AuditLogStoreWithTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 func(AuditLogStore) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AuditLogStoreWithTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AuditLogStoreWithTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockAuthzStore is a mock implementation of the AuthzStore interface (from
 // the package github.com/sourcegraph/sourcegraph/internal/database) used
 // for unit testing.
@@ -4887,6 +6407,9 @@ type MockDB struct {
 	// AccessTokensFunc is an instance of a mock function object controlling
 	// the behavior of the method AccessTokens.
 	AccessTokensFunc *DBAccessTokensFunc
+	// AuditLogsFunc is an instance of a mock function object controlling
+	// the behavior of the method AuditLogs.
+	AuditLogsFunc *DBAuditLogsFunc
 	// AuthzFunc is an instance of a mock function object controlling the
 	// behavior of the method Authz.
 	AuthzFunc *DBAuthzFunc
@@ -5048,6 +6571,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: func() (r0 AuditLogStore) {
+				return
+			},
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: func() (r0 AuthzStore) {
 				return
@@ -5305,6 +6833,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.AccessTokens")
 			},
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: func() AuditLogStore {
+				panic("unexpected invocation of MockDB.AuditLogs")
+			},
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: func() AuthzStore {
 				panic("unexpected invocation of MockDB.Authz")
@@ -5558,6 +7091,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		AccessTokensFunc: &DBAccessTokensFunc{
 			defaultHook: i.AccessTokens,
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: i.AuditLogs,
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: i.Authz,
 		},
@@ -5902,6 +7438,104 @@ func (c DBAccessTokensFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBAuditLogsFunc describes the behavior when the AuditLogs method of the
+// parent MockDB instance is invoked.
+type DBAuditLogsFunc struct {
+	defaultHook func() AuditLogStore
+	hooks       []func() AuditLogStore
+	history     []DBAuditLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// AuditLogs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockDB) AuditLogs() AuditLogStore {
+	r0 := m.AuditLogsFunc.nextHook()()
+	m.AuditLogsFunc.appendCall(DBAuditLogsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AuditLogs method of
+// the parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBAuditLogsFunc) SetDefaultHook(hook func() AuditLogStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AuditLogs method of the parent MockDB instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *DBAuditLogsFunc) PushHook(hook func() AuditLogStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBAuditLogsFunc) SetDefaultReturn(r0 AuditLogStore) {
+	f.SetDefaultHook(func() AuditLogStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBAuditLogsFunc) PushReturn(r0 AuditLogStore) {
+	f.PushHook(func() AuditLogStore {
+		return r0
+	})
+}
+
+func (f *DBAuditLogsFunc) nextHook() func() AuditLogStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBAuditLogsFunc) appendCall(r0 DBAuditLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBAuditLogsFuncCall objects describing the
+// invocations of this function.
+func (f *DBAuditLogsFunc) History() []DBAuditLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBAuditLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBAuditLogsFuncCall is an object that describes an invocation of method
+// AuditLogs on an instance of MockDB.
+type DBAuditLogsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 AuditLogStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBAuditLogsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBAuditLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBAuthzFunc describes the behavior when the Authz method of the parent
 // MockDB instance is invoked.
 type DBAuthzFunc struct {
@@ -25004,6 +26638,9 @@ type MockGitserverRepoStore struct {
 	// SetCloningProgressFunc is an instance of a mock function object
 	// controlling the behavior of the method SetCloningProgress.
 	SetCloningProgressFunc *GitserverRepoStoreSetCloningProgressFunc
+	// SetLFSSizeFunc is an instance of a mock function object controlling
+	// the behavior of the method SetLFSSize.
+	SetLFSSizeFunc *GitserverRepoStoreSetLFSSizeFunc
 	// SetLastErrorFunc is an instance of a mock function object controlling
 	// the behavior of the method SetLastError.
 	SetLastErrorFunc *GitserverRepoStoreSetLastErrorFunc
@@ -25088,6 +26725,11 @@ func NewMockGitserverRepoStore() *MockGitserverRepoStore {
 				return
 			},
 		},
+		SetLFSSizeFunc: &GitserverRepoStoreSetLFSSizeFunc{
+			defaultHook: func(context.Context, api.RepoName, int64) (r0 error) {
+				return
+			},
+		},
 		SetLastErrorFunc: &GitserverRepoStoreSetLastErrorFunc{
 			defaultHook: func(context.Context, api.RepoName, string, string) (r0 error) {
 				return
@@ -25186,6 +26828,11 @@ func NewStrictMockGitserverRepoStore() *MockGitserverRepoStore {
 				panic("unexpected invocation of MockGitserverRepoStore.SetCloningProgress")
 			},
 		},
+		SetLFSSizeFunc: &GitserverRepoStoreSetLFSSizeFunc{
+			defaultHook: func(context.Context, api.RepoName, int64) error {
+				panic("unexpected invocation of MockGitserverRepoStore.SetLFSSize")
+			},
+		},
 		SetLastErrorFunc: &GitserverRepoStoreSetLastErrorFunc{
 			defaultHook: func(context.Context, api.RepoName, string, string) error {
 				panic("unexpected invocation of MockGitserverRepoStore.SetLastError")
@@ -25262,6 +26909,9 @@ func NewMockGitserverRepoStoreFrom(i GitserverRepoStore) *MockGitserverRepoStore
 		SetCloningProgressFunc: &GitserverRepoStoreSetCloningProgressFunc{
 			defaultHook: i.SetCloningProgress,
 		},
+		SetLFSSizeFunc: &GitserverRepoStoreSetLFSSizeFunc{
+			defaultHook: i.SetLFSSize,
+		},
 		SetLastErrorFunc: &GitserverRepoStoreSetLastErrorFunc{
 			defaultHook: i.SetLastError,
 		},
@@ -26502,6 +28152,115 @@ func (c GitserverRepoStoreSetCloningProgressFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GitserverRepoStoreSetLFSSizeFunc describes the behavior when the
+// SetLFSSize method of the parent MockGitserverRepoStore instance is
+// invoked.
+type GitserverRepoStoreSetLFSSizeFunc struct {
+	defaultHook func(context.Context, api.RepoName, int64) error
+	hooks       []func(context.Context, api.RepoName, int64) error
+	history     []GitserverRepoStoreSetLFSSizeFuncCall
+	mutex       sync.Mutex
+}
+
+// SetLFSSize delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) SetLFSSize(v0 context.Context, v1 api.RepoName, v2 int64) error {
+	r0 := m.SetLFSSizeFunc.nextHook()(v0, v1, v2)
+	m.SetLFSSizeFunc.appendCall(GitserverRepoStoreSetLFSSizeFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetLFSSize method of
+// the parent MockGitserverRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *GitserverRepoStoreSetLFSSizeFunc) SetDefaultHook(hook func(context.Context, api.RepoName, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetLFSSize method of the parent MockGitserverRepoStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverRepoStoreSetLFSSizeFunc) PushHook(hook func(context.Context, api.RepoName, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreSetLFSSizeFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreSetLFSSizeFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, int64) error {
+		return r0
+	})
+}
+
+func (f *GitserverRepoStoreSetLFSSizeFunc) nextHook() func(context.Context, api.RepoName, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreSetLFSSizeFunc) appendCall(r0 GitserverRepoStoreSetLFSSizeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRepoStoreSetLFSSizeFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRepoStoreSetLFSSizeFunc) History() []GitserverRepoStoreSetLFSSizeFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreSetLFSSizeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreSetLFSSizeFuncCall is an object that describes an
+// invocation of method SetLFSSize on an instance of MockGitserverRepoStore.
+type GitserverRepoStoreSetLFSSizeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreSetLFSSizeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreSetLFSSizeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GitserverRepoStoreSetLastErrorFunc describes the behavior when the
 // SetLastError method of the parent MockGitserverRepoStore instance is
 // invoked.
@@ -48475,17 +50234,33 @@ func (c SavedSearchStoreWithTransactFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
 // testing.
 type MockSearchContextsStore struct {
+	// CountSearchContextResolutionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CountSearchContextResolutions.
+	CountSearchContextResolutionsFunc *SearchContextsStoreCountSearchContextResolutionsFunc
 	// CountSearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountSearchContexts.
 	CountSearchContextsFunc *SearchContextsStoreCountSearchContextsFunc
+	// CreateSearchContextResolutionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CreateSearchContextResolution.
+	CreateSearchContextResolutionFunc *SearchContextsStoreCreateSearchContextResolutionFunc
 	// CreateSearchContextStarForUserFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// CreateSearchContextStarForUser.
 	CreateSearchContextStarForUserFunc *SearchContextsStoreCreateSearchContextStarForUserFunc
+	// CreateSearchContextSubscriptionForUserFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// CreateSearchContextSubscriptionForUser.
+	CreateSearchContextSubscriptionForUserFunc *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc
 	// CreateSearchContextWithRepositoryRevisionsFunc is an instance of a
 	// mock function object controlling the behavior of the method
 	// CreateSearchContextWithRepositoryRevisions.
 	CreateSearchContextWithRepositoryRevisionsFunc *SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc
+	// DeleteOldSearchContextResolutionsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// DeleteOldSearchContextResolutions.
+	DeleteOldSearchContextResolutionsFunc *SearchContextsStoreDeleteOldSearchContextResolutionsFunc
 	// DeleteSearchContextFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteSearchContext.
 	DeleteSearchContextFunc *SearchContextsStoreDeleteSearchContextFunc
@@ -48493,6 +50268,10 @@ type MockSearchContextsStore struct {
 	// object controlling the behavior of the method
 	// DeleteSearchContextStarForUser.
 	DeleteSearchContextStarForUserFunc *SearchContextsStoreDeleteSearchContextStarForUserFunc
+	// DeleteSearchContextSubscriptionForUserFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// DeleteSearchContextSubscriptionForUser.
+	DeleteSearchContextSubscriptionForUserFunc *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *SearchContextsStoreDoneFunc
@@ -48509,6 +50288,18 @@ type MockSearchContextsStore struct {
 	// function object controlling the behavior of the method
 	// GetDefaultSearchContextForCurrentUser.
 	GetDefaultSearchContextForCurrentUserFunc *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc
+	// GetLatestSearchContextResolutionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetLatestSearchContextResolution.
+	GetLatestSearchContextResolutionFunc *SearchContextsStoreGetLatestSearchContextResolutionFunc
+	// GetPinnedSearchContextResolutionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetPinnedSearchContextResolution.
+	GetPinnedSearchContextResolutionFunc *SearchContextsStoreGetPinnedSearchContextResolutionFunc
+	// GetPreviousSearchContextResolutionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetPreviousSearchContextResolution.
+	GetPreviousSearchContextResolutionFunc *SearchContextsStoreGetPreviousSearchContextResolutionFunc
 	// GetSearchContextFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSearchContext.
 	GetSearchContextFunc *SearchContextsStoreGetSearchContextFunc
@@ -48516,12 +50307,32 @@ type MockSearchContextsStore struct {
 	// function object controlling the behavior of the method
 	// GetSearchContextRepositoryRevisions.
 	GetSearchContextRepositoryRevisionsFunc *SearchContextsStoreGetSearchContextRepositoryRevisionsFunc
+	// GetSearchContextResolutionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetSearchContextResolution.
+	GetSearchContextResolutionFunc *SearchContextsStoreGetSearchContextResolutionFunc
+	// GetSearchContextSubscriberIDsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetSearchContextSubscriberIDs.
+	GetSearchContextSubscriberIDsFunc *SearchContextsStoreGetSearchContextSubscriberIDsFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *SearchContextsStoreHandleFunc
+	// IsSearchContextSubscriberFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// IsSearchContextSubscriber.
+	IsSearchContextSubscriberFunc *SearchContextsStoreIsSearchContextSubscriberFunc
+	// ListSearchContextResolutionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListSearchContextResolutions.
+	ListSearchContextResolutionsFunc *SearchContextsStoreListSearchContextResolutionsFunc
 	// ListSearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSearchContexts.
 	ListSearchContextsFunc *SearchContextsStoreListSearchContextsFunc
+	// SetPinnedSearchContextResolutionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// SetPinnedSearchContextResolution.
+	SetPinnedSearchContextResolutionFunc *SearchContextsStoreSetPinnedSearchContextResolutionFunc
 	// SetSearchContextRepositoryRevisionsFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// SetSearchContextRepositoryRevisions.
@@ -48544,21 +50355,41 @@ type MockSearchContextsStore struct {
 // overwritten.
 func NewMockSearchContextsStore() *MockSearchContextsStore {
 	return &MockSearchContextsStore{
+		CountSearchContextResolutionsFunc: &SearchContextsStoreCountSearchContextResolutionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int32, r1 error) {
+				return
+			},
+		},
 		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
 			defaultHook: func(context.Context, ListSearchContextsOptions) (r0 int32, r1 error) {
 				return
 			},
 		},
+		CreateSearchContextResolutionFunc: &SearchContextsStoreCreateSearchContextResolutionFunc{
+			defaultHook: func(context.Context, *types.SearchContextResolution) (r0 *types.SearchContextResolution, r1 error) {
+				return
+			},
+		},
 		CreateSearchContextStarForUserFunc: &SearchContextsStoreCreateSearchContextStarForUserFunc{
 			defaultHook: func(context.Context, int32, int64) (r0 error) {
 				return
 			},
 		},
+		CreateSearchContextSubscriptionForUserFunc: &SearchContextsStoreCreateSearchContextSubscriptionForUserFunc{
+			defaultHook: func(context.Context, int32, int64) (r0 error) {
+				return
+			},
+		},
 		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (r0 *types.SearchContext, r1 error) {
 				return
 			},
 		},
+		DeleteOldSearchContextResolutionsFunc: &SearchContextsStoreDeleteOldSearchContextResolutionsFunc{
+			defaultHook: func(context.Context, int64, int) (r0 error) {
+				return
+			},
+		},
 		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -48569,6 +50400,11 @@ func NewMockSearchContextsStore() *MockSearchContextsStore {
 				return
 			},
 		},
+		DeleteSearchContextSubscriptionForUserFunc: &SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc{
+			defaultHook: func(context.Context, int32, int64) (r0 error) {
+				return
+			},
+		},
 		DoneFunc: &SearchContextsStoreDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
@@ -48594,6 +50430,21 @@ func NewMockSearchContextsStore() *MockSearchContextsStore {
 				return
 			},
 		},
+		GetLatestSearchContextResolutionFunc: &SearchContextsStoreGetLatestSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64) (r0 *types.SearchContextResolution, r1 error) {
+				return
+			},
+		},
+		GetPinnedSearchContextResolutionFunc: &SearchContextsStoreGetPinnedSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64) (r0 *types.SearchContextResolution, r1 error) {
+				return
+			},
+		},
+		GetPreviousSearchContextResolutionFunc: &SearchContextsStoreGetPreviousSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 *types.SearchContextResolution, r1 error) {
+				return
+			},
+		},
 		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
 			defaultHook: func(context.Context, GetSearchContextOptions) (r0 *types.SearchContext, r1 error) {
 				return
@@ -48604,16 +50455,41 @@ func NewMockSearchContextsStore() *MockSearchContextsStore {
 				return
 			},
 		},
+		GetSearchContextResolutionFunc: &SearchContextsStoreGetSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 *types.SearchContextResolution, r1 error) {
+				return
+			},
+		},
+		GetSearchContextSubscriberIDsFunc: &SearchContextsStoreGetSearchContextSubscriberIDsFunc{
+			defaultHook: func(context.Context, int64) (r0 []int32, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &SearchContextsStoreHandleFunc{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
 			},
 		},
+		IsSearchContextSubscriberFunc: &SearchContextsStoreIsSearchContextSubscriberFunc{
+			defaultHook: func(context.Context, int32, int64) (r0 bool, r1 error) {
+				return
+			},
+		},
+		ListSearchContextResolutionsFunc: &SearchContextsStoreListSearchContextResolutionsFunc{
+			defaultHook: func(context.Context, int64, ListSearchContextsPageOptions) (r0 []*types.SearchContextResolution, r1 error) {
+				return
+			},
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) (r0 []*types.SearchContext, r1 error) {
 				return
 			},
 		},
+		SetPinnedSearchContextResolutionFunc: &SearchContextsStoreSetPinnedSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) (r0 error) {
 				return
@@ -48642,21 +50518,41 @@ func NewMockSearchContextsStore() *MockSearchContextsStore {
 // overwritten.
 func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 	return &MockSearchContextsStore{
+		CountSearchContextResolutionsFunc: &SearchContextsStoreCountSearchContextResolutionsFunc{
+			defaultHook: func(context.Context, int64) (int32, error) {
+				panic("unexpected invocation of MockSearchContextsStore.CountSearchContextResolutions")
+			},
+		},
 		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
 			defaultHook: func(context.Context, ListSearchContextsOptions) (int32, error) {
 				panic("unexpected invocation of MockSearchContextsStore.CountSearchContexts")
 			},
 		},
+		CreateSearchContextResolutionFunc: &SearchContextsStoreCreateSearchContextResolutionFunc{
+			defaultHook: func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error) {
+				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextResolution")
+			},
+		},
 		CreateSearchContextStarForUserFunc: &SearchContextsStoreCreateSearchContextStarForUserFunc{
 			defaultHook: func(context.Context, int32, int64) error {
 				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextStarForUser")
 			},
 		},
+		CreateSearchContextSubscriptionForUserFunc: &SearchContextsStoreCreateSearchContextSubscriptionForUserFunc{
+			defaultHook: func(context.Context, int32, int64) error {
+				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextSubscriptionForUser")
+			},
+		},
 		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error) {
 				panic("unexpected invocation of MockSearchContextsStore.CreateSearchContextWithRepositoryRevisions")
 			},
 		},
+		DeleteOldSearchContextResolutionsFunc: &SearchContextsStoreDeleteOldSearchContextResolutionsFunc{
+			defaultHook: func(context.Context, int64, int) error {
+				panic("unexpected invocation of MockSearchContextsStore.DeleteOldSearchContextResolutions")
+			},
+		},
 		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockSearchContextsStore.DeleteSearchContext")
@@ -48667,6 +50563,11 @@ func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 				panic("unexpected invocation of MockSearchContextsStore.DeleteSearchContextStarForUser")
 			},
 		},
+		DeleteSearchContextSubscriptionForUserFunc: &SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc{
+			defaultHook: func(context.Context, int32, int64) error {
+				panic("unexpected invocation of MockSearchContextsStore.DeleteSearchContextSubscriptionForUser")
+			},
+		},
 		DoneFunc: &SearchContextsStoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockSearchContextsStore.Done")
@@ -48692,6 +50593,21 @@ func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 				panic("unexpected invocation of MockSearchContextsStore.GetDefaultSearchContextForCurrentUser")
 			},
 		},
+		GetLatestSearchContextResolutionFunc: &SearchContextsStoreGetLatestSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64) (*types.SearchContextResolution, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetLatestSearchContextResolution")
+			},
+		},
+		GetPinnedSearchContextResolutionFunc: &SearchContextsStoreGetPinnedSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64) (*types.SearchContextResolution, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetPinnedSearchContextResolution")
+			},
+		},
+		GetPreviousSearchContextResolutionFunc: &SearchContextsStoreGetPreviousSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetPreviousSearchContextResolution")
+			},
+		},
 		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
 			defaultHook: func(context.Context, GetSearchContextOptions) (*types.SearchContext, error) {
 				panic("unexpected invocation of MockSearchContextsStore.GetSearchContext")
@@ -48702,16 +50618,41 @@ func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 				panic("unexpected invocation of MockSearchContextsStore.GetSearchContextRepositoryRevisions")
 			},
 		},
+		GetSearchContextResolutionFunc: &SearchContextsStoreGetSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetSearchContextResolution")
+			},
+		},
+		GetSearchContextSubscriberIDsFunc: &SearchContextsStoreGetSearchContextSubscriberIDsFunc{
+			defaultHook: func(context.Context, int64) ([]int32, error) {
+				panic("unexpected invocation of MockSearchContextsStore.GetSearchContextSubscriberIDs")
+			},
+		},
 		HandleFunc: &SearchContextsStoreHandleFunc{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockSearchContextsStore.Handle")
 			},
 		},
+		IsSearchContextSubscriberFunc: &SearchContextsStoreIsSearchContextSubscriberFunc{
+			defaultHook: func(context.Context, int32, int64) (bool, error) {
+				panic("unexpected invocation of MockSearchContextsStore.IsSearchContextSubscriber")
+			},
+		},
+		ListSearchContextResolutionsFunc: &SearchContextsStoreListSearchContextResolutionsFunc{
+			defaultHook: func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error) {
+				panic("unexpected invocation of MockSearchContextsStore.ListSearchContextResolutions")
+			},
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 				panic("unexpected invocation of MockSearchContextsStore.ListSearchContexts")
 			},
 		},
+		SetPinnedSearchContextResolutionFunc: &SearchContextsStoreSetPinnedSearchContextResolutionFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockSearchContextsStore.SetPinnedSearchContextResolution")
+			},
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
 				panic("unexpected invocation of MockSearchContextsStore.SetSearchContextRepositoryRevisions")
@@ -48740,21 +50681,36 @@ func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 // implementation, unless overwritten.
 func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsStore {
 	return &MockSearchContextsStore{
+		CountSearchContextResolutionsFunc: &SearchContextsStoreCountSearchContextResolutionsFunc{
+			defaultHook: i.CountSearchContextResolutions,
+		},
 		CountSearchContextsFunc: &SearchContextsStoreCountSearchContextsFunc{
 			defaultHook: i.CountSearchContexts,
 		},
+		CreateSearchContextResolutionFunc: &SearchContextsStoreCreateSearchContextResolutionFunc{
+			defaultHook: i.CreateSearchContextResolution,
+		},
 		CreateSearchContextStarForUserFunc: &SearchContextsStoreCreateSearchContextStarForUserFunc{
 			defaultHook: i.CreateSearchContextStarForUser,
 		},
+		CreateSearchContextSubscriptionForUserFunc: &SearchContextsStoreCreateSearchContextSubscriptionForUserFunc{
+			defaultHook: i.CreateSearchContextSubscriptionForUser,
+		},
 		CreateSearchContextWithRepositoryRevisionsFunc: &SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFunc{
 			defaultHook: i.CreateSearchContextWithRepositoryRevisions,
 		},
+		DeleteOldSearchContextResolutionsFunc: &SearchContextsStoreDeleteOldSearchContextResolutionsFunc{
+			defaultHook: i.DeleteOldSearchContextResolutions,
+		},
 		DeleteSearchContextFunc: &SearchContextsStoreDeleteSearchContextFunc{
 			defaultHook: i.DeleteSearchContext,
 		},
 		DeleteSearchContextStarForUserFunc: &SearchContextsStoreDeleteSearchContextStarForUserFunc{
 			defaultHook: i.DeleteSearchContextStarForUser,
 		},
+		DeleteSearchContextSubscriptionForUserFunc: &SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc{
+			defaultHook: i.DeleteSearchContextSubscriptionForUser,
+		},
 		DoneFunc: &SearchContextsStoreDoneFunc{
 			defaultHook: i.Done,
 		},
@@ -48770,18 +50726,42 @@ func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsSt
 		GetDefaultSearchContextForCurrentUserFunc: &SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc{
 			defaultHook: i.GetDefaultSearchContextForCurrentUser,
 		},
+		GetLatestSearchContextResolutionFunc: &SearchContextsStoreGetLatestSearchContextResolutionFunc{
+			defaultHook: i.GetLatestSearchContextResolution,
+		},
+		GetPinnedSearchContextResolutionFunc: &SearchContextsStoreGetPinnedSearchContextResolutionFunc{
+			defaultHook: i.GetPinnedSearchContextResolution,
+		},
+		GetPreviousSearchContextResolutionFunc: &SearchContextsStoreGetPreviousSearchContextResolutionFunc{
+			defaultHook: i.GetPreviousSearchContextResolution,
+		},
 		GetSearchContextFunc: &SearchContextsStoreGetSearchContextFunc{
 			defaultHook: i.GetSearchContext,
 		},
 		GetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreGetSearchContextRepositoryRevisionsFunc{
 			defaultHook: i.GetSearchContextRepositoryRevisions,
 		},
+		GetSearchContextResolutionFunc: &SearchContextsStoreGetSearchContextResolutionFunc{
+			defaultHook: i.GetSearchContextResolution,
+		},
+		GetSearchContextSubscriberIDsFunc: &SearchContextsStoreGetSearchContextSubscriberIDsFunc{
+			defaultHook: i.GetSearchContextSubscriberIDs,
+		},
 		HandleFunc: &SearchContextsStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		IsSearchContextSubscriberFunc: &SearchContextsStoreIsSearchContextSubscriberFunc{
+			defaultHook: i.IsSearchContextSubscriber,
+		},
+		ListSearchContextResolutionsFunc: &SearchContextsStoreListSearchContextResolutionsFunc{
+			defaultHook: i.ListSearchContextResolutions,
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: i.ListSearchContexts,
 		},
+		SetPinnedSearchContextResolutionFunc: &SearchContextsStoreSetPinnedSearchContextResolutionFunc{
+			defaultHook: i.SetPinnedSearchContextResolution,
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: i.SetSearchContextRepositoryRevisions,
 		},
@@ -48797,6 +50777,118 @@ func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsSt
 	}
 }
 
+// SearchContextsStoreCountSearchContextResolutionsFunc describes the
+// behavior when the CountSearchContextResolutions method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreCountSearchContextResolutionsFunc struct {
+	defaultHook func(context.Context, int64) (int32, error)
+	hooks       []func(context.Context, int64) (int32, error)
+	history     []SearchContextsStoreCountSearchContextResolutionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountSearchContextResolutions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) CountSearchContextResolutions(v0 context.Context, v1 int64) (int32, error) {
+	r0, r1 := m.CountSearchContextResolutionsFunc.nextHook()(v0, v1)
+	m.CountSearchContextResolutionsFunc.appendCall(SearchContextsStoreCountSearchContextResolutionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountSearchContextResolutions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreCountSearchContextResolutionsFunc) SetDefaultHook(hook func(context.Context, int64) (int32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountSearchContextResolutions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreCountSearchContextResolutionsFunc) PushHook(hook func(context.Context, int64) (int32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreCountSearchContextResolutionsFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreCountSearchContextResolutionsFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, int64) (int32, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreCountSearchContextResolutionsFunc) nextHook() func(context.Context, int64) (int32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreCountSearchContextResolutionsFunc) appendCall(r0 SearchContextsStoreCountSearchContextResolutionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreCountSearchContextResolutionsFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreCountSearchContextResolutionsFunc) History() []SearchContextsStoreCountSearchContextResolutionsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreCountSearchContextResolutionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreCountSearchContextResolutionsFuncCall is an object
+// that describes an invocation of method CountSearchContextResolutions on
+// an instance of MockSearchContextsStore.
+type SearchContextsStoreCountSearchContextResolutionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreCountSearchContextResolutionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreCountSearchContextResolutionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SearchContextsStoreCountSearchContextsFunc describes the behavior when
 // the CountSearchContexts method of the parent MockSearchContextsStore
 // instance is invoked.
@@ -48909,37 +51001,262 @@ func (c SearchContextsStoreCountSearchContextsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreCreateSearchContextStarForUserFunc describes the
-// behavior when the CreateSearchContextStarForUser method of the parent
-// MockSearchContextsStore instance is invoked.
-type SearchContextsStoreCreateSearchContextStarForUserFunc struct {
+// SearchContextsStoreCreateSearchContextResolutionFunc describes the
+// behavior when the CreateSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreCreateSearchContextResolutionFunc struct {
+	defaultHook func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error)
+	hooks       []func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error)
+	history     []SearchContextsStoreCreateSearchContextResolutionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateSearchContextResolution delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) CreateSearchContextResolution(v0 context.Context, v1 *types.SearchContextResolution) (*types.SearchContextResolution, error) {
+	r0, r1 := m.CreateSearchContextResolutionFunc.nextHook()(v0, v1)
+	m.CreateSearchContextResolutionFunc.appendCall(SearchContextsStoreCreateSearchContextResolutionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreCreateSearchContextResolutionFunc) SetDefaultHook(hook func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateSearchContextResolution method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreCreateSearchContextResolutionFunc) PushHook(hook func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreCreateSearchContextResolutionFunc) SetDefaultReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreCreateSearchContextResolutionFunc) PushReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.PushHook(func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreCreateSearchContextResolutionFunc) nextHook() func(context.Context, *types.SearchContextResolution) (*types.SearchContextResolution, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreCreateSearchContextResolutionFunc) appendCall(r0 SearchContextsStoreCreateSearchContextResolutionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreCreateSearchContextResolutionFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreCreateSearchContextResolutionFunc) History() []SearchContextsStoreCreateSearchContextResolutionFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreCreateSearchContextResolutionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreCreateSearchContextResolutionFuncCall is an object
+// that describes an invocation of method CreateSearchContextResolution on
+// an instance of MockSearchContextsStore.
+type SearchContextsStoreCreateSearchContextResolutionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.SearchContextResolution
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContextResolution
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextResolutionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextResolutionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreCreateSearchContextStarForUserFunc describes the
+// behavior when the CreateSearchContextStarForUser method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreCreateSearchContextStarForUserFunc struct {
+	defaultHook func(context.Context, int32, int64) error
+	hooks       []func(context.Context, int32, int64) error
+	history     []SearchContextsStoreCreateSearchContextStarForUserFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateSearchContextStarForUser delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) CreateSearchContextStarForUser(v0 context.Context, v1 int32, v2 int64) error {
+	r0 := m.CreateSearchContextStarForUserFunc.nextHook()(v0, v1, v2)
+	m.CreateSearchContextStarForUserFunc.appendCall(SearchContextsStoreCreateSearchContextStarForUserFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateSearchContextStarForUser method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) SetDefaultHook(hook func(context.Context, int32, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateSearchContextStarForUser method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) PushHook(hook func(context.Context, int32, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, int64) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) nextHook() func(context.Context, int32, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) appendCall(r0 SearchContextsStoreCreateSearchContextStarForUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreCreateSearchContextStarForUserFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) History() []SearchContextsStoreCreateSearchContextStarForUserFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreCreateSearchContextStarForUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreCreateSearchContextStarForUserFuncCall is an object
+// that describes an invocation of method CreateSearchContextStarForUser on
+// an instance of MockSearchContextsStore.
+type SearchContextsStoreCreateSearchContextStarForUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextStarForUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreCreateSearchContextStarForUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SearchContextsStoreCreateSearchContextSubscriptionForUserFunc describes
+// the behavior when the CreateSearchContextSubscriptionForUser method of
+// the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreCreateSearchContextSubscriptionForUserFunc struct {
 	defaultHook func(context.Context, int32, int64) error
 	hooks       []func(context.Context, int32, int64) error
-	history     []SearchContextsStoreCreateSearchContextStarForUserFuncCall
+	history     []SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateSearchContextStarForUser delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) CreateSearchContextStarForUser(v0 context.Context, v1 int32, v2 int64) error {
-	r0 := m.CreateSearchContextStarForUserFunc.nextHook()(v0, v1, v2)
-	m.CreateSearchContextStarForUserFunc.appendCall(SearchContextsStoreCreateSearchContextStarForUserFuncCall{v0, v1, v2, r0})
+// CreateSearchContextSubscriptionForUser delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) CreateSearchContextSubscriptionForUser(v0 context.Context, v1 int32, v2 int64) error {
+	r0 := m.CreateSearchContextSubscriptionForUserFunc.nextHook()(v0, v1, v2)
+	m.CreateSearchContextSubscriptionForUserFunc.appendCall(SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// CreateSearchContextStarForUser method of the parent
+// CreateSearchContextSubscriptionForUser method of the parent
 // MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) SetDefaultHook(hook func(context.Context, int32, int64) error) {
+func (f *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc) SetDefaultHook(hook func(context.Context, int32, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateSearchContextStarForUser method of the parent
+// CreateSearchContextSubscriptionForUser method of the parent
 // MockSearchContextsStore instance invokes the hook at the front of the
 // queue and discards it. After the queue is empty, the default hook
 // function is invoked for any future action.
-func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) PushHook(hook func(context.Context, int32, int64) error) {
+func (f *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc) PushHook(hook func(context.Context, int32, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -48947,20 +51264,20 @@ func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) PushHook(hook fu
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) SetDefaultReturn(r0 error) {
+func (f *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc) SetDefaultReturn(r0 error) {
 	f.SetDefaultHook(func(context.Context, int32, int64) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) PushReturn(r0 error) {
+func (f *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc) PushReturn(r0 error) {
 	f.PushHook(func(context.Context, int32, int64) error {
 		return r0
 	})
 }
 
-func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) nextHook() func(context.Context, int32, int64) error {
+func (f *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc) nextHook() func(context.Context, int32, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -48973,28 +51290,29 @@ func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) nextHook() func(
 	return hook
 }
 
-func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) appendCall(r0 SearchContextsStoreCreateSearchContextStarForUserFuncCall) {
+func (f *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc) appendCall(r0 SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreCreateSearchContextStarForUserFuncCall objects
+// SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreCreateSearchContextStarForUserFunc) History() []SearchContextsStoreCreateSearchContextStarForUserFuncCall {
+func (f *SearchContextsStoreCreateSearchContextSubscriptionForUserFunc) History() []SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreCreateSearchContextStarForUserFuncCall, len(f.history))
+	history := make([]SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreCreateSearchContextStarForUserFuncCall is an object
-// that describes an invocation of method CreateSearchContextStarForUser on
-// an instance of MockSearchContextsStore.
-type SearchContextsStoreCreateSearchContextStarForUserFuncCall struct {
+// SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall is an
+// object that describes an invocation of method
+// CreateSearchContextSubscriptionForUser on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -49011,13 +51329,13 @@ type SearchContextsStoreCreateSearchContextStarForUserFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreCreateSearchContextStarForUserFuncCall) Args() []interface{} {
+func (c SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreCreateSearchContextStarForUserFuncCall) Results() []interface{} {
+func (c SearchContextsStoreCreateSearchContextSubscriptionForUserFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -49139,6 +51457,118 @@ func (c SearchContextsStoreCreateSearchContextWithRepositoryRevisionsFuncCall) R
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SearchContextsStoreDeleteOldSearchContextResolutionsFunc describes the
+// behavior when the DeleteOldSearchContextResolutions method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreDeleteOldSearchContextResolutionsFunc struct {
+	defaultHook func(context.Context, int64, int) error
+	hooks       []func(context.Context, int64, int) error
+	history     []SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteOldSearchContextResolutions delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) DeleteOldSearchContextResolutions(v0 context.Context, v1 int64, v2 int) error {
+	r0 := m.DeleteOldSearchContextResolutionsFunc.nextHook()(v0, v1, v2)
+	m.DeleteOldSearchContextResolutionsFunc.appendCall(SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteOldSearchContextResolutions method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreDeleteOldSearchContextResolutionsFunc) SetDefaultHook(hook func(context.Context, int64, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteOldSearchContextResolutions method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreDeleteOldSearchContextResolutionsFunc) PushHook(hook func(context.Context, int64, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreDeleteOldSearchContextResolutionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreDeleteOldSearchContextResolutionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreDeleteOldSearchContextResolutionsFunc) nextHook() func(context.Context, int64, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreDeleteOldSearchContextResolutionsFunc) appendCall(r0 SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreDeleteOldSearchContextResolutionsFunc) History() []SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall is an object
+// that describes an invocation of method DeleteOldSearchContextResolutions
+// on an instance of MockSearchContextsStore.
+type SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreDeleteOldSearchContextResolutionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // SearchContextsStoreDeleteSearchContextFunc describes the behavior when
 // the DeleteSearchContext method of the parent MockSearchContextsStore
 // instance is invoked.
@@ -49360,6 +51790,120 @@ func (c SearchContextsStoreDeleteSearchContextStarForUserFuncCall) Results() []i
 	return []interface{}{c.Result0}
 }
 
+// SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc describes
+// the behavior when the DeleteSearchContextSubscriptionForUser method of
+// the parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc struct {
+	defaultHook func(context.Context, int32, int64) error
+	hooks       []func(context.Context, int32, int64) error
+	history     []SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteSearchContextSubscriptionForUser delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) DeleteSearchContextSubscriptionForUser(v0 context.Context, v1 int32, v2 int64) error {
+	r0 := m.DeleteSearchContextSubscriptionForUserFunc.nextHook()(v0, v1, v2)
+	m.DeleteSearchContextSubscriptionForUserFunc.appendCall(SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteSearchContextSubscriptionForUser method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc) SetDefaultHook(hook func(context.Context, int32, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteSearchContextSubscriptionForUser method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc) PushHook(hook func(context.Context, int32, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, int64) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc) nextHook() func(context.Context, int32, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc) appendCall(r0 SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreDeleteSearchContextSubscriptionForUserFunc) History() []SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall is an
+// object that describes an invocation of method
+// DeleteSearchContextSubscriptionForUser on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreDeleteSearchContextSubscriptionForUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // SearchContextsStoreDoneFunc describes the behavior when the Done method
 // of the parent MockSearchContextsStore instance is invoked.
 type SearchContextsStoreDoneFunc struct {
@@ -49665,47 +52209,382 @@ type SearchContextsStoreGetAllQueriesFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetAllQueriesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SearchContextsStoreGetAllQueriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetAllQueriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreGetAllRevisionsForReposFunc describes the behavior
+// when the GetAllRevisionsForRepos method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetAllRevisionsForReposFunc struct {
+	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+	history     []SearchContextsStoreGetAllRevisionsForReposFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAllRevisionsForRepos delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetAllRevisionsForRepos(v0 context.Context, v1 []api.RepoID) (map[api.RepoID][]string, error) {
+	r0, r1 := m.GetAllRevisionsForReposFunc.nextHook()(v0, v1)
+	m.GetAllRevisionsForReposFunc.appendCall(SearchContextsStoreGetAllRevisionsForReposFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) appendCall(r0 SearchContextsStoreGetAllRevisionsForReposFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreGetAllRevisionsForReposFuncCall objects describing the
+// invocations of this function.
+func (f *SearchContextsStoreGetAllRevisionsForReposFunc) History() []SearchContextsStoreGetAllRevisionsForReposFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetAllRevisionsForReposFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetAllRevisionsForReposFuncCall is an object that
+// describes an invocation of method GetAllRevisionsForRepos on an instance
+// of MockSearchContextsStore.
+type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID][]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc describes
+// the behavior when the GetDefaultSearchContextForCurrentUser method of the
+// parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc struct {
+	defaultHook func(context.Context) (*types.SearchContext, error)
+	hooks       []func(context.Context) (*types.SearchContext, error)
+	history     []SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDefaultSearchContextForCurrentUser delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSearchContextsStore) GetDefaultSearchContextForCurrentUser(v0 context.Context) (*types.SearchContext, error) {
+	r0, r1 := m.GetDefaultSearchContextForCurrentUserFunc.nextHook()(v0)
+	m.GetDefaultSearchContextForCurrentUserFunc.appendCall(SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetDefaultSearchContextForCurrentUser method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) SetDefaultHook(hook func(context.Context) (*types.SearchContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDefaultSearchContextForCurrentUser method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) PushHook(hook func(context.Context) (*types.SearchContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context) (*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) PushReturn(r0 *types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context) (*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) nextHook() func(context.Context) (*types.SearchContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) appendCall(r0 SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) History() []SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall is an
+// object that describes an invocation of method
+// GetDefaultSearchContextForCurrentUser on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreGetLatestSearchContextResolutionFunc describes the
+// behavior when the GetLatestSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetLatestSearchContextResolutionFunc struct {
+	defaultHook func(context.Context, int64) (*types.SearchContextResolution, error)
+	hooks       []func(context.Context, int64) (*types.SearchContextResolution, error)
+	history     []SearchContextsStoreGetLatestSearchContextResolutionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetLatestSearchContextResolution delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetLatestSearchContextResolution(v0 context.Context, v1 int64) (*types.SearchContextResolution, error) {
+	r0, r1 := m.GetLatestSearchContextResolutionFunc.nextHook()(v0, v1)
+	m.GetLatestSearchContextResolutionFunc.appendCall(SearchContextsStoreGetLatestSearchContextResolutionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetLatestSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetLatestSearchContextResolutionFunc) SetDefaultHook(hook func(context.Context, int64) (*types.SearchContextResolution, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLatestSearchContextResolution method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreGetLatestSearchContextResolutionFunc) PushHook(hook func(context.Context, int64) (*types.SearchContextResolution, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetLatestSearchContextResolutionFunc) SetDefaultReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetLatestSearchContextResolutionFunc) PushReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.PushHook(func(context.Context, int64) (*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetLatestSearchContextResolutionFunc) nextHook() func(context.Context, int64) (*types.SearchContextResolution, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreGetLatestSearchContextResolutionFunc) appendCall(r0 SearchContextsStoreGetLatestSearchContextResolutionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreGetLatestSearchContextResolutionFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreGetLatestSearchContextResolutionFunc) History() []SearchContextsStoreGetLatestSearchContextResolutionFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetLatestSearchContextResolutionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetLatestSearchContextResolutionFuncCall is an object
+// that describes an invocation of method GetLatestSearchContextResolution
+// on an instance of MockSearchContextsStore.
+type SearchContextsStoreGetLatestSearchContextResolutionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContextResolution
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetLatestSearchContextResolutionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetAllQueriesFuncCall) Results() []interface{} {
+func (c SearchContextsStoreGetLatestSearchContextResolutionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetAllRevisionsForReposFunc describes the behavior
-// when the GetAllRevisionsForRepos method of the parent
+// SearchContextsStoreGetPinnedSearchContextResolutionFunc describes the
+// behavior when the GetPinnedSearchContextResolution method of the parent
 // MockSearchContextsStore instance is invoked.
-type SearchContextsStoreGetAllRevisionsForReposFunc struct {
-	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
-	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
-	history     []SearchContextsStoreGetAllRevisionsForReposFuncCall
+type SearchContextsStoreGetPinnedSearchContextResolutionFunc struct {
+	defaultHook func(context.Context, int64) (*types.SearchContextResolution, error)
+	hooks       []func(context.Context, int64) (*types.SearchContextResolution, error)
+	history     []SearchContextsStoreGetPinnedSearchContextResolutionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetAllRevisionsForRepos delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockSearchContextsStore) GetAllRevisionsForRepos(v0 context.Context, v1 []api.RepoID) (map[api.RepoID][]string, error) {
-	r0, r1 := m.GetAllRevisionsForReposFunc.nextHook()(v0, v1)
-	m.GetAllRevisionsForReposFunc.appendCall(SearchContextsStoreGetAllRevisionsForReposFuncCall{v0, v1, r0, r1})
+// GetPinnedSearchContextResolution delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetPinnedSearchContextResolution(v0 context.Context, v1 int64) (*types.SearchContextResolution, error) {
+	r0, r1 := m.GetPinnedSearchContextResolutionFunc.nextHook()(v0, v1)
+	m.GetPinnedSearchContextResolutionFunc.appendCall(SearchContextsStoreGetPinnedSearchContextResolutionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
-// instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+// GetPinnedSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetPinnedSearchContextResolutionFunc) SetDefaultHook(hook func(context.Context, int64) (*types.SearchContextResolution, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetAllRevisionsForRepos method of the parent MockSearchContextsStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)) {
+// GetPinnedSearchContextResolution method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreGetPinnedSearchContextResolutionFunc) PushHook(hook func(context.Context, int64) (*types.SearchContextResolution, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -49713,20 +52592,20 @@ func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushHook(hook func(cont
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
-	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SearchContextsStoreGetPinnedSearchContextResolutionFunc) SetDefaultReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*types.SearchContextResolution, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
-	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SearchContextsStoreGetPinnedSearchContextResolutionFunc) PushReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.PushHook(func(context.Context, int64) (*types.SearchContextResolution, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID][]string, error) {
+func (f *SearchContextsStoreGetPinnedSearchContextResolutionFunc) nextHook() func(context.Context, int64) (*types.SearchContextResolution, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -49739,37 +52618,37 @@ func (f *SearchContextsStoreGetAllRevisionsForReposFunc) nextHook() func(context
 	return hook
 }
 
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) appendCall(r0 SearchContextsStoreGetAllRevisionsForReposFuncCall) {
+func (f *SearchContextsStoreGetPinnedSearchContextResolutionFunc) appendCall(r0 SearchContextsStoreGetPinnedSearchContextResolutionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreGetAllRevisionsForReposFuncCall objects describing the
-// invocations of this function.
-func (f *SearchContextsStoreGetAllRevisionsForReposFunc) History() []SearchContextsStoreGetAllRevisionsForReposFuncCall {
+// SearchContextsStoreGetPinnedSearchContextResolutionFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreGetPinnedSearchContextResolutionFunc) History() []SearchContextsStoreGetPinnedSearchContextResolutionFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetAllRevisionsForReposFuncCall, len(f.history))
+	history := make([]SearchContextsStoreGetPinnedSearchContextResolutionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetAllRevisionsForReposFuncCall is an object that
-// describes an invocation of method GetAllRevisionsForRepos on an instance
-// of MockSearchContextsStore.
-type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
+// SearchContextsStoreGetPinnedSearchContextResolutionFuncCall is an object
+// that describes an invocation of method GetPinnedSearchContextResolution
+// on an instance of MockSearchContextsStore.
+type SearchContextsStoreGetPinnedSearchContextResolutionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 []api.RepoID
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[api.RepoID][]string
+	Result0 *types.SearchContextResolution
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -49777,48 +52656,47 @@ type SearchContextsStoreGetAllRevisionsForReposFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Args() []interface{} {
+func (c SearchContextsStoreGetPinnedSearchContextResolutionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetAllRevisionsForReposFuncCall) Results() []interface{} {
+func (c SearchContextsStoreGetPinnedSearchContextResolutionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc describes
-// the behavior when the GetDefaultSearchContextForCurrentUser method of the
-// parent MockSearchContextsStore instance is invoked.
-type SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc struct {
-	defaultHook func(context.Context) (*types.SearchContext, error)
-	hooks       []func(context.Context) (*types.SearchContext, error)
-	history     []SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall
+// SearchContextsStoreGetPreviousSearchContextResolutionFunc describes the
+// behavior when the GetPreviousSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetPreviousSearchContextResolutionFunc struct {
+	defaultHook func(context.Context, int64, int64) (*types.SearchContextResolution, error)
+	hooks       []func(context.Context, int64, int64) (*types.SearchContextResolution, error)
+	history     []SearchContextsStoreGetPreviousSearchContextResolutionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetDefaultSearchContextForCurrentUser delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockSearchContextsStore) GetDefaultSearchContextForCurrentUser(v0 context.Context) (*types.SearchContext, error) {
-	r0, r1 := m.GetDefaultSearchContextForCurrentUserFunc.nextHook()(v0)
-	m.GetDefaultSearchContextForCurrentUserFunc.appendCall(SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall{v0, r0, r1})
+// GetPreviousSearchContextResolution delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetPreviousSearchContextResolution(v0 context.Context, v1 int64, v2 int64) (*types.SearchContextResolution, error) {
+	r0, r1 := m.GetPreviousSearchContextResolutionFunc.nextHook()(v0, v1, v2)
+	m.GetPreviousSearchContextResolutionFunc.appendCall(SearchContextsStoreGetPreviousSearchContextResolutionFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// GetDefaultSearchContextForCurrentUser method of the parent
+// GetPreviousSearchContextResolution method of the parent
 // MockSearchContextsStore instance is invoked and the hook queue is empty.
-func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) SetDefaultHook(hook func(context.Context) (*types.SearchContext, error)) {
+func (f *SearchContextsStoreGetPreviousSearchContextResolutionFunc) SetDefaultHook(hook func(context.Context, int64, int64) (*types.SearchContextResolution, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetDefaultSearchContextForCurrentUser method of the parent
+// GetPreviousSearchContextResolution method of the parent
 // MockSearchContextsStore instance invokes the hook at the front of the
 // queue and discards it. After the queue is empty, the default hook
 // function is invoked for any future action.
-func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) PushHook(hook func(context.Context) (*types.SearchContext, error)) {
+func (f *SearchContextsStoreGetPreviousSearchContextResolutionFunc) PushHook(hook func(context.Context, int64, int64) (*types.SearchContextResolution, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -49826,20 +52704,20 @@ func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) PushHook(
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) SetDefaultReturn(r0 *types.SearchContext, r1 error) {
-	f.SetDefaultHook(func(context.Context) (*types.SearchContext, error) {
+func (f *SearchContextsStoreGetPreviousSearchContextResolutionFunc) SetDefaultReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) PushReturn(r0 *types.SearchContext, r1 error) {
-	f.PushHook(func(context.Context) (*types.SearchContext, error) {
+func (f *SearchContextsStoreGetPreviousSearchContextResolutionFunc) PushReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
 		return r0, r1
 	})
 }
 
-func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) nextHook() func(context.Context) (*types.SearchContext, error) {
+func (f *SearchContextsStoreGetPreviousSearchContextResolutionFunc) nextHook() func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -49852,35 +52730,41 @@ func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) nextHook(
 	return hook
 }
 
-func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) appendCall(r0 SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall) {
+func (f *SearchContextsStoreGetPreviousSearchContextResolutionFunc) appendCall(r0 SearchContextsStoreGetPreviousSearchContextResolutionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall objects
+// SearchContextsStoreGetPreviousSearchContextResolutionFuncCall objects
 // describing the invocations of this function.
-func (f *SearchContextsStoreGetDefaultSearchContextForCurrentUserFunc) History() []SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall {
+func (f *SearchContextsStoreGetPreviousSearchContextResolutionFunc) History() []SearchContextsStoreGetPreviousSearchContextResolutionFuncCall {
 	f.mutex.Lock()
-	history := make([]SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall, len(f.history))
+	history := make([]SearchContextsStoreGetPreviousSearchContextResolutionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall is an
+// SearchContextsStoreGetPreviousSearchContextResolutionFuncCall is an
 // object that describes an invocation of method
-// GetDefaultSearchContextForCurrentUser on an instance of
+// GetPreviousSearchContextResolution on an instance of
 // MockSearchContextsStore.
-type SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall struct {
+type SearchContextsStoreGetPreviousSearchContextResolutionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *types.SearchContext
+	Result0 *types.SearchContextResolution
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -49888,13 +52772,13 @@ type SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c SearchContextsStoreGetPreviousSearchContextResolutionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c SearchContextsStoreGetDefaultSearchContextForCurrentUserFuncCall) Results() []interface{} {
+func (c SearchContextsStoreGetPreviousSearchContextResolutionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -50123,6 +53007,233 @@ func (c SearchContextsStoreGetSearchContextRepositoryRevisionsFuncCall) Results(
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SearchContextsStoreGetSearchContextResolutionFunc describes the behavior
+// when the GetSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetSearchContextResolutionFunc struct {
+	defaultHook func(context.Context, int64, int64) (*types.SearchContextResolution, error)
+	hooks       []func(context.Context, int64, int64) (*types.SearchContextResolution, error)
+	history     []SearchContextsStoreGetSearchContextResolutionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSearchContextResolution delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetSearchContextResolution(v0 context.Context, v1 int64, v2 int64) (*types.SearchContextResolution, error) {
+	r0, r1 := m.GetSearchContextResolutionFunc.nextHook()(v0, v1, v2)
+	m.GetSearchContextResolutionFunc.appendCall(SearchContextsStoreGetSearchContextResolutionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetSearchContextResolution method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetSearchContextResolutionFunc) SetDefaultHook(hook func(context.Context, int64, int64) (*types.SearchContextResolution, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSearchContextResolution method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreGetSearchContextResolutionFunc) PushHook(hook func(context.Context, int64, int64) (*types.SearchContextResolution, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetSearchContextResolutionFunc) SetDefaultReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetSearchContextResolutionFunc) PushReturn(r0 *types.SearchContextResolution, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetSearchContextResolutionFunc) nextHook() func(context.Context, int64, int64) (*types.SearchContextResolution, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreGetSearchContextResolutionFunc) appendCall(r0 SearchContextsStoreGetSearchContextResolutionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreGetSearchContextResolutionFuncCall objects describing
+// the invocations of this function.
+func (f *SearchContextsStoreGetSearchContextResolutionFunc) History() []SearchContextsStoreGetSearchContextResolutionFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetSearchContextResolutionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetSearchContextResolutionFuncCall is an object that
+// describes an invocation of method GetSearchContextResolution on an
+// instance of MockSearchContextsStore.
+type SearchContextsStoreGetSearchContextResolutionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.SearchContextResolution
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetSearchContextResolutionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetSearchContextResolutionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreGetSearchContextSubscriberIDsFunc describes the
+// behavior when the GetSearchContextSubscriberIDs method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreGetSearchContextSubscriberIDsFunc struct {
+	defaultHook func(context.Context, int64) ([]int32, error)
+	hooks       []func(context.Context, int64) ([]int32, error)
+	history     []SearchContextsStoreGetSearchContextSubscriberIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSearchContextSubscriberIDs delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) GetSearchContextSubscriberIDs(v0 context.Context, v1 int64) ([]int32, error) {
+	r0, r1 := m.GetSearchContextSubscriberIDsFunc.nextHook()(v0, v1)
+	m.GetSearchContextSubscriberIDsFunc.appendCall(SearchContextsStoreGetSearchContextSubscriberIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetSearchContextSubscriberIDs method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreGetSearchContextSubscriberIDsFunc) SetDefaultHook(hook func(context.Context, int64) ([]int32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSearchContextSubscriberIDs method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreGetSearchContextSubscriberIDsFunc) PushHook(hook func(context.Context, int64) ([]int32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreGetSearchContextSubscriberIDsFunc) SetDefaultReturn(r0 []int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]int32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreGetSearchContextSubscriberIDsFunc) PushReturn(r0 []int32, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]int32, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreGetSearchContextSubscriberIDsFunc) nextHook() func(context.Context, int64) ([]int32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreGetSearchContextSubscriberIDsFunc) appendCall(r0 SearchContextsStoreGetSearchContextSubscriberIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreGetSearchContextSubscriberIDsFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreGetSearchContextSubscriberIDsFunc) History() []SearchContextsStoreGetSearchContextSubscriberIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreGetSearchContextSubscriberIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreGetSearchContextSubscriberIDsFuncCall is an object
+// that describes an invocation of method GetSearchContextSubscriberIDs on
+// an instance of MockSearchContextsStore.
+type SearchContextsStoreGetSearchContextSubscriberIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreGetSearchContextSubscriberIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreGetSearchContextSubscriberIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SearchContextsStoreHandleFunc describes the behavior when the Handle
 // method of the parent MockSearchContextsStore instance is invoked.
 type SearchContextsStoreHandleFunc struct {
@@ -50222,6 +53333,236 @@ func (c SearchContextsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// SearchContextsStoreIsSearchContextSubscriberFunc describes the behavior
+// when the IsSearchContextSubscriber method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreIsSearchContextSubscriberFunc struct {
+	defaultHook func(context.Context, int32, int64) (bool, error)
+	hooks       []func(context.Context, int32, int64) (bool, error)
+	history     []SearchContextsStoreIsSearchContextSubscriberFuncCall
+	mutex       sync.Mutex
+}
+
+// IsSearchContextSubscriber delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) IsSearchContextSubscriber(v0 context.Context, v1 int32, v2 int64) (bool, error) {
+	r0, r1 := m.IsSearchContextSubscriberFunc.nextHook()(v0, v1, v2)
+	m.IsSearchContextSubscriberFunc.appendCall(SearchContextsStoreIsSearchContextSubscriberFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// IsSearchContextSubscriber method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreIsSearchContextSubscriberFunc) SetDefaultHook(hook func(context.Context, int32, int64) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsSearchContextSubscriber method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreIsSearchContextSubscriberFunc) PushHook(hook func(context.Context, int32, int64) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreIsSearchContextSubscriberFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreIsSearchContextSubscriberFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int32, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreIsSearchContextSubscriberFunc) nextHook() func(context.Context, int32, int64) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreIsSearchContextSubscriberFunc) appendCall(r0 SearchContextsStoreIsSearchContextSubscriberFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreIsSearchContextSubscriberFuncCall objects describing
+// the invocations of this function.
+func (f *SearchContextsStoreIsSearchContextSubscriberFunc) History() []SearchContextsStoreIsSearchContextSubscriberFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreIsSearchContextSubscriberFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreIsSearchContextSubscriberFuncCall is an object that
+// describes an invocation of method IsSearchContextSubscriber on an
+// instance of MockSearchContextsStore.
+type SearchContextsStoreIsSearchContextSubscriberFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreIsSearchContextSubscriberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreIsSearchContextSubscriberFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreListSearchContextResolutionsFunc describes the
+// behavior when the ListSearchContextResolutions method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreListSearchContextResolutionsFunc struct {
+	defaultHook func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error)
+	hooks       []func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error)
+	history     []SearchContextsStoreListSearchContextResolutionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSearchContextResolutions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) ListSearchContextResolutions(v0 context.Context, v1 int64, v2 ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error) {
+	r0, r1 := m.ListSearchContextResolutionsFunc.nextHook()(v0, v1, v2)
+	m.ListSearchContextResolutionsFunc.appendCall(SearchContextsStoreListSearchContextResolutionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListSearchContextResolutions method of the parent MockSearchContextsStore
+// instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreListSearchContextResolutionsFunc) SetDefaultHook(hook func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSearchContextResolutions method of the parent MockSearchContextsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SearchContextsStoreListSearchContextResolutionsFunc) PushHook(hook func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreListSearchContextResolutionsFunc) SetDefaultReturn(r0 []*types.SearchContextResolution, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreListSearchContextResolutionsFunc) PushReturn(r0 []*types.SearchContextResolution, r1 error) {
+	f.PushHook(func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreListSearchContextResolutionsFunc) nextHook() func(context.Context, int64, ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreListSearchContextResolutionsFunc) appendCall(r0 SearchContextsStoreListSearchContextResolutionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreListSearchContextResolutionsFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreListSearchContextResolutionsFunc) History() []SearchContextsStoreListSearchContextResolutionsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreListSearchContextResolutionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreListSearchContextResolutionsFuncCall is an object that
+// describes an invocation of method ListSearchContextResolutions on an
+// instance of MockSearchContextsStore.
+type SearchContextsStoreListSearchContextResolutionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 ListSearchContextsPageOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SearchContextResolution
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreListSearchContextResolutionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreListSearchContextResolutionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SearchContextsStoreListSearchContextsFunc describes the behavior when the
 // ListSearchContexts method of the parent MockSearchContextsStore instance
 // is invoked.
@@ -50337,6 +53678,118 @@ func (c SearchContextsStoreListSearchContextsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SearchContextsStoreSetPinnedSearchContextResolutionFunc describes the
+// behavior when the SetPinnedSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreSetPinnedSearchContextResolutionFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []SearchContextsStoreSetPinnedSearchContextResolutionFuncCall
+	mutex       sync.Mutex
+}
+
+// SetPinnedSearchContextResolution delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) SetPinnedSearchContextResolution(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.SetPinnedSearchContextResolutionFunc.nextHook()(v0, v1, v2)
+	m.SetPinnedSearchContextResolutionFunc.appendCall(SearchContextsStoreSetPinnedSearchContextResolutionFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetPinnedSearchContextResolution method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreSetPinnedSearchContextResolutionFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetPinnedSearchContextResolution method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreSetPinnedSearchContextResolutionFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreSetPinnedSearchContextResolutionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreSetPinnedSearchContextResolutionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreSetPinnedSearchContextResolutionFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreSetPinnedSearchContextResolutionFunc) appendCall(r0 SearchContextsStoreSetPinnedSearchContextResolutionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreSetPinnedSearchContextResolutionFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreSetPinnedSearchContextResolutionFunc) History() []SearchContextsStoreSetPinnedSearchContextResolutionFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreSetPinnedSearchContextResolutionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreSetPinnedSearchContextResolutionFuncCall is an object
+// that describes an invocation of method SetPinnedSearchContextResolution
+// on an instance of MockSearchContextsStore.
+type SearchContextsStoreSetPinnedSearchContextResolutionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreSetPinnedSearchContextResolutionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreSetPinnedSearchContextResolutionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // SearchContextsStoreSetSearchContextRepositoryRevisionsFunc describes the
 // behavior when the SetSearchContextRepositoryRevisions method of the
 // parent MockSearchContextsStore instance is invoked.
@@ -2,8 +2,10 @@ package database
 
 import (
 	"context"
+	"sort"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -19,6 +21,16 @@ type RepoKVPStore interface {
 	Create(context.Context, api.RepoID, KeyValuePair) error
 	Update(context.Context, api.RepoID, KeyValuePair) (KeyValuePair, error)
 	Delete(context.Context, api.RepoID, string) error
+	// SetSynced replaces the key-value pairs of the repo that were synced from
+	// the given external service. Key-value pairs that were set manually are
+	// left untouched and take precedence over synced ones with the same key.
+	SetSynced(ctx context.Context, repoID api.RepoID, externalServiceID int64, kvps map[string]*string) error
+}
+
+// RepoKVPsWith instantiates and returns a new RepoKVPStore using the other
+// store handle.
+func RepoKVPsWith(other basestore.ShareableStore) RepoKVPStore {
+	return &repoKVPStore{Store: basestore.NewWithHandle(other.Handle())}
 }
 
 type repoKVPStore struct {
@@ -81,9 +93,11 @@ func (s *repoKVPStore) List(ctx context.Context, repoID api.RepoID) ([]KeyValueP
 }
 
 func (s *repoKVPStore) Update(ctx context.Context, repoID api.RepoID, kvp KeyValuePair) (KeyValuePair, error) {
+	// Updating a synced key-value pair turns it into a manual one, so that the
+	// update isn't overwritten by the next sync.
 	q := `
 	UPDATE repo_kvps
-	SET value = %s
+	SET value = %s, external_service_id = NULL
 	WHERE repo_id = %s
 		AND key = %s
 	RETURNING key, value
@@ -104,3 +118,41 @@ func (s *repoKVPStore) Delete(ctx context.Context, repoID api.RepoID, key string
 
 	return s.Exec(ctx, sqlf.Sprintf(q, repoID, key))
 }
+
+func (s *repoKVPStore) SetSynced(ctx context.Context, repoID api.RepoID, externalServiceID int64, kvps map[string]*string) error {
+	keys := make([]string, 0, len(kvps))
+	for key := range kvps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		q := `
+		DELETE FROM repo_kvps
+		WHERE repo_id = %s
+			AND external_service_id = %s
+			AND NOT key = ANY(%s)
+		`
+		if err := tx.Exec(ctx, sqlf.Sprintf(q, repoID, externalServiceID, pq.Array(keys))); err != nil {
+			return err
+		}
+
+		if len(keys) == 0 {
+			return nil
+		}
+
+		values := make([]*sqlf.Query, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, sqlf.Sprintf("(%s, %s, %s, %s)", repoID, key, kvps[key], externalServiceID))
+		}
+
+		q = `
+		INSERT INTO repo_kvps (repo_id, key, value, external_service_id)
+		VALUES %s
+		ON CONFLICT (repo_id, key) DO UPDATE
+		SET value = EXCLUDED.value, external_service_id = EXCLUDED.external_service_id
+		WHERE repo_kvps.external_service_id IS NOT NULL
+		`
+		return tx.Exec(ctx, sqlf.Sprintf(q, sqlf.Join(values, ", ")))
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/stretchr/testify/require"
 )
@@ -136,4 +138,53 @@ func TestRepoKVPs(t *testing.T) {
 			require.NoError(t, err)
 		})
 	})
+
+	t.Run("SetSynced", func(t *testing.T) {
+		es := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GITHUB #1",
+			Config:      extsvc.NewUnencryptedConfig(`{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`),
+		}
+		err := db.ExternalServices().Create(ctx, func() *conf.Unified { return &conf.Unified{} }, es)
+		require.NoError(t, err)
+
+		err = kvps.Create(ctx, repo.ID, KeyValuePair{Key: "manual", Value: strPtr("manual")})
+		require.NoError(t, err)
+
+		err = kvps.SetSynced(ctx, repo.ID, es.ID, map[string]*string{
+			"topic:go": nil,
+			"language": strPtr("Go"),
+			"manual":   strPtr("synced"),
+		})
+		require.NoError(t, err)
+
+		// Manual key-value pairs take precedence.
+		kvp, err := kvps.Get(ctx, repo.ID, "manual")
+		require.NoError(t, err)
+		require.Equal(t, KeyValuePair{Key: "manual", Value: strPtr("manual")}, kvp)
+
+		// Synced key-value pairs that aren't synced anymore are removed.
+		err = kvps.SetSynced(ctx, repo.ID, es.ID, map[string]*string{
+			"language": strPtr("Rust"),
+		})
+		require.NoError(t, err)
+
+		_, err = kvps.Get(ctx, repo.ID, "topic:go")
+		require.Error(t, err)
+		kvp, err = kvps.Get(ctx, repo.ID, "language")
+		require.NoError(t, err)
+		require.Equal(t, KeyValuePair{Key: "language", Value: strPtr("Rust")}, kvp)
+
+		// Updated key-value pairs become manual.
+		_, err = kvps.Update(ctx, repo.ID, KeyValuePair{Key: "language", Value: strPtr("Go")})
+		require.NoError(t, err)
+		err = kvps.SetSynced(ctx, repo.ID, es.ID, nil)
+		require.NoError(t, err)
+
+		kvp, err = kvps.Get(ctx, repo.ID, "language")
+		require.NoError(t, err)
+		require.Equal(t, KeyValuePair{Key: "language", Value: strPtr("Go")}, kvp)
+		_, err = kvps.Get(ctx, repo.ID, "manual")
+		require.NoError(t, err)
+	})
 }
@@ -19,6 +19,9 @@ type RepoStatistics struct {
 	Cloned      int
 	FailedFetch int
 	Corrupted   int
+	// LFSSizeBytes is the total size of the Git LFS objects fetched by
+	// gitserver.
+	LFSSizeBytes int64
 }
 
 // gitserverRepoStatistics represents the contents of the
@@ -69,7 +72,7 @@ func (s *repoStatisticsStore) WithTransact(ctx context.Context, f func(RepoStati
 func (s *repoStatisticsStore) GetRepoStatistics(ctx context.Context) (RepoStatistics, error) {
 	var rs RepoStatistics
 	row := s.QueryRow(ctx, sqlf.Sprintf(getRepoStatisticsQueryFmtstr))
-	err := row.Scan(&rs.Total, &rs.SoftDeleted, &rs.NotCloned, &rs.Cloning, &rs.Cloned, &rs.FailedFetch, &rs.Corrupted)
+	err := row.Scan(&rs.Total, &rs.SoftDeleted, &rs.NotCloned, &rs.Cloning, &rs.Cloned, &rs.FailedFetch, &rs.Corrupted, &rs.LFSSizeBytes)
 	if err != nil {
 		return rs, err
 	}
@@ -84,7 +87,8 @@ SELECT
 	SUM(cloning),
 	SUM(cloned),
 	SUM(failed_fetch),
-	SUM(corrupted)
+	SUM(corrupted),
+	SUM(lfs_size_bytes)
 FROM repo_statistics
 `
 
@@ -102,9 +106,10 @@ WITH deleted AS (
 		cloning,
 		cloned,
 		failed_fetch,
-		corrupted
+		corrupted,
+		lfs_size_bytes
 )
-INSERT INTO repo_statistics (total, soft_deleted, not_cloned, cloning, cloned, failed_fetch, corrupted)
+INSERT INTO repo_statistics (total, soft_deleted, not_cloned, cloning, cloned, failed_fetch, corrupted, lfs_size_bytes)
 SELECT
 	SUM(total),
 	SUM(soft_deleted),
@@ -112,7 +117,8 @@ SELECT
 	SUM(cloning),
 	SUM(cloned),
 	SUM(failed_fetch),
-	SUM(corrupted)
+	SUM(corrupted),
+	SUM(lfs_size_bytes)
 FROM deleted;
 `
 
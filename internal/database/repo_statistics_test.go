@@ -408,6 +408,55 @@ func TestRepoStatistics_AvoidZeros(t *testing.T) {
 	}
 }
 
+func TestRepoStatistics_LFSSize(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	s := &repoStatisticsStore{Store: basestore.NewWithHandle(db.Handle())}
+
+	repos := types.Repos{
+		&types.Repo{Name: "repo1"},
+		&types.Repo{Name: "repo2"},
+	}
+
+	createTestRepos(ctx, t, db, repos)
+
+	setLFSSize(t, db, repos[0].Name, 100)
+	setLFSSize(t, db, repos[1].Name, 50)
+	assertRepoStatistics(t, ctx, s, RepoStatistics{
+		Total: 2, NotCloned: 2, LFSSizeBytes: 150,
+	}, []GitserverReposStatistic{
+		{ShardID: "", Total: 2, NotCloned: 2},
+	})
+
+	// Shrinking the LFS objects of a repo is reflected
+	setLFSSize(t, db, repos[0].Name, 20)
+	assertRepoStatistics(t, ctx, s, RepoStatistics{
+		Total: 2, NotCloned: 2, LFSSizeBytes: 70,
+	}, []GitserverReposStatistic{
+		{ShardID: "", Total: 2, NotCloned: 2},
+	})
+
+	// Deleting a repo removes its LFS objects from the statistics
+	if err := s.Exec(ctx, sqlf.Sprintf("DELETE FROM repo WHERE id = %s;", repos[1].ID)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CompactRepoStatistics(ctx); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := s.GetRepoStatistics(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.LFSSizeBytes != 20 {
+		t.Fatalf("wrong LFS size. have=%d, want=%d", stats.LFSSizeBytes, 20)
+	}
+}
+
 func TestRepoStatistics_Compaction(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -520,6 +569,13 @@ func setLastError(t *testing.T, db DB, repoName api.RepoName, shard string, msg
 	}
 }
 
+func setLFSSize(t *testing.T, db DB, repoName api.RepoName, size int64) {
+	t.Helper()
+	if err := db.GitserverRepos().SetLFSSize(context.Background(), repoName, size); err != nil {
+		t.Fatalf("failed to set LFS size for repo %s: %s", repoName, err)
+	}
+}
+
 func logCorruption(t *testing.T, db DB, repoName api.RepoName, shard string, msg string) {
 	t.Helper()
 	if err := db.GitserverRepos().LogCorruption(context.Background(), repoName, msg, shard); err != nil {
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var ErrSearchContextResolutionNotFound = errors.New("search context resolution not found")
+
+const searchContextResolutionColumns = `
+	id,
+	search_context_id,
+	repositories,
+	pinned,
+	created_at
+`
+
+func scanSearchContextResolution(sc dbutil.Scanner) (*types.SearchContextResolution, error) {
+	var (
+		r            types.SearchContextResolution
+		repositories []byte
+	)
+	if err := sc.Scan(&r.ID, &r.SearchContextID, &repositories, &r.Pinned, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(repositories, &r.Repositories); err != nil {
+		return nil, errors.Wrap(err, "unmarshal repositories")
+	}
+	return &r, nil
+}
+
+var scanSearchContextResolutions = basestore.NewSliceScanner(scanSearchContextResolution)
+
+func (s *searchContextsStore) getSearchContextResolution(ctx context.Context, cond *sqlf.Query) (*types.SearchContextResolution, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM search_context_resolutions WHERE %s ORDER BY id DESC LIMIT 1",
+		sqlf.Sprintf(searchContextResolutionColumns),
+		cond,
+	)
+	resolution, err := scanSearchContextResolution(s.QueryRow(ctx, q))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSearchContextResolutionNotFound
+	}
+	return resolution, err
+}
+
+// 🚨 SECURITY: The caller must ensure that the repositories were resolved by an actor that can see
+// all repositories, and must filter them by the permissions of the viewer when reading them.
+func (s *searchContextsStore) CreateSearchContextResolution(ctx context.Context, resolution *types.SearchContextResolution) (*types.SearchContextResolution, error) {
+	repositories := resolution.Repositories
+	if repositories == nil {
+		repositories = []types.SearchContextResolutionRepository{}
+	}
+	repositoriesJSON, err := json.Marshal(repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	q := sqlf.Sprintf(
+		"INSERT INTO search_context_resolutions (search_context_id, repositories) VALUES (%s, %s) RETURNING %s",
+		resolution.SearchContextID,
+		repositoriesJSON,
+		sqlf.Sprintf(searchContextResolutionColumns),
+	)
+	return scanSearchContextResolution(s.QueryRow(ctx, q))
+}
+
+func (s *searchContextsStore) GetSearchContextResolution(ctx context.Context, searchContextID, resolutionID int64) (*types.SearchContextResolution, error) {
+	return s.getSearchContextResolution(ctx, sqlf.Sprintf("search_context_id = %s AND id = %s", searchContextID, resolutionID))
+}
+
+func (s *searchContextsStore) GetLatestSearchContextResolution(ctx context.Context, searchContextID int64) (*types.SearchContextResolution, error) {
+	return s.getSearchContextResolution(ctx, sqlf.Sprintf("search_context_id = %s", searchContextID))
+}
+
+// GetPreviousSearchContextResolution returns the resolution that was created before the given one.
+func (s *searchContextsStore) GetPreviousSearchContextResolution(ctx context.Context, searchContextID, resolutionID int64) (*types.SearchContextResolution, error) {
+	return s.getSearchContextResolution(ctx, sqlf.Sprintf("search_context_id = %s AND id < %s", searchContextID, resolutionID))
+}
+
+func (s *searchContextsStore) GetPinnedSearchContextResolution(ctx context.Context, searchContextID int64) (*types.SearchContextResolution, error) {
+	return s.getSearchContextResolution(ctx, sqlf.Sprintf("search_context_id = %s AND pinned", searchContextID))
+}
+
+// ListSearchContextResolutions lists the resolutions of a search context, newest first.
+func (s *searchContextsStore) ListSearchContextResolutions(ctx context.Context, searchContextID int64, pageOpts ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM search_context_resolutions WHERE search_context_id = %s ORDER BY id DESC LIMIT %s OFFSET %s",
+		sqlf.Sprintf(searchContextResolutionColumns),
+		searchContextID,
+		pageOpts.First,
+		pageOpts.After,
+	)
+	return scanSearchContextResolutions(s.Query(ctx, q))
+}
+
+func (s *searchContextsStore) CountSearchContextResolutions(ctx context.Context, searchContextID int64) (int32, error) {
+	var count int32
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM search_context_resolutions WHERE search_context_id = %s", searchContextID)
+	err := s.QueryRow(ctx, q).Scan(&count)
+	return count, err
+}
+
+// SetPinnedSearchContextResolution pins the given resolution of a search context and unpins the
+// previously pinned one. A resolutionID of 0 unpins the search context.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has write access to the search context.
+func (s *searchContextsStore) SetPinnedSearchContextResolution(ctx context.Context, searchContextID, resolutionID int64) (err error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	err = tx.Exec(ctx, sqlf.Sprintf("UPDATE search_context_resolutions SET pinned = false WHERE search_context_id = %s AND pinned", searchContextID))
+	if err != nil || resolutionID == 0 {
+		return err
+	}
+
+	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE search_context_resolutions SET pinned = true WHERE search_context_id = %s AND id = %s", searchContextID, resolutionID))
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSearchContextResolutionNotFound
+	}
+	return nil
+}
+
+// DeleteOldSearchContextResolutions deletes all but the keep most recent resolutions of a search
+// context. Pinned resolutions are never deleted.
+func (s *searchContextsStore) DeleteOldSearchContextResolutions(ctx context.Context, searchContextID int64, keep int) error {
+	return s.Exec(ctx, sqlf.Sprintf(`
+DELETE FROM search_context_resolutions
+WHERE search_context_id = %s AND NOT pinned AND id NOT IN (
+	SELECT id FROM search_context_resolutions WHERE search_context_id = %s ORDER BY id DESC LIMIT %s
+)`,
+		searchContextID,
+		searchContextID,
+		keep,
+	))
+}
+
+// 🚨 SECURITY: The caller must ensure that the actor is the user subscribing themselves, and that
+// they can see the search context.
+func (s *searchContextsStore) CreateSearchContextSubscriptionForUser(ctx context.Context, userID int32, searchContextID int64) error {
+	q := sqlf.Sprintf(
+		`INSERT INTO search_context_subscriptions (user_id, search_context_id)
+		VALUES (%d, %d)
+		ON CONFLICT DO NOTHING`, userID, searchContextID)
+	return s.Exec(ctx, q)
+}
+
+// 🚨 SECURITY: The caller must ensure that the actor is the user unsubscribing themselves.
+func (s *searchContextsStore) DeleteSearchContextSubscriptionForUser(ctx context.Context, userID int32, searchContextID int64) error {
+	q := sqlf.Sprintf(
+		`DELETE FROM search_context_subscriptions
+		WHERE user_id = %d AND search_context_id = %d`,
+		userID, searchContextID)
+	return s.Exec(ctx, q)
+}
+
+func (s *searchContextsStore) IsSearchContextSubscriber(ctx context.Context, userID int32, searchContextID int64) (bool, error) {
+	q := sqlf.Sprintf(
+		`SELECT EXISTS (SELECT FROM search_context_subscriptions WHERE user_id = %d AND search_context_id = %d)`,
+		userID, searchContextID)
+	var exists bool
+	err := s.QueryRow(ctx, q).Scan(&exists)
+	return exists, err
+}
+
+func (s *searchContextsStore) GetSearchContextSubscriberIDs(ctx context.Context, searchContextID int64) (ids []int32, _ error) {
+	q := sqlf.Sprintf(
+		`SELECT COALESCE(array_agg(user_id ORDER BY user_id), '{}') FROM search_context_subscriptions WHERE search_context_id = %d`,
+		searchContextID)
+	return ids, s.QueryRow(ctx, q).Scan(pq.Array(&ids))
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSearchContextResolutions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	t.Parallel()
+	ctx := actor.WithInternalActor(context.Background())
+	sc := db.SearchContexts()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	searchContexts, err := createSearchContexts(ctx, sc, []*types.SearchContext{
+		{Name: "query", Public: true, Query: "repo:^github\\.com/sourcegraph/"},
+		{Name: "repos", Public: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	searchContextID := searchContexts[0].ID
+
+	queryContexts, err := sc.ListSearchContexts(ctx, ListSearchContextsPageOptions{First: 10}, ListSearchContextsOptions{HasQuery: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queryContexts) != 1 || queryContexts[0].ID != searchContextID {
+		t.Fatalf("expected only the query-based search context, got %+v", queryContexts)
+	}
+
+	if _, err := sc.GetLatestSearchContextResolution(ctx, searchContextID); !errors.Is(err, ErrSearchContextResolutionNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	var resolutions []*types.SearchContextResolution
+	for _, names := range [][]string{{"a"}, {"a", "b"}, {"b"}} {
+		repos := make([]types.SearchContextResolutionRepository, 0, len(names))
+		for i, name := range names {
+			repos = append(repos, types.SearchContextResolutionRepository{ID: api.RepoID(i + 1), Name: api.RepoName("github.com/sourcegraph/" + name), Revisions: []string{"main"}})
+		}
+		resolution, err := sc.CreateSearchContextResolution(ctx, &types.SearchContextResolution{SearchContextID: searchContextID, Repositories: repos})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(repos, resolution.Repositories); diff != "" {
+			t.Fatalf("unexpected repositories (-want +got):\n%s", diff)
+		}
+		resolutions = append(resolutions, resolution)
+	}
+
+	ignoreCreatedAt := cmpopts.IgnoreFields(types.SearchContextResolution{}, "CreatedAt")
+
+	latest, err := sc.GetLatestSearchContextResolution(ctx, searchContextID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(resolutions[2], latest, ignoreCreatedAt); diff != "" {
+		t.Fatalf("unexpected latest resolution (-want +got):\n%s", diff)
+	}
+
+	previous, err := sc.GetPreviousSearchContextResolution(ctx, searchContextID, resolutions[2].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous.ID != resolutions[1].ID {
+		t.Fatalf("expected previous resolution %d, got %d", resolutions[1].ID, previous.ID)
+	}
+
+	listed, err := sc.ListSearchContextResolutions(ctx, searchContextID, ListSearchContextsPageOptions{First: 2, After: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*types.SearchContextResolution{resolutions[1], resolutions[0]}, listed, ignoreCreatedAt); diff != "" {
+		t.Fatalf("unexpected resolutions (-want +got):\n%s", diff)
+	}
+
+	t.Run("pinning", func(t *testing.T) {
+		if err := sc.SetPinnedSearchContextResolution(ctx, searchContextID, resolutions[0].ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := sc.SetPinnedSearchContextResolution(ctx, searchContextID, resolutions[1].ID); err != nil {
+			t.Fatal(err)
+		}
+		pinned, err := sc.GetPinnedSearchContextResolution(ctx, searchContextID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pinned.ID != resolutions[1].ID || !pinned.Pinned {
+			t.Fatalf("expected resolution %d to be pinned, got %+v", resolutions[1].ID, pinned)
+		}
+
+		if err := sc.SetPinnedSearchContextResolution(ctx, searchContexts[1].ID, resolutions[0].ID); !errors.Is(err, ErrSearchContextResolutionNotFound) {
+			t.Fatalf("expected not found error when pinning a resolution of another search context, got %v", err)
+		}
+
+		// Pruning keeps the pinned resolution.
+		if err := sc.DeleteOldSearchContextResolutions(ctx, searchContextID, 0); err != nil {
+			t.Fatal(err)
+		}
+		count, err := sc.CountSearchContextResolutions(ctx, searchContextID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatalf("expected only the pinned resolution to be kept, got %d resolutions", count)
+		}
+
+		if err := sc.SetPinnedSearchContextResolution(ctx, searchContextID, 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sc.GetPinnedSearchContextResolution(ctx, searchContextID); !errors.Is(err, ErrSearchContextResolutionNotFound) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("subscriptions", func(t *testing.T) {
+		if err := sc.CreateSearchContextSubscriptionForUser(ctx, user.ID, searchContextID); err != nil {
+			t.Fatal(err)
+		}
+		// Subscribing twice is a no-op.
+		if err := sc.CreateSearchContextSubscriptionForUser(ctx, user.ID, searchContextID); err != nil {
+			t.Fatal(err)
+		}
+
+		subscribed, err := sc.IsSearchContextSubscriber(ctx, user.ID, searchContextID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !subscribed {
+			t.Fatal("expected user to be subscribed")
+		}
+		ids, err := sc.GetSearchContextSubscriberIDs(ctx, searchContextID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int32{user.ID}, ids); diff != "" {
+			t.Fatalf("unexpected subscribers (-want +got):\n%s", diff)
+		}
+
+		if err := sc.DeleteSearchContextSubscriptionForUser(ctx, user.ID, searchContextID); err != nil {
+			t.Fatal(err)
+		}
+		ids, err = sc.GetSearchContextSubscriberIDs(ctx, searchContextID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 0 {
+			t.Fatalf("expected no subscribers, got %v", ids)
+		}
+	})
+}
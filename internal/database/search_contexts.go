@@ -44,6 +44,19 @@ type SearchContextsStore interface {
 	GetDefaultSearchContextForCurrentUser(ctx context.Context) (*types.SearchContext, error)
 	CreateSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
 	DeleteSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
+	CreateSearchContextResolution(ctx context.Context, resolution *types.SearchContextResolution) (*types.SearchContextResolution, error)
+	GetSearchContextResolution(ctx context.Context, searchContextID, resolutionID int64) (*types.SearchContextResolution, error)
+	GetLatestSearchContextResolution(ctx context.Context, searchContextID int64) (*types.SearchContextResolution, error)
+	GetPreviousSearchContextResolution(ctx context.Context, searchContextID, resolutionID int64) (*types.SearchContextResolution, error)
+	GetPinnedSearchContextResolution(ctx context.Context, searchContextID int64) (*types.SearchContextResolution, error)
+	ListSearchContextResolutions(ctx context.Context, searchContextID int64, pageOpts ListSearchContextsPageOptions) ([]*types.SearchContextResolution, error)
+	CountSearchContextResolutions(ctx context.Context, searchContextID int64) (int32, error)
+	SetPinnedSearchContextResolution(ctx context.Context, searchContextID, resolutionID int64) error
+	DeleteOldSearchContextResolutions(ctx context.Context, searchContextID int64, keep int) error
+	CreateSearchContextSubscriptionForUser(ctx context.Context, userID int32, searchContextID int64) error
+	DeleteSearchContextSubscriptionForUser(ctx context.Context, userID int32, searchContextID int64) error
+	IsSearchContextSubscriber(ctx context.Context, userID int32, searchContextID int64) (bool, error)
+	GetSearchContextSubscriberIDs(ctx context.Context, searchContextID int64) ([]int32, error)
 }
 
 type searchContextsStore struct {
@@ -188,6 +201,8 @@ type ListSearchContextsOptions struct {
 	NamespaceOrgIDs []int32
 	// NoNamespace matches search contexts without a namespace ("instance-level contexts").
 	NoNamespace bool
+	// HasQuery matches search contexts that are defined by a query instead of a list of repository revisions.
+	HasQuery bool
 	// OrderBy specifies the ordering option for search contexts. Search contexts are ordered using SearchContextsOrderByID by default.
 	// SearchContextsOrderBySpec option sorts contexts by coallesced namespace names first
 	// (user name and org name) and then by context name. SearchContextsOrderByUpdatedAt option sorts
@@ -261,6 +276,10 @@ func getSearchContextsQueryConditions(opts ListSearchContextsOptions) []*sqlf.Qu
 		conds = append(conds, sqlf.Sprintf("COALESCE(namespace_username, namespace_org_name, '') ILIKE %s", "%"+opts.NamespaceName+"%"))
 	}
 
+	if opts.HasQuery {
+		conds = append(conds, sqlf.Sprintf("query IS NOT NULL"))
+	}
+
 	if len(conds) == 0 {
 		// If no conditions are present, append a catch-all condition to avoid a SQL syntax error
 		conds = append(conds, sqlf.Sprintf("1 = 1"))
@@ -0,0 +1,265 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// defaultPerPage is the page size requested from list endpoints. Gitea caps
+// page sizes at its MAX_RESPONSE_ITEMS setting, which defaults to 50.
+const defaultPerPage = 50
+
+// Client accesses a Gitea (or Forgejo) instance via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client
+	Config *schema.GiteaConnection
+
+	// URL is the base URL of Gitea.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a concept
+	// of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+
+	perPage int
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+		perPage:    defaultPerPage,
+	}, nil
+}
+
+// GetAuthenticatedUser returns the user the configured token belongs to.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "api/v1/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if _, err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetRepo returns the repository with the given owner and name.
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*Repository, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", repoPath(owner, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// GetRepoByID returns the repository with the given ID.
+func (c *Client) GetRepoByID(ctx context.Context, id int64) (*Repository, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "api/v1/repositories/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// ListOrgRepos returns the repositories of the given organization that are
+// visible to the configured token.
+func (c *Client) ListOrgRepos(ctx context.Context, org string) *iterator.Iterator[*Repository] {
+	return paginate(ctx, c, "api/v1/orgs/"+url.PathEscape(org)+"/repos", nil, identity[*Repository])
+}
+
+// ListUserRepos returns the repositories owned by the given user that are
+// visible to the configured token.
+func (c *Client) ListUserRepos(ctx context.Context, user string) *iterator.Iterator[*Repository] {
+	return paginate(ctx, c, "api/v1/users/"+url.PathEscape(user)+"/repos", nil, identity[*Repository])
+}
+
+// searchReposResponse is the envelope returned by the repository search API.
+type searchReposResponse struct {
+	OK   bool          `json:"ok"`
+	Data []*Repository `json:"data"`
+}
+
+// SearchRepos returns the repositories visible to the configured token that
+// match the given keyword. An empty query matches all repositories.
+func (c *Client) SearchRepos(ctx context.Context, query string) *iterator.Iterator[*Repository] {
+	qs := make(url.Values)
+	if query != "" {
+		qs.Set("q", query)
+	}
+	return paginate(ctx, c, "api/v1/repos/search", qs, func(r *searchReposResponse) []*Repository {
+		return r.Data
+	})
+}
+
+// ListCollaborators returns the users that have been added as collaborators to
+// the given repository.
+func (c *Client) ListCollaborators(ctx context.Context, owner, name string) *iterator.Iterator[*User] {
+	return paginate(ctx, c, repoPath(owner, name)+"/collaborators", nil, identity[*User])
+}
+
+// ListRepoTeams returns the organization teams that have access to the given
+// repository. It is only meaningful for repositories owned by organizations.
+func (c *Client) ListRepoTeams(ctx context.Context, owner, name string) ([]*Team, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", repoPath(owner, name)+"/teams", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []*Team
+	if _, err := c.do(ctx, req, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ListTeamMembers returns the members of the team with the given ID.
+func (c *Client) ListTeamMembers(ctx context.Context, teamID int64) *iterator.Iterator[*User] {
+	return paginate(ctx, c, "api/v1/teams/"+strconv.FormatInt(teamID, 10)+"/members", nil, identity[*User])
+}
+
+// ListUsers returns all users of the instance. It requires the configured
+// token to belong to a site admin.
+func (c *Client) ListUsers(ctx context.Context) *iterator.Iterator[*User] {
+	return paginate(ctx, c, "api/v1/admin/users", nil, identity[*User])
+}
+
+func identity[T any](items *[]T) []T { return *items }
+
+// paginate returns an iterator over all pages of the given list endpoint. R is
+// the type the response body is decoded into, and items extracts the page's
+// results from it. Iteration stops at the first page that is not full.
+func paginate[T, R any](ctx context.Context, c *Client, path string, qs url.Values, items func(*R) []T) *iterator.Iterator[T] {
+	page := 1
+	done := false
+
+	return iterator.New(func() ([]T, error) {
+		if done {
+			return nil, nil
+		}
+
+		q := make(url.Values, len(qs)+2)
+		for k, v := range qs {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(page))
+		q.Set("limit", strconv.Itoa(c.perPage))
+
+		u := url.URL{Path: path, RawQuery: q.Encode()}
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp R
+		if _, err := c.do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+
+		results := items(&resp)
+		if len(results) < c.perPage {
+			done = true
+		}
+		page++
+
+		return results, nil
+	})
+}
+
+func repoPath(owner, name string) string {
+	return "api/v1/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	if c.Config.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Config.Token)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,131 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GiteaConnection{Url: srv.URL, Token: "secret"}, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli.perPage = 2
+	return cli
+}
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	var pages []string
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "token secret"; got != want {
+			t.Errorf("wrong Authorization header: got %q, want %q", got, want)
+		}
+		if r.URL.Path != "/api/v1/orgs/acme/repos" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("wrong limit: %q", got)
+		}
+
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		var repos []*Repository
+		switch page {
+		case "1":
+			repos = []*Repository{{ID: 1, FullName: "acme/a"}, {ID: 2, FullName: "acme/b"}}
+		case "2":
+			repos = []*Repository{{ID: 3, FullName: "acme/c", Archived: true}}
+		}
+		_ = json.NewEncoder(w).Encode(repos)
+	}))
+
+	it := cli.ListOrgRepos(context.Background(), "acme")
+	var names []string
+	for it.Next() {
+		names = append(names, it.Current().FullName)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"acme/a", "acme/b", "acme/c"}, names); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, pages); diff != "" {
+		t.Errorf("unexpected pages requested (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/search" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("q"); got != "infra" {
+			t.Errorf("wrong query: %q", got)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		resp := searchReposResponse{OK: true}
+		if page == 1 {
+			resp.Data = []*Repository{{ID: 1, FullName: "acme/infra", Fork: true}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	it := cli.SearchRepos(context.Background(), "infra")
+	var repos []*Repository
+	for it.Next() {
+		repos = append(repos, it.Current())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Repository{{ID: 1, FullName: "acme/infra", Fork: true}}
+	if diff := cmp.Diff(want, repos); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ListRepoTeams(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/acme/infra/teams":
+			fmt.Fprint(w, `[{"id": 7, "name": "Owners", "permission": "owner"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	teams, err := cli.ListRepoTeams(context.Background(), "acme", "infra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*Team{{ID: 7, Name: "Owners", Permission: "owner"}}, teams); diff != "" {
+		t.Errorf("unexpected teams (-want +got):\n%s", diff)
+	}
+
+	_, err = cli.GetRepo(context.Background(), "acme", "missing")
+	if !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
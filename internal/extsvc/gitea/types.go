@@ -0,0 +1,45 @@
+package gitea
+
+import "time"
+
+// Repository is a Gitea repository as returned by the REST API.
+type Repository struct {
+	ID            int64       `json:"id"`
+	Owner         *User       `json:"owner"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Description   string      `json:"description"`
+	Empty         bool        `json:"empty"`
+	Private       bool        `json:"private"`
+	Internal      bool        `json:"internal"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	Mirror        bool        `json:"mirror"`
+	Archived      bool        `json:"archived"`
+	HTMLURL       string      `json:"html_url"`
+	SSHURL        string      `json:"ssh_url"`
+	CloneURL      string      `json:"clone_url"`
+	DefaultBranch string      `json:"default_branch"`
+	StarsCount    int         `json:"stars_count"`
+	ForksCount    int         `json:"forks_count"`
+	Topics        []string    `json:"topics,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// User is a Gitea user or organization as returned by the REST API. Email is
+// only populated when the requesting token is allowed to see it.
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	IsAdmin  bool   `json:"is_admin"`
+}
+
+// Team is a Gitea organization team as returned by the REST API.
+type Team struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Permission string `json:"permission"`
+}
@@ -0,0 +1,86 @@
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// PushEventType is the event type of the webhook sent when commits are
+	// pushed to a repository.
+	PushEventType = "push"
+
+	eventTypeHeader = "X-Gitea-Event"
+	signatureHeader = "X-Gitea-Signature"
+)
+
+// PushEvent is the payload of a Gitea push webhook.
+type PushEvent struct {
+	Ref        string      `json:"ref"`
+	Before     string      `json:"before"`
+	After      string      `json:"after"`
+	Repository *Repository `json:"repository"`
+	Pusher     *User       `json:"pusher"`
+	Sender     *User       `json:"sender"`
+}
+
+// WebhookEventType returns the type of the webhook event sent with the given
+// request. Forgejo sends the same header as Gitea.
+func WebhookEventType(r *http.Request) string {
+	return r.Header.Get(eventTypeHeader)
+}
+
+// WebhookSignature returns the signature sent with the given request.
+func WebhookSignature(r *http.Request) string {
+	return r.Header.Get(signatureHeader)
+}
+
+// ValidateSignature checks that signature is the hex encoded HMAC-SHA256 of
+// payload keyed with secret, which is how Gitea signs webhook payloads.
+func ValidateSignature(signature string, payload, secret []byte) error {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(err, "decoding signature")
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("payload signature does not match")
+	}
+	return nil
+}
+
+// ParseWebhookEvent unmarshals the payload of a webhook event of the given
+// type. Only push events are supported.
+func ParseWebhookEvent(eventType string, payload []byte) (any, error) {
+	var target any
+	switch eventType {
+	case PushEventType:
+		target = &PushEvent{}
+	default:
+		return nil, webhookNotFoundErr{eventType: eventType}
+	}
+
+	if err := json.Unmarshal(payload, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+type webhookNotFoundErr struct {
+	eventType string
+}
+
+func (e webhookNotFoundErr) Error() string {
+	return "unknown Gitea webhook event type: " + e.eventType
+}
+
+func (e webhookNotFoundErr) NotFound() bool {
+	return true
+}
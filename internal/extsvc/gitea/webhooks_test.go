@@ -0,0 +1,53 @@
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"ref": "refs/heads/main"}`)
+	secret := []byte("s3cr3t")
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	if err := ValidateSignature(signature, payload, secret); err != nil {
+		t.Errorf("unexpected error for valid signature: %v", err)
+	}
+	if err := ValidateSignature(signature, payload, []byte("wrong")); err == nil {
+		t.Error("expected error for wrong secret")
+	}
+	if err := ValidateSignature("not hex", payload, secret); err == nil {
+		t.Error("expected error for malformed signature")
+	}
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	payload := []byte(`{
+		"ref": "refs/heads/main",
+		"after": "abc",
+		"repository": {"id": 1, "full_name": "acme/infra", "clone_url": "https://gitea.example.com/acme/infra.git"}
+	}`)
+
+	e, err := ParseWebhookEvent(PushEventType, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	push, ok := e.(*PushEvent)
+	if !ok {
+		t.Fatalf("unexpected event type %T", e)
+	}
+	if push.Repository.CloneURL != "https://gitea.example.com/acme/infra.git" {
+		t.Errorf("unexpected clone URL %q", push.Repository.CloneURL)
+	}
+
+	if _, err := ParseWebhookEvent("issues", payload); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error for unsupported event, got %v", err)
+	}
+}
@@ -1762,6 +1762,9 @@ type Repository struct {
 	// a list of topics the repository is tagged with
 	RepositoryTopics RepositoryTopics
 
+	// the primary language of the repository, nil if unknown
+	PrimaryLanguage *Language `json:",omitempty"`
+
 	// Metadata retained for ranking
 	StargazerCount int `json:",omitempty"`
 	ForkCount      int `json:",omitempty"`
@@ -1784,6 +1787,10 @@ type Topic struct {
 	Name string
 }
 
+type Language struct {
+	Name string
+}
+
 type restRepositoryPermissions struct {
 	Admin bool `json:"admin"`
 	Push  bool `json:"push"`
@@ -1805,6 +1812,7 @@ type restRepository struct {
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
 	Visibility  string                    `json:"visibility"`
+	Language    string                    `json:"language"`
 	Topics      []string                  `json:"topics"`
 }
 
@@ -1848,6 +1856,10 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		RepositoryTopics: RepositoryTopics{topics},
 	}
 
+	if restRepo.Language != "" {
+		repo.PrimaryLanguage = &Language{Name: restRepo.Language}
+	}
+
 	if conf.ExperimentalFeatures().EnableGithubInternalRepoVisibility {
 		repo.Visibility = Visibility(restRepo.Visibility)
 	}
@@ -1918,6 +1930,11 @@ type restTopicsResponse struct {
 	Names []string `json:"names"`
 }
 
+type restCustomPropertyValue struct {
+	PropertyName string          `json:"property_name"`
+	Value        json.RawMessage `json:"value"`
+}
+
 func GetExternalAccountData(ctx context.Context, data *extsvc.AccountData) (usr *github.User, tok *oauth2.Token, err error) {
 	if data.Data != nil {
 		usr, err = encryption.DecryptJSON[github.User](ctx, data.Data)
@@ -713,6 +713,44 @@ func (c *V3Client) ListTopicsOnRepository(ctx context.Context, ownerAndName stri
 	return result.Names, nil
 }
 
+// ListCustomPropertiesOnRepository lists the values of the custom properties
+// of the given repository, keyed by property name. The values of multi-select
+// properties are joined with commas, and properties without a value are
+// omitted.
+//
+// API docs: https://docs.github.com/en/rest/repos/custom-properties#get-all-custom-property-values-for-a-repository
+func (c *V3Client) ListCustomPropertiesOnRepository(ctx context.Context, ownerAndName string) (map[string]string, error) {
+	owner, name, err := SplitRepositoryNameWithOwner(ownerAndName)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []restCustomPropertyValue
+	if _, err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/properties/values", owner, name), &result); err != nil {
+		if HTTPErrorCode(err) == http.StatusNotFound {
+			return nil, ErrRepoNotFound
+		}
+		return nil, err
+	}
+
+	properties := make(map[string]string, len(result))
+	for _, p := range result {
+		if len(p.Value) == 0 || string(p.Value) == "null" {
+			continue
+		}
+		var single string
+		if err := json.Unmarshal(p.Value, &single); err == nil {
+			properties[p.PropertyName] = single
+			continue
+		}
+		var multi []string
+		if err := json.Unmarshal(p.Value, &multi); err == nil && len(multi) > 0 {
+			properties[p.PropertyName] = strings.Join(multi, ",")
+		}
+	}
+	return properties, nil
+}
+
 // ListInstallationRepositories lists repositories on which the authenticated
 // GitHub App has been installed.
 //
@@ -875,6 +875,31 @@ func TestClient_ListRepositoriesForSearch_incomplete(t *testing.T) {
 	}
 }
 
+func TestClient_ListCustomPropertiesOnRepository(t *testing.T) {
+	mock := mockHTTPResponseBody{
+		responseBody: `
+[
+  {"property_name": "team", "value": "search"},
+  {"property_name": "environments", "value": ["prod", "staging"]},
+  {"property_name": "unset", "value": null}
+]
+`,
+	}
+	c := newTestClient(t, &mock)
+
+	have, err := c.ListCustomPropertiesOnRepository(context.Background(), "sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"team":         "search",
+		"environments": "prod,staging",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected custom properties (-want +got):\n%s", diff)
+	}
+}
+
 type testCase struct {
 	repoName    string
 	expectedUrl string
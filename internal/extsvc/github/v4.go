@@ -599,6 +599,9 @@ fragment RepositoryFields on Repository {
 			}
 		}
 	}
+	primaryLanguage {
+		name
+	}
 }
 	`
 	}
@@ -636,6 +639,9 @@ fragment RepositoryFields on Repository {
 			}
 		}
 	}
+	primaryLanguage {
+		name
+	}
 	%s
 }
 	`, strings.Join(conditionalGHEFields, "\n	"))
@@ -0,0 +1,61 @@
+package hex
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+)
+
+// Run go test ./internal/extsvc/hex -update to update snapshots.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(m.Run())
+}
+
+var updateRecordings = flag.Bool("update", false, "make Hex API calls, record and save data")
+
+func newTestHTTPClient(t *testing.T) (client *Client, stop func()) {
+	t.Helper()
+	recorderFactory, stop := httptestutil.NewRecorderFactory(t, *updateRecordings, t.Name())
+
+	client, _ = NewClient("hex_urn", "https://repo.hex.pm", recorderFactory)
+	return client, stop
+}
+
+func TestGetPackageContents(t *testing.T) {
+	ctx := context.Background()
+	client, stop := newTestHTTPClient(t)
+	defer stop()
+	dep, err := reposource.ParseHexVersionedPackage("jason@1.4.1")
+	require.NoError(t, err)
+	readCloser, err := client.GetPackageContents(ctx, dep)
+	require.NoError(t, err)
+	defer readCloser.Close()
+
+	tmpDir := t.TempDir()
+	err = unpack.Tar(readCloser, tmpDir, unpack.Opts{})
+	require.NoError(t, err)
+	contentsTgz, err := os.ReadFile(filepath.Join(tmpDir, "contents.tar.gz"))
+	require.NoError(t, err)
+	files, err := unpack.ListTgzUnsorted(bytes.NewReader(contentsTgz))
+	require.NoError(t, err)
+	sort.Strings(files)
+
+	require.Equal(t, []string{
+		"README.md",
+		"lib/decoder.ex",
+		"lib/encoder.ex",
+		"lib/jason.ex",
+		"mix.exs",
+	}, files)
+}
@@ -0,0 +1,154 @@
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultRepository is the service index of nuget.org.
+const DefaultRepository = "https://api.nuget.org/v3/index.json"
+
+// packageBaseAddressType is the type of the service index resource from which
+// package content can be downloaded.
+// See https://learn.microsoft.com/en-us/nuget/api/package-base-address-resource.
+const packageBaseAddressType = "PackageBaseAddress/3.0.0"
+
+// Client is a client for NuGet v3 feeds.
+type Client struct {
+	repository  string
+	credentials string
+
+	uncachedClient httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+
+	mu          sync.Mutex
+	baseAddress string
+}
+
+// NewClient returns a client for the NuGet v3 feed with the given service
+// index URL. Credentials in the form "username:password" are sent with basic
+// authentication if not empty.
+func NewClient(urn, repository, credentials string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	if repository == "" {
+		repository = DefaultRepository
+	}
+	return &Client{
+		repository:     repository,
+		credentials:    credentials,
+		uncachedClient: uncached,
+		limiter:        ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// GetPackageContents downloads the .nupkg archive of the given package version.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (body io.ReadCloser, err error) {
+	baseAddress, err := c.packageBaseAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.ToLower(string(dep.PackageSyntax()))
+	version := strings.ToLower(dep.PackageVersion())
+	url := fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", strings.TrimSuffix(baseAddress, "/"), id, version, id, version)
+	return c.get(ctx, url)
+}
+
+type serviceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// packageBaseAddress returns the URL of the package content resource of the
+// feed, which is looked up in the service index on first use.
+func (c *Client) packageBaseAddress(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.baseAddress != "" {
+		return c.baseAddress, nil
+	}
+
+	body, err := c.get(ctx, c.repository)
+	if err != nil {
+		return "", errors.Wrap(err, "fetching service index")
+	}
+	defer body.Close()
+
+	var index serviceIndex
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return "", errors.Wrap(err, "decoding service index")
+	}
+	for _, r := range index.Resources {
+		if r.Type == packageBaseAddressType {
+			c.baseAddress = r.ID
+			return c.baseAddress, nil
+		}
+	}
+	return "", errors.Errorf("service index %s has no %s resource", c.repository, packageBaseAddressType)
+}
+
+func (c *Client) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-nuget-syncer (sourcegraph.com)")
+	if c.credentials != "" {
+		username, password, _ := strings.Cut(c.credentials, ":")
+		req.SetBasicAuth(username, password)
+	}
+
+	return c.do(c.uncachedClient, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			bs = []byte(errors.Wrap(err, "failed to read body").Error())
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+	return resp.Body, nil
+}
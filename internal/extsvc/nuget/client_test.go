@@ -0,0 +1,71 @@
+package nuget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func mockFeed(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "sourcegraph" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/index.json":
+			fmt.Fprintf(w, `{"version":"3.0.0","resources":[
+				{"@id":"%[1]s/query","@type":"SearchQueryService"},
+				{"@id":"%[1]s/v3-flatcontainer/","@type":"PackageBaseAddress/3.0.0"}
+			]}`, server.URL)
+		case "/v3-flatcontainer/newtonsoft.json/13.0.3/newtonsoft.json.13.0.3.nupkg":
+			w.Write([]byte("nupkg"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetPackageContents(t *testing.T) {
+	ctx := context.Background()
+	server := mockFeed(t)
+
+	client, err := NewClient("urn", server.URL+"/v3/index.json", "sourcegraph:secret", httpcli.ExternalClientFactory)
+	require.NoError(t, err)
+
+	body, err := client.GetPackageContents(ctx, reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@13.0.3"))
+	require.NoError(t, err)
+	defer body.Close()
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "nupkg", string(contents))
+
+	_, err = client.GetPackageContents(ctx, reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@0.0.1"))
+	var nugetErr *Error
+	require.True(t, errors.As(err, &nugetErr) && nugetErr.NotFound())
+}
+
+func TestGetPackageContents_Unauthorized(t *testing.T) {
+	server := mockFeed(t)
+
+	client, err := NewClient("urn", server.URL+"/v3/index.json", "sourcegraph:wrong", httpcli.ExternalClientFactory)
+	require.NoError(t, err)
+
+	_, err = client.GetPackageContents(context.Background(), reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@13.0.3"))
+	var nugetErr *Error
+	require.True(t, errors.As(err, &nugetErr) && nugetErr.code == http.StatusUnauthorized)
+}
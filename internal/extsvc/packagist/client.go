@@ -0,0 +1,185 @@
+package packagist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultRepository is the URL of the Packagist Composer repository.
+const DefaultRepository = "https://repo.packagist.org"
+
+// Client is a client for Composer repositories that implement the metadata
+// API of Packagist (https://packagist.org/apidoc).
+type Client struct {
+	repositoryURL string
+
+	uncachedClient httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+func NewClient(urn string, repositoryURL string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	if repositoryURL == "" {
+		repositoryURL = DefaultRepository
+	}
+	return &Client{
+		repositoryURL:  repositoryURL,
+		uncachedClient: uncached,
+		limiter:        ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// Version is a released version of a Composer package.
+type Version struct {
+	Version string `json:"version"`
+	Dist    *Dist  `json:"dist"`
+}
+
+// Dist describes the archive a version of a package is distributed as.
+type Dist struct {
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	Reference string `json:"reference"`
+	Shasum    string `json:"shasum"`
+}
+
+// Versions returns the tagged versions of the given package, most recent first.
+func (c *Client) Versions(ctx context.Context, name reposource.PackageName) ([]*Version, error) {
+	url := fmt.Sprintf("%s/p2/%s.json", strings.TrimSuffix(c.repositoryURL, "/"), name)
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var metadata struct {
+		Minified string                                  `json:"minified"`
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+	}
+	if err := json.NewDecoder(body).Decode(&metadata); err != nil {
+		return nil, errors.Wrapf(err, "decoding metadata of package %q", name)
+	}
+
+	entries := metadata.Packages[string(name)]
+	if metadata.Minified == "composer/2.0" {
+		entries = expandMinified(entries)
+	}
+
+	versions := make([]*Version, 0, len(entries))
+	for _, entry := range entries {
+		var v Version
+		if err := json.Unmarshal(entry["version"], &v.Version); err != nil {
+			return nil, errors.Wrapf(err, "decoding version of package %q", name)
+		}
+		if dist, ok := entry["dist"]; ok {
+			if err := json.Unmarshal(dist, &v.Dist); err != nil {
+				return nil, errors.Wrapf(err, "decoding dist of package %q version %q", name, v.Version)
+			}
+		}
+		versions = append(versions, &v)
+	}
+	return versions, nil
+}
+
+// Version returns the given version of a package. Versions are matched with
+// and without a "v" prefix, because tags of PHP packages use both conventions.
+func (c *Client) Version(ctx context.Context, name reposource.PackageName, version string) (*Version, error) {
+	versions, err := c.Versions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	want := strings.TrimPrefix(version, "v")
+	for _, v := range versions {
+		if strings.TrimPrefix(v.Version, "v") == want {
+			return v, nil
+		}
+	}
+	return nil, &Error{path: string(name), code: http.StatusNotFound, message: fmt.Sprintf("version %q not found", version)}
+}
+
+// Download downloads the archive at the given URL.
+func (c *Client) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	return c.get(ctx, url)
+}
+
+// expandMinified expands the versions of a package in the minified metadata
+// format, where each version only contains the fields that differ from the
+// previous version, and removed fields have the value "__unset".
+func expandMinified(entries []map[string]json.RawMessage) []map[string]json.RawMessage {
+	expanded := make([]map[string]json.RawMessage, 0, len(entries))
+	var previous map[string]json.RawMessage
+	for _, entry := range entries {
+		current := make(map[string]json.RawMessage, len(previous)+len(entry))
+		for k, v := range previous {
+			current[k] = v
+		}
+		for k, v := range entry {
+			if string(v) == `"__unset"` {
+				delete(current, k)
+			} else {
+				current[k] = v
+			}
+		}
+		expanded = append(expanded, current)
+		previous = current
+	}
+	return expanded
+}
+
+func (c *Client) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-packagist-syncer (sourcegraph.com)")
+
+	return c.do(c.uncachedClient, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			bs = []byte(errors.Wrap(err, "failed to read body").Error())
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+	return resp.Body, nil
+}
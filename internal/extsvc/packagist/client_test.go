@@ -0,0 +1,84 @@
+package packagist
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
+)
+
+// Run go test ./internal/extsvc/packagist -update to update snapshots.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(m.Run())
+}
+
+var updateRecordings = flag.Bool("update", false, "make Packagist API calls, record and save data")
+
+func newTestHTTPClient(t *testing.T) (client *Client, stop func()) {
+	t.Helper()
+	recorderFactory, stop := httptestutil.NewRecorderFactory(t, *updateRecordings, t.Name())
+
+	client, _ = NewClient("packagist_urn", "https://repo.packagist.org", recorderFactory)
+	return client, stop
+}
+
+func TestVersions(t *testing.T) {
+	ctx := context.Background()
+	client, stop := newTestHTTPClient(t)
+	defer stop()
+
+	versions, err := client.Versions(ctx, "monolog/monolog")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	require.Equal(t, "3.4.0", versions[0].Version)
+	require.Equal(t, "zip", versions[0].Dist.Type)
+	require.Equal(t, "https://api.github.com/repos/Seldaek/monolog/zipball/e2392369686d420ca32df3803de28b5d6f76867d", versions[0].Dist.URL)
+
+	// The second version of the minified metadata only lists changed fields.
+	require.Equal(t, "3.3.1", versions[1].Version)
+	require.Equal(t, "https://api.github.com/repos/Seldaek/monolog/zipball/9b5daeaffce5b926cac47923798bba91059e60e2", versions[1].Dist.URL)
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	client, stop := newTestHTTPClient(t)
+	defer stop()
+
+	version, err := client.Version(ctx, "monolog/monolog", "v3.4.0")
+	require.NoError(t, err)
+
+	body, err := client.Download(ctx, version.Dist.URL)
+	require.NoError(t, err)
+	defer body.Close()
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	require.NoError(t, err)
+	var files []string
+	for _, f := range zr.File {
+		files = append(files, f.Name)
+	}
+	sort.Strings(files)
+	require.Equal(t, []string{
+		"Seldaek-monolog-e239236/README.md",
+		"Seldaek-monolog-e239236/composer.json",
+		"Seldaek-monolog-e239236/src/Monolog/Handler/StreamHandler.php",
+		"Seldaek-monolog-e239236/src/Monolog/Logger.php",
+	}, files)
+
+	_, err = client.Version(ctx, "monolog/monolog", "0.0.1")
+	var packagistErr *Error
+	require.ErrorAs(t, err, &packagistErr)
+	require.True(t, packagistErr.NotFound())
+}
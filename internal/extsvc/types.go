@@ -122,25 +122,31 @@ const (
 	// The constants below represent the different kinds of external service we support and should be used
 	// in preference to the Type values below.
 
-	KindAWSCodeCommit   = "AWSCODECOMMIT"
-	KindBitbucketServer = "BITBUCKETSERVER"
-	KindBitbucketCloud  = "BITBUCKETCLOUD"
-	KindGerrit          = "GERRIT"
-	KindGitHub          = "GITHUB"
-	KindGitLab          = "GITLAB"
-	KindGitolite        = "GITOLITE"
-	KindPerforce        = "PERFORCE"
-	KindPhabricator     = "PHABRICATOR"
-	KindGoPackages      = "GOMODULES"
-	KindJVMPackages     = "JVMPACKAGES"
-	KindPythonPackages  = "PYTHONPACKAGES"
-	KindRustPackages    = "RUSTPACKAGES"
-	KindRubyPackages    = "RUBYPACKAGES"
-	KindNpmPackages     = "NPMPACKAGES"
-	KindPagure          = "PAGURE"
-	KindAzureDevOps     = "AZUREDEVOPS"
-	KindSCIM            = "SCIM"
-	KindOther           = "OTHER"
+	KindAWSCodeCommit    = "AWSCODECOMMIT"
+	KindBitbucketServer  = "BITBUCKETSERVER"
+	KindBitbucketCloud   = "BITBUCKETCLOUD"
+	KindGerrit           = "GERRIT"
+	KindGitea            = "GITEA"
+	KindGitHub           = "GITHUB"
+	KindGitLab           = "GITLAB"
+	KindGitolite         = "GITOLITE"
+	KindMercurial        = "MERCURIAL"
+	KindPerforce         = "PERFORCE"
+	KindPhabricator      = "PHABRICATOR"
+	KindSubversion       = "SUBVERSION"
+	KindGoPackages       = "GOMODULES"
+	KindJVMPackages      = "JVMPACKAGES"
+	KindPythonPackages   = "PYTHONPACKAGES"
+	KindRustPackages     = "RUSTPACKAGES"
+	KindRubyPackages     = "RUBYPACKAGES"
+	KindNuGetPackages    = "NUGETPACKAGES"
+	KindComposerPackages = "COMPOSERPACKAGES"
+	KindHexPackages      = "HEXPACKAGES"
+	KindNpmPackages      = "NPMPACKAGES"
+	KindPagure           = "PAGURE"
+	KindAzureDevOps      = "AZUREDEVOPS"
+	KindSCIM             = "SCIM"
+	KindOther            = "OTHER"
 )
 
 const (
@@ -162,6 +168,10 @@ const (
 	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	TypeGerrit = "gerrit"
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = "gitea"
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = "github"
@@ -173,9 +183,17 @@ const (
 	// TypeGitolite is the (api.ExternalRepoSpec).ServiceType value for Gitolite projects.
 	TypeGitolite = "gitolite"
 
+	// TypeMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories, which
+	// gitserver mirrors into Git. The ServiceID value is the base URL of the Mercurial server.
+	TypeMercurial = "mercurial"
+
 	// TypePerforce is the (api.ExternalRepoSpec).ServiceType value for Perforce projects.
 	TypePerforce = "perforce"
 
+	// TypeSubversion is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories, which
+	// gitserver mirrors into Git. The ServiceID value is the base URL of the Subversion server.
+	TypeSubversion = "subversion"
+
 	// TypePhabricator is the (api.ExternalRepoSpec).ServiceType value for Phabricator projects.
 	TypePhabricator = "phabricator"
 
@@ -203,6 +221,15 @@ const (
 	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	TypeRubyPackages = "rubyPackages"
 
+	// TypeNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages (.NET ecosystem libraries).
+	TypeNuGetPackages = "nugetPackages"
+
+	// TypeComposerPackages is the (api.ExternalRepoSpec).ServiceType value for Composer packages (PHP ecosystem libraries).
+	TypeComposerPackages = "composerPackages"
+
+	// TypeHexPackages is the (api.ExternalRepoSpec).ServiceType value for Hex packages (Elixir and Erlang ecosystem libraries).
+	TypeHexPackages = "hexPackages"
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = "other"
 )
@@ -219,6 +246,8 @@ func KindToType(kind string) string {
 		return TypeBitbucketCloud
 	case KindGerrit:
 		return TypeGerrit
+	case KindGitea:
+		return TypeGitea
 	case KindGitHub:
 		return TypeGitHub
 	case KindGitLab:
@@ -227,8 +256,12 @@ func KindToType(kind string) string {
 		return TypeGitolite
 	case KindPhabricator:
 		return TypePhabricator
+	case KindMercurial:
+		return TypeMercurial
 	case KindPerforce:
 		return TypePerforce
+	case KindSubversion:
+		return TypeSubversion
 	case KindJVMPackages:
 		return TypeJVMPackages
 	case KindPythonPackages:
@@ -237,6 +270,12 @@ func KindToType(kind string) string {
 		return TypeRustPackages
 	case KindRubyPackages:
 		return TypeRubyPackages
+	case KindNuGetPackages:
+		return TypeNuGetPackages
+	case KindComposerPackages:
+		return TypeComposerPackages
+	case KindHexPackages:
+		return TypeHexPackages
 	case KindNpmPackages:
 		return TypeNpmPackages
 	case KindGoPackages:
@@ -264,14 +303,20 @@ func TypeToKind(t string) string {
 		return KindBitbucketCloud
 	case TypeGerrit:
 		return KindGerrit
+	case TypeGitea:
+		return KindGitea
 	case TypeGitHub:
 		return KindGitHub
 	case TypeGitLab:
 		return KindGitLab
 	case TypeGitolite:
 		return KindGitolite
+	case TypeMercurial:
+		return KindMercurial
 	case TypePerforce:
 		return KindPerforce
+	case TypeSubversion:
+		return KindSubversion
 	case TypePhabricator:
 		return KindPhabricator
 	case TypeNpmPackages:
@@ -284,6 +329,12 @@ func TypeToKind(t string) string {
 		return KindRustPackages
 	case TypeRubyPackages:
 		return KindRubyPackages
+	case TypeNuGetPackages:
+		return KindNuGetPackages
+	case TypeComposerPackages:
+		return KindComposerPackages
+	case TypeHexPackages:
+		return KindHexPackages
 	case TypeGoModules:
 		return KindGoPackages
 	case TypePagure:
@@ -299,14 +350,17 @@ func TypeToKind(t string) string {
 
 var (
 	// Precompute these for use in ParseServiceType below since the constants are mixed case
-	bbsLower    = strings.ToLower(TypeBitbucketServer)
-	bbcLower    = strings.ToLower(TypeBitbucketCloud)
-	jvmLower    = strings.ToLower(TypeJVMPackages)
-	npmLower    = strings.ToLower(TypeNpmPackages)
-	goLower     = strings.ToLower(TypeGoModules)
-	pythonLower = strings.ToLower(TypePythonPackages)
-	rustLower   = strings.ToLower(TypeRustPackages)
-	rubyLower   = strings.ToLower(TypeRubyPackages)
+	bbsLower      = strings.ToLower(TypeBitbucketServer)
+	bbcLower      = strings.ToLower(TypeBitbucketCloud)
+	jvmLower      = strings.ToLower(TypeJVMPackages)
+	npmLower      = strings.ToLower(TypeNpmPackages)
+	goLower       = strings.ToLower(TypeGoModules)
+	pythonLower   = strings.ToLower(TypePythonPackages)
+	rustLower     = strings.ToLower(TypeRustPackages)
+	rubyLower     = strings.ToLower(TypeRubyPackages)
+	nugetLower    = strings.ToLower(TypeNuGetPackages)
+	composerLower = strings.ToLower(TypeComposerPackages)
+	hexLower      = strings.ToLower(TypeHexPackages)
 )
 
 // ParseServiceType will return a ServiceType constant after doing a case insensitive match on s.
@@ -321,14 +375,20 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeBitbucketCloud, true
 	case TypeGerrit:
 		return TypeGerrit, true
+	case TypeGitea:
+		return TypeGitea, true
 	case TypeGitHub:
 		return TypeGitHub, true
 	case TypeGitLab:
 		return TypeGitLab, true
 	case TypeGitolite:
 		return TypeGitolite, true
+	case TypeMercurial:
+		return TypeMercurial, true
 	case TypePerforce:
 		return TypePerforce, true
+	case TypeSubversion:
+		return TypeSubversion, true
 	case TypePhabricator:
 		return TypePhabricator, true
 	case goLower:
@@ -343,6 +403,12 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeRustPackages, true
 	case rubyLower:
 		return TypeRubyPackages, true
+	case nugetLower:
+		return TypeNuGetPackages, true
+	case composerLower:
+		return TypeComposerPackages, true
+	case hexLower:
+		return TypeHexPackages, true
 	case TypePagure:
 		return TypePagure, true
 	case TypeAzureDevOps:
@@ -366,14 +432,20 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindBitbucketCloud, true
 	case KindGerrit:
 		return KindGerrit, true
+	case KindGitea:
+		return KindGitea, true
 	case KindGitHub:
 		return KindGitHub, true
 	case KindGitLab:
 		return KindGitLab, true
 	case KindGitolite:
 		return KindGitolite, true
+	case KindMercurial:
+		return KindMercurial, true
 	case KindPerforce:
 		return KindPerforce, true
+	case KindSubversion:
+		return KindSubversion, true
 	case KindPhabricator:
 		return KindPhabricator, true
 	case KindGoPackages:
@@ -386,6 +458,12 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindRustPackages, true
 	case KindRubyPackages:
 		return KindRubyPackages, true
+	case KindNuGetPackages:
+		return KindNuGetPackages, true
+	case KindComposerPackages:
+		return KindComposerPackages, true
+	case KindHexPackages:
+		return KindHexPackages, true
 	case KindPagure:
 		return KindPagure, true
 	case KindAzureDevOps:
@@ -402,6 +480,7 @@ var supportsRepoExclusion = map[string]bool{
 	KindBitbucketCloud:  true,
 	KindBitbucketServer: true,
 	KindGitHub:          true,
+	KindGitea:           true,
 	KindGitLab:          true,
 	KindGitolite:        true,
 	KindAzureDevOps:     true,
@@ -465,14 +544,20 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.BitbucketCloudConnection{}, nil
 	case KindGerrit:
 		return &schema.GerritConnection{}, nil
+	case KindGitea:
+		return &schema.GiteaConnection{}, nil
 	case KindGitHub:
 		return &schema.GitHubConnection{}, nil
 	case KindGitLab:
 		return &schema.GitLabConnection{}, nil
 	case KindGitolite:
 		return &schema.GitoliteConnection{}, nil
+	case KindMercurial:
+		return &schema.MercurialConnection{}, nil
 	case KindPerforce:
 		return &schema.PerforceConnection{}, nil
+	case KindSubversion:
+		return &schema.SubversionConnection{}, nil
 	case KindPhabricator:
 		return &schema.PhabricatorConnection{}, nil
 	case KindGoPackages:
@@ -489,6 +574,12 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.RustPackagesConnection{}, nil
 	case KindRubyPackages:
 		return &schema.RubyPackagesConnection{}, nil
+	case KindNuGetPackages:
+		return &schema.NuGetPackagesConnection{}, nil
+	case KindComposerPackages:
+		return &schema.ComposerPackagesConnection{}, nil
+	case KindHexPackages:
+		return &schema.HexPackagesConnection{}, nil
 	case KindOther:
 		return &schema.OtherExternalServiceConnection{}, nil
 	default:
@@ -562,6 +653,8 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -644,6 +737,12 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		// 8/s is the default limit we enforce
+		limit = rate.Limit(8)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = rate.Limit(6000 / 3600.0) // Same as the default in npm-packages.schema.json
 		if c != nil && c.RateLimit != nil {
@@ -676,6 +775,24 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.NuGetPackagesConnection:
+		// nuget.org does not document a rate limit for its package content API.
+		limit = rate.Limit(36000.0 / 3600.0) // 10/second same as default in nuget-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.ComposerPackagesConnection:
+		// Packagist does not document a rate limit for its metadata API.
+		limit = rate.Limit(36000.0 / 3600.0) // 10/second same as default in composer-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.HexPackagesConnection:
+		// repo.hex.pm is served by a CDN and does not document a rate limit.
+		limit = rate.Limit(36000.0 / 3600.0) // 10/second same as default in hex-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	default:
 		return limit, ErrRateLimitUnsupported{codehostKind: kind}
 	}
@@ -780,10 +897,16 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
 		rawURL = c.Url
+	case *schema.SubversionConnection:
+		rawURL = c.Url
+	case *schema.MercurialConnection:
+		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
 	case *schema.AWSCodeCommitConnection:
@@ -805,6 +928,12 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return KindRustPackages, nil
 	case *schema.RubyPackagesConnection:
 		return KindRubyPackages, nil
+	case *schema.NuGetPackagesConnection:
+		return KindNuGetPackages, nil
+	case *schema.ComposerPackagesConnection:
+		return KindComposerPackages, nil
+	case *schema.HexPackagesConnection:
+		return KindHexPackages, nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
 	default:
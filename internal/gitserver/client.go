@@ -443,6 +443,10 @@ type ArchiveOptions struct {
 	Treeish   string               // the tree or commit to produce an archive for
 	Format    ArchiveFormat        // format of the resulting archive (usually "tar" or "zip")
 	Pathspecs []gitdomain.Pathspec // if nonempty, only include these pathspecs.
+	// ResolveLFS includes the content of files tracked by Git LFS instead of
+	// their pointer files, if gitserver fetched their LFS objects. Only
+	// supported for tar archives.
+	ResolveLFS bool
 }
 
 type BatchLogOptions protocol.BatchLogRequest
@@ -492,6 +496,10 @@ func (c *clientImplementor) archiveURL(repo api.RepoName, opt ArchiveOptions) *u
 		q.Add("path", string(pathspec))
 	}
 
+	if opt.ResolveLFS {
+		q.Set("lfs", "true")
+	}
+
 	addrForRepo := c.AddrForRepo(repo)
 	return &url.URL{
 		Scheme:   "http",
@@ -257,6 +257,11 @@ type ExecRequest struct {
 	Args           []string `json:"args"`
 	Stdin          []byte   `json:"stdin,omitempty"`
 	NoTimeout      bool     `json:"noTimeout"`
+
+	// ResolveLFS replaces Git LFS pointer files in the output of `git archive
+	// --format=tar` with the content of their LFS objects, if those have been
+	// fetched. It is only set by gitserver itself for archive requests.
+	ResolveLFS bool `json:"-"`
 }
 
 // BatchLogRequest is a request to execute a `git log` command inside a set of
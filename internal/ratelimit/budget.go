@@ -0,0 +1,255 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+// Priority is the priority class of a code host API request. When the rate
+// limit budget of a code host runs low, requests with a lower priority wait
+// for the budget to reset before requests with a higher priority do, so that
+// background work doesn't starve user-facing requests.
+type Priority int
+
+const (
+	// PriorityInteractive is the priority of requests that a user is waiting
+	// for, such as repository lookups. It's the default priority.
+	PriorityInteractive Priority = iota
+	// PrioritySync is the priority of requests that sync repositories and
+	// their metadata from code hosts.
+	PrioritySync
+	// PriorityBackground is the priority of all other background requests,
+	// such as permissions syncing.
+	PriorityBackground
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PrioritySync:
+		return "sync"
+	case PriorityBackground:
+		return "background"
+	default:
+		return "interactive"
+	}
+}
+
+// reserve returns the fraction of the rate limit budget that requests with
+// priority p may not consume, because it's reserved for requests with higher
+// priorities.
+func (p Priority) reserve() float64 {
+	switch p {
+	case PrioritySync:
+		return 0.1
+	case PriorityBackground:
+		return 0.3
+	default:
+		return 0
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context that makes code host API requests use the
+// given priority class.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority class set with WithPriority, or
+// PriorityInteractive if there is none.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// budgetState is the rate limit budget of a code host / token tuple as last
+// reported by the code host.
+type budgetState struct {
+	Known     bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Retry     time.Time
+}
+
+// sharedBucket is a token bucket in redis holding the rate limit budget of each
+// code host / token tuple. All services take tokens from it before they send a
+// request to the code host, so that together they don't overspend the budget,
+// and requests with a lower priority leave the tokens reserved for higher
+// priorities to the requests of all services. The bucket is filled from the
+// rate limit headers of the code host's responses.
+//
+// Without redis, for example in Sourcegraph App, there is no shared bucket and
+// monitors fall back to the budget they observed themselves.
+type sharedBucket struct {
+	kv redispool.KeyValue
+}
+
+// minBudgetTTL is the minimum time that a budget is kept in the store, so that
+// a Retry-After deadline is shared even if the rate limit reset is unknown.
+const minBudgetTTL = time.Minute
+
+// takeScript takes ARGV[2] tokens from the bucket KEYS[1] at ARGV[1] (unix
+// milliseconds), unless that would leave less than the fraction ARGV[3] of the
+// limit. It returns 0 if the tokens were taken, and the milliseconds to wait for
+// the budget to reset otherwise. An unknown or outdated budget doesn't limit
+// requests, and neither does a cost greater than the limit, since there will
+// never be enough tokens for it.
+var takeScript = redis.NewScript(1, `
+local now = tonumber(ARGV[1])
+local cost = tonumber(ARGV[2])
+local b = redis.call('HMGET', KEYS[1], 'limit', 'remaining', 'reset', 'retry')
+local limit, remaining, reset, retry = tonumber(b[1]), tonumber(b[2]), tonumber(b[3]), tonumber(b[4])
+if retry and retry > now then
+	return retry - now
+end
+if not limit or not remaining or not reset or reset <= now or cost > limit then
+	return 0
+end
+if remaining < cost + math.floor(limit * tonumber(ARGV[3])) then
+	return reset - now
+end
+redis.call('HINCRBY', KEYS[1], 'remaining', -cost)
+return 0
+`)
+
+// observeScript fills the bucket KEYS[1] with the budget reported by a code host
+// response: the limit ARGV[1], the remaining tokens ARGV[2] and the reset ARGV[3]
+// (unix milliseconds), if known, and the Retry-After deadline ARGV[4] (unix
+// milliseconds), if any. Within the same rate limit window, the bucket keeps the
+// lower number of remaining tokens, since tokens taken for requests that are
+// still in flight aren't reflected by the response yet. The bucket expires after
+// ARGV[5] milliseconds, unless it already lives longer.
+var observeScript = redis.NewScript(1, `
+if ARGV[1] ~= '' then
+	local remaining = tonumber(ARGV[2])
+	local b = redis.call('HMGET', KEYS[1], 'remaining', 'reset')
+	if tonumber(b[2]) == tonumber(ARGV[3]) and tonumber(b[1]) and tonumber(b[1]) < remaining then
+		remaining = tonumber(b[1])
+	end
+	redis.call('HSET', KEYS[1], 'limit', ARGV[1], 'remaining', remaining, 'reset', ARGV[3])
+end
+if ARGV[4] ~= '' then
+	local retry = tonumber(redis.call('HGET', KEYS[1], 'retry'))
+	if not retry or retry < tonumber(ARGV[4]) then
+		redis.call('HSET', KEYS[1], 'retry', ARGV[4])
+	end
+end
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[5]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[5])
+end
+return 0
+`)
+
+// take takes cost tokens from the bucket for a request with priority p, and
+// returns how long to wait for the budget to reset if there aren't enough
+// tokens left. ok is false if the bucket isn't available.
+func (b *sharedBucket) take(ctx context.Context, key string, cost int, p Priority, now time.Time) (wait time.Duration, ok bool) {
+	pool, ok := b.kv.Pool()
+	if !ok {
+		return 0, false
+	}
+	c, err := pool.GetContext(ctx)
+	if err != nil {
+		return 0, false
+	}
+	defer c.Close()
+
+	ms, err := redis.Int64(takeScript.Do(c, key, now.UnixMilli(), cost, strconv.FormatFloat(p.reserve(), 'f', -1, 64)))
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// observe fills the bucket with the budget reported by a code host response.
+// Errors are ignored, since the monitors fall back to the budget they observed
+// themselves.
+func (b *sharedBucket) observe(key string, state budgetState, now time.Time) {
+	pool, ok := b.kv.Pool()
+	if !ok {
+		return
+	}
+	c := pool.Get()
+	defer c.Close()
+
+	var limit, remaining, reset, retry string
+	ttl := minBudgetTTL
+	if state.Known {
+		limit = strconv.Itoa(state.Limit)
+		remaining = strconv.Itoa(state.Remaining)
+		reset = strconv.FormatInt(state.Reset.UnixMilli(), 10)
+		if d := state.Reset.Sub(now); d > ttl {
+			ttl = d
+		}
+	}
+	if !state.Retry.IsZero() {
+		retry = strconv.FormatInt(state.Retry.UnixMilli(), 10)
+		if d := state.Retry.Sub(now); d > ttl {
+			ttl = d
+		}
+	}
+	_, _ = observeScript.Do(c, key, limit, remaining, reset, retry, ttl.Milliseconds())
+}
+
+// get returns the budget in the bucket. ok is false if the bucket isn't
+// available.
+func (b *sharedBucket) get(key string) (state budgetState, ok bool) {
+	pool, ok := b.kv.Pool()
+	if !ok {
+		return budgetState{}, false
+	}
+	c := pool.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("HMGET", key, "limit", "remaining", "reset", "retry"))
+	if err != nil || len(values) != 4 {
+		return budgetState{}, false
+	}
+	// Fields that aren't set are nil.
+	fields := make([]*int64, len(values))
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		n, err := redis.Int64(v, nil)
+		if err != nil {
+			return budgetState{}, false
+		}
+		fields[i] = &n
+	}
+	limit, remaining, reset, retry := fields[0], fields[1], fields[2], fields[3]
+	if limit != nil && remaining != nil && reset != nil {
+		state.Known = true
+		state.Limit = int(*limit)
+		state.Remaining = int(*remaining)
+		state.Reset = time.UnixMilli(*reset)
+	}
+	if retry != nil {
+		state.Retry = time.UnixMilli(*retry)
+	}
+	return state, true
+}
+
+var (
+	metricBudgetRemaining = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_internal_rate_limit_budget_remaining",
+		Help: "Remaining code host API rate limit budget, as reported by the latest response of the code host",
+	}, []string{"code_host", "resource"})
+
+	metricBudgetWaitDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_internal_rate_limit_budget_wait_duration",
+		Help:    "Time spent waiting for the code host API rate limit budget to reset",
+		Buckets: []float64{1, 5, 10, 30, 60, 300, 900, 1800, 3600},
+	}, []string{"code_host", "priority"})
+)
@@ -0,0 +1,152 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+func TestPriorityFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, PriorityInteractive, PriorityFromContext(ctx))
+
+	ctx = WithPriority(ctx, PriorityBackground)
+	assert.Equal(t, PriorityBackground, PriorityFromContext(ctx))
+}
+
+func TestMonitor_WaitForRateLimit_Priority(t *testing.T) {
+	ctx := context.Background()
+	m := &Monitor{
+		known:     true,
+		limit:     5000,
+		remaining: 1000,
+		reset:     time.Now().Add(30 * time.Minute),
+	}
+
+	// 10% of the budget is reserved for interactive requests, and 30% for
+	// interactive and sync requests.
+	assert.Equal(t, time.Duration(0), m.calcRateLimitWaitTime(ctx, 1, PriorityInteractive))
+	assert.Equal(t, time.Duration(0), m.calcRateLimitWaitTime(ctx, 1, PrioritySync))
+	assert.Greater(t, m.calcRateLimitWaitTime(ctx, 1, PriorityBackground), time.Duration(0))
+
+	m.remaining = 300
+	assert.Equal(t, time.Duration(0), m.calcRateLimitWaitTime(ctx, 1, PriorityInteractive))
+	assert.Greater(t, m.calcRateLimitWaitTime(ctx, 1, PrioritySync), time.Duration(0))
+}
+
+func TestMonitorRegistry_SharedBudget(t *testing.T) {
+	ctx := context.Background()
+	kv := redisKeyValueForTest(t)
+
+	// Two services using the same code host and token.
+	a := NewSharedMonitorRegistry(kv).GetOrSet("https://github.com", t.Name(), "rest", &Monitor{HeaderPrefix: "X-"})
+	b := NewSharedMonitorRegistry(kv).GetOrSet("https://github.com", t.Name(), "rest", &Monitor{HeaderPrefix: "X-"})
+	// A different token has its own budget.
+	c := NewSharedMonitorRegistry(kv).GetOrSet("https://github.com", t.Name()+"-other", "rest", &Monitor{HeaderPrefix: "X-"})
+
+	assert.Equal(t, time.Duration(0), b.calcRateLimitWaitTime(ctx, 1, PriorityInteractive))
+
+	a.Update(http.Header{
+		"X-Ratelimit-Limit":     []string{"100"},
+		"X-Ratelimit-Remaining": []string{"15"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+	})
+
+	// Tokens taken by one service are gone for the other one.
+	assert.Equal(t, time.Duration(0), b.calcRateLimitWaitTime(ctx, 5, PriorityInteractive))
+	remaining, _, _, known := a.Get()
+	assert.True(t, known)
+	assert.Equal(t, 10, remaining)
+
+	// A response of a request sent before the tokens were taken doesn't give
+	// them back.
+	a.Update(http.Header{
+		"X-Ratelimit-Limit":     []string{"100"},
+		"X-Ratelimit-Remaining": []string{"14"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+	})
+	remaining, _, _, _ = b.Get()
+	assert.Equal(t, 10, remaining)
+
+	// 10% of the budget is reserved for interactive requests of all services.
+	assert.Greater(t, a.calcRateLimitWaitTime(ctx, 1, PrioritySync), time.Duration(0))
+	assert.Equal(t, time.Duration(0), b.calcRateLimitWaitTime(ctx, 10, PriorityInteractive))
+	assert.Greater(t, a.calcRateLimitWaitTime(ctx, 1, PriorityInteractive), time.Duration(0))
+
+	assert.Equal(t, time.Duration(0), c.calcRateLimitWaitTime(ctx, 1, PriorityInteractive))
+}
+
+func TestMonitorRegistry_SharedRetryAfter(t *testing.T) {
+	ctx := context.Background()
+	kv := redisKeyValueForTest(t)
+
+	a := NewSharedMonitorRegistry(kv).GetOrSet("https://github.com", t.Name(), "rest", &Monitor{HeaderPrefix: "X-"})
+	b := NewSharedMonitorRegistry(kv).GetOrSet("https://github.com", t.Name(), "rest", &Monitor{HeaderPrefix: "X-"})
+
+	a.Update(http.Header{"Retry-After": []string{"60"}})
+
+	wait := b.calcRateLimitWaitTime(ctx, 1, PriorityInteractive)
+	assert.Greater(t, wait, 50*time.Second)
+	assert.LessOrEqual(t, wait, time.Minute)
+}
+
+func TestMonitorRegistry_NoRedis(t *testing.T) {
+	ctx := context.Background()
+
+	// Without redis, monitors fall back to the budget they observed themselves.
+	m := NewSharedMonitorRegistry(redispool.MemoryKeyValue()).GetOrSet("https://github.com", "hash", "rest", &Monitor{HeaderPrefix: "X-"})
+	m.Update(http.Header{
+		"X-Ratelimit-Limit":     []string{"5000"},
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+	})
+	assert.Greater(t, m.calcRateLimitWaitTime(ctx, 1, PriorityInteractive), time.Duration(0))
+}
+
+// redisKeyValueForTest returns a key value store backed by a local redis, and
+// skips the test if redis isn't available outside of CI.
+func redisKeyValueForTest(t *testing.T) redispool.KeyValue {
+	t.Helper()
+
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+
+	c := pool.Get()
+	defer c.Close()
+
+	// If we are not on CI, skip the test if our redis connection fails.
+	if os.Getenv("CI") == "" {
+		if _, err := c.Do("PING"); err != nil {
+			t.Skip("could not connect to redis", err)
+		}
+	}
+
+	keys, err := redis.Strings(c.Do("KEYS", "ratelimit:bucket:*"+t.Name()+"*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if _, err := c.Do("DEL", key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return redispool.RedisKeyValue(pool)
+}
@@ -7,12 +7,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
 
 // DefaultMonitorRegistry is the default global rate limit monitor registry. It will hold rate limit mappings
-// for each instance of our services.
-var DefaultMonitorRegistry = NewMonitorRegistry()
+// for each instance of our services. The rate limit budgets observed by its monitors are shared with all
+// other services through a token bucket in redis.
+var DefaultMonitorRegistry = NewSharedMonitorRegistry(redispool.Store)
 
 // NewMonitorRegistry creates a new empty registry.
 func NewMonitorRegistry() *MonitorRegistry {
@@ -21,12 +23,25 @@ func NewMonitorRegistry() *MonitorRegistry {
 	}
 }
 
+// NewSharedMonitorRegistry creates a new empty registry whose monitors take
+// the rate limit budget of each code host / token tuple from a token bucket in
+// the given key value store, shared with the monitors of other registries using
+// the same store.
+func NewSharedMonitorRegistry(kv redispool.KeyValue) *MonitorRegistry {
+	r := NewMonitorRegistry()
+	r.bucket = &sharedBucket{kv: kv}
+	return r
+}
+
 // MonitorRegistry keeps a mapping of external service URL to *Monitor.
 type MonitorRegistry struct {
 	mu sync.Mutex
 	// Monitor per code host / token tuple, keys are the normalized base URL for a
 	// code host, plus the token hash.
 	monitors map[string]*Monitor
+	// bucket is where monitors share their rate limit budgets, nil if they
+	// aren't shared.
+	bucket *sharedBucket
 }
 
 // GetOrSet fetches the rate limit monitor associated with the given code host /
@@ -41,6 +56,12 @@ func (r *MonitorRegistry) GetOrSet(baseURL, authHash, resource string, monitor *
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if _, ok := r.monitors[key]; !ok {
+		monitor.codeHost = baseURL
+		monitor.resource = resource
+		if r.bucket != nil {
+			monitor.bucket = r.bucket
+			monitor.bucketKey = "ratelimit:bucket:" + key
+		}
 		r.monitors[key] = monitor
 	}
 	return r.monitors[key]
@@ -75,13 +96,27 @@ type Monitor struct {
 	retry     time.Time         // deadline based on Retry-After HTTP response header value
 	collector *MetricsCollector // metrics collector
 
+	// Set by MonitorRegistry.GetOrSet. bucket is nil if the rate limit budget
+	// isn't shared with other services.
+	codeHost  string
+	resource  string
+	bucket    *sharedBucket
+	bucketKey string
+
 	clock func() time.Time
 }
 
 // Get reports the client's rate limit status (as of the last API response it received).
 func (c *Monitor) Get() (remaining int, reset, retry time.Duration, known bool) {
+	shared, ok := c.loadShared()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
+
+	if ok {
+		c.mergeShared(shared)
+	}
+
 	now := c.now()
 	return c.remaining, c.reset.Sub(now), c.retry.Sub(now), c.known
 }
@@ -112,9 +147,15 @@ func (c *Monitor) Get() (remaining int, reset, retry time.Duration, known bool)
 //
 // See https://developer.github.com/v4/guides/resource-limitations/#rate-limit.
 func (c *Monitor) RecommendedWaitForBackgroundOp(cost int) (timeRemaining time.Duration) {
+	shared, ok := c.loadShared()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if ok {
+		c.mergeShared(shared)
+	}
+
 	if c.collector != nil && c.collector.WaitDuration != nil {
 		defer func() {
 			c.collector.WaitDuration(timeRemaining)
@@ -162,7 +203,15 @@ func (c *Monitor) RecommendedWaitForBackgroundOp(cost int) (timeRemaining time.D
 	return timeRemaining * time.Duration(cost) / time.Duration(limitRemaining)
 }
 
-func (c *Monitor) calcRateLimitWaitTime(cost int) time.Duration {
+func (c *Monitor) calcRateLimitWaitTime(ctx context.Context, cost int, p Priority) time.Duration {
+	// The shared bucket accounts for the requests of all services, so the
+	// budget we observed ourselves is only used if it isn't available.
+	if c.bucket != nil {
+		if wait, ok := c.bucket.take(ctx, c.bucketKey, cost, p, c.now()); ok {
+			return wait
+		}
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -174,10 +223,10 @@ func (c *Monitor) calcRateLimitWaitTime(cost int) time.Duration {
 	}
 
 	// If the external rate limit is unknown,
-	// or if there are still enough remaining tokens,
+	// or if there are still enough remaining tokens besides the ones reserved for higher priorities,
 	// or if the cost is greater than the actual rate limit (in which case there will never be enough tokens),
 	// we don't wait.
-	if !c.known || c.remaining >= cost || cost > c.limit {
+	if !c.known || c.remaining >= cost+int(float64(c.limit)*p.reserve()) || cost > c.limit {
 		return time.Duration(0)
 	}
 
@@ -200,15 +249,34 @@ func (c *Monitor) calcRateLimitWaitTime(cost int) time.Duration {
 // For normal REST requests, this can usually be set to 1. For GraphQL requests, rate limit costs
 // can be more expensive and a different cost can be used. If there aren't enough rate limit
 // tokens available, then the function will sleep until the tokens reset.
+//
+// Requests with a lower priority (see WithPriority) leave a part of the tokens to requests with a
+// higher priority, and sleep until the tokens reset once only the reserved tokens are left.
+//
+// If the rate limit budget is shared with other services, the tokens are taken from the shared
+// budget, so WaitForRateLimit sleeps until it could take them.
 func (c *Monitor) WaitForRateLimit(ctx context.Context, cost int) bool {
-	sleepDuration := c.calcRateLimitWaitTime(cost)
+	p := PriorityFromContext(ctx)
 
-	if sleepDuration == 0 {
-		return false
-	}
+	waited := false
+	for {
+		sleepDuration := c.calcRateLimitWaitTime(ctx, cost, p)
+		if sleepDuration == 0 {
+			return waited
+		}
+
+		if c.codeHost != "" {
+			metricBudgetWaitDuration.WithLabelValues(c.codeHost, p.String()).Observe(sleepDuration.Seconds())
+		}
 
-	timeutil.SleepWithContext(ctx, sleepDuration)
-	return true
+		timeutil.SleepWithContext(ctx, sleepDuration)
+		waited = true
+
+		// Without a shared budget, there is nothing to take after sleeping.
+		if c.bucket == nil || ctx.Err() != nil {
+			return waited
+		}
+	}
 }
 
 // Update updates the monitor's rate limit information based on the HTTP response headers.
@@ -218,29 +286,41 @@ func (c *Monitor) Update(h http.Header) {
 		return
 	}
 
+	state, ok := c.update(h)
+	if ok && c.bucket != nil {
+		c.bucket.observe(c.bucketKey, state, c.now())
+	}
+}
+
+// update updates the monitor's rate limit information based on the HTTP
+// response headers, and returns the information to share with other services
+// and whether there is any.
+func (c *Monitor) update(h http.Header) (_ budgetState, ok bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	var state budgetState
 	retry, _ := strconv.ParseInt(h.Get("Retry-After"), 10, 64)
 	if retry > 0 {
 		c.retry = c.now().Add(time.Duration(retry) * time.Second)
+		state.Retry = c.retry
 	}
 
 	// See https://developer.github.com/v3/#rate-limiting.
 	limit, err := strconv.Atoi(h.Get(c.HeaderPrefix + "RateLimit-Limit"))
 	if err != nil {
 		c.known = false
-		return
+		return state, retry > 0
 	}
 	remaining, err := strconv.Atoi(h.Get(c.HeaderPrefix + "RateLimit-Remaining"))
 	if err != nil {
 		c.known = false
-		return
+		return state, retry > 0
 	}
 	resetAtSeconds, err := strconv.ParseInt(h.Get(c.HeaderPrefix+"RateLimit-Reset"), 10, 64)
 	if err != nil {
 		c.known = false
-		return
+		return state, retry > 0
 	}
 	c.known = true
 	c.limit = limit
@@ -250,6 +330,40 @@ func (c *Monitor) Update(h http.Header) {
 	if c.known && c.collector != nil && c.collector.Remaining != nil {
 		c.collector.Remaining(float64(c.remaining))
 	}
+	if c.codeHost != "" {
+		metricBudgetRemaining.WithLabelValues(c.codeHost, c.resource).Set(float64(c.remaining))
+	}
+
+	state.Known = true
+	state.Limit = c.limit
+	state.Remaining = c.remaining
+	state.Reset = c.reset
+	return state, true
+}
+
+// loadShared returns the rate limit budget shared by all services, if any. It
+// must not be called while holding c.mu, since it talks to redis.
+func (c *Monitor) loadShared() (budgetState, bool) {
+	if c.bucket == nil {
+		return budgetState{}, false
+	}
+	return c.bucket.get(c.bucketKey)
+}
+
+// mergeShared adopts the rate limit budget shared by all services, which
+// accounts for the requests of other services as well. The caller must hold
+// c.mu.
+func (c *Monitor) mergeShared(shared budgetState) {
+	if shared.Retry.After(c.retry) {
+		c.retry = shared.Retry
+	}
+	if !shared.Known {
+		return
+	}
+	c.known = true
+	c.limit = shared.Limit
+	c.remaining = shared.Remaining
+	c.reset = shared.Reset
 }
 
 // SetCollector sets the metric collector.
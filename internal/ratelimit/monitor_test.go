@@ -1,6 +1,7 @@
 package ratelimit
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"testing"
@@ -67,7 +68,7 @@ func TestMonitor_WaitForRateLimit(t *testing.T) {
 			reset:     time.Now().Add(30 * time.Minute),
 		}
 
-		sleepDuration := m.calcRateLimitWaitTime(5)
+		sleepDuration := m.calcRateLimitWaitTime(context.Background(), 5, PriorityInteractive)
 
 		assert.Equal(t, time.Duration(0), sleepDuration)
 	})
@@ -79,7 +80,7 @@ func TestMonitor_WaitForRateLimit(t *testing.T) {
 			reset:     time.Now().Add(30 * time.Minute),
 		}
 
-		sleepDuration := m.calcRateLimitWaitTime(10)
+		sleepDuration := m.calcRateLimitWaitTime(context.Background(), 10, PriorityInteractive)
 
 		assert.Equal(t, time.Duration(0), sleepDuration)
 	})
@@ -91,7 +92,7 @@ func TestMonitor_WaitForRateLimit(t *testing.T) {
 			reset:     time.Now().Add(30 * time.Minute),
 		}
 
-		sleepDuration := m.calcRateLimitWaitTime(11)
+		sleepDuration := m.calcRateLimitWaitTime(context.Background(), 11, PriorityInteractive)
 
 		// Assert that the sleep duration is about 30 minutes (slightly inaccurate, so checking between 29 and 30 minutes)
 		assert.True(t, time.Duration(29)*time.Minute < sleepDuration)
@@ -5,3 +5,17 @@ package rbac
 const BatchChangesReadPermission string = "BATCH_CHANGES#READ"
 
 const BatchChangesWritePermission string = "BATCH_CHANGES#WRITE"
+
+const CodeInsightsWritePermission string = "CODE_INSIGHTS#WRITE"
+
+const CodeMonitorsWritePermission string = "CODE_MONITORS#WRITE"
+
+const NotebooksWritePermission string = "NOTEBOOKS#WRITE"
+
+const SearchContextsWritePermission string = "SEARCH_CONTEXTS#WRITE"
+
+const CodeIntelUploadPermission string = "CODE_INTEL#UPLOAD"
+
+const CodeIntelAutoIndexingWritePermission string = "CODE_INTEL#AUTO_INDEXING_WRITE"
+
+const CodyAccessPermission string = "CODY#ACCESS"
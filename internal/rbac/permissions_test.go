@@ -124,3 +124,17 @@ func TestComparePermissions(t *testing.T) {
 }
 
 func sortDeletePermissionOptSlice(a, b database.DeletePermissionOpts) bool { return a.ID < b.ID }
+
+func TestRBACSchemaExcludeFromUserRole(t *testing.T) {
+	permissions := make(map[string]struct{})
+	for _, n := range RBACSchema.Namespaces {
+		for _, a := range n.Actions {
+			permissions[(&types.Permission{Namespace: n.Name, Action: a}).DisplayName()] = struct{}{}
+		}
+	}
+
+	require.Contains(t, RBACSchema.ExcludeFromUserRole, CodeIntelAutoIndexingWritePermission)
+	for _, name := range RBACSchema.ExcludeFromUserRole {
+		require.Contains(t, permissions, name, "excluded permission is not in the schema")
+	}
+}
@@ -6,6 +6,9 @@ import "github.com/sourcegraph/sourcegraph/internal/types"
 // the RBAC system.
 type Schema struct {
 	Namespaces []Namespace `json:"namespaces"`
+	// ExcludeFromUserRole lists the display names of the permissions that are
+	// not granted to the USER system role when they are created.
+	ExcludeFromUserRole []string `json:"excludeFromUserRole" yaml:"excludeFromUserRole"`
 }
 
 // Namespace represents a feature to be guarded by RBAC. (example: Batch Changes, Code Insights e.t.c)
@@ -158,7 +158,7 @@ func (s *AzureDevOpsSource) makeRepo(p azuredevops.Repository) (*types.Repo, err
 	}
 
 	name := path.Join(fullURL.Host, fullURL.Path)
-	return &types.Repo{
+	repo := &types.Repo{
 		Name: api.RepoName(name),
 		URI:  name,
 		Fork: p.IsFork,
@@ -175,5 +175,9 @@ func (s *AzureDevOpsSource) makeRepo(p azuredevops.Repository) (*types.Repo, err
 		},
 		Metadata: p,
 		Private:  p.Project.Visibility == "private",
-	}, nil
+	}
+	if s.config.RepositoryMetadata != nil {
+		repo.KeyValuePairs = azureDevOpsMetadataKeys(s.config.RepositoryMetadata).kvps(azureDevOpsRepoMetadata(p))
+	}
+	return repo, nil
 }
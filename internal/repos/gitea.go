@@ -0,0 +1,179 @@
+package repos
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/goware/urlx"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea (or Forgejo) connection
+// configured in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc       *types.ExternalService
+	cli       *gitea.Client
+	config    *schema.GiteaConnection
+	serviceID string
+	exclude   excludeFunc
+}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gitea.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		if r.Archived {
+			eb.Generic(func(repo any) bool {
+				r, ok := repo.(*gitea.Repository)
+				return ok && r.Archived
+			})
+		}
+		if r.Forks {
+			eb.Generic(func(repo any) bool {
+				r, ok := repo.(*gitea.Repository)
+				return ok && r.Fork
+			})
+		}
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+	}
+
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:       svc,
+		cli:       cli,
+		config:    &c,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		exclude:   exclude,
+	}, nil
+}
+
+// CheckConnection fetches the user the configured token belongs to, which fails
+// if the instance is unreachable or the token is invalid.
+func (s *GiteaSource) CheckConnection(ctx context.Context) error {
+	_, err := s.cli.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config. Repositories matched by more than one of the orgs, users and
+// repositoryQuery options are only sent once.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]bool)
+	emit := func(it *iterator.Iterator[*gitea.Repository]) {
+		for it.Next() {
+			r := it.Current()
+			if seen[r.ID] || s.excludes(r) {
+				continue
+			}
+			seen[r.ID] = true
+
+			repo, err := s.makeRepo(r)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				continue
+			}
+			results <- SourceResult{Source: s, Repo: repo}
+		}
+
+		if err := it.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+		}
+	}
+
+	for _, org := range s.config.Orgs {
+		emit(s.cli.ListOrgRepos(ctx, org))
+	}
+
+	for _, user := range s.config.Users {
+		emit(s.cli.ListUserRepos(ctx, user))
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		if query == "all" {
+			query = ""
+		}
+		emit(s.cli.SearchRepos(ctx, query))
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	return s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10)) || s.exclude(r)
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) (*types.Repo, error) {
+	urn := s.svc.URN()
+
+	htmlURL, err := urlx.Parse(r.HTMLURL)
+	if err != nil {
+		return nil, err
+	}
+
+	name := path.Join(htmlURL.Host, htmlURL.Path)
+
+	return &types.Repo{
+		Name:        api.RepoName(name),
+		URI:         name,
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Private:     r.Private || r.Internal,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: r.CloneURL,
+			},
+		},
+		Metadata: r,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	var srv *httptest.Server
+	repo := func(id int64, fullName string, fork, archived, private bool) *gitea.Repository {
+		return &gitea.Repository{
+			ID:       id,
+			FullName: fullName,
+			Fork:     fork,
+			Archived: archived,
+			Private:  private,
+			HTMLURL:  srv.URL + "/" + fullName,
+			CloneURL: srv.URL + "/" + fullName + ".git",
+		}
+	}
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+
+		var resp any
+		switch r.URL.Path {
+		case "/api/v1/orgs/acme/repos":
+			resp = []*gitea.Repository{
+				repo(1, "acme/api", false, false, true),
+				repo(2, "acme/legacy", false, true, false),
+				repo(3, "acme/secret-plans", false, false, true),
+			}
+		case "/api/v1/users/alice/repos":
+			resp = []*gitea.Repository{
+				repo(4, "alice/dotfiles", false, false, false),
+				repo(5, "alice/api", true, false, false),
+			}
+		case "/api/v1/repos/search":
+			resp = map[string]any{"ok": true, "data": []*gitea.Repository{
+				repo(1, "acme/api", false, false, true),
+				repo(6, "bob/tools", false, false, false),
+			}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	conf := &schema.GiteaConnection{
+		Url:             srv.URL,
+		Token:           "secret",
+		Orgs:            []string{"acme"},
+		Users:           []string{"alice"},
+		RepositoryQuery: []string{"all"},
+		Exclude: []*schema.ExcludedGiteaRepo{
+			{Archived: true},
+			{Forks: true},
+			{Pattern: "^acme/secret-"},
+			{Id: 6},
+		},
+	}
+	svc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	ctx := context.Background()
+	src, err := NewGiteaSource(ctx, svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		ID      string
+		Private bool
+	}
+	var have []result
+	for _, r := range repos {
+		if r.ExternalRepo.ServiceType != extsvc.TypeGitea {
+			t.Errorf("unexpected service type %q", r.ExternalRepo.ServiceType)
+		}
+		have = append(have, result{ID: r.ExternalRepo.ID, Private: r.Private})
+	}
+	sort.Slice(have, func(i, j int) bool { return have[i].ID < have[j].ID })
+
+	want := []result{
+		{ID: "1", Private: true},
+		{ID: "4", Private: false},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
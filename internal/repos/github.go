@@ -294,7 +294,9 @@ func (s *GitHubSource) ListRepos(ctx context.Context, results chan SourceResult)
 
 		s.logger.Debug("unfiltered", log.String("repo", res.repo.NameWithOwner))
 		if !seen[res.repo.DatabaseID] && !s.excludes(res.repo) {
-			results <- SourceResult{Source: s, Repo: s.makeRepo(res.repo)}
+			repo := s.makeRepo(res.repo)
+			s.syncCustomProperties(ctx, repo, res.repo)
+			results <- SourceResult{Source: s, Repo: repo}
 			s.logger.Debug("sent to result", log.String("repo", res.repo.NameWithOwner))
 			seen[res.repo.DatabaseID] = true
 		}
@@ -415,7 +417,9 @@ func (s *GitHubSource) GetRepo(ctx context.Context, nameWithOwner string) (*type
 	if err != nil {
 		return nil, err
 	}
-	return s.makeRepo(r), nil
+	repo := s.makeRepo(r)
+	s.syncCustomProperties(ctx, repo, r)
+	return repo, nil
 }
 
 func sanitizeToUTF8(s string) string {
@@ -429,7 +433,7 @@ func (s *GitHubSource) makeRepo(r *github.Repository) *types.Repo {
 	// so we don't want to store it.
 	metadata.ViewerPermission = ""
 	metadata.Description = sanitizeToUTF8(metadata.Description)
-	return &types.Repo{
+	repo := &types.Repo{
 		Name: reposource.GitHubRepoName(
 			s.config.RepositoryPathPattern,
 			s.originalHostname,
@@ -454,6 +458,32 @@ func (s *GitHubSource) makeRepo(r *github.Repository) *types.Repo {
 		},
 		Metadata: &metadata,
 	}
+	if s.config.RepositoryMetadata != nil {
+		repo.KeyValuePairs = gitHubMetadataKeys(s.config.RepositoryMetadata).kvps(gitHubRepoMetadata(r))
+	}
+	return repo
+}
+
+// syncCustomProperties adds the key-value pairs of the custom properties of r
+// to repo, if any are configured to be synced. Since they require an extra
+// request per repository, custom properties aren't fetched otherwise. If they
+// can't be fetched, no key-value pairs of repo are synced, so that the ones
+// synced previously are kept.
+func (s *GitHubSource) syncCustomProperties(ctx context.Context, repo *types.Repo, r *github.Repository) {
+	if s.config.RepositoryMetadata == nil || len(s.config.RepositoryMetadata.CustomProperties) == 0 {
+		return
+	}
+
+	properties, err := s.v3Client.ListCustomPropertiesOnRepository(ctx, r.NameWithOwner)
+	if err != nil {
+		s.logger.Warn("failed to list custom properties", log.String("repo", r.NameWithOwner), log.Error(err))
+		repo.KeyValuePairs = nil
+		return
+	}
+
+	m := gitHubRepoMetadata(r)
+	m.CustomProperties = properties
+	repo.KeyValuePairs = gitHubMetadataKeys(s.config.RepositoryMetadata).kvps(m)
 }
 
 // remoteURL returns the repository's Git remote URL
@@ -204,7 +204,7 @@ func (s GitLabSource) ExternalServices() types.ExternalServices {
 
 func (s GitLabSource) makeRepo(proj *gitlab.Project) *types.Repo {
 	urn := s.svc.URN()
-	return &types.Repo{
+	repo := &types.Repo{
 		Name: reposource.GitLabRepoName(
 			s.config.RepositoryPathPattern,
 			s.baseURL.Hostname(),
@@ -231,6 +231,10 @@ func (s GitLabSource) makeRepo(proj *gitlab.Project) *types.Repo {
 		},
 		Metadata: proj,
 	}
+	if s.config.RepositoryMetadata != nil {
+		repo.KeyValuePairs = gitLabMetadataKeys(s.config.RepositoryMetadata).kvps(gitLabRepoMetadata(proj))
+	}
+	return repo
 }
 
 // remoteURL returns the GitLab project's Git remote URL
@@ -0,0 +1,113 @@
+package repos
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// repoMetadata is the code host metadata of a repository that can be synced
+// into its key-value pairs.
+type repoMetadata struct {
+	Topics           []string
+	Language         string
+	Visibility       string
+	Project          string
+	CustomProperties map[string]string
+}
+
+// metadataKeys maps each kind of repository metadata to the key it is synced
+// under. Metadata with an empty key isn't synced.
+type metadataKeys struct {
+	Topics           string // key prefix of topics
+	Language         string
+	Visibility       string
+	Project          string
+	CustomProperties map[string]string
+}
+
+// kvps returns the key-value pairs that the given metadata is synced into.
+// The result is never nil, so that key-value pairs synced previously are
+// removed when the metadata is gone.
+func (k metadataKeys) kvps(m repoMetadata) map[string]*string {
+	kvps := make(map[string]*string)
+	set := func(key, value string) {
+		if key != "" && value != "" {
+			kvps[key] = &value
+		}
+	}
+
+	if k.Topics != "" {
+		for _, topic := range m.Topics {
+			if topic != "" {
+				kvps[k.Topics+topic] = nil
+			}
+		}
+	}
+	set(k.Language, m.Language)
+	set(k.Visibility, m.Visibility)
+	set(k.Project, m.Project)
+	for name, key := range k.CustomProperties {
+		set(key, m.CustomProperties[name])
+	}
+
+	return kvps
+}
+
+func gitHubMetadataKeys(c *schema.GitHubRepositoryMetadata) metadataKeys {
+	return metadataKeys{
+		Topics:           c.Topics,
+		Language:         c.Language,
+		Visibility:       c.Visibility,
+		CustomProperties: c.CustomProperties,
+	}
+}
+
+func gitHubRepoMetadata(r *github.Repository) repoMetadata {
+	m := repoMetadata{
+		Visibility: string(r.Visibility),
+	}
+	for _, node := range r.RepositoryTopics.Nodes {
+		m.Topics = append(m.Topics, node.Topic.Name)
+	}
+	if r.PrimaryLanguage != nil {
+		m.Language = r.PrimaryLanguage.Name
+	}
+	// The visibility is only fetched if internal repositories are enabled.
+	if m.Visibility == "" {
+		m.Visibility = string(github.VisibilityPublic)
+		if r.IsPrivate {
+			m.Visibility = string(github.VisibilityPrivate)
+		}
+	}
+	return m
+}
+
+func gitLabMetadataKeys(c *schema.GitLabRepositoryMetadata) metadataKeys {
+	return metadataKeys{
+		Topics:     c.Topics,
+		Visibility: c.Visibility,
+	}
+}
+
+func gitLabRepoMetadata(p *gitlab.Project) repoMetadata {
+	return repoMetadata{
+		Topics:     p.Topics,
+		Visibility: string(p.Visibility),
+	}
+}
+
+func azureDevOpsMetadataKeys(c *schema.AzureDevOpsRepositoryMetadata) metadataKeys {
+	return metadataKeys{
+		Project:    c.Project,
+		Visibility: c.Visibility,
+	}
+}
+
+func azureDevOpsRepoMetadata(r azuredevops.Repository) repoMetadata {
+	return repoMetadata{
+		Project:    r.Project.Name,
+		Visibility: r.Project.Visibility,
+	}
+}
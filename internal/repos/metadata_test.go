@@ -0,0 +1,85 @@
+package repos
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMetadataKeys_KVPs(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	m := repoMetadata{
+		Topics:     []string{"go", "search"},
+		Language:   "Go",
+		Visibility: "internal",
+		CustomProperties: map[string]string{
+			"team":  "search",
+			"other": "ignored",
+		},
+	}
+
+	for _, tc := range []struct {
+		name string
+		keys metadataKeys
+		want map[string]*string
+	}{
+		{
+			name: "nothing mapped",
+			want: map[string]*string{},
+		},
+		{
+			name: "everything mapped",
+			keys: metadataKeys{
+				Topics:           "topic-",
+				Language:         "language",
+				Visibility:       "visibility",
+				Project:          "project",
+				CustomProperties: map[string]string{"team": "owner", "missing": "missing"},
+			},
+			want: map[string]*string{
+				"topic-go":     nil,
+				"topic-search": nil,
+				"language":     strPtr("Go"),
+				"visibility":   strPtr("internal"),
+				"owner":        strPtr("search"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.keys.kvps(m)); diff != "" {
+				t.Fatalf("unexpected key-value pairs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitHubRepoMetadata(t *testing.T) {
+	r := &github.Repository{
+		IsPrivate: true,
+		RepositoryTopics: github.RepositoryTopics{Nodes: []github.RepositoryTopic{
+			{Topic: github.Topic{Name: "go"}},
+		}},
+		PrimaryLanguage: &github.Language{Name: "Go"},
+	}
+
+	keys := gitHubMetadataKeys(&schema.GitHubRepositoryMetadata{
+		Topics:     "topic-",
+		Language:   "language",
+		Visibility: "visibility",
+	})
+	have := keys.kvps(gitHubRepoMetadata(r))
+
+	private, golang := "private", "Go"
+	want := map[string]*string{
+		"topic-go":   nil,
+		"language":   &golang,
+		"visibility": &private,
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected key-value pairs (-want +got):\n%s", diff)
+	}
+}
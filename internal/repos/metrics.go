@@ -77,6 +77,11 @@ var (
 		Help: "Incremented each time the scheduler updates a managed repository due to hitting a deadline.",
 	})
 
+	schedWebhookCovered = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_sched_webhook_covered",
+		Help: "Incremented each time the scheduler skips an update of a managed repository that recently received a push webhook.",
+	})
+
 	schedManualFetch = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "src_repoupdater_sched_manual_fetch",
 		Help: "Incremented each time the scheduler updates a repository due to user traffic.",
@@ -9,14 +9,1903 @@ package repos
 import (
 	"context"
 	"sync"
+	"time"
 
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	database "github.com/sourcegraph/sourcegraph/internal/database"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	protocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	trace "github.com/sourcegraph/sourcegraph/internal/trace"
 	types "github.com/sourcegraph/sourcegraph/internal/types"
 )
 
+// MockSchedulerStore is a mock implementation of the SchedulerStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/repos) used for unit testing.
+type MockSchedulerStore struct {
+	// CountsFunc is an instance of a mock function object controlling the
+	// behavior of the method Counts.
+	CountsFunc *SchedulerStoreCountsFunc
+	// DeleteFinishedJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteFinishedJobs.
+	DeleteFinishedJobsFunc *SchedulerStoreDeleteFinishedJobsFunc
+	// EnqueueFunc is an instance of a mock function object controlling the
+	// behavior of the method Enqueue.
+	EnqueueFunc *SchedulerStoreEnqueueFunc
+	// EnqueueDueFunc is an instance of a mock function object controlling
+	// the behavior of the method EnqueueDue.
+	EnqueueDueFunc *SchedulerStoreEnqueueDueFunc
+	// IntervalFunc is an instance of a mock function object controlling the
+	// behavior of the method Interval.
+	IntervalFunc *SchedulerStoreIntervalFunc
+	// ListQueueFunc is an instance of a mock function object controlling
+	// the behavior of the method ListQueue.
+	ListQueueFunc *SchedulerStoreListQueueFunc
+	// ListRepoIDsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListRepoIDs.
+	ListRepoIDsFunc *SchedulerStoreListRepoIDsFunc
+	// ListScheduleFunc is an instance of a mock function object controlling
+	// the behavior of the method ListSchedule.
+	ListScheduleFunc *SchedulerStoreListScheduleFunc
+	// PrioritiseUnclonedFunc is an instance of a mock function object
+	// controlling the behavior of the method PrioritiseUncloned.
+	PrioritiseUnclonedFunc *SchedulerStorePrioritiseUnclonedFunc
+	// RecordChangeFunc is an instance of a mock function object controlling
+	// the behavior of the method RecordChange.
+	RecordChangeFunc *SchedulerStoreRecordChangeFunc
+	// RecordWebhookFunc is an instance of a mock function object
+	// controlling the behavior of the method RecordWebhook.
+	RecordWebhookFunc *SchedulerStoreRecordWebhookFunc
+	// RemoveFunc is an instance of a mock function object controlling the
+	// behavior of the method Remove.
+	RemoveFunc *SchedulerStoreRemoveFunc
+	// ScheduleFunc is an instance of a mock function object controlling the
+	// behavior of the method Schedule.
+	ScheduleFunc *SchedulerStoreScheduleFunc
+	// ScheduleInfoFunc is an instance of a mock function object controlling
+	// the behavior of the method ScheduleInfo.
+	ScheduleInfoFunc *SchedulerStoreScheduleInfoFunc
+	// UpdateIntervalFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateInterval.
+	UpdateIntervalFunc *SchedulerStoreUpdateIntervalFunc
+}
+
+// NewMockSchedulerStore creates a new mock of the SchedulerStore interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockSchedulerStore() *MockSchedulerStore {
+	return &MockSchedulerStore{
+		CountsFunc: &SchedulerStoreCountsFunc{
+			defaultHook: func(context.Context) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
+		DeleteFinishedJobsFunc: &SchedulerStoreDeleteFinishedJobsFunc{
+			defaultHook: func(context.Context, time.Duration) (r0 error) {
+				return
+			},
+		},
+		EnqueueFunc: &SchedulerStoreEnqueueFunc{
+			defaultHook: func(context.Context, []api.RepoID, priority) (r0 error) {
+				return
+			},
+		},
+		EnqueueDueFunc: &SchedulerStoreEnqueueDueFunc{
+			defaultHook: func(context.Context, int, time.Duration) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
+		IntervalFunc: &SchedulerStoreIntervalFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 time.Duration, r1 bool, r2 error) {
+				return
+			},
+		},
+		ListQueueFunc: &SchedulerStoreListQueueFunc{
+			defaultHook: func(context.Context) (r0 []*RepoUpdateJob, r1 error) {
+				return
+			},
+		},
+		ListRepoIDsFunc: &SchedulerStoreListRepoIDsFunc{
+			defaultHook: func(context.Context) (r0 []api.RepoID, r1 error) {
+				return
+			},
+		},
+		ListScheduleFunc: &SchedulerStoreListScheduleFunc{
+			defaultHook: func(context.Context) (r0 []*ScheduledRepoUpdate, r1 error) {
+				return
+			},
+		},
+		PrioritiseUnclonedFunc: &SchedulerStorePrioritiseUnclonedFunc{
+			defaultHook: func(context.Context, []api.RepoID) (r0 error) {
+				return
+			},
+		},
+		RecordChangeFunc: &SchedulerStoreRecordChangeFunc{
+			defaultHook: func(context.Context, api.RepoID, time.Time) (r0 time.Duration, r1 bool, r2 error) {
+				return
+			},
+		},
+		RecordWebhookFunc: &SchedulerStoreRecordWebhookFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 error) {
+				return
+			},
+		},
+		RemoveFunc: &SchedulerStoreRemoveFunc{
+			defaultHook: func(context.Context, []api.RepoID) (r0 error) {
+				return
+			},
+		},
+		ScheduleFunc: &SchedulerStoreScheduleFunc{
+			defaultHook: func(context.Context, []api.RepoID) (r0 error) {
+				return
+			},
+		},
+		ScheduleInfoFunc: &SchedulerStoreScheduleInfoFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 *protocol.RepoUpdateSchedulerInfoResult, r1 error) {
+				return
+			},
+		},
+		UpdateIntervalFunc: &SchedulerStoreUpdateIntervalFunc{
+			defaultHook: func(context.Context, api.RepoID, time.Duration) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSchedulerStore creates a new mock of the SchedulerStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSchedulerStore() *MockSchedulerStore {
+	return &MockSchedulerStore{
+		CountsFunc: &SchedulerStoreCountsFunc{
+			defaultHook: func(context.Context) (int, int, error) {
+				panic("unexpected invocation of MockSchedulerStore.Counts")
+			},
+		},
+		DeleteFinishedJobsFunc: &SchedulerStoreDeleteFinishedJobsFunc{
+			defaultHook: func(context.Context, time.Duration) error {
+				panic("unexpected invocation of MockSchedulerStore.DeleteFinishedJobs")
+			},
+		},
+		EnqueueFunc: &SchedulerStoreEnqueueFunc{
+			defaultHook: func(context.Context, []api.RepoID, priority) error {
+				panic("unexpected invocation of MockSchedulerStore.Enqueue")
+			},
+		},
+		EnqueueDueFunc: &SchedulerStoreEnqueueDueFunc{
+			defaultHook: func(context.Context, int, time.Duration) (int, int, error) {
+				panic("unexpected invocation of MockSchedulerStore.EnqueueDue")
+			},
+		},
+		IntervalFunc: &SchedulerStoreIntervalFunc{
+			defaultHook: func(context.Context, api.RepoID) (time.Duration, bool, error) {
+				panic("unexpected invocation of MockSchedulerStore.Interval")
+			},
+		},
+		ListQueueFunc: &SchedulerStoreListQueueFunc{
+			defaultHook: func(context.Context) ([]*RepoUpdateJob, error) {
+				panic("unexpected invocation of MockSchedulerStore.ListQueue")
+			},
+		},
+		ListRepoIDsFunc: &SchedulerStoreListRepoIDsFunc{
+			defaultHook: func(context.Context) ([]api.RepoID, error) {
+				panic("unexpected invocation of MockSchedulerStore.ListRepoIDs")
+			},
+		},
+		ListScheduleFunc: &SchedulerStoreListScheduleFunc{
+			defaultHook: func(context.Context) ([]*ScheduledRepoUpdate, error) {
+				panic("unexpected invocation of MockSchedulerStore.ListSchedule")
+			},
+		},
+		PrioritiseUnclonedFunc: &SchedulerStorePrioritiseUnclonedFunc{
+			defaultHook: func(context.Context, []api.RepoID) error {
+				panic("unexpected invocation of MockSchedulerStore.PrioritiseUncloned")
+			},
+		},
+		RecordChangeFunc: &SchedulerStoreRecordChangeFunc{
+			defaultHook: func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error) {
+				panic("unexpected invocation of MockSchedulerStore.RecordChange")
+			},
+		},
+		RecordWebhookFunc: &SchedulerStoreRecordWebhookFunc{
+			defaultHook: func(context.Context, api.RepoID) error {
+				panic("unexpected invocation of MockSchedulerStore.RecordWebhook")
+			},
+		},
+		RemoveFunc: &SchedulerStoreRemoveFunc{
+			defaultHook: func(context.Context, []api.RepoID) error {
+				panic("unexpected invocation of MockSchedulerStore.Remove")
+			},
+		},
+		ScheduleFunc: &SchedulerStoreScheduleFunc{
+			defaultHook: func(context.Context, []api.RepoID) error {
+				panic("unexpected invocation of MockSchedulerStore.Schedule")
+			},
+		},
+		ScheduleInfoFunc: &SchedulerStoreScheduleInfoFunc{
+			defaultHook: func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+				panic("unexpected invocation of MockSchedulerStore.ScheduleInfo")
+			},
+		},
+		UpdateIntervalFunc: &SchedulerStoreUpdateIntervalFunc{
+			defaultHook: func(context.Context, api.RepoID, time.Duration) error {
+				panic("unexpected invocation of MockSchedulerStore.UpdateInterval")
+			},
+		},
+	}
+}
+
+// NewMockSchedulerStoreFrom creates a new mock of the MockSchedulerStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSchedulerStoreFrom(i SchedulerStore) *MockSchedulerStore {
+	return &MockSchedulerStore{
+		CountsFunc: &SchedulerStoreCountsFunc{
+			defaultHook: i.Counts,
+		},
+		DeleteFinishedJobsFunc: &SchedulerStoreDeleteFinishedJobsFunc{
+			defaultHook: i.DeleteFinishedJobs,
+		},
+		EnqueueFunc: &SchedulerStoreEnqueueFunc{
+			defaultHook: i.Enqueue,
+		},
+		EnqueueDueFunc: &SchedulerStoreEnqueueDueFunc{
+			defaultHook: i.EnqueueDue,
+		},
+		IntervalFunc: &SchedulerStoreIntervalFunc{
+			defaultHook: i.Interval,
+		},
+		ListQueueFunc: &SchedulerStoreListQueueFunc{
+			defaultHook: i.ListQueue,
+		},
+		ListRepoIDsFunc: &SchedulerStoreListRepoIDsFunc{
+			defaultHook: i.ListRepoIDs,
+		},
+		ListScheduleFunc: &SchedulerStoreListScheduleFunc{
+			defaultHook: i.ListSchedule,
+		},
+		PrioritiseUnclonedFunc: &SchedulerStorePrioritiseUnclonedFunc{
+			defaultHook: i.PrioritiseUncloned,
+		},
+		RecordChangeFunc: &SchedulerStoreRecordChangeFunc{
+			defaultHook: i.RecordChange,
+		},
+		RecordWebhookFunc: &SchedulerStoreRecordWebhookFunc{
+			defaultHook: i.RecordWebhook,
+		},
+		RemoveFunc: &SchedulerStoreRemoveFunc{
+			defaultHook: i.Remove,
+		},
+		ScheduleFunc: &SchedulerStoreScheduleFunc{
+			defaultHook: i.Schedule,
+		},
+		ScheduleInfoFunc: &SchedulerStoreScheduleInfoFunc{
+			defaultHook: i.ScheduleInfo,
+		},
+		UpdateIntervalFunc: &SchedulerStoreUpdateIntervalFunc{
+			defaultHook: i.UpdateInterval,
+		},
+	}
+}
+
+// SchedulerStoreCountsFunc describes the behavior when the Counts method of
+// the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreCountsFunc struct {
+	defaultHook func(context.Context) (int, int, error)
+	hooks       []func(context.Context) (int, int, error)
+	history     []SchedulerStoreCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// Counts delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSchedulerStore) Counts(v0 context.Context) (int, int, error) {
+	r0, r1, r2 := m.CountsFunc.nextHook()(v0)
+	m.CountsFunc.appendCall(SchedulerStoreCountsFuncCall{v0, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Counts method of the
+// parent MockSchedulerStore instance is invoked and the hook queue is
+// empty.
+func (f *SchedulerStoreCountsFunc) SetDefaultHook(hook func(context.Context) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Counts method of the parent MockSchedulerStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SchedulerStoreCountsFunc) PushHook(hook func(context.Context) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreCountsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreCountsFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *SchedulerStoreCountsFunc) nextHook() func(context.Context) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreCountsFunc) appendCall(r0 SchedulerStoreCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreCountsFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreCountsFunc) History() []SchedulerStoreCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreCountsFuncCall is an object that describes an invocation of
+// method Counts on an instance of MockSchedulerStore.
+type SchedulerStoreCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// SchedulerStoreDeleteFinishedJobsFunc describes the behavior when the
+// DeleteFinishedJobs method of the parent MockSchedulerStore instance is
+// invoked.
+type SchedulerStoreDeleteFinishedJobsFunc struct {
+	defaultHook func(context.Context, time.Duration) error
+	hooks       []func(context.Context, time.Duration) error
+	history     []SchedulerStoreDeleteFinishedJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteFinishedJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSchedulerStore) DeleteFinishedJobs(v0 context.Context, v1 time.Duration) error {
+	r0 := m.DeleteFinishedJobsFunc.nextHook()(v0, v1)
+	m.DeleteFinishedJobsFunc.appendCall(SchedulerStoreDeleteFinishedJobsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteFinishedJobs
+// method of the parent MockSchedulerStore instance is invoked and the hook
+// queue is empty.
+func (f *SchedulerStoreDeleteFinishedJobsFunc) SetDefaultHook(hook func(context.Context, time.Duration) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteFinishedJobs method of the parent MockSchedulerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SchedulerStoreDeleteFinishedJobsFunc) PushHook(hook func(context.Context, time.Duration) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreDeleteFinishedJobsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreDeleteFinishedJobsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, time.Duration) error {
+		return r0
+	})
+}
+
+func (f *SchedulerStoreDeleteFinishedJobsFunc) nextHook() func(context.Context, time.Duration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreDeleteFinishedJobsFunc) appendCall(r0 SchedulerStoreDeleteFinishedJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreDeleteFinishedJobsFuncCall
+// objects describing the invocations of this function.
+func (f *SchedulerStoreDeleteFinishedJobsFunc) History() []SchedulerStoreDeleteFinishedJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreDeleteFinishedJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreDeleteFinishedJobsFuncCall is an object that describes an
+// invocation of method DeleteFinishedJobs on an instance of
+// MockSchedulerStore.
+type SchedulerStoreDeleteFinishedJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreDeleteFinishedJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreDeleteFinishedJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SchedulerStoreEnqueueFunc describes the behavior when the Enqueue method
+// of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreEnqueueFunc struct {
+	defaultHook func(context.Context, []api.RepoID, priority) error
+	hooks       []func(context.Context, []api.RepoID, priority) error
+	history     []SchedulerStoreEnqueueFuncCall
+	mutex       sync.Mutex
+}
+
+// Enqueue delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSchedulerStore) Enqueue(v0 context.Context, v1 []api.RepoID, v2 priority) error {
+	r0 := m.EnqueueFunc.nextHook()(v0, v1, v2)
+	m.EnqueueFunc.appendCall(SchedulerStoreEnqueueFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Enqueue method of
+// the parent MockSchedulerStore instance is invoked and the hook queue is
+// empty.
+func (f *SchedulerStoreEnqueueFunc) SetDefaultHook(hook func(context.Context, []api.RepoID, priority) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Enqueue method of the parent MockSchedulerStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SchedulerStoreEnqueueFunc) PushHook(hook func(context.Context, []api.RepoID, priority) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreEnqueueFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID, priority) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreEnqueueFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []api.RepoID, priority) error {
+		return r0
+	})
+}
+
+func (f *SchedulerStoreEnqueueFunc) nextHook() func(context.Context, []api.RepoID, priority) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreEnqueueFunc) appendCall(r0 SchedulerStoreEnqueueFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreEnqueueFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreEnqueueFunc) History() []SchedulerStoreEnqueueFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreEnqueueFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreEnqueueFuncCall is an object that describes an invocation
+// of method Enqueue on an instance of MockSchedulerStore.
+type SchedulerStoreEnqueueFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 priority
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreEnqueueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreEnqueueFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SchedulerStoreEnqueueDueFunc describes the behavior when the EnqueueDue
+// method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreEnqueueDueFunc struct {
+	defaultHook func(context.Context, int, time.Duration) (int, int, error)
+	hooks       []func(context.Context, int, time.Duration) (int, int, error)
+	history     []SchedulerStoreEnqueueDueFuncCall
+	mutex       sync.Mutex
+}
+
+// EnqueueDue delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSchedulerStore) EnqueueDue(v0 context.Context, v1 int, v2 time.Duration) (int, int, error) {
+	r0, r1, r2 := m.EnqueueDueFunc.nextHook()(v0, v1, v2)
+	m.EnqueueDueFunc.appendCall(SchedulerStoreEnqueueDueFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the EnqueueDue method of
+// the parent MockSchedulerStore instance is invoked and the hook queue is
+// empty.
+func (f *SchedulerStoreEnqueueDueFunc) SetDefaultHook(hook func(context.Context, int, time.Duration) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EnqueueDue method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreEnqueueDueFunc) PushHook(hook func(context.Context, int, time.Duration) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreEnqueueDueFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Duration) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreEnqueueDueFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, time.Duration) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *SchedulerStoreEnqueueDueFunc) nextHook() func(context.Context, int, time.Duration) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreEnqueueDueFunc) appendCall(r0 SchedulerStoreEnqueueDueFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreEnqueueDueFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreEnqueueDueFunc) History() []SchedulerStoreEnqueueDueFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreEnqueueDueFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreEnqueueDueFuncCall is an object that describes an
+// invocation of method EnqueueDue on an instance of MockSchedulerStore.
+type SchedulerStoreEnqueueDueFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Duration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreEnqueueDueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreEnqueueDueFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// SchedulerStoreIntervalFunc describes the behavior when the Interval
+// method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreIntervalFunc struct {
+	defaultHook func(context.Context, api.RepoID) (time.Duration, bool, error)
+	hooks       []func(context.Context, api.RepoID) (time.Duration, bool, error)
+	history     []SchedulerStoreIntervalFuncCall
+	mutex       sync.Mutex
+}
+
+// Interval delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSchedulerStore) Interval(v0 context.Context, v1 api.RepoID) (time.Duration, bool, error) {
+	r0, r1, r2 := m.IntervalFunc.nextHook()(v0, v1)
+	m.IntervalFunc.appendCall(SchedulerStoreIntervalFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Interval method of
+// the parent MockSchedulerStore instance is invoked and the hook queue is
+// empty.
+func (f *SchedulerStoreIntervalFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (time.Duration, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Interval method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreIntervalFunc) PushHook(hook func(context.Context, api.RepoID) (time.Duration, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreIntervalFunc) SetDefaultReturn(r0 time.Duration, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (time.Duration, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreIntervalFunc) PushReturn(r0 time.Duration, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, api.RepoID) (time.Duration, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *SchedulerStoreIntervalFunc) nextHook() func(context.Context, api.RepoID) (time.Duration, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreIntervalFunc) appendCall(r0 SchedulerStoreIntervalFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreIntervalFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreIntervalFunc) History() []SchedulerStoreIntervalFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreIntervalFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreIntervalFuncCall is an object that describes an invocation
+// of method Interval on an instance of MockSchedulerStore.
+type SchedulerStoreIntervalFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 time.Duration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreIntervalFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreIntervalFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// SchedulerStoreListQueueFunc describes the behavior when the ListQueue
+// method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreListQueueFunc struct {
+	defaultHook func(context.Context) ([]*RepoUpdateJob, error)
+	hooks       []func(context.Context) ([]*RepoUpdateJob, error)
+	history     []SchedulerStoreListQueueFuncCall
+	mutex       sync.Mutex
+}
+
+// ListQueue delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSchedulerStore) ListQueue(v0 context.Context) ([]*RepoUpdateJob, error) {
+	r0, r1 := m.ListQueueFunc.nextHook()(v0)
+	m.ListQueueFunc.appendCall(SchedulerStoreListQueueFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListQueue method of
+// the parent MockSchedulerStore instance is invoked and the hook queue is
+// empty.
+func (f *SchedulerStoreListQueueFunc) SetDefaultHook(hook func(context.Context) ([]*RepoUpdateJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListQueue method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreListQueueFunc) PushHook(hook func(context.Context) ([]*RepoUpdateJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreListQueueFunc) SetDefaultReturn(r0 []*RepoUpdateJob, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*RepoUpdateJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreListQueueFunc) PushReturn(r0 []*RepoUpdateJob, r1 error) {
+	f.PushHook(func(context.Context) ([]*RepoUpdateJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *SchedulerStoreListQueueFunc) nextHook() func(context.Context) ([]*RepoUpdateJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreListQueueFunc) appendCall(r0 SchedulerStoreListQueueFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreListQueueFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreListQueueFunc) History() []SchedulerStoreListQueueFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreListQueueFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreListQueueFuncCall is an object that describes an invocation
+// of method ListQueue on an instance of MockSchedulerStore.
+type SchedulerStoreListQueueFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*RepoUpdateJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreListQueueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreListQueueFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SchedulerStoreListRepoIDsFunc describes the behavior when the ListRepoIDs
+// method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreListRepoIDsFunc struct {
+	defaultHook func(context.Context) ([]api.RepoID, error)
+	hooks       []func(context.Context) ([]api.RepoID, error)
+	history     []SchedulerStoreListRepoIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListRepoIDs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSchedulerStore) ListRepoIDs(v0 context.Context) ([]api.RepoID, error) {
+	r0, r1 := m.ListRepoIDsFunc.nextHook()(v0)
+	m.ListRepoIDsFunc.appendCall(SchedulerStoreListRepoIDsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListRepoIDs method
+// of the parent MockSchedulerStore instance is invoked and the hook queue
+// is empty.
+func (f *SchedulerStoreListRepoIDsFunc) SetDefaultHook(hook func(context.Context) ([]api.RepoID, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListRepoIDs method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreListRepoIDsFunc) PushHook(hook func(context.Context) ([]api.RepoID, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreListRepoIDsFunc) SetDefaultReturn(r0 []api.RepoID, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]api.RepoID, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreListRepoIDsFunc) PushReturn(r0 []api.RepoID, r1 error) {
+	f.PushHook(func(context.Context) ([]api.RepoID, error) {
+		return r0, r1
+	})
+}
+
+func (f *SchedulerStoreListRepoIDsFunc) nextHook() func(context.Context) ([]api.RepoID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreListRepoIDsFunc) appendCall(r0 SchedulerStoreListRepoIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreListRepoIDsFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreListRepoIDsFunc) History() []SchedulerStoreListRepoIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreListRepoIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreListRepoIDsFuncCall is an object that describes an
+// invocation of method ListRepoIDs on an instance of MockSchedulerStore.
+type SchedulerStoreListRepoIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []api.RepoID
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreListRepoIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreListRepoIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SchedulerStoreListScheduleFunc describes the behavior when the
+// ListSchedule method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreListScheduleFunc struct {
+	defaultHook func(context.Context) ([]*ScheduledRepoUpdate, error)
+	hooks       []func(context.Context) ([]*ScheduledRepoUpdate, error)
+	history     []SchedulerStoreListScheduleFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSchedule delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSchedulerStore) ListSchedule(v0 context.Context) ([]*ScheduledRepoUpdate, error) {
+	r0, r1 := m.ListScheduleFunc.nextHook()(v0)
+	m.ListScheduleFunc.appendCall(SchedulerStoreListScheduleFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListSchedule method
+// of the parent MockSchedulerStore instance is invoked and the hook queue
+// is empty.
+func (f *SchedulerStoreListScheduleFunc) SetDefaultHook(hook func(context.Context) ([]*ScheduledRepoUpdate, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSchedule method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreListScheduleFunc) PushHook(hook func(context.Context) ([]*ScheduledRepoUpdate, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreListScheduleFunc) SetDefaultReturn(r0 []*ScheduledRepoUpdate, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*ScheduledRepoUpdate, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreListScheduleFunc) PushReturn(r0 []*ScheduledRepoUpdate, r1 error) {
+	f.PushHook(func(context.Context) ([]*ScheduledRepoUpdate, error) {
+		return r0, r1
+	})
+}
+
+func (f *SchedulerStoreListScheduleFunc) nextHook() func(context.Context) ([]*ScheduledRepoUpdate, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreListScheduleFunc) appendCall(r0 SchedulerStoreListScheduleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreListScheduleFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreListScheduleFunc) History() []SchedulerStoreListScheduleFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreListScheduleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreListScheduleFuncCall is an object that describes an
+// invocation of method ListSchedule on an instance of MockSchedulerStore.
+type SchedulerStoreListScheduleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*ScheduledRepoUpdate
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreListScheduleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreListScheduleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SchedulerStorePrioritiseUnclonedFunc describes the behavior when the
+// PrioritiseUncloned method of the parent MockSchedulerStore instance is
+// invoked.
+type SchedulerStorePrioritiseUnclonedFunc struct {
+	defaultHook func(context.Context, []api.RepoID) error
+	hooks       []func(context.Context, []api.RepoID) error
+	history     []SchedulerStorePrioritiseUnclonedFuncCall
+	mutex       sync.Mutex
+}
+
+// PrioritiseUncloned delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSchedulerStore) PrioritiseUncloned(v0 context.Context, v1 []api.RepoID) error {
+	r0 := m.PrioritiseUnclonedFunc.nextHook()(v0, v1)
+	m.PrioritiseUnclonedFunc.appendCall(SchedulerStorePrioritiseUnclonedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the PrioritiseUncloned
+// method of the parent MockSchedulerStore instance is invoked and the hook
+// queue is empty.
+func (f *SchedulerStorePrioritiseUnclonedFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PrioritiseUncloned method of the parent MockSchedulerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SchedulerStorePrioritiseUnclonedFunc) PushHook(hook func(context.Context, []api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStorePrioritiseUnclonedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStorePrioritiseUnclonedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *SchedulerStorePrioritiseUnclonedFunc) nextHook() func(context.Context, []api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStorePrioritiseUnclonedFunc) appendCall(r0 SchedulerStorePrioritiseUnclonedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStorePrioritiseUnclonedFuncCall
+// objects describing the invocations of this function.
+func (f *SchedulerStorePrioritiseUnclonedFunc) History() []SchedulerStorePrioritiseUnclonedFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStorePrioritiseUnclonedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStorePrioritiseUnclonedFuncCall is an object that describes an
+// invocation of method PrioritiseUncloned on an instance of
+// MockSchedulerStore.
+type SchedulerStorePrioritiseUnclonedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStorePrioritiseUnclonedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStorePrioritiseUnclonedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SchedulerStoreRecordChangeFunc describes the behavior when the
+// RecordChange method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreRecordChangeFunc struct {
+	defaultHook func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error)
+	hooks       []func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error)
+	history     []SchedulerStoreRecordChangeFuncCall
+	mutex       sync.Mutex
+}
+
+// RecordChange delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSchedulerStore) RecordChange(v0 context.Context, v1 api.RepoID, v2 time.Time) (time.Duration, bool, error) {
+	r0, r1, r2 := m.RecordChangeFunc.nextHook()(v0, v1, v2)
+	m.RecordChangeFunc.appendCall(SchedulerStoreRecordChangeFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the RecordChange method
+// of the parent MockSchedulerStore instance is invoked and the hook queue
+// is empty.
+func (f *SchedulerStoreRecordChangeFunc) SetDefaultHook(hook func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecordChange method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreRecordChangeFunc) PushHook(hook func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreRecordChangeFunc) SetDefaultReturn(r0 time.Duration, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreRecordChangeFunc) PushReturn(r0 time.Duration, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *SchedulerStoreRecordChangeFunc) nextHook() func(context.Context, api.RepoID, time.Time) (time.Duration, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreRecordChangeFunc) appendCall(r0 SchedulerStoreRecordChangeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreRecordChangeFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreRecordChangeFunc) History() []SchedulerStoreRecordChangeFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreRecordChangeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreRecordChangeFuncCall is an object that describes an
+// invocation of method RecordChange on an instance of MockSchedulerStore.
+type SchedulerStoreRecordChangeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 time.Duration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreRecordChangeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreRecordChangeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// SchedulerStoreRecordWebhookFunc describes the behavior when the
+// RecordWebhook method of the parent MockSchedulerStore instance is
+// invoked.
+type SchedulerStoreRecordWebhookFunc struct {
+	defaultHook func(context.Context, api.RepoID) error
+	hooks       []func(context.Context, api.RepoID) error
+	history     []SchedulerStoreRecordWebhookFuncCall
+	mutex       sync.Mutex
+}
+
+// RecordWebhook delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSchedulerStore) RecordWebhook(v0 context.Context, v1 api.RepoID) error {
+	r0 := m.RecordWebhookFunc.nextHook()(v0, v1)
+	m.RecordWebhookFunc.appendCall(SchedulerStoreRecordWebhookFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecordWebhook method
+// of the parent MockSchedulerStore instance is invoked and the hook queue
+// is empty.
+func (f *SchedulerStoreRecordWebhookFunc) SetDefaultHook(hook func(context.Context, api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecordWebhook method of the parent MockSchedulerStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SchedulerStoreRecordWebhookFunc) PushHook(hook func(context.Context, api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreRecordWebhookFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreRecordWebhookFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *SchedulerStoreRecordWebhookFunc) nextHook() func(context.Context, api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreRecordWebhookFunc) appendCall(r0 SchedulerStoreRecordWebhookFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreRecordWebhookFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreRecordWebhookFunc) History() []SchedulerStoreRecordWebhookFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreRecordWebhookFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreRecordWebhookFuncCall is an object that describes an
+// invocation of method RecordWebhook on an instance of MockSchedulerStore.
+type SchedulerStoreRecordWebhookFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreRecordWebhookFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreRecordWebhookFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SchedulerStoreRemoveFunc describes the behavior when the Remove method of
+// the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreRemoveFunc struct {
+	defaultHook func(context.Context, []api.RepoID) error
+	hooks       []func(context.Context, []api.RepoID) error
+	history     []SchedulerStoreRemoveFuncCall
+	mutex       sync.Mutex
+}
+
+// Remove delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSchedulerStore) Remove(v0 context.Context, v1 []api.RepoID) error {
+	r0 := m.RemoveFunc.nextHook()(v0, v1)
+	m.RemoveFunc.appendCall(SchedulerStoreRemoveFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Remove method of the
+// parent MockSchedulerStore instance is invoked and the hook queue is
+// empty.
+func (f *SchedulerStoreRemoveFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Remove method of the parent MockSchedulerStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SchedulerStoreRemoveFunc) PushHook(hook func(context.Context, []api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreRemoveFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreRemoveFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *SchedulerStoreRemoveFunc) nextHook() func(context.Context, []api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreRemoveFunc) appendCall(r0 SchedulerStoreRemoveFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreRemoveFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreRemoveFunc) History() []SchedulerStoreRemoveFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreRemoveFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreRemoveFuncCall is an object that describes an invocation of
+// method Remove on an instance of MockSchedulerStore.
+type SchedulerStoreRemoveFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreRemoveFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreRemoveFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SchedulerStoreScheduleFunc describes the behavior when the Schedule
+// method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreScheduleFunc struct {
+	defaultHook func(context.Context, []api.RepoID) error
+	hooks       []func(context.Context, []api.RepoID) error
+	history     []SchedulerStoreScheduleFuncCall
+	mutex       sync.Mutex
+}
+
+// Schedule delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSchedulerStore) Schedule(v0 context.Context, v1 []api.RepoID) error {
+	r0 := m.ScheduleFunc.nextHook()(v0, v1)
+	m.ScheduleFunc.appendCall(SchedulerStoreScheduleFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Schedule method of
+// the parent MockSchedulerStore instance is invoked and the hook queue is
+// empty.
+func (f *SchedulerStoreScheduleFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Schedule method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreScheduleFunc) PushHook(hook func(context.Context, []api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreScheduleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreScheduleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *SchedulerStoreScheduleFunc) nextHook() func(context.Context, []api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreScheduleFunc) appendCall(r0 SchedulerStoreScheduleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreScheduleFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreScheduleFunc) History() []SchedulerStoreScheduleFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreScheduleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreScheduleFuncCall is an object that describes an invocation
+// of method Schedule on an instance of MockSchedulerStore.
+type SchedulerStoreScheduleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreScheduleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreScheduleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SchedulerStoreScheduleInfoFunc describes the behavior when the
+// ScheduleInfo method of the parent MockSchedulerStore instance is invoked.
+type SchedulerStoreScheduleInfoFunc struct {
+	defaultHook func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)
+	hooks       []func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)
+	history     []SchedulerStoreScheduleInfoFuncCall
+	mutex       sync.Mutex
+}
+
+// ScheduleInfo delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSchedulerStore) ScheduleInfo(v0 context.Context, v1 api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+	r0, r1 := m.ScheduleInfoFunc.nextHook()(v0, v1)
+	m.ScheduleInfoFunc.appendCall(SchedulerStoreScheduleInfoFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ScheduleInfo method
+// of the parent MockSchedulerStore instance is invoked and the hook queue
+// is empty.
+func (f *SchedulerStoreScheduleInfoFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScheduleInfo method of the parent MockSchedulerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SchedulerStoreScheduleInfoFunc) PushHook(hook func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreScheduleInfoFunc) SetDefaultReturn(r0 *protocol.RepoUpdateSchedulerInfoResult, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreScheduleInfoFunc) PushReturn(r0 *protocol.RepoUpdateSchedulerInfoResult, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+		return r0, r1
+	})
+}
+
+func (f *SchedulerStoreScheduleInfoFunc) nextHook() func(context.Context, api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreScheduleInfoFunc) appendCall(r0 SchedulerStoreScheduleInfoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreScheduleInfoFuncCall objects
+// describing the invocations of this function.
+func (f *SchedulerStoreScheduleInfoFunc) History() []SchedulerStoreScheduleInfoFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreScheduleInfoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreScheduleInfoFuncCall is an object that describes an
+// invocation of method ScheduleInfo on an instance of MockSchedulerStore.
+type SchedulerStoreScheduleInfoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *protocol.RepoUpdateSchedulerInfoResult
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreScheduleInfoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreScheduleInfoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SchedulerStoreUpdateIntervalFunc describes the behavior when the
+// UpdateInterval method of the parent MockSchedulerStore instance is
+// invoked.
+type SchedulerStoreUpdateIntervalFunc struct {
+	defaultHook func(context.Context, api.RepoID, time.Duration) error
+	hooks       []func(context.Context, api.RepoID, time.Duration) error
+	history     []SchedulerStoreUpdateIntervalFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateInterval delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSchedulerStore) UpdateInterval(v0 context.Context, v1 api.RepoID, v2 time.Duration) error {
+	r0 := m.UpdateIntervalFunc.nextHook()(v0, v1, v2)
+	m.UpdateIntervalFunc.appendCall(SchedulerStoreUpdateIntervalFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateInterval
+// method of the parent MockSchedulerStore instance is invoked and the hook
+// queue is empty.
+func (f *SchedulerStoreUpdateIntervalFunc) SetDefaultHook(hook func(context.Context, api.RepoID, time.Duration) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateInterval method of the parent MockSchedulerStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SchedulerStoreUpdateIntervalFunc) PushHook(hook func(context.Context, api.RepoID, time.Duration) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SchedulerStoreUpdateIntervalFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, time.Duration) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SchedulerStoreUpdateIntervalFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, time.Duration) error {
+		return r0
+	})
+}
+
+func (f *SchedulerStoreUpdateIntervalFunc) nextHook() func(context.Context, api.RepoID, time.Duration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SchedulerStoreUpdateIntervalFunc) appendCall(r0 SchedulerStoreUpdateIntervalFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SchedulerStoreUpdateIntervalFuncCall
+// objects describing the invocations of this function.
+func (f *SchedulerStoreUpdateIntervalFunc) History() []SchedulerStoreUpdateIntervalFuncCall {
+	f.mutex.Lock()
+	history := make([]SchedulerStoreUpdateIntervalFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SchedulerStoreUpdateIntervalFuncCall is an object that describes an
+// invocation of method UpdateInterval on an instance of MockSchedulerStore.
+type SchedulerStoreUpdateIntervalFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Duration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SchedulerStoreUpdateIntervalFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SchedulerStoreUpdateIntervalFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockStore is a mock implementation of the Store interface (from the
 // package github.com/sourcegraph/sourcegraph/internal/repos) used for unit
 // testing.
@@ -54,6 +1943,9 @@ type MockStore struct {
 	// ListSyncJobsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListSyncJobs.
 	ListSyncJobsFunc *StoreListSyncJobsFunc
+	// RepoKVPStoreFunc is an instance of a mock function object controlling
+	// the behavior of the method RepoKVPStore.
+	RepoKVPStoreFunc *StoreRepoKVPStoreFunc
 	// RepoStoreFunc is an instance of a mock function object controlling
 	// the behavior of the method RepoStore.
 	RepoStoreFunc *StoreRepoStoreFunc
@@ -132,6 +2024,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		RepoKVPStoreFunc: &StoreRepoKVPStoreFunc{
+			defaultHook: func() (r0 database.RepoKVPStore) {
+				return
+			},
+		},
 		RepoStoreFunc: &StoreRepoStoreFunc{
 			defaultHook: func() (r0 database.RepoStore) {
 				return
@@ -224,6 +2121,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.ListSyncJobs")
 			},
 		},
+		RepoKVPStoreFunc: &StoreRepoKVPStoreFunc{
+			defaultHook: func() database.RepoKVPStore {
+				panic("unexpected invocation of MockStore.RepoKVPStore")
+			},
+		},
 		RepoStoreFunc: &StoreRepoStoreFunc{
 			defaultHook: func() database.RepoStore {
 				panic("unexpected invocation of MockStore.RepoStore")
@@ -296,6 +2198,9 @@ func NewMockStoreFrom(i Store) *MockStore {
 		ListSyncJobsFunc: &StoreListSyncJobsFunc{
 			defaultHook: i.ListSyncJobs,
 		},
+		RepoKVPStoreFunc: &StoreRepoKVPStoreFunc{
+			defaultHook: i.RepoKVPStore,
+		},
 		RepoStoreFunc: &StoreRepoStoreFunc{
 			defaultHook: i.RepoStore,
 		},
@@ -1366,6 +3271,104 @@ func (c StoreListSyncJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreRepoKVPStoreFunc describes the behavior when the RepoKVPStore method
+// of the parent MockStore instance is invoked.
+type StoreRepoKVPStoreFunc struct {
+	defaultHook func() database.RepoKVPStore
+	hooks       []func() database.RepoKVPStore
+	history     []StoreRepoKVPStoreFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoKVPStore delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) RepoKVPStore() database.RepoKVPStore {
+	r0 := m.RepoKVPStoreFunc.nextHook()()
+	m.RepoKVPStoreFunc.appendCall(StoreRepoKVPStoreFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RepoKVPStore method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreRepoKVPStoreFunc) SetDefaultHook(hook func() database.RepoKVPStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoKVPStore method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreRepoKVPStoreFunc) PushHook(hook func() database.RepoKVPStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreRepoKVPStoreFunc) SetDefaultReturn(r0 database.RepoKVPStore) {
+	f.SetDefaultHook(func() database.RepoKVPStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreRepoKVPStoreFunc) PushReturn(r0 database.RepoKVPStore) {
+	f.PushHook(func() database.RepoKVPStore {
+		return r0
+	})
+}
+
+func (f *StoreRepoKVPStoreFunc) nextHook() func() database.RepoKVPStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreRepoKVPStoreFunc) appendCall(r0 StoreRepoKVPStoreFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreRepoKVPStoreFuncCall objects
+// describing the invocations of this function.
+func (f *StoreRepoKVPStoreFunc) History() []StoreRepoKVPStoreFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreRepoKVPStoreFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreRepoKVPStoreFuncCall is an object that describes an invocation of
+// method RepoKVPStore on an instance of MockStore.
+type StoreRepoKVPStoreFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RepoKVPStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreRepoKVPStoreFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreRepoKVPStoreFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreRepoStoreFunc describes the behavior when the RepoStore method of
 // the parent MockStore instance is invoked.
 type StoreRepoStoreFunc struct {
@@ -105,7 +105,7 @@ func (s OtherSource) ListRepos(ctx context.Context, results chan SourceResult) {
 
 	// If the current configuration does not define a src expose code host connection
 	// then we utilize cloneURLs
-	urls, err := s.cloneURLs()
+	urls, err := s.cloneURLs(ctx)
 	if err != nil {
 		results <- SourceResult{Source: s, Err: err}
 		return
@@ -113,11 +113,20 @@ func (s OtherSource) ListRepos(ctx context.Context, results chan SourceResult) {
 
 	urn := s.svc.URN()
 	for _, u := range urls {
+		// otherRepoFromCloneURL modifies u.
+		redacted := u.Redacted()
 		r, err := s.otherRepoFromCloneURL(urn, u)
 		if err != nil {
 			results <- SourceResult{Source: s, Err: err}
 			return
 		}
+		if r.Name == "" {
+			// Manifests may reference remotes outside of the base URL. We skip
+			// them rather than reporting an error, since any error would stop
+			// the syncer from deleting repositories dropped from the manifest.
+			s.logger.Warn("skipping repository not served by the Git clone base URL", log.String("url", redacted))
+			continue
+		}
 		if s.excludes(r) {
 			continue
 		}
@@ -135,8 +144,8 @@ func (s OtherSource) excludes(r *types.Repo) bool {
 	return s.exclude(string(r.Name))
 }
 
-func (s OtherSource) cloneURLs() ([]*url.URL, error) {
-	if len(s.conn.Repos) == 0 {
+func (s OtherSource) cloneURLs(ctx context.Context) ([]*url.URL, error) {
+	if len(s.conn.Repos) == 0 && s.conn.Manifest == nil {
 		return nil, nil
 	}
 
@@ -157,6 +166,23 @@ func (s OtherSource) cloneURLs() ([]*url.URL, error) {
 		cloneURLs = append(cloneURLs, cloneURL)
 	}
 
+	manifestURLs, err := s.manifestCloneURLs(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(cloneURLs))
+	for _, u := range cloneURLs {
+		seen[u.String()] = true
+	}
+	for _, u := range manifestURLs {
+		if seen[u.String()] {
+			continue
+		}
+		seen[u.String()] = true
+		cloneURLs = append(cloneURLs, u)
+	}
+
 	return cloneURLs, nil
 }
 
@@ -0,0 +1,176 @@
+package repos
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxOtherManifestSize is the maximum size of a manifest that is read.
+const maxOtherManifestSize = 10 * 1024 * 1024
+
+// manifestCloneURLs fetches the manifest of the connection and returns the
+// clone URLs of the repositories it lists.
+func (s OtherSource) manifestCloneURLs(ctx context.Context, base *url.URL) ([]*url.URL, error) {
+	if s.conn.Manifest == nil {
+		return nil, nil
+	}
+
+	data, err := s.fetchManifest(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch manifest")
+	}
+
+	var repos []string
+	switch otherManifestFormat(s.conn.Manifest.Url, s.conn.Manifest.Format) {
+	case "repo":
+		repos, err = parseRepoToolManifest(data, base)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse repo manifest")
+		}
+	default:
+		repos = parseListManifest(data)
+	}
+
+	cloneURLs := make([]*url.URL, 0, len(repos))
+	for _, repo := range repos {
+		cloneURL, err := otherRepoCloneURL(base, repo)
+		if err != nil {
+			return nil, err
+		}
+		cloneURLs = append(cloneURLs, cloneURL)
+	}
+	return cloneURLs, nil
+}
+
+func (s OtherSource) fetchManifest(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.conn.Manifest.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxOtherManifestSize))
+}
+
+// otherManifestFormat returns the format of the manifest at rawURL, which is
+// the configured format if any.
+func otherManifestFormat(rawURL, format string) string {
+	if format != "" {
+		return format
+	}
+	if u, err := url.Parse(rawURL); err == nil && path.Ext(u.Path) == ".xml" {
+		return "repo"
+	}
+	return "list"
+}
+
+// parseListManifest returns the repositories listed in a plain text manifest,
+// one per line. Empty lines and lines starting with # are ignored.
+func parseListManifest(data []byte) []string {
+	var repos []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		repos = append(repos, line)
+	}
+	return repos
+}
+
+// repoToolManifest is the subset of a Google repo tool manifest that is needed
+// to find the clone URLs of its projects. See
+// https://gerrit.googlesource.com/git-repo/+/HEAD/docs/manifest-format.md.
+type repoToolManifest struct {
+	Remotes []struct {
+		Name  string `xml:"name,attr"`
+		Fetch string `xml:"fetch,attr"`
+	} `xml:"remote"`
+	Default struct {
+		Remote string `xml:"remote,attr"`
+	} `xml:"default"`
+	Projects []struct {
+		Name   string `xml:"name,attr"`
+		Remote string `xml:"remote,attr"`
+	} `xml:"project"`
+	RemoveProjects []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"remove-project"`
+}
+
+// parseRepoToolManifest returns the clone URLs of the projects of a Google repo
+// tool manifest. Relative fetch URLs of remotes are resolved against base.
+// Manifests served base64 encoded, like Gitiles does for raw files, are
+// decoded first.
+func parseRepoToolManifest(data []byte, base *url.URL) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("<")) {
+		decoded, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, errors.New("manifest is neither XML nor base64 encoded XML")
+		}
+		data = decoded
+	}
+
+	var m repoToolManifest
+	if err := xml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	fetchURLs := make(map[string]string, len(m.Remotes))
+	for _, r := range m.Remotes {
+		fetch, err := url.Parse(r.Fetch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid fetch URL of remote %q", r.Name)
+		}
+		if !fetch.IsAbs() {
+			if base == nil {
+				return nil, errors.Errorf("relative fetch URL of remote %q requires the url of the connection to be set", r.Name)
+			}
+			fetch = base.ResolveReference(fetch)
+		}
+		fetchURLs[r.Name] = strings.TrimSuffix(fetch.String(), "/")
+	}
+
+	removed := make(map[string]bool, len(m.RemoveProjects))
+	for _, p := range m.RemoveProjects {
+		removed[p.Name] = true
+	}
+
+	var repos []string
+	for _, p := range m.Projects {
+		if removed[p.Name] {
+			continue
+		}
+		remote := p.Remote
+		if remote == "" {
+			remote = m.Default.Remote
+		}
+		fetch, ok := fetchURLs[remote]
+		if !ok {
+			return nil, errors.Errorf("unknown remote %q of project %q", remote, p.Name)
+		}
+		repos = append(repos, fetch+"/"+strings.TrimPrefix(p.Name, "/"))
+	}
+	return repos, nil
+}
@@ -0,0 +1,95 @@
+package repos
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testRepoToolManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest>
+  <remote name="aosp" fetch=".." review="https://review.example.com/" />
+  <remote name="mirror" fetch="https://mirror.example.com/git" />
+  <default revision="main" remote="aosp" sync-j="4" />
+
+  <project path="build/make" name="platform/build" groups="pdk" />
+  <project path="external/foo" name="external/foo" remote="mirror" />
+  <project path="external/bar" name="external/bar" />
+  <remove-project name="external/bar" />
+</manifest>
+`
+
+func TestParseRepoToolManifest(t *testing.T) {
+	base, err := url.Parse("https://git.example.com/android/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"https://git.example.com/platform/build",
+		"https://mirror.example.com/git/external/foo",
+	}
+
+	for name, data := range map[string]string{
+		"xml":    testRepoToolManifest,
+		"base64": base64.StdEncoding.EncodeToString([]byte(testRepoToolManifest)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := parseRepoToolManifest([]byte(data), base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("unknown remote", func(t *testing.T) {
+		_, err := parseRepoToolManifest([]byte(`<manifest><project name="a" remote="nope" /></manifest>`), base)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("relative fetch URL without base", func(t *testing.T) {
+		_, err := parseRepoToolManifest([]byte(testRepoToolManifest), nil)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestParseListManifest(t *testing.T) {
+	have := parseListManifest([]byte(`
+# Platform repositories
+platform/build
+  platform/art
+
+https://git.example.com/external/foo.git
+`))
+	want := []string{
+		"platform/build",
+		"platform/art",
+		"https://git.example.com/external/foo.git",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestOtherManifestFormat(t *testing.T) {
+	for _, tc := range []struct {
+		url, format, want string
+	}{
+		{url: "https://git.example.com/manifest/raw/main/default.xml", want: "repo"},
+		{url: "https://git.example.com/manifest/+/refs/heads/main/default.xml?format=TEXT", want: "repo"},
+		{url: "https://git.example.com/manifest/raw/main/repos.txt", want: "list"},
+		{url: "https://git.example.com/manifest/raw/main/default.xml", format: "list", want: "list"},
+	} {
+		if have := otherManifestFormat(tc.url, tc.format); have != tc.want {
+			t.Errorf("otherManifestFormat(%q, %q) = %q, want %q", tc.url, tc.format, have, tc.want)
+		}
+	}
+}
@@ -337,6 +337,26 @@ func TestOther_ListRepos(t *testing.T) {
 	}))
 	defer srcExpose.Close()
 
+	// Spin up a server hosting manifests
+	manifests := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/default.xml":
+			_, _ = w.Write([]byte(`<manifest>
+  <remote name="origin" fetch="." />
+  <remote name="mirror" fetch="https://mirror.example.com/git" />
+  <default remote="origin" />
+  <project path="b" name="b" />
+  <project path="a" name="a" />
+  <project path="external/foo" name="external/foo" remote="mirror" />
+</manifest>`))
+		case "/repos.txt":
+			_, _ = w.Write([]byte("# comment\nb/c\nd\n"))
+		default:
+			http.Error(w, r.URL.String()+" not found", http.StatusNotFound)
+		}
+	}))
+	defer manifests.Close()
+
 	cases := []struct {
 		Name           string
 		Conn           *schema.OtherExternalServiceConnection
@@ -422,6 +442,21 @@ func TestOther_ListRepos(t *testing.T) {
 			RepositoryPathPattern: "{repo}",
 		},
 		Want: []string{"keep1", "not-exact/keep2", "keep3"},
+	}, {
+		Name: "manifest/repo",
+		Conn: &schema.OtherExternalServiceConnection{
+			Url:      "http://test/android/",
+			Repos:    []string{"a"},
+			Manifest: &schema.OtherManifest{Url: manifests.URL + "/default.xml"},
+		},
+		Want: []string{"test/android/a", "test/android/b"},
+	}, {
+		Name: "manifest/list",
+		Conn: &schema.OtherExternalServiceConnection{
+			Url:      "http://test",
+			Manifest: &schema.OtherManifest{Url: manifests.URL + "/repos.txt"},
+		},
+		Want: []string{"test/b/c", "test/d"},
 	}}
 
 	for _, tc := range cases {
@@ -1,7 +1,6 @@
 package repos
 
 import (
-	"container/heap"
 	"context"
 	"math/rand"
 	"strings"
@@ -9,6 +8,9 @@ import (
 	"time"
 
 	"github.com/grafana/regexp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/sourcegraph/log"
 
@@ -17,16 +19,20 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
-	"github.com/sourcegraph/sourcegraph/internal/limiter"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
 // schedulerConfig tracks the active scheduler configuration.
 type schedulerConfig struct {
 	running               bool
 	autoGitUpdatesEnabled bool
+	maxConcurrentClones   int
 }
 
 // RunScheduler runs the worker that schedules git fetches of synced repositories in git-server.
@@ -38,12 +44,22 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 
 	logger = logger.Scoped("RunScheduler", "git fetch scheduler")
 
+	// The resetter doesn't depend on the configuration, so it runs for the
+	// whole lifetime of the scheduler.
+	resetter := scheduler.newResetter()
+	go resetter.Start()
+	go func() {
+		<-ctx.Done()
+		resetter.Stop()
+	}()
+
 	conf.Watch(func() {
 		c := conf.Get()
 
 		want := schedulerConfig{
 			running:               true,
 			autoGitUpdatesEnabled: !c.DisableAutoGitUpdates,
+			maxConcurrentClones:   conf.GitMaxConcurrentClones(),
 		}
 
 		if have == want {
@@ -63,7 +79,7 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 		var ctx2 context.Context
 		ctx2, stop = context.WithCancel(ctx)
 
-		go scheduler.runUpdateLoop(ctx2)
+		go scheduler.newWorker(ctx2, want.maxConcurrentClones).Start()
 		if want.autoGitUpdatesEnabled {
 			go scheduler.runScheduleLoop(ctx2)
 		}
@@ -88,6 +104,23 @@ const (
 
 	// maxDelay is the maximum amount of time between scheduled updates for a single repository.
 	maxDelay = 8 * time.Hour
+
+	// scheduleLoopInterval is how often the schedule is checked for repos that
+	// are due for an update.
+	scheduleLoopInterval = time.Second
+
+	// scheduleLoopBatchSize is the maximum number of due repos that are
+	// enqueued per iteration of the schedule loop.
+	scheduleLoopBatchSize = 500
+
+	// finishedJobsMaxAge is how long finished updates are kept around before
+	// they are deleted.
+	finishedJobsMaxAge = time.Hour
+
+	// webhookSilenceThreshold is how long a repo may go without a push webhook
+	// before it is polled again. It matches maxDelay, so that a silent webhook
+	// doesn't delay updates more than polling would.
+	webhookSilenceThreshold = maxDelay
 )
 
 // UpdateScheduler schedules repo update (or clone) requests to gitserver.
@@ -100,19 +133,43 @@ const (
 // then the next update will be scheduled 6 hours from then.
 // This heuristic is simple to compute and has nice backoff properties.
 //
+// The scheduler also learns the commit cadence of each repo from the fetch
+// results: whenever a fetch observes a new commit, the time since the previously
+// observed commit is added to a moving average. Once the cadence is known, the
+// interval is the mean of the heuristic above and half the cadence, so that
+// repos that commit regularly aren't polled right after a commit, and repos that
+// went quiet still back off.
+//
+// Repos that received a push webhook within webhookSilenceThreshold are not
+// polled, since the webhook triggers their updates. Once a repo's webhook
+// deliveries go stale, polling resumes.
+//
 // If an error occurs when attempting to fetch a repo we perform exponential
 // backoff by doubling the current interval. This ensures that problematic repos
 // don't stay in the front of the schedule clogging up the queue.
 //
 // When it is time for a repo to update, the scheduler inserts the repo into a queue.
 //
-// A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
-// is limited by the gitMaxConcurrentClones site configuration.
+// A worker continuously dequeues repos and sends updates to gitserver, but the number
+// of updates in progress across all repo-updater instances is limited by the
+// gitMaxConcurrentClones site configuration.
+//
+// The schedule and the queue are stored in the database, so that they survive
+// restarts and multiple repo-updater instances can share the work.
 type UpdateScheduler struct {
-	db          database.DB
-	updateQueue *updateQueue
-	schedule    *schedule
-	logger      log.Logger
+	db     database.DB
+	store  SchedulerStore
+	logger log.Logger
+
+	workerStore     dbworkerstore.Store[*RepoUpdateJob]
+	workerMetrics   workerutil.WorkerObservability
+	resetterMetrics dbworker.ResetterMetrics
+
+	// random source used to add jitter to repo update intervals.
+	randMu        sync.Mutex
+	randGenerator interface {
+		Int63n(n int64) int64
+	}
 }
 
 // A configuredRepo represents the configuration data for a given repo from
@@ -123,132 +180,248 @@ type configuredRepo struct {
 	Name api.RepoName
 }
 
-// notifyChanBuffer controls the buffer size of notification channels.
-// It is important that this value is 1 so that we can perform lossless
-// non-blocking sends.
-const notifyChanBuffer = 1
-
 // NewUpdateScheduler returns a new scheduler.
-func NewUpdateScheduler(logger log.Logger, db database.DB) *UpdateScheduler {
-	updateSchedLogger := logger.Scoped("UpdateScheduler", "repo update scheduler")
+func NewUpdateScheduler(observationCtx *observation.Context, db database.DB) *UpdateScheduler {
+	return newUpdateScheduler(observationCtx, db, NewSchedulerStore(db))
+}
+
+func newUpdateScheduler(observationCtx *observation.Context, db database.DB, store SchedulerStore) *UpdateScheduler {
+	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("UpdateScheduler", "repo update scheduler"), observationCtx)
+
+	workerStore := dbworkerstore.New(observationCtx, db.Handle(), dbworkerstore.Options[*RepoUpdateJob]{
+		Name:              "repo_update_worker_store",
+		TableName:         "repo_update_jobs",
+		Scan:              dbworkerstore.BuildWorkerScan(scanRepoUpdateJob),
+		OrderByExpression: repoUpdateJobsOrderBy,
+		ColumnExpressions: repoUpdateJobColumns,
+		StalledMaxAge:     30 * time.Second,
+		MaxNumResets:      5,
+		MaxNumRetries:     0,
+	})
 
 	return &UpdateScheduler{
-		db: db,
-		updateQueue: &updateQueue{
-			index:         make(map[api.RepoID]*repoUpdate),
-			notifyEnqueue: make(chan struct{}, notifyChanBuffer),
+		db:            db,
+		store:         store,
+		logger:        observationCtx.Logger,
+		workerStore:   workerStore,
+		workerMetrics: workerutil.NewMetrics(observationCtx, "repo_updater_repo_update_worker"),
+		resetterMetrics: dbworker.ResetterMetrics{
+			RecordResets: promauto.With(observationCtx.Registerer).NewCounter(prometheus.CounterOpts{
+				Name: "src_repo_update_queue_resets_total",
+				Help: "Total number of repo updates put back into queued state",
+			}),
+			RecordResetFailures: promauto.With(observationCtx.Registerer).NewCounter(prometheus.CounterOpts{
+				Name: "src_repo_update_queue_max_resets_total",
+				Help: "Total number of repo updates that exceed the max number of resets",
+			}),
+			Errors: promauto.With(observationCtx.Registerer).NewCounter(prometheus.CounterOpts{
+				Name: "src_repo_update_queue_reset_errors_total",
+				Help: "Total number of errors when running the repo update resetter",
+			}),
 		},
-		schedule: &schedule{
-			index:         make(map[api.RepoID]*scheduledRepoUpdate),
-			wakeup:        make(chan struct{}, notifyChanBuffer),
-			randGenerator: rand.New(rand.NewSource(time.Now().UnixNano())),
-			logger:        updateSchedLogger.Scoped("Schedule", ""),
-		},
-		logger: updateSchedLogger,
+		randGenerator: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
-// runScheduleLoop starts the loop that schedules updates by enqueuing them into the updateQueue.
+// newWorker returns a worker that dequeues repo updates and sends them to
+// gitserver, with at most maxConcurrentClones concurrent updates across all
+// repo-updater instances.
+func (s *UpdateScheduler) newWorker(ctx context.Context, maxConcurrentClones int) *workerutil.Worker[*RepoUpdateJob] {
+	handler := &updateHandler{scheduler: s, maxConcurrentClones: maxConcurrentClones}
+
+	return dbworker.NewWorker[*RepoUpdateJob](ctx, s.workerStore, handler, workerutil.WorkerOptions{
+		Name:              "repo_update_worker",
+		Description:       "sends repo update requests to gitserver",
+		NumHandlers:       maxConcurrentClones,
+		Interval:          time.Second,
+		HeartbeatInterval: 10 * time.Second,
+		Metrics:           s.workerMetrics,
+	})
+}
+
+// updateHandler handles the repo updates dequeued by the worker. NumHandlers
+// only limits the concurrent updates of a single repo-updater instance, so the
+// handler also restricts the dequeue to the times when fewer than
+// maxConcurrentClones updates are being processed across all instances.
+type updateHandler struct {
+	scheduler           *UpdateScheduler
+	maxConcurrentClones int
+}
+
+var (
+	_ workerutil.Handler[*RepoUpdateJob] = &updateHandler{}
+	_ workerutil.WithPreDequeue          = &updateHandler{}
+)
+
+func (h *updateHandler) Handle(ctx context.Context, logger log.Logger, job *RepoUpdateJob) error {
+	return h.scheduler.handle(ctx, logger, job)
+}
+
+func (h *updateHandler) PreDequeue(ctx context.Context, logger log.Logger) (bool, any, error) {
+	return true, []*sqlf.Query{sqlf.Sprintf(processingRepoUpdateJobsBelowLimitCondition, h.maxConcurrentClones)}, nil
+}
+
+// processingRepoUpdateJobsBelowLimitCondition is a dequeue condition that only
+// matches while fewer than the given number of repo updates are processed. Two
+// instances dequeueing at the same time can both see the last free slot, so the
+// limit may briefly be exceeded by up to the number of instances.
+const processingRepoUpdateJobsBelowLimitCondition = `
+(SELECT COUNT(*) FROM repo_update_jobs WHERE state = 'processing') < %s
+`
+
+// newResetter returns a resetter that requeues repo updates whose worker
+// stopped sending heartbeats.
+func (s *UpdateScheduler) newResetter() *dbworker.Resetter[*RepoUpdateJob] {
+	return dbworker.NewResetter(s.logger.Scoped("Resetter", ""), s.workerStore, dbworker.ResetterOptions{
+		Name:     "repo_update_worker_resetter",
+		Interval: time.Minute,
+		Metrics:  s.resetterMetrics,
+	})
+}
+
+// runScheduleLoop starts the loop that schedules updates by enqueuing them into the update queue.
 func (s *UpdateScheduler) runScheduleLoop(ctx context.Context) {
+	ticker := time.NewTicker(scheduleLoopInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Now()
 	for {
 		select {
-		case <-s.schedule.wakeup:
+		case <-ticker.C:
 		case <-ctx.Done():
-			s.schedule.reset()
 			return
 		}
 
-		s.runSchedule()
+		if err := s.runSchedule(ctx); err != nil && ctx.Err() == nil {
+			schedError.WithLabelValues("runSchedule").Inc()
+			s.logger.Error("error running schedule", log.Error(err))
+		}
 		schedLoops.Inc()
+
+		if time.Since(lastCleanup) > finishedJobsMaxAge {
+			if err := s.store.DeleteFinishedJobs(ctx, finishedJobsMaxAge); err != nil && ctx.Err() == nil {
+				s.logger.Error("error deleting finished repo updates", log.Error(err))
+			}
+			lastCleanup = time.Now()
+		}
 	}
 }
 
-func (s *UpdateScheduler) runSchedule() {
-	s.schedule.mu.Lock()
-	defer s.schedule.mu.Unlock()
-	defer s.schedule.rescheduleTimer()
-
-	for len(s.schedule.heap) != 0 {
-		repoUpdate := s.schedule.heap[0]
-		if !repoUpdate.Due.Before(timeNow().Add(time.Millisecond)) {
+// runSchedule enqueues all repos that are due for an update.
+func (s *UpdateScheduler) runSchedule(ctx context.Context) error {
+	for {
+		due, covered, err := s.store.EnqueueDue(ctx, scheduleLoopBatchSize, webhookSilenceThreshold)
+		if err != nil {
+			return err
+		}
+		schedAutoFetch.Add(float64(due - covered))
+		schedWebhookCovered.Add(float64(covered))
+		if due < scheduleLoopBatchSize {
 			break
 		}
+	}
 
-		schedAutoFetch.Inc()
-		s.updateQueue.enqueue(repoUpdate.Repo, priorityLow)
-		repoUpdate.Due = timeNow().Add(repoUpdate.Interval)
-		heap.Fix(s.schedule, 0)
+	scheduled, queued, err := s.store.Counts(ctx)
+	if err != nil {
+		return err
 	}
+	schedKnownRepos.Set(float64(scheduled))
+	schedUpdateQueueLength.Set(float64(queued))
+	return nil
 }
 
-// runUpdateLoop sends repo update requests to gitserver.
-func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
-	limiter := configuredLimiter()
+// handle sends a repo update request to gitserver and reschedules the repo
+// based on the result.
+func (s *UpdateScheduler) handle(ctx context.Context, logger log.Logger, job *RepoUpdateJob) error {
+	repo := configuredRepo{ID: job.RepoID, Name: job.RepoName}
 
-	for {
-		select {
-		case <-s.updateQueue.notifyEnqueue:
-		case <-ctx.Done():
-			s.updateQueue.reset()
-			return
+	// This is a blocking call since the repo will be cloned synchronously by gitserver
+	// if it doesn't exist or update it if it does. The timeout of this request depends
+	// on the value of conf.GitLongCommandTimeout() or if the passed context has a set
+	// deadline shorter than the value of this config.
+	resp, err := requestRepoUpdate(ctx, repo, 1*time.Second)
+	if err != nil {
+		schedError.WithLabelValues("requestRepoUpdate").Inc()
+		logger.Error("error requesting repo update", log.Error(err), log.String("uri", string(repo.Name)))
+	} else if resp != nil && resp.Error != "" {
+		schedError.WithLabelValues("repoUpdateResponse").Inc()
+		// We don't want to spam our logs when the rate limiter has been set to block all
+		// updates
+		if !strings.Contains(resp.Error, ratelimit.ErrBlockAll.Error()) {
+			logger.Error("error updating repo", log.String("err", resp.Error), log.String("uri", string(repo.Name)))
 		}
+	}
 
-		for {
-			ctx, cancel, err := limiter.Acquire(ctx)
-			if err != nil {
-				// context is canceled; shutdown
-				return
-			}
+	var (
+		changeInterval      time.Duration
+		changeIntervalKnown bool
+	)
+	if err == nil && resp != nil && resp.Error == "" && resp.LastChanged != nil {
+		changeInterval, changeIntervalKnown, err = s.store.RecordChange(ctx, repo.ID, *resp.LastChanged)
+		if err != nil {
+			return err
+		}
+	}
 
-			repo, ok := s.updateQueue.acquireNext()
-			if !ok {
-				cancel()
-				break
-			}
+	if interval := getCustomInterval(logger, conf.Get(), string(repo.Name)); interval > 0 {
+		return s.updateInterval(ctx, repo, interval)
+	}
 
-			subLogger := s.logger.Scoped("RunUpdateLoop", "")
-
-			go func(ctx context.Context, repo configuredRepo, cancel context.CancelFunc) {
-				defer cancel()
-				defer s.updateQueue.remove(repo, true)
-
-				// This is a blocking call since the repo will be cloned synchronously by gitserver
-				// if it doesn't exist or update it if it does. The timeout of this request depends
-				// on the value of conf.GitLongCommandTimeout() or if the passed context has a set
-				// deadline shorter than the value of this config.
-				resp, err := requestRepoUpdate(ctx, repo, 1*time.Second)
-				if err != nil {
-					schedError.WithLabelValues("requestRepoUpdate").Inc()
-					subLogger.Error("error requesting repo update", log.Error(err), log.String("uri", string(repo.Name)))
-				} else if resp != nil && resp.Error != "" {
-					schedError.WithLabelValues("repoUpdateResponse").Inc()
-					// We don't want to spam our logs when the rate limiter has been set to block all
-					// updates
-					if !strings.Contains(resp.Error, ratelimit.ErrBlockAll.Error()) {
-						subLogger.Error("error updating repo", log.String("err", resp.Error), log.String("uri", string(repo.Name)))
-					}
-				}
-
-				if interval := getCustomInterval(subLogger, conf.Get(), string(repo.Name)); interval > 0 {
-					s.schedule.updateInterval(repo, interval)
-					return
-				}
-
-				if err != nil || (resp != nil && resp.Error != "") {
-					// On error we will double the current interval so that we back off and don't
-					// get stuck with problematic repos with low intervals.
-					if currentInterval, ok := s.schedule.getCurrentInterval(repo); ok {
-						s.schedule.updateInterval(repo, currentInterval*2)
-					}
-				} else if resp != nil && resp.LastFetched != nil && resp.LastChanged != nil {
-					// This is the heuristic that is described in the UpdateScheduler documentation.
-					// Update that documentation if you update this logic.
-					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
-					s.schedule.updateInterval(repo, interval)
-				}
-			}(ctx, repo, cancel)
+	if err != nil || (resp != nil && resp.Error != "") {
+		// On error we will double the current interval so that we back off and don't
+		// get stuck with problematic repos with low intervals.
+		currentInterval, ok, err := s.store.Interval(ctx, repo.ID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return s.updateInterval(ctx, repo, currentInterval*2)
 		}
+	} else if resp != nil && resp.LastFetched != nil && resp.LastChanged != nil {
+		// This is the heuristic that is described in the UpdateScheduler documentation.
+		// Update that documentation if you update this logic.
+		interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
+		if changeIntervalKnown {
+			interval = (interval + changeInterval/2) / 2
+		}
+		return s.updateInterval(ctx, repo, interval)
 	}
+
+	return nil
+}
+
+// updateInterval updates the update interval of a repo in the schedule, after
+// clamping it to [minDelay, maxDelay] and adding jitter. It does nothing if the
+// repo is not in the schedule.
+func (s *UpdateScheduler) updateInterval(ctx context.Context, repo configuredRepo, interval time.Duration) error {
+	interval = s.jitter(clampInterval(interval))
+
+	s.logger.Debug("updated repo",
+		log.Object("repo", log.String("name", string(repo.Name)), log.Duration("due", interval)),
+	)
+	return s.store.UpdateInterval(ctx, repo.ID, interval)
+}
+
+// clampInterval clamps the given update interval to [minDelay, maxDelay].
+func clampInterval(interval time.Duration) time.Duration {
+	switch {
+	case interval > maxDelay:
+		return maxDelay
+	case interval < minDelay:
+		return minDelay
+	default:
+		return interval
+	}
+}
+
+// jitter adds a jitter of 5% on either side of the interval to avoid repos
+// getting updated at the same time.
+func (s *UpdateScheduler) jitter(interval time.Duration) time.Duration {
+	s.randMu.Lock()
+	defer s.randMu.Unlock()
+
+	delta := int64(interval) / 20
+	return interval + time.Duration(s.randGenerator.Int63n(2*delta)-delta)
 }
 
 func getCustomInterval(logger log.Logger, c *conf.Unified, repoName string) time.Duration {
@@ -273,17 +446,6 @@ var requestRepoUpdate = func(ctx context.Context, repo configuredRepo, since tim
 	return gitserver.NewClient().RequestRepoUpdate(ctx, repo.Name, since)
 }
 
-// configuredLimiter returns a mutable limiter that is
-// configured with the maximum number of concurrent update
-// requests that repo-updater should send to gitserver.
-var configuredLimiter = func() *limiter.MutableLimiter {
-	limiter := limiter.NewMutable(1)
-	conf.Watch(func() {
-		limiter.SetLimit(conf.GitMaxConcurrentClones())
-	})
-	return limiter
-}
-
 // UpdateFromDiff updates the scheduled and queued repos from the given sync
 // diff.
 //
@@ -301,27 +463,36 @@ var configuredLimiter = func() *limiter.MutableLimiter {
 //	             commits. Enqueue for asap clone (or fetch).
 //	Unmodified - we likely already have this cloned. Just rely on
 //	             the scheduler and do not enqueue.
-func (s *UpdateScheduler) UpdateFromDiff(diff Diff) {
+func (s *UpdateScheduler) UpdateFromDiff(ctx context.Context, diff Diff) {
+	var removed, scheduled, enqueued []api.RepoID
+
 	for _, r := range diff.Deleted {
-		s.remove(r)
+		removed = append(removed, r.ID)
 	}
 
 	for _, r := range diff.Added {
-		s.upsert(r, true)
+		enqueued = append(enqueued, r.ID)
 	}
 	for _, r := range diff.Modified.Repos() {
-		s.upsert(r, true)
+		enqueued = append(enqueued, r.ID)
 	}
 
-	known := len(diff.Added) + len(diff.Modified)
 	for _, r := range diff.Unmodified {
 		if r.IsDeleted() {
-			s.remove(r)
+			removed = append(removed, r.ID)
 			continue
 		}
+		scheduled = append(scheduled, r.ID)
+	}
 
-		known++
-		s.upsert(r, false)
+	if err := s.store.Remove(ctx, removed); err != nil {
+		s.logger.Error("error removing repos from scheduler", log.Error(err))
+	}
+	if err := s.store.Schedule(ctx, append(scheduled, enqueued...)); err != nil {
+		s.logger.Error("error scheduling repos", log.Error(err))
+	}
+	if err := s.store.Enqueue(ctx, enqueued, priorityLow); err != nil {
+		s.logger.Error("error enqueuing repo updates", log.Error(err))
 	}
 }
 
@@ -330,129 +501,57 @@ func (s *UpdateScheduler) UpdateFromDiff(diff Diff) {
 //
 // This method should be called periodically with the list of all repositories
 // managed by the scheduler that are not cloned on gitserver.
-func (s *UpdateScheduler) PrioritiseUncloned(repos []types.MinimalRepo) {
-	s.schedule.prioritiseUncloned(repos)
+func (s *UpdateScheduler) PrioritiseUncloned(ctx context.Context, repos []types.MinimalRepo) error {
+	return s.store.PrioritiseUncloned(ctx, minimalRepoIDs(repos))
 }
 
 // EnsureScheduled ensures that all repos in repos exist in the scheduler.
-func (s *UpdateScheduler) EnsureScheduled(repos []types.MinimalRepo) {
-	s.schedule.insertNew(repos)
+func (s *UpdateScheduler) EnsureScheduled(ctx context.Context, repos []types.MinimalRepo) error {
+	return s.store.Schedule(ctx, minimalRepoIDs(repos))
 }
 
-// ListRepoIDs lists the ids of all repos managed by the scheduler
-func (s *UpdateScheduler) ListRepoIDs() []api.RepoID {
-	s.schedule.mu.Lock()
-	defer s.schedule.mu.Unlock()
-
-	ids := make([]api.RepoID, len(s.schedule.heap))
-	for i := range s.schedule.heap {
-		ids[i] = s.schedule.heap[i].Repo.ID
+func minimalRepoIDs(repos []types.MinimalRepo) []api.RepoID {
+	ids := make([]api.RepoID, len(repos))
+	for i := range repos {
+		ids[i] = repos[i].ID
 	}
 	return ids
 }
 
-// upsert adds r to the scheduler for periodic updates. If r.ID is already in
-// the scheduler, then the fields are updated (upsert).
-//
-// If enqueue is true then r is also enqueued to the update queue for a git
-// fetch/clone soon.
-func (s *UpdateScheduler) upsert(r *types.Repo, enqueue bool) {
-	repo := configuredRepoFromRepo(r)
-	logger := s.logger.With(log.String("repo", string(r.Name)))
-
-	updated := s.schedule.upsert(repo)
-	logger.Debug("scheduler.schedule.upserted", log.Bool("updated", updated))
-
-	if !enqueue {
-		return
-	}
-	updated = s.updateQueue.enqueue(repo, priorityLow)
-	logger.Debug("scheduler.updateQueue.enqueued", log.Bool("updated", updated))
-}
-
-func (s *UpdateScheduler) remove(r *types.Repo) {
-	repo := configuredRepoFromRepo(r)
-	logger := s.logger.With(log.String("repo", string(r.Name)))
-
-	if s.schedule.remove(repo) {
-		logger.Debug("scheduler.schedule.removed")
-	}
-
-	if s.updateQueue.remove(repo, false) {
-		logger.Debug("scheduler.updateQueue.removed")
-	}
-}
-
-func configuredRepoFromRepo(r *types.Repo) configuredRepo {
-	repo := configuredRepo{
-		ID:   r.ID,
-		Name: r.Name,
-	}
-
-	return repo
+// ListRepoIDs lists the ids of all repos managed by the scheduler
+func (s *UpdateScheduler) ListRepoIDs(ctx context.Context) ([]api.RepoID, error) {
+	return s.store.ListRepoIDs(ctx)
 }
 
 // UpdateOnce causes a single update of the given repository.
 // It neither adds nor removes the repo from the schedule.
-func (s *UpdateScheduler) UpdateOnce(id api.RepoID, name api.RepoName) {
-	repo := configuredRepo{
-		ID:   id,
-		Name: name,
-	}
+func (s *UpdateScheduler) UpdateOnce(ctx context.Context, id api.RepoID, name api.RepoName) error {
 	schedManualFetch.Inc()
-	s.updateQueue.enqueue(repo, priorityHigh)
+	return s.store.Enqueue(ctx, []api.RepoID{id}, priorityHigh)
 }
 
 // DebugDump returns the state of the update scheduler for debugging.
 func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 	data := struct {
 		Name        string
-		UpdateQueue []*repoUpdate
-		Schedule    []*scheduledRepoUpdate
+		UpdateQueue []*RepoUpdateJob
+		Schedule    []*ScheduledRepoUpdate
 		SyncJobs    []*types.ExternalServiceSyncJob
 	}{
 		Name: "repos",
 	}
 
-	s.schedule.mu.Lock()
-	schedule := schedule{
-		heap: make([]*scheduledRepoUpdate, len(s.schedule.heap)),
-	}
-	for i, update := range s.schedule.heap {
-		// Copy the scheduledRepoUpdate as a value so that
-		// popping off the heap here won't update the index value of the real heap, and
-		// we don't do a racy read on the repo pointer which may change concurrently in the real heap.
-		updateCopy := *update
-		schedule.heap[i] = &updateCopy
-	}
-	s.schedule.mu.Unlock()
-
-	for len(schedule.heap) > 0 {
-		update := heap.Pop(&schedule).(*scheduledRepoUpdate)
-		data.Schedule = append(data.Schedule, update)
-	}
-
-	s.updateQueue.mu.Lock()
-	updateQueue := updateQueue{
-		heap: make([]*repoUpdate, len(s.updateQueue.heap)),
-	}
-	for i, update := range s.updateQueue.heap {
-		// Copy the repoUpdate as a value so that
-		// popping off the heap here won't update the index value of the real heap, and
-		// we don't do a racy read on the repo pointer which may change concurrently in the real heap.
-		updateCopy := *update
-		updateQueue.heap[i] = &updateCopy
+	var err error
+	data.Schedule, err = s.store.ListSchedule(ctx)
+	if err != nil {
+		s.logger.Warn("getting repo update schedule for debug page", log.Error(err))
 	}
-	s.updateQueue.mu.Unlock()
 
-	for len(updateQueue.heap) > 0 {
-		// Copy the scheduledRepoUpdate as a value so that the repo pointer
-		// won't change concurrently after we release the lock.
-		update := heap.Pop(&updateQueue).(*repoUpdate)
-		data.UpdateQueue = append(data.UpdateQueue, update)
+	data.UpdateQueue, err = s.store.ListQueue(ctx)
+	if err != nil {
+		s.logger.Warn("getting repo update queue for debug page", log.Error(err))
 	}
 
-	var err error
 	data.SyncJobs, err = s.db.ExternalServices().GetSyncJobs(ctx, database.ExternalServicesGetSyncJobsOptions{})
 	if err != nil {
 		s.logger.Warn("getting external service sync jobs for debug page", log.Error(err))
@@ -462,488 +561,6 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 }
 
 // ScheduleInfo returns the current schedule info for a repo.
-func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
-	var result protocol.RepoUpdateSchedulerInfoResult
-
-	s.schedule.mu.Lock()
-	if update := s.schedule.index[id]; update != nil {
-		result.Schedule = &protocol.RepoScheduleState{
-			Index:           update.Index,
-			Total:           len(s.schedule.index),
-			IntervalSeconds: int(update.Interval / time.Second),
-			Due:             update.Due,
-		}
-	}
-	s.schedule.mu.Unlock()
-
-	s.updateQueue.mu.Lock()
-	if update := s.updateQueue.index[id]; update != nil {
-		result.Queue = &protocol.RepoQueueState{
-			Index:    update.Index,
-			Total:    len(s.updateQueue.index),
-			Updating: update.Updating,
-			Priority: int(update.Priority),
-		}
-	}
-	s.updateQueue.mu.Unlock()
-
-	return &result
-}
-
-// updateQueue is a priority queue of repos to update.
-// A repo can't have more than one location in the queue.
-// Implements heap.Interface and sort.Interface.
-type updateQueue struct {
-	mu sync.Mutex
-
-	heap  []*repoUpdate
-	index map[api.RepoID]*repoUpdate
-
-	seq uint64
-
-	// The queue performs a non-blocking send on this channel
-	// when a new value is enqueued so that the update loop
-	// can wake up if it is idle.
-	notifyEnqueue chan struct{}
-}
-
-type priority int
-
-const (
-	priorityLow priority = iota
-	priorityHigh
-)
-
-// repoUpdate is a repository that has been queued for an update.
-type repoUpdate struct {
-	Repo     configuredRepo
-	Priority priority
-	Seq      uint64 // the sequence number of the update
-	Updating bool   // whether the repo has been acquired for update
-	Index    int    `json:"-"` // the index in the heap
-}
-
-func (q *updateQueue) reset() {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-
-	q.heap = q.heap[:0]
-	q.index = map[api.RepoID]*repoUpdate{}
-	q.seq = 0
-	q.notifyEnqueue = make(chan struct{}, notifyChanBuffer)
-
-	schedUpdateQueueLength.Set(0)
-}
-
-// enqueue adds the repo to the queue with the given priority.
-//
-// If the repo is already in the queue and it isn't yet updating,
-// the repo is updated.
-//
-// If the given priority is higher than the one in the queue,
-// the repo's position in the queue is updated accordingly.
-func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
-	if repo.ID == 0 {
-		panic("repo.id is zero")
-	}
-
-	q.mu.Lock()
-	defer q.mu.Unlock()
-
-	update := q.index[repo.ID]
-	if update == nil {
-		heap.Push(q, &repoUpdate{
-			Repo:     repo,
-			Priority: p,
-		})
-		notify(q.notifyEnqueue)
-		return false
-	}
-
-	if update.Updating {
-		return false
-	}
-
-	update.Repo = repo
-	if p <= update.Priority {
-		// Repo is already in the queue with at least as good priority.
-		return true
-	}
-
-	// Repo is in the queue at a lower priority.
-	update.Priority = p      // bump the priority
-	update.Seq = q.nextSeq() // put it after all existing updates with this priority
-	heap.Fix(q, update.Index)
-	notify(q.notifyEnqueue)
-
-	return true
-}
-
-// nextSeq increments and returns the next sequence number.
-// The caller must hold the lock on q.mu.
-func (q *updateQueue) nextSeq() uint64 {
-	q.seq++
-	return q.seq
-}
-
-// remove removes the repo from the queue if the repo.Updating matches the updating argument.
-func (q *updateQueue) remove(repo configuredRepo, updating bool) (removed bool) {
-	if repo.ID == 0 {
-		panic("repo.id is zero")
-	}
-
-	q.mu.Lock()
-	defer q.mu.Unlock()
-
-	update := q.index[repo.ID]
-	if update != nil && update.Updating == updating {
-		heap.Remove(q, update.Index)
-		return true
-	}
-
-	return false
-}
-
-// acquireNext acquires the next repo for update.
-// The acquired repo must be removed from the queue
-// when the update finishes (independent of success or failure).
-func (q *updateQueue) acquireNext() (configuredRepo, bool) {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	if len(q.heap) == 0 {
-		return configuredRepo{}, false
-	}
-	update := q.heap[0]
-	if update.Updating {
-		// Everything in the queue is already updating.
-		return configuredRepo{}, false
-	}
-	update.Updating = true
-	heap.Fix(q, update.Index)
-	return update.Repo, true
-}
-
-// The following methods implement heap.Interface based on the priority queue example:
-// https://golang.org/pkg/container/heap/#example__priorityQueue
-// These methods are not safe for concurrent use. Therefore, it is the caller's
-// responsibility to ensure they're being guarded by a mutex during any heap operation,
-// i.e. heap.Fix, heap.Remove, heap.Push, heap.Pop.
-
-func (q *updateQueue) Len() int {
-	n := len(q.heap)
-	schedUpdateQueueLength.Set(float64(n))
-	return n
-}
-
-func (q *updateQueue) Less(i, j int) bool {
-	qi := q.heap[i]
-	qj := q.heap[j]
-	if qi.Updating != qj.Updating {
-		// Repos that are already updating are sorted last.
-		return qj.Updating
-	}
-	if qi.Priority != qj.Priority {
-		// We want Pop to give us the highest, not lowest, priority so we use greater than here.
-		return qi.Priority > qj.Priority
-	}
-	// Queue semantics for items with the same priority.
-	return qi.Seq < qj.Seq
-}
-
-func (q *updateQueue) Swap(i, j int) {
-	q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
-	q.heap[i].Index = i
-	q.heap[j].Index = j
-}
-
-func (q *updateQueue) Push(x any) {
-	n := len(q.heap)
-	item := x.(*repoUpdate)
-	item.Index = n
-	item.Seq = q.nextSeq()
-	q.heap = append(q.heap, item)
-	q.index[item.Repo.ID] = item
-}
-
-func (q *updateQueue) Pop() any {
-	n := len(q.heap)
-	item := q.heap[n-1]
-	item.Index = -1 // for safety
-	q.heap = q.heap[0 : n-1]
-	delete(q.index, item.Repo.ID)
-	return item
-}
-
-// schedule is the schedule of when repos get enqueued into the updateQueue.
-type schedule struct {
-	mu sync.Mutex
-
-	heap  []*scheduledRepoUpdate // min heap of scheduledRepoUpdates based on their due time.
-	index map[api.RepoID]*scheduledRepoUpdate
-
-	// timer sends a value on the wakeup channel when it is time
-	timer  *time.Timer
-	wakeup chan struct{}
-	logger log.Logger
-
-	// random source used to add jitter to repo update intervals.
-	randGenerator interface {
-		Int63n(n int64) int64
-	}
+func (s *UpdateScheduler) ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+	return s.store.ScheduleInfo(ctx, id)
 }
-
-// scheduledRepoUpdate is the update schedule for a single repo.
-type scheduledRepoUpdate struct {
-	Repo     configuredRepo // the repo to update
-	Interval time.Duration  // how regularly the repo is updated
-	Due      time.Time      // the next time that the repo will be enqueued for a update
-	Index    int            `json:"-"` // the index in the heap
-}
-
-// upsert inserts or updates a repo in the schedule.
-func (s *schedule) upsert(repo configuredRepo) (updated bool) {
-	if repo.ID == 0 {
-		panic("repo.id is zero")
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if update := s.index[repo.ID]; update != nil {
-		update.Repo = repo
-		return true
-	}
-
-	heap.Push(s, &scheduledRepoUpdate{
-		Repo:     repo,
-		Interval: minDelay,
-		Due:      timeNow().Add(minDelay),
-	})
-
-	s.rescheduleTimer()
-
-	return false
-}
-
-func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
-	// All non-cloned repos will be due for cloning as if they are newly added
-	// repos.
-	notClonedDue := timeNow().Add(minDelay)
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Iterate over all repos in the scheduler. If it isn't in cloned bump it
-	// up the queue. Note: we iterate over index because we will be mutating
-	// heap.
-	rescheduleTimer := false
-	for _, repo := range uncloned {
-		if repoUpdate := s.index[repo.ID]; repoUpdate == nil {
-			heap.Push(s, &scheduledRepoUpdate{
-				Repo:     configuredRepo{ID: repo.ID, Name: repo.Name},
-				Interval: minDelay,
-				Due:      notClonedDue,
-			})
-			rescheduleTimer = true
-		} else if repoUpdate.Due.After(notClonedDue) {
-			repoUpdate.Due = notClonedDue
-			heap.Fix(s, repoUpdate.Index)
-			rescheduleTimer = true
-		}
-	}
-
-	// We updated the queue, inform the scheduler loop.
-	if rescheduleTimer {
-		s.rescheduleTimer()
-	}
-}
-
-// insertNew will insert repos only if they are not known to the scheduler
-func (s *schedule) insertNew(repos []types.MinimalRepo) {
-	required := make(map[string]struct{}, len(repos))
-	for _, n := range repos {
-		required[strings.ToLower(string(n.Name))] = struct{}{}
-	}
-
-	configuredRepos := make([]configuredRepo, len(repos))
-	for i := range repos {
-		configuredRepos[i] = configuredRepo{
-			ID:   repos[i].ID,
-			Name: repos[i].Name,
-		}
-	}
-
-	due := timeNow().Add(minDelay)
-	rescheduleTimer := false
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	for _, repo := range configuredRepos {
-		if update := s.index[repo.ID]; update != nil {
-			continue
-		}
-		heap.Push(s, &scheduledRepoUpdate{
-			Repo:     repo,
-			Interval: minDelay,
-			Due:      due,
-		})
-		rescheduleTimer = true
-	}
-
-	if rescheduleTimer {
-		s.rescheduleTimer()
-	}
-}
-
-// updateInterval updates the update interval of a repo in the schedule.
-// It does nothing if the repo is not in the schedule.
-func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
-	if repo.ID == 0 {
-		panic("repo.id is zero")
-	}
-
-	s.mu.Lock()
-	if update := s.index[repo.ID]; update != nil {
-		switch {
-		case interval > maxDelay:
-			update.Interval = maxDelay
-		case interval < minDelay:
-			update.Interval = minDelay
-		default:
-			update.Interval = interval
-		}
-
-		// Add a jitter of 5% on either side of the interval to avoid
-		// repos getting updated at the same time.
-		delta := int64(update.Interval) / 20
-		update.Interval = update.Interval + time.Duration(s.randGenerator.Int63n(2*delta)-delta)
-
-		update.Due = timeNow().Add(update.Interval)
-		s.logger.Debug("updated repo",
-			log.Object("repo", log.String("name", string(repo.Name)), log.Duration("due", update.Due.Sub(timeNow()))),
-		)
-		heap.Fix(s, update.Index)
-		s.rescheduleTimer()
-	}
-	s.mu.Unlock()
-}
-
-// getCurrentInterval gets the current interval for the supplied repo and a bool
-// indicating whether it was found.
-func (s *schedule) getCurrentInterval(repo configuredRepo) (time.Duration, bool) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	update, ok := s.index[repo.ID]
-	if !ok || update == nil {
-		return 0, false
-	}
-	return update.Interval, true
-}
-
-// remove removes a repo from the schedule.
-func (s *schedule) remove(repo configuredRepo) (removed bool) {
-	if repo.ID == 0 {
-		panic("repo.id is zero")
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	update := s.index[repo.ID]
-	if update == nil {
-		return false
-	}
-
-	reschedule := update.Index == 0
-	if heap.Remove(s, update.Index); reschedule {
-		s.rescheduleTimer()
-	}
-
-	return true
-}
-
-// rescheduleTimer schedules the scheduler to wakeup
-// at the time that the next repo is due for an update.
-// The caller must hold the lock on s.mu.
-func (s *schedule) rescheduleTimer() {
-	if s.timer != nil {
-		s.timer.Stop()
-		s.timer = nil
-	}
-	if len(s.heap) > 0 {
-		delay := s.heap[0].Due.Sub(timeNow())
-		s.timer = timeAfterFunc(delay, func() {
-			notify(s.wakeup)
-		})
-	}
-}
-
-func (s *schedule) reset() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.heap = s.heap[:0]
-	s.index = map[api.RepoID]*scheduledRepoUpdate{}
-	s.wakeup = make(chan struct{}, notifyChanBuffer)
-	if s.timer != nil {
-		s.timer.Stop()
-		s.timer = nil
-	}
-
-	s.logger.Debug("schedKnownRepos reset")
-	schedKnownRepos.Set(0)
-}
-
-// The following methods implement heap.Interface based on the priority queue example:
-// https://golang.org/pkg/container/heap/#example__priorityQueue
-// These methods are not safe for concurrent use. Therefore, it is the caller's
-// responsibility to ensure they're being guarded by a mutex during any heap operation,
-// i.e. heap.Fix, heap.Remove, heap.Push, heap.Pop.
-
-func (s *schedule) Len() int { return len(s.heap) }
-
-func (s *schedule) Less(i, j int) bool {
-	return s.heap[i].Due.Before(s.heap[j].Due)
-}
-
-func (s *schedule) Swap(i, j int) {
-	s.heap[i], s.heap[j] = s.heap[j], s.heap[i]
-	s.heap[i].Index = i
-	s.heap[j].Index = j
-}
-
-func (s *schedule) Push(x any) {
-	n := len(s.heap)
-	item := x.(*scheduledRepoUpdate)
-	item.Index = n
-	s.heap = append(s.heap, item)
-	s.index[item.Repo.ID] = item
-	schedKnownRepos.Inc()
-}
-
-func (s *schedule) Pop() any {
-	n := len(s.heap)
-	item := s.heap[n-1]
-	item.Index = -1 // for safety
-	s.heap = s.heap[0 : n-1]
-	delete(s.index, item.Repo.ID)
-	schedKnownRepos.Dec()
-	return item
-}
-
-// notify performs a non-blocking send on the channel.
-// The channel should be buffered.
-var notify = func(ch chan struct{}) {
-	select {
-	case ch <- struct{}{}:
-	default:
-	}
-}
-
-// Mockable time functions for testing.
-var (
-	timeNow       = time.Now
-	timeAfterFunc = time.AfterFunc
-)
@@ -0,0 +1,469 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+// SchedulerStore persists the schedule and the update queue of the
+// UpdateScheduler in the repo_update_schedule and repo_update_jobs tables, so
+// that they are shared by all repo-updater instances and survive restarts.
+type SchedulerStore interface {
+	// Schedule adds the given repos to the schedule, due after minDelay. Repos
+	// that are already scheduled are left untouched.
+	Schedule(ctx context.Context, ids []api.RepoID) error
+	// PrioritiseUncloned adds the given repos to the schedule like Schedule, but
+	// also moves repos that are already scheduled to be due after minDelay at the
+	// latest.
+	PrioritiseUncloned(ctx context.Context, ids []api.RepoID) error
+	// Remove removes the given repos from the schedule and the update queue.
+	// Updates that are already in progress are not affected.
+	Remove(ctx context.Context, ids []api.RepoID) error
+	// Enqueue queues an update of the given repos with the given priority. If an
+	// update of a repo is already queued with a lower priority, its priority is
+	// bumped and it's moved behind all updates with the new priority. Nothing
+	// happens for repos that are already being updated.
+	Enqueue(ctx context.Context, ids []api.RepoID, p priority) error
+	// EnqueueDue queues an update with low priority for at most limit repos whose
+	// schedule is due, reschedules them after their current interval and returns
+	// how many repos were due. Repos that received a push webhook within
+	// webhookWindow are rescheduled without being queued, and returned as
+	// covered.
+	EnqueueDue(ctx context.Context, limit int, webhookWindow time.Duration) (due, covered int, err error)
+	// RecordWebhook records that a push webhook for the given repo was
+	// received. It does nothing if the repo is not scheduled.
+	RecordWebhook(ctx context.Context, id api.RepoID) error
+	// UpdateInterval sets the update interval of the given repo and reschedules
+	// it after the interval. It does nothing if the repo is not scheduled.
+	UpdateInterval(ctx context.Context, id api.RepoID, interval time.Duration) error
+	// RecordChange records the time of the last commit of the given repo
+	// observed by a git fetch. If the repo changed since the previously recorded
+	// commit, the time between the two commits is added to the moving average of
+	// the time between commits, which is returned together with whether it is
+	// known.
+	RecordChange(ctx context.Context, id api.RepoID, lastChanged time.Time) (time.Duration, bool, error)
+	// Interval returns the current update interval of the given repo and whether
+	// the repo is scheduled.
+	Interval(ctx context.Context, id api.RepoID) (time.Duration, bool, error)
+	// ListRepoIDs lists the ids of all scheduled repos.
+	ListRepoIDs(ctx context.Context) ([]api.RepoID, error)
+	// ScheduleInfo returns the position of the given repo in the schedule and in
+	// the update queue.
+	ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)
+	// Counts returns the number of scheduled repos and of queued or running
+	// updates.
+	Counts(ctx context.Context) (scheduled, queued int, err error)
+	// ListSchedule returns the schedule, ordered by due time.
+	ListSchedule(ctx context.Context) ([]*ScheduledRepoUpdate, error)
+	// ListQueue returns the queued and running updates, in the order they are
+	// dequeued.
+	ListQueue(ctx context.Context) ([]*RepoUpdateJob, error)
+	// DeleteFinishedJobs deletes the updates that finished more than the given
+	// duration ago.
+	DeleteFinishedJobs(ctx context.Context, olderThan time.Duration) error
+}
+
+// ScheduledRepoUpdate is the update schedule for a single repo.
+type ScheduledRepoUpdate struct {
+	RepoID   api.RepoID
+	RepoName api.RepoName
+	Interval time.Duration // how regularly the repo is updated
+	Due      time.Time     // the next time that the repo will be enqueued for a update
+
+	// ChangeInterval is the learned average time between commits, or zero if
+	// it's not known yet.
+	ChangeInterval time.Duration
+}
+
+// RepoUpdateJob is a repository that has been queued for an update.
+type RepoUpdateJob struct {
+	ID             int
+	State          string
+	FailureMessage *string
+	QueuedAt       time.Time
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int
+	NumFailures    int
+	RepoID         api.RepoID
+	RepoName       api.RepoName
+	Priority       priority
+}
+
+// RecordID implements workerutil.Record and indicates the queued item id
+func (j *RepoUpdateJob) RecordID() int {
+	return j.ID
+}
+
+type priority int
+
+const (
+	priorityLow priority = iota
+	priorityHigh
+)
+
+type schedulerStore struct {
+	*basestore.Store
+}
+
+// NewSchedulerStore returns a SchedulerStore using the given database handle.
+func NewSchedulerStore(db database.DB) SchedulerStore {
+	return &schedulerStore{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+func (s *schedulerStore) Schedule(ctx context.Context, ids []api.RepoID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	seconds := int(minDelay / time.Second)
+	return s.Exec(ctx, sqlf.Sprintf(scheduleQueryFmtstr, seconds, seconds, pq.Array(ids), sqlf.Sprintf("DO NOTHING")))
+}
+
+func (s *schedulerStore) PrioritiseUncloned(ctx context.Context, ids []api.RepoID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	seconds := int(minDelay / time.Second)
+	return s.Exec(ctx, sqlf.Sprintf(
+		scheduleQueryFmtstr,
+		seconds,
+		seconds,
+		pq.Array(ids),
+		sqlf.Sprintf("(repo_id) DO UPDATE SET due_at = LEAST(repo_update_schedule.due_at, EXCLUDED.due_at)"),
+	))
+}
+
+const scheduleQueryFmtstr = `
+INSERT INTO repo_update_schedule (repo_id, interval_seconds, due_at)
+SELECT repo.id, %s::integer, NOW() + (%s * '1 second'::interval)
+FROM repo
+WHERE repo.id = ANY(%s) AND repo.deleted_at IS NULL
+ON CONFLICT %s
+`
+
+func (s *schedulerStore) Remove(ctx context.Context, ids []api.RepoID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(removeQueryFmtstr, pq.Array(ids), pq.Array(ids)))
+}
+
+const removeQueryFmtstr = `
+WITH removed_jobs AS (
+	DELETE FROM repo_update_jobs WHERE repo_id = ANY(%s) AND state = 'queued'
+)
+DELETE FROM repo_update_schedule WHERE repo_id = ANY(%s)
+`
+
+func (s *schedulerStore) Enqueue(ctx context.Context, ids []api.RepoID, p priority) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(enqueueQueryFmtstr, p, pq.Array(ids)))
+}
+
+// Setting queued_at to the current time when the priority is bumped puts the
+// update behind all other queued updates with the same priority. The ids are
+// deduplicated, as a row cannot be updated twice by the same statement.
+const enqueueQueryFmtstr = `
+INSERT INTO repo_update_jobs (repo_id, priority)
+SELECT DISTINCT id, %s::integer FROM unnest(%s::integer[]) AS id
+ON CONFLICT (repo_id) WHERE state IN ('queued', 'processing') DO UPDATE SET
+	priority = EXCLUDED.priority,
+	queued_at = NOW()
+WHERE
+	repo_update_jobs.state = 'queued'
+	AND repo_update_jobs.priority < EXCLUDED.priority
+`
+
+func (s *schedulerStore) EnqueueDue(ctx context.Context, limit int, webhookWindow time.Duration) (due, covered int, err error) {
+	windowSeconds := int(webhookWindow / time.Second)
+	rows, err := s.Query(ctx, sqlf.Sprintf(enqueueDueQueryFmtstr, limit, windowSeconds, priorityLow))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if rows.Next() {
+		err = rows.Scan(&due, &covered)
+	}
+	return due, covered, err
+}
+
+// Rows are locked with SKIP LOCKED so that concurrent repo-updater instances
+// enqueue disjoint sets of due repos.
+//
+// A repo is covered by a webhook if a push webhook for it was received within
+// the window. Webhooks only cover the repos they actually deliver events for,
+// and polling resumes once a repo's deliveries go stale.
+const enqueueDueQueryFmtstr = `
+WITH due AS (
+	SELECT repo_id, interval_seconds, last_webhook_at
+	FROM repo_update_schedule
+	WHERE due_at <= NOW()
+	ORDER BY due_at
+	LIMIT %s
+	FOR UPDATE SKIP LOCKED
+),
+covered AS (
+	SELECT repo_id
+	FROM due
+	WHERE last_webhook_at > NOW() - (%s * '1 second'::interval)
+),
+enqueued AS (
+	INSERT INTO repo_update_jobs (repo_id, priority)
+	SELECT repo_id, %s FROM due
+	WHERE repo_id NOT IN (SELECT repo_id FROM covered)
+	ON CONFLICT (repo_id) WHERE state IN ('queued', 'processing') DO NOTHING
+),
+rescheduled AS (
+	UPDATE repo_update_schedule s
+	SET due_at = NOW() + (due.interval_seconds * '1 second'::interval)
+	FROM due
+	WHERE s.repo_id = due.repo_id
+	RETURNING 1
+)
+SELECT
+	(SELECT COUNT(*) FROM rescheduled),
+	(SELECT COUNT(*) FROM covered)
+`
+
+func (s *schedulerStore) RecordWebhook(ctx context.Context, id api.RepoID) error {
+	return s.Exec(ctx, sqlf.Sprintf(`UPDATE repo_update_schedule SET last_webhook_at = NOW() WHERE repo_id = %s`, id))
+}
+
+func (s *schedulerStore) UpdateInterval(ctx context.Context, id api.RepoID, interval time.Duration) error {
+	seconds := int(interval / time.Second)
+	return s.Exec(ctx, sqlf.Sprintf(updateIntervalQueryFmtstr, seconds, seconds, id))
+}
+
+const updateIntervalQueryFmtstr = `
+UPDATE repo_update_schedule
+SET
+	interval_seconds = %s,
+	due_at = NOW() + (%s * '1 second'::interval)
+WHERE repo_id = %s
+`
+
+// changeIntervalWeight is the weight of the latest observed time between
+// commits in the moving average of the time between commits.
+const changeIntervalWeight = 0.25
+
+func (s *schedulerStore) RecordChange(ctx context.Context, id api.RepoID, lastChanged time.Time) (time.Duration, bool, error) {
+	seconds, ok, err := basestore.NewFirstScanner(scanNullInt)(s.Query(ctx, sqlf.Sprintf(
+		recordChangeQueryFmtstr,
+		changeIntervalWeight,
+		changeIntervalWeight,
+		lastChanged,
+		id,
+	)))
+	if err != nil || !ok || seconds == nil {
+		return 0, false, err
+	}
+	return time.Duration(*seconds) * time.Second, true, nil
+}
+
+// Commits that are not newer than the last recorded commit, e.g. after a force
+// push, don't change the moving average.
+const recordChangeQueryFmtstr = `
+UPDATE repo_update_schedule s
+SET
+	change_interval_seconds = CASE
+		WHEN s.last_changed_at IS NULL OR o.changed_at <= s.last_changed_at THEN s.change_interval_seconds
+		WHEN s.change_interval_seconds IS NULL THEN EXTRACT(EPOCH FROM o.changed_at - s.last_changed_at)::integer
+		ELSE ROUND(%s * EXTRACT(EPOCH FROM o.changed_at - s.last_changed_at) + (1 - %s) * s.change_interval_seconds)::integer
+	END,
+	last_changed_at = GREATEST(s.last_changed_at, o.changed_at)
+FROM (SELECT %s::timestamp with time zone AS changed_at) o
+WHERE s.repo_id = %s
+RETURNING s.change_interval_seconds
+`
+
+func scanNullInt(sc dbutil.Scanner) (*int, error) {
+	var n *int
+	err := sc.Scan(&n)
+	return n, err
+}
+
+func (s *schedulerStore) Interval(ctx context.Context, id api.RepoID) (time.Duration, bool, error) {
+	seconds, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		`SELECT interval_seconds FROM repo_update_schedule WHERE repo_id = %s`,
+		id,
+	)))
+	return time.Duration(seconds) * time.Second, ok, err
+}
+
+func (s *schedulerStore) ListRepoIDs(ctx context.Context) ([]api.RepoID, error) {
+	return basestore.NewSliceScanner(basestore.ScanAny[api.RepoID])(s.Query(ctx, sqlf.Sprintf(
+		`SELECT repo_id FROM repo_update_schedule ORDER BY repo_id`,
+	)))
+}
+
+func (s *schedulerStore) ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+	var result protocol.RepoUpdateSchedulerInfoResult
+
+	schedule, ok, err := basestore.NewFirstScanner(scanRepoScheduleState)(s.Query(ctx, sqlf.Sprintf(scheduleInfoQueryFmtstr, id)))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		result.Schedule = schedule
+	}
+
+	queue, ok, err := basestore.NewFirstScanner(scanRepoQueueState)(s.Query(ctx, sqlf.Sprintf(queueInfoQueryFmtstr, id)))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		result.Queue = queue
+	}
+
+	return &result, nil
+}
+
+const scheduleInfoQueryFmtstr = `
+SELECT
+	(SELECT COUNT(*) FROM repo_update_schedule o WHERE o.due_at < s.due_at),
+	(SELECT COUNT(*) FROM repo_update_schedule),
+	s.interval_seconds,
+	s.due_at
+FROM repo_update_schedule s
+WHERE s.repo_id = %s
+`
+
+// The index of a job in the queue is the number of jobs that are dequeued
+// before it. Running jobs are sorted last, like in the order of ListQueue.
+const queueInfoQueryFmtstr = `
+SELECT
+	CASE WHEN j.state = 'processing' THEN
+		(SELECT COUNT(*) FROM repo_update_jobs o WHERE o.state = 'queued')
+		+ (SELECT COUNT(*) FROM repo_update_jobs o WHERE o.state = 'processing' AND o.id < j.id)
+	ELSE
+		(SELECT COUNT(*) FROM repo_update_jobs o WHERE o.state = 'queued' AND (
+			o.priority > j.priority
+			OR (o.priority = j.priority AND (o.queued_at, o.id) < (j.queued_at, j.id))
+		))
+	END,
+	(SELECT COUNT(*) FROM repo_update_jobs WHERE state IN ('queued', 'processing')),
+	j.state = 'processing',
+	j.priority
+FROM repo_update_jobs j
+WHERE j.repo_id = %s AND j.state IN ('queued', 'processing')
+`
+
+func scanRepoScheduleState(sc dbutil.Scanner) (*protocol.RepoScheduleState, error) {
+	var state protocol.RepoScheduleState
+	return &state, sc.Scan(&state.Index, &state.Total, &state.IntervalSeconds, &state.Due)
+}
+
+func scanRepoQueueState(sc dbutil.Scanner) (*protocol.RepoQueueState, error) {
+	var state protocol.RepoQueueState
+	return &state, sc.Scan(&state.Index, &state.Total, &state.Updating, &state.Priority)
+}
+
+func (s *schedulerStore) Counts(ctx context.Context) (scheduled, queued int, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(`
+SELECT
+	(SELECT COUNT(*) FROM repo_update_schedule),
+	(SELECT COUNT(*) FROM repo_update_jobs WHERE state IN ('queued', 'processing'))
+`))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if rows.Next() {
+		err = rows.Scan(&scheduled, &queued)
+	}
+	return scheduled, queued, err
+}
+
+func (s *schedulerStore) ListSchedule(ctx context.Context) ([]*ScheduledRepoUpdate, error) {
+	return basestore.NewSliceScanner(scanScheduledRepoUpdate)(s.Query(ctx, sqlf.Sprintf(`
+SELECT s.repo_id, repo.name, s.interval_seconds, s.due_at, s.change_interval_seconds
+FROM repo_update_schedule s
+JOIN repo ON repo.id = s.repo_id
+ORDER BY s.due_at
+`)))
+}
+
+func scanScheduledRepoUpdate(sc dbutil.Scanner) (*ScheduledRepoUpdate, error) {
+	var (
+		update                ScheduledRepoUpdate
+		seconds               int
+		changeIntervalSeconds int
+	)
+	if err := sc.Scan(&update.RepoID, &update.RepoName, &seconds, &update.Due, &dbutil.NullInt{N: &changeIntervalSeconds}); err != nil {
+		return nil, err
+	}
+	update.Interval = time.Duration(seconds) * time.Second
+	update.ChangeInterval = time.Duration(changeIntervalSeconds) * time.Second
+	return &update, nil
+}
+
+func (s *schedulerStore) ListQueue(ctx context.Context) ([]*RepoUpdateJob, error) {
+	return basestore.NewSliceScanner(scanRepoUpdateJob)(s.Query(ctx, sqlf.Sprintf(
+		`SELECT %s FROM repo_update_jobs WHERE state IN ('queued', 'processing') ORDER BY %s`,
+		sqlf.Join(repoUpdateJobColumns, ", "),
+		sqlf.Sprintf("state = 'processing', %s", repoUpdateJobsOrderBy),
+	)))
+}
+
+func (s *schedulerStore) DeleteFinishedJobs(ctx context.Context, olderThan time.Duration) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		`DELETE FROM repo_update_jobs WHERE state IN ('completed', 'failed') AND finished_at < NOW() - (%s * '1 second'::interval)`,
+		int(olderThan/time.Second),
+	))
+}
+
+// repoUpdateJobsOrderBy is the order in which queued updates are dequeued:
+// high priority updates first, and in the order they were queued otherwise.
+var repoUpdateJobsOrderBy = sqlf.Sprintf("repo_update_jobs.priority DESC, repo_update_jobs.queued_at, repo_update_jobs.id")
+
+var repoUpdateJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("repo_update_jobs.id"),
+	sqlf.Sprintf("repo_update_jobs.state"),
+	sqlf.Sprintf("repo_update_jobs.failure_message"),
+	sqlf.Sprintf("repo_update_jobs.queued_at"),
+	sqlf.Sprintf("repo_update_jobs.started_at"),
+	sqlf.Sprintf("repo_update_jobs.finished_at"),
+	sqlf.Sprintf("repo_update_jobs.process_after"),
+	sqlf.Sprintf("repo_update_jobs.num_resets"),
+	sqlf.Sprintf("repo_update_jobs.num_failures"),
+	sqlf.Sprintf("repo_update_jobs.execution_logs"),
+	sqlf.Sprintf("repo_update_jobs.repo_id"),
+	sqlf.Sprintf("(SELECT name FROM repo WHERE repo.id = repo_update_jobs.repo_id)"),
+	sqlf.Sprintf("repo_update_jobs.priority"),
+}
+
+func scanRepoUpdateJob(sc dbutil.Scanner) (*RepoUpdateJob, error) {
+	// required field for the worker, but the value is thrown out here
+	var executionLogs *[]any
+
+	var job RepoUpdateJob
+	return &job, sc.Scan(
+		&job.ID,
+		&job.State,
+		&job.FailureMessage,
+		&job.QueuedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ProcessAfter,
+		&job.NumResets,
+		&job.NumFailures,
+		&executionLogs,
+		&job.RepoID,
+		&dbutil.NullString{S: (*string)(&job.RepoName)},
+		&job.Priority,
+	)
+}
@@ -0,0 +1,546 @@
+package repos
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func setupSchedulerStore(t *testing.T) (*schedulerStore, []*types.Repo) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	rs := []*types.Repo{
+		{Name: "github.com/sourcegraph/a"},
+		{Name: "github.com/sourcegraph/b"},
+		{Name: "github.com/sourcegraph/c"},
+	}
+	if err := db.Repos().Create(context.Background(), rs...); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewSchedulerStore(db).(*schedulerStore), rs
+}
+
+func TestSchedulerStore_Schedule(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID, rs[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+	// Scheduling again must not reset the interval.
+	if err := s.UpdateInterval(ctx, rs[0].ID, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := s.ListRepoIDs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]api.RepoID{rs[0].ID, rs[1].ID}, ids); diff != "" {
+		t.Fatalf("unexpected repo ids (-want +got):\n%s", diff)
+	}
+
+	interval, ok, err := s.Interval(ctx, rs[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || interval != time.Hour {
+		t.Fatalf("unexpected interval: have %v (%v), want %v", interval, ok, time.Hour)
+	}
+
+	if _, ok, err := s.Interval(ctx, rs[2].ID); err != nil || ok {
+		t.Fatalf("expected repo to not be scheduled, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestSchedulerStore_PrioritiseUncloned(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateInterval(ctx, rs[0].ID, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PrioritiseUncloned(ctx, []api.RepoID{rs[0].ID, rs[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := s.ListSchedule(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schedule) != 2 {
+		t.Fatalf("expected 2 scheduled repos, got %d", len(schedule))
+	}
+	for _, update := range schedule {
+		if due := time.Until(update.Due); due > minDelay {
+			t.Errorf("expected repo %d to be due within %v, got %v", update.RepoID, minDelay, due)
+		}
+	}
+}
+
+func TestSchedulerStore_Enqueue(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	for _, call := range []struct {
+		ids []api.RepoID
+		p   priority
+	}{
+		// Repos enqueued more than once by the same call are only queued once.
+		{[]api.RepoID{rs[0].ID, rs[1].ID, rs[0].ID}, priorityLow},
+		{[]api.RepoID{rs[2].ID}, priorityLow},
+		// Bumping the priority moves the update to the front of the queue.
+		{[]api.RepoID{rs[1].ID}, priorityHigh},
+		// Enqueuing with a lower priority has no effect.
+		{[]api.RepoID{rs[1].ID}, priorityLow},
+		{nil, priorityHigh},
+	} {
+		if err := s.Enqueue(ctx, call.ids, call.p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queue, err := s.ListQueue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have []api.RepoID
+	for _, job := range queue {
+		have = append(have, job.RepoID)
+	}
+	if diff := cmp.Diff([]api.RepoID{rs[1].ID, rs[0].ID, rs[2].ID}, have); diff != "" {
+		t.Fatalf("unexpected queue (-want +got):\n%s", diff)
+	}
+	if queue[0].Priority != priorityHigh || queue[0].RepoName != rs[1].Name {
+		t.Fatalf("unexpected first job: %+v", queue[0])
+	}
+
+	info, err := s.ScheduleInfo(ctx, rs[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Schedule != nil {
+		t.Errorf("expected repo to not be scheduled, got %+v", info.Schedule)
+	}
+	if info.Queue == nil || info.Queue.Index != 1 || info.Queue.Total != 3 || info.Queue.Updating {
+		t.Errorf("unexpected queue state: %+v", info.Queue)
+	}
+
+	if err := s.Remove(ctx, []api.RepoID{rs[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, queued, err := s.Counts(ctx); err != nil || queued != 2 {
+		t.Fatalf("expected 2 queued updates, got %d (err=%v)", queued, err)
+	}
+}
+
+func TestSchedulerStore_EnqueueDue(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID, rs[1].ID, rs[2].ID}); err != nil {
+		t.Fatal(err)
+	}
+	// Make the first two repos due.
+	if err := s.Exec(ctx, sqlf.Sprintf(
+		`UPDATE repo_update_schedule SET due_at = NOW() - '1 minute'::interval WHERE repo_id = ANY(ARRAY[%s, %s]::integer[])`,
+		rs[0].ID, rs[1].ID,
+	)); err != nil {
+		t.Fatal(err)
+	}
+	// An update of the second repo is already queued.
+	if err := s.Enqueue(ctx, []api.RepoID{rs[1].ID}, priorityHigh); err != nil {
+		t.Fatal(err)
+	}
+
+	due, covered, err := s.EnqueueDue(ctx, 10, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if due != 2 || covered != 0 {
+		t.Fatalf("expected 2 due repos and 0 covered repos, got %d and %d", due, covered)
+	}
+
+	scheduled, queued, err := s.Counts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scheduled != 3 || queued != 2 {
+		t.Fatalf("unexpected counts: scheduled=%d queued=%d", scheduled, queued)
+	}
+
+	// The due repos have been rescheduled.
+	if due, _, err := s.EnqueueDue(ctx, 10, time.Hour); err != nil || due != 0 {
+		t.Fatalf("expected no due repos, got %d (err=%v)", due, err)
+	}
+
+	// Finished updates are deleted.
+	if err := s.Exec(ctx, sqlf.Sprintf(
+		`UPDATE repo_update_jobs SET state = 'completed', finished_at = NOW() - '2 hours'::interval`,
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteFinishedJobs(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(`SELECT COUNT(*) FROM repo_update_jobs`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected finished updates to be deleted, got %d", count)
+	}
+}
+
+func TestSchedulerStore_EnqueueDue_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID, rs[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		webhook bool
+		age     time.Duration
+		covered int
+	}{
+		{name: "no webhook", covered: 0},
+		{name: "stale webhook", webhook: true, age: 2 * time.Hour, covered: 0},
+		{name: "recent webhook", webhook: true, covered: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := s.Exec(ctx, sqlf.Sprintf(`DELETE FROM repo_update_jobs`)); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Exec(ctx, sqlf.Sprintf(`UPDATE repo_update_schedule SET last_webhook_at = NULL`)); err != nil {
+				t.Fatal(err)
+			}
+			// Only the first repo receives webhooks, so the second repo must
+			// always be polled.
+			if tc.webhook {
+				if err := s.RecordWebhook(ctx, rs[0].ID); err != nil {
+					t.Fatal(err)
+				}
+				if err := s.Exec(ctx, sqlf.Sprintf(
+					`UPDATE repo_update_schedule SET last_webhook_at = last_webhook_at - (%s * '1 second'::interval)`,
+					int(tc.age/time.Second),
+				)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.Exec(ctx, sqlf.Sprintf(`UPDATE repo_update_schedule SET due_at = NOW() - '1 minute'::interval`)); err != nil {
+				t.Fatal(err)
+			}
+
+			due, covered, err := s.EnqueueDue(ctx, 10, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if due != 2 || covered != tc.covered {
+				t.Fatalf("unexpected result: due=%d covered=%d, want due=2 covered=%d", due, covered, tc.covered)
+			}
+
+			jobs, err := s.ListQueue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var queued []api.RepoID
+			for _, j := range jobs {
+				queued = append(queued, j.RepoID)
+			}
+			want := []api.RepoID{rs[1].ID}
+			if tc.covered == 0 {
+				want = []api.RepoID{rs[0].ID, rs[1].ID}
+			}
+			sort.Slice(queued, func(i, j int) bool { return queued[i] < queued[j] })
+			if diff := cmp.Diff(want, queued); diff != "" {
+				t.Fatalf("unexpected queued repos (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSchedulerStore_RecordChange(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		lastChanged time.Time
+		want        time.Duration
+	}{
+		// The first observed commit doesn't tell us anything about the cadence.
+		{lastChanged: defaultTime, want: 0},
+		{lastChanged: defaultTime.Add(4 * time.Hour), want: 4 * time.Hour},
+		// The same commit observed again doesn't change the cadence.
+		{lastChanged: defaultTime.Add(4 * time.Hour), want: 4 * time.Hour},
+		{lastChanged: defaultTime.Add(12 * time.Hour), want: 5 * time.Hour},
+	} {
+		have, ok, err := s.RecordChange(ctx, rs[0].ID, tc.lastChanged)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != tc.want || ok != (tc.want != 0) {
+			t.Fatalf("unexpected change interval after change at %v: have %v (%v), want %v", tc.lastChanged, have, ok, tc.want)
+		}
+	}
+
+	if _, ok, err := s.RecordChange(ctx, rs[1].ID, defaultTime); err != nil || ok {
+		t.Fatalf("expected no change interval for unscheduled repo, got ok=%v err=%v", ok, err)
+	}
+}
+
+// setJobState sets the state of the update of the given repo.
+func setJobState(t *testing.T, s *schedulerStore, id api.RepoID, state string, finishedAgo time.Duration) {
+	t.Helper()
+
+	if err := s.Exec(context.Background(), sqlf.Sprintf(
+		`UPDATE repo_update_jobs SET state = %s, finished_at = NOW() - (%s * '1 second'::interval) WHERE repo_id = %s`,
+		state, int(finishedAgo/time.Second), id,
+	)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchedulerStore_ScheduleInfo(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID, rs[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+	// The second repo is due before the first one.
+	if err := s.UpdateInterval(ctx, rs[0].ID, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Enqueue(ctx, []api.RepoID{rs[0].ID, rs[2].ID}, priorityLow); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Enqueue(ctx, []api.RepoID{rs[1].ID}, priorityHigh); err != nil {
+		t.Fatal(err)
+	}
+	setJobState(t, s, rs[2].ID, "processing", 0)
+
+	for _, tc := range []struct {
+		id           api.RepoID
+		wantSchedule *protocol.RepoScheduleState
+		wantQueue    *protocol.RepoQueueState
+	}{
+		{
+			id:           rs[0].ID,
+			wantSchedule: &protocol.RepoScheduleState{Index: 1, Total: 2, IntervalSeconds: 3600},
+			wantQueue:    &protocol.RepoQueueState{Index: 1, Total: 3, Priority: int(priorityLow)},
+		},
+		{
+			id:           rs[1].ID,
+			wantSchedule: &protocol.RepoScheduleState{Index: 0, Total: 2, IntervalSeconds: int(minDelay / time.Second)},
+			wantQueue:    &protocol.RepoQueueState{Index: 0, Total: 3, Priority: int(priorityHigh)},
+		},
+		{
+			// Running updates are listed after all queued updates.
+			id:        rs[2].ID,
+			wantQueue: &protocol.RepoQueueState{Index: 2, Total: 3, Updating: true, Priority: int(priorityLow)},
+		},
+	} {
+		info, err := s.ScheduleInfo(ctx, tc.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.wantSchedule, info.Schedule, cmpopts.IgnoreFields(protocol.RepoScheduleState{}, "Due")); diff != "" {
+			t.Errorf("unexpected schedule state of repo %d (-want +got):\n%s", tc.id, diff)
+		}
+		if diff := cmp.Diff(tc.wantQueue, info.Queue); diff != "" {
+			t.Errorf("unexpected queue state of repo %d (-want +got):\n%s", tc.id, diff)
+		}
+	}
+}
+
+func TestSchedulerStore_Remove(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	ids := []api.RepoID{rs[0].ID, rs[1].ID, rs[2].ID}
+	if err := s.Schedule(ctx, ids); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Enqueue(ctx, ids, priorityLow); err != nil {
+		t.Fatal(err)
+	}
+	setJobState(t, s, rs[1].ID, "processing", 0)
+
+	if err := s.Remove(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(ctx, []api.RepoID{rs[0].ID, rs[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	scheduled, err := s.ListRepoIDs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]api.RepoID{rs[2].ID}, scheduled); diff != "" {
+		t.Errorf("unexpected scheduled repos (-want +got):\n%s", diff)
+	}
+
+	// The running update of a removed repo is not affected.
+	queue, err := s.ListQueue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queued []api.RepoID
+	for _, job := range queue {
+		queued = append(queued, job.RepoID)
+	}
+	if diff := cmp.Diff([]api.RepoID{rs[2].ID, rs[1].ID}, queued); diff != "" {
+		t.Errorf("unexpected queue (-want +got):\n%s", diff)
+	}
+}
+
+func TestSchedulerStore_UpdateInterval(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Schedule(ctx, []api.RepoID{rs[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateInterval(ctx, rs[0].ID, 2*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	// Updating the interval of a repo that is not scheduled does nothing.
+	if err := s.UpdateInterval(ctx, rs[1].ID, 2*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := s.ListSchedule(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schedule) != 1 || schedule[0].RepoID != rs[0].ID {
+		t.Fatalf("expected only the first repo to be scheduled, got %+v", schedule)
+	}
+	if schedule[0].Interval != 2*time.Hour {
+		t.Errorf("unexpected interval: have %v, want %v", schedule[0].Interval, 2*time.Hour)
+	}
+	// The repo is rescheduled after the new interval.
+	if due := time.Until(schedule[0].Due); due < 2*time.Hour-time.Minute || due > 2*time.Hour {
+		t.Errorf("expected repo to be due in about %v, got %v", 2*time.Hour, due)
+	}
+}
+
+func TestSchedulerStore_ListQueue(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Enqueue(ctx, []api.RepoID{rs[0].ID, rs[1].ID}, priorityLow); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Enqueue(ctx, []api.RepoID{rs[2].ID}, priorityHigh); err != nil {
+		t.Fatal(err)
+	}
+	setJobState(t, s, rs[0].ID, "processing", 0)
+
+	queue, err := s.ListQueue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type job struct {
+		RepoID   api.RepoID
+		RepoName api.RepoName
+		State    string
+		Priority priority
+	}
+	var have []job
+	for _, j := range queue {
+		have = append(have, job{RepoID: j.RepoID, RepoName: j.RepoName, State: j.State, Priority: j.Priority})
+	}
+	// Queued updates are listed in the order they are dequeued, followed by the
+	// running updates.
+	want := []job{
+		{RepoID: rs[2].ID, RepoName: rs[2].Name, State: "queued", Priority: priorityHigh},
+		{RepoID: rs[1].ID, RepoName: rs[1].Name, State: "queued", Priority: priorityLow},
+		{RepoID: rs[0].ID, RepoName: rs[0].Name, State: "processing", Priority: priorityLow},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected queue (-want +got):\n%s", diff)
+	}
+
+	// Finished updates are not listed.
+	setJobState(t, s, rs[0].ID, "completed", 0)
+	queue, err = s.ListQueue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queue) != 2 {
+		t.Errorf("expected 2 queued updates, got %d", len(queue))
+	}
+}
+
+func TestSchedulerStore_DeleteFinishedJobs(t *testing.T) {
+	ctx := context.Background()
+	s, rs := setupSchedulerStore(t)
+
+	if err := s.Enqueue(ctx, []api.RepoID{rs[0].ID, rs[1].ID, rs[2].ID}, priorityLow); err != nil {
+		t.Fatal(err)
+	}
+	setJobState(t, s, rs[0].ID, "completed", 2*time.Hour)
+	setJobState(t, s, rs[1].ID, "failed", 2*time.Hour)
+	// A repo can only have one queued update, so the recently finished update
+	// is added for the first repo.
+	if err := s.Enqueue(ctx, []api.RepoID{rs[0].ID}, priorityLow); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Exec(ctx, sqlf.Sprintf(
+		`UPDATE repo_update_jobs SET state = 'completed', finished_at = NOW() - '1 minute'::interval WHERE repo_id = %s AND state = 'queued'`,
+		rs[0].ID,
+	)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteFinishedJobs(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	states, err := basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(`SELECT state FROM repo_update_jobs ORDER BY id`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only updates that finished more than an hour ago are deleted.
+	if diff := cmp.Diff([]string{"queued", "completed"}, states); diff != "" {
+		t.Errorf("unexpected remaining updates (-want +got):\n%s", diff)
+	}
+}
@@ -65,6 +65,10 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewAWSCodeCommitSource(ctx, svc, cf)
 	case extsvc.KindPerforce:
 		return NewPerforceSource(ctx, svc)
+	case extsvc.KindSubversion:
+		return NewSubversionSource(ctx, svc)
+	case extsvc.KindMercurial:
+		return NewMercurialSource(ctx, svc)
 	case extsvc.KindGoPackages:
 		return NewGoPackagesSource(ctx, svc, cf)
 	case extsvc.KindJVMPackages:
@@ -72,6 +76,8 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewJVMPackagesSource(ctx, svc)
 	case extsvc.KindPagure:
 		return NewPagureSource(ctx, svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, svc, cf)
 	case extsvc.KindNpmPackages:
 		return NewNpmPackagesSource(ctx, svc, cf)
 	case extsvc.KindPythonPackages:
@@ -80,6 +86,12 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewRustPackagesSource(ctx, svc, cf)
 	case extsvc.KindRubyPackages:
 		return NewRubyPackagesSource(ctx, svc, cf)
+	case extsvc.KindNuGetPackages:
+		return NewNuGetPackagesSource(ctx, svc, cf)
+	case extsvc.KindComposerPackages:
+		return NewComposerPackagesSource(ctx, svc, cf)
+	case extsvc.KindHexPackages:
+		return NewHexPackagesSource(ctx, svc, cf)
 	case extsvc.KindOther:
 		return NewOtherSource(ctx, svc, cf, logger.Scoped("OtherSource", ""))
 	default:
@@ -32,6 +32,9 @@ type Store interface {
 	// ExternalServiceStore returns a database.ExternalServiceStore using the same
 	// database handle.
 	ExternalServiceStore() database.ExternalServiceStore
+	// RepoKVPStore returns a database.RepoKVPStore using the same database
+	// handle.
+	RepoKVPStore() database.RepoKVPStore
 
 	// SetMetrics updates metrics for the store in place.
 	SetMetrics(m StoreMetrics)
@@ -128,6 +131,10 @@ func (s *store) ExternalServiceStore() database.ExternalServiceStore {
 	return database.ExternalServicesWith(s.Logger, s)
 }
 
+func (s *store) RepoKVPStore() database.RepoKVPStore {
+	return database.RepoKVPsWith(s)
+}
+
 func (s *store) SetMetrics(m StoreMetrics) { s.Metrics = m }
 func (s *store) SetTracer(t trace.Tracer)  { s.Tracer = t }
 
@@ -0,0 +1,105 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A SubversionSource yields repositories from a single Subversion connection
+// configured in Sourcegraph via the external services configuration. The
+// repositories are mirrored into Git by gitserver.
+type SubversionSource struct {
+	svc    *types.ExternalService
+	config *schema.SubversionConnection
+}
+
+// NewSubversionSource returns a new SubversionSource from the given external
+// service.
+func NewSubversionSource(ctx context.Context, svc *types.ExternalService) (*SubversionSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.SubversionConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return &SubversionSource{svc: svc, config: &c}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors returned
+// from the subsequent calls. This is going to be expanded as part of issue #44683
+// to actually only return true if the source can serve requests.
+func (s SubversionSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Subversion repositories configured on the connection.
+func (s SubversionSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	listMirroredRepos(ctx, s, extsvc.TypeSubversion, s.config.Url, s.config.Repos, s.config.RepositoryPathPattern, results)
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s SubversionSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+// listMirroredRepos sends a repository for each of the given paths relative to
+// baseURL. It is shared by the sources of version control systems that
+// gitserver mirrors into Git, whose repositories are listed in the connection
+// configuration.
+func listMirroredRepos(ctx context.Context, src Source, serviceType, baseURL string, paths []string, repositoryPathPattern string, results chan SourceResult) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		results <- SourceResult{Source: src, Err: errors.Wrap(err, "parsing url")}
+		return
+	}
+	base.User = nil
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+
+	urn := src.ExternalServices()[0].URN()
+	for _, p := range paths {
+		// Tiny optimization: exit early if context has been canceled.
+		if err := ctx.Err(); err != nil {
+			results <- SourceResult{Source: src, Err: err}
+			return
+		}
+		results <- SourceResult{Source: src, Repo: makeMirroredRepo(urn, serviceType, base, p, repositoryPathPattern)}
+	}
+}
+
+func makeMirroredRepo(urn, serviceType string, base *url.URL, path, repositoryPathPattern string) *types.Repo {
+	relativePath := strings.Trim(path, "/")
+	serviceID := base.String()
+	nameBase := (&url.URL{Host: base.Host, Path: base.Path}).String()
+
+	return &types.Repo{
+		Name: api.RepoName(reposource.OtherRepoName(repositoryPathPattern, nameBase, relativePath)),
+		URI:  reposource.OtherRepoName("", nameBase, relativePath),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          relativePath,
+			ServiceType: serviceType,
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: serviceID + relativePath,
+			},
+		},
+		Metadata: &extsvc.OtherRepoMetadata{
+			RelativePath: relativePath,
+		},
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -327,6 +328,7 @@ func (s *Syncer) SyncRepo(ctx context.Context, name api.RepoName, background boo
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 			defer cancel()
+			ctx = ratelimit.WithPriority(ctx, ratelimit.PrioritySync)
 
 			// We don't care about the return value here, but we still want to ensure that
 			// only one is in flight at a time.
@@ -503,6 +505,8 @@ func (s *Syncer) SyncExternalService(
 
 	// Ensure the job field is recorded when monitoring external API calls
 	ctx = metrics.ContextWithTask(ctx, "SyncExternalService")
+	// Leave part of the code host rate limit budget to interactive requests
+	ctx = ratelimit.WithPriority(ctx, ratelimit.PrioritySync)
 
 	var svc *types.ExternalService
 	ctx, save := s.observeSync(ctx, "Syncer.SyncExternalService", "")
@@ -740,6 +744,11 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		}
 	}()
 
+	// Remember the key-value pairs synced from the code host before sourced is
+	// overwritten with the stored repo.
+	kvps := sourced.KeyValuePairs
+	var repoID api.RepoID
+
 	stored, err := tx.RepoStore().List(ctx, database.ReposListOptions{
 		Names:          []string{string(sourced.Name)},
 		ExternalRepos:  []api.ExternalRepoSpec{sourced.ExternalRepo},
@@ -794,6 +803,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		modified := stored[0].Update(sourced)
 		if modified == types.RepoUnmodified {
 			d.Unmodified = append(d.Unmodified, stored[0])
+			repoID = stored[0].ID
 			break
 		}
 
@@ -802,6 +812,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		}
 
 		*sourced = *stored[0]
+		repoID = sourced.ID
 		d.Modified = append(d.Modified, RepoModified{Repo: stored[0], Modified: modified})
 		s.ObsvCtx.Logger.Debug("appended to modified repos")
 	case 0: // New repo, create.
@@ -817,12 +828,21 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return Diff{}, errors.Wrapf(err, "syncer: failed to create external service repo: %s", sourced.Name)
 		}
 
+		repoID = sourced.ID
 		d.Added = append(d.Added, sourced)
 		s.ObsvCtx.Logger.Debug("appended to added repos")
 	default: // Impossible since we have two separate unique constraints on name and external repo spec
 		panic("unreachable")
 	}
 
+	// Sources only set key-value pairs if syncing repository metadata is
+	// configured.
+	if kvps != nil {
+		if err = tx.RepoKVPStore().SetSynced(ctx, repoID, svc.ID, kvps); err != nil {
+			return Diff{}, errors.Wrapf(err, "syncer: failed to sync key-value pairs of repo %s", sourced.Name)
+		}
+	}
+
 	s.ObsvCtx.Logger.Debug("completed")
 	return d, nil
 }
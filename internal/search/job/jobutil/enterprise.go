@@ -14,6 +14,7 @@ import (
 type EnterpriseJobs interface {
 	FileHasOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job
 	SelectFileOwnerJob(child job.Job, features *search.Features) job.Job
+	PreciseSymbolSearchJob(child job.Job, repoOpts search.RepoOptions, patternInfo *search.TextPatternInfo, limit int) job.Job
 }
 
 func NewUnimplementedEnterpriseJobs() EnterpriseJobs {
@@ -30,6 +31,12 @@ func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Feat
 	return NewUnimplementedJob("`select:file.owners` searches are not available on this instance")
 }
 
+// PreciseSymbolSearchJob returns the child unchanged: without precise code intelligence,
+// symbol results always come from ctags.
+func (e *enterpriseJobs) PreciseSymbolSearchJob(child job.Job, repoOpts search.RepoOptions, patternInfo *search.TextPatternInfo, limit int) job.Job {
+	return child
+}
+
 func NewUnimplementedJob(msg string) *UnimplementedJob {
 	return &UnimplementedJob{msg: msg}
 }
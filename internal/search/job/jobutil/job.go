@@ -178,6 +178,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 
 	basicJob := NewParallelJob(children...)
 
+	{ // Prefer precise symbols over ctags symbols where available
+		if patternInfo, ok := isPreciseSymbolSearch(originalQuery, inputs); ok {
+			basicJob = enterpriseJobs.PreciseSymbolSearchJob(basicJob, toRepoOptions(originalQuery, inputs.UserSettings), patternInfo, originalQuery.MaxResults(inputs.DefaultLimit()))
+		}
+	}
+
 	{ // Apply file:contains.content() post-filter
 		if len(fileContainsPatterns) > 0 {
 			basicJob = NewFileContainsFilterJob(fileContainsPatterns, originalQuery.Pattern, b.IsCaseSensitive(), basicJob)
@@ -593,6 +599,28 @@ func count(b query.Basic, p search.Protocol) int {
 	panic("unreachable")
 }
 
+// isPreciseSymbolSearch returns the pattern to match against precise symbol names if the
+// query asks for symbols with `symbolsource:precise`. Only queries with at most a single
+// positive pattern can be answered from precise indexes.
+func isPreciseSymbolSearch(b query.Basic, inputs *search.Inputs) (*search.TextPatternInfo, bool) {
+	if b.SymbolSource() != query.SymbolSourcePrecise || b.IsStructural() {
+		return nil, false
+	}
+
+	resultTypes := computeResultTypes(b, inputs.PatternType)
+	if !resultTypes.Has(result.TypeSymbol) {
+		return nil, false
+	}
+
+	if b.Pattern != nil {
+		if p, ok := b.Pattern.(query.Pattern); !ok || p.Negated {
+			return nil, false
+		}
+	}
+
+	return toTextPatternInfo(b, resultTypes, inputs.Protocol), true
+}
+
 // toTextPatternInfo converts a an atomic query to internal values that drive
 // text search. An atomic query is a Basic query where the Pattern is either
 // nil, or comprises only one Pattern node (hence, an atom, and not an
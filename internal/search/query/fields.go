@@ -33,6 +33,9 @@ const (
 	FieldTimeout   = "timeout"
 	FieldCombyRule = "rule"
 	FieldSelect    = "select"
+
+	// FieldSymbolSource selects where symbol results come from. See SymbolSource.
+	FieldSymbolSource = "symbolsource"
 )
 
 var allFields = map[string]struct{}{
@@ -71,6 +74,7 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldSymbolSource:       empty,
 }
 
 var aliases = map[string]string{
@@ -579,6 +579,24 @@ func (p Parameters) Index() YesNoOnly {
 	return *v
 }
 
+const (
+	// SymbolSourceCtags resolves symbol results from the symbols service, which indexes
+	// repositories with ctags on demand. This is the default.
+	SymbolSourceCtags = "ctags"
+
+	// SymbolSourcePrecise resolves symbol results from precise code intelligence indexes
+	// where available, falling back to ctags for repositories without precise data.
+	SymbolSourcePrecise = "precise"
+)
+
+// SymbolSource returns the value of the "symbolsource:" field, defaulting to ctags.
+func (p Parameters) SymbolSource() string {
+	if v := p.FindValue(FieldSymbolSource); v != "" {
+		return v
+	}
+	return SymbolSourceCtags
+}
+
 func (p Parameters) Fork() *YesNoOnly {
 	return p.yesNoOnlyValue(FieldFork)
 }
@@ -222,6 +222,14 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return err
 	}
 
+	isValidSymbolSource := func() error {
+		switch value {
+		case SymbolSourceCtags, SymbolSourcePrecise:
+			return nil
+		}
+		return errors.Errorf("invalid value %q for field %q. Valid values are: %s, %s", value, field, SymbolSourceCtags, SymbolSourcePrecise)
+	}
+
 	isValidGitDate := func() error {
 		_, err := ParseGitDate(value, time.Now)
 		return err
@@ -298,6 +306,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldSymbolSource:
+		return satisfies(isSingular, isNotNegated, isValidSymbolSource)
 	default:
 		return isUnrecognizedField()
 	}
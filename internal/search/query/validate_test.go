@@ -17,6 +17,14 @@ func TestValidation(t *testing.T) {
 			input: "index:foo",
 			want:  `invalid value "foo" for field "index". Valid values are: yes, only, no`,
 		},
+		{
+			input: "type:symbol symbolsource:lsif",
+			want:  `invalid value "lsif" for field "symbolsource". Valid values are: ctags, precise`,
+		},
+		{
+			input: "type:symbol symbolsource:precise symbolsource:ctags",
+			want:  `field "symbolsource" may not be used more than once`,
+		},
 		{
 			input: "case:yes case:no",
 			want:  `field "case" may not be used more than once`,
@@ -25,6 +25,12 @@ type Symbol struct {
 	ParentKind string
 	Signature  string
 
+	// FullyQualifiedName, PackageName and PackageVersion are only set
+	// for symbols that come from precise code intelligence indexes.
+	FullyQualifiedName string
+	PackageName        string
+	PackageVersion     string
+
 	FileLimited bool
 }
 
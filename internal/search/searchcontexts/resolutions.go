@@ -0,0 +1,97 @@
+package searchcontexts
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ResolveQueryRepositories returns the repositories the query of a search context currently
+// matches, sorted by name, along with the revisions the query specifies for each of them.
+func ResolveQueryRepositories(ctx context.Context, db database.DB, searchContext *types.SearchContext) ([]types.SearchContextResolutionRepository, error) {
+	// 🚨 SECURITY: Resolutions are shared by all users that can see the search context, so
+	// they must contain all matching repositories and be filtered when they are read.
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("searchcontexts.ResolveQueryRepositories can only be accessed by an internal actor")
+	}
+	if searchContext.Query == "" {
+		return nil, errors.New("search context is not defined by a query")
+	}
+
+	opts, err := ParseRepoOpts(searchContext.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[api.RepoID]*types.SearchContextResolutionRepository{}
+	for _, o := range opts {
+		repos, err := db.Repos().ListMinimalRepos(ctx, o.ReposListOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			r, ok := byID[repo.ID]
+			if !ok {
+				r = &types.SearchContextResolutionRepository{ID: repo.ID, Name: repo.Name}
+				byID[repo.ID] = r
+			}
+			for _, rev := range o.RevSpecs {
+				if !slices.Contains(r.Revisions, rev) {
+					r.Revisions = append(r.Revisions, rev)
+				}
+			}
+		}
+	}
+
+	repositories := make([]types.SearchContextResolutionRepository, 0, len(byID))
+	for _, r := range byID {
+		sort.Strings(r.Revisions)
+		repositories = append(repositories, *r)
+	}
+	sort.Slice(repositories, func(i, j int) bool { return repositories[i].Name < repositories[j].Name })
+	return repositories, nil
+}
+
+// DiffResolutionRepositories returns the repositories of head that are not in base, and the
+// repositories of base that are not in head. Repositories are compared by ID, so renamed
+// repositories are neither added nor removed.
+func DiffResolutionRepositories(base, head []types.SearchContextResolutionRepository) (added, removed []types.SearchContextResolutionRepository) {
+	inBase := make(map[api.RepoID]struct{}, len(base))
+	for _, r := range base {
+		inBase[r.ID] = struct{}{}
+	}
+	inHead := make(map[api.RepoID]struct{}, len(head))
+	for _, r := range head {
+		inHead[r.ID] = struct{}{}
+		if _, ok := inBase[r.ID]; !ok {
+			added = append(added, r)
+		}
+	}
+	for _, r := range base {
+		if _, ok := inHead[r.ID]; !ok {
+			removed = append(removed, r)
+		}
+	}
+	return added, removed
+}
+
+// EqualResolutionRepositories reports whether two sorted lists of resolved repositories have the
+// same repositories with the same names and revisions.
+func EqualResolutionRepositories(a, b []types.SearchContextResolutionRepository) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || a[i].Name != b[i].Name || !slices.Equal(a[i].Revisions, b[i].Revisions) {
+			return false
+		}
+	}
+	return true
+}
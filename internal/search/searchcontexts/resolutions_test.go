@@ -0,0 +1,69 @@
+package searchcontexts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestResolveQueryRepositories(t *testing.T) {
+	repos := database.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]types.MinimalRepo, error) {
+		switch opts.IncludePatterns[0] {
+		case "foo":
+			return []types.MinimalRepo{{ID: 2, Name: "github.com/foo"}, {ID: 1, Name: "github.com/bar"}}, nil
+		case "bar":
+			return []types.MinimalRepo{{ID: 1, Name: "github.com/bar"}}, nil
+		}
+		return nil, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	searchContext := &types.SearchContext{Query: "(r:foo@main or r:bar@dev:main)"}
+
+	if _, err := ResolveQueryRepositories(context.Background(), db, searchContext); err == nil {
+		t.Fatal("expected error for non-internal actor")
+	}
+
+	got, err := ResolveQueryRepositories(actor.WithInternalActor(context.Background()), db, searchContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.SearchContextResolutionRepository{
+		{ID: 1, Name: "github.com/bar", Revisions: []string{"dev", "main"}},
+		{ID: 2, Name: "github.com/foo", Revisions: []string{"main"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected repositories (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffResolutionRepositories(t *testing.T) {
+	base := []types.SearchContextResolutionRepository{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	head := []types.SearchContextResolutionRepository{{ID: 2, Name: "b-renamed"}, {ID: 3, Name: "c"}}
+
+	added, removed := DiffResolutionRepositories(base, head)
+	if diff := cmp.Diff([]types.SearchContextResolutionRepository{{ID: 3, Name: "c"}}, added); diff != "" {
+		t.Fatalf("unexpected added repositories (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]types.SearchContextResolutionRepository{{ID: 1, Name: "a"}}, removed); diff != "" {
+		t.Fatalf("unexpected removed repositories (-want +got):\n%s", diff)
+	}
+
+	if EqualResolutionRepositories(base, head) {
+		t.Fatal("expected different repositories not to be equal")
+	}
+	withRevision := []types.SearchContextResolutionRepository{{ID: 1, Name: "a", Revisions: []string{"main"}}, {ID: 2, Name: "b"}}
+	if EqualResolutionRepositories(base, withRevision) {
+		t.Fatal("expected repositories with different revisions not to be equal")
+	}
+	if !EqualResolutionRepositories(base, []types.SearchContextResolutionRepository{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}) {
+		t.Fatal("expected same repositories to be equal")
+	}
+}
@@ -486,6 +486,24 @@ func ParseRepoOpts(contextQuery string) ([]RepoOpts, error) {
 			},
 		}
 
+		// The repo:has.* predicates allowed in search context queries narrow down the
+		// repositories the same way they do at search time.
+		rq.DescriptionPatterns = p.RepoHasDescription()
+		for _, filter := range p.RepoHasKVPs() {
+			rq.KVPFilters = append(rq.KVPFilters, database.RepoKVPFilter{
+				Key:     filter.Key,
+				Value:   filter.Value,
+				Negated: filter.Negated,
+				KeyOnly: filter.KeyOnly,
+			})
+		}
+		for _, filter := range p.RepoHasTopics() {
+			rq.TopicFilters = append(rq.TopicFilters, database.RepoTopicFilter{
+				Topic:   filter.Topic,
+				Negated: filter.Negated,
+			})
+		}
+
 		for _, r := range repoFilters {
 			for _, rev := range r.Revs {
 				if !rev.HasRefGlob() {
@@ -677,6 +677,21 @@ func TestParseRepoOpts(t *testing.T) {
 				},
 			},
 		},
+		{
+			in: "r:foo r:has.topic(go) -r:has.key(deprecated) r:has.description(service)",
+			out: []RepoOpts{
+				{
+					ReposListOptions: database.ReposListOptions{
+						IncludePatterns:     []string{"foo"},
+						DescriptionPatterns: []string{"(?:service)"},
+						KVPFilters:          []database.RepoKVPFilter{{Key: "deprecated", Negated: true, KeyOnly: true}},
+						TopicFilters:        []database.RepoTopicFilter{{Topic: "go"}},
+						NoForks:             true,
+						NoArchived:          true,
+					},
+				},
+			},
+		},
 		{
 			in: "(r:foo (rev:HEAD or rev:TAIL)) or r:bar@main:dev",
 			out: []RepoOpts{
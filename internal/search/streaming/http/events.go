@@ -137,6 +137,11 @@ type Symbol struct {
 	ContainerName string `json:"containerName"`
 	Kind          string `json:"kind"`
 	Line          int32  `json:"line"`
+
+	// Set only for symbols that come from precise code intelligence indexes.
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	PackageName        string `json:"packageName,omitempty"`
+	PackageVersion     string `json:"packageVersion,omitempty"`
 }
 
 // EventCommitMatch is the generic results interface from GQL. There is a lot
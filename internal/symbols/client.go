@@ -456,6 +456,67 @@ func (c *Client) symbolInfoJSON(ctx context.Context, args types.RepoCommitPathPo
 	return result, nil
 }
 
+// References returns the references to the symbol at the given point. References are only
+// available from the symbols service's HTTP API.
+func (c *Client) References(ctx context.Context, args types.ReferencesArgs) (result []types.RepoCommitPathRange, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "squirrel.Client.References") //nolint:staticcheck // OT is deprecated
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+		}
+		span.Finish()
+	}()
+	span.SetTag("Repo", args.Repo)
+	span.SetTag("CommitID", args.Commit)
+
+	resp, err := c.httpPost(ctx, "references", api.RepoName(args.Repo), args)
+	if err != nil {
+		return nil, errors.Wrap(err, "executing references request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// best-effort inclusion of body in error message
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, errors.Errorf(
+			"Squirrel.References http status %d: %s",
+			resp.StatusCode,
+			string(body),
+		)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding response body")
+	}
+
+	// 🚨 SECURITY: We have a valid result, so we need to apply sub-repo permissions filtering.
+	if c.SubRepoPermsChecker == nil {
+		return result, nil
+	}
+
+	checker := c.SubRepoPermsChecker()
+	if !authz.SubRepoEnabled(checker) {
+		return result, nil
+	}
+
+	a := actor.FromContext(ctx)
+	// Filter in place
+	filtered := result[:0]
+	for _, ref := range result {
+		ok, err := authz.FilterActorPath(ctx, checker, a, api.RepoName(ref.Repo), ref.Path)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, ref)
+		}
+	}
+
+	return filtered, nil
+}
+
 func (c *Client) httpPost(
 	ctx context.Context,
 	method string,
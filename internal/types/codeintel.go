@@ -158,6 +158,18 @@ type RepoCommitPathPoint struct {
 	Point
 }
 
+// MaxReferencesCandidatePaths is the maximum number of candidate paths of a ReferencesArgs. Each
+// candidate path is read and parsed, so requests with more are rejected.
+const MaxReferencesCandidatePaths = 100
+
+// ReferencesArgs describes a request for the references to the symbol at a point. The candidate
+// paths are other files at the same repository and commit that may reference the symbol, such as
+// the results of a text search for its name.
+type ReferencesArgs struct {
+	RepoCommitPathPoint
+	CandidatePaths []string `json:"candidatePaths,omitempty"`
+}
+
 type Point struct {
 	Row    int `json:"row"`
 	Column int `json:"column"`
@@ -61,6 +61,8 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.GerritConnection:
 		es.redactString(c.Password, "password")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
 	case *schema.BitbucketServerConnection:
 		es.redactString(c.Password, "password")
 		es.redactString(c.Token, "token")
@@ -73,6 +75,10 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.PerforceConnection:
 		es.redactString(c.P4Passwd, "p4.passwd")
+	case *schema.SubversionConnection:
+		es.redactString(c.Password, "password")
+	case *schema.MercurialConnection:
+		es.redactString(c.Password, "password")
 	case *schema.GitoliteConnection:
 		// Nothing to redact
 	case *schema.GoModulesConnection:
@@ -93,6 +99,19 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		// Nothing to redact
 	case *schema.RubyPackagesConnection:
 		es.redactString(c.Repository, "repository")
+	case *schema.NuGetPackagesConnection:
+		es.redactString(c.Credentials, "credentials")
+	case *schema.ComposerPackagesConnection:
+		// Private Packagist repositories embed an access token in the URL.
+		err = es.redactURL(c.Repository, "repository")
+		if err != nil {
+			return "", err
+		}
+	case *schema.HexPackagesConnection:
+		err = es.redactURL(c.Repository, "repository")
+		if err != nil {
+			return "", err
+		}
 	case *schema.JVMPackagesConnection:
 		es.redactString(c.Maven.Credentials, "maven", "credentials")
 	case *schema.PagureConnection:
@@ -104,6 +123,12 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		if err != nil {
 			return "", err
 		}
+		if c.Manifest != nil {
+			err = es.redactURL(c.Manifest.Url, "manifest", "url")
+			if err != nil {
+				return "", err
+			}
+		}
 	default:
 		// return an error; it's safer to fail than to incorrectly return unsafe data.
 		return "", errors.Errorf("Unrecognized ExternalServiceConfig for redaction: kind %+v not implemented", reflect.TypeOf(cfg))
@@ -202,6 +227,18 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 			return errCodeHostIdentityChanged{"p4.port", "p4.passwd"}
 		}
 		es.unredactString(c.P4Passwd, o.P4Passwd, "p4.passwd")
+	case *schema.SubversionConnection:
+		o := oldCfg.(*schema.SubversionConnection)
+		if c.Password == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "password"}
+		}
+		es.unredactString(c.Password, o.Password, "password")
+	case *schema.MercurialConnection:
+		o := oldCfg.(*schema.MercurialConnection)
+		if c.Password == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "password"}
+		}
+		es.unredactString(c.Password, o.Password, "password")
 	case *schema.GerritConnection:
 		o := oldCfg.(*schema.GerritConnection)
 		es.unredactString(c.Password, o.Password, "password")
@@ -225,6 +262,24 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 	case *schema.RubyPackagesConnection:
 		o := oldCfg.(*schema.RubyPackagesConnection)
 		es.unredactString(c.Repository, o.Repository, "repository")
+	case *schema.NuGetPackagesConnection:
+		o := oldCfg.(*schema.NuGetPackagesConnection)
+		if c.Credentials == RedactedSecret && c.Repository != o.Repository {
+			return errCodeHostIdentityChanged{"repository", "credentials"}
+		}
+		es.unredactString(c.Credentials, o.Credentials, "credentials")
+	case *schema.ComposerPackagesConnection:
+		o := oldCfg.(*schema.ComposerPackagesConnection)
+		err := es.unredactURL(c.Repository, o.Repository, "repository")
+		if err != nil {
+			return err
+		}
+	case *schema.HexPackagesConnection:
+		o := oldCfg.(*schema.HexPackagesConnection)
+		err := es.unredactURL(c.Repository, o.Repository, "repository")
+		if err != nil {
+			return err
+		}
 	case *schema.JVMPackagesConnection:
 		o := oldCfg.(*schema.JVMPackagesConnection)
 		// credentials didn't change check if repositories did
@@ -254,6 +309,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 			return errCodeHostIdentityChanged{"url", "token"}
 		}
 		es.unredactString(c.Token, o.Token, "token")
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		if c.Token == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "token"}
+		}
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.AzureDevOpsConnection:
 		o := oldCfg.(*schema.AzureDevOpsConnection)
 		if c.Token == RedactedSecret && c.Url != o.Url {
@@ -272,6 +333,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		if err != nil {
 			return err
 		}
+		if c.Manifest != nil && o.Manifest != nil {
+			err = es.unredactURL(c.Manifest.Url, o.Manifest.Url, "manifest", "url")
+			if err != nil {
+				return err
+			}
+		}
 		oldParsed, err := url.Parse(o.Url)
 		if err != nil {
 			return err
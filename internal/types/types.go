@@ -873,7 +873,13 @@ func (n PermissionNamespace) String() string {
 // Valid checks if a namespace is valid and supported by the Sourcegraph RBAC system.
 func (n PermissionNamespace) Valid() bool {
 	switch n {
-	case BatchChangesNamespace:
+	case BatchChangesNamespace,
+		CodeInsightsNamespace,
+		CodeMonitorsNamespace,
+		NotebooksNamespace,
+		SearchContextsNamespace,
+		CodeIntelNamespace,
+		CodyNamespace:
 		return true
 	default:
 		return false
@@ -883,6 +889,25 @@ func (n PermissionNamespace) Valid() bool {
 // BatchChangesNamespace represents the Batch Changes namespace.
 const BatchChangesNamespace PermissionNamespace = "BATCH_CHANGES"
 
+// CodeInsightsNamespace represents the Code Insights namespace.
+const CodeInsightsNamespace PermissionNamespace = "CODE_INSIGHTS"
+
+// CodeMonitorsNamespace represents the Code Monitors namespace.
+const CodeMonitorsNamespace PermissionNamespace = "CODE_MONITORS"
+
+// NotebooksNamespace represents the Notebooks namespace.
+const NotebooksNamespace PermissionNamespace = "NOTEBOOKS"
+
+// SearchContextsNamespace represents the Search Contexts namespace.
+const SearchContextsNamespace PermissionNamespace = "SEARCH_CONTEXTS"
+
+// CodeIntelNamespace represents the Code Intelligence namespace, covering precise
+// code intelligence uploads and auto-indexing configuration.
+const CodeIntelNamespace PermissionNamespace = "CODE_INTEL"
+
+// CodyNamespace represents the Cody namespace.
+const CodyNamespace PermissionNamespace = "CODY"
+
 type Permission struct {
 	ID        int32
 	Namespace PermissionNamespace
@@ -1852,6 +1877,26 @@ type SearchContextRepositoryRevisions struct {
 	Revisions []string
 }
 
+// SearchContextResolution is the set of repositories a query-based search context resolved to at
+// a point in time.
+type SearchContextResolution struct {
+	ID              int64
+	SearchContextID int64
+	// Repositories are sorted by name.
+	Repositories []SearchContextResolutionRepository
+	// Pinned resolutions are kept as a baseline to compare later resolutions against.
+	Pinned    bool
+	CreatedAt time.Time
+}
+
+// SearchContextResolutionRepository is a repository matched by a search context query, with the
+// revisions the query specified for it.
+type SearchContextResolutionRepository struct {
+	ID        api.RepoID   `json:"id"`
+	Name      api.RepoName `json:"name"`
+	Revisions []string     `json:"revisions,omitempty"`
+}
+
 type EncryptableSecret = encryption.Encryptable
 
 // NewUnencryptedSecret creates an EncryptableSecret that *may* be encrypted in
@@ -0,0 +1 @@
+ALTER TABLE codeintel_ranking_references DROP COLUMN IF EXISTS document_path;
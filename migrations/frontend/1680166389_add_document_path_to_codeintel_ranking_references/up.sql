@@ -0,0 +1,3 @@
+ALTER TABLE codeintel_ranking_references ADD COLUMN IF NOT EXISTS document_path TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN codeintel_ranking_references.document_path IS 'The path of the document containing the referenced symbols. Empty for references exported before document paths were tracked.';
@@ -0,0 +1,2 @@
+ALTER TABLE access_tokens DROP COLUMN IF EXISTS expiry_notified_at;
+ALTER TABLE access_tokens DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,5 @@
+ALTER TABLE access_tokens ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE access_tokens ADD COLUMN IF NOT EXISTS expiry_notified_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN access_tokens.expires_at IS 'The time after which the access token can no longer be used to authenticate. NULL if the access token does not expire.';
+COMMENT ON COLUMN access_tokens.expiry_notified_at IS 'The time at which the subject user was notified of the upcoming expiry of the access token.';
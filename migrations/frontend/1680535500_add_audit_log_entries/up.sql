@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS audit_log_entries (
+    id bigserial PRIMARY KEY,
+    audit_id text NOT NULL,
+    entity text NOT NULL,
+    action text NOT NULL,
+    actor_user_id integer,
+    actor_anonymous_uid text,
+    actor_ip text,
+    actor_forwarded_for text,
+    fields jsonb DEFAULT '{}'::jsonb NOT NULL,
+    source text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    exported_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entries_created_at ON audit_log_entries (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_entries_actor_user_id ON audit_log_entries (actor_user_id);
+CREATE INDEX IF NOT EXISTS audit_log_entries_action ON audit_log_entries (action);
+CREATE INDEX IF NOT EXISTS audit_log_entries_unexported ON audit_log_entries (id) WHERE exported_at IS NULL;
+
+COMMENT ON TABLE audit_log_entries IS 'Persisted entries of the audit log. Entries are deleted once they are older than the configured retention period.';
+COMMENT ON COLUMN audit_log_entries.audit_id IS 'The unique ID of the entry, as also written to the service logs.';
+COMMENT ON COLUMN audit_log_entries.actor_user_id IS 'The ID of the user that took the action. This is intentionally not a foreign key so entries outlive deleted users.';
+COMMENT ON COLUMN audit_log_entries.source IS 'The name of the service that recorded the entry.';
+COMMENT ON COLUMN audit_log_entries.exported_at IS 'When the entry was sent to the configured audit log export sink, or NULL if it has not been exported yet.';
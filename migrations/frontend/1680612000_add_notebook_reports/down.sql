@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notebook_report_snapshots;
+DROP TABLE IF EXISTS notebook_report_schedules;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS notebook_report_schedules (
+    id bigserial PRIMARY KEY,
+    notebook_id bigint NOT NULL REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    frequency text NOT NULL CONSTRAINT notebook_report_schedules_frequency_valid CHECK (frequency IN ('daily', 'weekly')),
+    send_email boolean DEFAULT false NOT NULL,
+    next_run_at timestamp with time zone DEFAULT now() NOT NULL,
+    last_run_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS notebook_report_schedules_notebook_id ON notebook_report_schedules (notebook_id);
+CREATE INDEX IF NOT EXISTS notebook_report_schedules_next_run_at ON notebook_report_schedules (next_run_at);
+
+COMMENT ON TABLE notebook_report_schedules IS 'Schedules for running the query blocks of a notebook server-side. A notebook has at most one schedule.';
+COMMENT ON COLUMN notebook_report_schedules.user_id IS 'The user that created the schedule. Query blocks are run with the permissions of this user, and emails are sent to them.';
+
+CREATE TABLE IF NOT EXISTS notebook_report_snapshots (
+    id bigserial PRIMARY KEY,
+    notebook_id bigint NOT NULL REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    blocks jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS notebook_report_snapshots_notebook_id ON notebook_report_snapshots (notebook_id, user_id, id);
+
+COMMENT ON TABLE notebook_report_snapshots IS 'The results of a single scheduled run of the query blocks of a notebook.';
+COMMENT ON COLUMN notebook_report_snapshots.blocks IS 'Result counts and top matches of each query block, keyed by block ID.';
+COMMENT ON COLUMN notebook_report_snapshots.user_id IS 'The user whose permissions the query blocks were run with. Only this user can view the snapshot.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS search_context_subscriptions;
+DROP TABLE IF EXISTS search_context_resolutions;
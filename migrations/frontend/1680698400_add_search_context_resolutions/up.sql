@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS search_context_resolutions (
+    id bigserial PRIMARY KEY,
+    search_context_id bigint NOT NULL REFERENCES search_contexts(id) ON DELETE CASCADE DEFERRABLE,
+    repositories jsonb DEFAULT '[]'::jsonb NOT NULL,
+    pinned boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS search_context_resolutions_search_context_id ON search_context_resolutions (search_context_id, id);
+CREATE UNIQUE INDEX IF NOT EXISTS search_context_resolutions_pinned ON search_context_resolutions (search_context_id) WHERE pinned;
+
+COMMENT ON TABLE search_context_resolutions IS 'The repositories a query-based search context resolved to. A row is only inserted when the set of repositories changes.';
+COMMENT ON COLUMN search_context_resolutions.repositories IS 'The ID, name and revisions of each repository the search context query matched.';
+COMMENT ON COLUMN search_context_resolutions.pinned IS 'Pinned resolutions are kept as a baseline for comparison and are never pruned. A search context has at most one pinned resolution.';
+
+CREATE TABLE IF NOT EXISTS search_context_subscriptions (
+    search_context_id bigint NOT NULL REFERENCES search_contexts(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (search_context_id, user_id)
+);
+
+COMMENT ON TABLE search_context_subscriptions IS 'Users that are emailed when the repositories a query-based search context resolves to change.';
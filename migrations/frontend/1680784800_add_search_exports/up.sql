@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS search_exports (
+    id SERIAL PRIMARY KEY,
+    state text DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT NOW(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer not null default 0,
+    num_failures integer not null default 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json [],
+    worker_hostname text not null default '',
+    cancel boolean DEFAULT false NOT NULL,
+    -- additional columns
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    query text NOT NULL,
+    format text NOT NULL,
+    repos_total integer NOT NULL DEFAULT 0,
+    repos_done integer NOT NULL DEFAULT 0,
+    match_count integer NOT NULL DEFAULT 0,
+    object_key text
+);
+
+CREATE INDEX IF NOT EXISTS search_exports_user_id ON search_exports (user_id, id);
+CREATE INDEX IF NOT EXISTS search_exports_state ON search_exports (state);
+
+COMMENT ON TABLE search_exports IS 'Asynchronous exports of all results of a search query, run by the worker.';
+COMMENT ON COLUMN search_exports.format IS 'The format the matches are written in, either csv or jsonl.';
+COMMENT ON COLUMN search_exports.object_key IS 'The key of the exported file in the upload store. Set once the export completed.';
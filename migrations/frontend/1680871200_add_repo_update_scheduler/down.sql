@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_update_jobs;
+DROP TABLE IF EXISTS repo_update_schedule;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS repo_update_schedule (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    interval_seconds integer NOT NULL,
+    due_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS repo_update_schedule_due_at ON repo_update_schedule (due_at);
+
+COMMENT ON TABLE repo_update_schedule IS 'The schedule of periodic git fetches of repositories, shared by all repo-updater instances.';
+COMMENT ON COLUMN repo_update_schedule.interval_seconds IS 'How regularly the repository is updated, based on how recently it changed.';
+COMMENT ON COLUMN repo_update_schedule.due_at IS 'The next time the repository is enqueued into repo_update_jobs.';
+
+CREATE TABLE IF NOT EXISTS repo_update_jobs (
+    id SERIAL PRIMARY KEY,
+    state text DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT NOW(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer not null default 0,
+    num_failures integer not null default 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json [],
+    worker_hostname text not null default '',
+    cancel boolean DEFAULT false NOT NULL,
+    -- additional columns
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    priority integer NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS repo_update_jobs_active_repo_id ON repo_update_jobs (repo_id) WHERE state IN ('queued', 'processing');
+CREATE INDEX IF NOT EXISTS repo_update_jobs_state ON repo_update_jobs (state);
+
+COMMENT ON TABLE repo_update_jobs IS 'The queue of git fetches (or clones) that repo-updater requests from gitserver.';
+COMMENT ON COLUMN repo_update_jobs.priority IS 'Jobs with a higher priority are dequeued first. Updates requested by users have a high priority (1), scheduled updates a low priority (0).';
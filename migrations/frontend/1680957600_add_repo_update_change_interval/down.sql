@@ -0,0 +1,4 @@
+ALTER TABLE repo_update_schedule
+    DROP COLUMN IF EXISTS last_changed_at,
+    DROP COLUMN IF EXISTS change_interval_seconds,
+    DROP COLUMN IF EXISTS last_webhook_at;
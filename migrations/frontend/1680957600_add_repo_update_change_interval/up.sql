@@ -0,0 +1,8 @@
+ALTER TABLE repo_update_schedule
+    ADD COLUMN IF NOT EXISTS last_changed_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS change_interval_seconds integer,
+    ADD COLUMN IF NOT EXISTS last_webhook_at timestamp with time zone;
+
+COMMENT ON COLUMN repo_update_schedule.last_changed_at IS 'The time of the last commit of the repository observed by a git fetch.';
+COMMENT ON COLUMN repo_update_schedule.change_interval_seconds IS 'Moving average of the time between commits of the repository observed by git fetches.';
+COMMENT ON COLUMN repo_update_schedule.last_webhook_at IS 'The time the last push webhook for the repository was received.';
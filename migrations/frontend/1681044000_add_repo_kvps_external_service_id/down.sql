@@ -0,0 +1,2 @@
+ALTER TABLE repo_kvps
+    DROP COLUMN IF EXISTS external_service_id;
@@ -0,0 +1,4 @@
+ALTER TABLE repo_kvps
+    ADD COLUMN IF NOT EXISTS external_service_id bigint REFERENCES external_services(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN repo_kvps.external_service_id IS 'The external service the key-value pair was synced from, or NULL if it was set manually.';
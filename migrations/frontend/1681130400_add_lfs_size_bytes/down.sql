@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS trig_recalc_repo_statistics_lfs_on_gitserver_update ON gitserver_repos;
+DROP TRIGGER IF EXISTS trig_recalc_repo_statistics_lfs_on_gitserver_delete ON gitserver_repos;
+
+DROP FUNCTION IF EXISTS recalc_repo_statistics_lfs_on_gitserver_update();
+DROP FUNCTION IF EXISTS recalc_repo_statistics_lfs_on_gitserver_delete();
+
+ALTER TABLE repo_statistics
+    DROP COLUMN IF EXISTS lfs_size_bytes;
+
+ALTER TABLE gitserver_repos
+    DROP COLUMN IF EXISTS lfs_size_bytes;
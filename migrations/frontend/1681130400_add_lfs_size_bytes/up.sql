@@ -0,0 +1,49 @@
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS lfs_size_bytes bigint;
+
+COMMENT ON COLUMN gitserver_repos.lfs_size_bytes IS 'Size of the Git LFS objects of the repository fetched by gitserver, in bytes.';
+
+ALTER TABLE repo_statistics
+    ADD COLUMN IF NOT EXISTS lfs_size_bytes bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN repo_statistics.lfs_size_bytes IS 'Total size of the Git LFS objects fetched by gitserver, in bytes';
+
+CREATE OR REPLACE FUNCTION recalc_repo_statistics_lfs_on_gitserver_update() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$ BEGIN
+      INSERT INTO repo_statistics (lfs_size_bytes)
+      SELECT diff.lfs_size_bytes
+      FROM (
+        SELECT (SELECT COALESCE(SUM(lfs_size_bytes), 0) FROM newtab) - (SELECT COALESCE(SUM(lfs_size_bytes), 0) FROM oldtab) AS lfs_size_bytes
+      ) diff
+      WHERE diff.lfs_size_bytes != 0;
+
+      RETURN NULL;
+  END
+$$;
+
+CREATE OR REPLACE FUNCTION recalc_repo_statistics_lfs_on_gitserver_delete() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$ BEGIN
+      INSERT INTO repo_statistics (lfs_size_bytes)
+      SELECT -diff.lfs_size_bytes
+      FROM (
+        SELECT COALESCE(SUM(lfs_size_bytes), 0) AS lfs_size_bytes FROM oldtab
+      ) diff
+      WHERE diff.lfs_size_bytes != 0;
+
+      RETURN NULL;
+  END
+$$;
+
+DROP TRIGGER IF EXISTS trig_recalc_repo_statistics_lfs_on_gitserver_update ON gitserver_repos;
+CREATE TRIGGER trig_recalc_repo_statistics_lfs_on_gitserver_update
+AFTER UPDATE ON gitserver_repos
+REFERENCING OLD TABLE AS oldtab NEW TABLE AS newtab
+FOR EACH STATEMENT EXECUTE FUNCTION recalc_repo_statistics_lfs_on_gitserver_update();
+
+DROP TRIGGER IF EXISTS trig_recalc_repo_statistics_lfs_on_gitserver_delete ON gitserver_repos;
+CREATE TRIGGER trig_recalc_repo_statistics_lfs_on_gitserver_delete
+AFTER DELETE ON gitserver_repos
+REFERENCING OLD TABLE AS oldtab
+FOR EACH STATEMENT EXECUTE FUNCTION recalc_repo_statistics_lfs_on_gitserver_delete();
@@ -84,16 +84,30 @@ type AppNotifications struct {
 
 // AuditLog description: EXPERIMENTAL: Configuration for audit logging (specially formatted log entries for tracking sensitive events)
 type AuditLog struct {
+	// Export description: Exports audit log entries to an HTTP endpoint, such as the HTTP event collector of a SIEM. Entries are sent in batches as the body of POST requests, one entry per line. All retained entries are exported the first time this is configured.
+	Export *AuditLogExport `json:"export,omitempty"`
 	// GitserverAccess description: Capture gitserver access logs as part of the audit log.
 	GitserverAccess bool `json:"gitserverAccess"`
 	// GraphQL description: Capture GraphQL requests and responses as part of the audit log.
 	GraphQL bool `json:"graphQL"`
 	// InternalTraffic description: Capture security events performed by the internal traffic (adds significant noise).
 	InternalTraffic bool `json:"internalTraffic"`
+	// RetentionDays description: The number of days audit log entries are kept in the database, where site admins can query them. Older entries are deleted periodically.
+	RetentionDays int `json:"retentionDays,omitempty"`
 	// SeverityLevel description: Severity logging level for the audit log.
 	SeverityLevel string `json:"severityLevel,omitempty"`
 }
 
+// AuditLogExport description: Exports audit log entries to an HTTP endpoint, such as the HTTP event collector of a SIEM. Entries are sent in batches as the body of POST requests, one entry per line. All retained entries are exported the first time this is configured.
+type AuditLogExport struct {
+	// Authorization description: The value of the Authorization header sent with each request, for example "Splunk <token>" or "Bearer <token>".
+	Authorization string `json:"authorization,omitempty"`
+	// Format description: The format of exported entries: "json" sends one JSON object per line (JSON Lines), "cef" sends one ArcSight Common Event Format record per line.
+	Format string `json:"format,omitempty"`
+	// Url description: The URL audit log entries are sent to.
+	Url string `json:"url"`
+}
+
 // AuthAccessRequest description: The config options for access requests
 type AuthAccessRequest struct {
 	// Enabled description: Enable/disable the access request feature, which allows users to request access if built-in signup is disabled.
@@ -231,6 +245,8 @@ type AzureDevOpsConnection struct {
 	Orgs []string `json:"orgs,omitempty"`
 	// Projects description: An array of projects "org/project" strings specifying which Azure DevOps projects' repositories should be mirrored on Sourcegraph.
 	Projects []string `json:"projects,omitempty"`
+	// RepositoryMetadata description: Sync metadata of repositories from Azure DevOps into repository key-value pairs, which can be filtered on with the `repo:has()` and `repo:has.key()` search predicates. Each property maps a kind of metadata to the key it is synced under, and metadata without a mapping isn't synced. Key-value pairs that are set manually take precedence over synced ones.
+	RepositoryMetadata *AzureDevOpsRepositoryMetadata `json:"repositoryMetadata,omitempty"`
 	// Token description: The Personal Access Token associated with the Azure DevOps username used for authentication.
 	Token string `json:"token"`
 	// Url description: URL for Azure DevOps Services, set to https://dev.azure.com.
@@ -238,6 +254,14 @@ type AzureDevOpsConnection struct {
 	// Username description: A username for authentication with the Azure DevOps code host.
 	Username string `json:"username"`
 }
+
+// AzureDevOpsRepositoryMetadata description: Sync metadata of repositories from Azure DevOps into repository key-value pairs, which can be filtered on with the `repo:has()` and `repo:has.key()` search predicates. Each property maps a kind of metadata to the key it is synced under, and metadata without a mapping isn't synced. Key-value pairs that are set manually take precedence over synced ones.
+type AzureDevOpsRepositoryMetadata struct {
+	// Project description: Key under which the name of the project of repositories is synced.
+	Project string `json:"project,omitempty"`
+	// Visibility description: Key under which the visibility of repositories (`public` or `private`) is synced.
+	Visibility string `json:"visibility,omitempty"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -569,6 +593,24 @@ type Completions struct {
 	Provider string `json:"provider"`
 }
 
+// ComposerPackagesConnection description: Configuration for a connection to a Composer package repository, such as Packagist.
+type ComposerPackagesConnection struct {
+	// Dependencies description: An array of strings specifying Composer packages to mirror in Sourcegraph, in the form `vendor/package:version`.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the Composer repository and to the hosts serving package archives.
+	RateLimit *ComposerRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the Composer repository, which must serve package metadata at `<repository>/p2/<vendor>/<package>.json`. Credentials for HTTP basic authentication can be included in the URL.
+	Repository string `json:"repository,omitempty"`
+}
+
+// ComposerRateLimit description: Rate limit applied when making background API requests to the Composer repository and to the hosts serving package archives.
+type ComposerRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // CustomGitFetchMapping description: Mapping from Git clone URl domain/path to git fetch command. The `domainPath` field contains the Git clone URL domain/path part. The `fetch` field contains the custom git fetch command.
 type CustomGitFetchMapping struct {
 	// DomainPath description: Git clone URL domain/path
@@ -730,6 +772,18 @@ type ExcludedGitLabProject struct {
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Id description: The ID of a Gitea repository (as returned by the Gitea API) to exclude from mirroring.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a Gitea repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository ("owner/name").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -763,6 +817,8 @@ type ExpandedGitCommitDescription struct {
 type ExperimentalFeatures struct {
 	// BitbucketServerFastPerm description: DEPRECATED: Configure in Bitbucket Server config.
 	BitbucketServerFastPerm string `json:"bitbucketServerFastPerm,omitempty"`
+	// ComposerPackages description: Allow adding Composer package host connections
+	ComposerPackages string `json:"composerPackages,omitempty"`
 	// CustomGitFetch description: JSON array of configuration that maps from Git clone URL domain/path to custom git fetch command. To enable this feature set environment variable `ENABLE_CUSTOM_GIT_FETCH` as `true` on gitserver.
 	CustomGitFetch []*CustomGitFetchMapping `json:"customGitFetch,omitempty"`
 	// DebugLog description: Turns on debug logging for specific debugging scenarios.
@@ -781,6 +837,8 @@ type ExperimentalFeatures struct {
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
+	// HexPackages description: Allow adding Hex package host connections
+	HexPackages string `json:"hexPackages,omitempty"`
 	// InsightsAlternateLoadingStrategy description: Use an in-memory strategy of loading Code Insights. Should only be used for benchmarking on large instances, not for customer use currently.
 	InsightsAlternateLoadingStrategy bool `json:"insightsAlternateLoadingStrategy,omitempty"`
 	// InsightsBackfillerV2 description: DEPRECATED: Setting any value to this flag has no effect.
@@ -791,6 +849,8 @@ type ExperimentalFeatures struct {
 	JvmPackages string `json:"jvmPackages,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
+	// NugetPackages description: Allow adding NuGet package host connections
+	NugetPackages string `json:"nugetPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
 	Pagure string `json:"pagure,omitempty"`
 	// PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
@@ -854,6 +914,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	delete(m, "bitbucketServerFastPerm")
+	delete(m, "composerPackages")
 	delete(m, "customGitFetch")
 	delete(m, "debug.log")
 	delete(m, "enableGRPC")
@@ -863,11 +924,13 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "eventLogging")
 	delete(m, "gitServerPinnedRepos")
 	delete(m, "goPackages")
+	delete(m, "hexPackages")
 	delete(m, "insightsAlternateLoadingStrategy")
 	delete(m, "insightsBackfillerV2")
 	delete(m, "insightsDataRetention")
 	delete(m, "jvmPackages")
 	delete(m, "npmPackages")
+	delete(m, "nugetPackages")
 	delete(m, "pagure")
 	delete(m, "passwordPolicy")
 	delete(m, "perforce")
@@ -1043,6 +1106,8 @@ type GitHubConnection struct {
 	//
 	// Note: ID is the GitHub GraphQL ID, not the GitHub database ID. eg: "curl https://api.github.com/repos/vuejs/vue | jq .node_id"
 	Exclude []*ExcludedGitHubRepo `json:"exclude,omitempty"`
+	// GitLFS description: Fetch the Git LFS objects of the repositories, so that files tracked by Git LFS show their content instead of LFS pointer files and can be searched. Only objects referenced at the default branch are fetched, and only over HTTP(S).
+	GitLFS *GitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitHub instance.
 	//
 	// If "http", Sourcegraph will access GitHub repositories using Git URLs of the form http(s)://github.com/myteam/myproject.git (using https: if the GitHub instance uses HTTPS).
@@ -1061,6 +1126,8 @@ type GitHubConnection struct {
 	RateLimit *GitHubRateLimit `json:"rateLimit,omitempty"`
 	// Repos description: An array of repository "owner/name" strings specifying which GitHub or GitHub Enterprise repositories to mirror on Sourcegraph.
 	Repos []string `json:"repos,omitempty"`
+	// RepositoryMetadata description: Sync metadata of repositories from GitHub into repository key-value pairs, which can be filtered on with the `repo:has()` and `repo:has.key()` search predicates. Each property maps a kind of metadata to the key it is synced under, and metadata without a mapping isn't synced. Key-value pairs that are set manually take precedence over synced ones.
+	RepositoryMetadata *GitHubRepositoryMetadata `json:"repositoryMetadata,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a GitHub or GitHub Enterprise repository. In the pattern, the variable "{host}" is replaced with the GitHub host (such as github.example.com), and "{nameWithOwner}" is replaced with the GitHub repository's "owner/path" (such as "myorg/myrepo").
 	//
 	// For example, if your GitHub Enterprise URL is https://github.example.com and your Sourcegraph URL is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a GitHub repository at https://github.example.com/myorg/myrepo is available on Sourcegraph at https://src.example.com/github.example.com/myorg/myrepo.
@@ -1099,6 +1166,18 @@ type GitHubRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// GitHubRepositoryMetadata description: Sync metadata of repositories from GitHub into repository key-value pairs, which can be filtered on with the `repo:has()` and `repo:has.key()` search predicates. Each property maps a kind of metadata to the key it is synced under, and metadata without a mapping isn't synced. Key-value pairs that are set manually take precedence over synced ones.
+type GitHubRepositoryMetadata struct {
+	// CustomProperties description: Maps the names of repository custom properties to the keys they are synced under. Syncing custom properties requires an additional API request per repository.
+	CustomProperties map[string]string `json:"customProperties,omitempty"`
+	// Language description: Key under which the primary language of repositories is synced.
+	Language string `json:"language,omitempty"`
+	// Topics description: Key prefix of repository topics. Each topic is synced as a key without a value, made of the prefix followed by the topic name.
+	Topics string `json:"topics,omitempty"`
+	// Visibility description: Key under which the visibility of repositories (`public`, `private` or `internal`) is synced.
+	Visibility string `json:"visibility,omitempty"`
+}
 type GitHubWebhook struct {
 	// Org description: The name of the GitHub organization to which the webhook belongs
 	Org string `json:"org"`
@@ -1106,6 +1185,16 @@ type GitHubWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitLFS description: Fetch the Git LFS objects of the repositories, so that files tracked by Git LFS show their content instead of LFS pointer files and can be searched. Only objects referenced at the default branch are fetched, and only over HTTP(S).
+type GitLFS struct {
+	// Enabled description: Whether to fetch Git LFS objects.
+	Enabled bool `json:"enabled"`
+	// MaxFileSize description: LFS objects larger than this size in bytes are not fetched. Files tracked by such objects keep showing the LFS pointer file.
+	MaxFileSize int `json:"maxFileSize,omitempty"`
+	// MaxRepoSize description: The maximum total size in bytes of the LFS objects fetched per repository. Once reached, no further objects are fetched.
+	MaxRepoSize int `json:"maxRepoSize,omitempty"`
+}
+
 // GitLabAuthProvider description: Configures the GitLab OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitLab instance: https://docs.gitlab.com/ee/integration/oauth_provider.html. The application should have `api` and `read_user` scopes and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/gitlab/callback".
 type GitLabAuthProvider struct {
 	// AllowGroups description: Restricts new logins and signups (if allowSignup is true) to members of these GitLab groups. Existing sessions won't be invalidated. Make sure to inform the full path for groups or subgroups instead of their names. Leave empty or unset for no group restrictions.
@@ -1144,6 +1233,8 @@ type GitLabConnection struct {
 	CloudGlobal bool `json:"cloudGlobal,omitempty"`
 	// Exclude description: A list of projects to never mirror from this GitLab instance. Takes precedence over "projects" and "projectQuery" configuration. Supports excluding by name ({"name": "group/name"}) or by ID ({"id": 42}).
 	Exclude []*ExcludedGitLabProject `json:"exclude,omitempty"`
+	// GitLFS description: Fetch the Git LFS objects of the repositories, so that files tracked by Git LFS show their content instead of LFS pointer files and can be searched. Only objects referenced at the default branch are fetched, and only over HTTP(S).
+	GitLFS *GitLabGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitLab instance.
 	//
 	// If "http", Sourcegraph will access GitLab repositories using Git URLs of the form http(s)://gitlab.example.com/myteam/myproject.git (using https: if the GitLab instance uses HTTPS).
@@ -1162,6 +1253,8 @@ type GitLabConnection struct {
 	Projects []*GitLabProject `json:"projects,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to GitLab.
 	RateLimit *GitLabRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryMetadata description: Sync metadata of repositories from GitLab into repository key-value pairs, which can be filtered on with the `repo:has()` and `repo:has.key()` search predicates. Each property maps a kind of metadata to the key it is synced under, and metadata without a mapping isn't synced. Key-value pairs that are set manually take precedence over synced ones.
+	RepositoryMetadata *GitLabRepositoryMetadata `json:"repositoryMetadata,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate a the corresponding Sourcegraph repository name for a GitLab project. In the pattern, the variable "{host}" is replaced with the GitLab URL's host (such as gitlab.example.com), and "{pathWithNamespace}" is replaced with the GitLab project's "namespace/path" (such as "myteam/myproject").
 	//
 	// For example, if your GitLab is https://gitlab.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{pathWithNamespace}" would mean that a GitLab project at https://gitlab.example.com/myteam/myproject is available on Sourcegraph at https://src.example.com/gitlab.example.com/myteam/myproject.
@@ -1181,6 +1274,16 @@ type GitLabConnection struct {
 	// Webhooks description: An array of webhook configurations
 	Webhooks []*GitLabWebhook `json:"webhooks,omitempty"`
 }
+
+// GitLabGitLFS description: Fetch the Git LFS objects of the repositories, so that files tracked by Git LFS show their content instead of LFS pointer files and can be searched. Only objects referenced at the default branch are fetched, and only over HTTP(S).
+type GitLabGitLFS struct {
+	// Enabled description: Whether to fetch Git LFS objects.
+	Enabled bool `json:"enabled"`
+	// MaxFileSize description: LFS objects larger than this size in bytes are not fetched. Files tracked by such objects keep showing the LFS pointer file.
+	MaxFileSize int `json:"maxFileSize,omitempty"`
+	// MaxRepoSize description: The maximum total size in bytes of the LFS objects fetched per repository. Once reached, no further objects are fetched.
+	MaxRepoSize int `json:"maxRepoSize,omitempty"`
+}
 type GitLabNameTransformation struct {
 	// Regex description: The regex to match for the occurrences of its replacement.
 	Regex string `json:"regex,omitempty"`
@@ -1201,6 +1304,14 @@ type GitLabRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// GitLabRepositoryMetadata description: Sync metadata of repositories from GitLab into repository key-value pairs, which can be filtered on with the `repo:has()` and `repo:has.key()` search predicates. Each property maps a kind of metadata to the key it is synced under, and metadata without a mapping isn't synced. Key-value pairs that are set manually take precedence over synced ones.
+type GitLabRepositoryMetadata struct {
+	// Topics description: Key prefix of repository topics. Each topic is synced as a key without a value, made of the prefix followed by the topic name.
+	Topics string `json:"topics,omitempty"`
+	// Visibility description: Key under which the visibility of repositories (`public`, `private` or `internal`) is synced.
+	Visibility string `json:"visibility,omitempty"`
+}
 type GitLabWebhook struct {
 	// Secret description: The secret used to authenticate incoming webhook requests
 	Secret string `json:"secret"`
@@ -1214,6 +1325,44 @@ type GitRecorder struct {
 	Size int `json:"size,omitempty"`
 }
 
+// GiteaAuthorization description: If non-null, enforces Gitea repository permissions. Sourcegraph users are matched to Gitea users by verified email address, and access to each private repository is granted to its owner, collaborators and the members of teams with access to it.
+type GiteaAuthorization struct {
+}
+
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Authorization description: If non-null, enforces Gitea repository permissions. Sourcegraph users are matched to Gitea users by verified email address, and access to each private repository is granted to its owner, collaborators and the members of teams with access to it.
+	Authorization *GiteaAuthorization `json:"authorization,omitempty"`
+	// Exclude description: A list of repositories to never mirror from this Gitea instance. Takes precedence over "orgs", "users" and "repositoryQuery" configuration.
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea instance.
+	//
+	// If "http", Sourcegraph will access Gitea repositories using Git URLs of the form http(s)://gitea.example.com/myorg/myrepo.git, authenticated with the configured token.
+	//
+	// If "ssh", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myorg/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Gitea organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryQuery description: An array of strings specifying which Gitea repositories to mirror on Sourcegraph. Each string is a keyword passed to the Gitea repository search API. The special value "all" mirrors every repository visible to the token.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: A Gitea access token with read access to the repositories, organizations and users that should be mirrored. If `authorization` is set, the token must belong to a site admin so that users and collaborators can be listed.
+	Token string `json:"token"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com.
+	Url string `json:"url"`
+	// Users description: An array of user names identifying Gitea users whose own repositories should be mirrored on Sourcegraph.
+	Users []string `json:"users,omitempty"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making background API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1276,6 +1425,24 @@ type Header struct {
 	Value     string `json:"value"`
 }
 
+// HexPackagesConnection description: Configuration for a connection to a Hex package repository, such as hex.pm.
+type HexPackagesConnection struct {
+	// Dependencies description: An array of strings specifying Hex packages to mirror in Sourcegraph, in the form `package@version`.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the Hex repository.
+	RateLimit *HexRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the Hex repository, which must serve package tarballs at `<repository>/tarballs/<package>-<version>.tar`. Credentials for HTTP basic authentication can be included in the URL.
+	Repository string `json:"repository,omitempty"`
+}
+
+// HexRateLimit description: Rate limit applied when making background API requests to the Hex repository.
+type HexRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the GitLab identity to use for a given Sourcegraph user.
 type IdentityProvider struct {
 	Oauth    *OAuthIdentity
@@ -1387,6 +1554,24 @@ type MavenRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// MercurialConnection description: Configuration for a connection to Mercurial repositories, which are mirrored into Git by gitserver.
+type MercurialConnection struct {
+	// Password description: The password used to authenticate to the Mercurial server over HTTP(S).
+	Password string `json:"password,omitempty"`
+	// Repos description: The paths of the Mercurial repositories to mirror, relative to `url`. Each path is mirrored as a separate repository on Sourcegraph.
+	Repos []string `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the host and path of `url`, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if `url` is https://hg.example.com/repos and `repos` contains the value "billing", then a repositoryPathPattern of "{base}/{repo}" would mean that the repository is available on Sourcegraph at https://sourcegraph.example.com/hg.example.com/repos/billing.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL of the Mercurial server. The paths in `repos` are resolved relative to it.
+	Url string `json:"url"`
+	// Username description: The username used to authenticate to the Mercurial server over HTTP(S).
+	Username string `json:"username,omitempty"`
+}
 type Mount struct {
 	// Mountpoint description: The path in the container to mount the path on the local machine to.
 	Mountpoint string `json:"mountpoint"`
@@ -1542,6 +1727,26 @@ type NpmRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// NuGetPackagesConnection description: Configuration for a connection to a NuGet v3 package feed.
+type NuGetPackagesConnection struct {
+	// Credentials description: Credentials for private feeds in the form `username:password`, sent with HTTP basic authentication. For Azure Artifacts and GitHub Packages, use a personal access token as the password.
+	Credentials string `json:"credentials,omitempty"`
+	// Dependencies description: An array of strings specifying NuGet packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the NuGet feed.
+	RateLimit *NuGetRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the service index of the NuGet v3 feed.
+	Repository string `json:"repository,omitempty"`
+}
+
+// NuGetRateLimit description: Rate limit applied when making background API requests to the NuGet feed.
+type NuGetRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type OAuthIdentity struct {
 	Type string `json:"type"`
 }
@@ -1636,7 +1841,11 @@ type OrganizationInvitations struct {
 type OtherExternalServiceConnection struct {
 	// Exclude description: A list of repositories to never mirror by name after applying repositoryPathPattern. Supports excluding by exact name ({"name": "myrepo"}) or regular expression ({"pattern": ".*secret.*"}).
 	Exclude []*ExcludedOtherRepo `json:"exclude,omitempty"`
-	Repos   []string             `json:"repos"`
+	// GitLFS description: Fetch the Git LFS objects of the repositories, so that files tracked by Git LFS show their content instead of LFS pointer files and can be searched. Only objects referenced at the default branch are fetched, and only over HTTP(S).
+	GitLFS *OtherGitLFS `json:"gitLFS,omitempty"`
+	// Manifest description: A manifest listing repositories to be discovered in addition to `repos`. The manifest is fetched again on every sync, so that the repositories it lists are kept in sync without editing this configuration. The repositories must be served by the Git clone base URL.
+	Manifest *OtherManifest `json:"manifest,omitempty"`
+	Repos    []string       `json:"repos,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -1649,6 +1858,24 @@ type OtherExternalServiceConnection struct {
 	Root string `json:"root,omitempty"`
 	Url  string `json:"url,omitempty"`
 }
+
+// OtherGitLFS description: Fetch the Git LFS objects of the repositories, so that files tracked by Git LFS show their content instead of LFS pointer files and can be searched. Only objects referenced at the default branch are fetched, and only over HTTP(S).
+type OtherGitLFS struct {
+	// Enabled description: Whether to fetch Git LFS objects.
+	Enabled bool `json:"enabled"`
+	// MaxFileSize description: LFS objects larger than this size in bytes are not fetched. Files tracked by such objects keep showing the LFS pointer file.
+	MaxFileSize int `json:"maxFileSize,omitempty"`
+	// MaxRepoSize description: The maximum total size in bytes of the LFS objects fetched per repository. Once reached, no further objects are fetched.
+	MaxRepoSize int `json:"maxRepoSize,omitempty"`
+}
+
+// OtherManifest description: A manifest listing repositories to be discovered in addition to `repos`. The manifest is fetched again on every sync, so that the repositories it lists are kept in sync without editing this configuration. The repositories must be served by the Git clone base URL.
+type OtherManifest struct {
+	// Format description: The format of the manifest file. "repo" is a manifest of the Google repo tool (such as default.xml), and "list" is a plain text file with a repository per line, which is interpreted like the entries of `repos`. Empty lines and lines starting with # are ignored. Defaults to "repo" if the path of the URL ends with .xml, and "list" otherwise.
+	Format string `json:"format,omitempty"`
+	// Url description: The HTTP(S) URL of the manifest file, such as the URL of the raw file in the repository that hosts it. Credentials can be included in the URL.
+	Url string `json:"url"`
+}
 type OutputVariable struct {
 	// Format description: The expected format of the output. If set, the output is being parsed in that format before being stored in the var. If not set, 'text' is assumed to the format.
 	Format string `json:"format,omitempty"`
@@ -2452,7 +2679,7 @@ type SiteConfiguration struct {
 	GitLongCommandTimeout int `json:"gitLongCommandTimeout,omitempty"`
 	// GitMaxCodehostRequestsPerSecond description: Maximum number of remote code host git operations (e.g. clone or ls-remote) to be run per second per gitserver. Default is -1, which is unlimited.
 	GitMaxCodehostRequestsPerSecond *int `json:"gitMaxCodehostRequestsPerSecond,omitempty"`
-	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler respects gitMaxConcurrentClones. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
+	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler runs at most gitMaxConcurrentClones updates at a time across all repo-updater replicas. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
 	// GitRecorder description: Record git operations that are executed on configured repositories. The following commands are not recorded: show, log, rev-parse and diff.
 	GitRecorder *GitRecorder `json:"gitRecorder,omitempty"`
@@ -2762,6 +2989,30 @@ type SubRepoPermissions struct {
 	UserCacheTTLSeconds int `json:"userCacheTTLSeconds,omitempty"`
 }
 
+// SubversionConnection description: Configuration for a connection to Subversion repositories, which are mirrored into Git by gitserver.
+type SubversionConnection struct {
+	// Layout description: The layout of the Subversion repositories.
+	//
+	// If "standard", each repository is expected to contain "trunk", "branches" and "tags" directories: trunk is mirrored as the "master" branch, each directory in "branches" as a Git branch and each directory in "tags" as a Git tag.
+	//
+	// If "none", the whole repository is mirrored as the "master" branch.
+	Layout string `json:"layout,omitempty"`
+	// Password description: The password used to authenticate to the Subversion server.
+	Password string `json:"password,omitempty"`
+	// Repos description: The paths of the Subversion repositories to mirror, relative to `url`. Each path is mirrored as a separate repository on Sourcegraph.
+	Repos []string `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the host and path of `url`, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if `url` is https://svn.example.com/svn and `repos` contains the value "billing", then a repositoryPathPattern of "{base}/{repo}" would mean that the repository is available on Sourcegraph at https://sourcegraph.example.com/svn.example.com/svn/billing.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL of the Subversion server. The paths in `repos` are resolved relative to it.
+	Url string `json:"url"`
+	// Username description: The username used to authenticate to the Subversion server.
+	Username string `json:"username,omitempty"`
+}
+
 // SyntaxHighlighting description: Syntax highlighting configuration
 type SyntaxHighlighting struct {
 	Engine    SyntaxHighlightingEngine   `json:"engine"`
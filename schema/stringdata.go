@@ -37,6 +37,11 @@ var ChangesetSpecSchemaJSON string
 //go:embed gerrit.schema.json
 var GerritSchemaJSON string
 
+// GiteaSchemaJSON is the content of the file "gitea.schema.json".
+//
+//go:embed gitea.schema.json
+var GiteaSchemaJSON string
+
 // GitHubSchemaJSON is the content of the file "github.schema.json".
 //
 //go:embed github.schema.json
@@ -52,15 +57,24 @@ var GitLabSchemaJSON string
 //go:embed gitolite.schema.json
 var GitoliteSchemaJSON string
 
+//go:embed composer-packages.schema.json
+var ComposerPackagesSchemaJSON string
+
 //go:embed go-modules.schema.json
 var GoModulesSchemaJSON string
 
+//go:embed hex-packages.schema.json
+var HexPackagesSchemaJSON string
+
 //go:embed jvm-packages.schema.json
 var JVMPackagesSchemaJSON string
 
 //go:embed npm-packages.schema.json
 var NpmPackagesSchemaJSON string
 
+//go:embed nuget-packages.schema.json
+var NuGetPackagesSchemaJSON string
+
 //go:embed python-packages.schema.json
 var PythonPackagesSchemaJSON string
 
@@ -75,6 +89,11 @@ var RubyPackagesSchemaJSON string
 //go:embed other_external_service.schema.json
 var OtherExternalServiceSchemaJSON string
 
+// MercurialSchemaJSON is the content of the file "mercurial.schema.json".
+//
+//go:embed mercurial.schema.json
+var MercurialSchemaJSON string
+
 // PerforceSchemaJSON is the content of the file "perforce.schema.json".
 //
 //go:embed perforce.schema.json
@@ -90,6 +109,11 @@ var PhabricatorSchemaJSON string
 //go:embed pagure.schema.json
 var PagureSchemaJSON string
 
+// SubversionSchemaJSON is the content of the file "subversion.schema.json".
+//
+//go:embed subversion.schema.json
+var SubversionSchemaJSON string
+
 // SettingsSchemaJSON is the content of the file "settings.schema.json".
 //
 //go:embed settings.schema.json